@@ -9,7 +9,16 @@ __version__ = "1.1.85"
 __author__ = "ch1kim0n1"
 __email__ = "vxk230059@utdallas.edu"
 
+from .api import analyze, generate
 from .core import CodebaseAnalyzer
 from .generator import ReadmeGenerator
+from .models import AnalysisOptions, AnalysisResult
 
-__all__ = ["CodebaseAnalyzer", "ReadmeGenerator"]
+__all__ = [
+    "AnalysisOptions",
+    "AnalysisResult",
+    "CodebaseAnalyzer",
+    "ReadmeGenerator",
+    "analyze",
+    "generate",
+]
@@ -0,0 +1,99 @@
+"""Load and run analyzer plugins that contribute extra README sections."""
+
+from __future__ import annotations
+
+import importlib
+from collections import Counter
+from collections.abc import Iterable, Mapping, Sequence
+from importlib import metadata
+from pathlib import Path
+from typing import Any, cast
+
+from .logging import get_logger
+from .models import AnalyzerPlugin, PluginFile, PluginSection
+
+logger = get_logger(__name__)
+
+ANALYZER_ENTRY_POINT_GROUP = "docgenie.analyzers"
+DEFAULT_PLUGIN_FUNCTION = "analyze"
+
+
+def load_analyzer_plugins(
+    modules: Sequence[str], *, entry_points: bool = True
+) -> tuple[list[tuple[str, AnalyzerPlugin]], int]:
+    """Return `(name, plugin)` pairs and the number of plugins that failed to load.
+
+    `modules` entries are `module.path` or `module.path:function`; entry points from the
+    `docgenie.analyzers` group are appended when `entry_points` is True.
+    """
+    plugins: list[tuple[str, AnalyzerPlugin]] = []
+    failed = 0
+    for spec in modules:
+        plugin = _load_module_plugin(spec)
+        if plugin is None:
+            failed += 1
+        else:
+            plugins.append((spec, plugin))
+    if entry_points:
+        for ep in _analyzer_entry_points():
+            try:
+                loaded = cast(Any, ep).load()
+            except Exception as exc:  # a plugin import may raise anything
+                logger.warning("analyzer plugin failed to load", plugin=ep.name, error=str(exc))
+                failed += 1
+                continue
+            if callable(loaded):
+                plugins.append((str(ep.name), cast(AnalyzerPlugin, loaded)))
+            else:
+                failed += 1
+    return plugins, failed
+
+
+def run_analyzer_plugins(
+    plugins: Iterable[tuple[str, AnalyzerPlugin]], root_path: Path, files: Sequence[PluginFile]
+) -> tuple[list[PluginSection], Counter[str]]:
+    """Call each plugin and collect its sections; a failing plugin contributes none.
+
+    Errors are returned as a skip-reason counter (`plugin_error`) so one broken plugin
+    never aborts the run.
+    """
+    sections: list[PluginSection] = []
+    errors: Counter[str] = Counter()
+    for name, plugin in plugins:
+        try:
+            returned = list(plugin(root_path, files) or [])
+            sections.extend(_coerce_section(item, name) for item in returned)
+        except Exception as exc:  # one broken plugin must not abort the run
+            logger.warning("analyzer plugin failed", plugin=name, error=str(exc))
+            errors["plugin_error"] += 1
+    return sections, errors
+
+
+def _load_module_plugin(spec: str) -> AnalyzerPlugin | None:
+    module_name, _, attr = spec.partition(":")
+    try:
+        module = importlib.import_module(module_name.strip())
+        plugin = getattr(module, attr.strip() or DEFAULT_PLUGIN_FUNCTION)
+    except Exception as exc:  # a plugin import may raise anything
+        logger.warning("analyzer plugin failed to load", plugin=spec, error=str(exc))
+        return None
+    return cast(AnalyzerPlugin, plugin) if callable(plugin) else None
+
+
+def _analyzer_entry_points() -> Iterable[Any]:
+    try:
+        eps_any = metadata.entry_points()
+        if hasattr(eps_any, "select"):
+            return cast(Any, eps_any).select(group=ANALYZER_ENTRY_POINT_GROUP)
+        return cast(Any, eps_any).get(ANALYZER_ENTRY_POINT_GROUP, [])
+    except Exception:  # pragma: no cover - best effort only
+        return []
+
+
+def _coerce_section(item: PluginSection | Mapping[str, str], plugin: str) -> PluginSection:
+    if isinstance(item, PluginSection):
+        return item if item.plugin else PluginSection(item.title, item.body, plugin)
+    title = str(item.get("title") or "").strip()
+    if not title:
+        raise ValueError("analyzer plugin section needs a title")
+    return PluginSection(title=title, body=str(item.get("body") or ""), plugin=plugin)
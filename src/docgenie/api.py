@@ -0,0 +1,148 @@
+"""Library entry points: analyze a codebase and render documentation without the CLI.
+
+    from docgenie.api import analyze, generate
+
+    result = analyze("path/to/repo")
+    readme = generate(result, "markdown").decode("utf-8")
+
+`analyze` loads and validates the configuration the way `docgenie analyze` does;
+`generate` returns what `docgenie generate --format <format>` would write, without
+writing it. Like the CLI, HTML output reads and updates the incremental build cache
+under `.docgenie/` unless `analysis.incremental` is off.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Mapping
+from pathlib import Path
+from typing import Any
+
+from .config import get_default_config, load_config, merge_configs
+from .config_schema import validate_config
+from .core import CodebaseAnalyzer
+from .docbook import DocBookGenerator
+from .epub import EpubGenerator
+from .exceptions import ConfigError
+from .generator import ReadmeGenerator
+from .html_generator import HTMLGenerator
+from .llms_txt import LlmsTxtGenerator
+from .man_page import ManPageGenerator
+from .models import AnalysisOptions, AnalysisResult
+from .readme_gate import evaluate_readme_readiness
+from .readme_quality import resolve_score_weights
+
+GENERATE_FORMATS = ("markdown", "html", "adoc", "confluence", "man", "llms", "docbook", "epub")
+
+
+def resolve_config(path: str | Path, options: AnalysisOptions | None = None) -> dict[str, Any]:
+    """Return the effective configuration for analyzing `path` with `options`.
+
+    Raises ConfigError listing every problem when the merged configuration is invalid.
+    """
+    options = options or AnalysisOptions()
+    config = load_config(Path(path)) if options.use_config_file else get_default_config()
+    errors = validate_config(config)
+    if errors:
+        raise ConfigError("Invalid configuration:\n" + "\n".join(f"  - {e}" for e in errors))
+    if options.config_overrides:
+        config = merge_configs(config, dict(options.config_overrides))
+    quality = config.get("quality", {})
+    try:
+        resolve_score_weights(quality.get("score_weights") if isinstance(quality, dict) else None)
+    except ConfigError as exc:
+        raise ConfigError(f"Invalid configuration: {exc}") from exc
+    return config
+
+
+def analyze(path: str | Path, options: AnalysisOptions | None = None) -> AnalysisResult:
+    """Analyze the codebase at `path`.
+
+    The incremental index under `.docgenie/` is read and updated as in the CLI; pass
+    `config_overrides={"analysis": {"incremental": False}}` to re-parse every file.
+    """
+    options = options or AnalysisOptions()
+    config = resolve_config(path, options)
+    ignore = config.get("ignore_patterns", [])
+    combined_ignore = list(
+        set([*options.ignore_patterns, *(ignore if isinstance(ignore, list) else [])])
+    )
+    analyzer = CodebaseAnalyzer(
+        str(path),
+        combined_ignore,
+        enable_tree_sitter=options.enable_tree_sitter,
+        config=config,
+        progress=options.progress,
+        json_stream=options.json_stream,
+    )
+    return analyzer.analyze_result()
+
+
+def readme_readiness(
+    analysis_data: dict[str, Any], generator: ReadmeGenerator | None = None
+) -> dict[str, Any]:
+    """Evaluate the README the analysis would produce against `quality.required_sections`.
+
+    The draft README is rendered with `generator` when given, so a caller rendering
+    several formats can share one template context.
+    """
+    config = analysis_data.get("config", {})
+    quality = config.get("quality", {}) if isinstance(config, dict) else {}
+    if not isinstance(quality, dict):
+        quality = {}
+    required = quality.get("required_sections", [])
+    return evaluate_readme_readiness(
+        (generator or ReadmeGenerator()).generate(analysis_data, None),
+        analysis_data=analysis_data,
+        required_sections=required if isinstance(required, list) else None,
+        min_confidence=str(quality.get("min_confidence", "medium")),
+    )
+
+
+def attach_readme_readiness(
+    analysis_data: dict[str, Any], generator: ReadmeGenerator | None = None
+) -> dict[str, Any]:
+    """Score readiness into `analysis_data["readme_readiness"]` and return it.
+
+    The report is part of every README format, so the README is scored again once the
+    first report is in it; every format then reports that second score.
+    """
+    generator = generator or ReadmeGenerator()
+    if not analysis_data.get("readme_readiness"):
+        analysis_data["readme_readiness"] = readme_readiness(analysis_data, generator)
+    analysis_data["readme_readiness"] = readme_readiness(analysis_data, generator)
+    return analysis_data["readme_readiness"]
+
+
+def generate(
+    result: AnalysisResult | Mapping[str, Any],
+    output_format: str = "markdown",
+    *,
+    generator: ReadmeGenerator | None = None,
+) -> bytes:
+    """Render `result` in `output_format` (one of GENERATE_FORMATS) as UTF-8 bytes.
+
+    `epub` returns the zip archive itself. README-based formats render with `generator`
+    when given, e.g. one built with a custom `template_dir`.
+
+    Raises ValueError for an unknown format.
+    """
+    if output_format not in GENERATE_FORMATS:
+        raise ValueError(
+            f"Unsupported format: {output_format} (choose {', '.join(GENERATE_FORMATS)})"
+        )
+    data = dict(result.to_public_dict() if isinstance(result, AnalysisResult) else result)
+    generator = generator or ReadmeGenerator()
+    attach_readme_readiness(data, generator)
+    if output_format in ("markdown", "adoc", "confluence"):
+        content = generator.generate(data, None, output_format=output_format)
+    elif output_format == "html":
+        content = HTMLGenerator().generate_from_analysis(data, None, readme_generator=generator)
+    elif output_format == "man":
+        content = ManPageGenerator().generate(data, None)
+    elif output_format == "llms":
+        content = LlmsTxtGenerator().generate(data, None)
+    elif output_format == "epub":
+        return EpubGenerator().generate(data, None, readme_generator=generator)
+    else:
+        content = DocBookGenerator().generate(data, None)
+    return content.encode("utf-8")
@@ -0,0 +1,228 @@
+"""Compare the API recorded in two analysis JSON files (`docgenie diff old.json new.json`)."""
+
+from __future__ import annotations
+
+import json
+import re
+from pathlib import Path
+from typing import Any
+
+from .exceptions import ConfigError
+from .module_index import relative_path
+from .schema import build_analysis_document
+
+CHANGE_ORDER = ("removed", "renamed", "changed", "added")
+
+
+def load_analysis(path: Path) -> dict[str, Any]:
+    """Read an `analyze --format json` output (plain or `--schema-version`) from `path`."""
+    try:
+        payload = json.loads(path.read_text(encoding="utf-8"))
+    except (OSError, UnicodeDecodeError, json.JSONDecodeError) as exc:
+        raise ConfigError(f"Cannot read analysis JSON {path}: {exc}") from exc
+    if not isinstance(payload, dict) or not ("modules" in payload or "functions" in payload):
+        raise ConfigError(f"{path} is not a DocGenie analysis (run `docgenie analyze -f json`)")
+    return payload
+
+
+def api_symbols(payload: dict[str, Any]) -> dict[tuple[str, str], dict[str, Any]]:
+    """Return symbols keyed by `(module, qualified_name)` from either JSON shape.
+
+    Plain analyzer output is converted through the versioned schema first, and
+    symbols without a parser-provided signature get one built from their
+    arguments so signature changes are still visible.
+    """
+    if "schema_version" in payload and "modules" in payload:
+        document = payload
+        fallbacks: dict[tuple[str, int, str], str] = {}
+    else:
+        document = build_analysis_document(payload).to_public_dict()
+        fallbacks = _argument_signatures(payload)
+    symbols: dict[tuple[str, str], dict[str, Any]] = {}
+    for module in document.get("modules", []):
+        language = str(module.get("language", ""))
+        for symbol in module.get("symbols", []):
+            qualified = str(symbol["qualified_name"])
+            name = str(symbol["name"])
+            line = int(symbol.get("line", 0) or 0)
+            signature = symbol.get("signature") or fallbacks.get(
+                (str(module["path"]), line, name), name
+            )
+            symbols.setdefault(
+                (str(module["path"]), qualified),
+                {
+                    "module": str(module["path"]),
+                    "name": name,
+                    "qualified_name": qualified,
+                    "kind": str(symbol.get("kind", "")),
+                    "parent": symbol.get("parent"),
+                    "signature": " ".join(str(signature).split()),
+                    "line": line,
+                    "exported": all(_exported(part, language) for part in qualified.split(".")),
+                },
+            )
+    return symbols
+
+
+def diff_api(old: dict[str, Any], new: dict[str, Any]) -> dict[str, Any]:
+    """Return per-module added/removed/renamed/changed symbols between two analyses.
+
+    A removed and an added symbol in the same module count as a rename when they
+    share kind, owner and signature apart from the name, and neither side has
+    another candidate with that shape. Removing or renaming an exported symbol
+    is marked `breaking`.
+    """
+    before = api_symbols(old)
+    after = api_symbols(new)
+    removed = [before[key] for key in sorted(before.keys() - after.keys())]
+    added = [after[key] for key in sorted(after.keys() - before.keys())]
+    changes: list[dict[str, Any]] = []
+
+    for old_symbol, new_symbol in _renames(removed, added):
+        removed.remove(old_symbol)
+        added.remove(new_symbol)
+        changes.append(
+            _change("renamed", new_symbol, old=old_symbol, breaking=old_symbol["exported"])
+        )
+    for symbol in removed:
+        changes.append(_change("removed", symbol, breaking=symbol["exported"]))
+    for symbol in added:
+        changes.append(_change("added", symbol))
+    for key in sorted(before.keys() & after.keys()):
+        if before[key]["signature"] != after[key]["signature"]:
+            changes.append(_change("changed", after[key], old=before[key]))
+
+    modules: dict[str, list[dict[str, Any]]] = {}
+    for change in sorted(
+        changes, key=lambda item: (CHANGE_ORDER.index(item["change"]), item["line"])
+    ):
+        modules.setdefault(change["module"], []).append(change)
+    totals = {kind: sum(c["change"] == kind for c in changes) for kind in CHANGE_ORDER}
+    return {
+        "old_project": _project_name(old),
+        "new_project": _project_name(new),
+        "totals": {**totals, "breaking": sum(bool(c["breaking"]) for c in changes)},
+        "modules": [{"path": path, "changes": modules[path]} for path in sorted(modules)],
+    }
+
+
+def render_api_diff(report: dict[str, Any], *, old_label: str, new_label: str) -> str:
+    """Render `diff_api` output as a Markdown changelog section."""
+    totals = report["totals"]
+    lines = [
+        "# API Changes",
+        "",
+        f"Comparing `{old_label}` with `{new_label}`.",
+        "",
+        (
+            f"- Added: {totals['added']}, Removed: {totals['removed']}, "
+            f"Renamed: {totals['renamed']}, Signature changed: {totals['changed']}"
+        ),
+        f"- Breaking: {totals['breaking']}",
+    ]
+    if not report["modules"]:
+        lines.extend(["", "No API changes."])
+    for module in report["modules"]:
+        lines.extend(["", f"## `{module['path']}`", ""])
+        lines.extend(_change_line(change) for change in module["changes"])
+    return "\n".join(lines) + "\n"
+
+
+def _change_line(change: dict[str, Any]) -> str:
+    prefix = "- **Breaking:** " if change["breaking"] else "- "
+    kind = change["kind"]
+    if change["change"] == "renamed":
+        return (
+            f"{prefix}Renamed {kind} `{change['old_name']}` to `{change['qualified_name']}` "
+            f"(`{change['signature']}`)"
+        )
+    if change["change"] == "changed":
+        return (
+            f"{prefix}Changed {kind} `{change['qualified_name']}`: "
+            f"`{change['old_signature']}` -> `{change['signature']}`"
+        )
+    return f"{prefix}{change['change'].capitalize()} {kind} `{change['signature']}`"
+
+
+def _change(
+    change: str,
+    symbol: dict[str, Any],
+    *,
+    old: dict[str, Any] | None = None,
+    breaking: bool = False,
+) -> dict[str, Any]:
+    entry: dict[str, Any] = {
+        "change": change,
+        "module": symbol["module"],
+        "kind": symbol["kind"],
+        "qualified_name": symbol["qualified_name"],
+        "signature": symbol["signature"],
+        "line": symbol["line"],
+        "breaking": breaking,
+    }
+    if old is not None:
+        entry["old_name"] = old["qualified_name"]
+        entry["old_signature"] = old["signature"]
+    return entry
+
+
+def _renames(
+    removed: list[dict[str, Any]], added: list[dict[str, Any]]
+) -> list[tuple[dict[str, Any], dict[str, Any]]]:
+    def groups(symbols: list[dict[str, Any]]) -> dict[tuple[Any, ...], list[dict[str, Any]]]:
+        grouped: dict[tuple[Any, ...], list[dict[str, Any]]] = {}
+        for symbol in symbols:
+            grouped.setdefault(_shape(symbol), []).append(symbol)
+        return grouped
+
+    old_groups = groups(removed)
+    new_groups = groups(added)
+    return [
+        (old_groups[shape][0], new_groups[shape][0])
+        for shape in old_groups
+        if len(old_groups[shape]) == 1 and len(new_groups.get(shape, [])) == 1
+    ]
+
+
+def _shape(symbol: dict[str, Any]) -> tuple[Any, ...]:
+    """Identify a symbol by everything except its own name."""
+    name = re.escape(symbol["name"])
+    signature = re.sub(rf"(?<![\w.]){name}(?!\w)", "<name>", symbol["signature"], count=1)
+    return (symbol["module"], symbol["kind"], symbol["parent"], signature)
+
+
+def _argument_signatures(payload: dict[str, Any]) -> dict[tuple[str, int, str], str]:
+    root = Path(str(payload.get("root_path", ".")))
+    items: list[dict[str, Any]] = []
+    for key in ("functions", "classes"):
+        for item in payload.get(key, []):
+            if isinstance(item, dict):
+                items.append(item)
+                items.extend(
+                    {**method, "file": item.get("file", "")}
+                    for method in item.get("methods", []) or []
+                    if isinstance(method, dict)
+                )
+    signatures: dict[tuple[str, int, str], str] = {}
+    for item in items:
+        if "args" not in item or not item.get("name"):
+            continue
+        args = ", ".join(str(arg) for arg in item.get("args") or [])
+        module = relative_path(root, str(item.get("file", "")))
+        signatures[(module, int(item.get("line", 0) or 0), str(item["name"]))] = (
+            f"{item['name']}({args})"
+        )
+    return signatures
+
+
+def _exported(name: str, language: str) -> bool:
+    if language == "go":
+        return name[:1].isupper()
+    return not name.startswith("_")
+
+
+def _project_name(payload: dict[str, Any]) -> str:
+    project = payload.get("project")
+    if isinstance(project, dict):
+        return str(project.get("name", ""))
+    return str(payload.get("project_name", ""))
@@ -0,0 +1,88 @@
+"""Turn symbol names mentioned in API docstrings into links to their API reference entry."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Callable
+from typing import Any
+
+from .html_sections import normalize_heading_ids
+
+# Text that must stay literal: code fences, inline code, existing links and URLs.
+_PROTECTED_RE = re.compile(
+    r"```.*?```|`[^`\n]*`|\[[^\]\n]*\]\([^)\n]*\)|<<[^>\n]*>>|<[^>\n]+>|https?://\S+",
+    re.DOTALL,
+)
+
+
+def autolink_docstrings(api_docs: dict[str, Any], *, output_format: str = "markdown") -> int:
+    """Link documented symbols mentioned in other docstrings; return the number of links.
+
+    Only symbols rendered in `api_docs` are link targets, so every link has an
+    anchor to land on, and a docstring never links to its own symbol. Names
+    documented more than once are ambiguous and left alone. All-lowercase names
+    such as `run` are only linked when written as a call (`run()`) so ordinary
+    prose words are not turned into links. Targets without an `anchor` are given
+    one by `normalize_heading_ids`, next to the anchors the others already carry.
+    """
+    docs = [*api_docs.get("functions", []), *api_docs.get("classes", [])]
+    targets: dict[str, dict[str, Any] | None] = {}
+    for doc in docs:
+        name = str(doc.get("name") or "")
+        if re.fullmatch(r"(?!\d)\w+", name):
+            targets[name] = None if name in targets else doc
+    linkable = {name: doc for name, doc in targets.items() if doc is not None}
+    if not linkable:
+        return 0
+    _assign_anchors(docs, [doc for doc in linkable.values() if not doc.get("anchor")])
+    names = sorted(linkable, key=len, reverse=True)
+    mention_re = re.compile(
+        r"(?<![\w.#/])(" + "|".join(map(re.escape, names)) + r")(?P<call>\(\))?(?![\w/])"
+    )
+
+    total = 0
+    for doc in docs:
+        docstring = doc.get("docstring")
+        if not docstring:
+            continue
+        own_name = str(doc.get("name") or "")
+
+        def link(match: re.Match[str], own_name: str = own_name) -> str:
+            nonlocal total
+            name = match.group(1)
+            if name == own_name or (name.islower() and not match.group("call")):
+                return match.group(0)
+            total += 1
+            return _format_link(match.group(0), linkable[name]["anchor"], output_format)
+
+        doc["docstring"] = _substitute_outside_code(str(docstring), mention_re, link)
+    return total
+
+
+def _assign_anchors(docs: list[dict[str, Any]], missing: list[dict[str, Any]]) -> None:
+    # The same IDs the HTML page gives `#### api Name` headings, skipping taken anchors.
+    taken = "".join(f'<a id="{doc["anchor"]}"></a>' for doc in docs if doc.get("anchor"))
+    headings = "".join(f'<h4 id="new">api {doc["name"]}</h4>' for doc in missing)
+    normalized, _ = normalize_heading_ids(taken + headings, "")
+    ids = re.findall(r'<h4 id="([^"]+)">', normalized)
+    for doc, anchor in zip(missing, ids, strict=True):
+        doc["anchor"] = anchor
+
+
+def _substitute_outside_code(
+    text: str, pattern: re.Pattern[str], repl: Callable[[re.Match[str]], str]
+) -> str:
+    parts: list[str] = []
+    last = 0
+    for protected in _PROTECTED_RE.finditer(text):
+        parts.append(pattern.sub(repl, text[last : protected.start()]))
+        parts.append(protected.group(0))
+        last = protected.end()
+    parts.append(pattern.sub(repl, text[last:]))
+    return "".join(parts)
+
+
+def _format_link(text: str, anchor: str, output_format: str) -> str:
+    if output_format == "adoc":
+        return f"<<{anchor},{text}>>"
+    return f"[{text}](#{anchor})"
@@ -3,33 +3,68 @@
 from __future__ import annotations
 
 import hashlib
+import io
 import json
+import sys
 import webbrowser
+import zipfile
 from pathlib import Path
-from typing import Any
+from typing import Any, TextIO
 
+import click
 import typer
 import yaml
 from rich.console import Console
 from rich.progress import Progress
 from rich.table import Table
 
+from .api import analyze as analyze_codebase
+from .api import attach_readme_readiness, readme_readiness
+from .api import generate as render_document
+from .api_diff import diff_api, load_analysis, render_api_diff
 from .config import load_config
-from .core import CodebaseAnalyzer
+from .config_schema import config_json_schema
 from .diff_engine import compute_git_diff_summary
+from .doc_links import parse_doc_bases
+from .docbook import DocBookGenerator
+from .drift import diff_stats, drift_diff
+from .epub import EpubGenerator
+from .exceptions import ConfigError, GeneratorError, RemoteRepoError
 from .generator import ReadmeGenerator
-from .html_generator import HTMLGenerator
+from .html_generator import HTMLGenerator, load_theme_css
+from .html_sections import FRAGMENT_CSS_FILENAME, GRAPH_SCOPES, SEARCH_INDEX_FILENAME
 from .index_store import IndexStore
+from .layers import parse_layers
+from .link_check import find_broken_links
+from .llms_txt import LlmsTxtGenerator
 from .logging import configure_logging, get_logger
+from .man_page import ManPageGenerator, program_name
+from .models import AnalysisOptions
+from .module_index import MODULE_GROUPINGS, SYMBOL_SORT_ORDERS, VISIBILITY_LEVELS
 from .pr_summary import render_pr_summary
-from .readme_gate import evaluate_readme_readiness
+from .progress import PROGRESS_MODES, ProgressReporter
+from .quality_gate import evaluate_quality_gate, language_coverage, parse_language_thresholds
+from .readme_quality import current_version, deprecations, resolve_score_weights
+from .remote import is_remote_url, project_root, remote_checkout
+from .reproducible import path_root, relative_paths
+from .schema import SUPPORTED_SCHEMA_VERSIONS, build_analysis_document
+from .templating import validate_template_dir
+from .trend import append_trend
+from .watcher import ChangeWatcher
+from .workspace import analyze_workspace, render_workspace_index
 
 app = typer.Typer(add_completion=False, help="DocGenie - Auto-documentation for any codebase.")
 index_app = typer.Typer(add_completion=False, help="Manage persistent DocGenie index store.")
 app.add_typer(index_app, name="index")
+config_app = typer.Typer(add_completion=False, help="Inspect DocGenie configuration.")
+app.add_typer(config_app, name="config")
 console = Console()
 
 OutputSpec = tuple[str, Path]
+OUTPUT_FORMATS = frozenset(
+    {"markdown", "html", "both", "adoc", "confluence", "man", "llms", "docbook", "epub", "json"}
+)
+FORMAT_ALIASES = {"md": "markdown"}
 
 
 def _print_summary(analysis_data: dict, target_formats: str) -> None:
@@ -54,21 +89,99 @@ def _print_summary(analysis_data: dict, target_formats: str) -> None:
 
 
 def _validate_format(fmt: str) -> str:
-    target_formats = fmt.lower()
-    if target_formats not in {"markdown", "html", "both"}:
-        typer.echo("Invalid format. Choose markdown, html, or both.")
+    """Normalize `fmt`, a format or comma-separated list of formats such as `md,html,json`."""
+    formats: list[str] = []
+    for item in fmt.lower().split(","):
+        name = FORMAT_ALIASES.get(item.strip(), item.strip())
+        if name not in OUTPUT_FORMATS:
+            typer.echo(
+                "Invalid format. Choose markdown (md), html, both, adoc, confluence, man, llms, "
+                "docbook, epub or json, or several separated by commas."
+            )
+            raise typer.Exit(code=1)
+        if name not in formats:
+            formats.append(name)
+    return ",".join(formats)
+
+
+def _format_list(target_formats: str) -> list[str]:
+    formats: list[str] = []
+    for name in target_formats.split(","):
+        for expanded in ("markdown", "html") if name == "both" else (name,):
+            if expanded not in formats:
+                formats.append(expanded)
+    return formats
+
+
+def _validate_graph_format(graph_format: str) -> str:
+    normalized = graph_format.lower()
+    if normalized not in {"none", "mermaid", "plantuml"}:
+        typer.echo("Invalid graph format. Choose none, mermaid or plantuml.")
         raise typer.Exit(code=1)
-    return target_formats
+    return normalized
 
 
-def _deep_merge(base: dict[str, Any], override: dict[str, Any]) -> dict[str, Any]:
-    merged = dict(base)
-    for key, value in override.items():
-        if isinstance(value, dict) and isinstance(merged.get(key), dict):
-            merged[key] = _deep_merge(merged[key], value)
-        else:
-            merged[key] = value
-    return merged
+def _validate_graph_scope(graph_scope: str) -> str:
+    normalized = graph_scope.lower()
+    if normalized not in GRAPH_SCOPES:
+        typer.echo(f"Invalid graph scope. Choose {' or '.join(GRAPH_SCOPES)}.")
+        raise typer.Exit(code=1)
+    return normalized
+
+
+def _validate_sort_symbols(sort_symbols: str) -> str:
+    normalized = sort_symbols.lower()
+    if normalized not in SYMBOL_SORT_ORDERS:
+        typer.echo(f"Invalid symbol order. Choose {', '.join(SYMBOL_SORT_ORDERS)}.")
+        raise typer.Exit(code=1)
+    return normalized
+
+
+def _validate_visibility(visibility: str) -> str:
+    normalized = visibility.lower()
+    if normalized not in VISIBILITY_LEVELS:
+        typer.echo(f"Invalid visibility. Choose {', '.join(VISIBILITY_LEVELS)}.")
+        raise typer.Exit(code=1)
+    return normalized
+
+
+def _validate_progress(progress: str, quiet: bool) -> str | None:
+    """Return the progress mode, or None when `--quiet` turns progress off."""
+    normalized = progress.lower()
+    if normalized not in PROGRESS_MODES:
+        typer.echo(f"Invalid progress mode. Choose {' or '.join(PROGRESS_MODES)}.")
+        raise typer.Exit(code=1)
+    return None if quiet else normalized
+
+
+def _validate_group_by(group_by: str) -> str:
+    normalized = group_by.lower()
+    if normalized not in MODULE_GROUPINGS:
+        typer.echo(f"Invalid grouping. Choose {', '.join(MODULE_GROUPINGS)}.")
+        raise typer.Exit(code=1)
+    return normalized
+
+
+def _validate_template_dir(template_dir: Path) -> Path:
+    resolved = template_dir.expanduser().resolve()
+    try:
+        validate_template_dir(resolved)
+    except ConfigError as exc:
+        typer.echo(f"Invalid template directory: {exc}")
+        raise typer.Exit(code=1) from exc
+    return resolved
+
+
+def _validate_schema_version(schema_version: int | None, fmt: str) -> None:
+    if schema_version is None:
+        return
+    if fmt != "json":
+        typer.echo("--schema-version requires --format json")
+        raise typer.Exit(code=1)
+    if schema_version not in SUPPORTED_SCHEMA_VERSIONS:
+        supported = ", ".join(str(version) for version in SUPPORTED_SCHEMA_VERSIONS)
+        typer.echo(f"Unsupported schema version: {schema_version}. Supported: {supported}")
+        raise typer.Exit(code=1)
 
 
 def _run_analysis(
@@ -77,28 +190,45 @@ def _run_analysis(
     tree_sitter: bool,
     verbose: bool,
     config_overrides: dict[str, Any] | None = None,
+    progress: str | None = "text",
+    json_stream: TextIO | None = None,
 ) -> dict:
-    config = load_config(path)
-    if config_overrides:
-        config = _deep_merge(config, config_overrides)
-    config_ignore = config.get("ignore_patterns", [])
-    combined_ignore = list(set(ignore + config_ignore))
-
-    analyzer = CodebaseAnalyzer(
-        str(path),
-        combined_ignore,
+    """Analyze `path`, reporting progress on stderr in `progress` mode (None: silent).
+
+    With `json_stream` the JSON document is written there while the analysis is
+    compiled; the returned data then has no functions or classes.
+    """
+    reporter = ProgressReporter(progress) if progress else None
+    options = AnalysisOptions(
+        ignore_patterns=tuple(ignore),
         enable_tree_sitter=tree_sitter,
-        config=config,
+        config_overrides=config_overrides or {},
+        progress=reporter,
+        json_stream=json_stream,
     )
-    with Progress(console=console, transient=True) as progress:
-        task = progress.add_task("Analyzing codebase...", total=100)
-        analysis_data = analyzer.analyze()
-        progress.update(task, completed=100)
+    try:
+        analysis_data = analyze_codebase(path, options).to_public_dict()
+    except ConfigError as exc:
+        typer.echo(str(exc))
+        raise typer.Exit(code=1) from exc
+    finally:
+        if reporter is not None:
+            reporter.finish()
     if verbose:
         console.log("Analysis complete")
     return analysis_data
 
 
+def _quality_weights(config: dict[str, Any]) -> dict[str, float]:
+    quality_cfg = config.get("quality", {})
+    overrides = quality_cfg.get("score_weights") if isinstance(quality_cfg, dict) else None
+    try:
+        return resolve_score_weights(overrides)
+    except ConfigError as exc:
+        typer.echo(f"Invalid configuration: {exc}")
+        raise typer.Exit(code=1) from exc
+
+
 def _content_hash(text: str) -> str:
     return hashlib.sha256(text.encode("utf-8")).hexdigest()
 
@@ -131,13 +261,30 @@ def _record_artifact(path: Path, target: str, content: str, root: Path) -> None:
         pass
 
 
-def _build_outputs(target_formats: str, output: Path | None, base: Path) -> list[OutputSpec]:
-    outputs: list[OutputSpec] = []
-    if target_formats in {"markdown", "both"}:
-        outputs.append(("markdown", _resolve_output(output, base, "README.md")))
-    if target_formats in {"html", "both"}:
-        outputs.append(("html", _resolve_output(output, base, "docs.html")))
-    return outputs
+def _build_outputs(
+    target_formats: str, output: Path | None, base: Path, man_name: str | None = None
+) -> list[OutputSpec]:
+    """Return one (format, path) pair per format in `target_formats`, in the order given.
+
+    Each format gets its conventional file name under `base`, or under `output` when that
+    is a directory; a file `output` is only valid for a single format.
+    """
+    default_names = {
+        "markdown": "README.md",
+        "html": "docs.html",
+        "adoc": "README.adoc",
+        "confluence": "README.confluence.xhtml",
+        # man1/<name>.1 so `-o share/man` produces an installable tree.
+        "man": f"man1/{man_name or base.name}.1",
+        "llms": "llms.txt",
+        "docbook": "README.docbook.xml",
+        "epub": "docs.epub",
+        "json": "analysis.json",
+    }
+    return [
+        (name, _resolve_output(output, base, default_names[name]))
+        for name in _format_list(target_formats)
+    ]
 
 
 def _confirm_overwrite(outputs: list[OutputSpec], *, preview: bool, force: bool) -> None:
@@ -149,67 +296,141 @@ def _confirm_overwrite(outputs: list[OutputSpec], *, preview: bool, force: bool)
             raise typer.Exit(code=1)
 
 
+def _render_markdown(
+    analysis_data: dict,
+    output_path: Path,
+    *,
+    preview: bool,
+    strict_readme: bool,
+    generator: ReadmeGenerator | None = None,
+) -> str:
+    generator = generator or ReadmeGenerator()
+    readiness = analysis_data["readme_readiness"]
+    try:
+        content = generator.generate(analysis_data, None if preview else str(output_path))
+    except GeneratorError as exc:
+        typer.echo(f"Cannot update README regions: {exc}")
+        raise typer.Exit(code=1) from exc
+    if preview:
+        console.rule("README Preview")
+        typer.echo(content)
+    else:
+        console.log(f"[green]README generated:[/green] {output_path}")
+
+    if readiness["status"] != "pass":
+        console.log("[yellow]README readiness warning[/yellow]")
+        for reason in readiness.get("reasons", []):
+            console.log(f"- {reason}")
+        if strict_readme and readiness["status"] == "fail":
+            raise typer.Exit(code=1)
+    return content
+
+
+def _report_broken_links(content: str, output_format: str, output_path: Path) -> int:
+    """Warn about in-page links to anchors the output never defines; return how many."""
+    broken = find_broken_links(content, output_format)
+    if broken:
+        console.log(f"[yellow]Broken internal links in {output_path.name}[/yellow]")
+        for link in broken:
+            console.log(f"- line {link['line']}: #{link['anchor']} ({link['text']})")
+    return len(broken)
+
+
 def _render_outputs(
     outputs: list[OutputSpec],
     analysis_data: dict,
     *,
     preview: bool,
     strict_readme: bool = False,
+    fail_on_broken_links: bool = False,
 ) -> None:
-    quality_cfg = analysis_data.get("config", {}).get("quality", {})
-    required_sections = (
-        quality_cfg.get("required_sections", []) if isinstance(quality_cfg, dict) else []
-    )
-    min_confidence = (
-        str(quality_cfg.get("min_confidence", "medium"))
-        if isinstance(quality_cfg, dict)
-        else "medium"
-    )
-
-    req_sections = required_sections if isinstance(required_sections, list) else None
-    if not analysis_data.get("readme_readiness"):
-        preview_readme = ReadmeGenerator().generate(analysis_data, None)
-        analysis_data["readme_readiness"] = evaluate_readme_readiness(
-            preview_readme,
-            analysis_data=analysis_data,
-            required_sections=req_sections,
-            min_confidence=min_confidence,
-        )
+    # Every format renders from this one analysis, so they share the template context.
+    generator = ReadmeGenerator(share_context=True)
+    attach_readme_readiness(analysis_data, generator)
 
+    broken_links = 0
     for output_format, output_path in outputs:
         if output_format == "markdown":
-            generator = ReadmeGenerator()
-            initial_content = generator.generate(analysis_data, None)
-            readiness = evaluate_readme_readiness(
-                initial_content,
-                analysis_data=analysis_data,
-                required_sections=req_sections,
-                min_confidence=min_confidence,
+            content = _render_markdown(
+                analysis_data,
+                output_path,
+                preview=preview,
+                strict_readme=strict_readme,
+                generator=generator,
+            )
+            broken_links += _report_broken_links(content, output_format, output_path)
+        elif output_format == "adoc":
+            content = generator.generate(
+                analysis_data, None if preview else str(output_path), output_format="adoc"
+            )
+            broken_links += _report_broken_links(content, output_format, output_path)
+            if preview:
+                console.rule("AsciiDoc Preview")
+                typer.echo(content)
+            else:
+                console.log(f"[green]AsciiDoc README generated:[/green] {output_path}")
+        elif output_format == "confluence":
+            content = generator.generate(
+                analysis_data, None if preview else str(output_path), output_format="confluence"
             )
-            analysis_data["readme_readiness"] = readiness
-            content = generator.generate(analysis_data, None if preview else str(output_path))
             if preview:
-                console.rule("README Preview")
+                console.rule("Confluence Preview")
+                typer.echo(content)
+            else:
+                console.log(f"[green]Confluence page generated:[/green] {output_path}")
+        elif output_format == "man":
+            content = ManPageGenerator().generate(analysis_data, None if preview else output_path)
+            if preview:
+                console.rule("Man Page Preview")
                 typer.echo(content)
             else:
-                console.log(f"[green]README generated:[/green] {output_path}")
-
-            if readiness["status"] != "pass":
-                console.log("[yellow]README readiness warning[/yellow]")
-                for reason in readiness.get("reasons", []):
-                    console.log(f"- {reason}")
-                if strict_readme and readiness["status"] == "fail":
-                    raise typer.Exit(code=1)
+                console.log(f"[green]Man page generated:[/green] {output_path}")
+        elif output_format == "llms":
+            content = LlmsTxtGenerator().generate(analysis_data, None if preview else output_path)
+            if preview:
+                console.rule("llms.txt Preview")
+                typer.echo(content)
+            else:
+                console.log(f"[green]llms.txt generated:[/green] {output_path}")
+        elif output_format == "docbook":
+            content = DocBookGenerator().generate(analysis_data, None if preview else output_path)
+            if preview:
+                console.rule("DocBook Preview")
+                typer.echo(content)
+            else:
+                console.log(f"[green]DocBook article generated:[/green] {output_path}")
+        elif output_format == "epub":
+            book = EpubGenerator().generate(
+                analysis_data, None if preview else output_path, readme_generator=generator
+            )
+            if preview:
+                console.rule("EPUB Preview (contents)")
+                with zipfile.ZipFile(io.BytesIO(book)) as archive:
+                    typer.echo("\n".join(archive.namelist()))
+            else:
+                console.log(f"[green]EPUB generated:[/green] {output_path}")
+        elif output_format == "json":
+            content = json.dumps(relative_paths(analysis_data, path_root(analysis_data)), indent=2)
+            if preview:
+                console.rule("Analysis JSON Preview")
+                typer.echo(content)
+            else:
+                output_path.write_text(content + "\n", encoding="utf-8")
+                console.log(f"[green]Analysis JSON written:[/green] {output_path}")
         else:
             html_generator = HTMLGenerator()
             content = html_generator.generate_from_analysis(
-                analysis_data, None if preview else str(output_path)
+                analysis_data, None if preview else str(output_path), readme_generator=generator
             )
             if preview:
                 console.rule("HTML Preview (truncated)")
                 typer.echo("\n".join(content.splitlines()[:80]))
             else:
                 console.log(f"[green]HTML generated:[/green] {output_path}")
+    # Like --strict-readme, the outputs are still written so the broken links can be inspected.
+    if fail_on_broken_links and broken_links:
+        typer.echo(f"{broken_links} broken internal link(s); failing (--fail-on-broken-links)")
+        raise typer.Exit(code=1)
 
 
 @app.command("generate")
@@ -220,16 +441,34 @@ def generate(  # noqa: PLR0913
     output: Path | None = typer.Option(
         None, "--output", "-o", help="Output path for documentation."
     ),
+    out_dir: Path | None = typer.Option(
+        None,
+        "--out-dir",
+        file_okay=False,
+        help="Directory to write every requested format to, under its conventional file name",
+        rich_help_panel="Output",
+    ),
     fmt: str = typer.Option(
         "both",
         "--format",
         "--fmt",
-        help="Output format",
+        "-f",
+        help="Output format: markdown (md), html, both, adoc, confluence, man, llms, docbook, "
+        "epub or json; several may be given comma-separated, e.g. md,html,json",
         case_sensitive=False,
         rich_help_panel="Output",
     ),
     ignore: list[str] = typer.Option([], "--ignore", "-i", help="Additional ignore patterns"),
-    force: bool = typer.Option(False, "--force", "-f", help="Overwrite existing files"),
+    include: list[str] = typer.Option(
+        [], "--include", help="Only scan files matching this glob (repeatable)"
+    ),
+    exclude: list[str] = typer.Option(
+        [], "--exclude", help="Skip files matching this glob; beats --include (repeatable)"
+    ),
+    no_gitignore: bool = typer.Option(
+        False, "--no-gitignore", help="Scan files ignored by .gitignore"
+    ),
+    force: bool = typer.Option(False, "--force", help="Overwrite existing files"),
     preview: bool = typer.Option(False, "--preview", "-p", help="Preview without saving"),
     verbose: bool = typer.Option(False, "--verbose", "-v", help="Verbose output"),
     tree_sitter: bool = typer.Option(
@@ -243,14 +482,222 @@ def generate(  # noqa: PLR0913
     include_file_review: bool = typer.Option(True, "--include-file-review/--no-file-review"),
     include_output_links: bool = typer.Option(True, "--include-output-links/--no-output-links"),
     strict_readme: bool = typer.Option(False, "--strict-readme", help="Fail when readiness is low"),
+    fail_on_broken_links: bool = typer.Option(
+        False,
+        "--fail-on-broken-links",
+        help="Exit non-zero when a README links to an anchor it does not define",
+    ),
     template_profile: str = typer.Option("pro", "--template-profile", help="legacy or pro"),
+    graph_format: str | None = typer.Option(
+        None,
+        "--graph-format",
+        help="Embed a diagram in Markdown/AsciiDoc/Confluence: none, mermaid or plantuml",
+        case_sensitive=False,
+        rich_help_panel="Output",
+    ),
+    graph_scope: str | None = typer.Option(
+        None,
+        "--graph",
+        help="Impact graph scope: all (default) or internal-only, which drops external calls",
+        case_sensitive=False,
+        rich_help_panel="Output",
+    ),
+    sort_symbols: str | None = typer.Option(
+        None,
+        "--sort-symbols",
+        help="Row order in each module table: source, alpha (default) or kind",
+        case_sensitive=False,
+        rich_help_panel="Output",
+    ),
+    group_by: str | None = typer.Option(
+        None,
+        "--group-by",
+        help="Module sections per file (default) or per Go/Python package",
+        case_sensitive=False,
+        rich_help_panel="Output",
+    ),
+    visibility: str | None = typer.Option(
+        None,
+        "--visibility",
+        help="Document the public API only (default) or all symbols, private ones included",
+        case_sensitive=False,
+        rich_help_panel="Output",
+    ),
     json_logs: bool = typer.Option(False, "--json-logs", help="Output structured logs as JSON"),
+    progress: str = typer.Option(
+        "text",
+        "--progress",
+        help="Progress on stderr: text (terminals only) or json (one object per line)",
+    ),
+    quiet: bool = typer.Option(False, "--quiet", "-q", help="Do not report progress"),
+    incremental: bool = typer.Option(
+        True,
+        "--incremental/--no-incremental",
+        " /--no-cache",
+        help="Reuse parses of unchanged files; --no-cache re-parses all and writes no index",
+    ),
+    cache_dir: Path | None = typer.Option(
+        None, "--cache-dir", help="Directory for the incremental index (default: .docgenie)"
+    ),
+    jobs: int | None = typer.Option(
+        None, "--jobs", "-j", min=1, help="Parallel parse workers (default: CPU count)"
+    ),
+    git_metadata: bool = typer.Option(
+        False, "--git-metadata", help="Add per-symbol last-commit date/author (runs git blame)"
+    ),
+    root: Path | None = typer.Option(
+        None,
+        "--root",
+        exists=True,
+        file_okay=False,
+        resolve_path=True,
+        help="Directory that file paths in the output are relative to (default: the analyzed "
+        "path)",
+    ),
+    ignore_unreferenced: list[str] = typer.Option(
+        [],
+        "--ignore-unreferenced",
+        help="Glob for intentional public API to omit from Unreferenced Symbols (repeatable)",
+    ),
+    template_dir: Path | None = typer.Option(
+        None,
+        "--template-dir",
+        help="Directory with readme.md.j2 / readme.adoc.j2 / readme.confluence.j2 / html.j2 "
+        "overriding the built-ins",
+        rich_help_panel="Output",
+    ),
+    theme_css: Path | None = typer.Option(
+        None,
+        "--theme-css",
+        exists=True,
+        dir_okay=False,
+        resolve_path=True,
+        help="Stylesheet inlined into HTML output after the built-in styles, e.g. to override "
+        "its --primary-color / --graph-* variables",
+        rich_help_panel="Output",
+    ),
+    fragment: bool = typer.Option(
+        False,
+        "--fragment",
+        help="Write HTML as an embeddable <div> without <html>/<head>, plus a separate "
+        "docgenie.css for the host page to link",
+        rich_help_panel="Output",
+    ),
+    autolink: bool = typer.Option(
+        False,
+        "--autolink",
+        help="Link symbol names mentioned in docstrings to their API reference entry",
+        rich_help_panel="Output",
+    ),
+    toc_depth: int | None = typer.Option(
+        None,
+        "--toc-depth",
+        min=2,
+        max=6,
+        help="Deepest heading level listed in the table of contents (default 2)",
+        rich_help_panel="Output",
+    ),
+    no_toc: bool = typer.Option(
+        False, "--no-toc", help="Omit the table of contents", rich_help_panel="Output"
+    ),
+    collapse_modules: bool = typer.Option(
+        False,
+        "--collapse-modules",
+        help="Wrap each module's symbol table in a collapsible <details> block",
+        rich_help_panel="Output",
+    ),
+    no_badges: bool = typer.Option(
+        False,
+        "--no-badges",
+        help="Omit the license, language, symbol count and quality badges",
+        rich_help_panel="Output",
+    ),
+    coverage_file: Path | None = typer.Option(
+        None,
+        "--coverage-file",
+        exists=True,
+        dir_okay=False,
+        resolve_path=True,
+        help="Go coverage.out or Cobertura coverage.xml to report per-module coverage from",
+    ),
+    coverage_threshold: float | None = typer.Option(
+        None,
+        "--coverage-threshold",
+        min=0,
+        max=100,
+        help="Warn about modules whose coverage percentage is below this value",
+    ),
+    complexity_threshold: int | None = typer.Option(
+        None,
+        "--complexity-threshold",
+        min=1,
+        help="Warn about functions whose estimated cyclomatic complexity is above this "
+        "(default 20)",
+    ),
+    tech_debt: bool = typer.Option(
+        False,
+        "--tech-debt",
+        help="List TODO/FIXME comments in a Technical Debt section",
+    ),
+    debt_markers: str | None = typer.Option(
+        None,
+        "--debt-markers",
+        help="Comma-separated comment markers to collect, e.g. TODO,FIXME,HACK (implies "
+        "--tech-debt)",
+    ),
+    flag_pattern: list[str] = typer.Option(
+        [],
+        "--flag-pattern",
+        help="Feature flag lookup call to detect, e.g. flags.Enabled or *.IsOn (repeatable)",
+    ),
+    doc_base: list[str] = typer.Option(
+        [],
+        "--doc-base",
+        help="Link external types from imports under PREFIX to URL, as PREFIX=URL; URL may use "
+        "{path} and {name} (repeatable)",
+        rich_help_panel="Output",
+    ),
+    max_tokens: int | None = typer.Option(
+        None,
+        "--max-tokens",
+        min=1,
+        help="Token budget for --format llms; the least referenced modules are dropped first",
+        rich_help_panel="Output",
+    ),
+    layers: str | None = typer.Option(
+        None,
+        "--layers",
+        help="Architectural layers as NAME=GLOB pairs, outermost first, e.g. "
+        '"handler=**/handler/**,service=**/service/**"; imports of an outer layer are flagged',
+    ),
+    release: str | None = typer.Option(
+        None,
+        "--current-version",
+        help="Current release, e.g. v2.1; deprecated symbols planned for removal in it or "
+        "earlier are reported as errors",
+    ),
+    plugin: list[str] = typer.Option(
+        [],
+        "--plugin",
+        help="Analyzer plugin to run, as module.path or module.path:function (repeatable)",
+    ),
+    append_trend_csv: Path | None = typer.Option(
+        None,
+        "--append-trend",
+        dir_okay=False,
+        help="Append a row with score, coverage and symbol count to this CSV file",
+    ),
 ) -> None:
-    """Generate README and/or HTML docs for a codebase."""
+    """Generate README and/or HTML docs for a codebase.
+
+    All requested formats are rendered from a single analysis pass.
+    """
     configure_logging(verbose=verbose, json_output=json_logs)
     logger = get_logger(__name__)
 
     target_formats = _validate_format(fmt)
+    output = _validate_output(output, out_dir, target_formats)
+    progress_mode = _validate_progress(progress, quiet)
     console.rule("[bold cyan]DocGenie")
     logger.info("Starting documentation generation", path=str(path), format=target_formats)
 
@@ -259,15 +706,108 @@ def generate(  # noqa: PLR0913
         "review": {"enabled": include_file_review},
         "output_links": {"enabled": include_output_links},
         "template_customizations": {"template_profile": template_profile},
+        "analysis": _analysis_overrides(
+            incremental=incremental,
+            cache_dir=cache_dir,
+            jobs=jobs,
+            git_metadata=git_metadata,
+            root=root,
+        ),
     }
+    if visibility is not None:
+        config_overrides["analysis"]["visibility"] = _validate_visibility(visibility)
+    if graph_format is not None:
+        config_overrides["template_customizations"]["graph_format"] = _validate_graph_format(
+            graph_format
+        )
+    if graph_scope is not None:
+        config_overrides["template_customizations"]["graph_scope"] = _validate_graph_scope(
+            graph_scope
+        )
+    if sort_symbols is not None:
+        config_overrides["template_customizations"]["sort_symbols"] = _validate_sort_symbols(
+            sort_symbols
+        )
+    if group_by is not None:
+        config_overrides["template_customizations"]["group_by"] = _validate_group_by(group_by)
+    if fragment:
+        config_overrides["template_customizations"]["html_fragment"] = True
+    if autolink:
+        config_overrides["template_customizations"]["autolink"] = True
+    if toc_depth is not None:
+        config_overrides["template_customizations"]["toc_depth"] = toc_depth
+    if no_toc:
+        config_overrides["template_customizations"]["include_toc"] = False
+    if collapse_modules:
+        config_overrides["template_customizations"]["collapse_modules"] = True
+    if no_badges:
+        config_overrides["template_customizations"]["include_badges"] = False
+    config_overrides.update(_coverage_overrides(coverage_file, coverage_threshold))
+    config_overrides.update(_tech_debt_overrides(tech_debt, debt_markers))
+    config_overrides.update(_file_overrides(include, exclude, no_gitignore))
+    if max_tokens is not None:
+        config_overrides["llms"] = {"max_tokens": max_tokens}
+    if complexity_threshold is not None:
+        config_overrides["quality"] = {"complexity_threshold": complexity_threshold}
+    if template_dir is not None:
+        config_overrides["template_customizations"]["template_dir"] = str(
+            _validate_template_dir(template_dir)
+        )
+    if theme_css is not None:
+        config_overrides["template_customizations"]["theme_css"] = str(theme_css)
+    if ignore_unreferenced:
+        dead_config = load_config(path).get("dead_code", {})
+        configured = dead_config.get("ignore", []) if isinstance(dead_config, dict) else []
+        config_overrides["dead_code"] = {"ignore": [*configured, *ignore_unreferenced]}
+    config_overrides.update(_flag_overrides(path, flag_pattern))
+    config_overrides.update(_doc_base_overrides(doc_base))
+    config_overrides.update(_layer_overrides(layers))
+    if release is not None:
+        config_overrides["project"] = {"version": release}
+    if plugin:
+        plugin_config = load_config(path).get("plugins", {})
+        configured = plugin_config.get("modules", []) if isinstance(plugin_config, dict) else []
+        config_overrides["plugins"] = {"modules": [*configured, *plugin]}
 
-    analysis_data = _run_analysis(path, ignore, tree_sitter, verbose, config_overrides)
-    outputs = _build_outputs(target_formats, output, path)
+    analysis_data = _run_analysis(
+        path, ignore, tree_sitter, verbose, config_overrides, progress=progress_mode
+    )
+    _log_overdue_deprecations(analysis_data)
+    outputs = _build_outputs(target_formats, output, path, program_name(analysis_data))
     _confirm_overwrite(outputs, preview=preview, force=force)
-    _render_outputs(outputs, analysis_data, preview=preview, strict_readme=strict_readme)
+    if out_dir is not None and not preview:
+        out_dir.mkdir(parents=True, exist_ok=True)
+    _render_outputs(
+        outputs,
+        analysis_data,
+        preview=preview,
+        strict_readme=strict_readme,
+        fail_on_broken_links=fail_on_broken_links,
+    )
 
     if not preview:
         _print_summary(analysis_data, target_formats)
+        if append_trend_csv is not None:
+            _append_trend(append_trend_csv, analysis_data)
+
+
+def _validate_output(output: Path | None, out_dir: Path | None, target_formats: str) -> Path | None:
+    """Return the directory or file the outputs are resolved against.
+
+    `--out-dir` is always a directory; `--output` may only name a file for one format.
+    """
+    if out_dir is not None:
+        if output is not None:
+            typer.echo("--output and --out-dir cannot be combined")
+            raise typer.Exit(code=1)
+        return out_dir
+    if output is not None and not output.is_dir() and len(_format_list(target_formats)) > 1:
+        typer.echo(
+            "--output must be an existing directory when several formats are requested; "
+            "use --out-dir"
+        )
+        raise typer.Exit(code=1)
+    return output
 
 
 def _resolve_output(output: Path | None, base: Path, default_name: str) -> Path:
@@ -278,10 +818,183 @@ def _resolve_output(output: Path | None, base: Path, default_name: str) -> Path:
     return output
 
 
+def _analysis_overrides(
+    *,
+    incremental: bool = True,
+    cache_dir: Path | None,
+    jobs: int | None = None,
+    git_metadata: bool = False,
+    root: Path | None = None,
+) -> dict[str, Any]:
+    overrides: dict[str, Any] = {}
+    if root is not None:
+        overrides["path_root"] = str(root)
+    if git_metadata:
+        overrides["git_metadata"] = True
+    if jobs is not None:
+        overrides["parallelism"] = jobs
+    if not incremental:
+        overrides["incremental"] = False
+    if cache_dir is not None:
+        overrides["cache_dir"] = str(cache_dir.resolve())
+    return overrides
+
+
+def _coverage_overrides(
+    coverage_file: Path | None, coverage_threshold: float | None
+) -> dict[str, Any]:
+    coverage: dict[str, Any] = {}
+    if coverage_file is not None:
+        coverage["file"] = str(coverage_file)
+    if coverage_threshold is not None:
+        coverage["threshold"] = coverage_threshold
+    return {"coverage": coverage} if coverage else {}
+
+
+def _file_overrides(include: list[str], exclude: list[str], no_gitignore: bool) -> dict[str, Any]:
+    files: dict[str, Any] = {}
+    if include or exclude:
+        # Command-line globs form their own layer, which outranks the config file's.
+        files["overrides"] = {"include": list(include), "exclude": list(exclude)}
+    if no_gitignore:
+        files["gitignore"] = False
+    return {"files": files} if files else {}
+
+
+def _flag_overrides(path: Path, patterns: list[str]) -> dict[str, Any]:
+    """Add `--flag-pattern` calls to the configured feature flag patterns."""
+    if not patterns:
+        return {}
+    flag_config = load_config(path).get("feature_flags", {})
+    configured = flag_config.get("patterns", []) if isinstance(flag_config, dict) else []
+    return {"feature_flags": {"patterns": [*configured, *patterns]}}
+
+
+def _doc_base_overrides(values: list[str]) -> dict[str, Any]:
+    """Add `--doc-base` URLs; they merge over `doc_links.bases` from the config file."""
+    if not values:
+        return {}
+    try:
+        return {"doc_links": {"bases": parse_doc_bases(values)}}
+    except ValueError as exc:
+        raise typer.BadParameter(f"--doc-base {exc}") from exc
+
+
+def _log_overdue_deprecations(analysis_data: dict[str, Any]) -> None:
+    """Log an error for each deprecated symbol still present past its removal version."""
+    release = current_version(analysis_data.get("config", {}))
+    for item in deprecations(analysis_data, release):
+        if item["overdue"]:
+            get_logger(__name__).error(
+                "Deprecated symbol is past its removal version",
+                symbol=item["name"],
+                location=f"{item['file']}:{item['line']}",
+                removed_in=item["removed_in"],
+                current_version=release,
+            )
+
+
+def _layer_overrides(value: str | None) -> dict[str, Any]:
+    """Turn `--layers` into the `layers` config; it merges over the config file's layers."""
+    if value is None:
+        return {}
+    try:
+        parsed = parse_layers(value)
+    except ValueError as exc:
+        raise typer.BadParameter(f"--layers {exc}") from exc
+    if not parsed:
+        raise typer.BadParameter("--layers needs at least one NAME=GLOB pair")
+    return {"layers": parsed}
+
+
+def _tech_debt_overrides(enabled: bool, markers: str | None) -> dict[str, Any]:
+    if markers is not None:
+        keywords = [marker.strip() for marker in markers.split(",") if marker.strip()]
+        if not keywords:
+            raise typer.BadParameter("--debt-markers needs at least one marker")
+        return {"tech_debt": {"enabled": True, "markers": keywords}}
+    return {"tech_debt": {"enabled": True}} if enabled else {}
+
+
+# Formats `check` can compare; the others are binary or not meant to be committed.
+CHECK_FORMATS = ("markdown", "adoc", "confluence", "html")
+
+
+@app.command("check")
+def check_command(  # noqa: PLR0913
+    path: Path = typer.Argument(Path("."), exists=True, file_okay=False, resolve_path=True),
+    fmt: str = typer.Option(
+        "markdown",
+        "--format",
+        "-f",
+        help="Committed formats to compare: markdown (md), adoc, confluence, html, or a "
+        "comma-separated list",
+    ),
+    output: Path | None = typer.Option(
+        None, "--output", "-o", help="Committed file, or the directory holding them"
+    ),
+    ignore: list[str] = typer.Option([], "--ignore", "-i", help="Additional ignore patterns"),
+    tree_sitter: bool = typer.Option(True, "--tree-sitter/--no-tree-sitter"),
+    max_diff_lines: int = typer.Option(
+        40, "--max-diff-lines", min=0, help="Diff lines shown per stale file"
+    ),
+) -> None:
+    """Exit 1 when the committed docs differ from what `generate` would write now.
+
+    Renders in memory and writes nothing, so it suits CI and pre-commit hooks. A README
+    with docgenie:begin/end markers is only compared inside the marked regions.
+
+    Output options are read from .docgenie.yaml only; docs generated with flags such as
+    `--visibility`, `--group-by`, `--layers` or `--fragment` need the matching config keys
+    for `check` to render the same output.
+    """
+    configure_logging(verbose=False)
+    target_formats = _validate_format(fmt)
+    unsupported = [name for name in _format_list(target_formats) if name not in CHECK_FORMATS]
+    if unsupported:
+        typer.echo(f"Cannot check {', '.join(unsupported)}; choose from {', '.join(CHECK_FORMATS)}")
+        raise typer.Exit(code=1)
+    output = _validate_output(output, None, target_formats)
+    analysis_data = _run_analysis(path, ignore, tree_sitter, verbose=False, progress=None)
+    outputs = _build_outputs(target_formats, output, path, program_name(analysis_data))
+
+    stale = 0
+    for output_format, output_path in outputs:
+        generated = render_document(analysis_data, output_format).decode("utf-8")
+        committed = output_path.read_text(encoding="utf-8") if output_path.is_file() else None
+        try:
+            diff = drift_diff(
+                committed, generated, output_path, regions=output_format == "markdown"
+            )
+        except GeneratorError as exc:
+            typer.echo(f"Cannot compare README regions: {exc}")
+            raise typer.Exit(code=1) from exc
+        if not diff:
+            console.log(f"[green]Up to date:[/green] {output_path}")
+            continue
+        stale += 1
+        added, removed = diff_stats(diff)
+        state = "missing" if committed is None else "out of date"
+        typer.echo(f"{output_path} is {state} (+{added}/-{removed} lines)")
+        shown = diff[:max_diff_lines]
+        if shown:
+            typer.echo("\n".join(shown))
+        if len(diff) > len(shown):
+            typer.echo(f"... {len(diff) - len(shown)} more diff lines")
+    if stale:
+        typer.echo(f"{stale} stale file(s); run `docgenie generate` to update them")
+        raise typer.Exit(code=1)
+
+
 @app.command("analyze")
-def analyze(
-    path: Path = typer.Argument(Path("."), exists=True, resolve_path=True),
-    fmt: str = typer.Option("text", "--format", "-f", help="Output format"),
+def analyze(  # noqa: PLR0913
+    target: str = typer.Argument(
+        ".", metavar="PATH", help="Directory to analyze, or a git URL to check out and analyze"
+    ),
+    ref: str | None = typer.Option(
+        None, "--ref", help="Branch, tag or commit SHA to check out (git URLs only)"
+    ),
+    fmt: str = typer.Option("text", "--format", "-f", help="text, json, yaml, or adoc"),
     tree_sitter: bool = typer.Option(
         True,
         "--tree-sitter/--no-tree-sitter",
@@ -291,32 +1004,190 @@ def analyze(
         None, "--metrics-json", help="Optional path to write run metrics as JSON"
     ),
     engine: str = typer.Option("hybrid", "--engine", help="Engine: hybrid|stateless"),
-    incremental: bool = typer.Option(True, "--incremental/--no-incremental"),
+    incremental: bool = typer.Option(
+        True,
+        "--incremental/--no-incremental",
+        " /--no-cache",
+        help="Reuse parses of unchanged files; --no-cache re-parses all and writes no index",
+    ),
+    cache_dir: Path | None = typer.Option(
+        None, "--cache-dir", help="Directory for the incremental index (default: .docgenie)"
+    ),
+    jobs: int | None = typer.Option(
+        None, "--jobs", "-j", min=1, help="Parallel parse workers (default: CPU count)"
+    ),
+    git_metadata: bool = typer.Option(
+        False, "--git-metadata", help="Add per-symbol last-commit date/author (runs git blame)"
+    ),
+    root: Path | None = typer.Option(
+        None,
+        "--root",
+        exists=True,
+        file_okay=False,
+        resolve_path=True,
+        help="Directory that file paths in the output are relative to (default: the analyzed "
+        "path)",
+    ),
+    schema_version: int | None = typer.Option(
+        None,
+        "--schema-version",
+        help="Emit the versioned analysis document (requires --format json)",
+    ),
+    stream: bool = typer.Option(
+        False,
+        "--stream",
+        help="Write JSON one module at a time as it is compiled (requires --format json)",
+    ),
+    coverage_file: Path | None = typer.Option(
+        None,
+        "--coverage-file",
+        exists=True,
+        dir_okay=False,
+        resolve_path=True,
+        help="Go coverage.out or Cobertura coverage.xml to include in run metrics",
+    ),
+    coverage_threshold: float | None = typer.Option(
+        None,
+        "--coverage-threshold",
+        min=0,
+        max=100,
+        help="Coverage percentage below which modules are flagged",
+    ),
+    fail_under: float | None = typer.Option(
+        None,
+        "--fail-under",
+        min=0,
+        max=100,
+        help="Exit 1 when the documentation quality score is below this value",
+    ),
+    fail_under_lang: str | None = typer.Option(
+        None,
+        "--fail-under-lang",
+        help="Per-language docstring coverage thresholds, e.g. go=80,py=60",
+    ),
+    include: list[str] = typer.Option(
+        [], "--include", help="Only scan files matching this glob (repeatable)"
+    ),
+    exclude: list[str] = typer.Option(
+        [], "--exclude", help="Skip files matching this glob; beats --include (repeatable)"
+    ),
+    no_gitignore: bool = typer.Option(
+        False, "--no-gitignore", help="Scan files ignored by .gitignore"
+    ),
+    workspace: bool = typer.Option(
+        False,
+        "--workspace",
+        help="Document each subproject (go.mod, package.json, pyproject.toml) separately",
+    ),
+    append_trend_csv: Path | None = typer.Option(
+        None,
+        "--append-trend",
+        dir_okay=False,
+        help="Append a row with score, coverage and symbol count to this CSV file",
+    ),
+    flag_pattern: list[str] = typer.Option(
+        [],
+        "--flag-pattern",
+        help="Feature flag lookup call to detect, e.g. flags.Enabled or *.IsOn (repeatable)",
+    ),
+    progress: str = typer.Option(
+        "text",
+        "--progress",
+        help="Progress on stderr: text (terminals only) or json (one object per line)",
+    ),
+    quiet: bool = typer.Option(False, "--quiet", "-q", help="Do not report progress"),
 ) -> None:
-    """Analyze a codebase and print structured results."""
+    """Analyze a codebase and print structured results.
+
+    With `--fail-under` or `--fail-under-lang` the outputs are written first, then the
+    command exits 1 if any threshold is missed.
+
+    A git URL is shallow-cloned first, at `--ref` or the default branch. The checkout is
+    cached under the cache directory for repeat runs; with `--no-cache` (an alias of
+    `--no-incremental`) it is made in a temporary directory and removed afterwards.
+    """
+    path = _analysis_target(str(target), ref, cache_dir=cache_dir, incremental=incremental)
+    _validate_schema_version(schema_version, fmt)
+    progress_mode = _validate_progress(progress, quiet)
+    language_thresholds = _validate_language_thresholds(fail_under_lang)
+    _validate_stream(
+        stream,
+        fmt,
+        schema_version=schema_version,
+        workspace=workspace,
+        quality_gate=fail_under is not None or bool(language_thresholds),
+        append_trend=append_trend_csv is not None,
+    )
+    if workspace and (fail_under is not None or language_thresholds):
+        typer.echo("--fail-under and --fail-under-lang are not supported with --workspace")
+        raise typer.Exit(code=1)
+    if workspace and append_trend_csv is not None:
+        typer.echo("--append-trend is not supported with --workspace")
+        raise typer.Exit(code=1)
+    analysis_config: dict[str, Any] = {
+        "engine": "hybrid_index" if engine == "hybrid" else "stateless",
+        "incremental": incremental,
+    }
+    analysis_config.update(
+        _analysis_overrides(
+            incremental=incremental,
+            cache_dir=cache_dir,
+            jobs=jobs,
+            git_metadata=git_metadata,
+            root=root,
+        )
+    )
+    if workspace:
+        _analyze_workspace(
+            path,
+            fmt=fmt,
+            tree_sitter=tree_sitter,
+            metrics_json=metrics_json,
+            config_overrides={
+                "analysis": analysis_config,
+                **_coverage_overrides(coverage_file, coverage_threshold),
+                **_file_overrides(include, exclude, no_gitignore),
+            },
+        )
+        return
     analysis_data = _run_analysis(
         path,
         ignore=[],
         tree_sitter=tree_sitter,
         verbose=False,
         config_overrides={
-            "analysis": {
-                "engine": "hybrid_index" if engine == "hybrid" else "stateless",
-                "incremental": incremental,
-            }
+            "analysis": analysis_config,
+            **_coverage_overrides(coverage_file, coverage_threshold),
+            **_file_overrides(include, exclude, no_gitignore),
+            **_flag_overrides(path, flag_pattern),
         },
+        progress=progress_mode,
+        json_stream=sys.stdout if stream else None,
     )
 
+    output_root = path_root(analysis_data)
     if metrics_json is not None:
+        metrics = dict(analysis_data.get("run_metrics", {}))
+        metrics["quality_weights"] = _quality_weights(analysis_data.get("config", {}))
         metrics_json.write_text(
-            json.dumps(analysis_data.get("run_metrics", {}), indent=2, sort_keys=True),
+            json.dumps(relative_paths(metrics, output_root), indent=2, sort_keys=True),
             encoding="utf-8",
         )
 
-    if fmt == "json":
-        typer.echo(json.dumps(analysis_data, indent=2))
+    if stream:
+        sys.stdout.write("\n")
+        sys.stdout.flush()
+    elif schema_version is not None:
+        document = build_analysis_document(analysis_data, schema_version=schema_version)
+        typer.echo(json.dumps(relative_paths(document.to_public_dict(), output_root), indent=2))
+    elif fmt == "json":
+        typer.echo(json.dumps(relative_paths(analysis_data, output_root), indent=2))
     elif fmt == "yaml":
-        typer.echo(yaml.dump(analysis_data, default_flow_style=False))
+        typer.echo(
+            yaml.dump(relative_paths(analysis_data, output_root), default_flow_style=False)
+        )
+    elif fmt == "adoc":
+        typer.echo(ReadmeGenerator().generate(analysis_data, None, output_format="adoc"))
     else:
         typer.echo("Codebase Analysis Results")
         typer.echo(f"Path: {analysis_data.get('root_path')}")
@@ -325,16 +1196,268 @@ def analyze(
         typer.echo(f"Functions: {len(analysis_data['functions'])}")
         typer.echo(f"Classes: {len(analysis_data['classes'])}")
 
+    if append_trend_csv is not None:
+        _append_trend(append_trend_csv, analysis_data)
+    if fail_under is not None or language_thresholds:
+        _apply_quality_gate(analysis_data, fail_under, language_thresholds)
+
+
+def _validate_stream(
+    stream: bool,
+    fmt: str,
+    *,
+    schema_version: int | None,
+    workspace: bool,
+    quality_gate: bool,
+    append_trend: bool,
+) -> None:
+    """Reject `--stream` outside plain JSON output or with options that need every symbol."""
+    if not stream:
+        return
+    if fmt != "json":
+        typer.echo("--stream requires --format json")
+        raise typer.Exit(code=1)
+    conflicts = [
+        name
+        for name, given in (
+            ("--schema-version", schema_version is not None),
+            ("--workspace", workspace),
+            ("--fail-under/--fail-under-lang", quality_gate),
+            ("--append-trend", append_trend),
+        )
+        if given
+    ]
+    if conflicts:
+        typer.echo(f"--stream is not supported with {', '.join(conflicts)}")
+        raise typer.Exit(code=1)
+
+
+def _analysis_target(
+    target: str, ref: str | None, *, cache_dir: Path | None, incremental: bool
+) -> Path:
+    """Resolve the `analyze` argument, checking out a git URL for the command's lifetime."""
+    if not is_remote_url(target):
+        if ref is not None:
+            raise typer.BadParameter("--ref needs a git URL", param_hint="'--ref'")
+        path = Path(target).resolve()
+        if not path.exists():
+            raise typer.BadParameter(f"Path '{target}' does not exist.", param_hint="'PATH'")
+        return path
+    checkout_cache = (cache_dir.resolve() if cache_dir else _cache_dir()) if incremental else None
+    try:
+        # The click context closes the checkout (removing a temporary one) when the
+        # command returns, so every exit path cleans up.
+        context = click.get_current_context()
+        return context.with_resource(remote_checkout(target, ref, cache_dir=checkout_cache))
+    except RemoteRepoError as exc:
+        typer.echo(str(exc))
+        raise typer.Exit(code=1) from exc
+
+
+def _cache_dir() -> Path:
+    """Return the cache directory of the project the command runs in.
+
+    The project is the git work tree around the current directory, or the directory
+    itself outside one; its `analysis.cache_dir` is resolved against it, as for a
+    local analysis, and defaults to its `.docgenie`.
+    """
+    project = project_root(Path.cwd().resolve())
+    analysis = load_config(project).get("analysis", {})
+    configured = analysis.get("cache_dir") if isinstance(analysis, dict) else None
+    cache_dir = Path(str(configured)) if configured else Path(".docgenie")
+    return cache_dir if cache_dir.is_absolute() else project / cache_dir
+
+
+def _validate_language_thresholds(spec: str | None) -> dict[str, float]:
+    try:
+        return parse_language_thresholds(spec)
+    except ConfigError as exc:
+        typer.echo(f"Invalid --fail-under-lang: {exc}")
+        raise typer.Exit(code=1) from exc
+
+
+def _append_trend(path: Path, analysis_data: dict[str, Any]) -> None:
+    score = ReadmeGenerator().quality_report(analysis_data)["score"]
+    try:
+        append_trend(path, analysis_data, score)
+    except (ConfigError, OSError) as exc:
+        typer.echo(f"Cannot append to trend file: {exc}")
+        raise typer.Exit(code=1) from exc
+
+
+def _apply_quality_gate(
+    analysis_data: dict[str, Any],
+    fail_under: float | None,
+    language_thresholds: dict[str, float],
+) -> None:
+    """Report the quality gate on stderr and exit 1 when a threshold is missed."""
+    score = ReadmeGenerator().quality_report(analysis_data)["score"]
+    failures = evaluate_quality_gate(
+        score,
+        language_coverage(analysis_data),
+        fail_under=fail_under,
+        language_thresholds=language_thresholds,
+    )
+    if failures:
+        for failure in failures:
+            typer.echo(f"Quality gate failed: {failure}", err=True)
+        raise typer.Exit(code=1)
+    typer.echo(f"Quality gate passed (score {score})", err=True)
+
+
+def _analyze_workspace(
+    path: Path,
+    *,
+    fmt: str,
+    tree_sitter: bool,
+    metrics_json: Path | None,
+    config_overrides: dict[str, Any],
+) -> None:
+    """Analyze each subproject, write its README and the index linking them all."""
+    with Progress(console=console, transient=True) as progress:
+        task = progress.add_task("Analyzing workspace...", total=100)
+        workspace = analyze_workspace(
+            path, enable_tree_sitter=tree_sitter, config_overrides=config_overrides
+        )
+        progress.update(task, completed=100)
+    if not workspace["subprojects"]:
+        typer.echo("No subprojects found (looked for go.mod, package.json, pyproject.toml).")
+        raise typer.Exit(code=1)
+
+    monorepo = workspace["config"].get("monorepo", {})
+    output_dir = path / str(monorepo.get("package_output_dir", ".docgenie/packages"))
+    if monorepo.get("per_package_docs", True):
+        for item in workspace["subprojects"]:
+            readme = output_dir / item["path"] / "README.md"
+            readme.parent.mkdir(parents=True, exist_ok=True)
+            _render_outputs([("markdown", readme)], item["analysis"], preview=False)
+    if monorepo.get("root_doc", True):
+        index_path = output_dir / "README.md"
+        index_path.parent.mkdir(parents=True, exist_ok=True)
+        index_path.write_text(render_workspace_index(workspace), encoding="utf-8")
+        console.log(f"[green]Workspace index generated:[/green] {index_path}")
+
+    output_root = path_root(workspace)
+    if metrics_json is not None:
+        metrics = relative_paths(workspace["run_metrics"], output_root)
+        metrics_json.write_text(json.dumps(metrics, indent=2, sort_keys=True), encoding="utf-8")
+    if fmt == "json":
+        typer.echo(json.dumps(relative_paths(workspace, output_root), indent=2))
+    else:
+        typer.echo("Workspace Analysis Results")
+        typer.echo(f"Path: {workspace['root_path']}")
+        for item in workspace["subprojects"]:
+            metrics = item["analysis"].get("run_metrics", {})
+            typer.echo(
+                f"- {item['path']} ({item['marker']}): "
+                f"{item['analysis'].get('files_analyzed', 0)} files, "
+                f"re-parsed {metrics.get('changed_files', 0)}"
+            )
+
+
+def _watch_exclusions(outputs: list[OutputSpec]) -> list[Path]:
+    excluded = [out_path for _, out_path in outputs]
+    excluded += [
+        out_path.parent / name
+        for kind, out_path in outputs
+        if kind == "html"
+        for name in (SEARCH_INDEX_FILENAME, FRAGMENT_CSS_FILENAME)
+    ]
+    return excluded
+
+
+def _watch_run(
+    path: Path,
+    outputs: list[OutputSpec],
+    *,
+    ignore: list[str],
+    tree_sitter: bool,
+    changes: list[str],
+) -> None:
+    analysis_data = _run_analysis(path, ignore, tree_sitter, verbose=False)
+    _render_outputs(outputs, analysis_data, preview=False)
+    metrics = analysis_data.get("run_metrics", {})
+    trigger = f"{len(changes)} changed" if changes else "initial run"
+    console.log(
+        f"[cyan]{trigger}[/cyan]: re-parsed {metrics.get('changed_files', 0)}, "
+        f"cache hits {metrics.get('cache_hits', 0)}, "
+        f"duration {metrics.get('duration_sec', 0.0)}s"
+    )
+
+
+@app.command("watch")
+def watch_command(  # noqa: PLR0913
+    path: Path = typer.Argument(
+        Path("."), exists=True, file_okay=False, dir_okay=True, resolve_path=True
+    ),
+    output: Path | None = typer.Option(
+        None, "--output", "-o", help="Output path for documentation."
+    ),
+    fmt: str = typer.Option(
+        "both",
+        "--format",
+        "--fmt",
+        "-f",
+        help="Output format: markdown (md), html, both, adoc, confluence, man, llms, docbook, "
+        "epub or json; several may be given comma-separated",
+    ),
+    ignore: list[str] = typer.Option([], "--ignore", "-i", help="Additional ignore patterns"),
+    force: bool = typer.Option(False, "--force", help="Overwrite existing files"),
+    interval: float = typer.Option(0.5, "--interval", help="Seconds between file scans"),
+    debounce: float = typer.Option(
+        0.5, "--debounce", help="Quiet seconds to wait after the last change before a run"
+    ),
+    tree_sitter: bool = typer.Option(True, "--tree-sitter/--no-tree-sitter"),
+) -> None:
+    """Regenerate docs whenever files change, re-parsing only the changed ones."""
+    target_formats = _validate_format(fmt)
+    outputs = _build_outputs(target_formats, _validate_output(output, None, target_formats), path)
+    _confirm_overwrite(outputs, preview=False, force=force)
+    config_ignore = load_config(path).get("ignore_patterns", [])
+    watcher = ChangeWatcher(
+        path,
+        ignore_patterns=list(set(ignore + config_ignore)),
+        excluded=_watch_exclusions(outputs),
+        interval=interval,
+        debounce=debounce,
+    )
+
+    _watch_run(path, outputs, ignore=ignore, tree_sitter=tree_sitter, changes=[])
+    console.log(f"Watching {path} for changes (Ctrl-C to stop)")
+    try:
+        while True:
+            changes = watcher.wait_for_changes()
+            _watch_run(path, outputs, ignore=ignore, tree_sitter=tree_sitter, changes=changes)
+    except KeyboardInterrupt:
+        watcher.poll()
+        pending = watcher.take_pending()
+        if pending:
+            _watch_run(path, outputs, ignore=ignore, tree_sitter=tree_sitter, changes=pending)
+        console.log("Watch stopped")
+
 
 @app.command("diff")
-def diff_command(
+def diff_command(  # noqa: PLR0913
     path: Path = typer.Argument(Path("."), exists=True, resolve_path=True),
+    new_analysis: Path | None = typer.Argument(
+        None,
+        exists=True,
+        dir_okay=False,
+        resolve_path=True,
+        help="Newer analysis JSON; PATH is then the older one and their APIs are compared",
+    ),
     from_ref: str | None = typer.Option(None, "--from-ref"),
     to_ref: str = typer.Option("HEAD", "--to-ref"),
     fmt: str = typer.Option("text", "--format", "-f", help="text or json"),
     rename_detection: bool = typer.Option(True, "--rename-detection/--no-rename-detection"),
+    output: Path | None = typer.Option(
+        None, "--output", "-o", help="Write the API change report here instead of stdout"
+    ),
 ) -> None:
-    """Show version-aware git diff metadata for documentation."""
+    """Show version-aware git diff metadata, or API changes between two analysis JSON files."""
+    if new_analysis is not None:
+        _api_diff(path, new_analysis, fmt=fmt, output=output)
+        return
     summary = compute_git_diff_summary(
         path,
         from_ref=from_ref,
@@ -364,6 +1487,23 @@ def diff_command(
     typer.echo(msg)
 
 
+def _api_diff(old_path: Path, new_path: Path, *, fmt: str, output: Path | None) -> None:
+    try:
+        report = diff_api(load_analysis(old_path), load_analysis(new_path))
+    except ConfigError as exc:
+        typer.echo(f"Invalid analysis: {exc}")
+        raise typer.Exit(code=1) from exc
+    if fmt.lower() == "json":
+        rendered = json.dumps(report, indent=2)
+    else:
+        rendered = render_api_diff(report, old_label=old_path.name, new_label=new_path.name)
+    if output:
+        output.write_text(rendered, encoding="utf-8")
+        console.log(f"[green]API change report generated:[/green] {output}")
+    else:
+        typer.echo(rendered)
+
+
 @app.command("pr-summary")
 def pr_summary_command(
     path: Path = typer.Argument(Path("."), exists=True, resolve_path=True),
@@ -385,11 +1525,7 @@ def pr_summary_command(
     )
 
     if not analysis_data.get("readme_readiness"):
-        readme_content = ReadmeGenerator().generate(analysis_data, None)
-        analysis_data["readme_readiness"] = evaluate_readme_readiness(
-            readme_content,
-            analysis_data=analysis_data,
-        )
+        analysis_data["readme_readiness"] = readme_readiness(analysis_data)
 
     if fmt.lower() == "json":
         payload = {
@@ -411,7 +1547,7 @@ def pr_summary_command(
 
 @app.command("init")
 def init_project_config(
-    force: bool = typer.Option(False, "--force", "-f", help="Overwrite existing config"),
+    force: bool = typer.Option(False, "--force", help="Overwrite existing config"),
 ) -> None:
     """Create a starter .docgenie.yaml configuration file."""
     config_path = Path(".docgenie.yaml")
@@ -465,7 +1601,7 @@ def html_command(  # noqa: PLR0913
     output: Path | None = typer.Option(None, "--output", "-o", help="Output HTML path"),
     source: str = typer.Option("readme", "--source", "-s", help="readme or codebase"),
     title: str | None = typer.Option(None, "--title", "-t", help="Custom HTML title"),
-    force: bool = typer.Option(False, "--force", "-f", help="Overwrite existing files"),
+    force: bool = typer.Option(False, "--force", help="Overwrite existing files"),
     open_browser: bool = typer.Option(
         False,
         "--open-browser",
@@ -474,9 +1610,25 @@ def html_command(  # noqa: PLR0913
     ),
     verbose: bool = typer.Option(False, "--verbose", "-v"),
     tree_sitter: bool = typer.Option(True, "--tree-sitter/--no-tree-sitter"),
+    theme_css: Path | None = typer.Option(
+        None,
+        "--theme-css",
+        exists=True,
+        dir_okay=False,
+        resolve_path=True,
+        help="Stylesheet inlined after the built-in styles to override their color variables",
+    ),
+    fragment: bool = typer.Option(
+        False,
+        "--fragment",
+        help="Write an embeddable <div> without <html>/<head>, plus a separate docgenie.css",
+    ),
 ) -> None:
-    """Convert README to HTML or generate HTML from codebase analysis."""
-    html_generator = HTMLGenerator()
+    """Convert README to HTML or generate HTML from codebase analysis.
+
+    With `--source codebase` the project is analyzed and rendered the way `generate
+    --format html` does it, so `.docgenie.yaml` applies.
+    """
     output_path = output
     if not output_path:
         output_path = (input_path.parent if source == "readme" else input_path) / "docs.html"
@@ -496,15 +1648,33 @@ def html_command(  # noqa: PLR0913
             raise typer.Exit(code=1)
         readme_content = input_path.read_text(encoding="utf-8")
         project_name = title or _extract_title(readme_content) or input_path.stem
-        html_generator.generate_from_readme(readme_content, str(output_path), project_name)
+        HTMLGenerator().generate_from_readme(
+            readme_content,
+            str(output_path),
+            project_name,
+            theme_css=load_theme_css(theme_css) if theme_css else None,
+            fragment=fragment,
+        )
+        console.log(f"[green]HTML generated:[/green] {output_path}")
     else:
-        analyzer = CodebaseAnalyzer(str(input_path), enable_tree_sitter=tree_sitter)
+        customizations: dict[str, Any] = {}
+        if theme_css:
+            customizations["theme_css"] = str(theme_css)
+        if fragment:
+            customizations["html_fragment"] = True
         if verbose:
             console.log(f"Analyzing codebase at {input_path}")
-        analysis_data = analyzer.analyze()
-        html_generator.generate_from_analysis(analysis_data, str(output_path))
+        analysis_data = _run_analysis(
+            input_path,
+            ignore=[],
+            tree_sitter=tree_sitter,
+            verbose=verbose,
+            config_overrides={"template_customizations": customizations}
+            if customizations
+            else None,
+        )
+        _render_outputs([("html", output_path)], analysis_data, preview=False)
 
-    console.log(f"[green]HTML generated:[/green] {output_path}")
     if open_browser:
         webbrowser.open(output_path.resolve().as_uri())
 
@@ -539,6 +1709,12 @@ def index_stats(
     typer.echo(json.dumps(stats, indent=2, sort_keys=True))
 
 
+@config_app.command("schema")
+def config_schema_command() -> None:
+    """Print the JSON Schema of .docgenie.yaml for editor autocompletion."""
+    typer.echo(json.dumps(config_json_schema(), indent=2))
+
+
 @app.command("diff-index")
 def diff_index_command(
     path: Path = typer.Argument(Path("."), exists=True, file_okay=False, resolve_path=True),
@@ -0,0 +1,286 @@
+"""Detect command-line flags and subcommands declared with Go's `flag` package or cobra."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from .languages._scan import code_lines
+from .routes import call_arguments, string_literal
+from .utils import get_file_language
+
+_FLAG_TYPES = (
+    "BoolSlice|Bool|Count|Duration|Float64|Int64|IntSlice|Int|StringArray|StringSlice|"
+    "StringToString|String|Uint64|Uint"
+)
+_STD_FLAG_RE = re.compile(rf"\b(?P<set>[A-Za-z_]\w*)\.(?P<type>{_FLAG_TYPES})(?P<var>Var)?\(")
+_COBRA_FLAG_RE = re.compile(
+    rf"\b(?P<cmd>[A-Za-z_]\w*)\.(?P<scope>PersistentFlags|Flags)\(\)\."
+    rf"(?P<type>{_FLAG_TYPES})(?P<var>Var)?(?P<short>P)?\("
+)
+_FLAGSET_RE = re.compile(r"\b([A-Za-z_]\w*)\s*:?=\s*flag\.NewFlagSet\(")
+_COMMAND_RE = re.compile(r"\b(?P<var>[A-Za-z_]\w*)\s*:?=\s*&cobra\.Command\{")
+_ADD_COMMAND_RE = re.compile(r"\b(?P<parent>[A-Za-z_]\w*)\.AddCommand\(")
+_FIELD_RE = r"\b{name}:\s*(\"(?:\\.|[^\"\\])*\"|`[^`]*`)"
+_MAIN_RE = re.compile(r"^func\s+main\s*\(\s*\)")
+_PACKAGE_MAIN_RE = re.compile(r"^package\s+main\b", re.MULTILINE)
+_MODULE_RE = re.compile(r"^module\s+(\S+)", re.MULTILINE)
+# Go's flag.PrintDefaults omits zero values; so do we.
+_ZERO_DEFAULTS = frozenset({"", "false", "0", "nil", "0.0", "[]string{}"})
+
+
+def scan_cli_interface(
+    root_path: Path, files: Iterable[Path], *, names_root: Path | None = None
+) -> dict[str, Any]:
+    """Return the program name, `main` entry point, flags and cobra commands in Go sources.
+
+    File names are relative to `names_root` (default `root_path`, where go.mod is read).
+    Flags are only reported when their name is a string literal. Cobra flags
+    record the command variable they are attached to in `command`; flags from
+    the standard library `flag` package (or a `flag.NewFlagSet`) leave it empty.
+    """
+    entry_point = ""
+    flags: list[dict[str, Any]] = []
+    commands: list[dict[str, Any]] = []
+    parents: dict[str, str] = {}
+    for path in sorted(files):
+        if get_file_language(path) != "go":
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        try:
+            rel = path.relative_to(names_root or root_path).as_posix()
+        except ValueError:
+            rel = path.as_posix()
+        code = code_lines(content, quotes="\"'`", multiline_quotes="`")
+        if not entry_point and _PACKAGE_MAIN_RE.search(content):
+            if any(_MAIN_RE.match(line) for line in code):
+                entry_point = rel
+        if "flag" not in content.lower() and "cobra" not in content:
+            continue
+        source = _GoSource(content, code)
+        flags.extend({**flag, "file": rel} for flag in source.flags())
+        commands.extend({**command, "file": rel} for command in source.commands())
+        parents.update(source.parents())
+
+    by_var = {command["var"]: command for command in commands}
+    for command in commands:
+        parent = by_var.get(parents.get(command["var"], ""))
+        command["parent"] = parent["name"] if parent else ""
+    roots = [command for command in commands if command["var"] not in parents]
+    root_command = roots[0] if roots else None
+    for flag in flags:
+        owner = by_var.get(flag["command"])
+        # Root and persistent flags apply to every invocation; others belong to one command.
+        is_global = owner is None or owner is root_command or flag["persistent"]
+        flag["command"] = "" if is_global else owner["name"]
+    return {
+        "program": _program_name(root_path, entry_point, root_command),
+        "entry_point": entry_point,
+        "summary": root_command["short"] if root_command else "",
+        "description": _description(root_command),
+        "flags": flags,
+        "commands": [command for command in commands if command is not root_command],
+    }
+
+
+class _GoSource:
+    def __init__(self, content: str, code: list[str]) -> None:
+        self.text = "\n".join(content.splitlines())
+        self.code = code
+        self.offsets = [0]
+        for line in content.splitlines():
+            self.offsets.append(self.offsets[-1] + len(line) + 1)
+        self.flag_sets = {"flag"}
+        for line in code:
+            self.flag_sets.update(match.group(1) for match in _FLAGSET_RE.finditer(line))
+
+    def flags(self) -> list[dict[str, Any]]:
+        found: list[dict[str, Any]] = []
+        for line_no, line in enumerate(self.code):
+            for match in _COBRA_FLAG_RE.finditer(line):
+                args = self._args(line_no, match.end())
+                flag = _flag_from_args(
+                    args,
+                    flag_type=match.group("type"),
+                    has_var=bool(match.group("var")),
+                    has_short=bool(match.group("short")),
+                )
+                if flag is not None:
+                    persistent = match.group("scope") == "PersistentFlags"
+                    found.append(
+                        {
+                            **flag,
+                            "style": "posix",
+                            "command": match.group("cmd"),
+                            "persistent": persistent,
+                            "line": line_no + 1,
+                        }
+                    )
+            for match in _STD_FLAG_RE.finditer(line):
+                if match.group("set") not in self.flag_sets:
+                    continue
+                args = self._args(line_no, match.end())
+                flag = _flag_from_args(
+                    args, flag_type=match.group("type"), has_var=bool(match.group("var"))
+                )
+                if flag is not None:
+                    found.append(
+                        {
+                            **flag,
+                            "style": "go",
+                            "command": "",
+                            "persistent": False,
+                            "line": line_no + 1,
+                        }
+                    )
+        return found
+
+    def commands(self) -> list[dict[str, Any]]:
+        found: list[dict[str, Any]] = []
+        for line_no, line in enumerate(self.code):
+            for match in _COMMAND_RE.finditer(line):
+                body, _ = _braced(self.text, self.offsets[line_no] + match.end())
+                use = _field(body, "Use")
+                if not use:
+                    continue
+                found.append(
+                    {
+                        "var": match.group("var"),
+                        "name": use.split()[0],
+                        "use": use,
+                        "short": _field(body, "Short"),
+                        "long": _field(body, "Long"),
+                        "line": line_no + 1,
+                    }
+                )
+        return found
+
+    def parents(self) -> dict[str, str]:
+        """Map each child command variable to the variable it was added to."""
+        mapping: dict[str, str] = {}
+        for line_no, line in enumerate(self.code):
+            for match in _ADD_COMMAND_RE.finditer(line):
+                for child in _split_arguments(self._args(line_no, match.end())):
+                    if re.fullmatch(r"[A-Za-z_]\w*", child):
+                        mapping[child] = match.group("parent")
+        return mapping
+
+    def _args(self, line_no: int, column: int) -> str:
+        return call_arguments(self.text, self.offsets[line_no] + column)[0]
+
+
+def _split_arguments(args: str) -> list[str]:
+    """Split Go call arguments on top-level commas, ignoring commas inside strings."""
+    parts: list[str] = []
+    current: list[str] = []
+    depth = 0
+    quote: str | None = None
+    escaped = False
+    for char in args:
+        current.append(char)
+        if quote is not None:
+            if escaped:
+                escaped = False
+            elif char == "\\" and quote == '"':
+                escaped = True
+            elif char == quote:
+                quote = None
+            continue
+        if char in "\"`":
+            quote = char
+        elif char in "([{":
+            depth += 1
+        elif char in ")]}":
+            depth -= 1
+        elif char == "," and depth == 0:
+            current.pop()
+            parts.append("".join(current).strip())
+            current = []
+    tail = "".join(current).strip()
+    if tail:
+        parts.append(tail)
+    return parts
+
+
+def _flag_from_args(
+    args: str, *, flag_type: str, has_var: bool, has_short: bool = False
+) -> dict[str, Any] | None:
+    parts = _split_arguments(args)
+    if has_var:
+        parts = parts[1:]
+    expected = 4 if has_short else 3
+    if len(parts) < expected:
+        return None
+    name = string_literal(parts[0])
+    if not name:
+        return None
+    shorthand = string_literal(parts[1]) if has_short else ""
+    default_raw = parts[expected - 2]
+    default = string_literal(default_raw)
+    if default is None:
+        default = " ".join(default_raw.split())
+    return {
+        "name": name,
+        "shorthand": shorthand or "",
+        "type": flag_type.lower(),
+        "default": "" if default in _ZERO_DEFAULTS else default,
+        "usage": string_literal(parts[expected - 1]) or "",
+    }
+
+
+def _braced(text: str, start: int) -> tuple[str, int]:
+    """Return the text of the `{...}` literal whose opening brace precedes `start`."""
+    depth = 1
+    idx = start
+    quote: str | None = None
+    while idx < len(text):
+        char = text[idx]
+        if quote is not None:
+            if char == "\\" and quote == '"':
+                idx += 1
+            elif char == quote:
+                quote = None
+        elif char in "\"`":
+            quote = char
+        elif char == "{":
+            depth += 1
+        elif char == "}":
+            depth -= 1
+            if depth == 0:
+                return text[start:idx], idx + 1
+        idx += 1
+    return text[start:], len(text)
+
+
+def _field(body: str, name: str) -> str:
+    match = re.search(_FIELD_RE.format(name=name), body)
+    value = (string_literal(match.group(1)) if match else None) or ""
+    # `Long` keeps its paragraphs; one-line fields are collapsed.
+    return value.strip() if name == "Long" else " ".join(value.split())
+
+
+def _description(root_command: dict[str, Any] | None) -> str:
+    if root_command is None:
+        return ""
+    return str(root_command.get("long") or root_command.get("short") or "")
+
+
+def _program_name(root_path: Path, entry_point: str, root_command: dict[str, Any] | None) -> str:
+    """Prefer the root command's `Use`, then `cmd/<name>/`, then go.mod, then the directory."""
+    if root_command is not None:
+        return str(root_command["name"])
+    parts = Path(entry_point).parts
+    if len(parts) >= 3 and parts[-3] == "cmd":
+        return parts[-2]
+    try:
+        module = _MODULE_RE.search((root_path / "go.mod").read_text(encoding="utf-8"))
+    except (OSError, UnicodeDecodeError):
+        module = None
+    if module:
+        return module.group(1).rstrip("/").rsplit("/", 1)[-1]
+    return root_path.resolve().name
@@ -0,0 +1,202 @@
+"""Heuristic cyclomatic complexity of parsed functions.
+
+Complexity is 1 plus the number of branch points in the function body: `if`,
+loops, `case`/`when` arms, `catch`/`except`/`rescue` clauses and the short-circuit
+operators `&&`/`||` (`and`/`or`). Python bodies are measured on the AST; other
+languages are scanned line by line with comments and string bodies blanked out,
+so a keyword inside a comment never counts. The result is an estimate meant to
+point at hotspots, not a McCabe measurement of the control-flow graph.
+"""
+
+from __future__ import annotations
+
+import ast
+import re
+from dataclasses import replace
+from typing import Any
+
+from .models import ParseResult
+from .module_index import relative_path
+from .reproducible import path_root
+
+DEFAULT_COMPLEXITY_THRESHOLD = 20
+# Upper bounds of the `low` and `moderate` bands; anything above is `high`.
+COMPLEXITY_BANDS = (("low", 10), ("moderate", 20))
+_MAX_WARNED = 5
+
+_C_BRANCHES = re.compile(r"\b(?:if|for|while|case|catch)\b|&&|\|\|")
+_BRANCHES: dict[str, re.Pattern[str]] = {
+    "go": re.compile(r"\b(?:if|for|case)\b|&&|\|\|"),
+    # Each `=>` is one arm of a `match`.
+    "rust": re.compile(r"\b(?:if|for|while)\b|=>|&&|\|\|"),
+    "swift": re.compile(r"\b(?:if|guard|for|while|case|catch)\b|&&|\|\|"),
+    "php": re.compile(r"\b(?:if|elseif|for|foreach|while|case|catch|and|or)\b|&&|\|\|"),
+    "ruby": re.compile(r"\b(?:if|elsif|unless|while|until|for|when|rescue|and|or)\b|&&|\|\|"),
+    "python": re.compile(r"\b(?:if|elif|for|while|except|case|and|or)\b"),
+    "lua": re.compile(r"\b(?:if|elseif|for|while|repeat|and|or)\b"),
+    # Each `;;` ends one `case` arm.
+    "shell": re.compile(r"\b(?:if|elif|for|while|until)\b|;;|&&|\|\|"),
+    # Each `->` is one clause of a `case`, `cond`, `with` or `fn`.
+    "elixir": re.compile(r"\b(?:if|unless|rescue|catch|and|or)\b|->|&&|\|\|"),
+    "r": re.compile(r"\b(?:if|for|while|repeat|tryCatch)\b|&&|\|\|"),
+    # Each lone `|` is a guard; `->` is left out since type signatures are full of it.
+    "haskell": re.compile(r"\b(?:if|case)\b|(?<!\|)\|(?!\|)|&&|\|\|"),
+}
+# Same comment and quote rules as the language's parser uses.
+_CODE_OPTIONS: dict[str, dict[str, Any]] = {
+    "go": {"quotes": "\"'`", "multiline_quotes": "`"},
+    "javascript": {"quotes": "\"'`", "multiline_quotes": "`"},
+    "typescript": {"quotes": "\"'`", "multiline_quotes": "`"},
+    "rust": {"quotes": '"', "char_literals": True},
+    "kotlin": {"quotes": '"', "char_literals": True},
+    "scala": {"quotes": '"', "multiline_quotes": '"', "char_literals": True},
+    "swift": {"quotes": '"'},
+    "c": {"char_literals": True},
+    "cpp": {"char_literals": True},
+    "csharp": {"char_literals": True},
+    "php": {"line_comments": ("//", "#")},
+    "ruby": {"line_comments": ("#",), "block_comments": (("=begin", "=end"),)},
+    "python": {"line_comments": ("#",), "block_comments": ()},
+    "lua": {"line_comments": ("--",), "block_comments": (("--[[", "]]"), ("[[", "]]"))},
+    "shell": {"line_comments": ("#",), "block_comments": (), "multiline_quotes": "\"'"},
+    "elixir": {"line_comments": ("#",), "block_comments": ()},
+    "r": {"line_comments": ("#",), "block_comments": (), "multiline_quotes": "\"'"},
+    "haskell": {
+        "line_comments": ("--",),
+        "block_comments": (("{-", "-}"),),
+        "quotes": '"',
+        "char_literals": True,
+    },
+}
+_PYTHON_BRANCHES = (
+    ast.If,
+    ast.For,
+    ast.AsyncFor,
+    ast.While,
+    ast.ExceptHandler,
+    ast.IfExp,
+    ast.match_case,
+)
+
+
+def python_complexity(node: ast.FunctionDef | ast.AsyncFunctionDef) -> int:
+    """Return the complexity of a Python function, not counting nested functions."""
+    branches = 0
+    stack: list[ast.AST] = list(node.body)
+    while stack:
+        child = stack.pop()
+        if isinstance(child, ast.FunctionDef | ast.AsyncFunctionDef | ast.ClassDef | ast.Lambda):
+            continue
+        if isinstance(child, _PYTHON_BRANCHES):
+            branches += 1
+        elif isinstance(child, ast.BoolOp):
+            branches += len(child.values) - 1
+        elif isinstance(child, ast.comprehension):
+            branches += 1 + len(child.ifs)
+        stack.extend(ast.iter_child_nodes(child))
+    return 1 + branches
+
+
+def text_complexity(code: list[str], start: int, end: int, language: str) -> int:
+    """Return the complexity of lines `start`..`end` (1-based) of comment-free `code`."""
+    pattern = _BRANCHES.get(language, _C_BRANCHES)
+    return 1 + sum(len(pattern.findall(line)) for line in code[start - 1 : end])
+
+
+def apply_complexity(result: ParseResult, content: str, language: str) -> ParseResult:
+    """Fill in `complexity` for functions and methods whose parser did not set it.
+
+    Symbols without an `end_line` and abstract methods, which have no body, are left
+    at None.
+    """
+    code: list[str] | None = None
+
+    def measured(symbol: Any) -> Any:
+        nonlocal code
+        if symbol.complexity is not None or not symbol.end_line:
+            return symbol
+        if symbol.kind == "abstract_method":
+            return symbol
+        if code is None:
+            from .languages._scan import code_lines  # noqa: PLC0415 - avoids import cycle
+
+            code = code_lines(content, **_CODE_OPTIONS.get(language, {}))
+        complexity = text_complexity(code, symbol.line, symbol.end_line, language)
+        return replace(symbol, complexity=complexity)
+
+    return replace(
+        result,
+        functions=[measured(func) for func in result.functions],
+        classes=[
+            replace(cls, methods=[measured(method) for method in cls.methods])
+            for cls in result.classes
+        ],
+    )
+
+
+def complexity_level(complexity: int) -> str:
+    """Return `low`, `moderate` or `high` for a complexity value."""
+    for level, upper in COMPLEXITY_BANDS:
+        if complexity <= upper:
+            return level
+    return "high"
+
+
+def complexity_threshold(analysis_data: dict[str, Any]) -> int:
+    """Return `quality.complexity_threshold`, falling back to the default."""
+    config = analysis_data.get("config", {})
+    quality = config.get("quality", {}) if isinstance(config, dict) else {}
+    value = quality.get("complexity_threshold") if isinstance(quality, dict) else None
+    if isinstance(value, int) and not isinstance(value, bool) and value > 0:
+        return value
+    return DEFAULT_COMPLEXITY_THRESHOLD
+
+
+def complex_functions(analysis_data: dict[str, Any], threshold: int) -> list[dict[str, Any]]:
+    """Return functions and methods above `threshold`, most complex first."""
+    root = path_root(analysis_data)
+    found: dict[tuple[str, int], dict[str, Any]] = {}
+
+    def add(item: Any, name: str) -> None:
+        if not isinstance(item, dict) or not isinstance(item.get("complexity"), int):
+            return
+        if item["complexity"] <= threshold:
+            return
+        module = relative_path(root, str(item.get("file", "")))
+        line = int(item.get("line", 0) or 0)
+        # Methods that a parser also reports as functions keep their qualified name.
+        found[(module, line)] = {
+            "name": name,
+            "module": module,
+            "line": line,
+            "complexity": item["complexity"],
+        }
+
+    for func in analysis_data.get("functions", []):
+        if isinstance(func, dict):
+            add(func, str(func.get("name", "")))
+    for cls in analysis_data.get("classes", []):
+        if not isinstance(cls, dict):
+            continue
+        for method in cls.get("methods", []) or []:
+            if isinstance(method, dict):
+                add(method, f"{cls.get('name')}.{method.get('name')}")
+    return sorted(
+        found.values(), key=lambda item: (-item["complexity"], item["module"], item["line"])
+    )
+
+
+def complexity_warnings(analysis_data: dict[str, Any]) -> list[str]:
+    """Warn once when functions exceed `quality.complexity_threshold`, naming the worst."""
+    threshold = complexity_threshold(analysis_data)
+    hotspots = complex_functions(analysis_data, threshold)
+    if not hotspots:
+        return []
+    named = ", ".join(
+        f"`{item['name']}` ({item['module']}:{item['line']}, {item['complexity']})"
+        for item in hotspots[:_MAX_WARNED]
+    )
+    more = f" and {len(hotspots) - _MAX_WARNED} more" if len(hotspots) > _MAX_WARNED else ""
+    noun = "function exceeds" if len(hotspots) == 1 else "functions exceed"
+    return [f"{len(hotspots)} {noun} estimated complexity {threshold}: {named}{more}"]
+
@@ -5,25 +5,41 @@ from __future__ import annotations
 from pathlib import Path
 from typing import Any
 
+import toml
 import yaml
 
+from .feature_flags import DEFAULT_FLAG_PATTERNS
+from .readme_quality import DEFAULT_SCORE_WEIGHTS
+
 
 def load_config(root_path: Path) -> dict[str, Any]:
     """
-    Load configuration from .docgenie.yaml in the project root.
-    Returns a default configuration if the file doesn't exist.
+    Load configuration from .docgenie.yaml in the project root, then apply the
+    `[files]` and `[project]` sections of docgenie.toml on top of it.
+    Returns a default configuration if neither file exists.
     """
+    config = get_default_config()
     config_path = root_path / ".docgenie.yaml"
-    if not config_path.exists():
-        return get_default_config()
+    if config_path.exists():
+        try:
+            with open(config_path, encoding="utf-8") as f:
+                user_config = yaml.safe_load(f) or {}
+                config = merge_configs(config, user_config)
+        except (yaml.YAMLError, OSError):
+            # Return default config if loading fails
+            return get_default_config()
+    toml_config = _toml_sections(root_path / "docgenie.toml", ("files", "project"))
+    return merge_configs(config, toml_config)
+
 
+def _toml_sections(path: Path, names: tuple[str, ...]) -> dict[str, Any]:
+    if not path.exists():
+        return {}
     try:
-        with open(config_path, encoding="utf-8") as f:
-            user_config = yaml.safe_load(f) or {}
-            return merge_configs(get_default_config(), user_config)
-    except (yaml.YAMLError, OSError):
-        # Return default config if loading fails
-        return get_default_config()
+        data = toml.load(path)
+    except (OSError, ValueError):
+        return {}
+    return {name: data[name] for name in names if isinstance(data.get(name), dict)}
 
 
 def get_default_config() -> dict[str, Any]:
@@ -51,15 +67,29 @@ def get_default_config() -> dict[str, Any]:
             "generated_patterns": [],
             "engine": "hybrid_index",
             "incremental": True,
+            "cache_dir": ".docgenie",
             "parallelism": "auto",
+            "git_metadata": False,
             "hard_file_cap": 300000,
             "full_rescan_interval_runs": 20,
+            # `public` documents the exported API only; `all` includes private symbols.
+            "visibility": "public",
+            # Directory that paths in generated output are relative to; None means the
+            # analyzed directory, a relative value is resolved against it.
+            "path_root": None,
+        },
+        # Globs relative to the project root; see file_rules.FileRules for precedence.
+        "files": {
+            "include": [],
+            "exclude": [],
+            "gitignore": True,
         },
         "monorepo": {
             "mode": "auto",
             "root_doc": True,
             "per_package_docs": True,
             "package_output_dir": ".docgenie/packages",
+            "markers": ["go.mod", "package.json", "pyproject.toml"],
         },
         "safety": {
             "redaction_mode": "strict",
@@ -71,6 +101,22 @@ def get_default_config() -> dict[str, Any]:
             "max_functions_documented": 10,
             "template_profile": "pro",
             "include_trust_badges": True,
+            "include_module_index": True,
+            "collapse_modules": False,
+            "include_badges": True,
+            "graph_format": "none",
+            "graph_scope": "all",
+            "template_dir": None,
+            "theme_css": None,
+            # Render HTML as an embeddable fragment plus a separate docgenie.css.
+            "html_fragment": False,
+            "autolink": False,
+            "include_toc": True,
+            "toc_depth": 2,
+            "toc_min_headings": 4,
+            "max_callers": 10,
+            "sort_symbols": "alpha",
+            "group_by": "file",
         },
         "diff": {
             "enabled": True,
@@ -88,6 +134,79 @@ def get_default_config() -> dict[str, Any]:
             "languages": ["python", "javascript", "typescript", "shell"],
             "confidence_threshold": "low",
         },
+        "http_routes": {
+            "enabled": True,
+        },
+        "cli_interface": {
+            "enabled": True,
+        },
+        "env_vars": {
+            "enabled": True,
+        },
+        "coverage": {
+            "file": None,
+            "threshold": None,
+        },
+        "dead_code": {
+            "enabled": True,
+            "ignore": [],
+            "max_listed": 50,
+        },
+        # Needs the reference scan, which `dead_code.enabled: false` turns off.
+        "tested_symbols": {
+            "enabled": True,
+            "max_listed": 50,
+            "max_untested_ratio": 0.5,
+        },
+        "tech_debt": {
+            "enabled": False,
+            "markers": ["TODO", "FIXME"],
+            "warning_threshold": 25,
+        },
+        # Dotted lookup calls whose first argument names a flag; `*` matches any identifier.
+        "feature_flags": {
+            "enabled": True,
+            "patterns": list(DEFAULT_FLAG_PATTERNS),
+        },
+        # Import path prefix -> documentation URL, with optional `{path}` / `{name}`
+        # placeholders; extends the built-in pkg.go.dev / docs.rs / docs.python.org links.
+        "doc_links": {
+            "enabled": True,
+            "bases": {},
+        },
+        # `.sql` files under a migrations/ directory, applied in file name order.
+        "database_schema": {
+            "enabled": True,
+        },
+        # gRPC services, their RPCs and the messages they exchange, from `.proto` files.
+        "grpc_api": {
+            "enabled": True,
+        },
+        # `.PHONY` and `## documented` targets of Makefiles, for the Build/Tasks section.
+        "make_targets": {
+            "enabled": True,
+        },
+        # Architectural layer -> path glob(s), outermost layer first. Each layer may import
+        # the ones after it; imports of an earlier layer are reported as violations.
+        "layers": {},
+        # Project header shown instead of the detected name and generated description;
+        # links map a label to a URL. OVERVIEW.md in the root fills in unset keys.
+        # version is the current release; deprecated symbols due for removal in it or
+        # earlier are reported as errors.
+        "project": {
+            "name": None,
+            "tagline": None,
+            "description": None,
+            "links": {},
+            "version": None,
+        },
+        "llms": {
+            "max_tokens": None,
+        },
+        "plugins": {
+            "modules": [],
+            "entry_points": True,
+        },
         "quality": {
             "confidence_enabled": True,
             "include_warnings": True,
@@ -101,6 +220,11 @@ def get_default_config() -> dict[str, Any]:
                 "## License",
             ],
             "min_confidence": "medium",
+            "max_undocumented_listed": 50,
+            # Warn about functions whose estimated cyclomatic complexity is above this.
+            "complexity_threshold": 20,
+            # One source of truth, so CLI and library runs score alike.
+            "score_weights": dict(DEFAULT_SCORE_WEIGHTS),
         },
     }
 
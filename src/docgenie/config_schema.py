@@ -0,0 +1,207 @@
+"""JSON Schema for `.docgenie.yaml`, derived from the default configuration.
+
+Every key of `get_default_config()` is a property of the schema; its type is taken
+from the default value unless `_OVERRIDES` says otherwise (keys that default to
+None, fixed choices). `validate_config` checks a loaded config against the schema
+without a jsonschema dependency, supporting only the keywords the schema uses.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+from .config import get_default_config
+from .html_sections import GRAPH_SCOPES
+from .module_index import MODULE_GROUPINGS, SYMBOL_SORT_ORDERS, VISIBILITY_LEVELS
+from .readme_gate import CONFIDENCE_ORDER
+from .readme_quality import DEFAULT_SCORE_WEIGHTS
+
+SCHEMA_DIALECT = "https://json-schema.org/draft/2020-12/schema"
+
+_CONFIDENCE = {"enum": list(CONFIDENCE_ORDER)}
+_OPTIONAL_STRING = {"type": ["string", "null"]}
+
+_OVERRIDES: dict[str, dict[str, Any]] = {
+    "analysis.parallelism": {"anyOf": [{"const": "auto"}, {"type": "integer", "minimum": 1}]},
+    "analysis.visibility": {"enum": list(VISIBILITY_LEVELS)},
+    "analysis.path_root": _OPTIONAL_STRING,
+    "template_customizations.template_profile": {"enum": ["legacy", "pro"]},
+    "template_customizations.graph_format": {"enum": ["none", "mermaid", "plantuml"]},
+    "template_customizations.graph_scope": {"enum": list(GRAPH_SCOPES)},
+    "template_customizations.template_dir": _OPTIONAL_STRING,
+    "template_customizations.theme_css": _OPTIONAL_STRING,
+    "template_customizations.sort_symbols": {"enum": list(SYMBOL_SORT_ORDERS)},
+    "template_customizations.group_by": {"enum": list(MODULE_GROUPINGS)},
+    "diff.from_ref": _OPTIONAL_STRING,
+    "output_links.confidence_threshold": _CONFIDENCE,
+    "coverage.file": _OPTIONAL_STRING,
+    "coverage.threshold": {"type": ["number", "null"]},
+    "llms.max_tokens": {"type": ["integer", "null"], "minimum": 1},
+    "quality.min_confidence_for_api_docs": _CONFIDENCE,
+    "quality.min_confidence": _CONFIDENCE,
+    # Import path prefix -> URL template.
+    "doc_links.bases": {"type": "object", "additionalProperties": {"type": "string"}},
+    "project.name": _OPTIONAL_STRING,
+    "project.tagline": _OPTIONAL_STRING,
+    "project.description": _OPTIONAL_STRING,
+    "project.version": _OPTIONAL_STRING,
+    # Link label -> URL.
+    "project.links": {"type": "object", "additionalProperties": {"type": "string"}},
+    # Layer name -> one glob or a list of globs.
+    "layers": {
+        "type": "object",
+        "additionalProperties": {
+            "anyOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]
+        },
+    },
+}
+
+# Mappings of factor to weight: any non-negative number. The score factors come from the
+# scorer itself, since the defaults do not list every one of them.
+_WEIGHT_FACTORS: dict[str, tuple[str, ...]] = {
+    "quality.score_weights": tuple(DEFAULT_SCORE_WEIGHTS),
+    "review.risk_weights": (),
+}
+
+_JSON_TYPES: dict[str, tuple[type, ...]] = {
+    "object": (dict,),
+    "array": (list,),
+    "string": (str,),
+    "boolean": (bool,),
+    "integer": (int,),
+    "number": (int, float),
+    "null": (type(None),),
+}
+_TYPE_PHRASES = {
+    "object": "a mapping",
+    "array": "a list",
+    "string": "a string",
+    "boolean": "a boolean",
+    "integer": "an integer",
+    "number": "a number",
+    "null": "null",
+}
+
+
+def config_json_schema() -> dict[str, Any]:
+    """Return the JSON Schema (draft 2020-12) of the DocGenie config file."""
+    schema = _schema_for(get_default_config(), "")
+    return {
+        "$schema": SCHEMA_DIALECT,
+        "title": "DocGenie configuration",
+        "description": "Options read from .docgenie.yaml in the project root.",
+        **schema,
+    }
+
+
+def _schema_for(value: Any, path: str) -> dict[str, Any]:
+    if path in _OVERRIDES:
+        return dict(_OVERRIDES[path])
+    if path in _WEIGHT_FACTORS and isinstance(value, dict):
+        factors = dict.fromkeys([*value, *_WEIGHT_FACTORS[path]])
+        return {
+            "type": "object",
+            "properties": {factor: {"type": "number", "minimum": 0} for factor in factors},
+            "additionalProperties": False,
+        }
+    if isinstance(value, dict):
+        return {
+            "type": "object",
+            "properties": {
+                key: _schema_for(item, f"{path}.{key}" if path else key)
+                for key, item in value.items()
+            },
+            "additionalProperties": False,
+        }
+    if isinstance(value, list):
+        # Every list option is a list of strings (globs, markers, module names...).
+        return {"type": "array", "items": {"type": "string"}}
+    if isinstance(value, bool):
+        return {"type": "boolean"}
+    if isinstance(value, int):
+        return {"type": "integer"}
+    if isinstance(value, float):
+        return {"type": "number"}
+    return {"type": "string"}
+
+
+def validate_config(config: dict[str, Any], schema: dict[str, Any] | None = None) -> list[str]:
+    """Return one readable message per schema violation in `config` (empty if valid)."""
+    errors: list[str] = []
+    _validate(config, schema or config_json_schema(), "", errors)
+    return errors
+
+
+def _validate(value: Any, schema: dict[str, Any], path: str, errors: list[str]) -> None:
+    if "anyOf" in schema:
+        if not any(_matches(value, option) for option in schema["anyOf"]):
+            errors.append(f"`{path}` must be {_describe(schema)}, got {value!r}")
+        return
+    if "const" in schema and value != schema["const"]:
+        errors.append(f"`{path}` must be {schema['const']!r}, got {value!r}")
+        return
+    if "enum" in schema and value not in schema["enum"]:
+        choices = ", ".join(str(choice) for choice in schema["enum"])
+        errors.append(f"`{path}` must be one of {choices}, got {value!r}")
+        return
+    if "type" in schema and not _has_type(value, schema["type"]):
+        errors.append(f"`{path}` must be {_describe(schema)}, got {_type_name(value)}")
+        return
+    if "minimum" in schema and _is_number(value) and value < schema["minimum"]:
+        errors.append(f"`{path}` must be at least {schema['minimum']}, got {value!r}")
+    if isinstance(value, dict):
+        properties = schema.get("properties", {})
+        for key, item in value.items():
+            child = f"{path}.{key}" if path else str(key)
+            if key in properties:
+                _validate(item, properties[key], child, errors)
+            elif schema.get("additionalProperties") is False:
+                errors.append(f"Unknown key `{child}`")
+            elif isinstance(schema.get("additionalProperties"), dict):
+                _validate(item, schema["additionalProperties"], child, errors)
+    if isinstance(value, list) and "items" in schema:
+        for index, item in enumerate(value):
+            _validate(item, schema["items"], f"{path}[{index}]", errors)
+
+
+def _matches(value: Any, schema: dict[str, Any]) -> bool:
+    errors: list[str] = []
+    _validate(value, schema, "", errors)
+    return not errors
+
+
+def _has_type(value: Any, expected: str | list[str]) -> bool:
+    names = [expected] if isinstance(expected, str) else expected
+    for name in names:
+        # bool is an int subclass, but YAML `true` is not a valid count.
+        if isinstance(value, bool) and name in {"integer", "number"}:
+            continue
+        if isinstance(value, _JSON_TYPES[name]):
+            return True
+    return False
+
+
+def _is_number(value: Any) -> bool:
+    return isinstance(value, (int, float)) and not isinstance(value, bool)
+
+
+def _describe(schema: dict[str, Any]) -> str:
+    if "anyOf" in schema:
+        return " or ".join(_describe(option) for option in schema["anyOf"])
+    if "const" in schema:
+        return repr(schema["const"])
+    expected = schema.get("type", "any")
+    names = [expected] if isinstance(expected, str) else expected
+    if names == ["array"] and "items" in schema:
+        return f"a list of {schema['items'].get('type', 'value')}s"
+    described = " or ".join(_TYPE_PHRASES.get(name, name) for name in names)
+    if "minimum" in schema:
+        described += f" >= {schema['minimum']}"
+    return described
+
+
+def _type_name(value: Any) -> str:
+    for name in ("null", "boolean", "integer", "number", "string", "array", "object"):
+        if _has_type(value, name):
+            return {"array": "list", "object": "mapping"}.get(name, name)
+    return type(value).__name__
@@ -6,22 +6,47 @@ import hashlib
 import json
 import os
 import re
+import time
 from collections import Counter, defaultdict
-from collections.abc import Iterable
+from collections.abc import Callable, Iterable, Iterator
 from concurrent.futures import ProcessPoolExecutor, as_completed
 from contextlib import suppress
+from dataclasses import asdict
 from pathlib import Path
-from typing import Any
+from typing import Any, TextIO
 
 import toml
 from pathspec import PathSpec
 
+from . import __version__
+from .analyzer_plugins import load_analyzer_plugins, run_analyzer_plugins
+from .cli_flags import scan_cli_interface
+from .coverage import build_coverage
+from .dead_code import scan_symbol_references
 from .diff_engine import compute_git_diff_summary
+from .env_vars import scan_env_vars
+from .feature_flags import DEFAULT_FLAG_PATTERNS, scan_feature_flags
+from .exceptions import ConfigError
+from .external_calls import external_aliases, scan_external_calls
+from .file_rules import EXCLUDED_REASON, FileRules
+from .git_metadata import attach_git_metadata
+from .grpc_api import scan_grpc_services
 from .index_store import IndexStore
-from .models import AnalysisResult
+from .json_stream import write_json_stream
+from .languages.elixir import module_path as elixir_module_path
+from .languages.r import is_exported, namespace_exports
+from .licenses import detect_license
+from .make_targets import scan_make_targets
+from .migrations import scan_migrations
+from .models import AnalysisResult, PluginFile, RunMetrics
+from .module_index import DEFAULT_VISIBILITY, symbol_visibility
 from .output_links import scan_output_links
+from .overview import load_project_overview
 from .parsers import ParserRegistry
+from .reproducible import path_root, relative_paths, source_date_epoch
 from .review_engine import build_reviews
+from .routes import UNRECOGNIZED_ROUTE_REASON, scan_http_routes
+from .tech_debt import DEFAULT_DEBT_MARKERS, scan_debt_markers
 from .utils import (
     extract_git_info,
     get_file_language,
@@ -29,10 +54,24 @@ from .utils import (
     is_probably_generated_file,
     is_website_project,
     load_gitignore_spec,
+    posix_path,
     should_ignore_file,
 )
 
 
+# Candidate suffixes tried when resolving `./relative` imports to repository files.
+LOCAL_IMPORT_SUFFIXES: dict[str, tuple[str, ...]] = {
+    "typescript": (".ts", ".tsx", ".d.ts", ".js", ".jsx"),
+    "javascript": (".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx"),
+    "ruby": (".rb",),
+    "shell": (".sh", ".bash"),
+}
+
+
+def _symbol_order(record: dict[str, Any]) -> tuple[str, int, str]:
+    return (str(record.get("file", "")), int(record.get("line", 0)), str(record.get("name", "")))
+
+
 def _hash_file(path: Path) -> str:
     digest = hashlib.sha256()
     with open(path, "rb") as handle:
@@ -41,30 +80,59 @@ def _hash_file(path: Path) -> str:
     return digest.hexdigest()
 
 
+# Bump when parse results change shape or meaning so stale entries are re-parsed.
+INDEX_VERSION = 17
+
+
 class CacheManager:
-    """Simple file-based cache to support incremental analysis."""
+    """Persisted per-file index of content hashes and parse results for incremental analysis.
+
+    The index lives at `<cache_dir>/index.json` (default `<root>/.docgenie`). An index
+    written for a different root, format version, DocGenie release or `visibility` is
+    discarded on load, so upgrading DocGenie re-parses every file with the new parsers.
+    """
 
-    def __init__(self, root: Path):
+    def __init__(
+        self, root: Path, cache_dir: Path | None = None, *, visibility: str = DEFAULT_VISIBILITY
+    ):
         self.root = root
-        self.cache_dir = root / ".docgenie"
-        self.cache_dir.mkdir(exist_ok=True)
-        self.cache_file = self.cache_dir / "cache.json"
+        self.visibility = visibility
+        self.cache_dir = cache_dir or root / ".docgenie"
+        self.cache_file = self.cache_dir / "index.json"
         self._data: dict[str, dict[str, Any]] = {}
         self._load()
 
     def _load(self) -> None:
-        if self.cache_file.exists():
-            try:
-                self._data = json.loads(self.cache_file.read_text(encoding="utf-8"))
-            except (json.JSONDecodeError, OSError):
-                # Cache corrupted, start fresh
-                self._data = {}
+        if not self.cache_file.exists():
+            return
+        try:
+            payload = json.loads(self.cache_file.read_text(encoding="utf-8"))
+        except (json.JSONDecodeError, OSError):
+            # Index corrupted, start fresh
+            return
+        if (
+            isinstance(payload, dict)
+            and payload.get("version") == INDEX_VERSION
+            and payload.get("docgenie_version") == __version__
+            and payload.get("root") == str(self.root)
+            and payload.get("visibility") == self.visibility
+            and isinstance(payload.get("files"), dict)
+        ):
+            self._data = payload["files"]
 
     def persist(self) -> None:
-        self.cache_file.write_text(json.dumps(self._data, indent=2), encoding="utf-8")
+        payload = {
+            "version": INDEX_VERSION,
+            "docgenie_version": __version__,
+            "root": str(self.root),
+            "visibility": self.visibility,
+            "files": self._data,
+        }
+        self.cache_dir.mkdir(parents=True, exist_ok=True)
+        self.cache_file.write_text(json.dumps(payload, indent=2, sort_keys=True), encoding="utf-8")
 
     def get(self, path: Path, digest: str) -> dict[str, Any] | None:
-        record = self._data.get(str(path))
+        record = self._data.get(posix_path(path))
         if record and record.get("hash") == digest:
             return record.get("parse")
         return None
@@ -72,34 +140,75 @@ class CacheManager:
     def set(self, path: Path, digest: str, parse_result: dict[str, Any], language: str) -> None:
         parse_result = dict(parse_result)
         parse_result["language"] = language
-        self._data[str(path)] = {"hash": digest, "parse": parse_result}
+        self._data[posix_path(path)] = {"hash": digest, "parse": parse_result}
+
+    def prune(self, keep: Iterable[str]) -> list[str]:
+        """Drop entries for files not in `keep` (deleted or now ignored) and return them."""
+        live = set(keep)
+        removed = sorted(path for path in self._data if path not in live)
+        for path in removed:
+            del self._data[path]
+        return removed
+
+
+ParsedFile = tuple[str, str, dict[str, Any] | None, str, str]
+
 
+def _analyze_file_task(payload: tuple[Any, ...]) -> ParsedFile:
+    """Worker for concurrent file analysis.
 
-def _analyze_file_task(
-    payload: tuple[str, list[str], bool],
-) -> tuple[str, str, dict[str, Any] | None, str]:
-    """Worker for concurrent file analysis."""
-    file_path_str, ignore_patterns, enable_tree_sitter = payload
+    `payload` is `(path, ignore_patterns, enable_tree_sitter[, include_private])`.
+    Returns `(path, language, parse_result, hash, error)`. A file that cannot be
+    read or parsed yields `parse_result=None` and a skip reason in `error`
+    instead of raising, so one bad file does not take down the worker pool.
+    """
+    file_path_str, ignore_patterns, enable_tree_sitter = payload[:3]
+    include_private = len(payload) > 3 and bool(payload[3])  # noqa: PLR2004
     _ = ignore_patterns
     file_path = Path(file_path_str)
     language = get_file_language(file_path)
     if not language:
-        return file_path_str, "", None, ""
+        return file_path_str, "", None, "", ""
     try:
         with open(file_path, encoding="utf-8") as handle:
             content = handle.read()
-    except (UnicodeDecodeError, PermissionError):
-        return file_path_str, language, None, ""
+    except UnicodeDecodeError:
+        return file_path_str, language, None, "", "decode_error"
+    except OSError:
+        return file_path_str, language, None, "", "read_error"
+
+    try:
+        file_hash = _hash_file(file_path)
+        parser_registry = ParserRegistry(
+            enable_tree_sitter=enable_tree_sitter, include_private=include_private
+        )
+        parse_result = parser_registry.parse(content, file_path, language)
+    except Exception:  # one broken file must not abort the run
+        return file_path_str, language, None, "", "parse_error"
+    return file_path_str, language, parse_result.to_public_dict(), file_hash, ""
+
 
-    file_hash = _hash_file(file_path)
-    parser_registry = ParserRegistry(enable_tree_sitter=enable_tree_sitter)
-    parse_result = parser_registry.parse(content, file_path, language)
-    return file_path_str, language, parse_result.to_public_dict(), file_hash
+def _resolve_jobs(value: Any) -> int:
+    """Return the worker count for `analysis.parallelism` ("auto" or a positive integer)."""
+    if value in (None, "auto"):
+        return os.cpu_count() or 1
+    try:
+        jobs = int(value)
+    except (TypeError, ValueError):
+        return os.cpu_count() or 1
+    return max(jobs, 1)
 
 
 class CodebaseAnalyzer:
     """
     Analyzes a codebase to extract comprehensive information for documentation generation.
+
+    `progress`, if given, is called as `progress(processed, total)` each time a discovered
+    file has been parsed or read from the cache; `total` counts every discovered file.
+
+    With `json_stream` the public JSON document is written there as results are compiled,
+    functions and classes one module at a time with root-relative paths; the returned
+    result then has empty `functions` and `classes`.
     """
 
     def __init__(
@@ -108,13 +217,23 @@ class CodebaseAnalyzer:
         ignore_patterns: list[str] | None = None,
         enable_tree_sitter: bool = True,
         config: dict[str, Any] | None = None,
+        progress: Callable[[int, int], None] | None = None,
+        json_stream: TextIO | None = None,
     ):
         self.root_path = Path(root_path).resolve()
+        self.progress = progress
+        self.json_stream = json_stream
         self.ignore_patterns = ignore_patterns or []
         self.enable_tree_sitter = enable_tree_sitter
         self.config = config or {}
+        # Module and file names are relative to `analysis.path_root` (`--root`) when set.
+        self.path_root = path_root({"root_path": str(self.root_path), "config": self.config})
         analysis_config = self.config.get("analysis", {}) if isinstance(self.config, dict) else {}
-        self.use_gitignore = bool(analysis_config.get("use_gitignore", True))
+        files_config = self.config.get("files", {}) if isinstance(self.config, dict) else {}
+        self.file_rules = FileRules.from_config(files_config)
+        self.use_gitignore = bool(analysis_config.get("use_gitignore", True)) and (
+            not isinstance(files_config, dict) or bool(files_config.get("gitignore", True))
+        )
         self.exclude_generated = bool(analysis_config.get("exclude_generated", True))
         self.include_hidden = bool(analysis_config.get("include_hidden", False))
         max_size_raw = analysis_config.get("max_file_size_kb", 512)
@@ -127,19 +246,29 @@ class CodebaseAnalyzer:
         self.engine = str(analysis_config.get("engine", "hybrid_index"))
         self.incremental = bool(analysis_config.get("incremental", True))
         self.parallelism = analysis_config.get("parallelism", "auto")
+        self.jobs = _resolve_jobs(self.parallelism)
+        self.git_metadata = bool(analysis_config.get("git_metadata", False))
         self.hard_file_cap = int(analysis_config.get("hard_file_cap", 300000))
         self.full_rescan_interval_runs = int(analysis_config.get("full_rescan_interval_runs", 20))
+        self.visibility = symbol_visibility({"config": self.config})
         self.gitignore_spec: PathSpec | None = (
             load_gitignore_spec(self.root_path) if self.use_gitignore else None
         )
-        self.cache = CacheManager(self.root_path)
-        self.index_store = IndexStore(self.root_path)
+        cache_dir_raw = analysis_config.get("cache_dir")
+        cache_dir = Path(str(cache_dir_raw)) if cache_dir_raw else None
+        if cache_dir is not None and not cache_dir.is_absolute():
+            cache_dir = self.root_path / cache_dir
+        self.cache = CacheManager(self.root_path, cache_dir, visibility=self.visibility)
+        self.index_store = IndexStore(self.root_path, persistent=self.incremental)
         self.active_run_id: int | None = None
 
         self.files_analyzed = 0
         self.files_discovered = 0
+        self.files_processed = 0
+        self.files_total = 0
         self.skipped_reasons: Counter[str] = Counter()
         self.cache_hits = 0
+        self.run_metrics: RunMetrics = RunMetrics()
         self.languages: Counter[str] = Counter()
         self.dependencies: dict[str, Any] = {}
         self.project_structure: dict[str, Any] = {}
@@ -147,15 +276,32 @@ class CodebaseAnalyzer:
         self.classes: list[dict[str, Any]] = []
         self.imports: dict[str, set[str]] = defaultdict(set)
         self.file_imports: dict[str, set[str]] = defaultdict(set)
+        self.module_docs: dict[str, str] = {}
+        self.constants: dict[str, list[dict[str, Any]]] = {}
+        self.r_namespaces: dict[Path, tuple[set[str], list[re.Pattern[str]]]] = {}
         self.documentation_files: list[str] = []
         self.config_files: list[str] = []
         self.git_info: dict[str, Any] = {}
+        self.license: dict[str, str] = {}
+        self.project_overview: dict[str, Any] = {}
         self.is_website = False
         self.website_detection_reason = ""
         self.diff_summary: dict[str, Any] = {}
         self.file_reviews: list[dict[str, Any]] = []
         self.folder_reviews: list[dict[str, Any]] = []
         self.output_links: list[dict[str, Any]] = []
+        self.http_routes: list[dict[str, Any]] = []
+        self.symbol_references: dict[str, list[str]] = {}
+        self.external_calls: dict[str, list[str]] = {}
+        self.cli_interface: dict[str, Any] = {}
+        self.env_vars: list[dict[str, Any]] = []
+        self.tech_debt: list[dict[str, Any]] = []
+        self.feature_flags: list[dict[str, Any]] = []
+        self.database_schema: dict[str, Any] = {}
+        self.grpc_api: dict[str, Any] = {}
+        self.make_targets: list[dict[str, Any]] = []
+        self.parsed_files: list[PluginFile] = []
+        self.plugin_sections: list[dict[str, Any]] = []
         self.readme_readiness: dict[str, Any] = {}
 
     def _skip_reason(self, path: Path, *, is_dir: bool) -> str | None:
@@ -168,6 +314,8 @@ class CodebaseAnalyzer:
         reason: str | None = None
         if is_path_ignored_by_gitignore(rel, self.gitignore_spec, is_dir=is_dir):
             reason = "gitignore"
+        elif self.file_rules.excluded(rel, is_dir=is_dir):
+            reason = EXCLUDED_REASON
         elif should_ignore_file(rel, self.ignore_patterns or None):
             reason = "ignore_pattern"
         elif not self.include_hidden and any(
@@ -195,40 +343,92 @@ class CodebaseAnalyzer:
             return True
         return False
 
-    def analyze(self) -> dict[str, Any]:  # noqa: PLR0915
+    def analyze(self) -> dict[str, Any]:
         """Perform comprehensive analysis of the codebase."""
+        return self.analyze_result().to_public_dict()
+
+    def analyze_result(self) -> AnalysisResult:  # noqa: PLR0915
+        """Perform the analysis and return it as an `AnalysisResult`."""
+        started = time.perf_counter()
         self.active_run_id = self.index_store.start_run(mode="analyze")
         self.git_info = extract_git_info(self.root_path)
+        self.license = detect_license(self.root_path)
+        self.project_overview = load_project_overview(
+            self.root_path, self.config if isinstance(self.config, dict) else {}
+        )
         files = list(self._iter_source_files())
+        self.files_total = len(files)
+        self._advance_progress(0)
 
-        tasks: list[tuple[str, list[str], bool]] = []
+        tasks: list[tuple[str, list[str], bool, bool]] = []
         for file_path in files:
-            digest = _hash_file(file_path)
-            cached = self.cache.get(file_path, digest)
+            rel_file = Path(self._relative_file_path(file_path))
+            cached = self.cache.get(rel_file, _hash_file(file_path)) if self.incremental else None
             if cached:
+                self.cache_hits += 1
                 self._apply_parsed_data(cached, file_path, cached_language=cached.get("language"))
+                self._advance_progress()
                 continue
-            tasks.append((str(file_path), self.ignore_patterns, self.enable_tree_sitter))
-
-        if tasks:
-            with ProcessPoolExecutor() as executor:
-                futures = {
-                    executor.submit(_analyze_file_task, payload): payload[0] for payload in tasks
-                }
-                for future in as_completed(futures):
-                    file_path_str, language, parsed, file_hash = future.result()
-                    if not language or parsed is None:
-                        continue
-                    self._apply_parsed_data(parsed, Path(file_path_str), cached_language=language)
-                    self.cache.set(Path(file_path_str), file_hash, parsed, language)
+            tasks.append(
+                (
+                    str(file_path),
+                    self.ignore_patterns,
+                    self.enable_tree_sitter,
+                    self.visibility == "all",
+                )
+            )
+
+        for file_path_str, language, parsed, file_hash, error in self._parse_files(tasks):
+            if error:
+                self.skipped_reasons[error] += 1
+                continue
+            if not language or parsed is None:
+                continue
+            self._apply_parsed_data(parsed, Path(file_path_str), cached_language=language)
+            rel_path = Path(self._relative_file_path(Path(file_path_str)))
+            self.cache.set(rel_path, file_hash, parsed, language)
+        pruned = self.cache.prune(self._relative_file_path(file_path) for file_path in files)
 
         self._analyze_project_structure()
         self._detect_dependencies()
         self._run_diff_and_review()
         self._run_output_link_scan()
+        self._run_route_scan(files)
+        self._run_reference_scan(files)
+        self._run_cli_scan(files)
+        self._run_env_var_scan(files)
+        self._run_debt_scan(files)
+        self._run_flag_scan(files)
+        self._run_migration_scan(files)
+        self._run_grpc_scan(files)
+        self._run_make_scan(files)
+        self._run_analyzer_plugins()
+        if self.git_metadata:
+            self._attach_git_metadata()
+        coverage = self._run_coverage_scan(files)
+        self.run_metrics = RunMetrics(
+            scanned_files=len(files),
+            changed_files=len(tasks),
+            skipped_files=max(self.files_discovered - len(files), 0),
+            # A reproducible build (SOURCE_DATE_EPOCH) must not depend on how long it took.
+            duration_sec=0.0
+            if source_date_epoch()
+            else round(time.perf_counter() - started, 3),
+            cache_hit_ratio=round(self.cache_hits / len(files), 3) if files else 0.0,
+            skip_reasons=dict(sorted(self.skipped_reasons.items())),
+            cache_hits=self.cache_hits,
+            pruned_files=len(pruned),
+            coverage=coverage,
+        )
+        self.is_website = is_website_project(
+            {
+                "project_structure": self.project_structure,
+                "dependencies": self.dependencies,
+                "languages": self.languages,
+            }
+        )
+        self.website_detection_reason = "Heuristic detection based on project assets"
         compiled = self._compile_results()
-        compiled.is_website = is_website_project(compiled.to_public_dict())
-        compiled.website_detection_reason = "Heuristic detection based on project assets"
         if self.active_run_id is not None:
             self.index_store.finish_run(
                 self.active_run_id,
@@ -236,6 +436,7 @@ class CodebaseAnalyzer:
                     "files_analyzed": self.files_analyzed,
                     "diff_available": bool(self.diff_summary.get("available")),
                     "output_links": len(self.output_links),
+                    **asdict(self.run_metrics),
                 },
             )
             if self.diff_summary:
@@ -250,8 +451,10 @@ class CodebaseAnalyzer:
             if self.output_links:
                 self.index_store.replace_output_links(self.active_run_id, self.output_links)
             self.index_store.commit()
-        self.cache.persist()
-        return compiled.to_public_dict()
+        # A full run (`--no-incremental`) leaves no `.docgenie` directory behind.
+        if self.incremental:
+            self.cache.persist()
+        return compiled
 
     def __del__(self) -> None:
         with suppress(Exception):
@@ -294,25 +497,270 @@ class CodebaseAnalyzer:
             languages=languages if isinstance(languages, list) else None,
         )
 
+    def _parse_files(self, tasks: list[tuple[str, list[str], bool, bool]]) -> list[ParsedFile]:
+        """Parse `tasks` with up to `self.jobs` workers and return results in task order.
+
+        Results are merged in discovery order rather than completion order so the
+        analysis (and every document built from it) is identical for any job count.
+        """
+        if self.jobs == 1 or len(tasks) <= 1:
+            parsed: list[ParsedFile] = []
+            for payload in tasks:
+                parsed.append(_analyze_file_task(payload))
+                self._advance_progress()
+            return parsed
+        results: dict[str, ParsedFile] = {}
+        with ProcessPoolExecutor(max_workers=min(self.jobs, len(tasks))) as executor:
+            futures = {
+                executor.submit(_analyze_file_task, payload): payload[0] for payload in tasks
+            }
+            for future in as_completed(futures):
+                file_path_str = futures[future]
+                try:
+                    results[file_path_str] = future.result()
+                except Exception:  # e.g. a worker process killed mid-file
+                    results[file_path_str] = (file_path_str, "", None, "", "worker_error")
+                self._advance_progress()
+        return [results[payload[0]] for payload in tasks]
+
+    def _advance_progress(self, count: int = 1) -> None:
+        self.files_processed += count
+        if self.progress is not None:
+            self.progress(self.files_processed, self.files_total)
+
+    def _attach_git_metadata(self) -> None:
+        # Symbol dicts are shared with cache entries; copy them so blame data is not
+        # persisted into the per-file index.
+        self.functions = [dict(item) for item in self.functions]
+        self.classes = [
+            {**item, "methods": [dict(method) for method in item.get("methods", [])]}
+            for item in self.classes
+        ]
+        methods = [method for item in self.classes for method in item["methods"]]
+        # Markers are blamed through throwaway entries that carry an absolute path.
+        debt_lines = [
+            {"file": str(self.path_root / item["file"]), "line": item["line"]}
+            for item in self.tech_debt
+        ]
+        attach_git_metadata(
+            self.root_path,
+            self.functions + self.classes + methods + debt_lines,
+            store=self.index_store,
+        )
+        for item, stamp in zip(self.tech_debt, debt_lines, strict=True):
+            if "last_modified" in stamp:
+                item["author"] = stamp["last_modified"]["author"]
+
+    def _run_route_scan(self, files: list[Path]) -> None:
+        route_config = self.config.get("http_routes", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(route_config, dict) or not route_config.get("enabled", True):
+            return
+        self.http_routes, unrecognized = scan_http_routes(self.path_root, files)
+        # Router calls whose path is built at runtime are counted, not guessed at.
+        if unrecognized:
+            self.skipped_reasons[UNRECOGNIZED_ROUTE_REASON] += len(unrecognized)
+
+    def _run_cli_scan(self, files: list[Path]) -> None:
+        cli_config = self.config.get("cli_interface", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(cli_config, dict) or not cli_config.get("enabled", True):
+            return
+        self.cli_interface = scan_cli_interface(self.root_path, files, names_root=self.path_root)
+
+    def _run_env_var_scan(self, files: list[Path]) -> None:
+        env_config = self.config.get("env_vars", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(env_config, dict) or not env_config.get("enabled", True):
+            return
+        self.env_vars = scan_env_vars(self.path_root, files)
+
+    def _run_debt_scan(self, files: list[Path]) -> None:
+        debt_config = self.config.get("tech_debt", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(debt_config, dict) or not debt_config.get("enabled", False):
+            return
+        markers = [str(marker) for marker in debt_config.get("markers") or DEFAULT_DEBT_MARKERS]
+        self.tech_debt = scan_debt_markers(self.path_root, files, markers)
+
+    def _run_flag_scan(self, files: list[Path]) -> None:
+        flag_config = self.config.get("feature_flags", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(flag_config, dict) or not flag_config.get("enabled", True):
+            return
+        configured = flag_config.get("patterns") or DEFAULT_FLAG_PATTERNS
+        patterns = [str(pattern) for pattern in configured]
+        self.feature_flags = scan_feature_flags(self.path_root, files, patterns)
+
+    def _run_migration_scan(self, files: list[Path]) -> None:
+        schema_config = (
+            self.config.get("database_schema", {}) if isinstance(self.config, dict) else {}
+        )
+        if not isinstance(schema_config, dict) or not schema_config.get("enabled", True):
+            return
+        schema = scan_migrations(self.path_root, files)
+        self.database_schema = schema if schema["migrations"] else {}
+
+    def _run_grpc_scan(self, files: list[Path]) -> None:
+        grpc_config = self.config.get("grpc_api", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(grpc_config, dict) or not grpc_config.get("enabled", True):
+            return
+        self.grpc_api = scan_grpc_services(self.path_root, files)
+
+    def _run_make_scan(self, files: list[Path]) -> None:
+        make_config = self.config.get("make_targets", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(make_config, dict) or not make_config.get("enabled", True):
+            return
+        self.make_targets = scan_make_targets(self.path_root, files)
+
+    def _run_analyzer_plugins(self) -> None:
+        plugin_config = self.config.get("plugins", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(plugin_config, dict):
+            return
+        modules = [str(spec) for spec in plugin_config.get("modules") or [] if str(spec).strip()]
+        plugins, failed = load_analyzer_plugins(
+            modules, entry_points=bool(plugin_config.get("entry_points", True))
+        )
+        if failed:
+            self.skipped_reasons["plugin_load_error"] += failed
+        if not plugins:
+            return
+        files = sorted(self.parsed_files, key=lambda parsed: parsed.path)
+        sections, errors = run_analyzer_plugins(plugins, self.root_path, files)
+        self.skipped_reasons.update(errors)
+        self.plugin_sections = [section.to_public_dict() for section in sections]
+
+    def _run_coverage_scan(self, files: list[Path]) -> dict[str, Any]:
+        coverage_config = self.config.get("coverage", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(coverage_config, dict) or not coverage_config.get("file"):
+            return {}
+        report = Path(str(coverage_config["file"]))
+        if not report.is_absolute():
+            report = self.root_path / report
+        threshold = coverage_config.get("threshold")
+        try:
+            return build_coverage(
+                report,
+                (self._relative_file_path(path) for path in files),
+                threshold=float(threshold) if threshold is not None else None,
+            )
+        except (ConfigError, TypeError, ValueError):
+            # A missing or malformed report must not abort documentation generation.
+            self.skipped_reasons["coverage_report_error"] += 1
+            return {}
+
+    def _run_reference_scan(self, files: list[Path]) -> None:
+        aliases = external_aliases(self.file_imports)
+        self.external_calls = scan_external_calls(self.path_root, files, aliases)
+        dead_config = self.config.get("dead_code", {}) if isinstance(self.config, dict) else {}
+        if not isinstance(dead_config, dict) or not dead_config.get("enabled", True):
+            return
+        names = {
+            str(item["name"])
+            for item in self.functions + self.classes
+            if item.get("name") and not str(item["name"]).startswith("_")
+        }
+        self.symbol_references = scan_symbol_references(self.path_root, files, names, aliases)
+
     def _apply_parsed_data(
         self, parsed: dict[str, Any], file_path: Path, cached_language: str | None
     ) -> None:
         language = (
             cached_language or parsed.get("language") or get_file_language(file_path) or "unknown"
         )
+        if language == "r":
+            parsed = self._apply_r_namespace(parsed, file_path)
         self.files_analyzed += 1
         self.languages[language] += 1
         self.functions.extend(parsed.get("functions", []))
         self.classes.extend(parsed.get("classes", []))
+        self.skipped_reasons.update(parsed.get("skipped", []))
+        rel_file = self._relative_file_path(file_path)
+        self.parsed_files.append(
+            PluginFile(
+                path=rel_file,
+                language=language,
+                functions=list(parsed.get("functions", [])),
+                classes=list(parsed.get("classes", [])),
+                imports=sorted(str(imp) for imp in parsed.get("imports", [])),
+            )
+        )
+        if parsed.get("module_doc"):
+            self.module_docs[rel_file] = str(parsed["module_doc"])
+        if parsed.get("constants"):
+            self.constants[rel_file] = list(parsed["constants"])
+        # Register every analyzed file so resolved local imports have a target entry.
+        file_imports = self.file_imports[rel_file]
         for imp in parsed.get("imports", []):
             self.imports[language].add(imp)
-            rel_file = self._relative_file_path(file_path)
-            if rel_file:
-                self.file_imports[rel_file].add(str(imp))
+            file_imports.add(self._resolve_local_import(file_path, imp, language))
+
+    def _resolve_local_import(self, file_path: Path, spec: str, language: str) -> str:
+        """Map a relative JS/TS, Ruby `require_relative` or shell `source` import to its file."""
+        suffixes = LOCAL_IMPORT_SUFFIXES.get(language)
+        spec = str(spec)
+        if language == "elixir":
+            return self._resolve_elixir_module(file_path, spec)
+        if not suffixes or not spec.startswith(("./", "../")):
+            return spec
+        base = file_path.parent / spec
+        candidates = [base]
+        if base.suffix in (".js", ".jsx", ".mjs", ".cjs"):
+            candidates.extend(base.with_suffix(suffix) for suffix in suffixes)
+        candidates.extend(base.with_name(base.name + suffix) for suffix in suffixes)
+        candidates.extend(base / f"index{suffix}" for suffix in suffixes)
+        for candidate in candidates:
+            if candidate.is_file():
+                return self._relative_file_path(candidate)
+        return spec
+
+    def _resolve_elixir_module(self, file_path: Path, spec: str) -> str:
+        """Map an Elixir module to its file under the `lib/` of the enclosing Mix project."""
+        relative = elixir_module_path(spec)
+        for parent in file_path.resolve().parents:
+            candidate = parent / "lib" / relative
+            if candidate.is_file():
+                return self._relative_file_path(candidate)
+            if parent == self.root_path:
+                break
+        return spec
+
+    def _apply_r_namespace(self, parsed: dict[str, Any], file_path: Path) -> dict[str, Any]:
+        """Make the exports of the enclosing R package's NAMESPACE its public API.
+
+        Functions, generics and S4 classes it does not export are marked private and
+        left out unless `visibility` is `all`. Scripts outside a package are unchanged.
+        The cached parse result is not modified, so NAMESPACE edits apply on every run.
+        """
+        exports = self._r_namespace(file_path)
+        if exports is None:
+            return parsed
+
+        def mark(items: list[dict[str, Any]]) -> list[dict[str, Any]]:
+            marked = [
+                {**item, "private": not is_exported(str(item.get("name", "")), exports)}
+                for item in items
+            ]
+            return [item for item in marked if self.visibility == "all" or not item["private"]]
+
+        return {
+            **parsed,
+            "functions": mark(parsed.get("functions", [])),
+            "classes": mark(parsed.get("classes", [])),
+        }
+
+    def _r_namespace(self, file_path: Path) -> tuple[set[str], list[re.Pattern[str]]] | None:
+        """Return the exports of the nearest NAMESPACE file above `file_path`, if any."""
+        for parent in file_path.resolve().parents:
+            namespace = parent / "NAMESPACE"
+            if namespace in self.r_namespaces:
+                return self.r_namespaces[namespace]
+            if namespace.is_file():
+                text = namespace.read_text(encoding="utf-8", errors="replace")
+                self.r_namespaces[namespace] = namespace_exports(text)
+                return self.r_namespaces[namespace]
+            if parent == self.root_path:
+                break
+        return None
 
     def _relative_file_path(self, file_path: Path) -> str:
         try:
-            return file_path.resolve().relative_to(self.root_path).as_posix()
+            return file_path.resolve().relative_to(self.path_root).as_posix()
         except ValueError:
             return file_path.as_posix()
 
@@ -328,14 +776,15 @@ class CodebaseAnalyzer:
                 yield file_path
 
     def _analyze_project_structure(self) -> None:
+        # Skips were already counted while discovering source files; don't count them twice.
         structure: dict[str, Any] = {}
         for root, dirs, files in os.walk(self.root_path):
             root_path = Path(root)
-            dirs[:] = [d for d in dirs if not self._should_skip_path(root_path / d, is_dir=True)]
+            dirs[:] = [d for d in dirs if self._skip_reason(root_path / d, is_dir=True) is None]
             rel_path = os.path.relpath(root, self.root_path)
             entry = {
                 "files": [
-                    f for f in files if not self._should_skip_path(root_path / f, is_dir=False)
+                    f for f in files if self._skip_reason(root_path / f, is_dir=False) is None
                 ],
                 "dirs": dirs,
             }
@@ -451,16 +900,11 @@ class CodebaseAnalyzer:
 
     def _compile_results(self) -> AnalysisResult:
         sorted_languages = dict(sorted(self.languages.items(), key=lambda kv: (-kv[1], kv[0])))
-        sorted_functions = sorted(
-            self.functions,
-            key=lambda f: (str(f.get("file", "")), int(f.get("line", 0)), str(f.get("name", ""))),
-        )
-        sorted_classes = sorted(
-            self.classes,
-            key=lambda c: (str(c.get("file", "")), int(c.get("line", 0)), str(c.get("name", ""))),
-        )
-        return AnalysisResult(
-            project_name=self.root_path.name,
+        streaming = self.json_stream is not None
+        sorted_functions = [] if streaming else sorted(self.functions, key=_symbol_order)
+        sorted_classes = [] if streaming else sorted(self.classes, key=_symbol_order)
+        result = AnalysisResult(
+            project_name=self.project_overview.get("name") or self.root_path.name,
             files_analyzed=self.files_analyzed,
             languages=sorted_languages,
             dependencies=self.dependencies,
@@ -469,6 +913,8 @@ class CodebaseAnalyzer:
             classes=sorted_classes,
             imports={lang: sorted(imps) for lang, imps in self.imports.items()},
             file_imports={path: sorted(imps) for path, imps in self.file_imports.items()},
+            module_docs=dict(sorted(self.module_docs.items())),
+            constants=dict(sorted(self.constants.items())),
             documentation_files=self.documentation_files,
             config_files=self.config_files,
             git_info=self.git_info,
@@ -480,5 +926,41 @@ class CodebaseAnalyzer:
             folder_reviews=self.folder_reviews,
             file_reviews=self.file_reviews,
             output_links=self.output_links,
+            http_routes=self.http_routes,
+            symbol_references=self.symbol_references,
+            external_calls=self.external_calls,
+            cli_interface=self.cli_interface,
+            env_vars=self.env_vars,
+            tech_debt=self.tech_debt,
+            feature_flags=self.feature_flags,
+            database_schema=self.database_schema,
+            grpc_api=self.grpc_api,
+            make_targets=self.make_targets,
+            plugin_sections=self.plugin_sections,
+            license=self.license,
+            project_overview=self.project_overview,
             readme_readiness=self.readme_readiness,
+            skipped_reasons=dict(sorted(self.skipped_reasons.items())),
+            run_metrics=asdict(self.run_metrics),
         )
+        if self.json_stream is not None:
+            write_json_stream(
+                relative_paths(result.to_public_dict(), self.path_root),
+                self.json_stream,
+                streams={
+                    "functions": self._module_batches(self.functions),
+                    "classes": self._module_batches(self.classes),
+                },
+            )
+        return result
+
+    def _module_batches(self, records: list[dict[str, Any]]) -> Iterator[list[dict[str, Any]]]:
+        """Yield `records` in output order one module at a time, removing each as it goes."""
+        records.sort(key=_symbol_order)
+        records.reverse()
+        while records:
+            module = records[-1].get("file")
+            batch = []
+            while records and records[-1].get("file") == module:
+                batch.append(records.pop())
+            yield relative_paths(batch, self.path_root)
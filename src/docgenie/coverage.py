@@ -0,0 +1,199 @@
+"""Read test coverage reports and attribute statement coverage to analyzed modules."""
+
+from __future__ import annotations
+
+import re
+import xml.etree.ElementTree as ET  # noqa: N817  # nosec B405
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+from urllib.parse import quote
+
+from .exceptions import ConfigError
+
+# `path/to/file.go:12.34,15.2 3 1` -> file, block range, statement count, hit count.
+_GO_BLOCK_RE = re.compile(
+    r"^(?P<file>.+):(?P<block>\d+\.\d+,\d+\.\d+)\s+(?P<stmts>\d+)\s+(?P<hits>\d+)$"
+)
+
+
+def load_coverage_report(path: Path) -> tuple[str, dict[str, tuple[int, int]]]:
+    """Parse a Go `coverage.out` or Cobertura `coverage.xml` file.
+
+    Returns the detected format and `{reported path: (covered, total)}` statement
+    counts. Raises ConfigError when the file is unreadable or in neither format.
+    """
+    try:
+        text = path.read_text(encoding="utf-8")
+    except (OSError, UnicodeDecodeError) as exc:
+        raise ConfigError(f"Cannot read coverage report: {exc}", path) from exc
+    if text.lstrip().startswith("mode:"):
+        return "go", parse_go_coverage(text)
+    if text.lstrip().startswith("<"):
+        return "cobertura", parse_cobertura(text, path)
+    raise ConfigError(
+        "Unrecognized coverage report (expected Go coverprofile or Cobertura XML)", path
+    )
+
+
+def parse_go_coverage(text: str) -> dict[str, tuple[int, int]]:
+    """Sum statements per file from a Go coverprofile.
+
+    Profiles merged from several test binaries repeat blocks; each block counts once
+    and is covered when any run hit it.
+    """
+    blocks: dict[tuple[str, str], tuple[int, bool]] = {}
+    for line in text.splitlines()[1:]:
+        match = _GO_BLOCK_RE.match(line.strip())
+        if match is None:
+            continue
+        key = (match.group("file"), match.group("block"))
+        hit = int(match.group("hits")) > 0 or blocks.get(key, (0, False))[1]
+        blocks[key] = (int(match.group("stmts")), hit)
+    return _tally((file, stmts, hit) for (file, _), (stmts, hit) in blocks.items())
+
+
+def parse_cobertura(text: str, path: Path | None = None) -> dict[str, tuple[int, int]]:
+    """Count covered lines per file from a Cobertura report (coverage.py, JaCoCo, Istanbul)."""
+    try:
+        # Coverage reports are produced locally by the project's own test run.
+        root = ET.fromstring(text)  # noqa: S314  # nosec B314
+    except ET.ParseError as exc:
+        raise ConfigError(f"Invalid Cobertura XML: {exc}", path) from exc
+    sources = [str(node.text).strip() for node in root.iter("source") if node.text]
+    lines: dict[tuple[str, str], bool] = {}
+    for cls in root.iter("class"):
+        filename = cls.get("filename")
+        if not filename:
+            continue
+        filename = _strip_source(filename, sources)
+        for line in cls.iter("line"):
+            key = (filename, line.get("number", ""))
+            lines[key] = lines.get(key, False) or int(line.get("hits", "0") or 0) > 0
+    return _tally((file, 1, hit) for (file, _), hit in lines.items())
+
+
+def build_coverage(
+    report: Path,
+    files: Iterable[str],
+    *,
+    threshold: float | None = None,
+) -> dict[str, Any]:
+    """Attribute a coverage report to repository-relative `files`.
+
+    Reported paths are matched by path suffix, so Go import paths and Cobertura
+    source-relative names both resolve. Paths matching no analyzed file, or more
+    than one, are counted in `unmatched`.
+    """
+    fmt, raw = load_coverage_report(report)
+    known = sorted(set(files))
+    modules: dict[str, list[int]] = {}
+    unmatched = 0
+    for reported, (covered, total) in raw.items():
+        module = _match_module(reported, known)
+        if module is None:
+            unmatched += 1
+            continue
+        counts = modules.setdefault(module, [0, 0])
+        counts[0] += covered
+        counts[1] += total
+    covered_total = sum(counts[0] for counts in modules.values())
+    statements = sum(counts[1] for counts in modules.values())
+    return {
+        "report": report.as_posix(),
+        "format": fmt,
+        "percent": _percent(covered_total, statements),
+        "covered": covered_total,
+        "total": statements,
+        "threshold": threshold,
+        "unmatched": unmatched,
+        "modules": {
+            path: {"covered": covered, "total": total, "percent": _percent(covered, total)}
+            for path, (covered, total) in sorted(modules.items())
+        },
+    }
+
+
+def coverage_warnings(coverage: Any) -> list[str]:
+    """Return one warning per module whose coverage is below the configured threshold."""
+    if not isinstance(coverage, dict) or coverage.get("threshold") is None:
+        return []
+    threshold = float(coverage["threshold"])
+    return [
+        f"Test coverage for `{path}` is {stats['percent']}%, "
+        f"below the {threshold:g}% threshold."
+        for path, stats in (coverage.get("modules") or {}).items()
+        if float(stats.get("percent", 0.0)) < threshold
+    ]
+
+
+def coverage_badge(coverage: Any) -> dict[str, str] | None:
+    """Describe a shields.io coverage badge, colored against the threshold when one is set."""
+    if not isinstance(coverage, dict) or not coverage.get("total"):
+        return None
+    percent = float(coverage.get("percent", 0.0))
+    threshold = coverage.get("threshold")
+    if threshold is not None:
+        color = "brightgreen" if percent >= float(threshold) else "red"
+    elif percent >= 80:  # noqa: PLR2004
+        color = "brightgreen"
+    elif percent >= 60:  # noqa: PLR2004
+        color = "yellow"
+    else:
+        color = "red"
+    label = f"{percent:g}%"
+    return {
+        "label": label,
+        "url": f"https://img.shields.io/badge/coverage-{quote(label)}-{color}",
+    }
+
+
+def coverage_table(coverage: Any) -> list[dict[str, Any]]:
+    """Return per-module coverage rows for the README, lowest coverage first."""
+    if not isinstance(coverage, dict):
+        return []
+    threshold = coverage.get("threshold")
+    rows = [
+        {
+            "path": path,
+            "percent": stats["percent"],
+            "covered": stats["covered"],
+            "total": stats["total"],
+            "below": threshold is not None and float(stats["percent"]) < float(threshold),
+        }
+        for path, stats in (coverage.get("modules") or {}).items()
+    ]
+    return sorted(rows, key=lambda row: (row["percent"], row["path"]))
+
+
+def _match_module(reported: str, known: list[str]) -> str | None:
+    reported = reported.replace("\\", "/").removeprefix("./")
+    if reported in known:
+        return reported
+    candidates = [
+        path
+        for path in known
+        if reported.endswith("/" + path) or path.endswith("/" + reported)
+    ]
+    return candidates[0] if len(candidates) == 1 else None
+
+
+def _strip_source(filename: str, sources: list[str]) -> str:
+    normalized = filename.replace("\\", "/")
+    for source in sources:
+        prefix = source.replace("\\", "/").rstrip("/") + "/"
+        if normalized.startswith(prefix):
+            return normalized[len(prefix) :]
+    return normalized
+
+
+def _tally(entries: Iterable[tuple[str, int, bool]]) -> dict[str, tuple[int, int]]:
+    totals: dict[str, tuple[int, int]] = {}
+    for file, stmts, hit in entries:
+        covered, total = totals.get(file, (0, 0))
+        totals[file] = (covered + (stmts if hit else 0), total + stmts)
+    return totals
+
+
+def _percent(covered: int, total: int) -> float:
+    return round(covered * 100 / total, 1) if total else 0.0
@@ -0,0 +1,114 @@
+"""Find circular dependencies among impact-graph nodes."""
+
+from __future__ import annotations
+
+from collections import deque
+from collections.abc import Iterable
+from typing import Any
+
+# Edge kinds that mean "source depends on target"; `defines`/`references` only point at symbols.
+DEPENDENCY_EDGE_KINDS = frozenset({"import", "extends"})
+MAX_REPORTED_CYCLES = 20
+
+
+def find_cycles(edges: Iterable[dict[str, Any]]) -> list[dict[str, list[str]]]:
+    """Return every strongly connected component with more than one node.
+
+    Each entry has `members` (sorted node IDs) and `path`, the shortest cycle from
+    the first member back to itself, so a two-node cycle reads `[a, b, a]`.
+    Components are ordered largest first, then by their first member.
+    """
+    graph: dict[str, set[str]] = {}
+    for edge in edges:
+        if edge.get("kind") not in DEPENDENCY_EDGE_KINDS:
+            continue
+        source, target = str(edge.get("source", "")), str(edge.get("target", ""))
+        if source and target and source != target:
+            graph.setdefault(source, set()).add(target)
+            graph.setdefault(target, set())
+
+    cycles = [
+        {"members": members, "path": _shortest_cycle(graph, members)}
+        for members in (sorted(component) for component in _components(graph))
+        if len(members) > 1
+    ]
+    cycles.sort(key=lambda cycle: (-len(cycle["members"]), cycle["members"]))
+    return cycles
+
+
+def mark_cycle_edges(edges: Iterable[dict[str, Any]], cycles: list[dict[str, list[str]]]) -> None:
+    """Set `cycle: True` on dependency edges whose ends sit in the same cycle."""
+    component = {member: idx for idx, cycle in enumerate(cycles) for member in cycle["members"]}
+    for edge in edges:
+        if edge.get("kind") not in DEPENDENCY_EDGE_KINDS:
+            continue
+        source = component.get(str(edge.get("source", "")))
+        if source is not None and source == component.get(str(edge.get("target", ""))):
+            edge["cycle"] = True
+
+
+def _components(graph: dict[str, set[str]]) -> list[list[str]]:
+    """Tarjan's algorithm, iterative so deep import chains cannot hit the recursion limit."""
+    index: dict[str, int] = {}
+    lowlink: dict[str, int] = {}
+    stack: list[str] = []
+    on_stack: set[str] = set()
+    components: list[list[str]] = []
+
+    for root in sorted(graph):
+        if root in index:
+            continue
+        work: list[tuple[str, Iterable[str]]] = [(root, iter(sorted(graph[root])))]
+        index[root] = lowlink[root] = len(index)
+        stack.append(root)
+        on_stack.add(root)
+        while work:
+            node, successors = work[-1]
+            advanced = False
+            for succ in successors:
+                if succ not in index:
+                    index[succ] = lowlink[succ] = len(index)
+                    stack.append(succ)
+                    on_stack.add(succ)
+                    work.append((succ, iter(sorted(graph[succ]))))
+                    advanced = True
+                    break
+                if succ in on_stack:
+                    lowlink[node] = min(lowlink[node], index[succ])
+            if advanced:
+                continue
+            work.pop()
+            if work:
+                parent = work[-1][0]
+                lowlink[parent] = min(lowlink[parent], lowlink[node])
+            if lowlink[node] == index[node]:
+                component: list[str] = []
+                while True:
+                    member = stack.pop()
+                    on_stack.discard(member)
+                    component.append(member)
+                    if member == node:
+                        break
+                components.append(component)
+    return components
+
+
+def _shortest_cycle(graph: dict[str, set[str]], members: list[str]) -> list[str]:
+    start = members[0]
+    allowed = set(members)
+    previous: dict[str, str] = {}
+    queue = deque([start])
+    while queue:
+        node = queue.popleft()
+        for succ in sorted(graph[node]):
+            if succ not in allowed:
+                continue
+            if succ == start:
+                path = [node]
+                while path[-1] != start:
+                    path.append(previous[path[-1]])
+                return [start, *reversed(path[:-1]), start]
+            if succ not in previous:
+                previous[succ] = node
+                queue.append(succ)
+    return [*members, start]
@@ -0,0 +1,122 @@
+"""Flag exported symbols that no other file in the repository references."""
+
+from __future__ import annotations
+
+import re
+import sys
+from collections.abc import Iterable, Mapping
+from fnmatch import fnmatch
+from pathlib import Path
+from typing import Any
+
+from .external_calls import qualified_call_re
+from .html_sections import build_impact_graph_data, symbol_node_id
+from .module_index import relative_path
+from .reproducible import path_root
+
+# Called by the runtime or a framework rather than by repository code.
+ENTRY_POINT_NAMES = frozenset({"main", "init", "setup", "teardown", "handler", "lambda_handler"})
+TEST_PATH_RE = re.compile(
+    r"(^|/)(tests?|__tests__|spec)/|(^|/)test_[^/]*$|_test\.\w+$|\.(test|spec)\.\w+$"
+)
+LIMITATION_WARNING = (
+    "Detection is static: a symbol counts as referenced when its name appears in another "
+    "analyzed file. Symbols reached only through reflection, dynamic imports, string-based "
+    "lookups or external consumers may be listed even though they are used."
+)
+_IDENT_RE = re.compile(r"[A-Za-z_$][\w$]*")
+
+
+def scan_symbol_references(
+    root_path: Path,
+    files: Iterable[Path],
+    names: Iterable[str],
+    external: Mapping[str, set[str]] | None = None,
+) -> dict[str, list[str]]:
+    """Return, per file, which of the given symbol `names` appear in it as identifiers.
+
+    `external` maps files to the qualifiers of their external imports (see
+    `external_calls.external_aliases`); `redis.NewClient(` does not reference a
+    repository `NewClient`.
+    """
+    wanted = set(names)
+    references: dict[str, list[str]] = {}
+    if not wanted:
+        return references
+    for path in sorted(files):
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        aliases = (external or {}).get(relative_path(root_path, str(path)))
+        if aliases:
+            content = qualified_call_re(aliases).sub(" (", content)
+        found = wanted.intersection(_IDENT_RE.findall(content))
+        if found:
+            references[relative_path(root_path, str(path))] = sorted(found)
+    return references
+
+
+def find_unreferenced_symbols(
+    analysis_data: dict[str, Any], *, ignore: list[str] | None = None
+) -> list[dict[str, Any]]:
+    """List exported functions and classes with no inbound edge in the impact graph.
+
+    `defines` edges (file to its own symbols) do not count as references. Entry
+    points, route handlers, decorated symbols (usually registered with a framework),
+    methods, test modules and names matching an `ignore` glob are never listed.
+    Globs match the symbol name, its module path or `module::name`.
+    """
+    graph = build_impact_graph_data(analysis_data, max_nodes=sys.maxsize, max_edges=sys.maxsize)
+    referenced = {str(edge["target"]) for edge in graph["edges"] if edge["kind"] != "defines"}
+    patterns = ignore or []
+    handlers = {
+        str(route.get("handler", "")).rsplit(".", 1)[-1]
+        for route in analysis_data.get("http_routes", [])
+        if isinstance(route, dict)
+    }
+    root = path_root(analysis_data)
+    methods = method_positions(analysis_data, root)
+
+    unreferenced: list[dict[str, Any]] = []
+    seen: set[str] = set()
+    for key, default_kind in (("functions", "function"), ("classes", "class")):
+        for item in analysis_data.get(key, []):
+            if not isinstance(item, dict) or not item.get("name") or not item.get("file"):
+                continue
+            name = str(item["name"])
+            module = relative_path(root, str(item["file"]))
+            kind = str(item.get("kind") or default_kind)
+            node_id = symbol_node_id(module, name)
+            if node_id in referenced or node_id in seen:
+                continue
+            line = int(item.get("line", 0) or 0)
+            if (module, line, name) in methods or _excluded(name, module, kind, item, handlers):
+                continue
+            targets = (name, module, f"{module}::{name}")
+            if any(fnmatch(value, pattern) for pattern in patterns for value in targets):
+                continue
+            seen.add(node_id)
+            unreferenced.append({"name": name, "kind": kind, "module": module, "line": line})
+    return sorted(unreferenced, key=lambda sym: (sym["module"], sym["line"], sym["name"]))
+
+
+def _excluded(name: str, module: str, kind: str, item: dict[str, Any], handlers: set[str]) -> bool:
+    if name.startswith("_") or name in ENTRY_POINT_NAMES or name in handlers:
+        return True
+    if kind == "method" or item.get("decorators"):
+        return True
+    return TEST_PATH_RE.search(module) is not None
+
+
+def method_positions(analysis_data: dict[str, Any], root: Path) -> set[tuple[str, int, str]]:
+    """Positions of class methods, which some parsers also report as plain functions."""
+    positions: set[tuple[str, int, str]] = set()
+    for item in analysis_data.get("classes", []):
+        if not isinstance(item, dict):
+            continue
+        module = relative_path(root, str(item.get("file", "")))
+        for method in item.get("methods", []) or []:
+            if isinstance(method, dict) and method.get("name"):
+                positions.add((module, int(method.get("line", 0) or 0), str(method["name"])))
+    return positions
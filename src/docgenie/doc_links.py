@@ -0,0 +1,167 @@
+"""Link third-party types in signatures to the documentation their package publishes.
+
+A reference is a type qualified by the name an external import is used under
+(`mux.Router`, `reqwest::Client`, `json.JSONDecoder`). Go packages link to
+pkg.go.dev, Rust crates to docs.rs (the standard library to doc.rust-lang.org)
+and the Python standard library to docs.python.org. `doc_links.bases` (or
+`--doc-base`) maps further import path prefixes to URLs; references into
+packages without a known URL stay plain text.
+"""
+
+from __future__ import annotations
+
+import re
+import sys
+from collections.abc import Iterable, Mapping
+from typing import Any
+
+from .external_calls import import_aliases, is_external_import, local_modules
+
+# `{path}` is the imported package and `{name}` the referenced type.
+DOC_URL_TEMPLATES = {
+    "go": "https://pkg.go.dev/{path}#{name}",
+    "rust": "https://docs.rs/{path}/latest/{path}/?search={name}",
+    "python": "https://docs.python.org/3/library/{path}.html#{path}.{name}",
+}
+_RUST_STD_TEMPLATE = "https://doc.rust-lang.org/{path}/?search={name}"
+_RUST_STD_CRATES = frozenset({"std", "core", "alloc"})
+_RUST_LOCAL_ROOTS = frozenset({"crate", "self", "super"})
+
+
+def parse_doc_bases(values: Iterable[str]) -> dict[str, str]:
+    """Parse `--doc-base PREFIX=URL` values; raise ValueError for one without `=`."""
+    bases: dict[str, str] = {}
+    for value in values:
+        prefix, sep, url = value.partition("=")
+        if not sep or not prefix.strip() or not url.strip():
+            raise ValueError(f"expected PREFIX=URL, got {value!r}")
+        bases[prefix.strip()] = url.strip()
+    return bases
+
+
+def doc_url(package: str, name: str, language: str, bases: Mapping[str, str]) -> str | None:
+    """Return the documentation URL of type `name` exported by `package`, if known.
+
+    The longest `bases` prefix matching `package` on a path or module boundary wins
+    over the built-in table. A base without `{path}`/`{name}` gets `/{path}` appended.
+    """
+    prefix = max(
+        (
+            key
+            for key in bases
+            if package == key or package.startswith((f"{key}/", f"{key}.", f"{key}::"))
+        ),
+        key=len,
+        default=None,
+    )
+    if prefix is not None:
+        template = bases[prefix]
+        if "{path}" not in template and "{name}" not in template:
+            template = template.rstrip("/") + "/{path}"
+    elif language == "rust" and package in _RUST_STD_CRATES:
+        template = _RUST_STD_TEMPLATE
+    elif language == "python" and package.split(".")[0] not in sys.stdlib_module_names:
+        return None
+    else:
+        template = DOC_URL_TEMPLATES.get(language)
+        if template is None:
+            return None
+    return template.replace("{path}", package).replace("{name}", name)
+
+
+def external_packages(
+    file_imports: Mapping[str, Any], local_paths: Iterable[str] = ()
+) -> dict[str, dict[str, str]]:
+    """Return, per analyzed file, the package each external import qualifier refers to.
+
+    Slash paths keep the whole path (`mux` is `github.com/gorilla/mux`); a Rust path
+    is used by its crate; a dotted Python module by its first segment (as itself) and
+    its last segment (as the whole module). `local_paths` are further repository files,
+    such as those that import nothing.
+    """
+    local = local_modules([*file_imports, *local_paths])
+    packages: dict[str, dict[str, str]] = {}
+    for path, imports in file_imports.items():
+        qualifiers: dict[str, str] = {}
+        for spec in imports if isinstance(imports, (list, set, tuple)) else []:
+            spec = str(spec)
+            if "::" in spec:
+                crate = spec.split("::", 1)[0]
+                if crate not in _RUST_LOCAL_ROOTS:
+                    qualifiers[crate] = crate
+            elif not is_external_import(spec, file_imports, local):
+                continue
+            elif "/" in spec:
+                qualifiers.update(dict.fromkeys(import_aliases(spec), spec))
+            else:
+                parts = spec.split(".")
+                qualifiers[parts[0]] = parts[0]
+                qualifiers[parts[-1]] = spec
+        if qualifiers:
+            packages[str(path)] = qualifiers
+    return packages
+
+
+def signature_links(
+    signature: str, packages: Mapping[str, str], language: str, bases: Mapping[str, str]
+) -> list[dict[str, str | None]]:
+    """Split `signature` into `text` parts, with `url` set on linked type references.
+
+    Returns an empty list when nothing in the signature links anywhere.
+    """
+    if not packages:
+        return []
+    names = "|".join(sorted(map(re.escape, packages), key=len, reverse=True))
+    reference_re = re.compile(
+        rf"(?<![\w.:$])(?P<qualifier>{names})(?:\.|::)(?P<name>[A-Za-z_]\w*)"
+    )
+    parts: list[dict[str, str | None]] = []
+    last = 0
+    for match in reference_re.finditer(signature):
+        url = doc_url(packages[match.group("qualifier")], match.group("name"), language, bases)
+        if url is None:
+            continue
+        if match.start() > last:
+            parts.append({"text": signature[last : match.start()], "url": None})
+        parts.append({"text": match.group(0), "url": url})
+        last = match.end()
+    if not parts:
+        return []
+    if last < len(signature):
+        parts.append({"text": signature[last:], "url": None})
+    return parts
+
+
+def link_module_signatures(
+    modules: list[dict[str, Any]], analysis_data: dict[str, Any]
+) -> list[dict[str, Any]]:
+    """Set `signature_links` on module index rows that reference documented external types.
+
+    Does nothing when `doc_links.enabled` is off.
+    """
+    config = analysis_data.get("config", {})
+    link_config = config.get("doc_links", {}) if isinstance(config, dict) else {}
+    if not isinstance(link_config, dict) or not link_config.get("enabled", True):
+        return modules
+    raw_bases = link_config.get("bases")
+    bases = {str(k): str(v) for k, v in raw_bases.items()} if isinstance(raw_bases, dict) else {}
+    file_imports = analysis_data.get("file_imports")
+    structure = analysis_data.get("project_structure")
+    local_paths = [
+        str(name) if directory == "root" else f"{directory}/{name}"
+        for directory, entry in (structure.items() if isinstance(structure, dict) else [])
+        for name in (entry.get("files") or [] if isinstance(entry, dict) else [])
+    ]
+    per_file = external_packages(
+        file_imports if isinstance(file_imports, dict) else {}, local_paths
+    )
+    for module in modules:
+        packages: dict[str, str] = {}
+        for path in module.get("files") or [module.get("path")]:
+            packages.update(per_file.get(str(path), {}))
+        for sym in module.get("symbols", []):
+            signature = str(sym.get("signature", ""))
+            links = signature_links(signature, packages, str(module.get("language")), bases)
+            if links:
+                sym["signature_links"] = links
+    return modules
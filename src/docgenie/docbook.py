@@ -0,0 +1,211 @@
+"""Render the analysis as a DocBook 5 `<article>` for XML publishing toolchains."""
+
+from __future__ import annotations
+
+import re
+import xml.etree.ElementTree as ET  # noqa: N817  # nosec B405
+from pathlib import Path
+from typing import Any
+
+from .html_sections import allocate_heading_ids, api_anchor, symbol_anchors
+from .llms_txt import (
+    dependency_names,
+    is_shown,
+    project_summary,
+    ranked_languages,
+    redact_output,
+)
+from .module_index import relative_path, symbol_visibility
+from .reproducible import path_root
+from .utils import create_directory_tree
+
+DOCBOOK_NS = "http://docbook.org/ns/docbook"
+XML_ID = "xml:id"
+# Marks the text each section's `xml:id` is allocated from; removed before serializing.
+_HEADING_ATTR = "heading"
+# Characters XML 1.0 does not allow anywhere in a document, even escaped.
+_INVALID_XML_RE = re.compile("[\x00-\x08\x0b\x0c\x0e-\x1f\ufffe\uffff]")
+
+
+def xml_text(value: Any) -> str:
+    """Return `value` as text that can be placed in an XML document."""
+    return _INVALID_XML_RE.sub("", str(value))
+
+
+class DocBookGenerator:
+    """Overview, languages, dependencies, project structure and an API reference.
+
+    Every section and symbol carries an `xml:id` allocated like the README heading
+    anchors, so repeated names get `-2`, `-3`, ... suffixes. Sections with nothing to
+    show are left out, since DocBook does not allow an empty `<section>`.
+    """
+
+    def generate(
+        self, analysis_data: dict[str, Any], output_path: str | Path | None = None
+    ) -> str:
+        """Return the DocBook XML; write it to `output_path` when given."""
+        project = str(analysis_data.get("project_name") or "project")
+        article = ET.Element("article", {"xmlns": DOCBOOK_NS, "version": "5.0"})
+        info = ET.SubElement(article, "info")
+        _text(info, "title", project)
+        abstract = ET.SubElement(info, "abstract")
+        _text(abstract, "para", project_summary(analysis_data, project))
+
+        sections = [
+            self._languages(analysis_data),
+            self._dependencies(analysis_data),
+            self._structure(analysis_data),
+            *self._modules(analysis_data),
+        ]
+        present = [section for section in sections if section is not None]
+        # Symbol sections carry the anchors they have in every other format already.
+        symbol_ids = [
+            str(element.get(XML_ID))
+            for section in present
+            for element in section.iter("section")
+            if element.get(XML_ID)
+        ]
+        headings = _take_headings(present)
+        anchors = allocate_heading_ids((heading for heading, _ in headings), symbol_ids)
+        for (_, element), anchor in zip(headings, anchors):
+            element.set(XML_ID, anchor)
+        article.extend(present)
+
+        ET.indent(article)
+        content = redact_output(
+            '<?xml version="1.0" encoding="UTF-8"?>\n' + ET.tostring(article, encoding="unicode"),
+            analysis_data.get("config", {}),
+        )
+        if output_path:
+            path = Path(output_path)
+            path.parent.mkdir(parents=True, exist_ok=True)
+            path.write_text(content + "\n", encoding="utf-8")
+        return content + "\n"
+
+    def _languages(self, analysis_data: dict[str, Any]) -> ET.Element | None:
+        ranked = ranked_languages(analysis_data)
+        if not ranked:
+            return None
+        section = _section("Languages")
+        section.append(_table("Languages", ["Language", "Files"], ranked))
+        return section
+
+    def _dependencies(self, analysis_data: dict[str, Any]) -> ET.Element | None:
+        dependencies = analysis_data.get("dependencies", {}) or {}
+        rows = [
+            (manifest, ", ".join(names))
+            for manifest in sorted(dependencies)
+            if (names := dependency_names(dependencies[manifest]))
+        ]
+        if not rows:
+            return None
+        section = _section("Dependencies")
+        section.append(_table("Dependencies", ["Manifest", "Packages"], rows))
+        return section
+
+    def _structure(self, analysis_data: dict[str, Any]) -> ET.Element | None:
+        structure = analysis_data.get("project_structure", {}) or {}
+        if not structure:
+            return None
+        section = _section("Project Structure")
+        _text(section, "programlisting", create_directory_tree(structure))
+        return section
+
+    def _modules(self, analysis_data: dict[str, Any]) -> list[ET.Element]:
+        """Return one section per source file with a subsection per public symbol."""
+        root = path_root(analysis_data)
+        visibility = symbol_visibility(analysis_data)
+        anchors = symbol_anchors(analysis_data)
+        grouped: dict[str, list[tuple[int, ET.Element]]] = {}
+        for key, default_kind in (("functions", "function"), ("classes", "class")):
+            for item in analysis_data.get(key, []) or []:
+                if not isinstance(item, dict) or not is_shown(item, visibility):
+                    continue
+                module = relative_path(root, str(item.get("file", "")))
+                symbol = _symbol(item, default_kind, module=module, anchors=anchors)
+                for method in item.get("methods", []) or []:
+                    if isinstance(method, dict) and is_shown(method, visibility):
+                        symbol.append(
+                            _symbol(
+                                method,
+                                "method",
+                                owner=str(item["name"]),
+                                module=module,
+                                anchors=anchors,
+                            )
+                        )
+                grouped.setdefault(module, []).append((int(item.get("line", 0) or 0), symbol))
+        sections: list[ET.Element] = []
+        for module in sorted(grouped):
+            section = _section(module, heading=f"module {module}")
+            section.extend(symbol for _, symbol in sorted(grouped[module], key=lambda s: s[0]))
+            sections.append(section)
+        return sections
+
+
+def _section(title: str, *, heading: str | None = None, anchor: str = "") -> ET.Element:
+    """Return a `<section>` with `anchor` as its `xml:id`, or one made from `heading`.
+
+    `heading` defaults to `title`; its ID is allocated once the whole article is built.
+    """
+    attrs = {XML_ID: anchor} if anchor else {_HEADING_ATTR: heading or title}
+    section = ET.Element("section", attrs)
+    _text(section, "title", title)
+    return section
+
+
+def _take_headings(sections: list[ET.Element]) -> list[tuple[str, ET.Element]]:
+    """Return `(heading, section)` in document order, removing the heading markers."""
+    return [
+        (element.attrib.pop(_HEADING_ATTR), element)
+        for section in sections
+        for element in section.iter("section")
+        if _HEADING_ATTR in element.attrib
+    ]
+
+
+def _symbol(
+    item: dict[str, Any],
+    default_kind: str,
+    *,
+    owner: str = "",
+    module: str,
+    anchors: dict[tuple[str, str, int], str],
+) -> ET.Element:
+    name = str(item.get("name"))
+    qualified = f"{owner}.{name}" if owner else name
+    kind = str(item.get("kind") or default_kind)
+    key = (module, qualified, int(item.get("line", 0) or 0))
+    section = _section(f"{qualified} ({kind})", anchor=anchors.get(key) or api_anchor(qualified))
+    signature = " ".join(str(item.get("signature") or "").split())
+    if not signature:
+        args = ", ".join(str(arg) for arg in item.get("args", []) or [])
+        signature = f"{kind} {name}" if default_kind == "class" else f"{name}({args})"
+    _text(section, "programlisting", signature)
+    docstring = str(item.get("docstring") or "").strip()
+    for paragraph in re.split(r"\n\s*\n", docstring) if docstring else []:
+        _text(section, "para", " ".join(paragraph.split()))
+    if item.get("line"):
+        _text(section, "para", f"Defined at line {item['line']}.")
+    return section
+
+
+def _table(title: str, header: list[str], rows: list[tuple[Any, ...]]) -> ET.Element:
+    table = ET.Element("table", {"frame": "all"})
+    _text(table, "title", title)
+    group = ET.SubElement(table, "tgroup", {"cols": str(len(header))})
+    head_row = ET.SubElement(ET.SubElement(group, "thead"), "row")
+    for cell in header:
+        _text(head_row, "entry", cell)
+    body = ET.SubElement(group, "tbody")
+    for values in rows:
+        row = ET.SubElement(body, "row")
+        for value in values:
+            _text(row, "entry", value)
+    return table
+
+
+def _text(parent: ET.Element, tag: str, value: Any) -> ET.Element:
+    element = ET.SubElement(parent, tag)
+    element.text = xml_text(value)
+    return element
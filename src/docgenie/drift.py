@@ -0,0 +1,71 @@
+"""Compare committed docs with a fresh render to catch documentation drift.
+
+`docgenie check` renders every format in memory and diffs it against the file in the
+repository. A Markdown README with `docgenie:begin`/`docgenie:end` markers is compared
+the way `generate` would write it: only the marked regions are regenerated, so hand-written
+text around them never counts as drift. The generation timestamp and the latest commit
+line change on every commit and are masked before comparing.
+"""
+
+from __future__ import annotations
+
+import difflib
+import re
+from pathlib import Path
+
+from .regions import merge_regions
+
+# Lines that legitimately differ between two renders of the same sources.
+_VOLATILE_RES = (
+    re.compile(
+        r"(?i)(generated\b.*\bon )"
+        r"(?:\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}|[A-Z][a-z]+ \d{2}, \d{4})"
+    ),
+    re.compile(r"(Latest commit: ).*"),
+)
+
+
+def drift_diff(
+    committed: str | None, generated: str, path: Path, *, regions: bool = False
+) -> list[str]:
+    """Return a unified diff from the committed file to what `generate` would write.
+
+    Empty when the docs are up to date. `committed` is None for a file that does not
+    exist yet, which is always drift. With `regions`, a file holding region markers is
+    compared only inside them; see `regions.merge_regions`, whose GeneratorError for
+    mismatched markers propagates.
+    """
+    expected = generated
+    if regions and committed is not None:
+        merged = merge_regions(committed, generated, path)
+        if merged is not None:
+            expected = merged
+    before = _mask_volatile(committed or "")
+    after = _mask_volatile(expected)
+    if committed is not None and before == after:
+        return []
+    return list(
+        difflib.unified_diff(
+            before.splitlines(),
+            after.splitlines(),
+            fromfile=f"{path.name} (committed)",
+            tofile=f"{path.name} (generated)",
+            lineterm="",
+        )
+    )
+
+
+def diff_stats(diff: list[str]) -> tuple[int, int]:
+    """Return the (added, removed) line counts of a unified diff."""
+    # The first two lines are the ---/+++ file headers.
+    body = [line for line in diff[2:] if not line.startswith("@@")]
+    return (
+        sum(1 for line in body if line.startswith("+")),
+        sum(1 for line in body if line.startswith("-")),
+    )
+
+
+def _mask_volatile(text: str) -> str:
+    for pattern in _VOLATILE_RES:
+        text = pattern.sub(lambda match: f"{match.group(1)}<volatile>", text)
+    return text
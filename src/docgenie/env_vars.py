@@ -0,0 +1,186 @@
+"""Detect environment variables read through `os.Getenv` (Go) and `os.environ` (Python)."""
+
+from __future__ import annotations
+
+import ast
+import re
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from .languages._scan import code_lines, split_top_level
+from .routes import call_arguments, string_literal
+from .utils import get_file_language
+
+_GO_READ_RE = re.compile(r"\bos\.(?:Getenv|LookupEnv)\(")
+# `cmp.Or(os.Getenv("PORT"), "8080")` is the idiomatic same-expression default.
+_GO_OR_RE = re.compile(r"\bcmp\.Or\(\s*$")
+_PYTHON_ACCESSORS = frozenset({"get", "setdefault"})
+_MARKERS = ("Getenv", "LookupEnv", "environ", "getenv")
+
+
+def scan_env_vars(root_path: Path, files: Iterable[Path]) -> list[dict[str, Any]]:
+    """Return one entry per environment variable read in Go and Python sources.
+
+    Each entry has `name`, `file`, `line`, `default` (the fallback supplied in the
+    same expression, else None) and `dynamic`. Keys that are not string literals
+    are kept with `dynamic` set and the key expression as `name`.
+    """
+    reads: list[dict[str, Any]] = []
+    for path in sorted(files):
+        language = get_file_language(path)
+        if language not in {"go", "python"}:
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        if not any(marker in content for marker in _MARKERS):
+            continue
+        try:
+            rel = path.relative_to(root_path).as_posix()
+        except ValueError:
+            rel = path.as_posix()
+        found = _go_reads(content) if language == "go" else _python_reads(content)
+        reads.extend({**read, "file": rel} for read in found)
+    return reads
+
+
+def env_var_groups(env_vars: Iterable[dict[str, Any]]) -> list[dict[str, Any]]:
+    """Group reads by module for the README, one row per variable.
+
+    Rows keep the first default seen; names and defaults are escaped for table cells.
+    """
+    modules: dict[str, dict[tuple[bool, str], dict[str, Any]]] = {}
+    for read in env_vars:
+        rows = modules.setdefault(str(read.get("file", "")), {})
+        key = (bool(read.get("dynamic")), _table_cell(str(read.get("name", ""))))
+        row = rows.setdefault(
+            key, {"name": key[1], "dynamic": key[0], "default": None, "lines": []}
+        )
+        if row["default"] is None and read.get("default") is not None:
+            row["default"] = _table_cell(str(read["default"]))
+        row["lines"].append(int(read.get("line", 0)))
+    return [
+        {
+            "module": module,
+            "variables": sorted(rows.values(), key=lambda row: (row["dynamic"], row["name"])),
+        }
+        for module, rows in sorted(modules.items())
+    ]
+
+
+def env_var_names(env_vars: Iterable[dict[str, Any]]) -> list[str]:
+    """Return the unique literal variable names, sorted."""
+    return sorted({str(read["name"]) for read in env_vars if not read.get("dynamic")})
+
+
+def _table_cell(value: str) -> str:
+    return value.replace("|", "\\|").replace("\n", " ").strip()
+
+
+def _go_reads(content: str) -> list[dict[str, Any]]:
+    raw = content.splitlines()
+    code = code_lines(content, quotes="\"'`", multiline_quotes="`")
+    text = "\n".join(raw)
+    offsets = [0]
+    for line in raw:
+        offsets.append(offsets[-1] + len(line) + 1)
+
+    reads: list[dict[str, Any]] = []
+    for line_no, code_line in enumerate(code):
+        for match in _GO_READ_RE.finditer(code_line):
+            args, _ = call_arguments(text, offsets[line_no] + match.end())
+            parts = split_top_level(args)
+            key = parts[0] if parts else ""
+            name = string_literal(key)
+            reads.append(
+                {
+                    "name": name if name is not None else " ".join(key.split()),
+                    "line": line_no + 1,
+                    "default": _go_default(text, offsets[line_no] + match.start()),
+                    "dynamic": name is None,
+                }
+            )
+    return reads
+
+
+def _go_default(text: str, call_start: int) -> str | None:
+    wrapper = _GO_OR_RE.search(text, max(0, call_start - 200), call_start)
+    if wrapper is None:
+        return None
+    args, _ = call_arguments(text, wrapper.end())
+    parts = split_top_level(args)
+    if len(parts) < 2:  # noqa: PLR2004
+        return None
+    literal = string_literal(parts[1])
+    return literal if literal is not None else " ".join(parts[1].split())
+
+
+def _python_reads(content: str) -> list[dict[str, Any]]:
+    try:
+        tree = ast.parse(content)
+    except (SyntaxError, ValueError):
+        return []
+    environ = {"os.environ"}
+    getenv = {"os.getenv"}
+    for node in ast.walk(tree):
+        if isinstance(node, ast.ImportFrom) and node.module == "os":
+            for alias in node.names:
+                if alias.name == "environ":
+                    environ.add(alias.asname or alias.name)
+                elif alias.name == "getenv":
+                    getenv.add(alias.asname or alias.name)
+
+    reads: list[dict[str, Any]] = []
+    for node in ast.walk(tree):
+        key: ast.expr | None = None
+        default: ast.expr | None = None
+        if isinstance(node, ast.Subscript) and isinstance(node.ctx, ast.Load):
+            if _dotted(node.value) in environ:
+                key = node.slice
+        elif isinstance(node, ast.Call) and node.args:
+            func = node.func
+            is_getenv = _dotted(func) in getenv
+            is_accessor = (
+                isinstance(func, ast.Attribute)
+                and func.attr in _PYTHON_ACCESSORS
+                and _dotted(func.value) in environ
+            )
+            if is_getenv or is_accessor:
+                key = node.args[0]
+                defaults = [kw.value for kw in node.keywords if kw.arg == "default"]
+                default = node.args[1] if len(node.args) > 1 else next(iter(defaults), None)
+        if key is None:
+            continue
+        literal = _string_constant(key)
+        reads.append(
+            {
+                "name": literal if literal is not None else ast.unparse(key),
+                "line": node.lineno,
+                "default": None if default is None else _python_default(default),
+                "dynamic": literal is None,
+            }
+        )
+    reads.sort(key=lambda read: read["line"])
+    return reads
+
+
+def _python_default(node: ast.expr) -> str:
+    literal = _string_constant(node)
+    return literal if literal is not None else ast.unparse(node)
+
+
+def _string_constant(node: ast.expr) -> str | None:
+    if isinstance(node, ast.Constant) and isinstance(node.value, str):
+        return node.value
+    return None
+
+
+def _dotted(node: ast.expr) -> str:
+    if isinstance(node, ast.Name):
+        return node.id
+    if isinstance(node, ast.Attribute):
+        owner = _dotted(node.value)
+        return f"{owner}.{node.attr}" if owner else ""
+    return ""
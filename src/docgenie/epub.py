@@ -0,0 +1,449 @@
+"""Package the HTML documentation as an EPUB 3 book for reading on e-readers.
+
+The README is split into chapters: the title page, one per `##` section, and one per
+module under `## Modules`. Each chapter goes through the HTML renderer and is then
+rewritten as well-formed XHTML. Heading IDs are allocated across the whole book, so
+the nav document, and the NCX kept for EPUB 2 readers, link straight to them.
+"""
+
+from __future__ import annotations
+
+import html
+import io
+import re
+import uuid
+import xml.etree.ElementTree as ET  # noqa: N817  # nosec B405
+import zipfile
+from datetime import timezone
+from html.parser import HTMLParser
+from pathlib import Path
+from typing import Any
+
+from .docbook import xml_text
+from .exceptions import GeneratorError
+from .generator import ReadmeGenerator
+from .html_generator import HTMLGenerator
+from .html_sections import heading_entries, normalize_heading_ids
+from .redaction import redact_text
+from .reproducible import build_time
+from .templating import template_dir_from_config
+from .toc import split_markdown_sections
+
+EPUB_MIMETYPE = "application/epub+zip"
+CONTAINER_PATH = "META-INF/container.xml"
+OPF_PATH = "OEBPS/content.opf"
+NCX_MEDIA_TYPE = "application/x-dtbncx+xml"
+XHTML_MEDIA_TYPE = "application/xhtml+xml"
+CONTAINER_NS = "urn:oasis:names:tc:opendocument:xmlns:container"
+OPF_NS = "http://www.idpf.org/2007/opf"
+NCX_NS = "http://www.daisy.org/z3986/2005/ncx/"
+DC_NS = "http://purl.org/dc/elements/1.1/"
+XHTML_NS = "http://www.w3.org/1999/xhtml"
+EPUB_NS = "http://www.idpf.org/2007/ops"
+# The `##` section split into one chapter per `###` module.
+MODULES_HEADING = "Modules"
+# Headings below this level stay inside their chapter and out of the nav.
+NAV_DEPTH = 3
+
+_HEADING_LINE_RE = re.compile(r"(?P<hashes>#{1,6})[ \t]+(?P<text>[^\n]+?)(?:[ \t]+#+)?[ \t]*$")
+_HEADERLINK_RE = re.compile(r'<a class="headerlink"[^>]*>.*?</a>', re.DOTALL)
+_LOCAL_HREF_RE = re.compile(r'href="#(?P<id>[^"]+)"')
+_ATTR_NAME_RE = re.compile(r"[A-Za-z_][\w.-]*")
+_VOID_ELEMENTS = frozenset(
+    {"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "wbr"}
+)
+# A nav entry: `(href, text, children)`.
+_NavNode = tuple[str, str, list[Any]]
+_CHAPTER_BREAK = "\n<!-- docgenie:chapter -->\n"
+_STYLESHEET = """body { font-family: serif; line-height: 1.5; }
+h1, h2, h3, h4 { font-family: sans-serif; }
+code, pre { font-family: monospace; font-size: 0.9em; }
+pre { white-space: pre-wrap; border-left: 3px solid #999; padding-left: 0.5em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #999; padding: 0.2em 0.4em; vertical-align: top; }
+"""
+
+
+def split_chapters(readme: str) -> list[str]:
+    """Split README Markdown into chapters: the title page, then one per `##` section.
+
+    `## Modules` keeps only its introduction; every `###` module under it becomes a
+    chapter of its own.
+    """
+    chapters: list[str] = []
+    in_modules = False
+    for section in split_markdown_sections(readme):
+        match = _HEADING_LINE_RE.match(section.split("\n", 1)[0])
+        level = len(match.group("hashes")) if match else 0
+        if match and level == 2:  # noqa: PLR2004
+            in_modules = match.group("text").strip() == MODULES_HEADING
+        if not chapters or level == 2 or (level == 3 and in_modules):  # noqa: PLR2004
+            chapters.append(section)
+        else:
+            chapters[-1] += section
+    return [chapter for chapter in chapters if chapter.strip()]
+
+
+class EpubGenerator:
+    """Render the README as an EPUB 3 book with a nav document and an NCX."""
+
+    def __init__(self) -> None:
+        self.html_generator = HTMLGenerator()
+
+    def generate(
+        self,
+        analysis_data: dict[str, Any],
+        output_path: str | Path | None = None,
+        readme_generator: ReadmeGenerator | None = None,
+    ) -> bytes:
+        """Return the EPUB archive; write it to `output_path` when given.
+
+        Pass the `readme_generator` used for the Markdown README to reuse its template
+        context (see `ReadmeGenerator.share_context`).
+        """
+        readme_gen = readme_generator or ReadmeGenerator(template_dir_from_config(analysis_data))
+        readme = readme_gen.generate(analysis_data, include_toc=False, include_badges=False)
+        config = analysis_data.get("config", {})
+        safety = config.get("safety", {}) if isinstance(config, dict) else {}
+        patterns = safety.get("redact_patterns", []) if isinstance(safety, dict) else []
+        return self.generate_from_readme(
+            readme,
+            output_path,
+            str(analysis_data.get("project_name") or "Project Documentation"),
+            redaction_mode=str(safety.get("redaction_mode", "strict")),
+            redact_patterns=patterns if isinstance(patterns, list) else [],
+        )
+
+    def generate_from_readme(
+        self,
+        readme_content: str,
+        output_path: str | Path | None = None,
+        project_name: str = "Project Documentation",
+        *,
+        redaction_mode: str = "strict",
+        redact_patterns: list[str] | None = None,
+    ) -> bytes:
+        """Render README Markdown as an EPUB archive.
+
+        Raises GeneratorError if the archive fails `validate_epub`, which would be a bug
+        here rather than a problem with the README.
+        """
+        safe_readme = redact_text(readme_content, redaction_mode, redact_patterns or [])
+        converted = [
+            _HEADERLINK_RE.sub("", self.html_generator.convert_generated(chapter))
+            for chapter in split_chapters(safe_readme)
+        ]
+        content, _ = normalize_heading_ids(_CHAPTER_BREAK.join(converted), "")
+        bodies = content.split(_CHAPTER_BREAK)
+        files = [f"chapter-{number:03d}.xhtml" for number in range(1, len(bodies) + 1)]
+        entries = [heading_entries(body) for body in bodies]
+        owners = {anchor: file for file, found in zip(files, entries) for _, anchor, _ in found}
+        chapters = [
+            (
+                file,
+                found[0][2] if found else project_name,
+                _link_across_chapters(body, file, owners),
+            )
+            for file, found, body in zip(files, entries, bodies)
+        ]
+        outline = _outline(
+            [
+                (max(level, 2), f"{file}#{anchor}", text)
+                for file, found in zip(files, entries)
+                for level, anchor, text in found
+                if level <= NAV_DEPTH
+            ]
+        )
+        book = self._package(project_name, chapters, outline)
+        problems = validate_epub(book)
+        if problems:
+            raise GeneratorError(f"Invalid EPUB: {'; '.join(problems)}")
+        if output_path:
+            path = Path(output_path)
+            path.parent.mkdir(parents=True, exist_ok=True)
+            path.write_bytes(book)
+        return book
+
+    def _package(
+        self,
+        project_name: str,
+        chapters: list[tuple[str, str, str]],
+        outline: list[_NavNode],
+    ) -> bytes:
+        identifier = f"urn:uuid:{uuid.uuid5(uuid.NAMESPACE_URL, f'docgenie:{project_name}')}"
+        modified = build_time().astimezone(timezone.utc)
+        stamp = f"{modified:%Y-%m-%dT%H:%M:%SZ}"
+        files = {
+            CONTAINER_PATH: _container(),
+            OPF_PATH: _opf(project_name, identifier, stamp, [file for file, _, _ in chapters]),
+            "OEBPS/toc.ncx": _ncx(project_name, identifier, outline),
+            "OEBPS/nav.xhtml": _xhtml_document(
+                "Contents",
+                f'<nav epub:type="toc" id="toc"><h1>Contents</h1>{_nav_list(outline)}</nav>',
+            ),
+            "OEBPS/style.css": _STYLESHEET,
+            **{
+                f"OEBPS/{file}": _xhtml_document(title, to_xhtml(body))
+                for file, title, body in chapters
+            },
+        }
+        # Zip entries carry the build time too, so SOURCE_DATE_EPOCH builds are byte-identical.
+        date_time = max(modified.timetuple()[:6], (1980, 1, 1, 0, 0, 0))
+        buffer = io.BytesIO()
+        with zipfile.ZipFile(buffer, "w") as archive:
+            # The OCF spec requires `mimetype` first, uncompressed, so readers can sniff it.
+            archive.writestr(zipfile.ZipInfo("mimetype", date_time), EPUB_MIMETYPE)
+            for name, text in files.items():
+                info = zipfile.ZipInfo(name, date_time)
+                info.compress_type = zipfile.ZIP_DEFLATED
+                archive.writestr(info, text.encode("utf-8"))
+        return buffer.getvalue()
+
+
+def _link_across_chapters(body: str, file: str, owners: dict[str, str]) -> str:
+    """Point `#id` links at the chapter file that holds the heading."""
+
+    def replace(match: re.Match[str]) -> str:
+        owner = owners.get(match.group("id"), file)
+        return f'href="{"" if owner == file else owner}#{match.group("id")}"'
+
+    return _LOCAL_HREF_RE.sub(replace, body)
+
+
+def _outline(entries: list[tuple[int, str, str]]) -> list[_NavNode]:
+    """Nest `(level, href, text)` headings into `(href, text, children)` nodes."""
+    root: list[_NavNode] = []
+    stack: list[tuple[int, list[_NavNode]]] = [(1, root)]
+    for level, href, text in entries:
+        while stack[-1][0] >= level:
+            stack.pop()
+        node: _NavNode = (href, text, [])
+        stack[-1][1].append(node)
+        stack.append((level, node[2]))
+    return root
+
+
+def _nav_list(nodes: list[_NavNode]) -> str:
+    items = "".join(
+        f'<li><a href="{html.escape(href)}">{html.escape(xml_text(text))}</a>'
+        f"{_nav_list(children) if children else ''}</li>"
+        for href, text, children in nodes
+    )
+    return f"<ol>{items}</ol>"
+
+
+def _xhtml_document(title: str, body: str) -> str:
+    return (
+        '<?xml version="1.0" encoding="UTF-8"?>\n<!DOCTYPE html>\n'
+        f'<html xmlns="{XHTML_NS}" xmlns:epub="{EPUB_NS}" lang="en" xml:lang="en">\n'
+        f"<head><meta charset=\"UTF-8\"/><title>{html.escape(xml_text(title))}</title>"
+        '<link rel="stylesheet" type="text/css" href="style.css"/></head>\n'
+        f"<body>\n{body}</body>\n</html>\n"
+    )
+
+
+def _xml(root: ET.Element) -> str:
+    ET.indent(root)
+    return '<?xml version="1.0" encoding="UTF-8"?>\n' + ET.tostring(root, encoding="unicode")
+
+
+def _container() -> str:
+    container = ET.Element("container", {"xmlns": CONTAINER_NS, "version": "1.0"})
+    rootfiles = ET.SubElement(container, "rootfiles")
+    attrs = {"full-path": OPF_PATH, "media-type": "application/oebps-package+xml"}
+    ET.SubElement(rootfiles, "rootfile", attrs)
+    return _xml(container)
+
+
+def _opf(title: str, identifier: str, modified: str, chapter_files: list[str]) -> str:
+    package = ET.Element(
+        "package",
+        {"xmlns": OPF_NS, "version": "3.0", "unique-identifier": "book-id", "xml:lang": "en"},
+    )
+    metadata = ET.SubElement(package, "metadata", {"xmlns:dc": DC_NS})
+    ET.SubElement(metadata, "dc:identifier", {"id": "book-id"}).text = identifier
+    ET.SubElement(metadata, "dc:title").text = xml_text(title)
+    ET.SubElement(metadata, "dc:language").text = "en"
+    ET.SubElement(metadata, "meta", {"property": "dcterms:modified"}).text = modified
+    manifest = ET.SubElement(package, "manifest")
+    items = [
+        ("nav", "nav.xhtml", XHTML_MEDIA_TYPE),
+        ("ncx", "toc.ncx", NCX_MEDIA_TYPE),
+        ("css", "style.css", "text/css"),
+        *((Path(file).stem, file, XHTML_MEDIA_TYPE) for file in chapter_files),
+    ]
+    for item_id, href, media_type in items:
+        attrs = {"id": item_id, "href": href, "media-type": media_type}
+        if item_id == "nav":
+            attrs["properties"] = "nav"
+        ET.SubElement(manifest, "item", attrs)
+    spine = ET.SubElement(package, "spine", {"toc": "ncx"})
+    for item_id in ["nav", *(Path(file).stem for file in chapter_files)]:
+        ET.SubElement(spine, "itemref", {"idref": item_id})
+    return _xml(package)
+
+
+def _ncx(title: str, identifier: str, outline: list[_NavNode]) -> str:
+    ncx = ET.Element("ncx", {"xmlns": NCX_NS, "version": "2005-1"})
+    head = ET.SubElement(ncx, "head")
+    depth = _depth(outline)
+    for name, content in (
+        ("dtb:uid", identifier),
+        ("dtb:depth", str(depth)),
+        ("dtb:totalPageCount", "0"),
+        ("dtb:maxPageNumber", "0"),
+    ):
+        ET.SubElement(head, "meta", {"name": name, "content": content})
+    ET.SubElement(ET.SubElement(ncx, "docTitle"), "text").text = xml_text(title)
+    nav_map = ET.SubElement(ncx, "navMap")
+    order = 0
+
+    def add(parent: ET.Element, nodes: list[_NavNode]) -> None:
+        nonlocal order
+        for href, text, children in nodes:
+            order += 1
+            point = ET.SubElement(
+                parent, "navPoint", {"id": f"nav-{order}", "playOrder": str(order)}
+            )
+            ET.SubElement(ET.SubElement(point, "navLabel"), "text").text = xml_text(text)
+            ET.SubElement(point, "content", {"src": href})
+            add(point, children)
+
+    add(nav_map, outline)
+    return _xml(ncx)
+
+
+def _depth(nodes: list[_NavNode]) -> int:
+    return 1 + max((_depth(children) for _, _, children in nodes), default=0) if nodes else 0
+
+
+class _XhtmlWriter(HTMLParser):
+    """Re-serialize HTML as XHTML: void elements self-closed, every element closed."""
+
+    def __init__(self) -> None:
+        super().__init__(convert_charrefs=True)
+        self.parts: list[str] = []
+        self.open: list[str] = []
+        self.skipping = 0
+
+    def handle_starttag(self, tag: str, attrs: list[tuple[str, str | None]]) -> None:
+        if tag == "script" or self.skipping:
+            self.skipping += tag == "script"
+            return
+        rendered = "".join(
+            f' {name}="{html.escape(xml_text(value or ""))}"'
+            for name, value in attrs
+            if _ATTR_NAME_RE.fullmatch(name)
+        )
+        if tag in _VOID_ELEMENTS:
+            self.parts.append(f"<{tag}{rendered}/>")
+        else:
+            self.parts.append(f"<{tag}{rendered}>")
+            self.open.append(tag)
+
+    def handle_endtag(self, tag: str) -> None:
+        if self.skipping:
+            self.skipping -= tag == "script"
+            return
+        if tag not in self.open:
+            return
+        while self.open:
+            closing = self.open.pop()
+            self.parts.append(f"</{closing}>")
+            if closing == tag:
+                break
+
+    def handle_data(self, data: str) -> None:
+        if not self.skipping:
+            self.parts.append(html.escape(xml_text(data), quote=False))
+
+
+def to_xhtml(fragment: str) -> str:
+    """Return an HTML fragment as well-formed XHTML, dropping comments and scripts.
+
+    Named entities such as `&para;` are written as characters, since XHTML only
+    defines the five XML ones.
+    """
+    writer = _XhtmlWriter()
+    writer.feed(fragment)
+    writer.close()
+    return "".join(writer.parts) + "".join(f"</{tag}>" for tag in reversed(writer.open))
+
+
+def validate_epub(data: bytes) -> list[str]:
+    """Return what keeps `data` from being a readable EPUB; an empty list when it is one.
+
+    Checks the OCF container (a zip whose first entry is an uncompressed `mimetype`),
+    that `container.xml`, the OPF package, the NCX and every XHTML file are well-formed
+    XML, and that every manifest item, spine entry and NCX target exists.
+    """
+    try:
+        archive = zipfile.ZipFile(io.BytesIO(data))
+    except zipfile.BadZipFile:
+        return ["not a zip archive"]
+    with archive:
+        names = archive.namelist()
+        first = archive.infolist()[0] if names else None
+        if first is None or first.filename != "mimetype":
+            return ["the first entry is not `mimetype`"]
+        problems: list[str] = []
+        if first.compress_type != zipfile.ZIP_STORED:
+            problems.append("`mimetype` is compressed")
+        if archive.read("mimetype") != EPUB_MIMETYPE.encode("ascii"):
+            problems.append(f"`mimetype` is not {EPUB_MIMETYPE}")
+
+        parsed: dict[str, ET.Element | None] = {}
+
+        def parse(name: str) -> ET.Element | None:
+            if name in parsed:
+                return parsed[name]
+            parsed[name] = None
+            if name not in names:
+                problems.append(f"{name} is missing")
+            else:
+                try:
+                    parsed[name] = ET.fromstring(archive.read(name))  # nosec B314
+                except ET.ParseError as exc:
+                    problems.append(f"{name} is not well-formed: {exc}")
+            return parsed[name]
+
+        container = parse(CONTAINER_PATH)
+        rootfile = (
+            container.find(f".//{{{CONTAINER_NS}}}rootfile") if container is not None else None
+        )
+        opf_path = rootfile.get("full-path", "") if rootfile is not None else ""
+        if container is not None and not opf_path:
+            problems.append(f"{CONTAINER_PATH} names no rootfile")
+        package = parse(opf_path) if opf_path else None
+        if package is None:
+            return problems
+        base = str(Path(opf_path).parent)
+        prefix = "" if base == "." else f"{base}/"
+        metadata = package.find(f"{{{OPF_NS}}}metadata")
+        for field in ("identifier", "title", "language"):
+            if metadata is None or metadata.find(f"{{{DC_NS}}}{field}") is None:
+                problems.append(f"{opf_path} has no dc:{field}")
+        items = {
+            item.get("id", ""): item for item in package.iterfind(f"{{{OPF_NS}}}manifest/*")
+        }
+        for item in items.values():
+            href = prefix + item.get("href", "")
+            if href not in names:
+                problems.append(f"{href} is in the manifest but not the archive")
+            elif item.get("media-type") in (XHTML_MEDIA_TYPE, NCX_MEDIA_TYPE):
+                parse(href)
+        if not any("nav" in item.get("properties", "").split() for item in items.values()):
+            problems.append(f"{opf_path} has no nav document")
+        spine = package.find(f"{{{OPF_NS}}}spine")
+        ncx_item = items.get(spine.get("toc", "")) if spine is not None else None
+        if ncx_item is None or ncx_item.get("media-type") != NCX_MEDIA_TYPE:
+            problems.append(f"{opf_path} has no NCX")
+        for itemref in spine.iterfind(f"{{{OPF_NS}}}itemref") if spine is not None else ():
+            if itemref.get("idref") not in items:
+                problems.append(f"spine entry {itemref.get('idref')} is not in the manifest")
+        ncx = parsed.get(prefix + ncx_item.get("href", "")) if ncx_item is not None else None
+        for content in ncx.iter(f"{{{NCX_NS}}}content") if ncx is not None else ():
+            target = prefix + content.get("src", "").split("#", 1)[0]
+            if target not in names:
+                problems.append(f"NCX entry {content.get('src')} points at a missing file")
+        return problems
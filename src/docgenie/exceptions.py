@@ -66,3 +66,9 @@ class GeneratorError(DocGenieError):
     """Raised when documentation generation fails."""
 
     pass
+
+
+class RemoteRepoError(DocGenieError):
+    """Raised when a remote git repository cannot be checked out."""
+
+    pass
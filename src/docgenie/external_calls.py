@@ -0,0 +1,109 @@
+"""Tell calls into repository code apart from calls into imported packages.
+
+A call is external when it is qualified by the name an external import is used
+under (`redis.NewClient`, `json.dumps`), where an import is external unless it
+resolves to an analyzed file or names a directory or module of the repository.
+Without this, `redis.NewClient` would count as a reference to a repository
+function that happens to be called `NewClient`.
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable, Mapping
+from pathlib import Path, PurePosixPath
+from typing import Any
+
+from .module_index import relative_path
+
+# Go major-version suffixes (`/v9`, `yaml.v3`) are not part of the package name.
+_VERSION_RE = re.compile(r"(?:^|\.)v\d+$")
+_IDENT_RE = re.compile(r"^[A-Za-z_]\w*$")
+
+
+def local_modules(paths: Iterable[str]) -> set[str]:
+    """Return every directory and extension-less file path of the analyzed `paths`."""
+    modules: set[str] = set()
+    for path in paths:
+        posix = PurePosixPath(path)
+        modules.add(str(posix.with_suffix("")))
+        modules.update(str(parent) for parent in posix.parents if str(parent) != ".")
+    return modules
+
+
+def is_external_import(spec: str, file_imports: Mapping[str, Any], local: set[str]) -> bool:
+    """Return whether import `spec` leaves the repository.
+
+    Resolved imports are analyzed file paths. Otherwise `spec` is internal when it
+    and a local module end the same way, by path (`example.com/app/services` and
+    `services/`) or dotted name (`pkg.calc` and `src/pkg/calc.py`). Ambiguous names
+    count as internal, which leaves their calls as ordinary references.
+    """
+    if spec in file_imports or spec.startswith((".", "/")):
+        return False
+    path = spec if "/" in spec else spec.replace(".", "/")
+    return not any(
+        path == module or path.endswith(f"/{module}") or module.endswith(f"/{path}")
+        for module in local
+    )
+
+
+def import_aliases(spec: str) -> set[str]:
+    """Return the names code qualifies calls into `spec` with.
+
+    Slash paths are used by their last segment without Go conventions
+    (`github.com/redis/go-redis/v9` is `redis`); dotted names by their first or
+    last segment (`os.path`, `java.util.List`).
+    """
+    if "/" in spec:
+        segments = [part for part in spec.split("/") if part and not _VERSION_RE.fullmatch(part)]
+        if not segments:
+            return set()
+        name = _VERSION_RE.sub("", segments[-1])
+        name = re.sub(r"^go-|[-.]go$", "", name).replace("-", "_")
+        return {name} if _IDENT_RE.match(name) else set()
+    parts = spec.split(".")
+    return {part for part in (parts[0], parts[-1]) if _IDENT_RE.match(part)}
+
+
+def external_aliases(file_imports: Mapping[str, Any]) -> dict[str, set[str]]:
+    """Return, per analyzed file, the qualifiers of calls into its external imports."""
+    local = local_modules(file_imports)
+    aliases: dict[str, set[str]] = {}
+    for path, imports in file_imports.items():
+        names: set[str] = set()
+        for spec in imports if isinstance(imports, (list, set, tuple)) else []:
+            if is_external_import(str(spec), file_imports, local):
+                names.update(import_aliases(str(spec)))
+        if names:
+            aliases[str(path)] = names
+    return aliases
+
+
+def qualified_call_re(aliases: Iterable[str]) -> re.Pattern[str]:
+    """Match `alias.Name(` for any of `aliases`; group `call` is the qualified name."""
+    names = "|".join(sorted(map(re.escape, aliases)))
+    return re.compile(rf"(?<![\w$.])(?P<call>(?:{names})\s*\.\s*[A-Za-z_$][\w$]*)\s*\(")
+
+
+def scan_external_calls(
+    root_path: Path, files: Iterable[Path], aliases: Mapping[str, set[str]]
+) -> dict[str, list[str]]:
+    """Return, per file, the external calls made in it as sorted `alias.Name` strings."""
+    calls: dict[str, list[str]] = {}
+    for path in sorted(files):
+        module = relative_path(root_path, str(path))
+        if not aliases.get(module):
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        found = {
+            "".join(match.group("call").split())
+            for match in qualified_call_re(aliases[module]).finditer(content)
+        }
+        if found:
+            calls[module] = sorted(found)
+    return calls
+
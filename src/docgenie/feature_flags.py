@@ -0,0 +1,113 @@
+"""Detect feature flag lookups such as `flags.Enabled("beta")` in source files.
+
+A flag pattern is the dotted name of the lookup call, where `*` stands for any one
+identifier: `featureflags.IsOn` or `*.BoolVariation` (LaunchDarkly clients are
+usually a local variable). A bare method name as common as `variation` is only
+matched on a known receiver (`ldClient.variation`). The first argument of a matching
+call is the flag name.
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable, Sequence
+from pathlib import Path
+from typing import Any
+
+from .languages._scan import split_top_level
+from .module_index import relative_path
+from .routes import call_arguments
+from .utils import get_file_language
+
+DEFAULT_FLAG_PATTERNS = (
+    "flags.Enabled",
+    "flags.IsEnabled",
+    "featureflags.IsOn",
+    "featureflags.Enabled",
+    "Flipper.enabled?",
+    "unleash.isEnabled",
+    "unleash.IsEnabled",
+    "*.BoolVariation",
+    "*.boolVariation",
+    "ldClient.variation",
+    "*.GetBooleanValue",
+    "*.getBooleanValue",
+    "*.get_boolean_value",
+)
+
+_LITERAL_RE = re.compile(r"""^(?:"([^"\\]*)"|'([^'\\]*)'|`([^`$]*)`|:([A-Za-z_]\w*[?!]?))$""")
+_COMMENT_RE = re.compile(r"^\s*(?:#|//|/\*|\*|--)")
+
+
+def flag_call_re(patterns: Iterable[str]) -> re.Pattern[str] | None:
+    """Compile `patterns` into one regex ending at the call's `(`, or None if empty."""
+    callees = [
+        r"\s*\.\s*".join(
+            r"[A-Za-z_$][\w$]*" if part == "*" else re.escape(part) for part in pattern.split(".")
+        )
+        for pattern in (str(item).strip() for item in patterns)
+        if pattern
+    ]
+    if not callees:
+        return None
+    return re.compile(rf"(?<![\w$.])(?:{'|'.join(callees)})\s*\(")
+
+
+def scan_feature_flags(
+    root_path: Path, files: Iterable[Path], patterns: Sequence[str] = DEFAULT_FLAG_PATTERNS
+) -> list[dict[str, Any]]:
+    """Return one entry per flag lookup with `name`, `file`, `line` and `dynamic`.
+
+    Lookups whose flag is not a string (or Ruby symbol) literal are kept with
+    `dynamic` set and the argument expression as `name`. Commented-out lines are skipped.
+    """
+    call_re = flag_call_re(patterns)
+    if call_re is None:
+        return []
+    markers = {pattern.rsplit(".", 1)[-1] for pattern in patterns}
+    lookups: list[dict[str, Any]] = []
+    for path in sorted(files):
+        if get_file_language(path) is None:
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        if not any(marker in content for marker in markers):
+            continue
+        rel = relative_path(root_path, str(path))
+        offset = 0
+        for number, line in enumerate(content.splitlines(keepends=True), start=1):
+            if not _COMMENT_RE.match(line):
+                for match in call_re.finditer(line):
+                    args, _ = call_arguments(content, offset + match.end())
+                    parts = split_top_level(args)
+                    flag = _flag_name(parts[0] if parts else "")
+                    lookups.append({**flag, "file": rel, "line": number})
+            offset += len(line)
+    return lookups
+
+
+def flag_groups(lookups: Iterable[dict[str, Any]]) -> list[dict[str, Any]]:
+    """Return one row per flag with the sorted modules referencing it.
+
+    Literal flags come first by name, then dynamic lookups by expression. Names are
+    returned as found; templates escape them for their table syntax.
+    """
+    flags: dict[tuple[bool, str], set[str]] = {}
+    for lookup in lookups:
+        name = str(lookup.get("name", ""))
+        key = (bool(lookup.get("dynamic")), name)
+        flags.setdefault(key, set()).add(str(lookup.get("file", "")))
+    return [
+        {"name": name, "dynamic": dynamic, "modules": sorted(modules)}
+        for (dynamic, name), modules in sorted(flags.items())
+    ]
+
+
+def _flag_name(argument: str) -> dict[str, Any]:
+    match = _LITERAL_RE.match(argument.strip())
+    if match is None:
+        return {"name": " ".join(argument.split()) or "?", "dynamic": True}
+    name = next(group for group in match.groups() if group is not None)
+    return {"name": name, "dynamic": False}
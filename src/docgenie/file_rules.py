@@ -0,0 +1,105 @@
+"""Include/exclude glob rules deciding which files are scanned."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable
+from dataclasses import dataclass
+from typing import Any
+
+from .utils import posix_path
+
+EXCLUDED_REASON = "excluded_by_pattern"
+
+Layer = tuple[tuple[re.Pattern[str], ...], tuple[re.Pattern[str], ...]]
+
+
+def glob_regex(pattern: str) -> re.Pattern[str]:
+    """Compile a glob matched against root-relative POSIX paths.
+
+    `**` spans directories while `*`, `?` and `[...]` stay within one segment. A
+    pattern without a `/` matches at any depth (`*.pb.go`), and a pattern that names
+    a directory also matches everything below it (`vendor/`, `src/gen`).
+    Backslash separators from Windows are read as `/`.
+    """
+    text = posix_path(pattern.strip()).removeprefix("./").strip("/")
+    if "/" not in text:
+        text = f"**/{text}"
+    out: list[str] = []
+    idx = 0
+    while idx < len(text):
+        if text.startswith("**/", idx):
+            out.append("(?:.*/)?")
+            idx += 3
+        elif text.startswith("**", idx):
+            out.append(".*")
+            idx += 2
+        elif text[idx] == "*":
+            out.append("[^/]*")
+            idx += 1
+        elif text[idx] == "?":
+            out.append("[^/]")
+            idx += 1
+        elif text[idx] == "[" and (close := text.find("]", idx + 2)) > 0:
+            body = text[idx + 1 : close]
+            out.append("[" + ("^" + body[1:] if body.startswith("!") else body) + "]")
+            idx = close + 1
+        else:
+            out.append(re.escape(text[idx]))
+            idx += 1
+    return re.compile("".join(out) + "(?:/.*)?")
+
+
+@dataclass(frozen=True)
+class FileRules:
+    """Ordered layers of `(include, exclude)` globs, lowest precedence first.
+
+    Precedence: the last layer with a matching pattern decides, so `--include` and
+    `--exclude` on the command line override the `files` config section. Within one
+    layer an explicit exclude beats an include. When any layer lists includes, a file
+    matching no rule at all is excluded.
+    """
+
+    layers: tuple[Layer, ...] = ()
+
+    @classmethod
+    def from_globs(cls, layers: Iterable[tuple[Iterable[str], Iterable[str]]]) -> FileRules:
+        compiled = tuple(
+            (
+                tuple(glob_regex(str(glob)) for glob in include if str(glob).strip()),
+                tuple(glob_regex(str(glob)) for glob in exclude if str(glob).strip()),
+            )
+            for include, exclude in layers
+        )
+        return cls(tuple(layer for layer in compiled if layer[0] or layer[1]))
+
+    @classmethod
+    def from_config(cls, files_config: Any) -> FileRules:
+        """Build rules from the `files` section; CLI flags arrive under `files.overrides`."""
+        if not isinstance(files_config, dict):
+            return cls()
+        overrides = files_config.get("overrides")
+        overrides = overrides if isinstance(overrides, dict) else {}
+        return cls.from_globs(
+            (_globs(section.get("include")), _globs(section.get("exclude")))
+            for section in (files_config, overrides)
+        )
+
+    def excluded(self, rel_path: str, *, is_dir: bool = False) -> bool:
+        rel_path = posix_path(rel_path)
+        for position in range(len(self.layers) - 1, -1, -1):
+            include, exclude = self.layers[position]
+            if any(pattern.fullmatch(rel_path) for pattern in exclude):
+                # A later layer's include may still pick up files below this directory.
+                later = self.layers[position + 1 :]
+                return not (is_dir and any(layer[0] for layer in later))
+            if any(pattern.fullmatch(rel_path) for pattern in include):
+                return False
+        # Directories are walked even when only files below them are included.
+        return not is_dir and any(layer[0] for layer in self.layers)
+
+
+def _globs(value: Any) -> list[str]:
+    if isinstance(value, str):
+        return [value]
+    return [str(item) for item in value] if isinstance(value, list) else []
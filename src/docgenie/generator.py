@@ -2,42 +2,143 @@
 README generation functionality for DocGenie.
 """
 
-from datetime import datetime
 from pathlib import Path
-from typing import Any, Dict, List
-
-from jinja2 import Template
-
+from typing import Any, Callable, Dict, List
+from urllib.parse import quote
+
+from .autolink import autolink_docstrings
+from .complexity import complexity_warnings
+from .coverage import coverage_badge, coverage_table, coverage_warnings
+from .dead_code import LIMITATION_WARNING, find_unreferenced_symbols
+from .doc_links import link_module_signatures
+from .env_vars import env_var_groups, env_var_names
+from .feature_flags import flag_groups
+from .go_examples import examples_for, find_go_examples
+from .go_interfaces import find_go_implementations
+from .graph_export import mermaid_impact_graph, mermaid_layer_graph, plantuml_class_diagram
+from .html_sections import (
+    build_caller_index,
+    build_impact_graph_data,
+    symbol_anchors,
+    symbol_node_id,
+)
+from .layers import layer_report, layer_warnings
 from .logging import get_logger
+from .module_index import (
+    DEFAULT_VISIBILITY,
+    build_module_index,
+    concurrency_note,
+    error_note,
+    field_table,
+    is_exported,
+    is_visible,
+    package_of,
+    relative_path,
+    symbol_visibility,
+)
+from .readme_quality import (
+    build_quality_report,
+    current_version,
+    deprecation_replacement,
+    deprecation_warnings,
+    deprecations,
+    is_deprecated,
+    resolve_score_weights,
+    undocumented_symbols,
+)
 from .redaction import redact_text
-from .utils import create_directory_tree, get_project_type, is_website_project
+from .regions import merge_regions
+from .reproducible import build_time, path_root, relative_paths
+from .routes import link_route_handlers
+from .tech_debt import DEFAULT_DEBT_MARKERS, debt_groups, debt_warnings
+from .tested_symbols import find_tested_symbols, tested_symbol_warnings
+from .templating import (
+    ADOC_TEMPLATE,
+    CONFLUENCE_TEMPLATE,
+    README_TEMPLATE,
+    load_template,
+    template_dir_from_config,
+)
+from .toc import insert_toc, toc_settings
+from .utils import create_directory_tree, get_project_type, is_website_project, posix_path
 
 
 class ReadmeGenerator:
     """
     Generates comprehensive README.md files based on codebase analysis.
-    """
 
-    def __init__(self) -> None:
-        self.template = self._get_template()
+    With `share_context`, the template context is prepared once per analysis and output
+    format, so rendering several formats from one analysis builds the API docs, module
+    index and quality report once. The analysis must not change between renders, except
+    for `readme_readiness`, which is read again on every render.
+    """
 
-    def generate(self, analysis_data: Dict[str, Any], output_path: str | None = None) -> str:
+    def __init__(self, template_dir: Path | None = None, share_context: bool = False) -> None:
+        self.template_dir = template_dir
+        self.share_context = share_context
+        self._contexts: Dict[str, Dict[str, Any]] = {}
+        self._context_source: Dict[str, Any] | None = None
+
+    def generate(
+        self,
+        analysis_data: Dict[str, Any],
+        output_path: str | None = None,
+        output_format: str = "markdown",
+        include_toc: bool = True,
+        include_badges: bool = True,
+        reuse_module: Callable[[Dict[str, Any]], bool] | None = None,
+    ) -> str:
         """
         Generate README content based on analysis data.
 
+        Templates come from `template_dir` (or `template_customizations.template_dir`)
+        when it contains `readme.md.j2` / `readme.adoc.j2` / `readme.confluence.j2`,
+        else from the built-ins.
+
         Args:
             analysis_data: Results from CodebaseAnalyzer
             output_path: Optional path to save the README file
+            output_format: "markdown" (default), "adoc" for AsciiDoc or "confluence"
+                for Confluence storage-format XHTML
+            include_toc: Insert a Markdown table of contents; HTML output passes False
+                and renders the TOC in its sidebar instead
+            include_badges: Render the badge block under the title; HTML output passes
+                False and draws the badges itself
+            reuse_module: Called with each `modules` entry of the template context;
+                entries it accepts are rendered as their bare "### `path`" heading, for
+                `HTMLGenerator` to splice in the section it rendered last time
+
+        When `output_path` is an existing Markdown file with `<!-- docgenie:begin SECTION -->`
+        markers, only the marked regions are rewritten; see `regions.merge_regions`.
 
         Returns:
             Generated README content as string
         """
+        templates = {
+            "markdown": README_TEMPLATE,
+            "adoc": ADOC_TEMPLATE,
+            "confluence": CONFLUENCE_TEMPLATE,
+        }
+        if output_format not in templates:
+            raise ValueError(f"Unsupported README format: {output_format}")
+        template_dir = self.template_dir or template_dir_from_config(analysis_data)
+        template = load_template(templates[output_format], template_dir)
+
         # Prepare template context
-        context = self._prepare_context(analysis_data)
+        context = self._context(analysis_data, output_format)
+        if not include_badges:
+            context["badges"] = []
+        if reuse_module is not None:
+            context["modules"] = [
+                {"path": module["path"]} if reuse_module(module) else module
+                for module in context["modules"]
+            ]
 
         # Render template
-        readme_content = self.template.render(**context)
+        readme_content = template.render(context)
         config = analysis_data.get("config", {})
+        if output_format == "markdown" and include_toc and context["toc"] is not None:
+            readme_content = insert_toc(readme_content, **context["toc"])
         safety = config.get("safety", {}) if isinstance(config, dict) else {}
         redaction_mode = str(safety.get("redaction_mode", "strict"))
         patterns = safety.get("redact_patterns", []) if isinstance(safety, dict) else []
@@ -48,6 +149,12 @@ class ReadmeGenerator:
         )
         # Save to file if path provided
         if output_path:
+            target = Path(output_path)
+            if output_format == "markdown" and target.is_file():
+                existing = target.read_text(encoding="utf-8")
+                merged = merge_regions(existing, readme_content, target)
+                if merged is not None:
+                    readme_content = merged
             with open(output_path, "w", encoding="utf-8") as f:
                 f.write(readme_content)
 
@@ -62,10 +169,31 @@ class ReadmeGenerator:
 
         return readme_content
 
-    def _prepare_context(self, analysis_data: Dict[str, Any]) -> Dict[str, Any]:
-        """Prepare template context from analysis data."""
+    def _context(self, analysis_data: Dict[str, Any], output_format: str) -> Dict[str, Any]:
+        if not self.share_context:
+            return self._prepare_context(analysis_data, output_format)
+        if self._context_source is not analysis_data:
+            self._context_source = analysis_data
+            self._contexts = {}
+        if output_format not in self._contexts:
+            self._contexts[output_format] = self._prepare_context(analysis_data, output_format)
+        context = dict(self._contexts[output_format])
+        context["readme_readiness"] = relative_paths(
+            analysis_data.get("readme_readiness", {}), path_root(analysis_data)
+        )
+        return context
+
+    def _prepare_context(
+        self, analysis_data: Dict[str, Any], output_format: str = "markdown"
+    ) -> Dict[str, Any]:
+        """Prepare template context from analysis data.
+
+        `output_format` only affects the link syntax used when `autolink` is enabled.
+        """
         # Basic project info
         project_name = self._get_project_name(analysis_data)
+        overview = analysis_data.get("project_overview")
+        overview = overview if isinstance(overview, dict) else {}
         project_type = get_project_type(analysis_data)
         is_website = is_website_project(analysis_data)
 
@@ -78,7 +206,10 @@ class ReadmeGenerator:
 
         include_directory_tree = template_customizations.get("include_directory_tree", True)
         include_api_docs = template_customizations.get("include_api_docs", True)
+        include_module_index = template_customizations.get("include_module_index", True)
+        collapse_modules = bool(template_customizations.get("collapse_modules", False))
         include_trust_badges = template_customizations.get("include_trust_badges", True)
+        graph_format = str(template_customizations.get("graph_format", "none")).lower()
 
         # Language statistics
         languages = analysis_data.get("languages", {})
@@ -103,11 +234,14 @@ class ReadmeGenerator:
         # Usage examples
         usage_examples = self._generate_usage_examples(analysis_data)
 
+        run_metrics = analysis_data.get("run_metrics", {})
+        coverage = run_metrics.get("coverage") if isinstance(run_metrics, dict) else None
+
         quality_config = config.get("quality", {}) if isinstance(config, dict) else {}
         quality_enabled = bool(quality_config.get("confidence_enabled", True))
         min_confidence = str(quality_config.get("min_confidence_for_api_docs", "low")).lower()
         quality = (
-            self._build_quality_report(analysis_data)
+            self.quality_report(analysis_data)
             if quality_enabled
             else {
                 "score": 100,
@@ -120,19 +254,42 @@ class ReadmeGenerator:
             str(quality["confidence"]).lower(), 0
         ) >= confidence_rank.get(min_confidence, 0)
 
+        tested_config = config.get("tested_symbols", {}) if isinstance(config, dict) else {}
+        tested_config = tested_config if isinstance(tested_config, dict) else {}
+        tested = self._tested_report(analysis_data, tested_config)
+        layers = layer_report(analysis_data)
+
         # API documentation
+        go_examples = find_go_examples(analysis_data)
         if include_api_docs and not is_website and allow_api:
             api_docs = self._generate_api_docs(
-                functions, classes, config if isinstance(config, dict) else {}
+                functions,
+                classes,
+                config if isinstance(config, dict) else {},
+                implementations=find_go_implementations(analysis_data),
+                examples=go_examples["symbols"],
+                visibility=symbol_visibility(analysis_data),
+                callers=build_caller_index(
+                    analysis_data,
+                    max_callers=int(template_customizations.get("max_callers", 10)),
+                ),
+                root_path=path_root(analysis_data),
+                anchors=symbol_anchors(analysis_data),
             )
         else:
             api_docs = {"functions": [], "classes": []}
+        # Confluence escapes docstrings as text, so Markdown-style links would show literally.
+        if template_customizations.get("autolink", False) and output_format != "confluence":
+            autolink_docstrings(api_docs, output_format=output_format)
 
-        return {
+        context: Dict[str, Any] = {
             "project_name": project_name,
             "project_type": project_type,
             "is_website": is_website,
-            "description": self._generate_description(analysis_data),
+            "description": overview.get("description")
+            or self._generate_description(analysis_data),
+            "tagline": overview.get("tagline"),
+            "project_links": overview.get("links", []),
             "languages": languages,
             "main_language": main_language,
             "total_files": analysis_data.get("files_analyzed", 0),
@@ -144,22 +301,287 @@ class ReadmeGenerator:
             "install_commands": install_commands,
             "usage_examples": usage_examples,
             "api_docs": api_docs,
+            "go_examples": self._general_examples(go_examples, api_docs),
+            "analysis_quality": quality["score"],
+            "confidence_level": quality["confidence"],
+            "analysis_warnings": list(quality["warnings"])
+            + deprecation_warnings(analysis_data, current_version(config))
+            + complexity_warnings(analysis_data)
+            + coverage_warnings(coverage)
+            + self._tech_debt_warnings(analysis_data, config)
+            + tested_symbol_warnings(
+                tested, float(tested_config.get("max_untested_ratio", 0.5))
+            )
+            + layer_warnings(layers),
+            "modules": link_module_signatures(build_module_index(analysis_data), analysis_data)
+            if include_module_index
+            else [],
+            "collapse_modules": collapse_modules,
+            "dependency_graph": mermaid_impact_graph(analysis_data)
+            if graph_format == "mermaid"
+            else None,
+            "class_diagram": plantuml_class_diagram(analysis_data)
+            if graph_format == "plantuml"
+            else None,
             "features": self._extract_features(analysis_data),
             "requirements": self._extract_requirements(dependencies),
-            "generated_date": datetime.now().strftime("%Y-%m-%d %H:%M:%S"),
+            "generated_date": build_time().strftime("%Y-%m-%d %H:%M:%S"),
             "has_tests": self._has_tests(analysis_data),
             "has_docs": len(analysis_data.get("documentation_files", [])) > 0,
             "config_files": analysis_data.get("config_files", []),
+            "env_vars": env_var_groups(analysis_data.get("env_vars", [])),
+            "env_var_names": env_var_names(analysis_data.get("env_vars", [])),
             "packages": analysis_data.get("packages", []),
-            "run_metrics": analysis_data.get("run_metrics", {}),
+            "run_metrics": run_metrics,
+            "coverage": self._coverage_context(coverage),
+            "badges": self.badges(analysis_data),
+            "toc": toc_settings(config),
             "website_info": self._get_website_info(analysis_data) if is_website else None,
             "diff_summary": analysis_data.get("diff_summary", {}),
             "folder_reviews": analysis_data.get("folder_reviews", []),
             "file_reviews": analysis_data.get("file_reviews", []),
             "output_links": analysis_data.get("output_links", []),
+            "http_routes": link_route_handlers(analysis_data.get("http_routes", []), api_docs),
+            "unreferenced": self._unreferenced_symbols(analysis_data, config),
+            "undocumented": self._undocumented_api(analysis_data, config),
+            "tested_symbols": self._tested_symbols(tested, tested_config),
+            "circular_dependencies": self._circular_dependencies(analysis_data),
+            "architecture_layers": {**layers, "diagram": mermaid_layer_graph(layers)}
+            if layers
+            else None,
+            "tech_debt": self._tech_debt(analysis_data, config),
+            "deprecations": deprecations(analysis_data, current_version(config)),
+            "feature_flags": flag_groups(analysis_data.get("feature_flags", []) or []),
+            "database_schema": analysis_data.get("database_schema") or {},
+            "grpc_api": analysis_data.get("grpc_api") or {},
+            "make_targets": analysis_data.get("make_targets") or [],
+            "plugin_sections": analysis_data.get("plugin_sections", []),
             "readme_readiness": analysis_data.get("readme_readiness", {}),
             "trust": self._build_trust_badges(analysis_data, enabled=bool(include_trust_badges)),
         }
+        # Absolute paths would leak the checkout location and differ between machines.
+        return relative_paths(context, path_root(analysis_data))
+
+    def badges(self, analysis_data: Dict[str, Any]) -> List[Dict[str, str]]:
+        """Return the badge block shown under the README title.
+
+        Empty when `template_customizations.include_badges` is off. The quality badge is
+        left out when `quality.confidence_enabled` is off.
+        """
+        config = analysis_data.get("config", {})
+        if not isinstance(config, dict):
+            config = {}
+        customizations = config.get("template_customizations", {})
+        if isinstance(customizations, dict) and not customizations.get("include_badges", True):
+            return []
+        quality_config = config.get("quality", {})
+        quality_enabled = not isinstance(quality_config, dict) or bool(
+            quality_config.get("confidence_enabled", True)
+        )
+        quality_score = (
+            self.quality_report(analysis_data)["score"] if quality_enabled else None
+        )
+        return self._build_badges(analysis_data, quality_score=quality_score)
+
+    def _build_badges(
+        self, analysis_data: Dict[str, Any], *, quality_score: int | None
+    ) -> List[Dict[str, str]]:
+        """Build shields.io badges for license, primary language, public symbols and quality."""
+
+        def badge(label: str, message: str, color: str, **extra: str) -> Dict[str, str]:
+            # shields.io reads `-` and `_` as separators in the path; doubling escapes them.
+            parts = (label, message, color)
+            path = "-".join(
+                quote(part.replace("-", "--").replace("_", "__"), safe="") for part in parts
+            )
+            return {
+                "label": label,
+                "message": message,
+                "color": color,
+                "url": f"https://img.shields.io/badge/{path}",
+                "title": extra.get("title", f"{label}: {message}"),
+                "link": extra.get("link", ""),
+            }
+
+        badges: List[Dict[str, str]] = []
+        license_info = analysis_data.get("license")
+        if isinstance(license_info, dict) and license_info.get("spdx_id"):
+            badges.append(
+                badge(
+                    "license",
+                    str(license_info["spdx_id"]),
+                    "blue",
+                    link=str(license_info.get("file", "")),
+                )
+            )
+
+        counts = {
+            str(lang): int(count)
+            for lang, count in (analysis_data.get("languages") or {}).items()
+            if int(count) > 0
+        }
+        if counts:
+            total = sum(counts.values())
+            ranked = sorted(counts.items(), key=lambda kv: (-kv[1], kv[0]))
+            breakdown = ", ".join(f"{lang} {count * 100 / total:.0f}%" for lang, count in ranked)
+            primary, count = ranked[0]
+            badges.append(
+                badge(
+                    "language",
+                    f"{primary} {count * 100 / total:.0f}%",
+                    "informational",
+                    title=f"Files by language: {breakdown}",
+                )
+            )
+
+        public = [
+            item
+            for item in list(analysis_data.get("functions", []))
+            + list(analysis_data.get("classes", []))
+            if isinstance(item, dict) and is_exported(item)
+        ]
+        badges.append(badge("public symbols", str(len(public)), "informational"))
+
+        if quality_score is not None:
+            if quality_score >= 80:  # noqa: PLR2004
+                color = "brightgreen"
+            elif quality_score >= 60:  # noqa: PLR2004
+                color = "yellow"
+            else:
+                color = "red"
+            badges.append(badge("docs quality", f"{quality_score}/100", color))
+        return badges
+
+    def _coverage_context(self, coverage: Any) -> Dict[str, Any] | None:
+        """Return the coverage badge and per-module rows, or None when no report was given."""
+        badge = coverage_badge(coverage)
+        if badge is None:
+            return None
+        return {
+            "badge": badge,
+            "percent": coverage["percent"],
+            "covered": coverage["covered"],
+            "total": coverage["total"],
+            "threshold": coverage.get("threshold"),
+            "report": coverage.get("report", ""),
+            "modules": coverage_table(coverage),
+        }
+
+    def _unreferenced_symbols(
+        self, analysis_data: Dict[str, Any], config: Any
+    ) -> Dict[str, Any] | None:
+        """Return the capped Unreferenced Symbols listing, or None when disabled or empty."""
+        dead_config = config.get("dead_code", {}) if isinstance(config, dict) else {}
+        if not isinstance(dead_config, dict) or not dead_config.get("enabled", True):
+            return None
+        # Without the reference scan every symbol would look unused.
+        if "symbol_references" not in analysis_data:
+            return None
+        ignore = [str(pattern) for pattern in dead_config.get("ignore", []) or []]
+        symbols = find_unreferenced_symbols(analysis_data, ignore=ignore)
+        if not symbols:
+            return None
+        limit = max(int(dead_config.get("max_listed", 50) or 0), 0)
+        return {
+            "symbols": symbols[:limit],
+            "remaining": max(len(symbols) - limit, 0),
+            "warning": LIMITATION_WARNING,
+        }
+
+    def _tested_report(
+        self, analysis_data: Dict[str, Any], tested_config: Dict[str, Any]
+    ) -> Dict[str, Any] | None:
+        if not tested_config.get("enabled", True):
+            return None
+        # Without the reference scan every symbol would look untested.
+        if "symbol_references" not in analysis_data:
+            return None
+        return find_tested_symbols(analysis_data)
+
+    def _tested_symbols(
+        self, report: Dict[str, Any] | None, tested_config: Dict[str, Any]
+    ) -> Dict[str, Any] | None:
+        """Return the Tested Symbols ratio with a capped list of untested symbols."""
+        if not report:
+            return None
+        limit = max(int(tested_config.get("max_listed", 50) or 0), 0)
+        return {
+            "tested": report["tested"],
+            "total": report["total"],
+            "percent": round(report["ratio"] * 100),
+            "untested": report["untested"][:limit],
+            "remaining": max(len(report["untested"]) - limit, 0),
+        }
+
+    def _undocumented_api(
+        self, analysis_data: Dict[str, Any], config: Any
+    ) -> Dict[str, Any] | None:
+        """Return exported symbols without doc comments grouped by module, or None when none.
+
+        Uses the same symbol set as the quality score's docstring coverage.
+        """
+        symbols = undocumented_symbols(
+            analysis_data.get("functions", []),
+            analysis_data.get("classes", []),
+            symbol_visibility(analysis_data),
+        )
+        if not symbols:
+            return None
+        quality = config.get("quality", {}) if isinstance(config, dict) else {}
+        limit = quality.get("max_undocumented_listed", 50) if isinstance(quality, dict) else 50
+        limit = max(int(limit or 0), 0)
+        root = path_root(analysis_data)
+        listed = sorted(
+            (
+                relative_path(root, str(item.get("file", ""))),
+                int(item.get("line", 0) or 0),
+                str(item.get("name", "")),
+                str(item.get("kind") or "function"),
+            )
+            for item in symbols
+        )
+        modules: Dict[str, List[Dict[str, Any]]] = {}
+        for module, line, name, kind in listed[:limit]:
+            modules.setdefault(module, []).append({"name": name, "kind": kind, "line": line})
+        return {
+            "modules": [{"module": module, "symbols": items} for module, items in modules.items()],
+            "total": len(symbols),
+            "remaining": max(len(symbols) - limit, 0),
+        }
+
+    def _circular_dependencies(self, analysis_data: Dict[str, Any]) -> Dict[str, Any] | None:
+        """Return the capped Circular Dependencies listing, or None when the graph is acyclic."""
+        graph = build_impact_graph_data(analysis_data)
+        total = int(graph.get("total_cycles", 0))
+        if not total:
+            return None
+        cycles = [
+            {
+                # Node IDs are `<type>:<name>`; the README only needs the name.
+                "path": [node.split(":", 1)[-1] for node in cycle["path"]],
+                "size": len(cycle["members"]),
+            }
+            for cycle in graph["cycles"]
+        ]
+        shown = len(cycles)
+        note = f"Showing {shown}/{total} cycles, largest first." if total > shown else None
+        return {"cycles": cycles, "total": total, "note": note}
+
+    def _tech_debt(self, analysis_data: Dict[str, Any], config: Any) -> Dict[str, Any] | None:
+        """Return the Technical Debt listing grouped by marker, or None when nothing was found."""
+        items = analysis_data.get("tech_debt", []) or []
+        if not items:
+            return None
+        debt_config = config.get("tech_debt", {}) if isinstance(config, dict) else {}
+        markers = debt_config.get("markers") if isinstance(debt_config, dict) else None
+        order = [str(marker) for marker in markers or DEFAULT_DEBT_MARKERS]
+        return {"groups": debt_groups(items, order), "total": len(items)}
+
+    def _tech_debt_warnings(self, analysis_data: Dict[str, Any], config: Any) -> List[str]:
+        debt_config = config.get("tech_debt", {}) if isinstance(config, dict) else {}
+        threshold = debt_config.get("warning_threshold") if isinstance(debt_config, dict) else None
+        items = analysis_data.get("tech_debt", []) or []
+        return debt_warnings(items, None if threshold is None else int(threshold))
 
     def generate_package_docs(
         self, analysis_data: Dict[str, Any], output_dir: Path
@@ -180,12 +602,12 @@ class ReadmeGenerator:
             package_data["functions"] = [
                 f
                 for f in analysis_data.get("functions", [])
-                if str(f.get("file", "")).startswith(str(abs_pkg))
+                if posix_path(f.get("file", "")).startswith(posix_path(abs_pkg))
             ]
             package_data["classes"] = [
                 c
                 for c in analysis_data.get("classes", [])
-                if str(c.get("file", "")).startswith(str(abs_pkg))
+                if posix_path(c.get("file", "")).startswith(posix_path(abs_pkg))
             ]
             package_data["files_analyzed"] = len(package_data["functions"]) + len(
                 package_data["classes"]
@@ -196,56 +618,18 @@ class ReadmeGenerator:
             artifacts[pkg_path] = content
         return artifacts
 
-    def _build_quality_report(self, analysis_data: Dict[str, Any]) -> Dict[str, Any]:
+    def quality_report(self, analysis_data: Dict[str, Any]) -> Dict[str, Any]:
         """Compute simple quality/confidence signals for generated docs."""
-        files_analyzed = int(analysis_data.get("files_analyzed", 0) or 0)
-        languages = analysis_data.get("languages", {})
-        functions = analysis_data.get("functions", [])
-        classes = analysis_data.get("classes", [])
-        dependencies = analysis_data.get("dependencies", {})
-
-        score = 30
-        warnings: list[str] = []
-
-        if files_analyzed >= 20:
-            score += 20
-        elif files_analyzed >= 5:
-            score += 10
-        else:
-            warnings.append("Low file count analyzed. Results may be incomplete.")
-
-        if len(languages) >= 2:
-            score += 15
-        elif len(languages) == 1:
-            score += 8
-        else:
-            warnings.append("No recognized source languages detected.")
-
-        if len(functions) + len(classes) >= 10:
-            score += 20
-        elif len(functions) + len(classes) >= 1:
-            score += 10
-        else:
-            warnings.append("No functions/classes were extracted from source files.")
-
-        if dependencies:
-            score += 10
-        else:
-            warnings.append("No dependency metadata files were detected.")
-
-        if self._has_tests(analysis_data):
-            score += 5
-        else:
-            warnings.append("No tests detected. Generated usage guidance may need manual review.")
-
-        score = max(0, min(score, 100))
-        if score >= 75:
-            confidence = "High"
-        elif score >= 50:
-            confidence = "Medium"
-        else:
-            confidence = "Low"
-        return {"score": score, "confidence": confidence, "warnings": warnings}
+        config = analysis_data.get("config", {})
+        quality_config = config.get("quality", {}) if isinstance(config, dict) else {}
+        overrides = (
+            quality_config.get("score_weights") if isinstance(quality_config, dict) else None
+        )
+        return build_quality_report(
+            analysis_data,
+            has_tests=self._has_tests(analysis_data),
+            weights=resolve_score_weights(overrides),
+        )
 
     def _get_project_name(self, analysis_data: Dict[str, Any]) -> str:
         """Extract project name from various sources."""
@@ -406,15 +790,40 @@ class ReadmeGenerator:
         return examples
 
     def _generate_api_docs(
-        self, functions: List[Dict], classes: List[Dict], config: Dict[str, Any]
+        self,
+        functions: List[Dict],
+        classes: List[Dict],
+        config: Dict[str, Any],
+        *,
+        implementations: Dict[tuple[str, str], List[Dict[str, str]]] | None = None,
+        callers: Dict[str, Dict[str, Any]] | None = None,
+        examples: Dict[tuple[str, str], List[Dict[str, Any]]] | None = None,
+        root_path: Path = Path("."),
+        visibility: str = DEFAULT_VISIBILITY,
+        anchors: Dict[tuple[str, str, int], str] | None = None,
     ) -> Dict[str, Any]:
-        """Generate API documentation from functions and classes."""
+        """Generate API documentation from functions and classes.
+
+        `implementations` maps `(module, interface)` to the Go types satisfying it,
+        `callers` maps symbol graph IDs to their "Called By" listing and `examples`
+        maps `(package, symbol)` to the Go example functions demonstrating it.
+        `anchors` comes from `symbol_anchors`; each entry gets its `anchor` from it.
+        Private symbols are left out unless `visibility` is `all`. Go methods declared
+        in another file than their receiver type are listed under that type.
+        """
         api_docs: Dict[str, Any] = {"functions": [], "classes": []}
 
         max_funcs = config.get("template_customizations", {}).get("max_functions_documented", 10)
-
-        # Document main functions (limit to avoid overwhelming)
-        main_functions = [f for f in functions if not f["name"].startswith("_")][:max_funcs]
+        main_classes = [c for c in classes if is_visible(c, visibility)][:10]
+        receiver_methods, nested = self._receiver_methods(functions, main_classes, root_path)
+
+        # Document main functions (limit to avoid overwhelming); Go examples are shown
+        # under the symbols they demonstrate instead.
+        main_functions = [
+            f
+            for f in functions
+            if is_visible(f, visibility) and not f.get("example") and id(f) not in nested
+        ][:max_funcs]
         for func in main_functions:
             doc = {
                 "name": func["name"],
@@ -424,23 +833,110 @@ class ReadmeGenerator:
                 "args": func.get("args", []),
                 "decorators": func.get("decorators", []),
             }
+            module = relative_path(root_path, str(doc["file"]))
+            key = (module, func["name"], int(doc["line"] or 0))
+            doc["anchor"] = (anchors or {}).get(key, "")
+            doc["called_by"] = (callers or {}).get(symbol_node_id(module, func["name"]))
+            doc["errors"] = error_note(func.get("errors"))
+            doc["concurrency"] = concurrency_note(func)
+            doc["usage"] = func.get("usage")
+            doc["examples"] = examples_for(examples or {}, module, func["name"])
+            doc["deprecated"] = is_deprecated(func)
+            doc["replacement"] = deprecation_replacement(func)
             api_docs["functions"].append(doc)
 
         # Document main classes (limit to avoid overwhelming)
-        main_classes = [c for c in classes if not c["name"].startswith("_")][:10]
         for cls in main_classes:
             doc = {
                 "name": cls["name"],
                 "file": cls.get("file", ""),
                 "line": cls.get("line", 0),
                 "docstring": cls.get("docstring", ""),
-                "methods": cls.get("methods", [])[:5],  # Limit methods shown
+                "methods": [
+                    method
+                    for method in [*cls.get("methods", []), *receiver_methods.get(id(cls), [])]
+                    if not isinstance(method, dict) or is_visible(method, visibility)
+                ][:5],  # Limit methods shown
                 "bases": cls.get("bases", []),
             }
+            fields = field_table(cls.get("fields", []))
+            doc["fields"] = fields["rows"]
+            doc["serialization"] = fields["serialization"]
+            doc["field_descriptions"] = fields["descriptions"]
+            module = relative_path(root_path, str(doc["file"]))
+            key = (module, cls["name"], int(doc["line"] or 0))
+            doc["anchor"] = (anchors or {}).get(key, "")
+            doc["implementations"] = (implementations or {}).get((module, cls["name"]), [])
+            doc["called_by"] = (callers or {}).get(symbol_node_id(module, cls["name"]))
+            doc["examples"] = examples_for(
+                examples or {}, module, cls["name"], cls.get("methods", [])
+            )
+            doc["deprecated"] = is_deprecated(cls)
+            doc["replacement"] = deprecation_replacement(cls)
+            # Methods declared in other files lock the type's mutex just the same.
+            doc["concurrency"] = concurrency_note(
+                {**cls, "methods": [*cls.get("methods", []), *receiver_methods.get(id(cls), [])]}
+            )
+            doc["deprecated_methods"] = [
+                method.get("name") for method in doc["methods"] if is_deprecated(method)
+            ]
             api_docs["classes"].append(doc)
 
         return api_docs
 
+    def _receiver_methods(
+        self, functions: List[Dict], classes: List[Dict], root_path: Path
+    ) -> tuple[Dict[int, List[Dict[str, Any]]], set[int]]:
+        """Return Go methods declared apart from their receiver type, by `id()` of the class.
+
+        The parser reports them as `Type.Method` functions; here they get their bare
+        method name so they read like the methods declared next to the type. The second
+        value holds the `id()` of each function moved this way.
+        """
+        types = {
+            (package_of(relative_path(root_path, str(cls.get("file", "")))), cls["name"]): cls
+            for cls in classes
+            if str(cls.get("file", "")).endswith(".go")
+        }
+        found: Dict[int, List[Dict[str, Any]]] = {}
+        moved: set[int] = set()
+        for func in functions:
+            receiver = func.get("receiver")
+            if func.get("kind") != "method" or not receiver:
+                continue
+            type_name = str(receiver).lstrip("*").split("[", 1)[0]
+            package = package_of(relative_path(root_path, str(func.get("file", ""))))
+            cls = types.get((package, type_name))
+            if cls is not None:
+                method = {**func, "name": str(func["name"]).rsplit(".", 1)[-1]}
+                found.setdefault(id(cls), []).append(method)
+                moved.add(id(func))
+        return found, moved
+
+    def _general_examples(
+        self, go_examples: Dict[str, Any], api_docs: Dict[str, Any]
+    ) -> List[Dict[str, Any]]:
+        """Return the Go examples not already shown under an API Reference entry.
+
+        Package-level examples land here, as do examples of symbols left out of the
+        API Reference by its size limits.
+        """
+        shown = {
+            (example["module"], example["name"])
+            for key in ("functions", "classes")
+            for doc in api_docs.get(key, [])
+            for example in doc.get("examples", [])
+        }
+        remaining = [
+            example
+            for found in go_examples["symbols"].values()
+            for example in found
+            if (example["module"], example["name"]) not in shown
+        ]
+        return sorted(
+            go_examples["general"] + remaining, key=lambda item: (item["module"], item["line"])
+        )
+
     def _extract_features(self, analysis_data: Dict[str, Any]) -> List[str]:
         """Extract key features from the codebase analysis."""
         features = []
@@ -560,17 +1056,14 @@ class ReadmeGenerator:
         file_reviews = analysis_data.get("file_reviews", [])
         output_links = analysis_data.get("output_links", [])
         structure = analysis_data.get("project_structure", {})
-        root_path = Path(str(analysis_data.get("root_path", ".")))
+        root_path = path_root(analysis_data)
 
         def source_from_symbol(item: Dict[str, Any]) -> str:
             file_path = str(item.get("file", ""))
             line = item.get("line")
             if not file_path:
                 return ""
-            try:
-                rel = Path(file_path).resolve().relative_to(root_path).as_posix()
-            except ValueError:
-                rel = Path(file_path).as_posix()
+            rel = relative_path(root_path, file_path)
             return f"{rel}:{line}" if line else rel
 
         def badge(level: str, sources: list[str]) -> Dict[str, Any]:
@@ -616,376 +1109,6 @@ class ReadmeGenerator:
             "readiness": badge("inferred", output_sources[:2] + review_sources[:2]),
         }
 
-    def _get_template(self) -> Template:
-        """Get the README template."""
-        template_content = """# {{ project_name }}
-
-{{ description }}
-
-{% if is_website %}
-Website project detected. Documentation format optimized for web applications.
-
-{% if website_info.framework_detected %}
-## Technology Stack
-
-**Frontend Framework:** {{ website_info.framework_detected }}
-{% if website_info.build_system %}**Build System:** {{ website_info.build_system }}{% endif %}
-{% if website_info.static_site_generator %}**Static Site Generator:** {{ website_info.static_site_generator }}{% endif %}
-
-{% endif %}
-
-{% if website_info.entry_points %}
-## Entry Points
-
-{% for entry in website_info.entry_points %}
-- `{{ entry }}` - Main entry point
-{% endfor %}
-{% endif %}
-
-{% endif %}
-
-## Features
-> Trust: **{{ trust.features.level }}** | Sources: {% if trust.features.sources %}{{ trust.features.sources|join(', ') }}{% else %}n/a{% endif %}
-
-{% for feature in features %}
-- {{ feature }}
-{% endfor %}
-
-{% if is_website and website_info.has_responsive_design %}
-- Responsive design for all devices
-{% endif %}
-{% if is_website and website_info.deployment_platforms %}
-- Ready for deployment on: {{ website_info.deployment_platforms|join(', ') }}
-{% endif %}
-
-## Requirements
-
-{% for req in requirements %}
-- {{ req }}
-{% endfor %}
-
-## Installation
-> Trust: **{{ trust.installation.level }}** | Sources: {% if trust.installation.sources %}{{ trust.installation.sources|join(', ') }}{% else %}n/a{% endif %}
-
-{% for cmd in install_commands %}
-### {{ cmd.title }}
-
-```bash
-{{ cmd.command }}
-```
-
-{% endfor %}
-
-{% if is_website %}
-{% if website_info.build_system %}
-## Build and Development
-
-### Development Server
-```bash
-{% if website_info.framework_detected == 'React' or website_info.framework_detected == 'Vue.js' %}
-npm start
-{% elif website_info.build_system == 'Vite' %}
-npm run dev
-{% elif website_info.build_system == 'Next.js' %}
-npm run dev
-{% elif website_info.build_system == 'Gatsby' %}
-gatsby develop
-{% else %}
-npm run serve
-{% endif %}
-```
-
-### Build for Production
-```bash
-{% if website_info.build_system == 'Gatsby' %}
-gatsby build
-{% elif website_info.build_system == 'Next.js' %}
-npm run build
-{% else %}
-npm run build
-{% endif %}
-```
-{% endif %}
-
-{% if website_info.deployment_platforms %}
-## Deployment
-
-This website is configured for deployment on:
-{% for platform in website_info.deployment_platforms %}
-- **{{ platform }}**: {% if platform == 'Netlify' %}Drag and drop the `dist` folder or connect your Git repository{% elif platform == 'Vercel' %}Import project from Git repository{% elif platform == 'GitHub Actions' %}Automated deployment via GitHub Actions{% elif platform == 'Firebase' %}Use `firebase deploy` command{% elif platform == 'Docker' %}Build and run the Docker container{% else %}Follow platform-specific instructions{% endif %}
-{% endfor %}
-{% endif %}
-
-{% if website_info.asset_directories %}
-## Project Structure
-
-### Asset Directories
-{% for dir in website_info.asset_directories %}
-- `{{ dir }}/` - {% if 'public' in dir.lower() or 'static' in dir.lower() %}Static assets{% elif 'css' in dir.lower() %}Stylesheets{% elif 'js' in dir.lower() %}JavaScript files{% elif 'image' in dir.lower() or 'img' in dir.lower() %}Images and media{% elif 'font' in dir.lower() %}Web fonts{% else %}Project assets{% endif %}
-{% endfor %}
-{% endif %}
-
-{% else %}
-## Usage
-> Trust: **{{ trust.usage.level }}** | Sources: {% if trust.usage.sources %}{{ trust.usage.sources|join(', ') }}{% else %}n/a{% endif %}
-
-{% for example in usage_examples %}
-### {{ example.title }}
-
-```{% if main_language != 'unknown' %}{{ main_language }}{% endif %}
-{{ example.command }}
-```
-
-{% endfor %}
-{% endif %}
-
-{% if directory_tree %}
-## Project Structure
-
-```
-{{ directory_tree }}
-```
-{% endif %}
-
-{% if packages %}
-## Monorepo Inventory
-
-| Package Path | Type | Manifest | Parent |
-| --- | --- | --- | --- |
-{% for pkg in packages %}
-| `{{ pkg.path }}` | {{ pkg.package_type }} | {{ pkg.manifest or '-' }} | {{ pkg.parent_path or '-' }} |
-{% endfor %}
-{% endif %}
-
-## Architecture
-> Trust: **{{ trust.architecture.level }}** | Sources: {% if trust.architecture.sources %}{{ trust.architecture.sources|join(', ') }}{% else %}n/a{% endif %}
-
-This {{ project_type.lower() }} is built with {{ main_language }} and consists of:
-
-- **{{ functions_count }}** functions across the codebase
-- **{{ classes_count }}** classes/components
-- **{{ total_files }}** source files analyzed
-- **{{ languages|length }}** programming languages used
-
-## Documentation Quality
-
-- **Quality Score**: {{ analysis_quality }}/100
-- **Confidence**: {{ confidence_level }}
-{% if analysis_warnings %}
-- **Warnings**:
-{% for warning in analysis_warnings %}
-  - {{ warning }}
-{% endfor %}
-{% endif %}
-
-### Language Distribution
-
-{% for lang, count in languages.items() %}
-- **{{ lang.title() }}**: {{ count }} files
-{% endfor %}
-
-{% if run_metrics %}
-## Run Metrics
-
-- Scanned files: {{ run_metrics.scanned_files }}
-- Changed files: {{ run_metrics.changed_files }}
-- Skipped files: {{ run_metrics.skipped_files }}
-- Duration (sec): {{ run_metrics.duration_sec }}
-- Cache hit ratio: {{ run_metrics.cache_hit_ratio }}
-{% endif %}
-
-{% if api_docs.functions and not is_website %}
-## API Reference
-> Trust: **{{ trust.api.level }}** | Sources: {% if trust.api.sources %}{{ trust.api.sources|join(', ') }}{% else %}n/a{% endif %}
-
-### Functions
-
-{% for func in api_docs.functions %}
-#### `{{ func.name }}({{ func.args|join(', ') }})`
-
-{% if func.docstring %}
-{{ func.docstring }}
-{% else %}
-Function defined in `{{ func.file }}` at line {{ func.line }}.
-{% endif %}
-
-{% endfor %}
-{% endif %}
-
-{% if api_docs.classes and not is_website %}
-### Classes
-
-{% for cls in api_docs.classes %}
-#### `{{ cls.name }}`
-
-{% if cls.docstring %}
-{{ cls.docstring }}
-{% else %}
-Class defined in `{{ cls.file }}` at line {{ cls.line }}.
-{% endif %}
-
-{% if cls.methods %}
-**Methods:**
-{% for method in cls.methods %}
-- `{{ method.name }}({{ method.args|join(', ') }})`
-{% endfor %}
-{% endif %}
-
-{% endfor %}
-{% endif %}
-
-{% if dependencies %}
-## Dependencies
-> Trust: **{{ trust.dependencies.level }}** | Sources: {% if trust.dependencies.sources %}{{ trust.dependencies.sources|join(', ') }}{% else %}n/a{% endif %}
-
-{% for dep_file, deps in dependencies.items() %}
-### {{ dep_file }}
-
-{% if deps is mapping %}
-{% for category, dep_list in deps.items() %}
-**{{ category.title() }}:**
-{% for dep in dep_list %}
-- {{ dep }}
-{% endfor %}
-{% endfor %}
-{% else %}
-{% for dep in deps %}
-- {{ dep }}
-{% endfor %}
-{% endif %}
-
-{% endfor %}
-{% endif %}
-
-{% if has_tests %}
-## Testing
-> Trust: **{{ trust.testing.level }}** | Sources: {% if trust.testing.sources %}{{ trust.testing.sources|join(', ') }}{% else %}n/a{% endif %}
-
-This project includes comprehensive tests. Run them with:
-
-```bash
-{% if main_language == 'python' %}
-pytest
-{% elif main_language == 'javascript' %}
-npm test
-{% elif main_language == 'rust' %}
-cargo test
-{% elif main_language == 'go' %}
-go test ./...
-{% elif main_language == 'java' %}
-mvn test
-{% else %}
-# Run your tests here
-{% endif %}
-```
-{% endif %}
-
-{% if config_files %}
-## Configuration
-
-Configuration files:
-{% for config in config_files %}
-- `{{ config }}`
-{% endfor %}
-{% endif %}
-
-{% if diff_summary and diff_summary.available %}
-## Version Diff Overview
-> Trust: **{{ trust.diffs.level }}** | Sources: {% if trust.diffs.sources %}{{ trust.diffs.sources|join(', ') }}{% else %}n/a{% endif %}
-
-Comparing `{{ diff_summary.from_ref }}` to `{{ diff_summary.to_ref }}`.
-
-- Added files: {{ diff_summary.totals.added }}
-- Modified files: {{ diff_summary.totals.modified }}
-- Deleted files: {{ diff_summary.totals.deleted }}
-- Renamed files: {{ diff_summary.totals.renamed }}
-- Total line churn: {{ diff_summary.totals.changes }}
-{% endif %}
-
-{% if folder_reviews %}
-## Folder Reviews
-
-{% for folder in folder_reviews %}
-### `{{ folder.folder }}`
-
-- Files changed: {{ folder.files_changed }}
-- Risk distribution: low={{ folder.risk_distribution.low }}, medium={{ folder.risk_distribution.medium }}, high={{ folder.risk_distribution.high }}
-{% endfor %}
-{% endif %}
-
-{% if file_reviews %}
-## File Reviews
-> Trust: **{{ trust.reviews.level }}** | Sources: {% if trust.reviews.sources %}{{ trust.reviews.sources|join(', ') }}{% else %}n/a{% endif %}
-
-{% for review in file_reviews %}
-### `{{ review.path }}`
-
-- Risk: **{{ review.risk_level }}** (score: {{ review.risk_score }})
-- Change type: {{ review.change_type }}
-- Churn: {{ review.churn }} lines
-{% for reason in review.rationale %}
-- {{ reason }}
-{% endfor %}
-{% endfor %}
-{% endif %}
-
-{% if output_links %}
-## Output Flow Links
-> Trust: **{{ trust.output_links.level }}** | Sources: {% if trust.output_links.sources %}{{ trust.output_links.sources|join(', ') }}{% else %}n/a{% endif %}
-
-{% for item in output_links %}
-- `{{ item.source_file }}:{{ item.source_line }}` writes to {% if item.target_file %}`{{ item.target_file }}`{% else %}unresolved target{% endif %} ({{ item.operation }}, confidence={{ item.confidence }})
-{% endfor %}
-{% endif %}
-
-{% if readme_readiness %}
-## README Readiness
-> Trust: **{{ trust.readiness.level }}** | Sources: {% if trust.readiness.sources %}{{ trust.readiness.sources|join(', ') }}{% else %}n/a{% endif %}
-
-- Status: **{{ readme_readiness.status }}**
-- Score: {{ readme_readiness.score }}/100
-{% if readme_readiness.reasons %}
-Issues:
-{% for reason in readme_readiness.reasons %}
-- {{ reason }}
-{% endfor %}
-{% endif %}
-{% endif %}
-
-## Contributing
-
-1. Fork the repository
-2. Create your feature branch (`git checkout -b feature/amazing-feature`)
-3. Commit your changes (`git commit -m 'Add some amazing feature'`)
-4. Push to the branch (`git push origin feature/amazing-feature`)
-5. Open a Pull Request
-
-{% if git_info.contributor_count %}
-## Contributors
-
-This project has {{ git_info.contributor_count }} contributor{{ 's' if git_info.contributor_count != 1 else '' }}.
-{% endif %}
-
-## License
-
-This project is licensed under the MIT License - see the [LICENSE](LICENSE) file for details.
-
-## Contact
-
-{% if git_info.remote_url %}
-- Repository: [{{ git_info.repo_name }}]({{ git_info.remote_url }})
-{% endif %}
-{% if git_info.latest_commit %}
-- Latest commit: {{ git_info.latest_commit.message }}
-{% endif %}
-
----
-
-*This README was automatically generated by [DocGenie](https://github.com/docgenie/docgenie) on {{ generated_date }}*
-"""
-
-        return Template(template_content)
-
     def _get_website_info(self, analysis_data: Dict[str, Any]) -> Dict[str, Any]:
         """Extract website-specific information."""
         files = analysis_data.get("project_structure", {}).get("root", {}).get("files", [])
@@ -0,0 +1,121 @@
+"""Attribute documented symbols to their last commit with `git blame`."""
+
+from __future__ import annotations
+
+import hashlib
+from collections import defaultdict
+from datetime import datetime, timezone
+from pathlib import Path
+from typing import Any
+
+from git import GitCommandError, InvalidGitRepositoryError, NoSuchPathError, Repo
+
+from .index_store import IndexStore
+from .logging import get_logger
+
+logger = get_logger(__name__)
+
+# `git blame` reports lines that are not committed yet under the all-zero SHA.
+UNCOMMITTED_SHA = "0" * 40
+_GIT_ERRORS = (GitCommandError, InvalidGitRepositoryError, NoSuchPathError, OSError, ValueError)
+
+
+def parse_line_porcelain(output: str) -> dict[str, Any]:
+    """Parse `git blame --line-porcelain` into per-line SHAs and per-commit metadata."""
+    lines: list[str] = []
+    commits: dict[str, dict[str, Any]] = {}
+    sha: str | None = None
+    author = ""
+    when = 0
+    for raw in output.splitlines():
+        if raw.startswith("\t"):
+            if sha is not None:
+                lines.append(sha)
+                commits.setdefault(sha, {"author": author, "time": when})
+            sha = None
+            continue
+        if sha is None:
+            sha = raw.split(" ", 1)[0]
+            continue
+        key, _, value = raw.partition(" ")
+        if key == "author":
+            author = value
+        elif key == "author-time" and value.isdigit():
+            when = int(value)
+    return {"lines": lines, "commits": commits}
+
+
+def attach_git_metadata(
+    root: Path,
+    symbols: list[dict[str, Any]],
+    *,
+    store: IndexStore | None = None,
+) -> int:
+    """Set `last_modified` on each symbol from the newest commit touching its line range.
+
+    Blame results are cached in the index store per HEAD commit and file content, so
+    unchanged files are not blamed again. Outside a git checkout, or when git is
+    unavailable, nothing is attached and 0 is returned.
+    """
+    try:
+        repo = Repo(root, search_parent_directories=True)
+        head = repo.head.commit.hexsha
+        work_tree = Path(str(repo.working_tree_dir)).resolve()
+    except _GIT_ERRORS:
+        return 0
+
+    by_file: dict[str, list[dict[str, Any]]] = defaultdict(list)
+    for item in symbols:
+        if isinstance(item, dict) and item.get("file"):
+            by_file[str(item["file"])].append(item)
+
+    attached = 0
+    for file_path, items in sorted(by_file.items()):
+        blame = _file_blame(repo, store, head, work_tree, Path(file_path))
+        if blame is None:
+            continue
+        for item in items:
+            stamp = _last_modified(blame, item)
+            if stamp is not None:
+                item["last_modified"] = stamp
+                attached += 1
+    return attached
+
+
+def _file_blame(
+    repo: Repo, store: IndexStore | None, head: str, work_tree: Path, file_path: Path
+) -> dict[str, Any] | None:
+    try:
+        resolved = file_path.resolve()
+        rel = resolved.relative_to(work_tree).as_posix()
+        digest = hashlib.sha256(resolved.read_bytes()).hexdigest()
+    except (OSError, ValueError):
+        return None
+    cached = store.get_blame(head, rel, digest) if store is not None else None
+    if cached is not None:
+        return cached
+    try:
+        blame = parse_line_porcelain(repo.git.blame("--line-porcelain", "--", rel))
+    except _GIT_ERRORS as exc:
+        logger.debug("git blame failed", path=rel, error=str(exc))
+        return None
+    if store is not None:
+        store.put_blame(head, rel, digest, blame)
+    return blame
+
+
+def _last_modified(blame: dict[str, Any], item: dict[str, Any]) -> dict[str, str] | None:
+    start = int(item.get("line", 0) or 0)
+    end = int(item.get("end_line") or start)
+    shas = blame.get("lines", [])[max(start - 1, 0) : end]
+    commits = blame.get("commits", {})
+    dated = [(commits[sha]["time"], sha) for sha in set(shas) if sha in commits]
+    committed = [entry for entry in dated if entry[1] != UNCOMMITTED_SHA]
+    if not committed:
+        return None
+    when, sha = max(committed)
+    return {
+        "date": datetime.fromtimestamp(when, tz=timezone.utc).strftime("%Y-%m-%d"),
+        "author": str(commits[sha]["author"]),
+        "commit": sha[:12],
+    }
@@ -0,0 +1,83 @@
+"""Attach Go `ExampleXxx` test functions to the symbols they demonstrate.
+
+`go test` names examples after their target: `ExampleF` documents function or type
+`F`, `ExampleT_M` documents method `T.M`, and a trailing lowercase `_suffix` tells
+several examples of one symbol apart. `Example` and `Example_suffix` document the
+package as a whole.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+from .module_index import package_of, relative_path
+from .reproducible import path_root
+
+
+def example_target(name: str) -> str | None:
+    """Return the symbol an example function documents (`T.M`), or None for the package."""
+    parts = name.removeprefix("Example").split("_")
+    if len(parts) > 1 and parts[-1][:1].islower():
+        parts = parts[:-1]
+    target = ".".join(parts)
+    return target or None
+
+
+def find_go_examples(analysis_data: dict[str, Any]) -> dict[str, Any]:
+    """Return `{"symbols": {(package, name): [example, ...]}, "general": [example, ...]}`.
+
+    Each example is `{"name", "target", "module", "line", "code"}`. Examples whose
+    target is not a symbol of their package, including package-level examples, are
+    listed under `general`.
+    """
+    root = path_root(analysis_data)
+    known: set[tuple[str, str]] = set()
+    examples: list[dict[str, Any]] = []
+    for func in analysis_data.get("functions", []):
+        if not isinstance(func, dict) or not str(func.get("file", "")).endswith(".go"):
+            continue
+        module = relative_path(root, str(func["file"]))
+        if func.get("example"):
+            examples.append(
+                {
+                    "name": str(func["name"]),
+                    "target": example_target(str(func["name"])),
+                    "module": module,
+                    "line": func.get("line", 0),
+                    "code": str(func["example"]),
+                }
+            )
+        elif not module.endswith("_test.go"):
+            known.add((package_of(module), str(func.get("name", ""))))
+    for cls in analysis_data.get("classes", []):
+        if not isinstance(cls, dict) or not str(cls.get("file", "")).endswith(".go"):
+            continue
+        package = package_of(relative_path(root, str(cls["file"])))
+        known.add((package, str(cls.get("name", ""))))
+        for method in cls.get("methods", []) or []:
+            if isinstance(method, dict):
+                known.add((package, f"{cls.get('name')}.{method.get('name')}"))
+
+    symbols: dict[tuple[str, str], list[dict[str, Any]]] = {}
+    general: list[dict[str, Any]] = []
+    for example in sorted(examples, key=lambda item: (item["module"], item["line"])):
+        key = (package_of(example["module"]), str(example["target"]))
+        if example["target"] is not None and key in known:
+            symbols.setdefault(key, []).append(example)
+        else:
+            general.append(example)
+    return {"symbols": symbols, "general": general}
+
+
+def examples_for(
+    examples: dict[tuple[str, str], list[dict[str, Any]]],
+    module: str,
+    name: str,
+    methods: list[Any] | None = None,
+) -> list[dict[str, Any]]:
+    """Return the examples of symbol `name` followed by those of its listed `methods`."""
+    package = package_of(module)
+    targets = [name] + [
+        f"{name}.{method.get('name')}" for method in methods or [] if isinstance(method, dict)
+    ]
+    return [example for target in targets for example in examples.get((package, target), [])]
@@ -0,0 +1,172 @@
+"""Map Go interfaces to the concrete types whose method sets satisfy them.
+
+Go types implement interfaces implicitly, so the mapping is recovered by comparing
+method names and signatures across every parsed Go type in the analysis.
+"""
+
+from __future__ import annotations
+
+import re
+from pathlib import PurePosixPath
+from typing import Any
+
+from .languages._scan import paren_contents, split_top_level
+from .module_index import relative_path
+from .reproducible import path_root
+
+MethodShape = tuple[tuple[str, ...], tuple[str, ...]]
+
+_METHOD_HEAD_RE = re.compile(
+    r"^(?:func\s*(?:\([^)]*\)\s*)?)?(?P<name>[A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\s*(?=\()"
+)
+_QUALIFIER_RE = re.compile(r"\b[A-Za-z_]\w*\.(?=[A-Za-z_])")
+_TYPE_KEYWORDS = frozenset({"chan", "func", "interface", "map", "struct"})
+
+
+def find_go_implementations(
+    analysis_data: dict[str, Any],
+) -> dict[tuple[str, str], list[dict[str, str]]]:
+    """Return `{(module, interface): [{"name", "module"}, ...]}` for analyzed Go interfaces.
+
+    Embedded interfaces are flattened into the method set. Interfaces embedding one
+    that was not analyzed (such as `io.Closer`) are skipped because their full
+    method set is unknown, as are interfaces without methods.
+    """
+    root = path_root(analysis_data)
+    interfaces: dict[tuple[str, str], dict[str, Any]] = {}
+    types: dict[tuple[str, str], dict[str, Any]] = {}
+    for cls in analysis_data.get("classes", []):
+        if not isinstance(cls, dict) or not str(cls.get("file", "")).endswith(".go"):
+            continue
+        module = relative_path(root, str(cls["file"]))
+        methods = _shapes(cls.get("methods", []) or [])
+        if cls.get("kind") == "interface":
+            interfaces[(module, str(cls["name"]))] = {
+                "methods": methods,
+                "bases": [str(base) for base in cls.get("bases", []) or []],
+            }
+        else:
+            key = (_package(module), str(cls["name"]))
+            types[key] = {"module": module, "methods": methods}
+
+    # Methods on types declared in another file of the package arrive as `Type.Method`.
+    for func in analysis_data.get("functions", []):
+        if not isinstance(func, dict) or func.get("kind") != "method":
+            continue
+        if not str(func.get("file", "")).endswith(".go") or "." not in str(func.get("name", "")):
+            continue
+        receiver = str(func["name"]).split(".", 1)[0]
+        target = types.get((_package(relative_path(root, str(func["file"]))), receiver))
+        if target is not None:
+            target["methods"].update(_shapes([func]))
+
+    implementations: dict[tuple[str, str], list[dict[str, str]]] = {}
+    for key in sorted(interfaces):
+        required = _method_set(key, interfaces, set())
+        if not required:
+            continue
+        matches = [
+            {"name": name, "module": info["module"]}
+            for (_package_dir, name), info in sorted(types.items())
+            if all(info["methods"].get(method) == shape for method, shape in required.items())
+        ]
+        if matches:
+            implementations[key] = sorted(matches, key=lambda item: (item["name"], item["module"]))
+    return implementations
+
+
+def method_shape(signature: str) -> tuple[str, MethodShape] | None:
+    """Return `(name, (param types, result types))` with names and package qualifiers dropped.
+
+    `func (u *User) Get(ctx context.Context, a, b int) (n int, err error)` and the
+    interface line `Get(context.Context, int, int) (int, error)` have the same shape.
+    """
+    text = " ".join(signature.split()).rstrip("{").strip()
+    match = _METHOD_HEAD_RE.match(text)
+    if match is None:
+        return None
+    rest = text[match.end() :]
+    params = paren_contents(rest)
+    results = rest[len(params) + 2 :].strip()
+    result_types = _types(paren_contents(results)) if results.startswith("(") else [results]
+    returns = tuple(_normalize(item) for item in result_types if item)
+    return match.group("name"), (tuple(_types(params)), returns)
+
+
+def _shapes(methods: list[Any]) -> dict[str, MethodShape]:
+    shapes: dict[str, MethodShape] = {}
+    for method in methods:
+        if not isinstance(method, dict):
+            continue
+        parsed = method_shape(str(method.get("signature") or ""))
+        if parsed is not None:
+            shapes[parsed[0]] = parsed[1]
+    return shapes
+
+
+def _method_set(
+    key: tuple[str, str],
+    interfaces: dict[tuple[str, str], dict[str, Any]],
+    seen: set[tuple[str, str]],
+) -> dict[str, MethodShape] | None:
+    interface = interfaces[key]
+    methods: dict[str, MethodShape] = {}
+    for base in interface["bases"]:
+        embedded = _resolve_interface(base, key[0], interfaces)
+        if embedded is None:
+            return None
+        if embedded in seen or embedded == key:
+            continue
+        nested = _method_set(embedded, interfaces, seen | {key})
+        if nested is None:
+            return None
+        methods.update(nested)
+    methods.update(interface["methods"])
+    return methods
+
+
+def _resolve_interface(
+    base: str, module: str, interfaces: dict[tuple[str, str], dict[str, Any]]
+) -> tuple[str, str] | None:
+    """Resolve an embedded interface: same package, then package name, then a unique name."""
+    qualifier, _, name = base.split("[", 1)[0].strip().rpartition(".")
+    candidates = [key for key in interfaces if key[1] == name]
+    if not qualifier:
+        local = [key for key in candidates if _package(key[0]) == _package(module)]
+        if local:
+            return local[0]
+    else:
+        named = [key for key in candidates if PurePosixPath(_package(key[0])).name == qualifier]
+        if len(named) == 1:
+            return named[0]
+    return candidates[0] if len(candidates) == 1 else None
+
+
+def _types(params: str) -> list[str]:
+    """Return the types of a Go parameter list, expanding `a, b int` to two `int`s."""
+    parts = split_top_level(params)
+    if not any(_is_named(part) for part in parts):
+        return [_normalize(part) for part in parts]
+    types: list[str] = []
+    pending = 0
+    for part in parts:
+        tokens = part.split(None, 1)
+        if len(tokens) == 1:
+            pending += 1
+            continue
+        types.extend([_normalize(tokens[1])] * (pending + 1))
+        pending = 0
+    return types
+
+
+def _is_named(part: str) -> bool:
+    name, _, type_text = part.partition(" ")
+    return bool(type_text) and name.isidentifier() and name not in _TYPE_KEYWORDS
+
+
+def _normalize(type_text: str) -> str:
+    return _QUALIFIER_RE.sub("", " ".join(type_text.split()))
+
+
+def _package(module: str) -> str:
+    return str(PurePosixPath(module).parent)
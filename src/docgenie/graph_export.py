@@ -0,0 +1,243 @@
+"""Export impact-graph data to diagram formats that render outside the HTML page."""
+
+from __future__ import annotations
+
+import re
+from typing import Any
+
+from .go_interfaces import find_go_implementations
+from .html_sections import (
+    base_name,
+    build_impact_graph_data,
+    graph_scope,
+    resolve_symbol_module,
+)
+from .module_index import module_grouping, relative_path
+from .reproducible import path_root
+
+# Mermaid diagrams stop being readable long before the HTML graph's 600-node cap.
+MERMAID_MAX_NODES = 80
+MERMAID_MAX_EDGES = 160
+
+_SHAPES = {
+    "file": ('["', '"]'),
+    "module": ('(["', '"])'),
+    "output": ('[/"', '"/]'),
+    "package": ('[["', '"]]'),
+    "symbol": ('{{"', '"}}'),
+    "external": ('>"', '"]'),
+}
+# PlantUML lays class diagrams out server-side; past this they are a wall of boxes.
+PLANTUML_MAX_CLASSES = 60
+PLANTUML_MAX_MEMBERS = 12
+
+_PLANTUML_KINDS = {"interface": "interface", "enum": "enum", "protocol": "interface"}
+_TYPE_WORD_RE = re.compile(r"[A-Za-z_]\w*")
+
+_CLASS_DEFS = {
+    "file": "fill:#1f4f78,color:#fff",
+    "module": "fill:#0f766e,color:#fff",
+    "output": "fill:#b45309,color:#fff",
+    "package": "fill:#1e3a8a,color:#fff",
+    "symbol": "fill:#7c3aed,color:#fff",
+    "external": "fill:#e5e7eb,color:#4b5563",
+}
+
+
+def mermaid_impact_graph(
+    analysis_data: dict[str, Any],
+    *,
+    max_nodes: int = MERMAID_MAX_NODES,
+    max_edges: int = MERMAID_MAX_EDGES,
+) -> dict[str, Any]:
+    """Build the impact graph and render it as Mermaid.
+
+    Returns `{"diagram": str, "note": str | None}`. The diagram is empty when there
+    is nothing to draw; the note says how much was omitted when the graph was truncated.
+    """
+    graph = build_impact_graph_data(
+        analysis_data,
+        max_nodes=max_nodes,
+        max_edges=max_edges,
+        group_by=module_grouping(analysis_data),
+        scope=graph_scope(analysis_data),
+    )
+    return {"diagram": mermaid_from_graph(graph), "note": _omitted_note(graph)}
+
+
+def mermaid_from_graph(graph: dict[str, Any]) -> str:
+    """Render impact-graph data as a Mermaid `graph TD` definition."""
+    nodes = [node for node in graph.get("nodes", []) if isinstance(node, dict)]
+    if not nodes:
+        return ""
+    ids = {str(node.get("id", "")): f"n{idx}" for idx, node in enumerate(nodes)}
+    lines = ["graph TD"]
+    used_types: set[str] = set()
+    for node in nodes:
+        node_id = ids[str(node.get("id", ""))]
+        node_type = str(node.get("type", "file"))
+        opener, closer = _SHAPES.get(node_type, _SHAPES["file"])
+        label = _escape_label(str(node.get("label", "")))
+        if node.get("module"):
+            label += "<br/>" + _escape_label(str(node["module"]))
+        lines.append(f"    {node_id}{opener}{label}{closer}")
+        if node_type in _CLASS_DEFS:
+            lines.append(f"    class {node_id} {node_type}")
+            used_types.add(node_type)
+
+    for edge in graph.get("edges", []):
+        source = ids.get(str(edge.get("source", "")))
+        target = ids.get(str(edge.get("target", "")))
+        if not source or not target:
+            continue
+        kind = _escape_label(str(edge.get("kind", "")))
+        # Dotted arrows leave the subproject being documented (workspace mode).
+        arrow = "-.->" if edge.get("external") else "-->"
+        label = f"|{kind}|" if kind else ""
+        lines.append(f"    {source} {arrow}{label} {target}")
+
+    lines.extend(f"    classDef {kind} {_CLASS_DEFS[kind]}" for kind in sorted(used_types))
+    return "\n".join(lines)
+
+
+def mermaid_layer_graph(report: dict[str, Any]) -> str:
+    """Render a `layers.layer_report` as a Mermaid graph of layers.
+
+    Edges are labeled with their import count; edges against the layer order are
+    drawn dashed and red. Empty when no layer has modules.
+    """
+    layers = [layer for layer in report.get("layers", []) if layer.get("modules")]
+    if not layers:
+        return ""
+    ids = {str(layer["name"]): f"l{idx}" for idx, layer in enumerate(layers)}
+    lines = ["graph TD"]
+    for layer in layers:
+        count = len(layer["modules"])
+        label = f"{_escape_label(str(layer['name']))}<br/>{count} module{'s' if count != 1 else ''}"
+        lines.append(f'    {ids[str(layer["name"])]}["{label}"]')
+    violated: list[int] = []
+    edges = 0
+    for dependency in report.get("dependencies", []):
+        source, target = ids.get(dependency["source"]), ids.get(dependency["target"])
+        if not source or not target:
+            continue
+        arrow = "-->" if dependency["allowed"] else "-.->"
+        lines.append(f"    {source} {arrow}|{dependency['count']}| {target}")
+        if not dependency["allowed"]:
+            violated.append(edges)
+        edges += 1
+    if violated:
+        indexes = ",".join(str(index) for index in violated)
+        lines.append(f"    linkStyle {indexes} stroke:#dc2626,color:#dc2626")
+    return "\n".join(lines)
+
+
+def plantuml_class_diagram(
+    analysis_data: dict[str, Any],
+    *,
+    max_classes: int = PLANTUML_MAX_CLASSES,
+    max_members: int = PLANTUML_MAX_MEMBERS,
+) -> dict[str, Any]:
+    """Render the analyzed types as a PlantUML class diagram.
+
+    Returns `{"diagram": str, "note": str | None}` like `mermaid_impact_graph`.
+    Bases become inheritance edges (implementation when the base is an interface),
+    Go interfaces link to the types satisfying them, and fields whose type names
+    another documented type become composition edges. Only the first `max_classes`
+    types in module order are drawn, each with at most `max_members` members.
+    """
+    root = path_root(analysis_data)
+    file_imports = analysis_data.get("file_imports", {})
+    if not isinstance(file_imports, dict):
+        file_imports = {}
+    types: list[tuple[str, dict[str, Any]]] = sorted(
+        (
+            (relative_path(root, str(cls["file"])), cls)
+            for cls in analysis_data.get("classes", [])
+            if isinstance(cls, dict) and cls.get("name") and cls.get("file")
+        ),
+        key=lambda item: (item[0], int(item[1].get("line") or 0)),
+    )
+    if not types:
+        return {"diagram": "", "note": None}
+    shown = types[:max_classes]
+    ids = {(module, str(cls["name"])): f"C{idx}" for idx, (module, cls) in enumerate(shown)}
+    kinds = {(module, str(cls["name"])): str(cls.get("kind", "class")) for module, cls in shown}
+    by_name: dict[str, list[str]] = {}
+    for module, name in ids:
+        by_name.setdefault(name, []).append(module)
+
+    def resolve(name: str, module: str) -> tuple[str, str] | None:
+        imported = file_imports.get(module, [])
+        target = resolve_symbol_module(base_name(name), module, imported, by_name)
+        return None if target is None else (target, base_name(name))
+
+    lines = ["@startuml", "hide empty members"]
+    edges: list[str] = []
+    for module, cls in shown:
+        key = (module, str(cls["name"]))
+        lines.extend(_plantuml_type(ids[key], cls, max_members))
+        for base in cls.get("bases", []) or []:
+            target = resolve(str(base), module)
+            if target is None or target == key:
+                continue
+            implements = kinds[target] in _PLANTUML_KINDS and kinds[key] not in _PLANTUML_KINDS
+            edges.append(f"{ids[target]} {'<|..' if implements else '<|--'} {ids[key]}")
+        for item in cls.get("fields", []) or []:
+            for word in dict.fromkeys(_TYPE_WORD_RE.findall(str(item.get("type", "")))):
+                target = resolve(word, module)
+                if target is not None and target != key:
+                    edges.append(f"{ids[key]} *-- {ids[target]} : {item.get('name', '')}")
+
+    for (module, interface), implementers in find_go_implementations(analysis_data).items():
+        for implementer in implementers:
+            source = ids.get((implementer["module"], implementer["name"]))
+            if source is not None and (module, interface) in ids:
+                edges.append(f"{ids[(module, interface)]} <|.. {source}")
+
+    lines.extend(dict.fromkeys(edges))
+    lines.append("@enduml")
+    note = None
+    if len(types) > len(shown):
+        note = f"Showing {len(shown)} of {len(types)} types; {len(types) - len(shown)} omitted."
+    return {"diagram": "\n".join(lines), "note": note}
+
+
+def _plantuml_type(alias: str, cls: dict[str, Any], max_members: int) -> list[str]:
+    keyword = _PLANTUML_KINDS.get(str(cls.get("kind", "class")), "class")
+    label = str(cls["name"]).replace('"', "'")
+    members = [
+        f"  {item.get('name', '')} : {item.get('type', '')}".rstrip(" :")
+        for item in cls.get("fields", []) or []
+        if isinstance(item, dict)
+    ]
+    members.extend(
+        f"  {'-' if method.get('private') else '+'}{method.get('name', '')}("
+        f"{', '.join(arg for arg in method.get('args', []) or [] if arg not in ('self', 'cls'))})"
+        for method in cls.get("methods", []) or []
+        if isinstance(method, dict)
+    )
+    if not members:
+        return [f'{keyword} "{label}" as {alias}']
+    if len(members) > max_members:
+        members = members[:max_members] + [f"  .. {len(members) - max_members} more .."]
+    return [f'{keyword} "{label}" as {alias} {{', *members, "}"]
+
+
+def _omitted_note(graph: dict[str, Any]) -> str | None:
+    if not graph.get("truncated"):
+        return None
+    shown_nodes = len(graph.get("nodes", []))
+    shown_edges = len(graph.get("edges", []))
+    total_nodes = int(graph.get("total_nodes", shown_nodes))
+    omitted_nodes = max(total_nodes - shown_nodes, 0)
+    total_edges = int(graph.get("total_edges", shown_edges))
+    omitted_edges = max(int(graph.get("omitted_edges", total_edges - shown_edges)), 0)
+    return (
+        f"Showing {shown_nodes} of {total_nodes} nodes; "
+        f"{omitted_nodes} nodes and {omitted_edges} edges omitted."
+    )
+
+
+def _escape_label(text: str) -> str:
+    return text.replace('"', "#quot;").replace("|", "#124;").replace("\n", " ")
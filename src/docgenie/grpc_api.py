@@ -0,0 +1,90 @@
+"""Collect the gRPC services and protobuf messages declared in a project's `.proto` files.
+
+Every RPC maps a request message to a response message; either side may be a stream.
+Messages are listed with their fields so the README can show what each RPC exchanges.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from .languages.protobuf import parse_proto
+from .module_index import relative_path
+
+
+def scan_grpc_services(root_path: Path, files: Iterable[Path]) -> dict[str, Any]:
+    """Return the `services` and `messages` found in `.proto` files.
+
+    Each service has `name`, `file`, `line`, `summary` and `rpcs` (`name`, `request`,
+    `response`, `streaming` and `summary`); `streaming` is `client`, `server`,
+    `bidirectional` or "". Each message has `name`, `file`, `summary` and `fields`
+    (`name`, `type`, `number`, `label`, `description`). Empty when no file declares
+    a service.
+    """
+    services: list[dict[str, Any]] = []
+    messages: list[dict[str, Any]] = []
+    for path in sorted(files):
+        if path.suffix != ".proto":
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        rel = relative_path(root_path, str(path))
+        proto = parse_proto(content)
+        services.extend(
+            {
+                "name": service["name"],
+                "file": rel,
+                "line": service["line"],
+                "summary": _one_line(service["docstring"]),
+                "rpcs": [_rpc(rpc) for rpc in service["rpcs"]],
+            }
+            for service in proto["services"]
+        )
+        messages.extend(
+            {
+                "name": message["name"],
+                "file": rel,
+                "summary": _one_line(message["docstring"]),
+                "fields": [
+                    {
+                        "name": item["name"],
+                        "type": item["type"],
+                        "number": item["number"],
+                        "label": item["label"],
+                        "description": _one_line(item["docstring"], paragraphs=True),
+                    }
+                    for item in message["fields"]
+                ],
+            }
+            for message in proto["messages"]
+        )
+    if not services:
+        return {}
+    return {"services": services, "messages": messages}
+
+
+def _rpc(rpc: dict[str, Any]) -> dict[str, Any]:
+    client, server = rpc["client_streaming"], rpc["server_streaming"]
+    if client and server:
+        streaming = "bidirectional"
+    else:
+        streaming = "client" if client else "server" if server else ""
+    return {
+        "name": rpc["name"],
+        "request": rpc["request"],
+        "response": rpc["response"],
+        "streaming": streaming,
+        "summary": _one_line(rpc["docstring"]),
+    }
+
+
+def _one_line(docstring: str | None, *, paragraphs: bool = False) -> str:
+    """Collapse a doc comment onto one table-cell line; only its first paragraph by default."""
+    if not docstring:
+        return ""
+    text = docstring if paragraphs else docstring.strip().split("\n\n", 1)[0]
+    return " ".join(text.split())
@@ -0,0 +1,129 @@
+"""Reuse the rendered HTML of README sections and modules that have not changed."""
+
+from __future__ import annotations
+
+import hashlib
+import json
+from collections.abc import Callable, Mapping
+from pathlib import Path
+from typing import Any
+
+HTML_CACHE_VERSION = 3
+HTML_CACHE_FILENAME = "html-sections.json"
+
+
+def section_digest(markdown_text: str) -> str:
+    return hashlib.sha256(markdown_text.encode("utf-8")).hexdigest()
+
+
+def module_digest(module: Mapping[str, Any]) -> str:
+    """Digest the template data of one `modules` entry of the README context."""
+    encoded = json.dumps(module, sort_keys=True, default=str)
+    return hashlib.sha256(encoded.encode("utf-8")).hexdigest()
+
+
+class HtmlSectionCache:
+    """Persisted HTML of the previous build's README sections and modules.
+
+    Sections are keyed by the digest of their Markdown, so only changed Markdown is
+    converted again. Each `## Modules` subsection is also kept under its module path
+    with the digest of the module's template data: `reuse_module` tells the README
+    generator which modules are unchanged, so they are not rendered at all, and
+    `render_module` splices their previous HTML back into the page.
+
+    The cache lives at `<cache_dir>/html-sections.json` next to the analysis index.
+    A cache written under a different `fingerprint` (the templates, settings and
+    DocGenie version that produced it) is discarded on load, which forces a full
+    rebuild.
+    """
+
+    def __init__(self, cache_dir: Path, fingerprint: str) -> None:
+        self.cache_file = cache_dir / HTML_CACHE_FILENAME
+        self.fingerprint = fingerprint
+        self.hits = 0
+        self.misses = 0
+        self.modules_reused = 0
+        self._previous: dict[str, str] = {}
+        self._current: dict[str, str] = {}
+        self._previous_modules: dict[str, dict[str, str]] = {}
+        self._current_modules: dict[str, dict[str, str]] = {}
+        self._module_digests: dict[str, str] = {}
+        self._reused: set[str] = set()
+        self._load()
+
+    def _load(self) -> None:
+        if not self.cache_file.exists():
+            return
+        try:
+            payload = json.loads(self.cache_file.read_text(encoding="utf-8"))
+        except (json.JSONDecodeError, OSError):
+            return
+        if (
+            isinstance(payload, dict)
+            and payload.get("version") == HTML_CACHE_VERSION
+            and payload.get("fingerprint") == self.fingerprint
+            and isinstance(payload.get("sections"), dict)
+            and isinstance(payload.get("modules"), dict)
+        ):
+            self._previous = payload["sections"]
+            self._previous_modules = payload["modules"]
+
+    def render(self, markdown_text: str, convert: Callable[[str], str]) -> str:
+        """Return the cached HTML for `markdown_text`, converting it only on a miss."""
+        digest = section_digest(markdown_text)
+        cached = self._previous.get(digest)
+        if cached is None:
+            self.misses += 1
+            cached = convert(markdown_text)
+        else:
+            self.hits += 1
+        self._current[digest] = cached
+        return cached
+
+    def reuse_module(self, module: Mapping[str, Any]) -> bool:
+        """Return True when the previous build's HTML for `module` can be spliced in.
+
+        Meant as `ReadmeGenerator.generate(reuse_module=...)`: `module` is a `modules`
+        entry of the README context, and one whose data is unchanged since the last
+        build is rendered as its bare heading instead of in full.
+        """
+        path = str(module.get("path", ""))
+        digest = module_digest(module)
+        self._module_digests[path] = digest
+        previous = self._previous_modules.get(path)
+        if previous is None or previous.get("digest") != digest:
+            return False
+        self._reused.add(path)
+        return True
+
+    def render_module(
+        self, path: str, markdown_text: str, convert: Callable[[str], str]
+    ) -> str:
+        """Return the HTML of the `## Modules` subsection documenting `path`.
+
+        A module accepted by `reuse_module` gets its previous HTML, whatever
+        `markdown_text` holds; a changed one is converted. Modules `reuse_module`
+        never saw go through `render` like any other section.
+        """
+        digest = self._module_digests.get(path)
+        if digest is None:
+            return self.render(markdown_text, convert)
+        if path in self._reused:
+            self.modules_reused += 1
+            html = self._previous_modules[path]["html"]
+        else:
+            self.misses += 1
+            html = convert(markdown_text)
+        self._current_modules[path] = {"digest": digest, "html": html}
+        return html
+
+    def persist(self) -> None:
+        """Write what this build used; sections and modules no longer rendered are dropped."""
+        payload: dict[str, Any] = {
+            "version": HTML_CACHE_VERSION,
+            "fingerprint": self.fingerprint,
+            "sections": self._current,
+            "modules": self._current_modules,
+        }
+        self.cache_file.parent.mkdir(parents=True, exist_ok=True)
+        self.cache_file.write_text(json.dumps(payload, sort_keys=True), encoding="utf-8")
@@ -2,15 +2,54 @@
 
 from __future__ import annotations
 
+import hashlib
 import json
-from datetime import datetime
+import re
+from collections.abc import Callable
 from pathlib import Path
 from typing import Any
 
 import markdown
 
+from . import __version__
+from .exceptions import ConfigError
 from .generator import ReadmeGenerator
+from .html_cache import HtmlSectionCache, section_digest
+from .html_sections import (
+    FRAGMENT_CSS_FILENAME,
+    SEARCH_INDEX_FILENAME,
+    badges_html,
+    build_impact_graph_data,
+    code_heading_anchors,
+    element_ids,
+    graph_scope,
+    impact_graph_summary,
+    iter_search_entries,
+    mark_complexity_cells,
+    normalize_heading_ids,
+    scope_heading_ids,
+    toc_sidebar_html,
+    write_search_index,
+)
+from .logging import get_logger
+from .module_index import module_grouping
+from .reproducible import build_time
 from .sanitize import sanitize_html
+from .templating import (
+    BUILTIN_TEMPLATE_DIR,
+    HTML_TEMPLATE,
+    README_TEMPLATE,
+    load_template,
+    template_dir_from_config,
+    template_path,
+)
+from .toc import (
+    DEFAULT_TOC_DEPTH,
+    DEFAULT_TOC_MIN_HEADINGS,
+    module_sections,
+    split_markdown_sections,
+    toc_settings,
+)
 
 try:
     from .redaction import redact_text
@@ -22,132 +61,130 @@ except ModuleNotFoundError:  # pragma: no cover - optional in trimmed installs
         return text
 
 
-class HTMLGenerator:
-    """Generate minimal, professional HTML docs from README or analysis data."""
+def _markdown_processor(*, attr_list: bool) -> markdown.Markdown:
+    extensions = ["codehilite", "toc", "tables", "fenced_code"]
+    return markdown.Markdown(
+        extensions=[*extensions, "attr_list"] if attr_list else extensions,
+        extension_configs={
+            "codehilite": {"css_class": "highlight", "linenums": False},
+            "toc": {"permalink": True, "baselevel": 1},
+        },
+    )
+
+
+# Every color is a CSS custom property so dark mode, the theme toggle and a
+# `--theme-css` stylesheet restyle the whole page, impact graph included.
+LIGHT_THEME = {
+    "primary-color": "#1f4f78",
+    "accent-color": "#0f766e",
+    "bg": "#f7f8fa",
+    "surface": "#ffffff",
+    "text": "#111827",
+    "muted": "#4b5563",
+    "border": "#d1d5db",
+    "mono-bg": "#f3f4f6",
+    "code-bg": "#111827",
+    "code-text": "#f9fafb",
+    "graph-bg": "#f9fafb",
+    "graph-node": "#334155",
+    "graph-file": "#1f4f78",
+    "graph-package": "#1e3a8a",
+    "graph-module": "#0f766e",
+    "graph-output": "#b45309",
+    "graph-symbol": "#7c3aed",
+    "graph-external": "#9ca3af",
+    "graph-edge": "#cbd5e1",
+    "graph-cycle": "#dc2626",
+    "graph-inbound": "#16a34a",
+    "graph-outbound": "#2563eb",
+    "complexity-low": "#15803d",
+    "complexity-moderate": "#b45309",
+    "complexity-high": "#b91c1c",
+}
+DARK_THEME = {
+    "primary-color": "#7cb7e8",
+    "accent-color": "#2dd4bf",
+    "bg": "#0f172a",
+    "surface": "#111827",
+    "text": "#e5e7eb",
+    "muted": "#9ca3af",
+    "border": "#374151",
+    "mono-bg": "#1f2937",
+    "code-bg": "#020617",
+    "code-text": "#e5e7eb",
+    "graph-bg": "#0b1220",
+    "graph-node": "#94a3b8",
+    "graph-file": "#60a5fa",
+    "graph-package": "#93c5fd",
+    "graph-module": "#2dd4bf",
+    "graph-output": "#f59e0b",
+    "graph-symbol": "#a78bfa",
+    "graph-external": "#4b5563",
+    "graph-edge": "#334155",
+    "graph-cycle": "#f87171",
+    "graph-inbound": "#4ade80",
+    "graph-outbound": "#60a5fa",
+    "complexity-low": "#4ade80",
+    "complexity-moderate": "#fbbf24",
+    "complexity-high": "#f87171",
+}
 
-    def __init__(self) -> None:
-        self.markdown_processor = markdown.Markdown(
-            extensions=["codehilite", "toc", "tables", "fenced_code", "attr_list"],
-            extension_configs={
-                "codehilite": {"css_class": "highlight", "linenums": False},
-                "toc": {"permalink": True, "baselevel": 1},
-            },
-        )
 
-    def generate_from_readme(  # noqa: PLR0913
-        self,
-        readme_content: str,
-        output_path: str | None = None,
-        project_name: str = "Project Documentation",
-        redaction_mode: str = "strict",
-        redact_patterns: list[str] | None = None,
-        graph_data: dict[str, Any] | None = None,
-    ) -> str:
-        safe_readme = redact_text(readme_content, redaction_mode, redact_patterns or [])
-        content = self.markdown_processor.convert(safe_readme)
-        full_html = self._create_html_document(content, project_name, graph_data=graph_data)
-        if output_path:
-            with open(output_path, "w", encoding="utf-8") as f:
-                f.write(full_html)
-        return full_html
-
-    def generate_from_analysis(
-        self, analysis_data: dict[str, Any], output_path: str | None = None
-    ) -> str:
-        readme_gen = ReadmeGenerator()
-        readme_content = readme_gen.generate(analysis_data)
-        config = analysis_data.get("config", {})
-        safety = config.get("safety", {}) if isinstance(config, dict) else {}
-        redaction_mode = str(safety.get("redaction_mode", "strict"))
-        redact_patterns = safety.get("redact_patterns", []) if isinstance(safety, dict) else []
-        if not isinstance(redact_patterns, list):
-            redact_patterns = []
+def theme_variables_css(scope: str = ":root") -> str:
+    """Return the color variables: light by default, dark for `prefers-color-scheme: dark`.
 
-        project_name = self._extract_project_name(analysis_data)
-        graph_data = self._build_impact_graph_data(analysis_data)
-        return self.generate_from_readme(
-            readme_content,
-            output_path,
-            project_name,
-            redaction_mode=redaction_mode,
-            redact_patterns=redact_patterns,
-            graph_data=graph_data,
+    The toggle sets `data-theme` on `<html>`, which wins over the system preference.
+    Any other `scope`, such as the fragment's `.docgenie`, gets the variables on that
+    element instead of `:root`.
+    """
+    light = _declarations(LIGHT_THEME, "  ")
+    dark = _declarations(DARK_THEME, "  ")
+    if scope != ":root":
+        return (
+            f"{scope} {{\n  color-scheme: light;\n{light}\n}}\n"
+            "@media (prefers-color-scheme: dark) {\n"
+            f"{scope} {{\n  color-scheme: dark;\n{dark}\n}}\n"
+            "}\n"
+            f'[data-theme="light"] {scope} {{\n  color-scheme: light;\n{light}\n}}\n'
+            f'[data-theme="dark"] {scope} {{\n  color-scheme: dark;\n{dark}\n}}\n'
         )
+    return (
+        f":root {{\n  color-scheme: light;\n{light}\n}}\n"
+        "@media (prefers-color-scheme: dark) {\n"
+        f':root:not([data-theme="light"]) {{\n  color-scheme: dark;\n{dark}\n}}\n'
+        "}\n"
+        f':root[data-theme="dark"] {{\n  color-scheme: dark;\n{dark}\n}}\n'
+    )
 
-    def _create_html_document(
-        self,
-        content: str,
-        project_name: str,
-        *,
-        graph_data: dict[str, Any] | None = None,
-    ) -> str:
-        safe_project_name = sanitize_html(project_name)
-        toc_html = getattr(self.markdown_processor, "toc", "")
-        generated_on = datetime.now().strftime("%B %d, %Y")
-        impact_block = self._impact_graph_block(graph_data)
 
-        return f"""<!DOCTYPE html>
-<html lang=\"en\">
-<head>
-  <meta charset=\"UTF-8\">
-  <meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\">
-  <title>{safe_project_name}</title>
-  <link rel=\"preconnect\" href=\"https://fonts.googleapis.com\">
-  <link rel=\"preconnect\" href=\"https://fonts.gstatic.com\" crossorigin>
-  <link href=\"https://fonts.googleapis.com/css2?family=IBM+Plex+Sans:wght@400;500;600;700&family=IBM+Plex+Mono:wght@400;500&display=swap\" rel=\"stylesheet\">
-  <style>{self._get_css_styles()}</style>
-</head>
-<body>
-  <a class=\"skip-link\" href=\"#main-content\">Skip to main content</a>
-  <button type=\"button\" class=\"mobile-menu-btn\" aria-label=\"Toggle menu\"></button>
-  <div class=\"layout\">
-    <aside class=\"sidebar\" aria-label=\"Table of contents\">
-      <div class=\"brand\">{safe_project_name}</div>
-      <label class=\"sr-only\" for=\"toc-filter\">Filter sections</label>
-      <input id=\"toc-filter\" class=\"toc-filter\" type=\"search\" placeholder=\"Filter sections\" autocomplete=\"off\" />
-      <nav class=\"toc\">{toc_html}</nav>
-    </aside>
-    <main id=\"main-content\" class=\"content\">
-      <header class=\"top\">
-        <h1>{safe_project_name}</h1>
-        <p>Generated by DocGenie on {generated_on}</p>
-      </header>
-      {impact_block}
-      <article class=\"markdown-content\">{content}</article>
-      <a href=\"#main-content\" class=\"back-to-top\" aria-label=\"Back to top\">Back to top</a>
-    </main>
-  </div>
-  <script>{self._get_javascript()}</script>
-</body>
-</html>"""
-
-    def _get_css_styles(self) -> str:
-        return """
-:root {
-  --primary-color: #1f4f78;
-  --accent-color: #0f766e;
-  --bg: #f7f8fa;
-  --surface: #ffffff;
-  --text: #111827;
-  --muted: #4b5563;
-  --border: #d1d5db;
-  --mono-bg: #f3f4f6;
-  --sidebar-width: 280px;
+def _declarations(values: dict[str, str], indent: str) -> str:
+    return "\n".join(f"{indent}--{name}: {value};" for name, value in values.items())
+
+
+# Fragment styles are nested under the wrapper div so they cannot restyle the host page.
+FRAGMENT_SCOPE = ".docgenie"
+_SPACING_CSS = """  --sidebar-width: 280px;
   --space-1: 8px;
   --space-2: 12px;
   --space-3: 16px;
   --space-4: 24px;
-  --space-5: 32px;
-}
-* { box-sizing: border-box; }
-body {
-  margin: 0;
-  color: var(--text);
+  --space-5: 32px;"""
+_TEXT_CSS = """  color: var(--text);
   background: var(--bg);
   font-family: 'IBM Plex Sans', sans-serif;
-  line-height: 1.6;
-}
-.layout { display: flex; min-height: 100vh; }
+  line-height: 1.6;"""
+# Rules for the standalone page only; a fragment leaves the host page's layout alone.
+_PAGE_CSS = f"""* {{ box-sizing: border-box; }}
+body {{
+  margin: 0;
+{_TEXT_CSS}
+}}
+.layout {{ display: flex; min-height: 100vh; }}
+@media (max-width: 960px) {{
+  .layout {{ display: block; }}
+}}
+"""
+_COMPONENT_CSS = """
 .sidebar {
   width: var(--sidebar-width);
   background: var(--surface);
@@ -166,14 +203,67 @@ body {
   padding: 10px;
   margin-bottom: var(--space-3);
 }
+.toc-panel summary { cursor: pointer; font-weight: 600; margin-bottom: var(--space-1); }
+.toc-panel ul { list-style: none; margin: 0; padding: 0; }
+.toc-panel li { padding: 2px 0; }
+.toc-panel .toc-level-3 { padding-left: var(--space-2); }
+.toc-panel .toc-level-4 { padding-left: var(--space-4); }
+.toc-panel .toc-level-5, .toc-panel .toc-level-6 { padding-left: var(--space-5); }
+.symbol-results { list-style: none; margin: 0 0 var(--space-3) 0; padding: 0; }
+.symbol-results button {
+  width: 100%;
+  text-align: left;
+  border: none;
+  background: none;
+  padding: 4px 0;
+  color: var(--primary-color);
+  font-family: 'IBM Plex Mono', monospace;
+  font-size: 0.85rem;
+  cursor: pointer;
+}
+.symbol-results button span { color: var(--muted); font-family: 'IBM Plex Sans', sans-serif; }
 .content {
   flex: 1;
   padding: var(--space-5);
   max-width: 900px;
   margin: 0 auto;
 }
+.top { position: relative; }
 .top h1 { margin: 0 0 var(--space-1) 0; }
+.theme-toggle {
+  position: absolute;
+  top: 0;
+  right: 0;
+  border: 1px solid var(--border);
+  border-radius: 8px;
+  background: var(--surface);
+  color: var(--text);
+  padding: 4px 10px;
+  font: inherit;
+  font-size: 0.85rem;
+  cursor: pointer;
+}
 .top p { margin: 0 0 var(--space-4) 0; color: var(--muted); }
+.badges { display: flex; flex-wrap: wrap; gap: var(--space-1); }
+.badge {
+  display: inline-flex;
+  border-radius: 4px;
+  overflow: hidden;
+  font-size: 0.75rem;
+  line-height: 1.6;
+  text-decoration: none;
+}
+.badge-label, .badge-message { padding: 0 6px; color: #ffffff; }
+.badge-label { background: #555555; }
+.badge-message { background: #007ec6; }
+.badge-brightgreen { background: #44cc11; }
+.badge-yellow { background: #b08800; }
+.badge-red { background: #e05d44; }
+.badge-blue, .badge-informational { background: #007ec6; }
+.complexity { font-weight: 600; }
+.complexity-low { color: var(--complexity-low); }
+.complexity-moderate { color: var(--complexity-moderate); }
+.complexity-high { color: var(--complexity-high); }
 .impact-graph-card {
   background: var(--surface);
   border: 1px solid var(--border);
@@ -192,8 +282,23 @@ body {
   height: 260px;
   border: 1px solid var(--border);
   border-radius: 8px;
-  background: #f9fafb;
+  background: var(--graph-bg);
 }
+#impact-graph .impact-empty { fill: var(--muted); font-size: 14px; }
+#impact-graph .impact-dot { fill: var(--graph-node); }
+#impact-graph .impact-dot-file { fill: var(--graph-file); }
+#impact-graph .impact-dot-package { fill: var(--graph-package); }
+#impact-graph .impact-dot-module { fill: var(--graph-module); }
+#impact-graph .impact-dot-output { fill: var(--graph-output); }
+#impact-graph .impact-dot-symbol { fill: var(--graph-symbol); }
+#impact-graph .impact-dot-external { fill: var(--graph-external); opacity: 0.6; }
+#impact-graph .impact-edge { stroke: var(--graph-edge); stroke-width: 1; }
+#impact-graph .impact-edge.is-external { stroke-dasharray: 3 3; opacity: 0.6; }
+#impact-graph .impact-edge.is-cycle { stroke: var(--graph-cycle); stroke-width: 2; }
+#impact-graph.is-focused .impact-node:not(.is-active),
+#impact-graph.is-focused .impact-edge:not(.is-inbound):not(.is-outbound) { opacity: 0.15; }
+#impact-graph .impact-edge.is-inbound { stroke: var(--graph-inbound); stroke-width: 2; }
+#impact-graph .impact-edge.is-outbound { stroke: var(--graph-outbound); stroke-width: 2; }
 .impact-graph-legend {
   margin-top: var(--space-2);
   color: var(--muted);
@@ -212,8 +317,8 @@ body {
   border-radius: 6px;
 }
 .markdown-content pre {
-  background: #111827;
-  color: #f9fafb;
+  background: var(--code-bg);
+  color: var(--code-text);
   padding: var(--space-3);
   border-radius: 10px;
   overflow-x: auto;
@@ -239,7 +344,6 @@ body {
   border: 0;
 }
 @media (max-width: 960px) {
-  .layout { display: block; }
   .sidebar {
     position: static;
     width: 100%;
@@ -252,15 +356,378 @@ body {
   #impact-graph { height: 220px; }
 }
 """
+_CSS_RULE_RE = re.compile(r"(^|[{}])(\s*)([^{}@;]+?)(\s*\{)")
+
+
+def scope_css(css: str, scope: str) -> str:
+    """Prefix every selector in `css` with `scope`: `.a, .b {` -> `.docgenie .a, .docgenie .b {`.
+
+    Rules inside `@media` blocks are scoped too. Only handles the flat rules of the
+    built-in stylesheet, not nested CSS or `@supports` conditions.
+    """
+
+    def prefix(match: re.Match[str]) -> str:
+        selectors = ", ".join(f"{scope} {part.strip()}" for part in match.group(3).split(","))
+        return f"{match.group(1)}{match.group(2)}{selectors}{match.group(4)}"
+
+    return _CSS_RULE_RE.sub(prefix, css)
+
+
+def load_theme_css(path: Path) -> str:
+    """Read a `--theme-css` stylesheet to inline after the built-in styles.
+
+    Raises ConfigError when the file cannot be read.
+    """
+    try:
+        css = path.read_text(encoding="utf-8")
+    except OSError as exc:
+        raise ConfigError(f"Theme stylesheet not readable: {path}") from exc
+    # Inline CSS must not be able to close the <style> element early.
+    return css.replace("</", "<\\/")
+
+
+def _render_section(
+    section: str,
+    module: str | None,
+    convert: Callable[[str], str],
+    section_cache: HtmlSectionCache | None,
+) -> str:
+    if section_cache is None:
+        return convert(section)
+    if module is None:
+        return section_cache.render(section, convert)
+    return section_cache.render_module(module, section, convert)
+
+
+def theme_css_path(analysis_data: dict[str, Any]) -> Path | None:
+    """Read `template_customizations.theme_css`, resolving it against the project root."""
+    config = analysis_data.get("config", {})
+    customizations = config.get("template_customizations", {}) if isinstance(config, dict) else {}
+    raw = customizations.get("theme_css") if isinstance(customizations, dict) else None
+    if not raw:
+        return None
+    path = Path(str(raw)).expanduser()
+    if not path.is_absolute():
+        path = Path(str(analysis_data.get("root_path", "."))) / path
+    return path
+
+
+class HTMLGenerator:
+    """Generate minimal, professional HTML docs from README or analysis data."""
+
+    def __init__(self) -> None:
+        self.markdown_processor = _markdown_processor(attr_list=True)
+        # Generated docs embed docstrings verbatim; `attr_list` would strip a trailing
+        # `{% ... %}` or `{key: value}` line from them as if it were `{.class}` syntax.
+        self.generated_processor = _markdown_processor(attr_list=False)
+
+    def generate_from_readme(  # noqa: PLR0913
+        self,
+        readme_content: str,
+        output_path: str | None = None,
+        project_name: str = "Project Documentation",
+        redaction_mode: str = "strict",
+        redact_patterns: list[str] | None = None,
+        graph_data: dict[str, Any] | None = None,
+        template_dir: Path | None = None,
+        toc_depth: int | None = DEFAULT_TOC_DEPTH,
+        toc_min_headings: int = DEFAULT_TOC_MIN_HEADINGS,
+        badges: list[dict[str, str]] | None = None,
+        section_cache: HtmlSectionCache | None = None,
+        attr_list: bool = True,
+        theme_css: str | None = None,
+        fragment: bool = False,
+    ) -> str:
+        """Render README markdown as an HTML page.
+
+        The sidebar lists `##` through `toc_depth` headings in a collapsible panel;
+        `toc_depth=None` leaves it out. `badges` are drawn as styled spans in the header.
+        Each `##`/`###` section is converted on its own, so with a `section_cache` only
+        sections whose Markdown changed since the last build are converted again, and
+        `## Modules` subsections the cache chose to reuse are spliced in from the last
+        build (see `HtmlSectionCache.reuse_module`).
+        `attr_list=False` leaves `{...}` attribute syntax as text, for Markdown that
+        embeds user-written docstrings. `theme_css` is inlined after the built-in
+        styles, so it can override their color variables.
+
+        `fragment=True` renders only the content div, without `<html>`/`<head>`, for
+        embedding in another page; the styles are written to `docgenie.css` next to
+        `output_path` instead of being inlined.
+        """
+        safe_readme = redact_text(readme_content, redaction_mode, redact_patterns or [])
+        processor = self.markdown_processor if attr_list else self.generated_processor
+
+        def convert(section: str) -> str:
+            return self._convert_section(section, processor)
+
+        content = "".join(
+            _render_section(section, module, convert, section_cache)
+            for module, section in module_sections(split_markdown_sections(safe_readme))
+        )
+        full_html = self._create_html_document(
+            content,
+            project_name,
+            graph_data=graph_data,
+            template_dir=template_dir,
+            toc_depth=toc_depth,
+            toc_min_headings=toc_min_headings,
+            badges=badges,
+            theme_css=theme_css,
+            fragment=fragment,
+        )
+        if output_path:
+            with open(output_path, "w", encoding="utf-8") as f:
+                f.write(full_html)
+            if fragment:
+                Path(output_path).with_name(FRAGMENT_CSS_FILENAME).write_text(
+                    self.stylesheet(theme_css, fragment=True), encoding="utf-8"
+                )
+        return full_html
+
+    def generate_from_analysis(
+        self,
+        analysis_data: dict[str, Any],
+        output_path: str | None = None,
+        readme_generator: ReadmeGenerator | None = None,
+    ) -> str:
+        """Render `analysis_data` as a single HTML page.
+
+        Pass the `readme_generator` used for the Markdown README to reuse its template
+        context (see `ReadmeGenerator.share_context`).
+
+        Unless incremental analysis is off, the page is built incrementally against
+        the section cache in the analysis cache directory, with or without
+        `output_path`: modules whose README data did not change since the last build
+        are neither rendered nor converted again, and their previous HTML is spliced
+        into the page. A custom README template renders every module, since it may
+        use module data outside the `## Modules` section.
+        """
+        template_dir = template_dir_from_config(analysis_data)
+        readme_gen = readme_generator or ReadmeGenerator(template_dir)
+        readme_template_dir = readme_gen.template_dir or template_dir
+        section_cache = self._section_cache(analysis_data, readme_template_dir, template_dir)
+        builtin_readme = (
+            template_path(README_TEMPLATE, readme_template_dir).parent == BUILTIN_TEMPLATE_DIR
+        )
+        readme_content = readme_gen.generate(
+            analysis_data,
+            include_toc=False,
+            include_badges=False,
+            reuse_module=section_cache.reuse_module
+            if section_cache is not None and builtin_readme
+            else None,
+        )
+        config = analysis_data.get("config", {})
+        toc = toc_settings(config)
+        customizations = (
+            config.get("template_customizations", {}) if isinstance(config, dict) else {}
+        )
+        safety = config.get("safety", {}) if isinstance(config, dict) else {}
+        redaction_mode = str(safety.get("redaction_mode", "strict"))
+        redact_patterns = safety.get("redact_patterns", []) if isinstance(safety, dict) else []
+        if not isinstance(redact_patterns, list):
+            redact_patterns = []
+
+        project_name = self._extract_project_name(analysis_data)
+        graph_data = self._build_impact_graph_data(analysis_data)
+        theme_path = theme_css_path(analysis_data)
+        full_html = self.generate_from_readme(
+            readme_content,
+            output_path,
+            project_name,
+            redaction_mode=redaction_mode,
+            redact_patterns=redact_patterns,
+            graph_data=graph_data,
+            template_dir=template_dir,
+            toc_depth=toc["depth"] if toc else None,
+            toc_min_headings=toc["min_headings"] if toc else 0,
+            badges=readme_gen.badges(analysis_data),
+            section_cache=section_cache,
+            attr_list=False,
+            theme_css=load_theme_css(theme_path) if theme_path else None,
+            fragment=isinstance(customizations, dict)
+            and bool(customizations.get("html_fragment", False)),
+        )
+        if output_path:
+            self.write_search_index(analysis_data, full_html, Path(output_path))
+        if section_cache is not None:
+            section_cache.persist()
+            get_logger(__name__).debug(
+                "HTML sections rendered",
+                reused=section_cache.hits,
+                converted=section_cache.misses,
+                modules_spliced=section_cache.modules_reused,
+            )
+        return full_html
+
+    def _section_cache(
+        self,
+        analysis_data: dict[str, Any],
+        readme_template_dir: Path | None,
+        template_dir: Path | None,
+    ) -> HtmlSectionCache | None:
+        """Open the section cache in the analysis cache directory.
+
+        Returns None when incremental analysis is off (`--no-cache`), so every
+        section is rendered and converted again.
+        """
+        config = analysis_data.get("config", {})
+        analysis_config = config.get("analysis", {}) if isinstance(config, dict) else {}
+        if not isinstance(analysis_config, dict) or not analysis_config.get("incremental", True):
+            return None
+        root = Path(str(analysis_data.get("root_path", ".")))
+        raw = analysis_config.get("cache_dir")
+        cache_dir = Path(str(raw)) if raw else root / ".docgenie"
+        if not cache_dir.is_absolute():
+            cache_dir = root / cache_dir
+        # Any template edit changes the fingerprint and so discards every cached section,
+        # as do the settings that shape a module's section beyond its own data.
+        fingerprint = hashlib.sha256(__version__.encode("utf-8"))
+        fingerprint.update(template_path(README_TEMPLATE, readme_template_dir).read_bytes())
+        fingerprint.update(template_path(HTML_TEMPLATE, template_dir).read_bytes())
+        config = config if isinstance(config, dict) else {}
+        customizations = config.get("template_customizations", {})
+        settings = {
+            "collapse_modules": customizations.get("collapse_modules")
+            if isinstance(customizations, dict)
+            else None,
+            "safety": config.get("safety"),
+        }
+        fingerprint.update(json.dumps(settings, sort_keys=True, default=str).encode("utf-8"))
+        return HtmlSectionCache(cache_dir, fingerprint.hexdigest())
+
+    def _convert_section(
+        self, markdown_text: str, processor: markdown.Markdown | None = None
+    ) -> str:
+        processor = processor or self.markdown_processor
+        processor.reset()
+        converted = processor.convert(markdown_text)
+        converted = mark_complexity_cells(converted)
+        # Sections convert independently and may repeat IDs until they are normalized.
+        return scope_heading_ids(converted, f"s{section_digest(markdown_text)[:8]}") + "\n"
+
+    def convert_generated(self, markdown_text: str) -> str:
+        """Convert generated Markdown section by section, as `generate_from_analysis` does.
+
+        Heading IDs are scoped per section; `normalize_heading_ids` makes them final.
+        """
+        return "".join(
+            self._convert_section(section, self.generated_processor)
+            for section in split_markdown_sections(markdown_text)
+        )
+
+    def write_search_index(
+        self, analysis_data: dict[str, Any], full_html: str, html_path: Path
+    ) -> Path:
+        """Write `search-index.json` next to `html_path` for the page's symbol search."""
+        index_path = html_path.with_name(SEARCH_INDEX_FILENAME)
+        entries = iter_search_entries(
+            analysis_data, code_heading_anchors(full_html), element_ids(full_html)
+        )
+        write_search_index(entries, index_path)
+        return index_path
+
+    def _create_html_document(
+        self,
+        content: str,
+        project_name: str,
+        *,
+        graph_data: dict[str, Any] | None = None,
+        template_dir: Path | None = None,
+        toc_depth: int | None = DEFAULT_TOC_DEPTH,
+        toc_min_headings: int = DEFAULT_TOC_MIN_HEADINGS,
+        badges: list[dict[str, str]] | None = None,
+        theme_css: str | None = None,
+        fragment: bool = False,
+    ) -> str:
+        safe_project_name = sanitize_html(project_name)
+        content, _ = normalize_heading_ids(content, "")
+        toc_html = (
+            toc_sidebar_html(content, depth=toc_depth, min_headings=toc_min_headings)
+            if toc_depth is not None
+            else ""
+        )
+        generated_on = build_time().strftime("%B %d, %Y")
+        impact_block = self._impact_graph_block(graph_data)
+
+        return load_template(HTML_TEMPLATE, template_dir).render(
+            {
+                "project_name": safe_project_name,
+                "toc_html": toc_html,
+                "content": content,
+                "impact_block": impact_block,
+                "badges_html": badges_html(badges or []),
+                "generated_on": generated_on,
+                "css": "" if fragment else self.stylesheet(theme_css),
+                "javascript": self._get_javascript(),
+                "fragment": fragment,
+            }
+        )
+
+    def stylesheet(self, theme_css: str | None = None, *, fragment: bool = False) -> str:
+        """Return the page styles, with `theme_css` appended so it wins over the built-ins.
+
+        With `fragment` every rule is scoped under `.docgenie` and the `body`, `:root`,
+        `*` and `.layout` rules are left out, so `docgenie.css` does not restyle the page
+        embedding it.
+        """
+        css = self._get_css_styles(fragment=fragment)
+        if theme_css:
+            css += f"\n/* --theme-css */\n{theme_css}\n"
+        return css
+
+    def _get_css_styles(self, *, fragment: bool = False) -> str:
+        if fragment:
+            return (
+                theme_variables_css(FRAGMENT_SCOPE)
+                + f"{FRAGMENT_SCOPE} {{\n{_SPACING_CSS}\n{_TEXT_CSS}\n}}\n"
+                + scope_css(_COMPONENT_CSS, FRAGMENT_SCOPE)
+            )
+        return (
+            theme_variables_css()
+            + f":root {{\n{_SPACING_CSS}\n}}\n"
+            + _PAGE_CSS
+            + _COMPONENT_CSS
+        )
 
     def _get_javascript(self) -> str:
         return """
+// Theme toggle: an explicit choice is stored and wins over prefers-color-scheme.
+const root = document.documentElement;
+try {
+  const storedTheme = localStorage.getItem('docgenie-theme');
+  if (!root.dataset.theme && (storedTheme === 'dark' || storedTheme === 'light')) {
+    root.dataset.theme = storedTheme;
+  }
+} catch (_err) {}
+const themeToggle = document.querySelector('.theme-toggle');
+if (themeToggle) {
+  const systemDark = window.matchMedia && window.matchMedia('(prefers-color-scheme: dark)');
+  const activeTheme = () => root.dataset.theme || (systemDark && systemDark.matches ? 'dark' : 'light');
+  const showTheme = () => {
+    const dark = activeTheme() === 'dark';
+    themeToggle.setAttribute('aria-pressed', String(dark));
+    themeToggle.textContent = dark ? 'Light mode' : 'Dark mode';
+  };
+  themeToggle.addEventListener('click', () => {
+    const next = activeTheme() === 'dark' ? 'light' : 'dark';
+    root.dataset.theme = next;
+    try {
+      localStorage.setItem('docgenie-theme', next);
+    } catch (_err) {}
+    showTheme();
+  });
+  if (systemDark && systemDark.addEventListener) systemDark.addEventListener('change', showTheme);
+  showTheme();
+}
+
 // Smooth scrolling for hash links.
 document.querySelectorAll('a[href^="#"]').forEach((anchor) => {
   anchor.addEventListener('click', (e) => {
     const id = anchor.getAttribute('href');
     if (!id || id === '#') return;
-    const target = document.querySelector(id);
+    // getElementById accepts any ID; querySelector rejects ones starting with a digit.
+    const target = document.getElementById(decodeURIComponent(id.slice(1)));
     if (!target) return;
     e.preventDefault();
     target.scrollIntoView({ behavior: 'smooth', block: 'start' });
@@ -279,6 +746,76 @@ if (filter) {
   });
 }
 
+// Symbol search: search-index.json when served over HTTP; page headings otherwise. A page
+// embedding the fragment can point data-search-index at wherever it serves the index.
+const symbolSearch = document.getElementById('symbol-search');
+const symbolResults = document.getElementById('symbol-search-results');
+if (symbolSearch && symbolResults) {
+  let symbols = Array.from(document.querySelectorAll('.markdown-content [id] > code'))
+    .map((code) => ({
+      name: String(code.textContent || '').split('(')[0],
+      kind: 'heading',
+      module: '',
+      anchor: code.parentElement.id,
+    }));
+  if (window.fetch) {
+    const indexHolder = document.querySelector('[data-search-index]');
+    fetch(indexHolder ? indexHolder.dataset.searchIndex : 'search-index.json')
+      .then((response) => (response.ok ? response.json() : null))
+      .then((data) => {
+        if (Array.isArray(data)) symbols = data;
+      })
+      .catch(() => {});
+  }
+
+  const jumpTo = (anchor) => {
+    const target = anchor ? document.getElementById(anchor) : null;
+    if (!target) return;
+    target.scrollIntoView({ behavior: 'smooth', block: 'start' });
+    history.replaceState(null, '', '#' + anchor);
+  };
+
+  const findSymbols = (term) => {
+    if (!term) return [];
+    const rank = (symbol) => {
+      const name = String(symbol.name || '').toLowerCase();
+      if (name === term) return 0;
+      return name.startsWith(term) ? 1 : 2;
+    };
+    return symbols
+      .filter((symbol) => String(symbol.name || '').toLowerCase().includes(term))
+      .sort((a, b) => rank(a) - rank(b))
+      .slice(0, 20);
+  };
+
+  const renderSymbols = (matches) => {
+    symbolResults.innerHTML = '';
+    matches.forEach((symbol) => {
+      const item = document.createElement('li');
+      const button = document.createElement('button');
+      const kind = document.createElement('span');
+      button.type = 'button';
+      button.title = String(symbol.module || '');
+      button.textContent = String(symbol.name || '') + ' ';
+      kind.textContent = String(symbol.kind || '');
+      button.appendChild(kind);
+      button.addEventListener('click', () => jumpTo(symbol.anchor));
+      item.appendChild(button);
+      symbolResults.appendChild(item);
+    });
+  };
+
+  const currentTerm = () => String(symbolSearch.value || '').trim().toLowerCase();
+  symbolSearch.addEventListener('input', () => renderSymbols(findSymbols(currentTerm())));
+  symbolSearch.addEventListener('keydown', (event) => {
+    if (event.key !== 'Enter') return;
+    const matches = findSymbols(currentTerm());
+    if (!matches.length) return;
+    event.preventDefault();
+    jumpTo(matches[0].anchor);
+  });
+}
+
 const impactDataTag = document.getElementById('impact-graph-data');
 if (impactDataTag) {
   let payload = { nodes: [], edges: [] };
@@ -293,9 +830,13 @@ if (impactDataTag) {
 
 function renderImpactGraph(svg, payload) {
   const nodes = Array.isArray(payload.nodes) ? payload.nodes.slice(0, 80) : [];
-  const edges = Array.isArray(payload.edges) ? payload.edges.slice(0, 160) : [];
+  // Only edges between drawn nodes count towards the edge limit.
+  const drawn = new Set(nodes.map((node) => node.id));
+  const edges = (Array.isArray(payload.edges) ? payload.edges : [])
+    .filter((edge) => drawn.has(edge.source) && drawn.has(edge.target))
+    .slice(0, 160);
   if (!nodes.length) {
-    svg.innerHTML = '<text x="20" y="40" fill="#6b7280" font-size="14">No impact graph data available</text>';
+    svg.innerHTML = '<text class="impact-empty" x="20" y="40">No impact graph data available</text>';
     return;
   }
 
@@ -312,17 +853,19 @@ function renderImpactGraph(svg, payload) {
       x: cx + Math.cos(angle) * radius,
       y: cy + Math.sin(angle) * radius,
       type: node.type,
-      label: node.label,
+      label: node.module ? node.label + ' (' + node.module + ')' : node.label,
     });
   });
 
-  const colorByType = { file: '#1f4f78', module: '#0f766e', output: '#b45309' };
   const edgeSvg = edges
     .map((edge) => {
       const s = positions.get(edge.source);
       const t = positions.get(edge.target);
       if (!s || !t) return '';
-      return '<line x1="' + s.x + '" y1="' + s.y + '" x2="' + t.x + '" y2="' + t.y + '" stroke="#cbd5e1" stroke-width="1" />';
+      let cls = edge.cycle ? 'impact-edge is-cycle' : 'impact-edge';
+      if (t.type === 'external') cls += ' is-external';
+      const ends = ' data-source="' + escapeAttr(edge.source) + '" data-target="' + escapeAttr(edge.target) + '"';
+      return '<line class="' + cls + '"' + ends + ' x1="' + s.x + '" y1="' + s.y + '" x2="' + t.x + '" y2="' + t.y + '" />';
     })
     .join('');
 
@@ -330,13 +873,45 @@ function renderImpactGraph(svg, payload) {
     .map((node) => {
       const p = positions.get(node.id);
       if (!p) return '';
-      const fill = colorByType[node.type] || '#334155';
+      // Colors come from the --graph-* theme variables via the type class.
+      const dot = 'impact-dot impact-dot-' + String(node.type || '').replace(/[^a-z-]/g, '');
       const safeLabel = String(p.label || '').replace(/&/g, '&amp;').replace(/</g, '&lt;');
-      return '<g><circle cx="' + p.x + '" cy="' + p.y + '" r="5" fill="' + fill + '"></circle><title>' + safeLabel + '</title></g>';
+      return '<g class="impact-node" data-id="' + escapeAttr(node.id) + '"><circle class="' + dot + '" cx="' + p.x + '" cy="' + p.y + '" r="5"></circle><title>' + safeLabel + '</title></g>';
     })
     .join('');
 
   svg.innerHTML = edgeSvg + nodeSvg;
+
+  // Hovering a node highlights its callers (inbound) and callees (outbound).
+  const neighbors = new Map();
+  edges.forEach((edge) => {
+    if (!positions.has(edge.source) || !positions.has(edge.target)) return;
+    [[edge.source, edge.target], [edge.target, edge.source]].forEach(([from, to]) => {
+      if (!neighbors.has(from)) neighbors.set(from, new Set());
+      neighbors.get(from).add(to);
+    });
+  });
+  svg.querySelectorAll('.impact-node').forEach((group) => {
+    const id = group.getAttribute('data-id');
+    group.addEventListener('mouseenter', () => highlightNeighbors(svg, id, neighbors.get(id) || new Set()));
+    group.addEventListener('mouseleave', () => svg.classList.remove('is-focused'));
+  });
+}
+
+function highlightNeighbors(svg, id, linked) {
+  svg.classList.add('is-focused');
+  svg.querySelectorAll('.impact-node').forEach((group) => {
+    const nodeId = group.getAttribute('data-id');
+    group.classList.toggle('is-active', nodeId === id || linked.has(nodeId));
+  });
+  svg.querySelectorAll('.impact-edge').forEach((line) => {
+    line.classList.toggle('is-inbound', line.getAttribute('data-target') === id);
+    line.classList.toggle('is-outbound', line.getAttribute('data-source') === id);
+  });
+}
+
+function escapeAttr(value) {
+  return String(value).replace(/&/g, '&amp;').replace(/"/g, '&quot;').replace(/</g, '&lt;');
 }
 """
 
@@ -348,50 +923,22 @@ function renderImpactGraph(svg, payload) {
             '<p class="impact-graph-hint">Dependency and output-flow impact for changed files.</p>'
             '<svg id="impact-graph" aria-label="Impact graph"></svg>'
             '<div class="impact-graph-legend">'
-            "Blue: files, Teal: modules, Amber: output targets"
+            "Blue: files and packages, Teal: modules, Amber: output targets, "
+            "Purple: symbols (hover for the defining module), "
+            "Red edges: circular dependencies. "
+            "Hover a node to highlight its callers (green) and callees (blue). "
+            f"{impact_graph_summary(graph_data or {})}."
             "</div>"
             f'<script id="impact-graph-data" type="application/json">{payload}</script>'
             "</section>"
         )
 
     def _build_impact_graph_data(self, analysis_data: dict[str, Any]) -> dict[str, Any]:
-        nodes: dict[str, dict[str, str]] = {}
-        edges: list[dict[str, str]] = []
-
-        def add_node(node_id: str, label: str, node_type: str) -> None:
-            if node_id not in nodes:
-                nodes[node_id] = {"id": node_id, "label": label, "type": node_type}
-
-        file_imports = analysis_data.get("file_imports", {})
-        if isinstance(file_imports, dict):
-            for path, imports in file_imports.items():
-                file_id = f"file:{path}"
-                add_node(file_id, str(path), "file")
-                if isinstance(imports, list):
-                    for imported in imports[:8]:
-                        module_id = f"module:{imported}"
-                        add_node(module_id, str(imported), "module")
-                        edges.append({"source": file_id, "target": module_id, "kind": "import"})
-
-        for link in analysis_data.get("output_links", [])[:60]:
-            source = str(link.get("source_file", ""))
-            target = str(link.get("target_file") or "unresolved-output")
-            if not source:
-                continue
-            src_id = f"file:{source}"
-            tgt_id = f"output:{target}"
-            add_node(src_id, source, "file")
-            add_node(tgt_id, target, "output")
-            edges.append({"source": src_id, "target": tgt_id, "kind": "output"})
-
-        diff_summary = analysis_data.get("diff_summary", {})
-        if isinstance(diff_summary, dict):
-            for item in diff_summary.get("files", [])[:40]:
-                path = str(item.get("path", ""))
-                if path:
-                    add_node(f"file:{path}", path, "file")
-
-        return {"nodes": list(nodes.values())[:120], "edges": edges[:220]}
+        return build_impact_graph_data(
+            analysis_data,
+            group_by=module_grouping(analysis_data),
+            scope=graph_scope(analysis_data),
+        )
 
     def _extract_project_name(self, analysis_data: dict[str, Any]) -> str:
         project_name = analysis_data.get("project_name")
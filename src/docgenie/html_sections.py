@@ -2,10 +2,51 @@
 
 from __future__ import annotations
 
+import html
 import json
 import re
+import sys
+import unicodedata
+from collections.abc import Collection, Iterable, Iterator
+from functools import lru_cache
+from pathlib import Path
 from typing import Any
 
+from .complexity import complexity_level
+from .cycles import MAX_REPORTED_CYCLES, find_cycles, mark_cycle_edges
+from .external_calls import is_external_import, local_modules
+from .go_interfaces import find_go_implementations
+from .layers import import_targets
+from .module_index import (
+    is_visible,
+    package_of,
+    relative_path,
+    symbol_visibility,
+    type_param_constraints,
+)
+from .reproducible import path_root
+
+SEARCH_INDEX_FILENAME = "search-index.json"
+# The stylesheet written next to an HTML fragment, for the host page to link.
+FRAGMENT_CSS_FILENAME = "docgenie.css"
+GRAPH_SCOPES = ("all", "internal-only")
+# Imports drawn per file; the rest still count towards cycles.
+_MAX_DRAWN_IMPORTS = 8
+
+_HEADING_RE = re.compile(
+    r'<h(?P<level>[1-6])\s+id="(?P<id>[^"]+)">(?P<body>.*?)</h[1-6]>',
+    re.DOTALL,
+)
+_CODE_HEADING_RE = re.compile(r"^\s*<code>(?P<text>.*?)</code>", re.DOTALL)
+_ID_ATTR_RE = re.compile(r'\bid="([^"]+)"')
+_LOCAL_HREF_RE = re.compile(r'href="#(?P<id>[^"]+)"')
+_TABLE_RE = re.compile(r"<table\b.*?</table>", re.DOTALL)
+_TR_RE = re.compile(r"<tr\b.*?</tr>", re.DOTALL)
+_TH_RE = re.compile(r"<th\b[^>]*>(.*?)</th>", re.DOTALL)
+_TD_RE = re.compile(r"<td(?P<attrs>\b[^>]*)>(?P<body>.*?)</td>", re.DOTALL)
+# A template heading without `{{ }}` / `{% %}`, whose ID is the same in every README.
+_FIXED_HEADING_RE = re.compile(r"^#{2,6} ([^{}\n]+)$", re.MULTILINE)
+
 
 def impact_graph_block(graph_data: dict[str, Any] | None) -> str:
     payload_dict = graph_data or {
@@ -15,20 +56,7 @@ def impact_graph_block(graph_data: dict[str, Any] | None) -> str:
         "total_edges": 0,
     }
     payload = json.dumps(payload_dict, sort_keys=True)
-    total_nodes_default = len(payload_dict.get("nodes", [])) or 0
-    total_nodes = int(payload_dict.get("total_nodes", total_nodes_default))
-    total_edges_default = len(payload_dict.get("edges", [])) or 0
-    total_edges = int(payload_dict.get("total_edges", total_edges_default))
-    nodes_list = payload_dict.get("nodes", [])
-    shown_nodes = len(nodes_list) if isinstance(nodes_list, list) else 0
-    edges_list = payload_dict.get("edges", [])
-    shown_edges = len(edges_list) if isinstance(edges_list, list) else 0
-    truncated = bool(payload_dict.get("truncated", False))
-    summary = (
-        f"Showing {shown_nodes}/{total_nodes} nodes and {shown_edges}/{total_edges} edges"
-        if truncated
-        else f"{shown_nodes} nodes and {shown_edges} edges"
-    )
+    summary = impact_graph_summary(payload_dict)
     return (
         '<section class="impact-graph-card">'
         '<div class="impact-graph-header"><h2>Impact Graph</h2></div>'
@@ -40,31 +68,110 @@ def impact_graph_block(graph_data: dict[str, Any] | None) -> str:
         "</div>"
         '<svg id="impact-graph" aria-label="Impact graph"></svg>'
         '<div class="impact-graph-legend">'
-        f"Blue: files, Teal: modules, Amber: output targets. {summary}."
+        "Blue: files and packages, Teal: modules, Amber: output targets, Purple: symbols, "
+        f"Gray: external calls, Red edges: circular dependencies. {summary}."
         "</div>"
         f'<script id="impact-graph-data" type="application/json">{payload}</script>'
         "</section>"
     )
 
 
-def build_impact_graph_data(analysis_data: dict[str, Any]) -> dict[str, Any]:
+def graph_scope(analysis_data: dict[str, Any]) -> str:
+    """Return the configured impact-graph scope, `all` unless set to `internal-only`."""
+    config = analysis_data.get("config", {})
+    customizations = config.get("template_customizations", {}) if isinstance(config, dict) else {}
+    value = customizations.get("graph_scope") if isinstance(customizations, dict) else None
+    return str(value) if value in GRAPH_SCOPES else GRAPH_SCOPES[0]
+
+
+def impact_graph_summary(graph_data: dict[str, Any]) -> str:
+    """Describe how much of the impact graph is drawn, with omitted counts when truncated."""
+    nodes_list = graph_data.get("nodes", [])
+    shown_nodes = len(nodes_list) if isinstance(nodes_list, list) else 0
+    edges_list = graph_data.get("edges", [])
+    shown_edges = len(edges_list) if isinstance(edges_list, list) else 0
+    if not graph_data.get("truncated", False):
+        return f"{shown_nodes} nodes and {shown_edges} edges"
+    total_nodes = int(graph_data.get("total_nodes", shown_nodes))
+    total_edges = int(graph_data.get("total_edges", shown_edges))
+    omitted_edges = int(graph_data.get("omitted_edges", total_edges - shown_edges))
+    return (
+        f"Showing {shown_nodes}/{total_nodes} nodes and {shown_edges}/{total_edges} edges "
+        f"({total_nodes - shown_nodes} nodes and {omitted_edges} edges omitted)"
+    )
+
+
+def build_impact_graph_data(
+    analysis_data: dict[str, Any],
+    *,
+    max_nodes: int = 600,
+    max_edges: int = 1400,
+    max_cycles: int = MAX_REPORTED_CYCLES,
+    group_by: str = "file",
+    scope: str = "all",
+) -> dict[str, Any]:
+    """Build the impact graph payload.
+
+    With `scope="internal-only"`, imports of packages outside the repository and
+    calls into them are left out, so only repository files and symbols remain.
+    """
     nodes: dict[str, dict[str, str]] = {}
-    edges: list[dict[str, str]] = []
+    edges: list[dict[str, Any]] = []
+    # File-to-file imports past the per-file drawing cap; only cycle detection sees them.
+    hidden_imports: list[dict[str, Any]] = []
 
-    def add_node(node_id: str, label: str, node_type: str) -> None:
+    def add_node(node_id: str, label: str, node_type: str, module: str = "") -> None:
         if node_id not in nodes:
             nodes[node_id] = {"id": node_id, "label": label, "type": node_type}
+            if module:
+                nodes[node_id]["module"] = module
 
     file_imports = analysis_data.get("file_imports", {})
+    workspace = analysis_data.get("workspace")
     if isinstance(file_imports, dict):
+        local = local_modules(file_imports)
+        resolved: dict[str, list[str]] = {}
         for path, imports in file_imports.items():
             file_id = f"file:{path}"
             add_node(file_id, str(path), "file")
-            if isinstance(imports, list):
-                for imported in imports[:8]:
-                    module_id = f"module:{imported}"
-                    add_node(module_id, str(imported), "module")
-                    edges.append({"source": file_id, "target": module_id, "kind": "import"})
+            if not isinstance(imports, list):
+                continue
+            for position, imported in enumerate(imports):
+                # Import specs (`pkg.calc`, `example.com/app/services`) that name analyzed
+                # files link file-to-file, so cycles between them are found.
+                if imported not in resolved:
+                    resolved[imported] = import_targets(str(imported), file_imports)
+                targets = [target for target in resolved[imported] if target != path]
+                if position >= _MAX_DRAWN_IMPORTS:
+                    hidden_imports.extend(
+                        {"source": file_id, "target": f"file:{target}", "kind": "import"}
+                        for target in targets
+                    )
+                    continue
+                # In workspace mode, imports of a sibling subproject cross its boundary.
+                subproject = import_subproject(str(imported), workspace)
+                if targets:
+                    target_ids = [f"file:{target}" for target in targets]
+                    for target in targets:
+                        add_node(f"file:{target}", target, "file")
+                elif (
+                    scope == "internal-only"
+                    and subproject is None
+                    and is_external_import(str(imported), file_imports, local)
+                ):
+                    continue
+                else:
+                    target_ids = [f"module:{imported}"]
+                    add_node(target_ids[0], str(imported), "module")
+                for target_id in target_ids:
+                    edge: dict[str, Any] = {
+                        "source": file_id,
+                        "target": target_id,
+                        "kind": "import",
+                    }
+                    if subproject is not None:
+                        edge.update(external=True, subproject=subproject)
+                    edges.append(edge)
 
     for link in analysis_data.get("output_links", [])[:60]:
         source = str(link.get("source_file", ""))
@@ -84,11 +191,104 @@ def build_impact_graph_data(analysis_data: dict[str, Any]) -> dict[str, Any]:
             if path:
                 add_node(f"file:{path}", path, "file")
 
+    # Symbols come last so truncation drops them before files, modules and outputs.
+    symbols = _graph_symbols(analysis_data)
+    by_name: dict[str, list[str]] = {}
+    declared = {(module, name) for module, name, _bases in symbols}
+    qualified: dict[tuple[str, str], str] = {}
+    for module, name, _bases in symbols:
+        by_name.setdefault(name, []).append(module)
+        short = base_name(name)
+        if short != name and (module, short) not in declared:
+            # Namespace-qualified names (C# `Shop.Models.User`) are found by their last segment.
+            modules = by_name.setdefault(short, [])
+            if module not in modules:
+                modules.append(module)
+            qualified.setdefault((module, short), name)
+    for module, name, bases in symbols:
+        symbol_id = symbol_node_id(module, name)
+        file_id = f"file:{module}"
+        add_node(file_id, module, "file")
+        add_node(symbol_id, name, "symbol", module)
+        edges.append({"source": file_id, "target": symbol_id, "kind": "defines"})
+        imported = file_imports.get(module, []) if isinstance(file_imports, dict) else []
+        for base in bases:
+            base_module = resolve_symbol_module(base, module, imported, by_name)
+            if base_module is not None:
+                target_id = symbol_node_id(base_module, qualified.get((base_module, base), base))
+                edges.append({"source": symbol_id, "target": target_id, "kind": "extends"})
+
+    # Generic symbols depend on the interfaces their type parameters are constrained by.
+    for module, name, constraints in _graph_constraints(analysis_data):
+        imported = file_imports.get(module, []) if isinstance(file_imports, dict) else []
+        for constraint in constraints:
+            target = resolve_symbol_module(constraint, module, imported, by_name)
+            if target is not None:
+                edges.append(
+                    {
+                        "source": symbol_node_id(module, name),
+                        "target": symbol_node_id(
+                            target, qualified.get((target, constraint), constraint)
+                        ),
+                        "kind": "constraint",
+                    }
+                )
+
+    # Go interfaces are satisfied implicitly; link each implementer to the interface.
+    for (module, interface), implementers in find_go_implementations(analysis_data).items():
+        for implementer in implementers:
+            edges.append(
+                {
+                    "source": symbol_node_id(implementer["module"], implementer["name"]),
+                    "target": symbol_node_id(module, interface),
+                    "kind": "implements",
+                }
+            )
+
+    # Mentions of a symbol in other files; ambiguous names link to every candidate.
+    references = analysis_data.get("symbol_references", {})
+    if not isinstance(references, dict):
+        references = {}
+    for path, names in references.items():
+        imported = file_imports.get(path, []) if isinstance(file_imports, dict) else []
+        for name in names:
+            resolved = resolve_symbol_module(name, path, imported, by_name)
+            for target in [resolved] if resolved else by_name.get(name, []):
+                if target != path:
+                    edges.append(
+                        {
+                            "source": f"file:{path}",
+                            "target": symbol_node_id(target, name),
+                            "kind": "references",
+                        }
+                    )
+
+    # Calls into third-party and standard-library packages come last, so they are
+    # truncated first; the HTML graph draws them muted.
+    external_calls = analysis_data.get("external_calls", {})
+    if scope != "internal-only" and isinstance(external_calls, dict):
+        for path, calls in external_calls.items():
+            for call in calls[:8]:
+                add_node(f"external:{call}", str(call), "external")
+                edges.append(
+                    {
+                        "source": f"file:{path}",
+                        "target": f"external:{call}",
+                        "kind": "calls",
+                    }
+                )
+
+    if group_by == "package":
+        hidden_imports = collapse_packages(nodes, hidden_imports)[1]
+        nodes, edges = collapse_packages(nodes, edges)
     all_nodes = list(nodes.values())
     all_edges = list(edges)
-    max_nodes = 600
-    max_edges = 1400
+    # Cycles come from the full edge set so truncation cannot hide or invent one.
+    cycles = find_cycles(all_edges + hidden_imports)
+    mark_cycle_edges(all_edges, cycles)
     render_nodes = all_nodes[:max_nodes]
+    # Edges are kept only when both endpoints survived the node cap, so the renderer
+    # never sees an edge to a node that is not in the payload.
     allowed_ids = {n.get("id", "") for n in render_nodes}
     render_edges = [
         e
@@ -101,44 +301,512 @@ def build_impact_graph_data(analysis_data: dict[str, Any]) -> dict[str, Any]:
         "edges": render_edges,
         "total_nodes": len(all_nodes),
         "total_edges": len(all_edges),
+        "omitted_edges": len(all_edges) - len(render_edges),
         "truncated": truncated,
+        "cycles": cycles[:max_cycles],
+        "total_cycles": len(cycles),
     }
 
 
-def normalize_heading_ids(content: str, toc_html: str) -> tuple[str, str]:
-    """Normalize heading IDs to avoid awkward suffixes like `_1`."""
+def collapse_packages(
+    nodes: dict[str, dict[str, str]], edges: list[dict[str, Any]]
+) -> tuple[dict[str, dict[str, str]], list[dict[str, Any]]]:
+    """Merge file nodes into one `package` node per `package_of` and drop intra-package edges.
+
+    Imports between files of one package and references from one of its files to a
+    symbol declared in another disappear; symbols stay, defined by their package.
+    """
+
+    def package_id(node_id: str) -> str:
+        if node_id.startswith("file:"):
+            return f"package:{package_of(node_id[len('file:') :])}"
+        return node_id
+
+    collapsed: dict[str, dict[str, str]] = {}
+    for node_id, node in nodes.items():
+        new_id = package_id(node_id)
+        if new_id == node_id:
+            collapsed[node_id] = node
+        elif new_id not in collapsed:
+            label = new_id.split(":", 1)[1]
+            collapsed[new_id] = {"id": new_id, "label": label, "type": "package"}
+
+    merged: list[dict[str, Any]] = []
+    seen: set[tuple[str, str, str]] = set()
+    for edge in edges:
+        source = package_id(str(edge.get("source", "")))
+        target = package_id(str(edge.get("target", "")))
+        module = nodes.get(target, {}).get("module")
+        owner = f"package:{package_of(module)}" if module else target
+        kind = str(edge.get("kind", ""))
+        if source == target or (kind != "defines" and source == owner):
+            continue
+        if (source, target, kind) not in seen:
+            seen.add((source, target, kind))
+            merged.append({**edge, "source": source, "target": target})
+    return collapsed, merged
+
+
+def invert_edges(edges: Iterable[dict[str, Any]]) -> dict[str, list[dict[str, Any]]]:
+    """Map each node ID to the edges pointing at it, skipping file-to-own-symbol `defines`."""
+    inbound: dict[str, list[dict[str, Any]]] = {}
+    for edge in edges:
+        if edge.get("kind") == "defines":
+            continue
+        inbound.setdefault(str(edge.get("target", "")), []).append(edge)
+    return inbound
+
+
+def build_caller_index(
+    analysis_data: dict[str, Any], *, max_callers: int = 10
+) -> dict[str, dict[str, Any]]:
+    """Return the "Called By" listing for every symbol with at least one inbound edge.
+
+    Keys are `symbol_node_id`s. Each entry holds up to `max_callers` callers (files that
+    reference the symbol, or symbols extending or implementing it) ordered by label, plus
+    `total` and `truncated` in the same way the impact graph reports `total_nodes`.
+    Callers are taken from the full graph, so the counts do not depend on its display caps.
+    """
+    graph = build_impact_graph_data(analysis_data, max_nodes=sys.maxsize, max_edges=sys.maxsize)
+    nodes = {str(node["id"]): node for node in graph["nodes"]}
+    index: dict[str, dict[str, Any]] = {}
+    for target, edges in invert_edges(graph["edges"]).items():
+        if not target.startswith("symbol:"):
+            continue
+        callers: dict[str, dict[str, str]] = {}
+        for edge in edges:
+            source = str(edge.get("source", ""))
+            node = nodes.get(source, {})
+            callers.setdefault(
+                source,
+                {
+                    "label": str(node.get("label") or source.split(":", 1)[-1]),
+                    "module": str(node.get("module", "")),
+                    "kind": str(edge.get("kind", "")),
+                },
+            )
+        ordered = sorted(callers.values(), key=lambda caller: (caller["label"], caller["module"]))
+        shown = ordered[: max(max_callers, 0)]
+        index[target] = {
+            "callers": shown,
+            "total": len(ordered),
+            "truncated": len(ordered) > len(shown),
+        }
+    return index
+
+
+def symbol_node_id(module: str, name: str) -> str:
+    """Return the graph key for a symbol, qualified by its module so equal names stay apart."""
+    return f"symbol:{module}::{name}"
+
+
+def _graph_symbols(analysis_data: dict[str, Any]) -> list[tuple[str, str, list[str]]]:
+    """Return `(module, name, base names)` for every top-level function and class.
+
+    Repeated declarations of a name in one module (a Swift `extension` or a Rust
+    `impl` next to the type) are merged, so their conformances join the type's bases.
+    """
+    root = path_root(analysis_data)
+    symbols: dict[tuple[str, str], list[str]] = {}
+    for key in ("functions", "classes"):
+        for item in analysis_data.get(key, []):
+            if not isinstance(item, dict) or not item.get("name") or not item.get("file"):
+                continue
+            module = relative_path(root, str(item["file"]))
+            bases = symbols.setdefault((module, str(item["name"])), [])
+            for base in item.get("bases", []) or []:
+                name = base_name(str(base))
+                if name and name not in bases:
+                    bases.append(name)
+    return [(module, name, bases) for (module, name), bases in symbols.items()]
+
+
+def _graph_constraints(analysis_data: dict[str, Any]) -> list[tuple[str, str, list[str]]]:
+    """Return `(module, name, constraint names)` for generic functions and classes."""
+    root = path_root(analysis_data)
+    found: list[tuple[str, str, list[str]]] = []
+    for key in ("functions", "classes"):
+        for item in analysis_data.get(key, []):
+            if not isinstance(item, dict) or not item.get("name") or not item.get("file"):
+                continue
+            constraints = type_param_constraints(item.get("type_params"))
+            if constraints:
+                module = relative_path(root, str(item["file"]))
+                found.append((module, str(item["name"]), constraints))
+    return found
+
+
+def base_name(base: str) -> str:
+    """Strip generics, call arguments and qualifiers: `pkg.Base[T]` -> `Base`."""
+    bare = re.split(r"[<(\[]", base, maxsplit=1)[0].strip()
+    return re.split(r"\.|::", bare)[-1]
+
+
+def resolve_symbol_module(
+    name: str, module: str, imported: list[str], by_name: dict[str, list[str]]
+) -> str | None:
+    """Pick the module defining `name`: same module, then imported modules, then a unique match.
+
+    Ambiguous names stay unlinked rather than being attributed to the wrong module.
+    """
+    candidates = by_name.get(name, [])
+    if module in candidates:
+        return module
+    imported_matches = [candidate for candidate in candidates if candidate in imported]
+    if len(imported_matches) == 1:
+        return imported_matches[0]
+    if len(candidates) == 1:
+        return candidates[0]
+    return None
+
+
+def import_subproject(imported: str, workspace: dict[str, Any] | None) -> str | None:
+    """Return the path of the sibling workspace subproject `imported` belongs to, if any."""
+    packages = workspace.get("packages", {}) if isinstance(workspace, dict) else {}
+    for package, path in packages.items():
+        if imported == package or imported.startswith((f"{package}/", f"{package}.")):
+            return str(path)
+    return None
+
+
+def slugify(text: str) -> str:
+    """Lowercase `text` and join its runs of Unicode letters and digits with `-`.
+
+    Accented and CJK characters are kept rather than dropped, after NFC
+    normalization so composed and decomposed spellings give the same slug:
+    `Größe_Wert` -> `größe-wert`, `获取用户` -> `获取用户`. Every anchor in the README,
+    HTML and search index is built from this one function.
+    """
+    normalized = unicodedata.normalize("NFC", text).lower()
+    return re.sub(r"[\W_]+", "-", normalized).strip("-")
+
+
+def heading_slug(text: str) -> str:
+    """Slugify heading text or HTML: `<code>src/app.py</code>` -> `src-app-py`."""
+    return slugify(_plain_text(text)) or "section"
+
+
+def allocate_heading_ids(headings: Iterable[str], reserved: Iterable[str] = ()) -> list[str]:
+    """Return a unique ID for each heading in document order.
+
+    Repeats get `-2`, `-3`, ... suffixes so the first occurrence keeps the bare slug,
+    and no ID reuses one in `reserved` or one handed out earlier.
+    """
+    taken = set(reserved)
     seen: dict[str, int] = {}
+    ids: list[str] = []
+    for heading in headings:
+        base = heading_slug(heading)
+        count = seen.get(base, 1)
+        new_id = base
+        while new_id in taken:
+            count += 1
+            new_id = f"{base}-{count}"
+        seen[base] = count
+        taken.add(new_id)
+        ids.append(new_id)
+    return ids
+
+
+@lru_cache(maxsize=1)
+def template_heading_ids() -> frozenset[str]:
+    """Return the IDs of the built-in README template's fixed headings, e.g. `api-reference`.
+
+    Symbol anchors are allocated around these so `reference()` cannot take the ID of
+    the "API Reference" heading.
+    """
+    source = (Path(__file__).parent / "templates" / "readme.md.j2").read_text(encoding="utf-8")
+    return frozenset(heading_slug(text) for text in _FIXED_HEADING_RE.findall(source))
+
+
+def api_anchor(name: str) -> str:
+    """Return the undeduplicated anchor of an API entry: `GetUser` -> `api-getuser`."""
+    return heading_slug(f"api {name}")
+
+
+def symbol_anchors(analysis_data: dict[str, Any]) -> dict[tuple[str, str, int], str]:
+    """Return the anchor of every visible symbol, keyed by `(module, name, line)`.
+
+    The README, HTML site, search index and DocBook all take their symbol anchors
+    from here, so one symbol keeps one anchor in every format. Anchors are handed
+    out module by module in line order, each class followed by its methods as
+    `Class.method`; a name seen before, or one whose anchor is a fixed heading ID of
+    the README template, gets `-2`, `-3`, ... like a repeated heading.
+    """
+    root = path_root(analysis_data)
+    visibility = symbol_visibility(analysis_data)
+    groups: list[tuple[str, int, int, list[tuple[str, int]]]] = []
+    for key in ("functions", "classes"):
+        for item in analysis_data.get(key, []) or []:
+            if not isinstance(item, dict) or not item.get("name"):
+                continue
+            if not is_visible(item, visibility):
+                continue
+            name = str(item["name"])
+            line = int(item.get("line", 0) or 0)
+            members = [(name, line)]
+            for method in item.get("methods", []) or []:
+                if isinstance(method, dict) and method.get("name"):
+                    if is_visible(method, visibility):
+                        members.append(
+                            (f"{name}.{method['name']}", int(method.get("line", 0) or 0))
+                        )
+            module = relative_path(root, str(item.get("file", "")))
+            groups.append((module, line, len(groups), members))
+    keys = [
+        (module, name, line)
+        for module, _, _, members in sorted(groups, key=lambda group: group[:3])
+        for name, line in members
+    ]
+    ids = allocate_heading_ids((f"api {name}" for _, name, _ in keys), template_heading_ids())
+    anchors: dict[tuple[str, str, int], str] = {}
+    for key, anchor in zip(keys, ids):
+        anchors.setdefault(key, anchor)
+    return anchors
+
+
+def module_anchors(modules: Iterable[str]) -> dict[str, str]:
+    """Map each module to the ID of its `` ### `module` `` heading, in the order given.
+
+    Paths such as `a-b.py` and `a_b.py` slugify alike; the later one gets `-2`, as
+    it does when the README is rendered.
+    """
+    ordered = list(dict.fromkeys(modules))
+    return dict(zip(ordered, allocate_heading_ids(f"`{module}`" for module in ordered)))
+
+
+def element_ids(content: str) -> set[str]:
+    """Return every `id="..."` defined in rendered HTML."""
+    return set(_ID_ATTR_RE.findall(content))
+
+
+def toc_sidebar_html(content: str, *, depth: int, min_headings: int = 0) -> str:
+    """Render a collapsible table of contents for `##` through `depth` headings.
+
+    Expects headings already passed through `normalize_heading_ids`; returns ""
+    when fewer than `min_headings` headings qualify.
+    """
+    items = [
+        item
+        for item in heading_entries(content)
+        if 2 <= item[0] <= depth  # noqa: PLR2004
+    ]
+    if not items or len(items) < min_headings:
+        return ""
+    links = "".join(
+        f'<li class="toc-level-{level}"><a href="#{anchor}">{html.escape(text)}</a></li>'
+        for level, anchor, text in items
+    )
+    return (
+        '<details class="toc-panel" open><summary>Table of Contents</summary>'
+        f"<ul>{links}</ul></details>"
+    )
+
+
+def heading_entries(content: str) -> list[tuple[int, str, str]]:
+    """Return `(level, id, text)` for every `<hN id="...">` heading in document order."""
+    return [
+        (int(match.group("level")), match.group("id"), _plain_text(match.group("body")))
+        for match in _HEADING_RE.finditer(content)
+    ]
+
+
+def badges_html(badges: Iterable[dict[str, str]]) -> str:
+    """Render README badges as styled label/message spans instead of remote images."""
+    items: list[str] = []
+    for badge in badges:
+        color = re.sub(r"[^a-z]", "", str(badge.get("color", "")).lower())
+        inner = (
+            f'<span class="badge-label">{html.escape(str(badge.get("label", "")))}</span>'
+            f'<span class="badge-message badge-{color}">'
+            f'{html.escape(str(badge.get("message", "")))}</span>'
+        )
+        title = html.escape(str(badge.get("title", "")), quote=True)
+        link = str(badge.get("link", ""))
+        if link:
+            href = html.escape(link, quote=True)
+            items.append(f'<a class="badge" href="{href}" title="{title}">{inner}</a>')
+        else:
+            items.append(f'<span class="badge" title="{title}">{inner}</span>')
+    return f'<p class="badges">{"".join(items)}</p>' if items else ""
+
+
+def normalize_heading_ids(content: str, toc_html: str) -> tuple[str, str]:
+    """Give every heading a readable, unique ID and point in-page links at it.
+
+    IDs slugify the heading text. Repeats get `-2`, `-3`, ... suffixes in document
+    order, so the first occurrence keeps the bare slug, and a suffixed ID never
+    reuses one taken by another heading or by an explicit anchor such as
+    `<a id="api-getuser">`. Links to the old IDs in the TOC and the content are
+    rewritten in a single pass so remapped IDs are never renamed twice.
+    """
+    headings = list(_HEADING_RE.finditer(content))
+    heading_ids = {match.group("id") for match in headings}
+    reserved = {anchor for anchor in _ID_ATTR_RE.findall(content) if anchor not in heading_ids}
+    new_ids = allocate_heading_ids([match.group("body") for match in headings], reserved)
     id_map: dict[str, str] = {}
+    for match, new_id in zip(headings, new_ids, strict=True):
+        id_map.setdefault(match.group("id"), new_id)
 
-    def slugify(text: str) -> str:
-        plain = re.sub(r"<[^>]+>", "", text)
-        plain = plain.replace("¶", " ").strip().lower()
-        slug = re.sub(r"[^a-z0-9]+", "-", plain).strip("-")
-        return slug or "section"
+    pending = iter(new_ids)
 
     def replace_heading(match: re.Match[str]) -> str:
         level = match.group("level")
-        old_id = match.group("id")
-        body = match.group("body")
-        base = slugify(body)
-        count = seen.get(base, 0) + 1
-        seen[base] = count
-        new_id = base if count == 1 else f"{base}-{count}"
-        id_map[old_id] = new_id
-        body_with_link = re.sub(
-            r'href="#[^"]+"',
-            f'href="#{new_id}"',
-            body,
-            count=1,
-        )
-        return f'<h{level} id="{new_id}">{body_with_link}</h{level}>'
+        return f'<h{level} id="{next(pending)}">{match.group("body")}</h{level}>'
 
-    heading_re = re.compile(
-        r'<h(?P<level>[1-6])\s+id="(?P<id>[^"]+)">(?P<body>.*?)</h[1-6]>',
-        re.DOTALL,
-    )
-    normalized_content = heading_re.sub(replace_heading, content)
-    normalized_toc = toc_html
-    for old_id, new_id in id_map.items():
-        normalized_toc = normalized_toc.replace(f'href="#{old_id}"', f'href="#{new_id}"')
-    return normalized_content, normalized_toc
+    def replace_link(match: re.Match[str]) -> str:
+        return f'href="#{id_map.get(match.group("id"), match.group("id"))}"'
+
+    normalized_content = _LOCAL_HREF_RE.sub(replace_link, _HEADING_RE.sub(replace_heading, content))
+    return normalized_content, _LOCAL_HREF_RE.sub(replace_link, toc_html)
+
+
+def mark_complexity_cells(content: str) -> str:
+    """Class the cells of every table's `Complexity` column by `complexity_level`.
+
+    The Markdown module tables carry plain numbers; the classes let the HTML page
+    color them. Cells that are not a number (`-` for classes) are left alone.
+    """
+
+    def mark_table(table: re.Match[str]) -> str:
+        headers = [
+            re.sub(r"<[^>]+>", "", cell).strip() for cell in _TH_RE.findall(table.group(0))
+        ]
+        if "Complexity" not in headers:
+            return table.group(0)
+        column = headers.index("Complexity")
+
+        def mark_row(row: re.Match[str]) -> str:
+            cells = list(_TD_RE.finditer(row.group(0)))
+            if len(cells) <= column or not cells[column].group("body").strip().isdigit():
+                return row.group(0)
+            cell = cells[column]
+            value = cell.group("body").strip()
+            level = complexity_level(int(value))
+            marked = f'<td{cell.group("attrs")} class="complexity complexity-{level}">{value}</td>'
+            return row.group(0)[: cell.start()] + marked + row.group(0)[cell.end() :]
+
+        return _TR_RE.sub(mark_row, table.group(0))
+
+    return _TABLE_RE.sub(mark_table, content)
+
+
+def scope_heading_ids(content: str, prefix: str) -> str:
+    """Prefix heading IDs, and in-page links to them, with `prefix`.
+
+    Sections converted separately can repeat an ID; scoping keeps them apart until
+    `normalize_heading_ids` assigns the final, document-wide IDs.
+    """
+    ids = {match.group("id") for match in _HEADING_RE.finditer(content)}
+    if not ids:
+        return content
+
+    def replace_heading(match: re.Match[str]) -> str:
+        level = match.group("level")
+        return f'<h{level} id="{prefix}-{match.group("id")}">{match.group("body")}</h{level}>'
+
+    def replace_link(match: re.Match[str]) -> str:
+        target = match.group("id")
+        return f'href="#{prefix}-{target}"' if target in ids else match.group(0)
+
+    return _LOCAL_HREF_RE.sub(replace_link, _HEADING_RE.sub(replace_heading, content))
+
+
+def code_heading_anchors(content: str) -> dict[str, list[str]]:
+    """Map the text of every `<code>`-only heading to its IDs in document order.
+
+    API reference and module headings render as inline code, so this keeps
+    prose sections such as "Features" from matching a symbol of the same name.
+    """
+    anchors: dict[str, list[str]] = {}
+    for match in _HEADING_RE.finditer(content):
+        code = _CODE_HEADING_RE.match(match.group("body"))
+        if code is not None:
+            text = html.unescape(code.group("text")).strip()
+            anchors.setdefault(text, []).append(match.group("id"))
+    return anchors
+
+
+def iter_search_entries(
+    analysis_data: dict[str, Any],
+    anchors: dict[str, list[str]],
+    page_ids: Collection[str] = (),
+) -> Iterator[dict[str, str]]:
+    """Yield one search entry per function, class and method.
+
+    A symbol links to its `symbol_anchors` anchor when `page_ids` holds it, else
+    to its API reference heading when one was rendered and to its module heading
+    otherwise. Methods without an anchor of their own link to their owning class.
+    """
+    root = path_root(analysis_data)
+    symbols = symbol_anchors(analysis_data) if page_ids else {}
+    pending = {text: list(ids) for text, ids in anchors.items()}
+
+    def take(text: str, module: str) -> str:
+        ids = pending.get(text)
+        if ids:
+            return ids.pop(0)
+        return anchors.get(module, [""])[0]
+
+    def pick(key: tuple[str, str, int], fallback: str) -> str:
+        anchor = symbols.get(key)
+        return anchor if anchor in page_ids else fallback
+
+    for func in analysis_data.get("functions", []):
+        if not isinstance(func, dict) or not func.get("name"):
+            continue
+        name = str(func["name"])
+        module = relative_path(root, str(func.get("file", "")))
+        args = ", ".join(str(arg) for arg in func.get("args", []) or [])
+        line = int(func.get("line", 0) or 0)
+        yield {
+            "name": name,
+            "kind": str(func.get("kind") or "function"),
+            "module": module,
+            "anchor": pick((module, name, line), take(f"{name}({args})", module)),
+        }
+
+    for cls in analysis_data.get("classes", []):
+        if not isinstance(cls, dict) or not cls.get("name"):
+            continue
+        name = str(cls["name"])
+        module = relative_path(root, str(cls.get("file", "")))
+        anchor = pick((module, name, int(cls.get("line", 0) or 0)), take(name, module))
+        yield {
+            "name": name,
+            "kind": str(cls.get("kind") or "class"),
+            "module": module,
+            "anchor": anchor,
+        }
+        for method in cls.get("methods", []) or []:
+            if isinstance(method, dict) and method.get("name"):
+                qualified = f"{name}.{method['name']}"
+                line = int(method.get("line", 0) or 0)
+                yield {
+                    "name": qualified,
+                    "kind": str(method.get("kind") or "method"),
+                    "module": module,
+                    "anchor": pick((module, qualified, line), anchor),
+                }
+
+
+def write_search_index(entries: Iterable[dict[str, str]], path: Path) -> int:
+    """Stream entries to `path` as a JSON array and return how many were written.
+
+    Entries are serialized one at a time so large repositories never hold the
+    whole index in memory.
+    """
+    count = 0
+    with path.open("w", encoding="utf-8") as handle:
+        handle.write("[")
+        for entry in entries:
+            handle.write(",\n" if count else "\n")
+            handle.write(json.dumps(entry, sort_keys=True))
+            count += 1
+        handle.write("\n]\n" if count else "]\n")
+    return count
+
+
+def _plain_text(fragment: str) -> str:
+    plain = html.unescape(re.sub(r"<[^>]+>", "", fragment))
+    return plain.replace("¶", " ").strip()
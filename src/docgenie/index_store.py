@@ -8,15 +8,17 @@ import time
 from pathlib import Path
 from typing import Any
 
-SCHEMA_VERSION = 3
+SCHEMA_VERSION = 4
 
 
 class IndexStore:
-    def __init__(self, root: Path) -> None:
+    def __init__(self, root: Path, *, persistent: bool = True) -> None:
         self.root = root
         self.db_path = root / ".docgenie" / "index.db"
-        self.db_path.parent.mkdir(parents=True, exist_ok=True)
-        self._conn = sqlite3.connect(self.db_path)
+        # A non-persistent store lives in memory, so no `.docgenie` directory is created.
+        if persistent:
+            self.db_path.parent.mkdir(parents=True, exist_ok=True)
+        self._conn = sqlite3.connect(self.db_path if persistent else ":memory:")
         self._conn.row_factory = sqlite3.Row
         self._migrate()
 
@@ -83,6 +85,14 @@ class IndexStore:
                 section_hashes_json TEXT,
                 FOREIGN KEY(run_id) REFERENCES runs(id)
             );
+
+            CREATE TABLE IF NOT EXISTS blame_cache (
+                commit_sha TEXT NOT NULL,
+                path TEXT NOT NULL,
+                digest TEXT NOT NULL,
+                blame_json TEXT NOT NULL,
+                PRIMARY KEY(commit_sha, path)
+            );
             """
         )
         self._conn.execute(
@@ -205,9 +215,27 @@ class IndexStore:
             )
         return result
 
+    def get_blame(self, commit_sha: str, path: str, digest: str) -> dict[str, Any] | None:
+        """Return cached blame for `path` at `commit_sha` if the file content still matches."""
+        row = self._conn.execute(
+            "SELECT digest, blame_json FROM blame_cache WHERE commit_sha=? AND path=?",
+            (commit_sha, path),
+        ).fetchone()
+        if row is None or row["digest"] != digest:
+            return None
+        payload: dict[str, Any] = json.loads(row["blame_json"])
+        return payload
+
+    def put_blame(self, commit_sha: str, path: str, digest: str, blame: dict[str, Any]) -> None:
+        self._conn.execute(
+            "INSERT OR REPLACE INTO blame_cache(commit_sha,path,digest,blame_json) VALUES(?,?,?,?)",
+            (commit_sha, path, digest, json.dumps(blame, sort_keys=True)),
+        )
+
     def clear_all(self) -> None:
         self._conn.executescript(
             """
+            DELETE FROM blame_cache;
             DELETE FROM doc_artifacts;
             DELETE FROM output_links;
             DELETE FROM file_reviews;
@@ -220,8 +248,10 @@ class IndexStore:
         run_count = self._conn.execute("SELECT COUNT(*) FROM runs").fetchone()[0]
         artifact_count = self._conn.execute("SELECT COUNT(*) FROM doc_artifacts").fetchone()[0]
         review_count = self._conn.execute("SELECT COUNT(*) FROM file_reviews").fetchone()[0]
+        blame_count = self._conn.execute("SELECT COUNT(*) FROM blame_cache").fetchone()[0]
         return {
             "runs": int(run_count),
             "doc_artifacts": int(artifact_count),
             "file_reviews": int(review_count),
+            "blame_entries": int(blame_count),
         }
@@ -0,0 +1,54 @@
+"""Write analysis JSON incrementally instead of building the whole document string."""
+
+from __future__ import annotations
+
+import json
+from collections.abc import Iterable, Mapping
+from typing import Any, TextIO
+
+
+def write_json_stream(
+    document: Mapping[str, Any],
+    out: TextIO,
+    *,
+    indent: int = 2,
+    streams: Mapping[str, Iterable[list[Any]]] | None = None,
+) -> None:
+    """Write `document` exactly as `json.dumps(document, indent=indent)` would.
+
+    Values are encoded and written one top-level key at a time, and list values one
+    element at a time. The elements of a key in `streams` come from its batches
+    instead, each batch written as soon as it is produced, so a caller can hand over
+    one module's symbols at a time without ever holding the whole list.
+    """
+    streams = streams or {}
+    if not document:
+        out.write("{}")
+        return
+    pad = " " * indent
+    out.write("{")
+    for position, (key, value) in enumerate(document.items()):
+        out.write(("," if position else "") + f"\n{pad}{json.dumps(str(key))}: ")
+        if key in streams:
+            _write_array(streams[key], out, indent=indent)
+        elif isinstance(value, list) and value:
+            _write_array([value], out, indent=indent)
+        else:
+            out.write(_encode(value, indent, depth=1))
+    out.write("\n}")
+
+
+def _write_array(batches: Iterable[list[Any]], out: TextIO, *, indent: int) -> None:
+    pad = " " * (indent * 2)
+    written = 0
+    for batch in batches:
+        for item in batch:
+            out.write(("," if written else "[") + f"\n{pad}{_encode(item, indent, depth=2)}")
+            written += 1
+    out.write("\n" + " " * indent + "]" if written else "[]")
+
+
+def _encode(value: Any, indent: int, *, depth: int) -> str:
+    # json.dumps indents nested containers cumulatively, so re-indenting a
+    # standalone encoding by the nesting depth reproduces it exactly.
+    return json.dumps(value, indent=indent).replace("\n", "\n" + " " * (indent * depth))
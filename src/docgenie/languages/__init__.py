@@ -0,0 +1,69 @@
+"""Dedicated parsers for languages beyond Python."""
+
+from __future__ import annotations
+
+from ..parsers import ParserPlugin
+from .c_header import CHeaderParser
+from .csharp import CSharpParser
+from .dart import DartParser
+from .elixir import ElixirParser
+from .go import GoParser
+from .haskell import HaskellParser
+from .java import JavaParser
+from .kotlin import KotlinParser
+from .lua import LuaParser
+from .php import PhpParser
+from .protobuf import ProtobufParser
+from .r import RParser
+from .ruby import RubyParser
+from .rust import RustParser
+from .scala import ScalaParser
+from .shell import ShellParser
+from .swift import SwiftParser
+from .typescript import TypeScriptParser
+
+__all__ = [
+    "CHeaderParser",
+    "CSharpParser",
+    "DartParser",
+    "ElixirParser",
+    "GoParser",
+    "HaskellParser",
+    "JavaParser",
+    "KotlinParser",
+    "LuaParser",
+    "PhpParser",
+    "ProtobufParser",
+    "RParser",
+    "RubyParser",
+    "RustParser",
+    "ScalaParser",
+    "ShellParser",
+    "SwiftParser",
+    "TypeScriptParser",
+    "builtin_language_parsers",
+]
+
+
+def builtin_language_parsers() -> list[ParserPlugin]:
+    """Return fresh instances of every bundled language parser."""
+    return [
+        CHeaderParser(),
+        CSharpParser(),
+        DartParser(),
+        ElixirParser(),
+        GoParser(),
+        HaskellParser(),
+        JavaParser(),
+        KotlinParser(),
+        LuaParser(),
+        PhpParser(),
+        ProtobufParser(),
+        RParser(),
+        RubyParser(),
+        RustParser(),
+        ScalaParser(),
+        ShellParser(),
+        SwiftParser(),
+        TypeScriptParser(),
+    ]
@@ -0,0 +1,340 @@
+"""Shared line-scanning helpers for the dedicated language parsers."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Callable, Sequence
+from dataclasses import replace
+from typing import TypeVar
+
+from ..models import ClassDoc, FunctionDoc, ParseResult
+
+_Symbol = TypeVar("_Symbol", bound=FunctionDoc | ClassDoc)
+
+_CHAR_LITERAL_RE = re.compile(r"'(?:\\.|[^\\'\n])'")
+_OPENERS = {"(": ")", "[": "]", "{": "}"}
+
+
+def code_lines(
+    content: str,
+    *,
+    line_comments: Sequence[str] = ("//",),
+    block_comments: Sequence[tuple[str, str]] = (("/*", "*/"),),
+    quotes: str = "\"'",
+    multiline_quotes: str = "",
+    char_literals: bool = False,
+) -> list[str]:
+    """Return source lines with comments and string bodies blanked out.
+
+    The result has exactly one entry per input line so indexes line up with the
+    raw source, which keeps doc-comment lookup and brace matching in sync.
+    Quotes listed in `multiline_quotes` (e.g. JS template literals) stay open
+    across line breaks; all others are closed at the end of the line.
+    """
+    lines: list[str] = []
+    block_end: str | None = None
+    quote: str | None = None
+    for raw in content.splitlines():
+        line = _CHAR_LITERAL_RE.sub("' '", raw) if char_literals else raw
+        out: list[str] = []
+        idx = 0
+        if quote is not None and quote not in multiline_quotes:
+            quote = None
+        while idx < len(line):
+            if block_end is not None:
+                end = line.find(block_end, idx)
+                if end < 0:
+                    idx = len(line)
+                    continue
+                out.append(" " * (end + len(block_end) - idx))
+                idx = end + len(block_end)
+                block_end = None
+                continue
+            char = line[idx]
+            if quote is not None:
+                if char == "\\":
+                    out.append("  ")
+                    idx += 2
+                    continue
+                if char == quote:
+                    quote = None
+                    out.append(char)
+                else:
+                    out.append(" ")
+                idx += 1
+                continue
+            # Block openers win over line comments so Lua's `--[[` is not read as `--`.
+            opened = next((pair for pair in block_comments if line.startswith(pair[0], idx)), None)
+            if opened is not None:
+                block_end = opened[1]
+                out.append(" " * len(opened[0]))
+                idx += len(opened[0])
+                continue
+            if any(line.startswith(marker, idx) for marker in line_comments):
+                break
+            if char in quotes:
+                quote = char
+            out.append(char)
+            idx += 1
+        lines.append("".join(out))
+    return lines
+
+
+def brace_depths(code: Sequence[str]) -> list[int]:
+    """Return the `{}` nesting depth at the start of every line."""
+    depths: list[int] = []
+    depth = 0
+    for line in code:
+        depths.append(depth)
+        depth += line.count("{") - line.count("}")
+        depth = max(depth, 0)
+    return depths
+
+
+def item_end(code: Sequence[str], start: int) -> tuple[int, bool]:
+    """Find where the declaration starting at `start` ends.
+
+    Returns the 0-based index of the closing line and whether the declaration
+    owns a `{}` body. A `;` outside of any brackets before the first `{` ends a
+    body-less declaration such as a prototype or a unit struct.
+    """
+    stack: list[str] = []
+    opened_body = False
+    for idx in range(start, len(code)):
+        for char in code[idx]:
+            if char in _OPENERS:
+                if char == "{" and not stack:
+                    opened_body = True
+                stack.append(_OPENERS[char])
+            elif stack and char == stack[-1]:
+                stack.pop()
+                if opened_body and not stack:
+                    return idx, True
+            elif char == ";" and not stack and not opened_body:
+                return idx, False
+    return len(code) - 1, opened_body
+
+
+_CONTINUATION_TAIL = ("=", "=>", ",", "(", "[", "{", "|", "&", "?", ":", "+", "-", "*", ".", "<")
+_OPTIONAL_TYPE_TAIL = tuple(token for token in _CONTINUATION_TAIL if token != "?")
+_CONTINUATION_HEAD = (".", "|", "&", "?", ":", "=>", "+", "-", "*")
+
+
+def statement_end(code: Sequence[str], start: int, *, question_continues: bool = True) -> int:
+    """Find the last line of a statement in a language with optional semicolons.
+
+    The statement ends on the first line where every bracket is closed and
+    neither that line nor the next one signals a continuation. Pass
+    `question_continues=False` where a trailing `?` marks an optional type
+    (Kotlin `User?`, Swift `String?`) rather than an unfinished ternary.
+    """
+    tail = _CONTINUATION_TAIL if question_continues else _OPTIONAL_TYPE_TAIL
+    stack: list[str] = []
+    for idx in range(start, len(code)):
+        for char in code[idx]:
+            if char in _OPENERS:
+                stack.append(_OPENERS[char])
+            elif stack and char == stack[-1]:
+                stack.pop()
+        if stack:
+            continue
+        stripped = code[idx].rstrip()
+        if stripped.endswith(";"):
+            return idx
+        if stripped.endswith(tail):
+            continue
+        following = next((line.strip() for line in code[idx + 1 :] if line.strip()), "")
+        if following.startswith(_CONTINUATION_HEAD):
+            continue
+        return idx
+    return len(code) - 1
+
+
+def header_text(code: Sequence[str], start: int, end: int) -> str:
+    """Collapse a declaration header (up to its body or `;`) onto one line."""
+    parts: list[str] = []
+    depth = 0
+    for idx in range(start, end + 1):
+        segment: list[str] = []
+        for char in code[idx]:
+            if char in "([":
+                depth += 1
+            elif char in ")]":
+                depth -= 1
+            elif depth <= 0 and char in "{;":
+                parts.append("".join(segment))
+                return _collapse(" ".join(parts))
+            segment.append(char)
+        parts.append("".join(segment))
+    return _collapse(" ".join(parts))
+
+
+def split_top_level(text: str, sep: str = ",") -> list[str]:
+    """Split on `sep` while ignoring separators nested in brackets or generics."""
+    parts: list[str] = []
+    current: list[str] = []
+    depth = 0
+    for char in text:
+        if char in "([{<":
+            depth += 1
+        elif char in ")]}>":
+            depth -= 1
+        if char == sep and depth <= 0:
+            parts.append("".join(current).strip())
+            current = []
+            continue
+        current.append(char)
+    tail = "".join(current).strip()
+    if tail:
+        parts.append(tail)
+    return [part for part in parts if part]
+
+
+def heritage(header: str, keywords: Sequence[str] = ("extends", "implements")) -> list[str]:
+    """Return the types named after `extends`/`implements` style clauses.
+
+    Type parameters on the declared name are ignored, so `<T extends Base>` does
+    not count as a supertype; each base keeps its own generic arguments.
+    """
+    name_end = re.search(r"\b(?:class|interface|record|trait|struct)\s+[\w$]+\s*", header)
+    head = header
+    if name_end and header[name_end.end() :].startswith("<"):
+        rest = header[name_end.end() :]
+        depth = 0
+        for idx, char in enumerate(rest):
+            depth += {"<": 1, ">": -1}.get(char, 0)
+            if depth == 0:
+                head = header[: name_end.end()] + rest[idx + 1 :]
+                break
+    bases: list[str] = []
+    alternatives = "|".join(keywords)
+    for keyword in keywords:
+        match = re.search(rf"\b{keyword}\s+(.+?)(?=\s+\b(?:{alternatives})\b|$)", head)
+        if match:
+            bases.extend(split_top_level(match.group(1)))
+    return bases
+
+
+def paren_contents(text: str) -> str:
+    """Return the text inside the first balanced `(...)` group."""
+    start = text.find("(")
+    if start < 0:
+        return ""
+    depth = 0
+    for idx in range(start, len(text)):
+        if text[idx] == "(":
+            depth += 1
+        elif text[idx] == ")":
+            depth -= 1
+            if depth == 0:
+                return text[start + 1 : idx]
+    return text[start + 1 :]
+
+
+def leading_comment(
+    raw: Sequence[str],
+    index: int,
+    *,
+    prefixes: Sequence[str] = ("///",),
+    block: tuple[str, str] | None = ("/**", "*/"),
+    skip: Callable[[str], bool] | None = None,
+) -> tuple[str | None, list[str]]:
+    """Collect the doc comment directly above `raw[index]`.
+
+    Lines accepted by `skip` (attributes, annotations, decorators) may sit
+    between the comment and the declaration; they are returned separately in
+    source order so callers can record them as decorators.
+    """
+    text, skipped, _ = _comment_above(raw, index, prefixes, block, skip)
+    return text, skipped
+
+
+def with_doc_ranges(
+    result: ParseResult,
+    raw: Sequence[str],
+    *,
+    prefixes: Sequence[str] = ("///",),
+    block: tuple[str, str] | None = ("/**", "*/"),
+    skip: Callable[[str], bool] | None = None,
+) -> ParseResult:
+    """Fill in `doc_line`/`doc_end_line` for every documented symbol in `result`.
+
+    Pass the same comment style the parser gave `leading_comment`; the range is
+    looked up above each symbol's declaration line, past any skipped lines.
+    """
+
+    def located(symbol: _Symbol) -> _Symbol:
+        if not symbol.docstring:
+            return symbol
+        span = _comment_above(raw, symbol.line - 1, prefixes, block, skip)[2]
+        if span is None:
+            return symbol
+        return replace(symbol, doc_line=span[0], doc_end_line=span[1])
+
+    return ParseResult(
+        functions=[located(func) for func in result.functions],
+        classes=[
+            replace(located(cls), methods=[located(method) for method in cls.methods])
+            for cls in result.classes
+        ],
+        imports=result.imports,
+        skipped=result.skipped,
+        module_doc=result.module_doc,
+        constants=result.constants,
+    )
+
+
+def _comment_above(
+    raw: Sequence[str],
+    index: int,
+    prefixes: Sequence[str],
+    block: tuple[str, str] | None,
+    skip: Callable[[str], bool] | None,
+) -> tuple[str | None, list[str], tuple[int, int] | None]:
+    """Return the doc text, the skipped lines and the comment's 1-based line range."""
+    skipped: list[str] = []
+    idx = index - 1
+    while idx >= 0 and skip is not None and raw[idx].strip() and skip(raw[idx].strip()):
+        skipped.insert(0, raw[idx].strip())
+        idx -= 1
+
+    collected: list[str] = []
+    last = idx
+    if idx >= 0 and block is not None and raw[idx].strip().endswith(block[1]):
+        end = idx
+        while idx >= 0 and "/*" not in raw[idx]:
+            idx -= 1
+        # Only doc-style openers count; a plain `/* ... */` block is not documentation.
+        if idx >= 0 and block[0] in raw[idx]:
+            collected = [_strip_block_line(line, block) for line in raw[idx : end + 1]]
+        first = idx
+    else:
+        while idx >= 0:
+            stripped = raw[idx].strip()
+            prefix = next((p for p in prefixes if stripped.startswith(p)), None)
+            if prefix is None:
+                break
+            collected.insert(0, stripped[len(prefix) :].removeprefix(" ").rstrip())
+            idx -= 1
+        first = idx + 1
+
+    text = "\n".join(collected).strip()
+    if not text:
+        return None, skipped, None
+    return text, skipped, (first + 1, last + 1)
+
+
+def _strip_block_line(line: str, block: tuple[str, str]) -> str:
+    stripped = line.strip()
+    if stripped.startswith(block[0]):
+        stripped = stripped[len(block[0]) :]
+    if stripped.endswith(block[1]):
+        stripped = stripped[: -len(block[1])]
+    stripped = stripped.strip()
+    if stripped.startswith("*"):
+        stripped = stripped[1:].removeprefix(" ")
+    return stripped.rstrip()
+
+
+def _collapse(text: str) -> str:
+    return re.sub(r"\s+", " ", text).strip()
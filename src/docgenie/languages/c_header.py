@@ -0,0 +1,433 @@
+"""C/C++ header parser for prototypes, structs, classes, typedefs and macros."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    code_lines,
+    header_text,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    with_doc_ranges,
+)
+
+HEADER_SUFFIXES = frozenset({".h", ".hh", ".hpp", ".hxx"})
+
+_DIRECTIVE_RE = re.compile(r"^#\s*(?P<name>\w+)\s*(?P<rest>.*)$")
+_DEFINE_RE = re.compile(r"^(?P<name>[A-Za-z_]\w*)(?P<params>\([^)]*\))?\s*(?P<value>.*)$")
+_INCLUDE_RE = re.compile(r'^[<"](?P<path>[^>"]+)[>"]')
+_TRANSPARENT_BLOCK_RE = re.compile(r'^(?:extern\s+"\s*"|namespace(?:\s+[\w:]+)?)\s*\{')
+_ATTRIBUTE_RE = re.compile(r"__attribute__\s*\(\(.*?\)\)|__declspec\s*\([^)]*\)|\[\[.*?\]\]")
+_RECORD_RE = re.compile(r"^(?:typedef\s+)?(?P<kind>struct|union|enum|class)\b\s*(?P<tag>\w+)?")
+_FUNC_NAME_RE = re.compile(r"(?P<name>~?[A-Za-z_][\w:]*)\s*\($")
+_FUNC_POINTER_RE = re.compile(r"\(\s*\*\s*(?P<name>\w+)\s*\)\s*\(")
+_FIELD_RE = re.compile(r"^(?P<type>.+?)\s*\b(?P<name>\w+)\s*(?:\[[^\]]*\])?\s*(?::\s*\d+)?;$")
+_ACCESS_RE = re.compile(r"^(?P<access>public|protected|private)\s*:(?!:)\s*")
+# Member declarations that are not member functions, even with a `(` in them.
+_NON_METHOD_PREFIXES = ("typedef ", "using ", "friend ", "template", "static_assert")
+_INITIALIZER_RE = re.compile(r"\)\s*:(?!:).*$")
+_GUARD_RE = re.compile(r"^#\s*(?:ifndef\s+|if\s+!\s*defined\s*\(?\s*)(?P<name>\w+)")
+_TYPE_WORDS = frozenset(
+    {"void", "char", "short", "int", "long", "float", "double", "signed", "unsigned", "bool"}
+    | {"const", "volatile", "struct", "union", "enum", "size_t"}
+)
+_CONDITIONAL_OPENERS = ("if", "ifdef", "ifndef")
+_CONDITIONAL_BRANCHES = ("else", "elif", "elifdef", "elifndef")
+
+
+class CHeaderParser(ParserPlugin):
+    """Extract the public API declared in C and C++ header files.
+
+    Source files (`.c`, `.cpp`) are left to the tree-sitter and regex parsers.
+    """
+
+    def __init__(self) -> None:
+        super().__init__(name="c-header", languages={"c", "cpp"}, priority=10)
+
+    def accepts(self, path: Path) -> bool:
+        return path.suffix.lower() in HEADER_SUFFIXES
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _HeaderWalker(content, path, include_private=self.include_private)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions, classes=walker.classes, imports=walker.imports
+        )
+        return with_doc_ranges(
+            result, walker.raw, prefixes=("///",), block=("/**", "*/"), skip=_doc_skip
+        )
+
+
+class _HeaderWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = code_lines(content, char_literals=True)
+        self.depths = _api_depths(self.code)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+        # Alternative `#if`/`#else` branches often redeclare the same symbol; keep the first.
+        self.seen: set[tuple[str, str]] = set()
+        self.inactive = _inactive_lines(self.code)
+
+    def walk(self) -> None:
+        idx = 0
+        while idx < len(self.code):
+            line = self.code[idx].strip()
+            if idx in self.inactive or not line:
+                idx += 1
+            elif line.startswith("#"):
+                idx = self._directive(idx)
+            elif self.depths[idx] != 0 or _TRANSPARENT_BLOCK_RE.match(line) or line == "}":
+                idx += 1
+            else:
+                idx = self._declaration(idx)
+
+    def _directive(self, idx: int) -> int:
+        end = idx
+        while end < len(self.raw) - 1 and self.raw[end].rstrip().endswith("\\"):
+            end += 1
+        # Comments are blanked in `code`, but include paths are string bodies there.
+        raw_match = _DIRECTIVE_RE.match(self.raw[idx].strip())
+        match = _DIRECTIVE_RE.match(self.code[idx].strip())
+        if match is None or raw_match is None:
+            return end + 1
+        rest = match.group("rest").strip()
+        if match.group("name") == "include":
+            include = _INCLUDE_RE.match(raw_match.group("rest").strip())
+            if include:
+                self.imports.add(include.group("path"))
+        elif match.group("name") == "define" and not self._include_guard(idx, rest):
+            self._define(idx, end, rest)
+        return end + 1
+
+    def _define(self, idx: int, end: int, rest: str) -> None:
+        match = _DEFINE_RE.match(rest)
+        if match is None or not _public(match.group("name")):
+            return
+        name = match.group("name")
+        params = match.group("params")
+        if not self._first("macro", name):
+            return
+        args = [arg.strip() for arg in params[1:-1].split(",") if arg.strip()] if params else []
+        self.functions.append(
+            FunctionDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=_doc(self.raw, idx),
+                args=args,
+                kind="macro",
+                signature=f"#define {name}{params or ''}",
+            )
+        )
+
+    def _include_guard(self, idx: int, rest: str) -> bool:
+        """Return True for the valueless `#define X` right after `#ifndef X`."""
+        words = rest.split()
+        if len(words) != 1:
+            return False
+        previous = next(
+            (self.code[i].strip() for i in range(idx - 1, -1, -1) if self.code[i].strip()), ""
+        )
+        guard = _GUARD_RE.match(previous)
+        return guard is not None and guard.group("name") == words[0]
+
+    def _declaration(self, idx: int) -> int:
+        end, has_body = item_end(self.code, idx)
+        full_header = header_text(self.code, idx, end)
+        header = re.sub(r"\s+", " ", _ATTRIBUTE_RE.sub(" ", full_header)).strip()
+        attributes = " ".join(_ATTRIBUTE_RE.findall(full_header))
+        deprecated = "deprecated" in attributes or any(
+            word.endswith("DEPRECATED") for word in re.findall(r"\w+", header)
+        )
+        decorators = ["deprecated"] if deprecated else []
+        record = _RECORD_RE.match(header)
+        if header.startswith("template") or header.startswith("using "):
+            return end + 1
+        # Attribute-only lines above the declaration are not part of its range.
+        start = next((line for line in range(idx, end + 1) if not _doc_skip(self.code[line])), idx)
+        if record is not None and (has_body or header.startswith("typedef")):
+            self._record(start, end, header, record, has_body=has_body, decorators=decorators)
+        elif header.startswith("typedef "):
+            self._typedef(start, end, header, decorators)
+        elif "(" in header:
+            self._prototype(start, end, header, decorators)
+        return end + 1
+
+    def _record(
+        self,
+        idx: int,
+        end: int,
+        header: str,
+        record: re.Match[str],
+        *,
+        has_body: bool,
+        decorators: list[str],
+    ) -> None:
+        tail = self.code[end].rsplit("}", 1)[-1] if has_body else ""
+        alias = re.search(r"(\w+)\s*(?:\[[^\]]*\])?\s*;", tail)
+        if header.startswith("typedef"):
+            if has_body:
+                name = alias.group(1) if alias else record.group("tag")
+            else:
+                name = _last_identifier(header)
+        else:
+            name = record.group("tag")
+        if not name or not _public(name) or not self._first("type", name):
+            return
+        kind = record.group("kind")
+        signature = f"typedef {kind} {name}" if header.startswith("typedef") else header
+        fields = self._fields(idx, end) if has_body and kind in ("struct", "union") else []
+        methods = self._methods(idx, end, kind, name) if has_body and kind != "enum" else []
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=_doc(self.raw, idx),
+                decorators=decorators,
+                kind=kind if has_body else "typedef",
+                signature=signature if has_body else header,
+                fields=fields,
+                methods=methods,
+            )
+        )
+
+    def _typedef(self, idx: int, end: int, header: str, decorators: list[str]) -> None:
+        pointer = _FUNC_POINTER_RE.search(header)
+        name = pointer.group("name") if pointer else _last_identifier(header)
+        if not name or not _public(name) or not self._first("type", name):
+            return
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=_doc(self.raw, idx),
+                decorators=decorators,
+                kind="typedef",
+                signature=header,
+            )
+        )
+
+    def _prototype(self, idx: int, end: int, header: str, decorators: list[str]) -> None:
+        declared = _declared_function(header)
+        if declared is None:
+            return
+        prefix, match = declared
+        name = match.group("name")
+        qualifiers = prefix[: match.start()].split()
+        # `static` functions in a header are private to each translation unit.
+        if "static" in qualifiers or not qualifiers:
+            return
+        if not _public(name.rsplit("::", 1)[-1]) or not self._first("function", name):
+            return
+        self.functions.append(
+            FunctionDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=_doc(self.raw, idx),
+                args=_param_names(paren_contents(header[len(prefix) :])),
+                decorators=decorators,
+                signature=header,
+            )
+        )
+
+    def _methods(self, start: int, end: int, kind: str, owner: str) -> list[MethodDoc]:
+        """Return the member functions declared in a `class`, `struct` or `union` body.
+
+        Members are public until the first access label in a `struct` or `union` and
+        private in a `class`. Private and protected ones are only kept, marked
+        `private`, when private symbols are included.
+        """
+        depth = self.depths[start] + 1
+        access = "private" if kind == "class" else "public"
+        methods: list[MethodDoc] = []
+        idx = start + 1
+        while idx < end:
+            line = self.code[idx].strip()
+            label = _ACCESS_RE.match(line)
+            if label is not None:
+                access = label.group("access")
+                line = line[label.end() :]
+            if idx in self.inactive or self.depths[idx] != depth or line[:1] in ("", "#"):
+                idx += 1
+                continue
+            first = idx
+            member_end, _ = item_end(self.code, first)
+            full_header = _ACCESS_RE.sub("", header_text(self.code, first, member_end))
+            header = re.sub(r"\s+", " ", _ATTRIBUTE_RE.sub(" ", full_header)).strip()
+            declared = _declared_function(header)
+            idx = max(member_end, first) + 1
+            if declared is None or header.startswith(_NON_METHOD_PREFIXES):
+                continue
+            prefix, match = declared
+            name = match.group("name")
+            private = access != "public" or not _public(name.lstrip("~"))
+            if private and not self.include_private:
+                continue
+            methods.append(
+                MethodDoc(
+                    name=name,
+                    file=self.path,
+                    line=first + 1,
+                    end_line=member_end + 1,
+                    docstring=_doc(self.raw, first),
+                    args=_param_names(paren_contents(header[len(prefix) :])),
+                    kind="constructor" if name == owner else "method",
+                    signature=_INITIALIZER_RE.sub(")", header),
+                    private=private,
+                )
+            )
+        return methods
+
+    def _fields(self, start: int, end: int) -> list[FieldDoc]:
+        depth = self.depths[start] + 1
+        fields: list[FieldDoc] = []
+        for idx in range(start + 1, end + 1):
+            code = self.code[idx].strip()
+            if self.depths[idx] != depth or not code.endswith(";") or code.startswith("}"):
+                continue
+            match = _FIELD_RE.match(code)
+            if match is None or "(" in code:
+                continue
+            fields.append(
+                FieldDoc(
+                    name=match.group("name"),
+                    type=match.group("type").strip(),
+                    docstring=_trailing_doc(self.raw[idx]) or _doc(self.raw, idx),
+                )
+            )
+        return fields
+
+    def _first(self, kind: str, name: str) -> bool:
+        key = (kind, name)
+        if key in self.seen:
+            return False
+        self.seen.add(key)
+        return True
+
+
+def _api_depths(code: Sequence[str]) -> list[int]:
+    """Brace depth per line, not counting `extern "C" {` and `namespace {` blocks."""
+    depths: list[int] = []
+    stack: list[bool] = []
+    for line in code:
+        depths.append(sum(1 for transparent in stack if not transparent))
+        transparent = _TRANSPARENT_BLOCK_RE.match(line.strip()) is not None
+        for char in line:
+            if char == "{":
+                stack.append(transparent)
+                transparent = False
+            elif char == "}" and stack:
+                stack.pop()
+    return depths
+
+
+def _inactive_lines(code: Sequence[str]) -> set[int]:
+    """Return lines inside `#if 0` blocks, which are never compiled."""
+    inactive: set[int] = set()
+    stack: list[bool] = []
+    for idx, line in enumerate(code):
+        match = _DIRECTIVE_RE.match(line.strip())
+        name = match.group("name") if match else ""
+        if name in _CONDITIONAL_OPENERS:
+            stack.append(name == "if" and match is not None and match.group("rest").strip() == "0")
+        elif name in _CONDITIONAL_BRANCHES and stack:
+            stack[-1] = False
+        elif name == "endif" and stack:
+            stack.pop()
+        elif any(stack):
+            inactive.add(idx)
+    return inactive
+
+
+def _doc(raw: Sequence[str], idx: int) -> str | None:
+    return leading_comment(raw, idx, prefixes=("///",), block=("/**", "*/"), skip=_doc_skip)[0]
+
+
+def _doc_skip(line: str) -> bool:
+    # A comment above `#ifdef X` documents the declaration inside it; `#else`/`#endif`
+    # start a different branch, so they break the pairing. Lone attribute lines sit
+    # between a comment and its declaration like annotations do in other languages.
+    return _opener(line) or not _ATTRIBUTE_RE.sub("", line).strip()
+
+
+def _opener(line: str) -> bool:
+    match = _DIRECTIVE_RE.match(line)
+    return match is not None and match.group("name") in _CONDITIONAL_OPENERS
+
+
+def _trailing_doc(raw: str) -> str | None:
+    for marker in ("///<", "/**<"):
+        if marker in raw:
+            return raw.split(marker, 1)[1].removesuffix("*/").strip() or None
+    return None
+
+
+def _declared_function(header: str) -> tuple[str, re.Match[str]] | None:
+    """Return the text before the parameter list and the name match of a function.
+
+    Returns None for anything else: `int (*handler)(int);` declares a function pointer
+    variable, an `=` before the parameters an initialized variable, and
+    `operator bool()` is a conversion operator.
+    """
+    prefix = header[: _top_level_paren(header)]
+    match = _FUNC_NAME_RE.search(prefix + "(") if prefix != header else None
+    if match is None or "=" in prefix or header[len(prefix) + 1 :].lstrip().startswith("*"):
+        return None
+    if prefix[: match.start()].split()[-1:] == ["operator"]:
+        return None
+    return prefix, match
+
+
+def _top_level_paren(header: str) -> int:
+    depth = 0
+    for idx, char in enumerate(header):
+        if char == "(" and depth == 0:
+            return idx
+        depth += {"<": 1, ">": -1}.get(char, 0)
+    return len(header)
+
+
+def _last_identifier(header: str) -> str:
+    names = re.findall(r"[A-Za-z_]\w*", re.sub(r"\[[^\]]*\]", "", header))
+    return names[-1] if names else ""
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for part in split_top_level(params):
+        if part == "void":
+            continue
+        if part == "...":
+            names.append("...")
+            continue
+        declarator = part.split("=", 1)[0]
+        pointer = _FUNC_POINTER_RE.search(declarator)
+        identifiers = re.findall(r"[A-Za-z_]\w*", re.sub(r"\[[^\]]*\]", "", declarator))
+        # A lone type (`int`) has no parameter name.
+        if pointer:
+            names.append(pointer.group("name"))
+        elif len(identifiers) > 1 and identifiers[-1] not in _TYPE_WORDS:
+            names.append(identifiers[-1])
+    return names
+
+
+def _public(name: str) -> bool:
+    return bool(name) and not name.startswith("_")
@@ -0,0 +1,399 @@
+"""C# parser for namespaces, types, methods, properties and XML doc comments."""
+
+from __future__ import annotations
+
+import html
+import re
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    with_doc_ranges,
+)
+
+_MODIFIERS = frozenset(
+    "public protected internal private static readonly virtual override abstract sealed async "
+    "extern unsafe new partial const volatile required file ref implicit explicit event fixed "
+    "scoped".split()
+)
+_VISIBLE = frozenset({"public", "protected", "internal"})
+_TYPE_RE = re.compile(
+    r"^(?:(?:public|protected|internal|private|static|abstract|sealed|partial|readonly|unsafe|"
+    r"new|file|ref)\s+)*"
+    r"(?P<kind>record\s+struct|record\s+class|class|interface|struct|enum|record)\s+"
+    r"(?P<name>@?\w+)"
+)
+_NAMESPACE_RE = re.compile(r"^namespace\s+(?P<name>[\w.]+)\s*(?P<scoped>;)?")
+_USING_RE = re.compile(
+    r"^\s*(?:global\s+)?using\s+(?:static\s+)?(?:\w+\s*=\s*)?(?P<name>[\w.]+)\s*;", re.MULTILINE
+)
+_LEADING_ATTRIBUTES_RE = re.compile(r"^(?:\[[^\]]*\]\s*)+")
+_METHOD_NAME_RE = re.compile(r"(?P<name>~?@?\w+)\s*(?:<[\w\s,]*>)?\s*$")
+_PROPERTY_RE = re.compile(r"^(?P<type>\S.*?)\s+(?P<name>@?\w+)$")
+_WHERE_RE = re.compile(r"\bwhere\s+(?P<param>\w+)\s*:\s*(?P<bounds>.+?)(?=\s+where\b|$)")
+_NON_METHOD_WORDS = {"new", "return", "throw", "else", "case", "await", "yield", "operator"}
+# Constraint keywords name no type, so they never become impact-graph edges.
+_CONSTRAINT_KEYWORDS = {"class", "class?", "struct", "new()", "notnull", "unmanaged", "default"}
+_PARAM_MODIFIERS = {"this", "ref", "out", "in", "params", "scoped", "readonly"}
+
+_XML_DOC_RE = re.compile(
+    r"<(?:summary|remarks|returns|value|param|typeparam|exception|inheritdoc|para|see|c)\b"
+)
+_XML_SECTION_RE = re.compile(
+    r"<(?P<tag>summary|remarks|returns|value)>(?P<body>.*?)</(?P=tag)>", re.S
+)
+_XML_NAMED_RE = re.compile(
+    r'<(?P<tag>param|typeparam|exception)\s+(?:name|cref)="(?P<name>[^"]*)"\s*>(?P<body>.*?)'
+    r"</(?P=tag)>",
+    re.S,
+)
+_XML_REF_RE = re.compile(r'<(?:see|seealso)\s+(?:cref|href)="(?:\w:)?(?P<ref>[^"]*)"\s*/>')
+_XML_LANGWORD_RE = re.compile(r'<see\s+langword="(?P<word>[^"]*)"\s*/>')
+_XML_PARAMREF_RE = re.compile(r'<(?:paramref|typeparamref)\s+name="(?P<name>[^"]*)"\s*/>')
+_XML_CODE_RE = re.compile(r"<c>(?P<code>.*?)</c>", re.S)
+_XML_TAG_RE = re.compile(r"</?[\w]+(?:\s+[^>]*)?/?>")
+
+
+class CSharpParser(ParserPlugin):
+    """Extract classes, interfaces, structs, enums and records from C# sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="csharp", languages={"csharp"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _CSharpWalker(content, path)
+        walker.walk(0, len(walker.code), depth=0, namespace="", owner=None)
+        result = ParseResult(
+            classes=walker.classes,
+            imports={match.group("name") for match in _USING_RE.finditer(content)},
+        )
+        return with_doc_ranges(
+            result, walker.raw, prefixes=("///",), block=("/**", "*/"), skip=_is_attribute
+        )
+
+
+class _CSharpWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        self.code = code_lines(content, char_literals=True)
+        self.depths = brace_depths(self.code)
+        self.classes: list[ClassDoc] = []
+
+    def walk(self, start: int, stop: int, *, depth: int, namespace: str, owner: str | None) -> None:
+        """Record every type declared at `depth`; namespaces qualify the names below them."""
+        idx = start
+        while idx < stop:
+            if self.depths[idx] != depth:
+                idx += 1
+                continue
+            line, attributes = self._strip_attributes(idx)
+            scope = _NAMESPACE_RE.match(line) if owner is None else None
+            if scope is not None:
+                name = _join(namespace, scope.group("name"))
+                if scope.group("scoped"):
+                    # A file-scoped `namespace X;` covers the rest of the file.
+                    namespace = name
+                    idx += 1
+                    continue
+                end, _ = item_end(self.code, idx)
+                self.walk(idx + 1, end, depth=depth + 1, namespace=name, owner=None)
+                idx = end + 1
+                continue
+            match = _TYPE_RE.match(line)
+            if match is None:
+                idx += 1
+                continue
+            end = self._record_type(idx, match, attributes, _join(namespace, owner or ""))
+            idx = end + 1
+
+    def _record_type(
+        self, idx: int, match: re.Match[str], attributes: list[str], scope: str
+    ) -> int:
+        end, has_body = item_end(self.code, idx)
+        header = _LEADING_ATTRIBUTES_RE.sub("", header_text(self.code, idx, end))
+        keyword = " ".join(match.group("kind").split())
+        kind = keyword.split()[0]
+        short = match.group("name").lstrip("@")
+        doc, decorators = _xml_doc(self.raw, idx)
+        text, params = _doc_sections(doc)
+        declared = re.search(rf"\b{keyword}\s+{re.escape(match.group('name'))}", header)
+        type_params, rest = _type_params(header[declared.end() :] if declared else "", header)
+        fields: list[FieldDoc] = []
+        if rest.lstrip().startswith("("):
+            # Record positional parameters and C# 12 primary constructors.
+            primary = paren_contents(rest)
+            if kind == "record":
+                fields = _record_fields(primary, params)
+                params = {}
+            rest = rest[rest.find("(") + len(primary) + 2 :]
+        text = _with_params(text, params)
+        methods: list[MethodDoc] = []
+        if has_body:
+            methods = self._members(idx, end, self.depths[idx] + 1, short, kind, fields)
+        self.classes.append(
+            ClassDoc(
+                name=_join(scope, short),
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=text,
+                bases=_bases(rest) if kind != "enum" else [],
+                decorators=_split_attributes(decorators) + attributes,
+                methods=methods,
+                kind=kind,
+                signature=header,
+                fields=fields,
+                type_params=type_params,
+            )
+        )
+        if has_body:
+            self.walk(idx + 1, end, depth=self.depths[idx] + 1, namespace=scope, owner=short)
+        return end
+
+    def _members(
+        self,
+        start: int,
+        end: int,
+        depth: int,
+        type_name: str,
+        kind: str,
+        fields: list[FieldDoc],
+    ) -> list[MethodDoc]:
+        """Collect visible methods, adding properties to `fields` on the way."""
+        methods: list[MethodDoc] = []
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, attributes = self._strip_attributes(idx)
+            if not line:
+                # A lone `[...]` line belongs to the member below; `_xml_doc` picks it up there.
+                idx += 1
+                continue
+            member_end, has_body = item_end(self.code, idx)
+            if _TYPE_RE.match(line):
+                # Nested types are walked separately; skip over their bodies here.
+                idx = member_end + 1
+                continue
+            header = _LEADING_ATTRIBUTES_RE.sub("", header_text(self.code, idx, member_end))
+            modifiers, rest = _modifiers(header)
+            visible = kind == "interface" or bool(_VISIBLE & set(modifiers))
+            before_body = rest.split("=>", 1)[0]
+            if visible and "(" in before_body:
+                method = self._method(idx, member_end, header, rest, type_name, attributes)
+                if method is not None:
+                    methods.append(method)
+            elif visible and "event" not in modifiers and (has_body or "=>" in rest):
+                prop = _PROPERTY_RE.match(before_body.strip())
+                if prop is not None and "=" not in before_body:
+                    text, _ = _doc_sections(_xml_doc(self.raw, idx)[0])
+                    name = prop.group("name").lstrip("@")
+                    fields.append(FieldDoc(name=name, type=prop.group("type"), docstring=text))
+            idx = max(member_end, idx) + 1
+        return methods
+
+    def _method(
+        self,
+        idx: int,
+        end: int,
+        header: str,
+        rest: str,
+        type_name: str,
+        attributes: list[str],
+    ) -> MethodDoc | None:
+        before_params = rest.split("(", 1)[0].strip()
+        match = _METHOD_NAME_RE.search(before_params)
+        if match is None or "=" in before_params or match.group("name").startswith("~"):
+            return None
+        name = match.group("name").lstrip("@")
+        returns = before_params[: match.start()].split()
+        if name in _NON_METHOD_WORDS or _NON_METHOD_WORDS & set(returns):
+            return None
+        if not returns and name != type_name:
+            return None
+        doc, decorators = _xml_doc(self.raw, idx)
+        text, params = _doc_sections(doc)
+        signature = re.sub(r"\s*=>.*$", "", header)
+        type_params, _ = _type_params(before_params[match.end("name") :], signature)
+        return MethodDoc(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            end_line=end + 1,
+            docstring=_with_params(text, params),
+            args=_param_names(paren_contents(rest)),
+            decorators=_split_attributes(decorators) + attributes,
+            kind="constructor" if not returns else "method",
+            signature=signature,
+            type_params=type_params,
+        )
+
+    def _strip_attributes(self, idx: int) -> tuple[str, list[str]]:
+        """Split leading `[...]` attributes off a line, reading their text from the raw source."""
+        line = self.code[idx].strip()
+        match = _LEADING_ATTRIBUTES_RE.match(line)
+        if match is None:
+            return line, []
+        raw = self.raw[idx].strip()[: match.end()]
+        return line[match.end() :], _split_attributes([raw])
+
+
+def _join(scope: str, name: str) -> str:
+    return f"{scope}.{name}" if scope and name else scope or name
+
+
+def _is_attribute(line: str) -> bool:
+    return line.startswith("[") and not line.startswith("[[")
+
+
+def _modifiers(header: str) -> tuple[list[str], str]:
+    words = header.split()
+    count = 0
+    while count < len(words) and words[count] in _MODIFIERS:
+        count += 1
+    return words[:count], " ".join(words[count:])
+
+
+def _split_attributes(texts: Sequence[str]) -> list[str]:
+    """Turn `[A, B(1)]` attribute lists into one `[A]`, `[B(1)]` entry per attribute."""
+    found: list[str] = []
+    for text in texts:
+        depth = 0
+        start = -1
+        quote: str | None = None
+        for pos, char in enumerate(text):
+            if quote is not None:
+                quote = None if char == quote else quote
+            elif char in "\"'":
+                quote = char
+            elif char == "[":
+                depth += 1
+                start = pos if depth == 1 else start
+            elif char == "]" and depth:
+                depth -= 1
+                if depth == 0:
+                    found.extend(f"[{part}]" for part in split_top_level(text[start + 1 : pos]))
+    return found
+
+
+def _type_params(after_name: str, header: str) -> tuple[list[str], str]:
+    """Return `["T IEntity", "U"]` from `<T, U>` plus `where` clauses, and the text after `>`."""
+    rest = after_name.lstrip()
+    if not rest.startswith("<"):
+        return [], after_name
+    depth = 0
+    for pos, char in enumerate(rest):
+        depth += {"<": 1, ">": -1}.get(char, 0)
+        if depth == 0:
+            names = [part.split()[-1] for part in split_top_level(rest[1:pos]) if part.split()]
+            bounds = {
+                match.group("param"): [
+                    bound
+                    for bound in split_top_level(match.group("bounds"))
+                    if bound not in _CONSTRAINT_KEYWORDS
+                ]
+                for match in _WHERE_RE.finditer(header)
+            }
+            params = [" ".join([name, ", ".join(bounds.get(name, []))]).strip() for name in names]
+            return params, rest[pos + 1 :]
+    return [], after_name
+
+
+def _bases(rest: str) -> list[str]:
+    """Read the `: Base, IFace` list that follows a type's name and type parameters."""
+    rest = rest.strip()
+    if not rest.startswith(":"):
+        return []
+    clause = re.split(r"\bwhere\b", rest[1:], maxsplit=1)[0]
+    return split_top_level(clause)
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(params):
+        cleaned = _LEADING_ATTRIBUTES_RE.sub("", param).split("=", 1)[0]
+        parts = [word for word in cleaned.split() if word not in _PARAM_MODIFIERS]
+        if parts:
+            names.append(parts[-1].lstrip("@"))
+    return names
+
+
+def _record_fields(params: str, notes: dict[str, str]) -> list[FieldDoc]:
+    """Positional record parameters become properties documented by their `<param>` tag."""
+    fields: list[FieldDoc] = []
+    for param in split_top_level(params):
+        parts = _LEADING_ATTRIBUTES_RE.sub("", param).split("=", 1)[0].split()
+        if len(parts) >= 2:  # noqa: PLR2004
+            name = parts[-1].lstrip("@")
+            fields.append(
+                FieldDoc(name=name, type=" ".join(parts[:-1]), docstring=notes.get(name) or None)
+            )
+    return fields
+
+
+def _xml_doc(raw: Sequence[str], idx: int) -> tuple[str | None, list[str]]:
+    return leading_comment(raw, idx, prefixes=("///",), block=("/**", "*/"), skip=_is_attribute)
+
+
+def _doc_sections(doc: str | None) -> tuple[str | None, dict[str, str]]:
+    """Convert an XML doc comment to plain text, keeping `<param>` notes apart.
+
+    `<summary>`, `<remarks>`, `<value>` and `<returns>` become paragraphs; a comment
+    without XML tags is kept as written. `<see cref>`, `<paramref>` and `<c>` turn
+    into inline code.
+    """
+    if not doc:
+        return None, {}
+    if not _XML_DOC_RE.search(doc):
+        return doc, {}
+    sections = {
+        match.group("tag"): _inline(match.group("body")) for match in _XML_SECTION_RE.finditer(doc)
+    }
+    params: dict[str, str] = {}
+    extra: list[str] = []
+    for match in _XML_NAMED_RE.finditer(doc):
+        name = re.sub(r"^\w:", "", match.group("name"))
+        body = _inline(match.group("body"))
+        if match.group("tag") == "param":
+            params[name] = body
+        elif match.group("tag") == "typeparam":
+            extra.append(f"- `{name}`: {body}")
+        else:
+            extra.append(f"Throws `{name}`: {body}")
+    parts = [sections[tag] for tag in ("summary", "remarks", "value") if sections.get(tag)]
+    if sections.get("returns"):
+        parts.append(f"Returns: {sections['returns']}")
+    parts.extend(extra)
+    if not sections and not params and not extra:
+        parts = [_inline(doc)]
+    text = "\n\n".join(part for part in parts if part).strip()
+    return text or None, params
+
+
+def _with_params(text: str | None, params: dict[str, str]) -> str | None:
+    if not params:
+        return text
+    lines = "\n".join(f"- `{name}`: {body}" for name, body in params.items())
+    return f"{text}\n\nParameters:\n{lines}" if text else f"Parameters:\n{lines}"
+
+
+def _inline(text: str) -> str:
+    text = _XML_REF_RE.sub(lambda match: f"`{match.group('ref').rsplit('.', 1)[-1]}`", text)
+    text = _XML_LANGWORD_RE.sub(lambda match: f"`{match.group('word')}`", text)
+    text = _XML_PARAMREF_RE.sub(lambda match: f"`{match.group('name')}`", text)
+    text = _XML_CODE_RE.sub(lambda match: f"`{match.group('code').strip()}`", text)
+    text = re.sub(r"</?para>|<br\s*/>", "\n\n", text)
+    text = html.unescape(_XML_TAG_RE.sub("", text))
+    paragraphs = (" ".join(chunk.split()) for chunk in re.split(r"\n\s*\n", text))
+    return "\n\n".join(chunk for chunk in paragraphs if chunk)
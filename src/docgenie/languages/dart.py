@@ -0,0 +1,319 @@
+"""Dart parser for classes, mixins, enums, extensions, functions and `///` doc comments."""
+
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+from ..models import ClassDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    heritage,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    with_doc_ranges,
+)
+
+_ANNOTATION = r"@[\w.]+(?:\((?:'[^']*'|\"[^\"]*\"|[^)'\"])*\))?"
+_LEADING_ANNOTATIONS_RE = re.compile(rf"^(?:{_ANNOTATION}\s+)+")
+_ANNOTATION_RE = re.compile(_ANNOTATION)
+_TYPE_RE = re.compile(
+    r"^(?P<mods>(?:(?:abstract|sealed|base|final|interface|mixin)\s+)*)"
+    r"(?P<kind>class|mixin|enum|extension\s+type|extension)\b\s*"
+    r"(?P<name>(?!on\b)[A-Za-z_$][\w$]*)?"
+)
+_DIRECTIVE_RE = re.compile(r"^(?:import|export|part|library|typedef)\b")
+_GETTER_RE = re.compile(r"^(?:.+\s)?get\s+(?P<name>[A-Za-z_$][\w$]*)$")
+_CALLABLE_NAME_RE = re.compile(
+    r"(?P<name>[A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)?)\s*(?:<.*>)?\s*$"
+)
+_OPERATOR_RE = re.compile(r"\boperator\s*(?P<symbol>\[\]=?|[^\s\w(\[]+)\s*\(")
+_ASYNC_RE = re.compile(r"\)\s*async\b")
+_IMPORT_RE = re.compile(r"""^\s*import\s+['"](?P<uri>[^'"]+)['"]""", re.MULTILINE)
+
+
+class DartParser(ParserPlugin):
+    """Extract classes, mixins, enums, extensions and functions from Dart and Flutter code."""
+
+    def __init__(self) -> None:
+        super().__init__(name="dart", languages={"dart"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _DartWalker(content, path)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports={match.group("uri") for match in _IMPORT_RE.finditer(content)},
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=("///",),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+
+
+class _DartWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        self.code = code_lines(content)
+        self.depths = brace_depths(self.code)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+
+    def walk(self) -> None:
+        """Record top-level types and functions; Dart does not nest type declarations."""
+        idx = 0
+        while idx < len(self.code):
+            if self.depths[idx] != 0:
+                idx += 1
+                continue
+            line, inline_annotations = self._strip_annotations(idx)
+            if not line:
+                idx += 1
+                continue
+            end, _ = item_end(self.code, idx)
+            match = _TYPE_RE.match(line)
+            if match is not None:
+                self._handle_type(match, idx, end, inline_annotations)
+            elif not _DIRECTIVE_RE.match(line):
+                header = _LEADING_ANNOTATIONS_RE.sub("", header_text(self.code, idx, end))
+                callable_ = _callable(header)
+                if callable_ is not None and _is_public(callable_[0]):
+                    self.functions.append(
+                        self._function(FunctionDoc, idx, end, header, inline_annotations)
+                    )
+            idx = end + 1
+
+    def _handle_type(
+        self, match: re.Match[str], idx: int, end: int, inline_annotations: list[str]
+    ) -> None:
+        header = _LEADING_ANNOTATIONS_RE.sub("", header_text(self.code, idx, end))
+        kind = " ".join(match.group("kind").split())
+        rest = _drop_type_params(header[match.end() :])
+        bases = heritage(rest, ("extends", "with", "implements", "on"))
+        name = match.group("name")
+        if name is None:
+            # An unnamed extension documents the type it extends.
+            if kind != "extension" or not bases:
+                return
+            name, bases = bases[0], []
+        if not _is_public(name):
+            return
+        doc, annotations = self._doc_and_annotations(idx)
+        has_body = "{" in "".join(self.code[idx : end + 1])
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                docstring=doc,
+                bases=bases,
+                decorators=annotations + inline_annotations,
+                methods=self._members(idx, end, name) if has_body else [],
+                kind=_kind(match.group("mods"), kind),
+                signature=header,
+                end_line=end + 1,
+            )
+        )
+
+    def _members(self, start: int, end: int, owner: str) -> list[MethodDoc]:
+        """Return the public methods, constructors and documented getters of a type body."""
+        methods: list[MethodDoc] = []
+        depth = self.depths[start] + 1
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, inline_annotations = self._strip_annotations(idx)
+            if not line:
+                idx += 1
+                continue
+            member_end, _ = item_end(self.code, idx)
+            member_end = min(member_end, end)
+            header = _LEADING_ANNOTATIONS_RE.sub("", header_text(self.code, idx, member_end))
+            callable_ = _callable(header)
+            getter = _GETTER_RE.match(_drop_body(header))
+            if callable_ is not None and _is_public(callable_[0]):
+                name, prefix, _ = callable_
+                constructor = name == owner or name.startswith(f"{owner}.")
+                has_body = "{" in "".join(self.code[idx : member_end + 1]) or "=>" in header
+                # A bare `name(...)` with no return type is only a declaration when it has a
+                # body; otherwise it is an enum value such as `red(0xFF0000),`.
+                if constructor or prefix.strip() or has_body:
+                    methods.append(
+                        self._function(
+                            MethodDoc,
+                            idx,
+                            member_end,
+                            header,
+                            inline_annotations,
+                            kind="constructor" if constructor else None,
+                        )
+                    )
+            elif getter is not None and _is_public(getter.group("name")):
+                doc, annotations = self._doc_and_annotations(idx)
+                # Only documented getters are API; the rest are usually backing-field plumbing.
+                if doc:
+                    methods.append(
+                        MethodDoc(
+                            name=getter.group("name"),
+                            file=self.path,
+                            line=idx + 1,
+                            docstring=doc,
+                            decorators=annotations + inline_annotations,
+                            kind="property",
+                            signature=_drop_body(header),
+                            end_line=member_end + 1,
+                        )
+                    )
+            idx = member_end + 1
+        return methods
+
+    def _function(
+        self,
+        doc_type: type[FunctionDoc],
+        idx: int,
+        end: int,
+        header: str,
+        inline_annotations: list[str],
+        *,
+        kind: str | None = None,
+    ) -> FunctionDoc:
+        name, _, params_start = _callable(header) or ("<anonymous>", "", 0)
+        doc, annotations = self._doc_and_annotations(idx)
+        return doc_type(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            docstring=doc,
+            args=_param_names(paren_contents(header[params_start:])),
+            decorators=annotations + inline_annotations,
+            is_async=bool(_ASYNC_RE.search(header)),
+            kind=kind or doc_type.kind,
+            signature=_drop_body(header),
+            end_line=end + 1,
+        )
+
+    def _strip_annotations(self, idx: int) -> tuple[str, list[str]]:
+        """Return the declaration text of a line and the annotations written before it.
+
+        Annotation arguments are read from the raw line, since string bodies are blanked
+        in the scanned code.
+        """
+        line = self.code[idx].strip()
+        match = _LEADING_ANNOTATIONS_RE.match(line)
+        if match is None:
+            # A lone annotation line belongs to the declaration below it.
+            return ("", []) if line.startswith("@") else (line, [])
+        raw = self.raw[idx].strip()[: match.end()]
+        return line[match.end() :], _ANNOTATION_RE.findall(raw)
+
+    def _doc_and_annotations(self, idx: int) -> tuple[str | None, list[str]]:
+        doc, annotations = leading_comment(
+            self.raw,
+            idx,
+            prefixes=("///",),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+        return doc, [found for line in annotations for found in _ANNOTATION_RE.findall(line)]
+
+
+def _callable(header: str) -> tuple[str, str, int] | None:
+    """Return `(name, prefix, params_start)` for a function, method or constructor header.
+
+    The prefix is everything before the name: modifiers and the return type. Fields
+    initialized with a call (`final items = List.filled(3, 0);`) and setters are not
+    callables.
+    """
+    operator = _OPERATOR_RE.search(header)
+    if operator is not None:
+        name = f"operator {operator.group('symbol')}"
+        return name, header[: operator.start()], operator.end() - 1
+    depth = 0
+    for pos, char in enumerate(header):
+        if char in "<[{(":
+            if char != "(" or depth > 0:
+                depth += 1
+                continue
+            match = _CALLABLE_NAME_RE.search(header[:pos])
+            if match is None:
+                return None
+            if match.group("name") == "Function":
+                # `void Function(int) handler(...)`: the name follows the function type.
+                depth += 1
+                continue
+            prefix = header[: match.start()]
+            words = prefix.split()
+            if words and words[-1] == "set":
+                return None
+            return match.group("name"), prefix, pos
+        if char in ">]})":
+            depth -= 1
+        elif char == "=" and depth <= 0:
+            return None
+    return None
+
+
+def _kind(modifiers: str, kind: str) -> str:
+    for modifier in ("abstract", "sealed", "mixin"):
+        if modifier in modifiers.split() and kind == "class":
+            return f"{modifier} class"
+    return kind
+
+
+def _drop_type_params(rest: str) -> str:
+    """Remove the `<T extends Base>` list that follows a declared type name."""
+    rest = rest.lstrip()
+    if not rest.startswith("<"):
+        return rest
+    depth = 0
+    for idx, char in enumerate(rest):
+        depth += {"<": 1, ">": -1}.get(char, 0)
+        if depth == 0:
+            return rest[idx + 1 :]
+    return rest
+
+
+def _drop_body(header: str) -> str:
+    """Cut an `=> expression` body off a header, keeping `async` and the parameters."""
+    depth = 0
+    for idx, char in enumerate(header):
+        if char in "([":
+            depth += 1
+        elif char in ")]":
+            depth -= 1
+        elif depth == 0 and header.startswith("=>", idx):
+            return header[:idx].rstrip()
+    return header
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(params):
+        if param[:1] in "[{":
+            names.extend(_param_names(param.strip("[]{} ")))
+            continue
+        declared = _ANNOTATION_RE.sub("", param).split("=", 1)[0]
+        if not re.search(r"\bFunction\b", declared):
+            # Old-style function-typed parameters: `void onTap(int count)`.
+            declared = declared.split("(", 1)[0]
+        words = declared.split()
+        if words:
+            names.append(words[-1].removeprefix("this.").removeprefix("super."))
+    return names
+
+
+def _is_public(name: str) -> bool:
+    """Dart hides library-private names behind a leading `_`, including `Type._named`."""
+    return not any(part.startswith("_") for part in name.split("."))
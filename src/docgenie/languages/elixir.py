@@ -0,0 +1,374 @@
+"""Elixir parser for modules, functions, macros and their `@doc` attributes.
+
+A `defmodule` (or `defprotocol`) is reported as a module-kind class whose methods
+are its `def`, `defmacro` and `defdelegate` clauses; clauses of one function are
+merged into a single entry per name and arity. `@moduledoc` and `@doc` strings are
+the docstrings, `@spec` gives the signature, and `defp`/`defmacrop` functions, or
+those marked `@doc false`, are private. `use`, `import`, `alias` and `require`
+record the referenced modules as imports, and `use` also lists them as bases.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field, replace
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines, split_top_level
+
+_ALIAS = r"[A-Z]\w*(?:\.[A-Z]\w*)*"
+_MODULE_RE = re.compile(rf"^(?P<kind>defmodule|defprotocol)\s+(?P<name>{_ALIAS})\b")
+_DEF_RE = re.compile(
+    r"^(?P<kind>defmacrop|defmacro|defdelegate|defp|def)\s+(?P<name>[a-z_]\w*[?!]?)"
+    r"\s*(?P<rest>.*)$"
+)
+_ATTRIBUTE_RE = re.compile(r"^@(?P<name>moduledoc|doc|spec|deprecated)\b\s*(?P<value>.*)$")
+_IMPORT_RE = re.compile(
+    rf"^(?P<kind>use|import|alias|require)\s+(?P<name>{_ALIAS})(?:\.\{{(?P<group>[^}}]*)\}})?"
+)
+_STRUCT_RE = re.compile(r"^defstruct\b\s*(?P<fields>.*)$")
+_SPEC_RE = re.compile(r"^(?P<name>[a-z_]\w*[?!]?)(?P<rest>.*)$")
+_STRING_RE = re.compile(
+    r"""^(?:~[Ss])?(?:"(?P<double>(?:\\.|[^"\\])*)"|'(?P<single>(?:\\.|[^'\\])*)')"""
+)
+_HEREDOC_RE = re.compile(r"""(?:~[A-Za-z])?(?P<quote>\"\"\"|''')\s*$""")
+_IDENT_RE = re.compile(r"^[a-z_]\w*$")
+_GUARD_RE = re.compile(r"^\s*when\s+(?P<guard>.+?)\s*(?:,\s*do:.*|\bdo)?$")
+
+# `do:` and `:do` are keyword-list keys, not block openers.
+_OPENER_RE = re.compile(r"(?<![\w:.@])(?:do|fn)\b(?![:?!])")
+_CLOSER_RE = re.compile(r"(?<![\w:.@])end\b(?![:?!])")
+
+_DEF_KINDS = {
+    "def": "function",
+    "defp": "function",
+    "defdelegate": "function",
+    "defmacro": "macro",
+    "defmacrop": "macro",
+}
+
+
+class ElixirParser(ParserPlugin):
+    """Extract modules, public functions, macros and `defstruct` fields from Elixir sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="elixir", languages={"elixir"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _ElixirWalker(content, path, include_private=self.include_private)
+        walker.walk(0, len(walker.code), None)
+        return ParseResult(classes=walker.classes, imports=walker.imports)
+
+
+@dataclass
+class _Module:
+    """A `defmodule` body, collected before it becomes a ClassDoc."""
+
+    name: str
+    kind: str
+    line: int
+    end_line: int
+    signature: str
+    docstring: str | None = None
+    moduledoc_range: tuple[int, int] | None = None
+    hidden: bool = False
+    bases: list[str] = field(default_factory=list)
+    fields: list[FieldDoc] = field(default_factory=list)
+    methods: list[MethodDoc] = field(default_factory=list)
+    clauses: dict[tuple[str, int], int] = field(default_factory=dict)
+    # Attributes waiting for the next `def`: `@doc`, `@deprecated` and `@spec` by name/arity.
+    doc: str | None = None
+    doc_range: tuple[int, int] | None = None
+    doc_false: bool = False
+    deprecated: str | None = None
+    specs: dict[tuple[str, int], str] = field(default_factory=dict)
+
+
+class _ElixirWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = _blank_heredocs(
+            self.raw, code_lines(content, line_comments=("#",), block_comments=())
+        )
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+
+    def walk(self, start: int, stop: int, owner: _Module | None) -> None:
+        """Record the declarations of one module body; function bodies are skipped."""
+        idx = start
+        while idx < stop:
+            line = self.code[idx].strip()
+            if not line:
+                idx += 1
+                continue
+            end = min(self._statement_end(idx), stop - 1)
+            if module := _MODULE_RE.match(line):
+                self._module(idx, end, module, owner)
+                idx = end + 1
+                continue
+            if owner is not None and (definition := _DEF_RE.match(line)):
+                self._def(idx, end, definition, owner)
+            elif owner is not None and (attribute := _ATTRIBUTE_RE.match(line)):
+                end = self._attribute(idx, end, attribute, owner)
+            elif owner is not None and (struct := _STRUCT_RE.match(line)):
+                owner.fields.extend(_struct_fields(self._text(idx, end, struct.start("fields"))))
+            self._record_imports(idx, end, owner)
+            idx = end + 1
+
+    def _module(self, idx: int, end: int, match: re.Match[str], owner: _Module | None) -> None:
+        name = match.group("name")
+        if owner is not None:
+            name = f"{owner.name}.{name}"
+        module = _Module(
+            name=name,
+            kind="protocol" if match.group("kind") == "defprotocol" else "module",
+            line=idx + 1,
+            end_line=end + 1,
+            signature=f"{match.group('kind')} {name}",
+        )
+        self.walk(idx + 1, end, module)
+        cls = ClassDoc(
+            name=module.name,
+            file=self.path,
+            line=module.line,
+            end_line=module.end_line,
+            docstring=module.docstring,
+            bases=module.bases,
+            methods=[method for method in module.methods if self._keep(method.private)],
+            kind=module.kind,
+            signature=module.signature,
+            fields=module.fields,
+            doc_line=module.moduledoc_range[0] if module.moduledoc_range else None,
+            doc_end_line=module.moduledoc_range[1] if module.moduledoc_range else None,
+            private=module.hidden,
+        )
+        if self._keep(cls.private):
+            self.classes.append(cls)
+
+    def _def(self, idx: int, end: int, match: re.Match[str], owner: _Module) -> None:
+        kind = match.group("kind")
+        header = self._text(idx, self._head_end(idx, end), match.start("rest"))
+        params = _head_params(header)
+        name, arity = match.group("name"), len(params)
+        doc, doc_range = owner.doc, owner.doc_range
+        hidden = kind in {"defp", "defmacrop"} or owner.doc_false
+        deprecated = owner.deprecated
+        owner.doc, owner.doc_range, owner.doc_false, owner.deprecated = None, None, False, None
+        key = (name, arity)
+        if key in owner.clauses:
+            # A further clause of a function seen above extends its body.
+            pos = owner.clauses[key]
+            first = owner.methods[pos]
+            owner.methods[pos] = replace(
+                first,
+                end_line=end + 1,
+                docstring=first.docstring or doc,
+                doc_line=first.doc_line or (doc_range[0] if doc_range else None),
+                doc_end_line=first.doc_end_line or (doc_range[1] if doc_range else None),
+            )
+            return
+        spec = owner.specs.pop(key, None)
+        owner.clauses[key] = len(owner.methods)
+        owner.methods.append(
+            MethodDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=doc,
+                args=[_param_name(param) for param in params],
+                kind=_DEF_KINDS[kind],
+                signature=spec or _def_signature(kind, name, header),
+                doc_line=doc_range[0] if doc_range else None,
+                doc_end_line=doc_range[1] if doc_range else None,
+                deprecated=deprecated,
+                private=hidden,
+            )
+        )
+
+    def _attribute(self, idx: int, end: int, match: re.Match[str], owner: _Module) -> int:
+        """Record a module attribute and return the last line of its value."""
+        name = match.group("name")
+        text, end = self._attribute_value(idx, end, match.start("value"))
+        if name == "spec":
+            spec = _SPEC_RE.match(" ".join(text.split()))
+            if spec is not None:
+                rest = spec.group("rest").lstrip()
+                arity = len(split_top_level(_inner(rest))) if rest.startswith("(") else 0
+                owner.specs.setdefault((spec.group("name"), arity), spec.string)
+            return end
+        value = _string_value(text)
+        if name == "moduledoc":
+            owner.docstring = value
+            owner.moduledoc_range = (idx + 1, end + 1) if value else None
+            owner.hidden = text.strip() == "false"
+        elif name == "doc":
+            owner.doc = value
+            owner.doc_range = (idx + 1, end + 1) if value else None
+            owner.doc_false = text.strip() == "false"
+        else:
+            owner.deprecated = value or ""
+        return end
+
+    def _attribute_value(self, idx: int, end: int, column: int) -> tuple[str, int]:
+        """Return the raw text of the attribute value starting at `column` and its last line."""
+        first = self.raw[idx].strip()[column:]
+        heredoc = _HEREDOC_RE.search(first)
+        if heredoc is None:
+            return self._text(idx, end, column), end
+        closing = heredoc.group("quote")
+        body: list[str] = []
+        pos = idx + 1
+        while pos < len(self.raw) and not self.raw[pos].lstrip().startswith(closing):
+            body.append(self.raw[pos])
+            pos += 1
+        closer = self.raw[pos] if pos < len(self.raw) else ""
+        # The closing quotes set the indentation stripped from every line.
+        indent = closer[: len(closer) - len(closer.lstrip())]
+        text = "\n".join(line.removeprefix(indent) for line in body)
+        return closing + text + closing, min(pos, len(self.raw) - 1)
+
+    def _record_imports(self, idx: int, end: int, owner: _Module | None) -> None:
+        for pos in range(idx, end + 1):
+            found = _IMPORT_RE.match(self.code[pos].strip())
+            if found is None:
+                continue
+            base = found.group("name")
+            group = found.group("group")
+            names = (
+                [f"{base}.{item.strip()}" for item in group.split(",") if item.strip()]
+                if group is not None
+                else [base]
+            )
+            self.imports.update(names)
+            if found.group("kind") == "use" and owner is not None and pos == idx:
+                owner.bases.extend(name for name in names if name not in owner.bases)
+
+    def _text(self, start: int, end: int, column: int) -> str:
+        """Join raw lines `start`..`end`, starting at `column` of the stripped first line."""
+        lines = [self.raw[start].strip()[column:], *self.raw[start + 1 : end + 1]]
+        return " ".join(line.strip() for line in lines).strip()
+
+    def _head_end(self, start: int, end: int) -> int:
+        """Return the line closing the parameter list opened on line `start`."""
+        depth = 0
+        for idx in range(start, end + 1):
+            depth += self.code[idx].count("(") - self.code[idx].count(")")
+            if depth <= 0:
+                return idx
+        return end
+
+    def _statement_end(self, start: int) -> int:
+        """Return the line closing the blocks and brackets opened on line `start`."""
+        blocks = brackets = 0
+        for idx in range(start, len(self.code)):
+            line = self.code[idx]
+            blocks += len(_OPENER_RE.findall(line)) - len(_CLOSER_RE.findall(line))
+            brackets += sum(line.count(char) for char in "([{") - sum(
+                line.count(char) for char in ")]}"
+            )
+            # A trailing operator or comma continues the expression on the next line.
+            continued = line.rstrip().endswith((",", "|", "::", "=", "->", "\\\\"))
+            if blocks <= 0 and brackets <= 0 and not continued:
+                return idx
+        return len(self.code) - 1
+
+    def _keep(self, private: bool) -> bool:
+        return self.include_private or not private
+
+
+def _blank_heredocs(raw: list[str], code: list[str]) -> list[str]:
+    """Blank heredoc bodies so keywords in `@doc` text do not open or close blocks."""
+    result = list(code)
+    closing: str | None = None
+    for idx, line in enumerate(raw):
+        if closing is not None:
+            result[idx] = ""
+            if line.lstrip().startswith(closing):
+                closing = None
+            continue
+        heredoc = _HEREDOC_RE.search(line)
+        if heredoc is not None and heredoc.group("quote")[0] in code[idx]:
+            closing = heredoc.group("quote")
+    return result
+
+
+def _string_value(text: str) -> str | None:
+    """Return the text of a string (or heredoc) literal; None for `false` or other values."""
+    text = text.strip()
+    for quote in ('"""', "'''"):
+        body = text.removeprefix("~S").removeprefix("~s")
+        if body.startswith(quote) and body.endswith(quote) and len(body) >= 2 * len(quote):
+            return body[len(quote) : -len(quote)].strip() or None
+    match = _STRING_RE.match(text)
+    if match is None:
+        return None
+    value = match.group("double") if match.group("double") is not None else match.group("single")
+    return value.replace('\\"', '"').strip() or None
+
+
+def _head_params(header: str) -> list[str]:
+    """Return the parameters of a `def` head such as `name(a, b \\\\ 1) when a > 0 do`."""
+    header = header.strip()
+    if not header.startswith("("):
+        return []
+    return [param.strip() for param in split_top_level(_inner(header)) if param.strip()]
+
+
+def _inner(text: str) -> str:
+    """Return the text inside the parentheses opening `text`."""
+    depth = 0
+    for idx, char in enumerate(text):
+        depth += {"(": 1, ")": -1}.get(char, 0)
+        if depth == 0:
+            return text[1:idx]
+    return text[1:]
+
+
+def _param_name(param: str) -> str:
+    """Name a parameter by its variable: `opts \\\\ []` is `opts`, `%User{} = user` is `user`."""
+    param = param.split("\\\\", 1)[0].strip()
+    for side in reversed(split_top_level(param, "=")):
+        if _IDENT_RE.match(side.strip()):
+            return side.strip()
+    return " ".join(param.split())
+
+
+def _def_signature(kind: str, name: str, header: str) -> str:
+    header = header.strip()
+    if not header.startswith("("):
+        return f"{kind} {name}"
+    params = _inner(header)
+    signature = f"{kind} {name}({' '.join(params.split())})"
+    guard = _GUARD_RE.match(header[len(params) + 2 :])
+    if guard is not None:
+        signature += f" when {guard.group('guard')}"
+    return signature
+
+
+def _struct_fields(text: str) -> list[FieldDoc]:
+    """Return the fields of `defstruct [:a, :b]` or `defstruct a: nil, b: 0`."""
+    text = text.strip()
+    if text.startswith("[") and text.endswith("]"):
+        text = text[1:-1]
+    fields: list[FieldDoc] = []
+    for entry in split_top_level(text):
+        entry = entry.strip()
+        key = re.match(r"^:?(?P<name>[a-z_]\w*)(?::\s|$)", entry)
+        if key is not None:
+            # Struct fields are untyped unless a `@type t` spells them out.
+            fields.append(FieldDoc(name=key.group("name"), type="term()"))
+    return fields
+
+
+def module_path(name: str) -> str:
+    """Return where Mix expects module `name`: `MyApp.HTTPClient` is `my_app/http_client.ex`."""
+    parts = [
+        re.sub(r"([a-z\d])([A-Z])", r"\1_\2", re.sub(r"([A-Z]+)([A-Z][a-z])", r"\1_\2", part))
+        for part in name.split(".")
+    ]
+    return "/".join(parts).lower() + ".ex"
@@ -0,0 +1,660 @@
+"""Go parser for exported declarations, doc comments and struct field tags.
+
+Unexported declarations are skipped unless the registry asks for private symbols.
+"""
+
+from __future__ import annotations
+
+import ast
+import operator
+import re
+import textwrap
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, ConstantDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    statement_end,
+    with_doc_ranges,
+)
+
+_FUNC_RE = re.compile(r"^func\s*(?:\((?P<recv>[^)]*)\)\s*)?(?P<name>[A-Za-z_]\w*)\s*[\[(]")
+_RECEIVER_TYPE_RE = re.compile(r"\*?\s*(?P<type>[A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\s*$")
+_TYPE_NAME_RE = re.compile(r"^(?P<name>[A-Za-z_]\w*)")
+_TYPE_REST_RE = re.compile(r"^\s*(?P<alias>=\s*)?(?P<rest>struct\b|interface\b|.+)")
+_FIELD_RE = re.compile(r"^(?P<names>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s+(?P<type>\S.*)$")
+_TAG_RE = re.compile(r"`(?P<tag>[^`]*)`\s*(?://.*)?$")
+_TAG_PAIR_RE = re.compile(r'(?P<key>[\w.-]+):"(?P<value>(?:[^"\\]|\\.)*)"')
+_INTERFACE_METHOD_RE = re.compile(r"^(?P<name>[A-Z]\w*)\s*\(")
+_IMPORT_SPEC_RE = re.compile(r'^\s*(?:[\w.]+\s+)?"(?P<path>[^"]+)"')
+_RETURN_RE = re.compile(r"\breturn\b")
+_PANIC_RE = re.compile(r"\bpanic\s*\(")
+_ERRORF_RE = re.compile(r"\bfmt\.Errorf\s*\(")
+# `go test` runs these from _test.go files and checks their `// Output:` comment.
+_EXAMPLE_RE = re.compile(r"^Example(?:$|[A-Z_])")
+_STRING_LITERAL_RE = re.compile(r'\s*(?:"(?P<quoted>(?:[^"\\]|\\.)*)"|`(?P<raw>[^`]*)`)')
+_CONST_NAMES_RE = re.compile(r"^(?P<names>[A-Za-z_]\w*(?:\s*,\s*[A-Za-z_]\w*)*)\s*(?P<type>.*)$")
+_IOTA_RE = re.compile(r"\biota\b")
+_GO_STMT_RE = re.compile(r"(?:^|[\s;{])go\s+(?:func\b|[A-Za-z_][\w.]*\s*[\[(])")
+_CHAN_RE = re.compile(r"\bchan\b|<-")
+_LOCK_RE = re.compile(r"\.(?P<kind>R?Lock)\s*\(\s*\)")
+_MUTEX_TYPE_RE = re.compile(r"\*?sync\.(?:RW)?Mutex")
+# Untyped constant literals and the type Go gives them by default.
+_LITERAL_TYPES = (
+    (re.compile(r'^(?:"(?:[^"\\]|\\.)*"|`[^`]*`)$'), "string"),
+    (re.compile(r"^'(?:[^'\\]|\\.)+'$"), "rune"),
+    (re.compile(r"^-?(?:0[xX][\da-fA-F_]+|0[bB][01_]+|0[oO]?[0-7_]+|\d[\d_]*)$"), "int"),
+    (re.compile(r"^-?(?:\d[\d_]*\.[\d_]*|\.\d[\d_]*|\d[\d_]*)(?:[eE][+-]?\d+)?$"), "float64"),
+    (re.compile(r"^(?:true|false)$"), "bool"),
+)
+_IOTA_OPERATORS = {
+    ast.Add: operator.add,
+    ast.Sub: operator.sub,
+    ast.Mult: operator.mul,
+    ast.FloorDiv: operator.floordiv,
+    ast.Mod: operator.mod,
+    ast.LShift: operator.lshift,
+    ast.RShift: operator.rshift,
+    ast.BitOr: operator.or_,
+    ast.BitAnd: operator.and_,
+    ast.BitXor: operator.xor,
+}
+
+
+class GoParser(ParserPlugin):
+    """Extract exported functions, methods, structs and interfaces from Go sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="go", languages={"go"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _GoWalker(content, path, include_private=self.include_private)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.attach_methods(),
+            imports=walker.imports,
+            module_doc=walker.package_doc,
+            constants=walker.constants,
+        )
+        return with_doc_ranges(result, walker.raw, prefixes=("//",), block=None)
+
+
+class _GoWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = code_lines(content, quotes="\"'`", multiline_quotes="`")
+        self.depths = brace_depths(self.code)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.methods: dict[str, list[MethodDoc]] = {}
+        self.imports: set[str] = set()
+        self.package_doc: str | None = None
+        self.constants: list[ConstantDoc] = []
+
+    def walk(self) -> None:
+        idx = 0
+        while idx < len(self.code):
+            line = self.code[idx].strip()
+            if self.depths[idx] != 0 or not line:
+                idx += 1
+            elif line.startswith("package "):
+                # Long package docs (usually in doc.go) are often written as a /* */ block.
+                self.package_doc = leading_comment(
+                    self.raw, idx, prefixes=("//",), block=("/*", "*/")
+                )[0]
+                idx += 1
+            elif line.startswith("import"):
+                idx = self._imports(idx)
+            elif re.match(r"^type\s*\($", line):
+                idx = self._type_group(idx)
+            elif line.startswith("type "):
+                idx = self._type_spec(idx, line[len("type ") :].strip(), _doc(self.raw, idx))
+            elif re.match(r"^const\s*\($", line):
+                idx = self._const_group(idx)
+            elif line.startswith("const "):
+                self._const_spec(idx, self.code[idx].index("const") + len("const"))
+                idx += 1
+            elif match := _FUNC_RE.match(line):
+                idx = self._func(idx, match)
+            else:
+                idx += 1
+
+    def attach_methods(self) -> list[ClassDoc]:
+        """Attach receiver methods to the types declared in this file.
+
+        Methods on types declared elsewhere in the package are kept as
+        `Type.Method` functions so they are still documented.
+        """
+        classes: list[ClassDoc] = []
+        for cls in self.classes:
+            extra = self.methods.pop(cls.name, [])
+            if extra:
+                cls = ClassDoc(
+                    name=cls.name,
+                    file=cls.file,
+                    line=cls.line,
+                    end_line=cls.end_line,
+                    docstring=cls.docstring,
+                    bases=cls.bases,
+                    methods=cls.methods + extra,
+                    kind=cls.kind,
+                    signature=cls.signature,
+                    fields=cls.fields,
+                    type_params=cls.type_params,
+                    private=cls.private,
+                    concurrency=cls.concurrency,
+                )
+            classes.append(cls)
+        for receiver, methods in self.methods.items():
+            for method in methods:
+                self.functions.append(
+                    FunctionDoc(
+                        name=f"{receiver}.{method.name}",
+                        file=method.file,
+                        line=method.line,
+                        end_line=method.end_line,
+                        docstring=method.docstring,
+                        args=method.args,
+                        kind="method",
+                        signature=method.signature,
+                        errors=method.errors,
+                        private=method.private or not _exported(receiver),
+                        receiver=method.receiver,
+                        concurrency=method.concurrency,
+                    )
+                )
+        self.methods = {}
+        return classes
+
+    def _imports(self, idx: int) -> int:
+        line = self.raw[idx].strip()
+        if not re.match(r"^import\s*\(", line):
+            match = _IMPORT_SPEC_RE.match(line[len("import") :])
+            if match:
+                self.imports.add(match.group("path"))
+            return idx + 1
+        end = idx + 1
+        while end < len(self.raw) and self.code[end].strip() != ")":
+            match = _IMPORT_SPEC_RE.match(self.raw[end])
+            if match:
+                self.imports.add(match.group("path"))
+            end += 1
+        return end + 1
+
+    def _type_group(self, idx: int) -> int:
+        end = idx + 1
+        while end < len(self.code) and self.code[end].strip() != ")":
+            spec = self.code[end].strip()
+            if self.depths[end] == 0 and spec:
+                end = self._type_spec(end, spec, _doc(self.raw, end))
+            else:
+                end += 1
+        return end + 1
+
+    def _const_group(self, idx: int) -> int:
+        """Record the specs of a `const (...)` block, tracking `iota` and implicit repeats.
+
+        A spec without `= values` repeats the previous spec's type and expressions with
+        the next `iota`. Blocks that use `iota` are grouped under their first declared
+        type (or first constant name) and numbered by `iota`.
+        """
+        end = idx + 1
+        while end < len(self.code) and self.code[end].strip() != ")":
+            end += 1
+        specs: list[tuple[int, list[str], str, list[str]]] = []
+        previous: tuple[str, list[str]] = ("", [])
+        for line in range(idx + 1, end):
+            parsed = self._const_parts(line, 0)
+            if parsed is None:
+                continue
+            names, const_type, values = parsed
+            if values:
+                previous = (const_type, values)
+            else:
+                const_type, values = previous
+            specs.append((line, names, const_type, values))
+        group = None
+        if any(_IOTA_RE.search(value) for _, _, _, values in specs for value in values):
+            group = next((spec[2] for spec in specs if spec[2]), None) or next(
+                (name for _, names, _, _ in specs for name in names if name != "_"), None
+            )
+        for iota, (line, names, const_type, values) in enumerate(specs):
+            self._add_constants(line, names, const_type, values, iota=iota, group=group)
+        return end + 1
+
+    def _const_spec(self, idx: int, column: int) -> None:
+        parsed = self._const_parts(idx, column)
+        if parsed is not None and parsed[2]:
+            self._add_constants(idx, *parsed, iota=0, group=None)
+
+    def _const_parts(self, idx: int, column: int) -> tuple[list[str], str, list[str]] | None:
+        """Split the spec on line `idx` into names, declared type and value expressions."""
+        code = self.code[idx][column:].rstrip()
+        raw = self.raw[idx][column : column + len(code)]
+        if self.depths[idx] != 0 or not code.strip():
+            return None
+        equals = code.find("=")
+        head = code if equals < 0 else code[:equals]
+        match = _CONST_NAMES_RE.match(head.strip())
+        if match is None:
+            return None
+        names = [name.strip() for name in match.group("names").split(",")]
+        values = [] if equals < 0 else _split_values(code[equals + 1 :], raw[equals + 1 :])
+        return names, " ".join(match.group("type").split()), values
+
+    def _add_constants(
+        self,
+        idx: int,
+        names: list[str],
+        const_type: str,
+        values: list[str],
+        *,
+        iota: int,
+        group: str | None,
+    ) -> None:
+        doc = _trailing_comment(self.raw[idx]) or _doc(self.raw, idx)
+        for name, expression in zip(names, values, strict=False):
+            if name == "_" or not self._keep(name):
+                continue
+            literal = _literal_type(expression)
+            value = expression if literal else _iota_value(expression, iota)
+            self.constants.append(
+                ConstantDoc(
+                    name=name,
+                    file=self.path,
+                    line=idx + 1,
+                    expression=expression,
+                    value=value,
+                    type=const_type or literal or ("int" if value is not None else None),
+                    docstring=doc,
+                    group=group,
+                    index=iota if group else None,
+                    private=not _exported(name),
+                )
+            )
+
+    def _type_spec(self, idx: int, spec: str, doc: str | None) -> int:
+        name_match = _TYPE_NAME_RE.match(spec)
+        if name_match is None:
+            return idx + 1
+        type_params, after = _split_type_params(spec[name_match.end() :])
+        match = _TYPE_REST_RE.match(after)
+        if match is None:
+            return idx + 1
+        rest = match.group("rest")
+        is_body = not match.group("alias") and rest in ("struct", "interface")
+        end, has_body = item_end(self.code, idx) if is_body else (idx, False)
+        name = name_match.group("name")
+        if not self._keep(name):
+            return end + 1
+        signature = header_text(self.code, idx, end) if is_body else " ".join(spec.split())
+        if not self.code[idx].strip().startswith("type"):
+            signature = f"type {signature}"
+        fields: list[FieldDoc] = []
+        methods: list[MethodDoc] = []
+        concurrency: dict[str, object] = {}
+        if is_body and has_body and rest == "struct":
+            fields = self._fields(idx + 1, end)
+            concurrency = self._struct_concurrency(idx + 1, end)
+        elif is_body and has_body:
+            methods = self._interface_methods(idx + 1, end)
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=doc,
+                bases=self._embedded(idx + 1, end) if is_body and rest == "interface" else [],
+                methods=methods,
+                kind=rest if is_body else "type",
+                signature=signature,
+                fields=fields,
+                type_params=type_params,
+                private=not _exported(name),
+                concurrency=concurrency,
+            )
+        )
+        return end + 1
+
+    def _field_lines(self, start: int, end: int) -> list[tuple[int, list[str], str]]:
+        """Return `(line index, names, type)` for every field of a struct body, unexported too.
+
+        An embedded field is named after its type: `sync.Mutex` -> `Mutex`.
+        """
+        depth = self.depths[start]
+        specs: list[tuple[int, list[str], str]] = []
+        for idx in range(start, end):
+            code = self.code[idx].strip()
+            if self.depths[idx] != depth or not code or code.startswith("}"):
+                continue
+            declaration = re.sub(r"`.*$", "", code).strip().rstrip("{").strip()
+            field_match = _FIELD_RE.match(declaration)
+            if field_match:
+                names = [n.strip() for n in field_match.group("names").split(",")]
+                field_type = field_match.group("type").strip()
+            else:
+                field_type = declaration
+                names = [declaration.lstrip("*").rsplit(".", 1)[-1]]
+            specs.append((idx, names, "struct{...}" if field_type == "struct" else field_type))
+        return specs
+
+    def _fields(self, start: int, end: int) -> list[FieldDoc]:
+        fields: list[FieldDoc] = []
+        for idx, names, field_type in self._field_lines(start, end):
+            code = self.code[idx].strip()
+            raw = self.raw[idx].strip()
+            if code.endswith("{"):
+                # Anonymous struct field: the tag follows the closing brace.
+                raw_tail = self.raw[item_end(self.code, idx)[0]].strip()
+                tag_match = _TAG_RE.search(raw_tail)
+            else:
+                tag_match = _TAG_RE.search(raw)
+            tags = _parse_tag(tag_match.group("tag")) if tag_match else {}
+            doc = _trailing_comment(raw) or _doc(self.raw, idx)
+            fields.extend(
+                FieldDoc(name=name, type=field_type, tags=tags, docstring=doc)
+                for name in names
+                if _exported(name)
+            )
+        return fields
+
+    def _struct_concurrency(self, start: int, end: int) -> dict[str, object]:
+        """Return the struct's mutex and channel fields, unexported ones included.
+
+        A type holding a mutex is taken to guard its state with it, so it is reported
+        as safe for concurrent use; that is a heuristic, not something checked.
+        """
+        mutexes: list[dict[str, str]] = []
+        channels: list[dict[str, str]] = []
+        for _, names, field_type in self._field_lines(start, end):
+            if _MUTEX_TYPE_RE.fullmatch(field_type):
+                mutexes.extend({"name": name, "type": field_type} for name in names)
+            elif _CHAN_RE.search(field_type):
+                channels.extend({"name": name, "type": field_type} for name in names)
+        if not (mutexes or channels):
+            return {}
+        return {"mutexes": mutexes, "channels": channels}
+
+    def _interface_methods(self, start: int, end: int) -> list[MethodDoc]:
+        methods: list[MethodDoc] = []
+        for idx in range(start, end):
+            line = self.code[idx].strip()
+            match = _INTERFACE_METHOD_RE.match(line)
+            if match is None:
+                continue
+            methods.append(
+                MethodDoc(
+                    name=match.group("name"),
+                    file=self.path,
+                    line=idx + 1,
+                    end_line=idx + 1,
+                    docstring=_doc(self.raw, idx),
+                    args=_param_names(paren_contents(line)),
+                    signature=" ".join(line.split()),
+                )
+            )
+        return methods
+
+    def _embedded(self, start: int, end: int) -> list[str]:
+        bases: list[str] = []
+        for idx in range(start, end):
+            line = self.code[idx].strip()
+            if re.fullmatch(r"[A-Za-z_][\w.]*(?:\[[^\]]*\])?", line):
+                bases.append(line)
+        return bases
+
+    def _func(self, idx: int, match: re.Match[str]) -> int:
+        end, _ = item_end(self.code, idx)
+        name = match.group("name")
+        receiver = match.group("recv")
+        if not self._keep(name):
+            return end + 1
+        header = header_text(self.code, idx, end)
+        type_params, after = _split_type_params(self.code[idx].strip()[match.end("name") :])
+        params = paren_contents(after)
+        errors = self._errors(idx, end, header)
+        concurrency = self._concurrency(idx, end, header)
+        if receiver is not None:
+            receiver_match = _RECEIVER_TYPE_RE.search(receiver.strip())
+            receiver_type = receiver_match.group("type") if receiver_match else ""
+            if self._keep(receiver_type):
+                self.methods.setdefault(receiver_type, []).append(
+                    MethodDoc(
+                        name=name,
+                        file=self.path,
+                        line=idx + 1,
+                        end_line=end + 1,
+                        docstring=_doc(self.raw, idx),
+                        args=_param_names(params),
+                        signature=header,
+                        errors=errors,
+                        concurrency=concurrency,
+                        private=not (_exported(name) and _exported(receiver_type)),
+                        # `*Stack[T]` keeps the pointer marker and type parameters.
+                        receiver="".join(receiver_match.group(0).split())
+                        if receiver_match
+                        else None,
+                    )
+                )
+            return end + 1
+        self.functions.append(
+            FunctionDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=_doc(self.raw, idx),
+                args=_param_names(params),
+                signature=header,
+                type_params=type_params,
+                errors=errors,
+                concurrency=concurrency,
+                example=self._example(idx, end, name, params),
+                private=not _exported(name),
+            )
+        )
+        return end + 1
+
+    def _keep(self, name: str) -> bool:
+        return bool(name) and (self.include_private or _exported(name))
+
+    def _example(self, idx: int, end: int, name: str, params: str) -> str | None:
+        """Return the dedented body of a runnable example function, or None."""
+        if params.strip() or not self.path.name.endswith("_test.go") or not _EXAMPLE_RE.match(name):
+            return None
+        if end == idx:
+            line = self.raw[idx]
+            body = [line[line.find("{") + 1 : line.rfind("}")].strip()]
+        else:
+            body = self.raw[idx + 1 : end]
+        return textwrap.dedent("\n".join(body)).strip("\n") or None
+
+    def _errors(self, idx: int, end: int, header: str) -> dict[str, object]:
+        """Best-effort failure modes of the function body from `idx` to `end`.
+
+        A function "returns an error" when `error` is among its results and some
+        `return` passes something other than `nil` in that position; closures and
+        unusual control flow can fool this, so it is a hint, not a guarantee.
+        """
+        results = _result_types(header)
+        error_slot = results.index("error") - len(results) if "error" in results else None
+        named_error = bool(re.search(r"\)\s*\([^()]*\b\w+\s+error\b", header))
+        returns_error = panics = False
+        wrapped: list[str] = []
+        line = idx + 1
+        while line < end:
+            code = self.code[line]
+            panics = panics or bool(_PANIC_RE.search(code))
+            for call in _ERRORF_RE.finditer(code):
+                template = _string_argument(self.raw, line, call.end())
+                if template is not None and template not in wrapped:
+                    wrapped.append(template)
+            found = _RETURN_RE.search(code)
+            if found is None or error_slot is None:
+                line += 1
+                continue
+            stop = max(min(statement_end(self.code, line), end - 1), line)
+            text = " ".join(part.strip() for part in self.code[line : stop + 1])
+            values = split_top_level(text[text.find("return") + len("return") :].strip("; "))
+            if values == [""] or not values:
+                # A bare `return` hands back named results, which may hold an error.
+                returns_error = returns_error or named_error
+            elif len(values) >= -error_slot:
+                returns_error = returns_error or values[error_slot].strip() != "nil"
+            line += 1
+        if not (returns_error or panics or wrapped):
+            return {}
+        return {"returns_error": returns_error, "panics": panics, "wrapped": wrapped}
+
+    def _concurrency(self, idx: int, end: int, header: str) -> dict[str, object]:
+        """Best-effort concurrency use of the function from `idx` to `end`.
+
+        Records `go` statements, channel types and operations (in the signature too)
+        and the `Lock`/`RLock` calls made, on any receiver.
+        """
+        body = self.code[idx + 1 : end] if end > idx else [self.code[idx]]
+        goroutines = any(_GO_STMT_RE.search(code) for code in body)
+        channels = bool(_CHAN_RE.search(header)) or any(_CHAN_RE.search(code) for code in body)
+        locks = sorted({match.group("kind") for code in body for match in _LOCK_RE.finditer(code)})
+        if not (goroutines or channels or locks):
+            return {}
+        return {"goroutines": goroutines, "channels": channels, "locks": locks}
+
+
+def _result_types(header: str) -> list[str]:
+    """Return the result types of a `func` header: `(*User, error)` -> `["*User", "error"]`."""
+    match = _FUNC_RE.match(header)
+    if match is None:
+        return []
+    _, rest = _split_type_params(header[match.end("name") :])
+    params = paren_contents(rest)
+    rest = rest[rest.find("(") + len(params) + 2 :].strip().removesuffix("{").strip()
+    if rest.startswith("("):
+        rest = paren_contents(rest)
+    return [part.split()[-1] for part in split_top_level(rest) if part.strip()]
+
+
+def _string_argument(raw: Sequence[str], idx: int, column: int) -> str | None:
+    """Return the string literal starting at `column` of line `idx` (or the next line)."""
+    text = raw[idx][column:]
+    if not text.strip() and idx + 1 < len(raw):
+        text = raw[idx + 1]
+    match = _STRING_LITERAL_RE.match(text)
+    if match is None:
+        return None
+    return match.group("quoted") if match.group("quoted") is not None else match.group("raw")
+
+
+def _split_values(code: str, raw: str) -> list[str]:
+    """Split a spec's value list at top-level commas found in `code`, returning `raw` text."""
+    values: list[str] = []
+    depth = start = 0
+    for pos, char in enumerate(code):
+        depth += {"(": 1, "[": 1, "{": 1, ")": -1, "]": -1, "}": -1}.get(char, 0)
+        if char == "," and depth == 0:
+            values.append(raw[start:pos].strip())
+            start = pos + 1
+    values.append(raw[start:].strip())
+    return [value for value in values if value]
+
+
+def _literal_type(expression: str) -> str | None:
+    """Return the default type of an untyped literal (`"s"` -> `string`), else None."""
+    return next((name for pattern, name in _LITERAL_TYPES if pattern.match(expression)), None)
+
+
+def _iota_value(expression: str, iota: int) -> str | None:
+    """Evaluate integer arithmetic on `iota` (`1 << iota`, `iota + 1`); None otherwise."""
+    if not _IOTA_RE.search(expression):
+        return None
+    text = _IOTA_RE.sub(str(iota), expression).replace("/", "//").replace("_", "")
+    try:
+        return str(_evaluate(ast.parse(text, mode="eval").body))
+    except (SyntaxError, ValueError, ArithmeticError):
+        return None
+
+
+def _evaluate(node: ast.expr) -> int:
+    if isinstance(node, ast.Constant) and type(node.value) is int:
+        return node.value
+    if isinstance(node, ast.UnaryOp) and isinstance(node.op, ast.USub | ast.UAdd):
+        operand = _evaluate(node.operand)
+        return -operand if isinstance(node.op, ast.USub) else operand
+    if isinstance(node, ast.BinOp) and type(node.op) in _IOTA_OPERATORS:
+        left, right = _evaluate(node.left), _evaluate(node.right)
+        if isinstance(node.op, ast.LShift) and right > 256:
+            raise ValueError("shift too large")
+        return _IOTA_OPERATORS[type(node.op)](left, right)
+    raise ValueError("not an integer expression")
+
+
+def _exported(name: str) -> bool:
+    return bool(name) and name[0].isupper()
+
+
+def _parse_tag(tag: str) -> dict[str, str]:
+    return {match.group("key"): match.group("value") for match in _TAG_PAIR_RE.finditer(tag)}
+
+
+def _trailing_comment(raw: str) -> str | None:
+    code, sep, comment = raw.rpartition("//")
+    if not sep or code.count('"') % 2 or code.count("`") % 2:
+        return None
+    return comment.strip() or None
+
+
+def _split_type_params(text: str) -> tuple[list[str], str]:
+    """Split a leading `[T, U any]` clause off `text` as `["T any", "U any"]`.
+
+    Brackets whose groups are all single tokens (`[4]int`, `[N]T`) are array
+    lengths, not type parameters, and are left in place.
+    """
+    stripped = text.lstrip()
+    if not stripped.startswith("["):
+        return [], text
+    depth = 0
+    for pos, char in enumerate(stripped):
+        depth += {"[": 1, "]": -1}.get(char, 0)
+        if depth == 0:
+            break
+    else:
+        return [], text
+    groups = [group.split(None, 1) for group in split_top_level(stripped[1:pos])]
+    if not any(len(group) > 1 for group in groups):
+        return [], text
+    params: list[str] = []
+    pending: list[str] = []
+    for group in groups:
+        if not group:
+            continue
+        pending.append(group[0])
+        if len(group) > 1:
+            # `T, U any` shares the constraint across the names before it.
+            constraint = " ".join(group[1].split())
+            params.extend(f"{param} {constraint}" for param in pending)
+            pending = []
+    return params, stripped[pos + 1 :]
+
+
+def _param_names(params: str) -> list[str]:
+    """Return parameter names; unnamed parameter lists (`(int, error)`) yield none."""
+    parts = [part.split() for part in split_top_level(params)]
+    if not any(len(tokens) > 1 for tokens in parts):
+        return []
+    return [tokens[0] for tokens in parts if tokens]
+
+
+def _doc(raw: Sequence[str], idx: int) -> str | None:
+    return leading_comment(raw, idx, prefixes=("//",), block=None)[0]
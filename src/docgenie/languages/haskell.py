@@ -0,0 +1,612 @@
+"""Haskell parser for type signatures, bindings, data types, classes and instances.
+
+A top-level type signature (`weightedMean :: [Double] -> Double`) is paired with the
+equations that define the name, and the signature is the function's signature; a
+binding without one is still listed. `data`, `newtype` and `type` declarations are
+class-like symbols with their record fields, `class` declarations list their method
+signatures and `instance` declarations, like Rust impls, are named after the instance
+type with the class as base. A `-- |` or `{-| -}` Haddock comment above a declaration
+is its docstring and `-- ^` documents a record field. When the module header has an
+export list, it is the public API and every other name is private; a file without a
+header is the `Main` module, which exports only `main`.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines
+
+# `'` also appears in names such as `foldl'`, so only char literals are blanked.
+_CODE_OPTIONS = {
+    "line_comments": ("--",),
+    "block_comments": (("{-", "-}"),),
+    "quotes": '"',
+    "char_literals": True,
+}
+
+_SYMBOL = r"[!#$%&*+./<=>?@\\^|~:-]"
+_VAR = r"[a-z_][\w']*"
+_OPERATOR = rf"\({_SYMBOL}+\)"
+_MODULE_RE = re.compile(r"^module\s+(?P<name>[A-Z][\w.']*)\s*(?P<rest>.*)$", re.DOTALL)
+_IMPORT_RE = re.compile(
+    r"^import\s+(?:safe\s+)?(?:qualified\s+)?(?:\"[^\"]*\"\s+)?(?P<module>[A-Z][\w.']*)"
+)
+_SIGNATURE_RE = re.compile(
+    rf"^(?P<names>(?:{_VAR}|{_OPERATOR})(?:\s*,\s*(?:{_VAR}|{_OPERATOR}))*)\s*::(?!{_SYMBOL})"
+    r"\s*(?P<type>.*)$",
+    re.DOTALL,
+)
+_TYPE_RE = re.compile(r"^(?P<kind>data|newtype|type|class|instance)\b\s*(?P<rest>.*)$")
+_TYPE_NAME_RE = re.compile(r"^(?P<name>[A-Z][\w']*)")
+_PREFIX_OPERATOR_RE = re.compile(rf"^(?P<name>{_OPERATOR})")
+_NAME_RE = re.compile(rf"^(?P<name>{_VAR})")
+_TOKEN_RE = re.compile(
+    rf"`(?P<infix>{_VAR})`|(?P<op>{_SYMBOL}+)|(?P<open>[(\[{{])|(?P<close>[)\]}}])"
+)
+# `type family`, `data instance` and the like declare no new documented name.
+_SKIPPED_TYPE_FORMS = ("family ", "instance ", "role ")
+_SKIPPED_RE = re.compile(r"^(?:pattern|foreign|infix[lr]?|deriving|default)\b")
+# Infix operators that are patterns, not definitions: `!x`, `~(a, b)`, `xs@(x:_)`.
+_PATTERN_OPERATORS = {"!", "~", "@"}
+_CONTINUATION_RE = re.compile(r"^(?:[)\]},|=]|(?:where|deriving)\b)")
+
+
+class HaskellParser(ParserPlugin):
+    """Extract signatures, bindings, data types, classes and instances from Haskell modules."""
+
+    def __init__(self) -> None:
+        super().__init__(name="haskell", languages={"haskell"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _HaskellWalker(content, path, include_private=self.include_private)
+        walker.walk()
+        return ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports=walker.imports,
+            module_doc=walker.module_doc,
+        )
+
+
+@dataclass
+class _Haddock:
+    text: str | None = None
+    first: int | None = None
+    last: int | None = None
+
+
+@dataclass
+class _Binding:
+    """A name's type signature and defining equations, merged across declarations."""
+
+    name: str
+    line: int
+    end: int
+    signature: str | None = None
+    args: list[str] | None = None
+    defined: bool = False
+
+
+@dataclass
+class _Exports:
+    """A module export list: plain names, and types with their exported members."""
+
+    names: set[str]
+    members: dict[str, set[str] | None]
+
+    def exports(self, name: str, parent: str | None = None) -> bool:
+        if name in self.names:
+            return True
+        if parent is None:
+            return name in self.members
+        members = self.members.get(parent, set())
+        # `Type(..)` exports every member.
+        return parent in self.members and (members is None or name in members)
+
+
+class _HaskellWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = code_lines(content, **_CODE_OPTIONS)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+        self.module_doc: str | None = None
+        self.exports: _Exports | None = None
+
+    def walk(self) -> None:
+        declarations = self._declarations(0, len(self.code) - 1, 0)
+        has_header = False
+        bindings: list[tuple[int, int]] = []
+        for start, end in declarations:
+            text = self._text(start, end)
+            if match := _MODULE_RE.match(text):
+                has_header = True
+                self.module_doc = self._haddock(start).text
+                self.exports = _export_list(match.group("rest"))
+            elif match := _IMPORT_RE.match(text):
+                self.imports.add(match.group("module"))
+            elif match := _TYPE_RE.match(text):
+                self._type(start, end, match.group("kind"), match.group("rest"))
+            elif not _SKIPPED_RE.match(text):
+                bindings.append((start, end))
+        if not has_header:
+            # A module without a header is `module Main (main) where`.
+            self.exports = _Exports(names={"main"}, members={})
+        for binding in self._bindings(bindings):
+            doc = self._binding_doc(binding)
+            function = FunctionDoc(
+                name=binding.name,
+                file=self.path,
+                line=binding.line + 1,
+                end_line=binding.end + 1,
+                docstring=doc.text,
+                args=binding.args or [],
+                signature=binding.signature,
+                doc_line=doc.first,
+                doc_end_line=doc.last,
+                private=not self._exported(binding.name),
+            )
+            if self._keep(function):
+                self.functions.append(function)
+        self.classes = [cls for cls in self.classes if self._keep(cls)]
+
+    def _declarations(self, start: int, stop: int, indent: int) -> list[tuple[int, int]]:
+        """Split lines `start`..`stop` into declarations starting at column `indent`.
+
+        Lines indented further continue the declaration, and so do lines that close a
+        bracket or start with `where`, `deriving`, `|` or `=` at that column.
+        """
+        found: list[tuple[int, int]] = []
+        current: int | None = None
+        last = start
+        depth = 0
+        for idx in range(start, stop + 1):
+            line = self.code[idx]
+            stripped = line.strip()
+            if not stripped or line.startswith("#"):
+                continue
+            column = len(line) - len(line.lstrip())
+            starts = column <= indent and depth <= 0 and not _CONTINUATION_RE.match(stripped)
+            if column < indent:
+                break
+            if starts:
+                if current is not None:
+                    found.append((current, last))
+                current = idx
+                depth = 0
+            depth += sum(line.count(char) for char in "([{") - sum(
+                line.count(char) for char in ")]}"
+            )
+            last = idx
+        if current is not None:
+            found.append((current, last))
+        return found
+
+    def _bindings(self, declarations: list[tuple[int, int]]) -> list[_Binding]:
+        """Merge type signatures with the equations defining the same names, in order."""
+        bindings: dict[str, _Binding] = {}
+        for start, end in declarations:
+            text = self._text(start, end)
+            if signature := _SIGNATURE_RE.match(text):
+                type_text = " ".join(signature.group("type").split())
+                for name in re.findall(rf"{_VAR}|{_OPERATOR}", signature.group("names")):
+                    binding = bindings.setdefault(name, _Binding(name, start, end))
+                    binding.line, binding.end = min(binding.line, start), max(binding.end, end)
+                    binding.signature = f"{name} :: {type_text}"
+                continue
+            equation = _equation(text)
+            if equation is None:
+                continue
+            name, args = equation
+            binding = bindings.setdefault(name, _Binding(name, start, end))
+            binding.end = max(binding.end, end)
+            binding.line = min(binding.line, start)
+            if not binding.defined:
+                binding.args = args
+                binding.defined = True
+        return list(bindings.values())
+
+    def _type(self, start: int, end: int, kind: str, rest: str) -> None:
+        if rest.startswith(_SKIPPED_TYPE_FORMS):
+            return
+        head = _split_keyword(rest, "where")[0]
+        if kind == "instance":
+            self._instance(start, end, head)
+            return
+        # The context ends before the constructors, whose fields may have their own.
+        context, _, declared = (_left_hand_side(head) or head).rpartition("=>")
+        name_match = _TYPE_NAME_RE.match(declared.strip())
+        if name_match is None:
+            return
+        name = name_match.group("name")
+        doc = self._haddock(start)
+        methods: list[MethodDoc] = []
+        fields: list[FieldDoc] = []
+        decorators: list[str] = []
+        bases: list[str] = []
+        signature = f"{kind} {head}"
+        if kind == "class":
+            bases = _constraint_classes(context)
+            methods = self._methods(start, end, parent=name)
+        elif kind in {"data", "newtype"}:
+            head, *derivings = _split_keyword(head, "deriving")
+            decorators = [f"deriving {clause}" for clause in derivings]
+            fields = self._fields(start, end)
+            signature = f"{kind} {_collapse_records(head)}"
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=start + 1,
+                end_line=end + 1,
+                docstring=doc.text,
+                bases=bases,
+                decorators=decorators,
+                methods=methods,
+                kind=kind,
+                signature=signature,
+                fields=fields,
+                doc_line=doc.first,
+                doc_end_line=doc.last,
+                private=not self._exported(name),
+            )
+        )
+
+    def _instance(self, start: int, end: int, head: str) -> None:
+        """Record `instance Show Shape` as class-like `Shape` with base `Show`."""
+        instance = head.rpartition("=>")[2].strip()
+        parts = instance.split(None, 1)
+        if len(parts) < 2:
+            return
+        target = parts[1].strip().lstrip("(").strip()
+        name_match = _TYPE_NAME_RE.match(target) or re.match(r"^(?P<name>\S+)", target)
+        if name_match is None:
+            return
+        doc = self._haddock(start)
+        self.classes.append(
+            ClassDoc(
+                name=name_match.group("name"),
+                file=self.path,
+                line=start + 1,
+                end_line=end + 1,
+                docstring=doc.text,
+                bases=[parts[0]],
+                # Instances are global in Haskell, so their methods are public API.
+                methods=self._methods(start, end, parent=None),
+                kind="instance",
+                signature=f"instance {head}",
+                doc_line=doc.first,
+                doc_end_line=doc.last,
+            )
+        )
+
+    def _methods(self, start: int, end: int, *, parent: str | None) -> list[MethodDoc]:
+        """Return the bindings in a `where` body; a class method without one is abstract."""
+        body = self._where_body(start, end)
+        if body is None:
+            return []
+        first, indent = body
+        methods: list[MethodDoc] = []
+        for binding in self._bindings(self._declarations(first, end, indent)):
+            doc = self._binding_doc(binding)
+            private = parent is not None and not self._exported(binding.name, parent)
+            method = MethodDoc(
+                name=binding.name,
+                file=self.path,
+                line=binding.line + 1,
+                end_line=binding.end + 1,
+                docstring=doc.text,
+                args=binding.args or [],
+                kind="method" if binding.defined else "abstract_method",
+                signature=binding.signature,
+                doc_line=doc.first,
+                doc_end_line=doc.last,
+                private=private,
+            )
+            if self._keep(method):
+                methods.append(method)
+        return methods
+
+    def _fields(self, start: int, end: int) -> list[FieldDoc]:
+        """Return the record fields of a `data`/`newtype` declaration."""
+        fields: list[FieldDoc] = []
+        seen: set[str] = set()
+        pending: list[tuple[str, int]] = []
+        for entry, line in self._record_entries(start, end):
+            names, separator, type_text = entry.partition("::")
+            pending.extend((name.strip(), line) for name in names.split(",") if name.strip())
+            if not separator:
+                continue
+            for name, name_line in pending:
+                if name in seen or not re.fullmatch(_VAR, name):
+                    continue
+                seen.add(name)
+                doc = self._field_doc(name_line)
+                fields.append(
+                    FieldDoc(name=name, type=" ".join(type_text.split()), docstring=doc)
+                )
+            pending = []
+        return fields
+
+    def _record_entries(self, start: int, end: int) -> list[tuple[str, int]]:
+        """Return each comma-separated entry of the declaration's `{ }` records, with its line."""
+        entries: list[tuple[str, int]] = []
+        stack: list[str] = []
+        current: list[str] = []
+        line_of_entry = start
+        for idx in range(start, end + 1):
+            for char in self.code[idx]:
+                in_record = stack[:1] == ["{"]
+                if char in "([{":
+                    stack.append(char)
+                    if stack == ["{"]:
+                        current = []
+                        continue
+                elif char in ")]}" and stack:
+                    stack.pop()
+                    if not stack and in_record:
+                        entries.append(("".join(current), line_of_entry))
+                        continue
+                if stack == ["{"] and char == ",":
+                    entries.append(("".join(current), line_of_entry))
+                    current = []
+                    continue
+                if in_record:
+                    if not "".join(current).strip() and not char.isspace():
+                        line_of_entry = idx
+                    current.append(char)
+            if stack[:1] == ["{"]:
+                current.append(" ")
+        return [(entry, line) for entry, line in entries if entry.strip()]
+
+    def _field_doc(self, idx: int) -> str | None:
+        """Return a field's `-- ^` comment on its line, or the `-- |` comment above it."""
+        comment = [self.raw[idx][len(self.code[idx].rstrip()) :].strip()]
+        # The comment may also start on the line below the field.
+        for raw, code in zip(self.raw[idx + 1 :], self.code[idx + 1 :]):
+            if code.strip() or not raw.strip().startswith("--"):
+                break
+            comment.append(raw.strip())
+        comment = [line for line in comment if line]
+        if comment and re.match(r"^--\s*\^", comment[0]):
+            lines = [re.sub(r"^--\s*\^?", "", comment[0])]
+            for line in comment[1:]:
+                if re.match(r"^--\s*[|^]", line):
+                    break
+                lines.append(line[2:])
+            return " ".join(" ".join(lines).split()) or None
+        return self._haddock(idx).text
+
+    def _where_body(self, start: int, end: int) -> tuple[int, int] | None:
+        """Return the first line and indentation of a declaration's `where` body."""
+        for idx in range(start, end + 1):
+            if not re.search(r"\bwhere\s*$", self.code[idx]):
+                continue
+            body = next(
+                (line for line in range(idx + 1, end + 1) if self.code[line].strip()), None
+            )
+            if body is None:
+                return None
+            return body, len(self.code[body]) - len(self.code[body].lstrip())
+        return None
+
+    def _binding_doc(self, binding: _Binding) -> _Haddock:
+        return self._haddock(binding.line)
+
+    def _haddock(self, idx: int) -> _Haddock:
+        """Return the `-- |` or `{-| -}` comment directly above line `idx`, if any.
+
+        Pragmas such as `{-# INLINE f #-}` may sit between the comment and the line.
+        """
+        above = idx - 1
+        while above >= 0 and self.raw[above].strip().startswith("{-#"):
+            above -= 1
+        if above < 0:
+            return _Haddock()
+        last = self.raw[above].strip()
+        if last.endswith("-}") and not last.endswith("#-}"):
+            first = above
+            while first >= 0 and "{-" not in self.raw[first]:
+                first -= 1
+            if first < 0 or not re.search(r"\{-\s*\|", self.raw[first]):
+                return _Haddock()
+            lines = [line.strip() for line in self.raw[first : above + 1]]
+            lines[0] = re.sub(r"^\{-\s*\|", "", lines[0])
+            lines[-1] = lines[-1].removesuffix("-}")
+            return _Haddock(_dedent(lines), first + 1, above + 1)
+        comment: list[str] = []
+        first = above
+        while first >= 0 and self.raw[first].strip().startswith("--"):
+            comment.insert(0, self.raw[first].strip())
+            first -= 1
+        # Plain comments may precede the Haddock one; it starts at the last `-- |`.
+        starts = [pos for pos, line in enumerate(comment) if re.match(r"^--\s*\|", line)]
+        if not starts:
+            return _Haddock()
+        comment = comment[starts[-1] :]
+        comment[0] = re.sub(r"^--\s*\|", "--", comment[0])
+        text = _dedent([line[2:] for line in comment])
+        return _Haddock(text, first + 2 + starts[-1], above + 1)
+
+    def _exported(self, name: str, parent: str | None = None) -> bool:
+        return self.exports is None or self.exports.exports(name, parent)
+
+    def _keep(self, symbol: FunctionDoc | ClassDoc) -> bool:
+        return self.include_private or not symbol.private
+
+    def _text(self, start: int, end: int) -> str:
+        """Join lines `start`..`end` of comment-free code onto one line."""
+        return " ".join(" ".join(self.code[start : end + 1]).split())
+
+
+def _equation(text: str) -> tuple[str, list[str]] | None:
+    """Return the name an equation defines and its argument patterns.
+
+    Handles prefix (`area (Circle r) = ...`), operator (`(<+>) a b = ...`) and infix
+    (`a <+> b = ...`, ``x `plus` y = ...``) definitions; pattern bindings such as
+    `(a, b) = ...` define no single name and give None.
+    """
+    lhs = _left_hand_side(text)
+    if lhs is None:
+        return None
+    if operator := _PREFIX_OPERATOR_RE.match(lhs):
+        return operator.group("name"), _atoms(lhs[operator.end() :])
+    depth = 0
+    for token in _TOKEN_RE.finditer(lhs):
+        if token.group("open"):
+            depth += 1
+        elif token.group("close"):
+            depth -= 1
+        elif depth == 0 and token.group("infix"):
+            left, right = lhs[: token.start()], lhs[token.end() :]
+            return token.group("infix"), [*_atoms(left), *_atoms(right)]
+        elif depth == 0 and token.group("op") not in _PATTERN_OPERATORS:
+            spaced = lhs[token.start() - 1 : token.start()].isspace() and lhs[
+                token.end() : token.end() + 1
+            ].isspace()
+            if spaced:
+                left, right = lhs[: token.start()], lhs[token.end() :]
+                return f"({token.group('op')})", [*_atoms(left), *_atoms(right)]
+    name = _NAME_RE.match(lhs)
+    if name is None:
+        return None
+    return name.group("name"), _atoms(lhs[name.end() :])
+
+
+def _left_hand_side(text: str) -> str | None:
+    """Return the text before an equation's `=` or first guard, or None if it has neither."""
+    depth = 0
+    for token in _TOKEN_RE.finditer(text):
+        if token.group("open"):
+            depth += 1
+        elif token.group("close"):
+            depth -= 1
+        elif depth == 0 and token.group("op") in {"=", "|"}:
+            return text[: token.start()].strip()
+    return None
+
+
+def _atoms(text: str) -> list[str]:
+    """Split argument patterns on spaces outside brackets: `(x:xs) acc` -> two atoms."""
+    atoms: list[str] = []
+    current: list[str] = []
+    depth = 0
+    for char in text.strip():
+        if char in "([{":
+            depth += 1
+        elif char in ")]}":
+            depth -= 1
+        if char.isspace() and depth <= 0:
+            if current:
+                atoms.append("".join(current))
+                current = []
+            continue
+        current.append(char)
+    if current:
+        atoms.append("".join(current))
+    return atoms
+
+
+def _split_keyword(text: str, keyword: str) -> list[str]:
+    """Split `text` on `keyword` where it appears outside brackets."""
+    parts: list[str] = []
+    depth = 0
+    start = 0
+    for match in re.finditer(rf"[(\[{{]|[)\]}}]|\b{keyword}\b", text):
+        token = match.group()
+        if token in "([{":
+            depth += 1
+        elif token in ")]}":
+            depth -= 1
+        elif depth == 0:
+            parts.append(text[start : match.start()].strip())
+            start = match.end()
+    parts.append(text[start:].strip())
+    return parts
+
+
+def _collapse_records(text: str) -> str:
+    """Shorten each `{ field :: Type, ... }` record body to `{..}`."""
+    out: list[str] = []
+    depth = 0
+    for char in text:
+        if char == "{":
+            depth += 1
+            if depth == 1:
+                out.append("{..}")
+            continue
+        if char == "}":
+            depth -= 1
+            continue
+        if depth == 0:
+            out.append(char)
+    return " ".join("".join(out).split())
+
+
+def _constraint_classes(context: str) -> list[str]:
+    """Return the class names of a `(Eq a, Show a) =>` context."""
+    context = context.strip()
+    if context.startswith("(") and context.endswith(")"):
+        context = context[1:-1]
+    return [part.split()[0] for part in _split_commas(context) if part.split()]
+
+
+def _export_list(rest: str) -> _Exports | None:
+    """Parse the `( ... ) where` after a module name; None when there is no export list."""
+    rest = rest.strip()
+    if not rest.startswith("("):
+        return None
+    depth = 0
+    for end, char in enumerate(rest):
+        depth += {"(": 1, ")": -1}.get(char, 0)
+        if depth == 0:
+            break
+    names: set[str] = set()
+    members: dict[str, set[str] | None] = {}
+    for item in _split_commas(rest[1:end]):
+        item = re.sub(r"^(?:type|pattern)\s+", "", item.strip())
+        if item.startswith("module ") or not item:
+            continue
+        if _PREFIX_OPERATOR_RE.fullmatch("".join(item.split())):
+            names.add("".join(item.split()))
+            continue
+        name, _, subordinates = item.partition("(")
+        name = name.strip()
+        if not subordinates:
+            names.add(name)
+            continue
+        listed = subordinates.rsplit(")", 1)[0].strip()
+        members[name] = None if listed == ".." else {
+            "".join(member.split()) for member in _split_commas(listed)
+        }
+    return _Exports(names=names, members=members)
+
+
+def _split_commas(text: str) -> list[str]:
+    """Split on commas outside brackets; unlike `split_top_level`, `<` and `>` are operators."""
+    parts: list[str] = []
+    current: list[str] = []
+    depth = 0
+    for char in text:
+        depth += {"(": 1, "[": 1, "{": 1, ")": -1, "]": -1, "}": -1}.get(char, 0)
+        if char == "," and depth == 0:
+            parts.append("".join(current).strip())
+            current = []
+            continue
+        current.append(char)
+    parts.append("".join(current).strip())
+    return [part for part in parts if part]
+
+
+def _dedent(lines: list[str]) -> str | None:
+    """Join comment lines, dropping the one space that follows each comment marker."""
+    text = "\n".join(line.removeprefix(" ").rstrip() for line in lines).strip()
+    return text or None
@@ -0,0 +1,191 @@
+"""Java parser for types, methods, Javadoc and annotations."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    heritage,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    with_doc_ranges,
+)
+
+_MODIFIER_WORDS = (
+    "public|protected|private|static|final|abstract|sealed|non-sealed|strictfp|"
+    "default|synchronized|native|transient|volatile"
+)
+_MODIFIERS = rf"(?:(?:{_MODIFIER_WORDS})\s+)*"
+_TYPE_RE = re.compile(
+    rf"^{_MODIFIERS}(?P<kind>class|interface|enum|record|@interface)\s+(?P<name>[A-Za-z_$][\w$]*)"
+)
+_METHOD_NAME_RE = re.compile(r"(?P<name>[A-Za-z_$][\w$]*)\s*$")
+_LEADING_ANNOTATIONS_RE = re.compile(r"^(?:@[\w.]+(?:\([^)]*\))?\s+)+")
+_ANNOTATION_RE = re.compile(r"@[\w.]+(?:\([^)]*\))?")
+_ANNOTATION_ONLY_RE = re.compile(r"@[\w.]+(?:\(.*\))?")
+_IMPORT_RE = re.compile(r"^\s*import\s+(?:static\s+)?(?P<name>[\w.*]+)\s*;", re.MULTILINE)
+_PRIVATE_RE = re.compile(rf"^(?:(?:{_MODIFIER_WORDS})\s+)*?private\b")
+_NON_METHOD_WORDS = {"new", "return", "throw", "else", "case", "assert", "yield"}
+
+_KIND_NAMES = {"@interface": "annotation"}
+
+
+class JavaParser(ParserPlugin):
+    """Extract classes, interfaces, enums, records and their methods from Java sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="java", languages={"java"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _JavaWalker(content, path, include_private=self.include_private)
+        walker.walk_types(0, len(walker.code), depth=0, owner=None)
+        result = ParseResult(
+            classes=walker.classes,
+            imports={match.group("name") for match in _IMPORT_RE.finditer(content)},
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=(),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+
+
+class _JavaWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = code_lines(content)
+        self.depths = brace_depths(self.code)
+        self.classes: list[ClassDoc] = []
+
+    def walk_types(self, start: int, stop: int, *, depth: int, owner: str | None) -> None:
+        """Record every type declared at `depth` between `start` and `stop`."""
+        idx = start
+        while idx < stop:
+            if self.depths[idx] != depth:
+                idx += 1
+                continue
+            line, inline_annotations = _strip_annotations(self.code[idx].strip())
+            match = _TYPE_RE.match(line)
+            if match is None:
+                idx += 1
+                continue
+            end, has_body = item_end(self.code, idx)
+            header = _LEADING_ANNOTATIONS_RE.sub("", header_text(self.code, idx, end))
+            doc, annotations = _javadoc(self.raw, idx)
+            name = match.group("name") if owner is None else f"{owner}.{match.group('name')}"
+            kind = match.group("kind")
+            self.classes.append(
+                ClassDoc(
+                    name=name,
+                    file=self.path,
+                    line=idx + 1,
+                    end_line=end + 1,
+                    docstring=doc,
+                    bases=heritage(header),
+                    decorators=annotations + inline_annotations,
+                    methods=self._methods(idx, end, depth + 1, match.group("name"))
+                    if has_body
+                    else [],
+                    kind=_KIND_NAMES.get(kind, kind),
+                    signature=header,
+                    private=_PRIVATE_RE.match(line) is not None,
+                )
+            )
+            if has_body:
+                self.walk_types(idx + 1, end, depth=depth + 1, owner=name)
+            idx = end + 1
+
+    def _methods(self, start: int, end: int, depth: int, type_name: str) -> list[MethodDoc]:
+        methods: list[MethodDoc] = []
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, inline_annotations = _strip_annotations(self.code[idx].strip())
+            if _ANNOTATION_ONLY_RE.fullmatch(line):
+                idx += 1
+                continue
+            if _TYPE_RE.match(line):
+                # Nested types are walked separately; skip over their bodies here.
+                idx = item_end(self.code, idx)[0] + 1
+                continue
+            member_end, _ = item_end(self.code, idx)
+            header = _LEADING_ANNOTATIONS_RE.sub("", header_text(self.code, idx, member_end))
+            name = _method_name(header, type_name)
+            private = _PRIVATE_RE.match(header) is not None
+            if name is None or (private and not self.include_private):
+                idx = max(member_end, idx) + 1
+                continue
+            doc, annotations = _javadoc(self.raw, idx)
+            methods.append(
+                MethodDoc(
+                    name=name,
+                    file=self.path,
+                    line=idx + 1,
+                    end_line=member_end + 1,
+                    docstring=doc,
+                    args=_param_names(paren_contents(header[header.find(name) + len(name) :])),
+                    decorators=annotations + inline_annotations,
+                    kind="constructor" if name == type_name else "method",
+                    signature=header,
+                    private=private,
+                )
+            )
+            idx = member_end + 1
+        return methods
+
+
+def _method_name(header: str, type_name: str) -> str | None:
+    """Return the declared method name, or None for fields, constants and blocks."""
+    before_params = header.split("(", 1)[0].strip() if "(" in header else ""
+    if not before_params or "=" in before_params:
+        return None
+    match = _METHOD_NAME_RE.search(before_params)
+    if match is None:
+        return None
+    name = match.group("name")
+    prefix = before_params[: match.start()].strip()
+    if name in _NON_METHOD_WORDS or any(word in _NON_METHOD_WORDS for word in prefix.split()):
+        return None
+    # Enum constants (`RED("r"),`) and calls have no return type or modifier in front.
+    if not prefix and name != type_name:
+        return None
+    return name
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(params):
+        cleaned = _ANNOTATION_RE.sub("", param).replace("...", " ").strip()
+        parts = cleaned.split()
+        if parts:
+            names.append(parts[-1].rstrip("[]"))
+    return names
+
+
+def _strip_annotations(line: str) -> tuple[str, list[str]]:
+    match = _LEADING_ANNOTATIONS_RE.match(line)
+    if match is None:
+        return line, []
+    return line[match.end() :], _ANNOTATION_RE.findall(match.group(0))
+
+
+def _javadoc(raw: Sequence[str], idx: int) -> tuple[str | None, list[str]]:
+    return leading_comment(
+        raw, idx, prefixes=(), block=("/**", "*/"), skip=lambda line: line.startswith("@")
+    )
+
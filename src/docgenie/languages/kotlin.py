@@ -0,0 +1,347 @@
+"""Kotlin parser for classes, objects, functions and KDoc comments."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    statement_end,
+    with_doc_ranges,
+)
+
+_MODIFIER_WORDS = (
+    "public|protected|private|internal|open|final|abstract|sealed|data|enum|annotation|inner|"
+    "value|inline|override|suspend|operator|infix|tailrec|external|lateinit|const|expect|actual|"
+    "companion"
+)
+_MODIFIERS = rf"(?:(?:{_MODIFIER_WORDS})\s+)*"
+_ANNOTATION = r"@[\w.:]+(?:\((?:\"[^\"]*\"|[^)\"])*\))?"
+_LEADING_ANNOTATIONS_RE = re.compile(rf"^(?:{_ANNOTATION}\s+)+")
+_ANNOTATION_RE = re.compile(_ANNOTATION)
+_TYPE_RE = re.compile(
+    rf"^(?P<mods>{_MODIFIERS})(?P<kind>class|interface|object|fun\s+interface)\b\s*"
+    r"(?P<name>[A-Za-z_]\w*)?"
+)
+_FUN_RE = re.compile(
+    rf"^(?P<mods>{_MODIFIERS})fun\s+(?:<[^>]*(?:<[^>]*>[^>]*)*>\s*)?"
+    r"(?:(?P<receiver>[\w.]+(?:<.*?>)?\??)\.)?(?P<name>[A-Za-z_]\w*|`[^`]+`)\s*\("
+)
+_CONSTRUCTOR_RE = re.compile(rf"^{_MODIFIERS}constructor\s*\(")
+_CTOR_PREFIX_RE = re.compile(rf"^\s*(?:{_ANNOTATION}\s+)*(?:{_MODIFIERS})?constructor\s*")
+_CTOR_PROPERTY_RE = re.compile(
+    rf"^(?P<mods>{_MODIFIERS})(?:val|var)\s+(?P<name>\w+)\s*:\s*(?P<type>[^=]+?)\s*(?:=.*)?$"
+)
+_PROPERTY_TAG_RE = re.compile(r"^@property\s+(?P<name>\w+)\s*(?P<text>.*)$")
+_IMPORT_RE = re.compile(r"^\s*import\s+(?P<name>[\w.*`]+)", re.MULTILINE)
+_HIDDEN_RE = re.compile(r"\bprivate\b")
+
+COMPANION_KIND = "companion_method"
+
+
+class KotlinParser(ParserPlugin):
+    """Extract classes, objects, interfaces and functions from Kotlin sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="kotlin", languages={"kotlin"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _KotlinWalker(content, path)
+        walker.walk(0, len(walker.code), depth=0, owner=None)
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports={match.group("name") for match in _IMPORT_RE.finditer(content)},
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=(),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+
+
+class _KotlinWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        self.code = code_lines(content, quotes='"', char_literals=True)
+        self.depths = brace_depths(self.code)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+
+    def walk(self, start: int, stop: int, *, depth: int, owner: str | None) -> None:
+        """Record types declared at `depth` and, at the top level, functions."""
+        idx = start
+        while idx < stop:
+            if self.depths[idx] != depth:
+                idx += 1
+                continue
+            line, inline_annotations = _strip_annotations(self.code[idx].strip())
+            if not line:
+                idx += 1
+                continue
+            end = statement_end(self.code, idx, question_continues=False)
+            if _is_hidden(line):
+                idx = end + 1
+                continue
+            type_match = _TYPE_RE.match(line)
+            fun_match = _FUN_RE.match(line)
+            if type_match is not None and type_match.group("name") and not _is_companion(
+                type_match
+            ):
+                self._handle_type(type_match, idx, end, depth, owner, inline_annotations)
+            elif owner is None and fun_match is not None:
+                self.functions.append(
+                    self._function(FunctionDoc, fun_match, idx, end, inline_annotations)
+                )
+            idx = end + 1
+
+    def _handle_type(
+        self,
+        match: re.Match[str],
+        idx: int,
+        end: int,
+        depth: int,
+        owner: str | None,
+        inline_annotations: list[str],
+    ) -> None:
+        header = _LEADING_ANNOTATIONS_RE.sub("", header_text(self.code, idx, end))
+        doc, annotations = self._kdoc(idx)
+        name = str(match.group("name"))
+        qualified = name if owner is None else f"{owner}.{name}"
+        header_match = _TYPE_RE.match(header)
+        params, supertypes = _split_header(header, header_match.end() if header_match else 0)
+        has_body = "{" in "".join(self.code[idx : end + 1])
+        methods = self._members(idx, end, depth + 1) if has_body else []
+        self.classes.append(
+            ClassDoc(
+                name=qualified,
+                file=self.path,
+                line=idx + 1,
+                docstring=_strip_property_tags(doc),
+                bases=supertypes,
+                decorators=annotations + inline_annotations,
+                methods=methods,
+                kind=_kind(match),
+                signature=header,
+                fields=_constructor_fields(params, doc),
+                end_line=end + 1,
+            )
+        )
+        if has_body:
+            self.walk(idx + 1, end, depth=depth + 1, owner=qualified)
+
+    def _members(
+        self, start: int, end: int, depth: int, *, kind: str | None = None
+    ) -> list[MethodDoc]:
+        methods: list[MethodDoc] = []
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, inline_annotations = _strip_annotations(self.code[idx].strip())
+            if not line:
+                idx += 1
+                continue
+            member_end = statement_end(self.code, idx, question_continues=False)
+            type_match = _TYPE_RE.match(line)
+            fun_match = _FUN_RE.match(line)
+            if _is_hidden(line):
+                pass
+            elif type_match is not None and _is_companion(type_match):
+                # Companion members are called through the owning class, so list them there.
+                methods.extend(self._members(idx, member_end, depth + 1, kind=COMPANION_KIND))
+            elif fun_match is not None:
+                methods.append(
+                    self._function(
+                        MethodDoc, fun_match, idx, member_end, inline_annotations, kind=kind
+                    )
+                )
+            elif _CONSTRUCTOR_RE.match(line):
+                methods.append(self._constructor(idx, member_end, inline_annotations))
+            idx = member_end + 1
+        return methods
+
+    def _function(
+        self,
+        doc_type: type[FunctionDoc],
+        match: re.Match[str],
+        idx: int,
+        end: int,
+        inline_annotations: list[str],
+        *,
+        kind: str | None = None,
+    ) -> FunctionDoc:
+        header = _LEADING_ANNOTATIONS_RE.sub("", _signature(self.code, idx, end))
+        doc, annotations = self._kdoc(idx)
+        name = match.group("name").strip("`")
+        receiver = match.group("receiver")
+        kind = kind or doc_type.kind
+        if receiver:
+            name = f"{_strip_generics(receiver)}.{name}"
+            kind = "extension_function"
+        return doc_type(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            docstring=doc,
+            args=_param_names(paren_contents(header[header.find("(", match.start("name")) :])),
+            decorators=annotations + inline_annotations,
+            is_async=bool(re.search(r"\bsuspend\b", match.group("mods"))),
+            kind=kind,
+            signature=header,
+            end_line=end + 1,
+        )
+
+    def _constructor(self, idx: int, end: int, inline_annotations: list[str]) -> MethodDoc:
+        header = _LEADING_ANNOTATIONS_RE.sub("", _signature(self.code, idx, end))
+        doc, annotations = self._kdoc(idx)
+        return MethodDoc(
+            name="constructor",
+            file=self.path,
+            line=idx + 1,
+            docstring=doc,
+            args=_param_names(paren_contents(header)),
+            decorators=annotations + inline_annotations,
+            kind="constructor",
+            signature=header,
+            end_line=end + 1,
+        )
+
+    def _kdoc(self, idx: int) -> tuple[str | None, list[str]]:
+        doc, annotations = leading_comment(
+            self.raw, idx, prefixes=(), block=("/**", "*/"), skip=lambda line: line.startswith("@")
+        )
+        return doc, [found for line in annotations for found in _ANNOTATION_RE.findall(line)]
+
+
+def _is_hidden(line: str) -> bool:
+    prefix = re.match(_MODIFIERS, line)
+    return bool(prefix and _HIDDEN_RE.search(prefix.group(0)))
+
+
+def _is_companion(match: re.Match[str]) -> bool:
+    return match.group("kind") == "object" and "companion" in match.group("mods").split()
+
+
+def _kind(match: re.Match[str]) -> str:
+    kind = " ".join(match.group("kind").split())
+    modifiers = match.group("mods").split()
+    for modifier in ("data", "enum", "sealed", "annotation", "value"):
+        if modifier in modifiers and kind in ("class", "interface"):
+            return f"{modifier} {kind}"
+    return kind
+
+
+def _split_header(header: str, name_end: int) -> tuple[str | None, list[str]]:
+    """Return the primary constructor parameter list and the supertypes of a class header."""
+    rest = header[name_end:]
+    if rest.startswith("<"):
+        depth = 0
+        for idx, char in enumerate(rest):
+            depth += {"<": 1, ">": -1}.get(char, 0)
+            if depth == 0:
+                rest = rest[idx + 1 :]
+                break
+    params: str | None = None
+    prefix = _CTOR_PREFIX_RE.match(rest)
+    if prefix is not None:
+        rest = rest[prefix.end() :]
+    if rest.lstrip().startswith("("):
+        params = paren_contents(rest)
+        rest = rest[rest.find("(") + len(params) + 2 :]
+    rest = rest.strip()
+    if not rest.startswith(":"):
+        return params, []
+    listed = re.split(r"\s+where\s+", rest[1:], maxsplit=1)[0]
+    # `Base(arg)` calls the superclass constructor; only the type name is a base.
+    return params, [_strip_call(item) for item in split_top_level(listed) if item]
+
+
+def _constructor_fields(params: str | None, doc: str | None) -> list[FieldDoc]:
+    """Turn `val`/`var` primary constructor parameters into fields documented by `@property`."""
+    if params is None:
+        return []
+    notes = _property_notes(doc)
+    fields: list[FieldDoc] = []
+    for param in split_top_level(params):
+        match = _CTOR_PROPERTY_RE.match(_LEADING_ANNOTATIONS_RE.sub("", param.strip()))
+        if match is None or _HIDDEN_RE.search(match.group("mods")):
+            continue
+        fields.append(
+            FieldDoc(
+                name=match.group("name"),
+                type=match.group("type"),
+                docstring=notes.get(match.group("name")),
+            )
+        )
+    return fields
+
+
+def _property_notes(doc: str | None) -> dict[str, str]:
+    notes: dict[str, str] = {}
+    for line in (doc or "").splitlines():
+        match = _PROPERTY_TAG_RE.match(line.strip())
+        if match is not None:
+            notes[match.group("name")] = match.group("text").strip() or ""
+    return notes
+
+
+def _strip_property_tags(doc: str | None) -> str | None:
+    if doc is None:
+        return None
+    kept = [line for line in doc.splitlines() if not _PROPERTY_TAG_RE.match(line.strip())]
+    return "\n".join(kept).strip() or None
+
+
+def _signature(code: Sequence[str], start: int, end: int) -> str:
+    """Collapse a function header, dropping an expression body (`= ...`) or block."""
+    header = header_text(code, start, end)
+    depth = 0
+    for idx, char in enumerate(header):
+        previous = header[idx - 1 : idx]
+        if char in "(<[":
+            depth += 1
+        elif char in ")]" or (char == ">" and previous != "-"):
+            depth -= 1
+        elif char == "=" and depth <= 0 and previous not in ("!", "<", ">", "="):
+            return header[:idx].rstrip()
+    return header
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(params):
+        cleaned = _LEADING_ANNOTATIONS_RE.sub("", param.strip())
+        cleaned = re.sub(rf"^{_MODIFIERS}(?:vararg\s+|val\s+|var\s+)*", "", cleaned)
+        name = cleaned.split(":", 1)[0].strip()
+        if name:
+            names.append(name)
+    return names
+
+
+def _strip_annotations(line: str) -> tuple[str, list[str]]:
+    match = _LEADING_ANNOTATIONS_RE.match(line)
+    if match is None:
+        return ("", []) if _ANNOTATION_RE.fullmatch(line) else (line, [])
+    return line[match.end() :], _ANNOTATION_RE.findall(match.group(0))
+
+
+def _strip_call(base: str) -> str:
+    return re.sub(r"\s*\(.*\)$", "", base.strip())
+
+
+def _strip_generics(name: str) -> str:
+    return re.split(r"[<?]", name, maxsplit=1)[0]
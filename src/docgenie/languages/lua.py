@@ -0,0 +1,267 @@
+"""Lua parser for functions, module tables and `:` methods.
+
+Functions stored in a table (`function M.load()`, `M.load = function()`,
+`function Widget:draw()` or a `load = function()` field of the table constructor)
+are grouped under that table, which is reported as a class-like symbol. When a
+file ends with `return M`, the returned table is the module's public API and every
+other declaration is private; `return { load = load }` exports the listed locals.
+Without a return, `local` declarations are private and globals are public. Names
+starting with `_` are private either way.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field, replace
+from pathlib import Path
+
+from ..models import ClassDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines, leading_comment, paren_contents, split_top_level, with_doc_ranges
+
+# Long strings share the `[[ ... ]]` brackets of long comments; blanking them too keeps
+# keywords inside them out of the block count.
+_CODE_OPTIONS = {
+    "line_comments": ("--",),
+    "block_comments": (
+        ("--[==[", "]==]"),
+        ("--[=[", "]=]"),
+        ("--[[", "]]"),
+        ("[==[", "]==]"),
+        ("[=[", "]=]"),
+        ("[[", "]]"),
+    ),
+}
+
+_NAME = r"[A-Za-z_]\w*"
+_FUNCTION_RE = re.compile(
+    rf"^(?P<local>local\s+)?function\s+(?P<name>{_NAME}(?:\.{_NAME})*)"
+    rf"(?::(?P<method>{_NAME}))?\s*\("
+)
+_ASSIGNED_RE = re.compile(
+    rf"^(?P<local>local\s+)?(?P<name>{_NAME}(?:\.{_NAME})*)\s*=\s*function\s*\("
+)
+_TABLE_RE = re.compile(
+    rf"^(?P<local>local\s+)?(?P<name>{_NAME}(?:\.{_NAME})*)\s*=\s*(?:\{{|setmetatable\s*\()"
+)
+_FIELD_FUNCTION_RE = re.compile(rf"^(?P<name>{_NAME})\s*=\s*function\s*\(")
+_INDEX_RE = re.compile(rf"__index\s*=\s*(?P<base>{_NAME}(?:\.{_NAME})*)")
+_RETURN_NAME_RE = re.compile(rf"^return\s+(?P<name>{_NAME})\s*;?$")
+_RETURN_TABLE_RE = re.compile(r"^return\s*\{")
+_EXPORTED_RE = re.compile(rf"^(?:{_NAME}\s*=\s*)?(?P<value>{_NAME})$")
+_REQUIRE_RE = re.compile(r"""\brequire\s*\(?\s*["'](?P<module>[^"']+)["']""")
+
+# `elseif ... then` continues an `if` block, so its `then` must not open another one.
+_OPENER_RE = re.compile(r"\b(?:function|do|then|repeat)\b")
+_CLOSER_RE = re.compile(r"\b(?:end|until|elseif)\b")
+
+
+class LuaParser(ParserPlugin):
+    """Extract functions, module tables and their methods from Lua scripts."""
+
+    def __init__(self) -> None:
+        super().__init__(name="lua", languages={"lua"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _LuaWalker(content, path, include_private=self.include_private)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports=walker.imports,
+        )
+        return with_doc_ranges(result, walker.raw, prefixes=("---", "--"), block=None)
+
+
+@dataclass
+class _Table:
+    """A table that owns functions, collected before it becomes a ClassDoc."""
+
+    name: str
+    line: int
+    end_line: int
+    docstring: str | None = None
+    signature: str = ""
+    local: bool = False
+    bases: list[str] = field(default_factory=list)
+    methods: list[MethodDoc] = field(default_factory=list)
+
+
+class _LuaWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = code_lines(content, **_CODE_OPTIONS)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+        self.tables: dict[str, _Table] = {}
+        self.returned: str | None = None
+        self.exported: set[str] | None = None
+
+    def walk(self) -> None:
+        """Record top-level statements; functions nested in bodies are implementation."""
+        for idx, line in enumerate(self.code):
+            # String bodies are blanked in the scanned code, so the name is read from the raw line.
+            if "require" in line:
+                self.imports.update(m.group("module") for m in _REQUIRE_RE.finditer(self.raw[idx]))
+        found: list[FunctionDoc] = []
+        idx = 0
+        while idx < len(self.code):
+            line = self.code[idx].strip()
+            end = self._statement_end(idx)
+            if match := _FUNCTION_RE.match(line) or _ASSIGNED_RE.match(line):
+                function = self._function(idx, end, match, line)
+                if function is not None:
+                    found.append(function)
+            elif table := _TABLE_RE.match(line):
+                self._table(table.group("name"), idx, end, local=bool(table.group("local")))
+            elif returned := _RETURN_NAME_RE.match(line):
+                self.returned = returned.group("name")
+            elif _RETURN_TABLE_RE.match(line):
+                self.exported = self._exported(idx, end)
+            idx = end + 1
+        self.functions = [func for func in map(self._visible, found) if self._keep(func)]
+        self.classes = self._classes()
+
+    def _function(
+        self, idx: int, end: int, match: re.Match[str], line: str
+    ) -> FunctionDoc | None:
+        """Return a top-level function, or file a table member under its owner and return None."""
+        name = match.group("name")
+        method = match.groupdict().get("method")
+        params = paren_contents(line[match.end() - 1 :])
+        local = bool(match.group("local"))
+        if method is None and "." not in name:
+            return FunctionDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=self._doc(idx),
+                args=_param_names(params),
+                signature=f"{'local ' if local else ''}function {name}({params})",
+                private=local,
+            )
+        owner, member = (name, method) if method is not None else name.rsplit(".", 1)
+        separator = ":" if method is not None else "."
+        self._member(owner, idx, end, member, params, separator)
+        return None
+
+    def _member(
+        self, owner: str, idx: int, end: int, name: str, params: str, separator: str
+    ) -> None:
+        table = self.tables.setdefault(owner, _Table(name=owner, line=idx + 1, end_line=end + 1))
+        table.end_line = max(table.end_line, end + 1)
+        table.methods.append(
+            MethodDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=self._doc(idx),
+                args=_param_names(params),
+                kind="method" if separator == ":" else "class_method",
+                signature=f"function {owner}{separator}{name}({params})",
+                private=name.startswith("_"),
+            )
+        )
+
+    def _table(self, name: str, idx: int, end: int, *, local: bool) -> None:
+        """Record a `M = {}` or `M = setmetatable(...)` declaration and its constructor fields."""
+        table = self.tables.setdefault(name, _Table(name=name, line=idx + 1, end_line=end + 1))
+        # The declaration documents the table even when a member was seen first.
+        table.line = idx + 1
+        table.end_line = max(table.end_line, end + 1)
+        table.docstring = self._doc(idx)
+        # A multi-line constructor is summarized; its function fields are listed as members.
+        table.signature = re.sub(r"\{\s*$", "{...}", " ".join(self.raw[idx].split()))
+        table.local = local
+        header = " ".join(line.strip() for line in self.code[idx : end + 1])
+        table.bases = [match.group("base") for match in _INDEX_RE.finditer(header)]
+        depth = self.code[idx].count("{") - self.code[idx].count("}")
+        pos = idx + 1
+        while pos <= end:
+            field_end = self._statement_end(pos)
+            field_ = _FIELD_FUNCTION_RE.match(self.code[pos].strip())
+            if depth == 1 and field_ is not None:
+                params = paren_contents(self.code[pos].strip()[field_.end() - 1 :])
+                self._member(name, pos, min(field_end, end), field_.group("name"), params, ".")
+            for line in self.code[pos : field_end + 1]:
+                depth += line.count("{") - line.count("}")
+            pos = field_end + 1
+
+    def _exported(self, start: int, end: int) -> set[str]:
+        """Return the local names handed out by a `return { load = load, ... }` table."""
+        body = " ".join(line.strip() for line in self.code[start : end + 1])
+        body = body.split("{", 1)[1].rsplit("}", 1)[0]
+        return {
+            match.group("value")
+            for entry in split_top_level(body.replace(";", ","))
+            if (match := _EXPORTED_RE.match(entry.strip()))
+        }
+
+    def _visible(self, func: FunctionDoc) -> FunctionDoc:
+        if self.returned is not None:
+            private = True
+        elif self.exported is not None:
+            private = func.name not in self.exported
+        else:
+            private = func.private
+        return replace(func, private=private or func.name.startswith("_"))
+
+    def _keep(self, symbol: FunctionDoc | ClassDoc) -> bool:
+        return self.include_private or not symbol.private
+
+    def _classes(self) -> list[ClassDoc]:
+        classes: list[ClassDoc] = []
+        for table in self.tables.values():
+            if not table.methods and table.name != self.returned:
+                continue
+            cls = ClassDoc(
+                name=table.name,
+                file=self.path,
+                line=table.line,
+                end_line=table.end_line,
+                docstring=table.docstring,
+                bases=table.bases,
+                methods=[method for method in table.methods if self._keep(method)],
+                kind="class" if any(m.kind == "method" for m in table.methods) else "module",
+                signature=table.signature or table.name,
+                private=self._table_private(table),
+            )
+            if self._keep(cls):
+                classes.append(cls)
+        return sorted(classes, key=lambda cls: cls.line)
+
+    def _table_private(self, table: _Table) -> bool:
+        if any(part.startswith("_") for part in table.name.split(".")):
+            return True
+        root = table.name.split(".", 1)[0]
+        if self.returned is not None:
+            return root != self.returned
+        if self.exported is not None:
+            return root not in self.exported
+        owner = self.tables.get(root, table)
+        return owner.local
+
+    def _statement_end(self, start: int) -> int:
+        """Return the line closing the blocks and brackets opened on line `start`."""
+        blocks = brackets = 0
+        for idx in range(start, len(self.code)):
+            line = self.code[idx]
+            blocks += len(_OPENER_RE.findall(line)) - len(_CLOSER_RE.findall(line))
+            brackets += sum(line.count(char) for char in "({") - sum(
+                line.count(char) for char in ")}"
+            )
+            if blocks <= 0 and brackets <= 0:
+                return idx
+        return len(self.code) - 1
+
+    def _doc(self, idx: int) -> str | None:
+        return leading_comment(self.raw, idx, prefixes=("---", "--"), block=None)[0]
+
+
+def _param_names(params: str) -> list[str]:
+    return [param.strip() for param in split_top_level(params) if param.strip()]
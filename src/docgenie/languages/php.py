@@ -0,0 +1,378 @@
+"""PHP parser for classes, interfaces, traits, functions and PHPDoc."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    heritage,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    with_doc_ranges,
+)
+
+_TYPE_RE = re.compile(
+    r"^(?:(?:abstract|final|readonly)\s+)*(?P<kind>class|interface|trait|enum)\s+(?P<name>\w+)"
+)
+_FUNCTION_RE = re.compile(r"^function\s+&?(?P<name>\w+)\s*\(")
+_METHOD_RE = re.compile(
+    r"^(?P<mods>(?:(?:public|protected|private|static|abstract|final)\s+)*)"
+    r"function\s+&?(?P<name>\w+)\s*\("
+)
+_PROPERTY_RE = re.compile(
+    r"^(?P<mods>(?:(?:public|protected|private|static|readonly|var)\s+)+)"
+    r"(?:(?P<type>[?\w\\|&()]+)\s+)?\$(?P<name>\w+)"
+)
+_NAMESPACE_RE = re.compile(r"^namespace\s+(?P<name>[\w\\]*)\s*(?P<brace>\{)?")
+_USE_RE = re.compile(r"^use\s+(?:(?P<kind>function|const)\s+)?(?P<body>[^;{]+(?:\{[^}]*\})?)\s*;")
+_TRAIT_USE_RE = re.compile(r"^use\s+(?P<names>[\w\\,\s]+?)\s*[;{]")
+_PARAM_RE = re.compile(r"^(?P<prefix>.*?)(?P<var>&?(?:\.\.\.)?\$(?P<name>\w+))(?P<rest>.*)$")
+_PARAM_TAG_RE = re.compile(r"^@param\s+(?P<type>[^\s$]+)\s+(?:&?\.\.\.)?\$(?P<name>\w+)")
+_RETURN_TAG_RE = re.compile(r"^@return\s+(?P<type>\S+)")
+_VAR_TAG_RE = re.compile(r"^@var\s+(?P<type>\S+)(?:\s+\$\w+)?\s*(?P<text>.*)$")
+_HEREDOC_RE = re.compile(r"<<<\s*([\"']?)(?P<label>\w+)\1\s*$")
+_PARAM_MODIFIERS = {"public", "protected", "private", "readonly"}
+
+
+class PhpParser(ParserPlugin):
+    """Extract classes, interfaces, traits, functions and typed properties from PHP sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="php", languages={"php"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _PhpWalker(content, path)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions, classes=walker.classes, imports=walker.imports
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=(),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("#["),
+        )
+
+
+class _PhpWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        # `#[...]` attributes are not comments; mask them before `#` comments are stripped.
+        self.code = [
+            _unmask_attributes(line)
+            for line in code_lines(
+                _blank_heredocs(content).replace("#[", "@["), line_comments=("//", "#")
+            )
+        ]
+        self.depths = brace_depths(self.code)
+        self.namespace = ""
+        self.aliases: dict[str, str] = {}
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+
+    def walk(self) -> None:
+        idx = 0
+        while idx < len(self.code):
+            line, attributes = _strip_attributes(self.code[idx].strip())
+            namespace = _NAMESPACE_RE.match(line)
+            if namespace is not None:
+                # Each namespace starts a fresh import scope.
+                self.namespace = namespace.group("name").strip("\\")
+                self.aliases = {}
+                idx += 1
+                continue
+            use = _USE_RE.match(line)
+            if use is not None:
+                self._record_use(use.group("kind"), use.group("body"))
+                idx += 1
+                continue
+            match = _TYPE_RE.match(line)
+            if match is not None:
+                idx = self._record_type(idx, match, attributes) + 1
+                continue
+            match = _FUNCTION_RE.match(line)
+            if match is not None:
+                end, _ = item_end(self.code, idx)
+                doc, decorators = _phpdoc(self.raw, idx)
+                header = header_text(self.code, idx, end)
+                self.functions.append(
+                    FunctionDoc(
+                        name=self._declared(match.group("name")),
+                        file=self.path,
+                        line=idx + 1,
+                        docstring=doc,
+                        args=_param_names(header),
+                        decorators=decorators + attributes,
+                        signature=_typed_signature(header, doc),
+                        end_line=end + 1,
+                    )
+                )
+                idx = end + 1
+                continue
+            idx += 1
+
+    def _record_use(self, kind: str | None, body: str) -> None:
+        body = " ".join(body.split())
+        prefix = ""
+        if "{" in body:
+            prefix, _, body = body.partition("{")
+            body = body.rstrip("}")
+        for clause in split_top_level(body):
+            target, _, alias = clause.partition(" as ")
+            full = (prefix.strip() + target.strip()).strip("\\")
+            if not full:
+                continue
+            self.imports.add(full)
+            if kind is None:
+                self.aliases[(alias.strip() or full.rsplit("\\", 1)[-1])] = full
+
+    def _record_type(self, idx: int, match: re.Match[str], attributes: list[str]) -> int:
+        end, has_body = item_end(self.code, idx)
+        header = header_text(self.code, idx, end)
+        doc, decorators = _phpdoc(self.raw, idx)
+        kind = match.group("kind")
+        bases = [self._qualify(base) for base in heritage(header)]
+        methods: list[MethodDoc] = []
+        fields: list[FieldDoc] = []
+        if has_body:
+            depth = self.depths[idx] + 1
+            bases.extend(self._members(idx, end, depth, methods, fields))
+        self.classes.append(
+            ClassDoc(
+                name=self._declared(match.group("name")),
+                file=self.path,
+                line=idx + 1,
+                docstring=doc,
+                bases=bases,
+                decorators=decorators + attributes,
+                methods=methods,
+                kind=kind,
+                signature=header,
+                fields=fields,
+                end_line=end + 1,
+            )
+        )
+        return end
+
+    def _members(
+        self,
+        start: int,
+        end: int,
+        depth: int,
+        methods: list[MethodDoc],
+        fields: list[FieldDoc],
+    ) -> list[str]:
+        """Collect methods and properties into the given lists; return used traits."""
+        traits: list[str] = []
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, attributes = _strip_attributes(self.code[idx].strip())
+            if not line:
+                # A lone `#[...]` line belongs to the member below; `_phpdoc` picks it up there.
+                idx += 1
+                continue
+            member_end, _ = item_end(self.code, idx)
+            trait_use = _TRAIT_USE_RE.match(line)
+            method = _METHOD_RE.match(line)
+            prop = _PROPERTY_RE.match(line)
+            if trait_use is not None:
+                traits.extend(
+                    self._qualify(name) for name in split_top_level(trait_use.group("names"))
+                )
+            elif method is not None and "private" not in method.group("mods").split():
+                methods.append(self._method(idx, member_end, method, attributes))
+                if method.group("name") == "__construct":
+                    fields.extend(_promoted_fields(header_text(self.code, idx, member_end)))
+            elif prop is not None and "private" not in prop.group("mods").split():
+                doc, _ = _phpdoc(self.raw, idx)
+                var_tag = next(filter(None, map(_VAR_TAG_RE.match, (doc or "").splitlines())), None)
+                prop_type = prop.group("type") or (var_tag and var_tag.group("type"))
+                if prop_type:
+                    fields.append(
+                        FieldDoc(
+                            name=prop.group("name"),
+                            type=prop_type,
+                            docstring=_without_tags(doc)
+                            or (var_tag and var_tag.group("text").strip())
+                            or None,
+                        )
+                    )
+            idx = max(member_end, idx) + 1
+        return traits
+
+    def _method(
+        self, idx: int, end: int, match: re.Match[str], attributes: list[str]
+    ) -> MethodDoc:
+        name = match.group("name")
+        modifiers = match.group("mods").split()
+        header = header_text(self.code, idx, end)
+        doc, decorators = _phpdoc(self.raw, idx)
+        if "abstract" in modifiers:
+            kind = "abstract_method"
+        elif name == "__construct":
+            kind = "constructor"
+        elif "static" in modifiers:
+            kind = "class_method"
+        else:
+            kind = "method"
+        return MethodDoc(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            docstring=doc,
+            args=_param_names(header),
+            decorators=decorators + attributes,
+            kind=kind,
+            signature=_typed_signature(header, doc, returns=name != "__construct"),
+            end_line=end + 1,
+        )
+
+    def _declared(self, name: str) -> str:
+        return f"{self.namespace}\\{name}" if self.namespace else name
+
+    def _qualify(self, name: str) -> str:
+        """Resolve a referenced class name the way PHP does: `use` aliases, then namespace."""
+        name = name.strip()
+        if name.startswith("\\"):
+            return name[1:]
+        if name.lower() == "namespace" or name.lower().startswith("namespace\\"):
+            return self._declared(name.split("\\", 1)[-1])
+        head, sep, rest = name.partition("\\")
+        if head in self.aliases:
+            return self.aliases[head] + sep + rest
+        return self._declared(name)
+
+
+def _typed_signature(header: str, doc: str | None, *, returns: bool = True) -> str:
+    """Fill parameter and return types missing from `header` with PHPDoc tag types."""
+    param_types = _param_tag_types(doc)
+    return_type = _tag_type(doc, _RETURN_TAG_RE)
+    if not param_types and not return_type:
+        return header
+    open_at = header.find("(")
+    if open_at < 0:
+        return header
+    inner = paren_contents(header)
+    tail = header[open_at + len(inner) + 2 :]
+    params: list[str] = []
+    for param in split_top_level(inner):
+        match = _PARAM_RE.match(param)
+        if match is None:
+            params.append(param)
+            continue
+        prefix = match.group("prefix")
+        declared = [word for word in prefix.split() if word not in _PARAM_MODIFIERS]
+        doc_type = param_types.get(match.group("name"))
+        if not declared and doc_type:
+            prefix = f"{prefix}{doc_type} "
+        params.append(prefix + match.group("var") + match.group("rest"))
+    if returns and return_type and not tail.lstrip().startswith(":"):
+        tail = f": {return_type}{tail}"
+    return f"{header[:open_at]}({', '.join(params)}){tail}"
+
+
+def _param_tag_types(doc: str | None) -> dict[str, str]:
+    types: dict[str, str] = {}
+    for line in (doc or "").splitlines():
+        match = _PARAM_TAG_RE.match(line.strip())
+        if match is not None:
+            types.setdefault(match.group("name"), match.group("type"))
+    return types
+
+
+def _tag_type(doc: str | None, pattern: re.Pattern[str]) -> str | None:
+    for line in (doc or "").splitlines():
+        match = pattern.match(line.strip())
+        if match is not None:
+            return match.group("type")
+    return None
+
+
+def _without_tags(doc: str | None) -> str | None:
+    text = "\n".join(line for line in (doc or "").splitlines() if not line.startswith("@"))
+    return text.strip() or None
+
+
+def _param_names(header: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(paren_contents(header)):
+        match = _PARAM_RE.match(param)
+        if match is not None:
+            names.append(match.group("name"))
+    return names
+
+
+def _promoted_fields(header: str) -> list[FieldDoc]:
+    """Constructor parameters with a visibility are properties too (PHP 8 promotion)."""
+    fields: list[FieldDoc] = []
+    for param in split_top_level(paren_contents(header)):
+        match = _PARAM_RE.match(param)
+        if match is None:
+            continue
+        words = match.group("prefix").split()
+        if "public" not in words and "protected" not in words:
+            continue
+        declared = [word for word in words if word not in _PARAM_MODIFIERS]
+        if declared:
+            fields.append(FieldDoc(name=match.group("name"), type=" ".join(declared)))
+    return fields
+
+
+def _strip_attributes(line: str) -> tuple[str, list[str]]:
+    """Split leading `#[...]` attributes off a code line."""
+    attributes: list[str] = []
+    while line.startswith("#["):
+        depth = 0
+        # Start at the `[`: the leading `#` would otherwise close the attribute at depth 0.
+        for idx, char in enumerate(line[1:], 1):
+            depth += {"[": 1, "]": -1}.get(char, 0)
+            if depth == 0:
+                attributes.append(line[: idx + 1])
+                line = line[idx + 1 :].lstrip()
+                break
+        else:
+            return "", attributes
+    return line, attributes
+
+
+def _unmask_attributes(line: str) -> str:
+    return line.replace("@[", "#[")
+
+
+def _blank_heredocs(content: str) -> str:
+    """Blank heredoc/nowdoc bodies so their text is not scanned as code."""
+    lines = content.splitlines()
+    label: str | None = None
+    for idx, line in enumerate(lines):
+        if label is not None:
+            closing = line.strip()
+            lines[idx] = ""
+            if re.match(rf"{label}\b", closing):
+                lines[idx] = line[: len(line) - len(closing)] + closing
+                label = None
+            continue
+        match = _HEREDOC_RE.search(line)
+        if match is not None:
+            label = match.group("label")
+    return "\n".join(lines)
+
+
+def _phpdoc(raw: Sequence[str], idx: int) -> tuple[str | None, list[str]]:
+    return leading_comment(
+        raw, idx, prefixes=(), block=("/**", "*/"), skip=lambda line: line.startswith("#[")
+    )
@@ -0,0 +1,265 @@
+"""Protocol Buffers parser for `message`, `enum` and `service` definitions.
+
+The comment directly above a definition (`//` lines or a `/* */` block) is its doc; a
+field or enum value without one may use a trailing `//` comment on its own line. Nested
+messages and enums are named `Outer.Inner`. Services are reported as class-like symbols
+with their RPCs as methods; `parse_proto` keeps the request and response types and the
+streaming flags the gRPC API section is built from.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from pathlib import Path
+from typing import Any
+
+from ..models import ClassDoc, FieldDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines, header_text, item_end, leading_comment, with_doc_ranges
+
+_BLOCK_RE = re.compile(r"^(?P<kind>message|enum|service|oneof|extend)\s+(?P<name>[\w.]+)\s*\{")
+_RPC_RE = re.compile(
+    r"^rpc\s+(?P<name>\w+)\s*\(\s*(?P<client>stream\s+)?(?P<request>\.?[\w.]+)\s*\)\s*"
+    r"returns\s*\(\s*(?P<server>stream\s+)?(?P<response>\.?[\w.]+)\s*\)"
+)
+_FIELD_RE = re.compile(
+    r"^(?:(?P<label>repeated|optional|required)\s+)?"
+    r"(?P<type>map\s*<[^>]*>|\.?[A-Za-z_][\w.]*)\s+(?P<name>[A-Za-z_]\w*)\s*=\s*(?P<number>\d+)"
+)
+_VALUE_RE = re.compile(r"^(?P<name>[A-Za-z_]\w*)\s*=\s*(?P<number>-?(?:0[xX][0-9A-Fa-f]+|\d+))")
+_IMPORT_RE = re.compile(r"""^\s*import\s+(?:(?:public|weak)\s+)?["'](?P<path>[^"']+)["']""")
+_PACKAGE_RE = re.compile(r"^package\s+(?P<name>[\w.]+)")
+_TRAILING_RE = re.compile(r"//+\s?(?P<text>.*)$")
+
+
+class ProtobufParser(ParserPlugin):
+    """Extract messages, enums and gRPC services from `.proto` files."""
+
+    def __init__(self) -> None:
+        super().__init__(name="protobuf", languages={"protobuf"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        proto = parse_proto(content)
+        classes = [
+            ClassDoc(
+                name=message["name"],
+                file=path,
+                line=message["line"],
+                end_line=message["end_line"],
+                docstring=message["docstring"],
+                kind="message",
+                fields=[
+                    FieldDoc(
+                        name=item["name"],
+                        type=f"{item['label']} {item['type']}" if item["label"] else item["type"],
+                        tags={"number": item["number"]},
+                        docstring=item["docstring"],
+                    )
+                    for item in message["fields"]
+                ],
+            )
+            for message in proto["messages"]
+        ]
+        classes.extend(
+            ClassDoc(
+                name=enum["name"],
+                file=path,
+                line=enum["line"],
+                end_line=enum["end_line"],
+                docstring=enum["docstring"],
+                kind="enum",
+                fields=[
+                    FieldDoc(
+                        name=value["name"],
+                        type=enum["name"],
+                        tags={"number": value["number"]},
+                        docstring=value["docstring"],
+                    )
+                    for value in enum["values"]
+                ],
+            )
+            for enum in proto["enums"]
+        )
+        classes.extend(
+            ClassDoc(
+                name=service["name"],
+                file=path,
+                line=service["line"],
+                end_line=service["end_line"],
+                docstring=service["docstring"],
+                kind="service",
+                methods=[
+                    MethodDoc(
+                        name=rpc["name"],
+                        file=path,
+                        line=rpc["line"],
+                        end_line=rpc["end_line"],
+                        docstring=rpc["docstring"],
+                        args=[rpc["request"]],
+                        kind="rpc",
+                        signature=rpc["signature"],
+                    )
+                    for rpc in service["rpcs"]
+                ],
+            )
+            for service in proto["services"]
+        )
+        classes.sort(key=lambda cls: cls.line)
+        result = ParseResult(classes=classes, imports=set(proto["imports"]))
+        return with_doc_ranges(
+            result, content.splitlines(), prefixes=("//",), block=("/*", "*/")
+        )
+
+
+@dataclass
+class _Frame:
+    """An open `{}` block; `entry` is the message, enum or service it defines."""
+
+    kind: str
+    entry: dict[str, Any] | None = None
+    oneof: str | None = None
+
+
+def parse_proto(content: str) -> dict[str, Any]:
+    """Return the file's `package`, `imports`, `messages`, `enums` and `services`.
+
+    Messages have `name`, `line`, `end_line`, `docstring` and `fields` (`name`, `type`,
+    `number`, `label`, `docstring`); a field inside a `oneof` has `oneof NAME` as its
+    label. Enums list `values` (`name`, `number`, `docstring`). Services list `rpcs`
+    with `request`/`response` types, `client_streaming`/`server_streaming` flags and
+    the collapsed `signature`. Lines are 1-based.
+    """
+    raw = content.splitlines()
+    code = code_lines(content)
+    proto: dict[str, Any] = {
+        "package": None,
+        "imports": [],
+        "messages": [],
+        "enums": [],
+        "services": [],
+    }
+    stack: list[_Frame] = []
+    idx = 0
+    while idx < len(code):
+        line = code[idx].strip()
+        top = stack[-1] if stack else _Frame("file")
+        if top.kind == "file":
+            imported = _IMPORT_RE.match(raw[idx])
+            package = _PACKAGE_RE.match(line)
+            if imported and line.startswith("import"):
+                proto["imports"].append(imported.group("path"))
+            elif package:
+                proto["package"] = package.group("name")
+        if top.kind == "service" and line.startswith("rpc"):
+            end, _ = item_end(code, idx)
+            rpc = _RPC_RE.match(header_text(code, idx, end))
+            if rpc is not None and top.entry is not None:
+                top.entry["rpcs"].append(_rpc(rpc, raw, idx, end))
+                idx = end + 1
+                continue
+        block = _BLOCK_RE.match(line)
+        opens = line.count("{")
+        if block is not None:
+            stack.append(_open(block, stack, proto, raw, idx))
+            opens -= 1
+        elif top.kind in ("message", "oneof") and top.entry is not None:
+            field = _FIELD_RE.match(line)
+            if field is not None:
+                top.entry["fields"].append(_field(field, top.oneof, raw, code, idx))
+        elif top.kind == "enum" and top.entry is not None:
+            value = _VALUE_RE.match(line)
+            if value is not None and value.group("name") not in ("option", "reserved"):
+                top.entry["values"].append(
+                    {
+                        "name": value.group("name"),
+                        "number": value.group("number"),
+                        "docstring": _doc(raw, code, idx),
+                    }
+                )
+        stack.extend(_Frame("block") for _ in range(max(opens, 0)))
+        for _ in range(line.count("}")):
+            if not stack:
+                break
+            closed = stack.pop()
+            if closed.entry is not None and closed.kind != "oneof":
+                closed.entry["end_line"] = idx + 1
+        idx += 1
+    return proto
+
+
+def _open(
+    block: re.Match[str],
+    stack: list[_Frame],
+    proto: dict[str, Any],
+    raw: list[str],
+    idx: int,
+) -> _Frame:
+    kind, name = block.group("kind"), block.group("name")
+    parent = stack[-1] if stack else None
+    if kind == "oneof" and parent is not None and parent.kind == "message":
+        return _Frame("oneof", parent.entry, oneof=name)
+    if kind not in ("message", "enum", "service"):
+        return _Frame("block")
+    outer = [frame.entry["name"] for frame in stack if frame.kind == "message" and frame.entry]
+    entry: dict[str, Any] = {
+        "name": f"{outer[-1]}.{name}" if outer else name,
+        "line": idx + 1,
+        "end_line": idx + 1,
+        "docstring": _leading(raw, idx),
+    }
+    if kind == "message":
+        entry["fields"] = []
+        proto["messages"].append(entry)
+    elif kind == "enum":
+        entry["values"] = []
+        proto["enums"].append(entry)
+    else:
+        entry["rpcs"] = []
+        proto["services"].append(entry)
+    return _Frame(kind, entry)
+
+
+def _rpc(match: re.Match[str], raw: list[str], idx: int, end: int) -> dict[str, Any]:
+    client, server = bool(match.group("client")), bool(match.group("server"))
+    request, response = match.group("request"), match.group("response")
+    return {
+        "name": match.group("name"),
+        "line": idx + 1,
+        "end_line": end + 1,
+        "docstring": _leading(raw, idx),
+        "request": request,
+        "response": response,
+        "client_streaming": client,
+        "server_streaming": server,
+        "signature": (
+            f"rpc {match.group('name')}({'stream ' if client else ''}{request}) "
+            f"returns ({'stream ' if server else ''}{response})"
+        ),
+    }
+
+
+def _field(
+    match: re.Match[str], oneof: str | None, raw: list[str], code: list[str], idx: int
+) -> dict[str, Any]:
+    field_type = re.sub(r"\s*,\s*", ", ", re.sub(r"\s+", "", match.group("type")))
+    return {
+        "name": match.group("name"),
+        "type": field_type,
+        "number": match.group("number"),
+        "label": f"oneof {oneof}" if oneof else match.group("label") or "",
+        "docstring": _doc(raw, code, idx),
+    }
+
+
+def _leading(raw: list[str], idx: int) -> str | None:
+    return leading_comment(raw, idx, prefixes=("//",), block=("/*", "*/"))[0]
+
+
+def _doc(raw: list[str], code: list[str], idx: int) -> str | None:
+    """Prefer the comment above a field or value; fall back to one trailing it."""
+    above = _leading(raw, idx)
+    if above:
+        return above
+    trailing = _TRAILING_RE.search(raw[idx][len(code[idx].rstrip()) :])
+    return trailing.group("text").strip() or None if trailing else None
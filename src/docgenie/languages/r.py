@@ -0,0 +1,391 @@
+"""R parser for functions, S4 classes, generics and methods, and roxygen2 comments.
+
+`name <- function(...)` assignments (also `=` and `<<-`) at the top level are
+functions. `setClass` declares a class whose slots are its fields, `setGeneric` a
+generic function and `setMethod` a method of that generic, listed under its class
+when the class is declared in the same file. A `#'` roxygen2 block above a
+declaration is its docstring, with `@param` and `@return` tags turned into an
+argument list and a "Returns" line. Names starting with `.` are private; a
+package's NAMESPACE file decides the rest (see `namespace_exports`).
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field, replace
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines, leading_comment, paren_contents, split_top_level, with_doc_ranges
+
+# R strings may span lines.
+_CODE_OPTIONS = {"line_comments": ("#",), "block_comments": (), "multiline_quotes": "\"'"}
+_DOC_PREFIXES = ("#'",)
+
+_NAME = r"[A-Za-z.][\w.]*"
+_ASSIGN = r"\s*(?:<<?-|=)\s*"
+_FUNCTION_RE = re.compile(rf"^(?P<name>{_NAME}){_ASSIGN}function\s*\(")
+_S4_RE = re.compile(rf"^(?:{_NAME}{_ASSIGN})?(?P<call>setClass|setGeneric|setMethod)\s*\(")
+_LIBRARY_RE = re.compile(
+    r"\b(?:library|require|requireNamespace|loadNamespace)\s*\("
+    r"""\s*["']?(?P<pkg>[A-Za-z][\w.]*)"""
+)
+_IMPORT_TAG_RE = re.compile(r"^#'\s*@import(?:From)?\s+(?P<pkg>[A-Za-z][\w.]*)")
+_STRING_RE = re.compile(r"""^(?P<quote>["'])(?P<value>.*)(?P=quote)$""")
+_TAG_RE = re.compile(r"^@(?P<tag>\w+)\s*(?P<text>.*)$")
+_DIRECTIVE_RE = re.compile(
+    r"\b(?P<directive>export|exportPattern|exportClasses|exportClass|exportMethods|S3method)"
+    r"\s*\((?P<args>[^()]*)\)"
+)
+_POSIX_CLASSES = {
+    "[:alpha:]": "A-Za-z",
+    "[:alnum:]": "A-Za-z0-9",
+    "[:digit:]": "0-9",
+    "[:upper:]": "A-Z",
+    "[:lower:]": "a-z",
+    "[:space:]": r"\s",
+    "[:punct:]": r"!-/:-@\[-`{-~",
+}
+
+# Tags whose text belongs to the description; the rest, bar `@param`/`@return`, are dropped.
+_DESCRIPTION_TAGS = {"title", "description", "details"}
+_CONTINUATION_TAIL = (",", "(", "[", "{", "+", "-", "*", "/", "^", "=", "~", "&", "|", "%", ">")
+
+
+class RParser(ParserPlugin):
+    """Extract functions, S4 classes, generics and methods from R scripts and packages."""
+
+    def __init__(self) -> None:
+        super().__init__(name="r", languages={"r"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _RWalker(content, path, include_private=self.include_private)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions, classes=walker.classes, imports=walker.imports
+        )
+        return with_doc_ranges(result, walker.raw, prefixes=_DOC_PREFIXES, block=None)
+
+
+@dataclass
+class _Method:
+    """A `setMethod` call, kept until every class of the file is known."""
+
+    owner: str
+    method: MethodDoc
+
+
+@dataclass
+class _Roxygen:
+    docstring: str | None = None
+    slots: dict[str, str] = field(default_factory=dict)
+
+
+class _RWalker:
+    def __init__(self, content: str, path: Path, *, include_private: bool = False) -> None:
+        self.path = path
+        self.include_private = include_private
+        self.raw = content.splitlines()
+        self.code = code_lines(content, **_CODE_OPTIONS)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+        self.methods: list[_Method] = []
+
+    def walk(self) -> None:
+        """Record top-level declarations; functions nested in bodies are implementation."""
+        for idx, line in enumerate(self.raw):
+            if "library" in self.code[idx] or "require" in self.code[idx]:
+                self.imports.update(m.group("pkg") for m in _LIBRARY_RE.finditer(line))
+            if tag := _IMPORT_TAG_RE.match(line.strip()):
+                self.imports.add(tag.group("pkg"))
+        idx = 0
+        while idx < len(self.code):
+            line = self.code[idx].strip()
+            if not line:
+                idx += 1
+                continue
+            end = self._statement_end(idx)
+            if match := _FUNCTION_RE.match(line):
+                self._function(idx, end, match.group("name"))
+            elif match := _S4_RE.match(line):
+                self._s4(idx, end, match.group("call"))
+            idx = end + 1
+        self._attach_methods()
+        self.functions = [func for func in self.functions if self._keep(func)]
+        self.classes = [cls for cls in self.classes if self._keep(cls)]
+
+    def _function(self, idx: int, end: int, name: str) -> None:
+        params = paren_contents(self._source(idx, end).split("function", 1)[1])
+        self.functions.append(
+            FunctionDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=self._doc(idx).docstring,
+                args=_param_names(params),
+                signature=f"{name} <- function({' '.join(params.split())})",
+                private=name.startswith("."),
+            )
+        )
+
+    def _s4(self, idx: int, end: int, call: str) -> None:
+        source = self._source(idx, end)
+        args = split_top_level(paren_contents(source[source.index(call) :]))
+        positional = [arg.strip() for arg in args if not _named(arg)]
+        named = {
+            key.strip(): value.strip()
+            for arg in args
+            if _named(arg)
+            for key, value in [arg.split("=", 1)]
+        }
+        name = _string(named.get("Class") or named.get("name") or next(iter(positional), ""))
+        if not name:
+            return
+        doc = self._doc(idx)
+        if call == "setClass":
+            self._class(idx, end, name, positional[1:], named, doc)
+            return
+        function = next((arg for arg in positional[1:] if arg.startswith("function")), None)
+        function = named.get("def") or named.get("definition") or function or ""
+        params = paren_contents(function) if function.startswith("function") else ""
+        if call == "setGeneric":
+            self.functions.append(
+                FunctionDoc(
+                    name=name,
+                    file=self.path,
+                    line=idx + 1,
+                    end_line=end + 1,
+                    docstring=doc.docstring,
+                    args=_param_names(params),
+                    kind="generic",
+                    signature=f'setGeneric("{name}", function({" ".join(params.split())}))',
+                    private=name.startswith("."),
+                )
+            )
+            return
+        signature = named.get("signature") or (positional[1] if len(positional) > 1 else "")
+        # `signature("Circle")` and `signature(shape = "Circle")` both name the class.
+        classes = [_string(part.split("=", 1)[-1]) for part in _vector(signature)]
+        owner = next(filter(None, classes), "")
+        method = MethodDoc(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            end_line=end + 1,
+            docstring=doc.docstring,
+            args=_param_names(params),
+            kind="method",
+            signature=(
+                f'setMethod("{name}", "{owner}", function({" ".join(params.split())}))'
+            ),
+            private=name.startswith("."),
+            receiver=owner or None,
+        )
+        self.methods.append(_Method(owner=owner, method=method))
+
+    def _class(
+        self,
+        idx: int,
+        end: int,
+        name: str,
+        positional: list[str],
+        named: dict[str, str],
+        doc: _Roxygen,
+    ) -> None:
+        """Record a `setClass` call with its slots as fields and `contains` as bases."""
+        representation = named.get("slots") or named.get("representation")
+        if representation is None and positional:
+            representation = positional[0]
+        fields: list[FieldDoc] = []
+        bases = [_string(part) for part in _vector(named.get("contains", "")) if _string(part)]
+        for entry in _vector(representation or ""):
+            if _named(entry):
+                slot, slot_type = (part.strip() for part in entry.split("=", 1))
+                slot_type = _string(slot_type) or slot_type
+            elif named.get("slots") is not None and _string(entry):
+                slot, slot_type = _string(entry), "ANY"
+            else:
+                # An unnamed `representation()` entry is a superclass.
+                bases.extend(filter(None, [_string(entry)]))
+                continue
+            fields.append(FieldDoc(name=slot, type=slot_type, docstring=doc.slots.get(slot)))
+        contains = ", ".join(f'"{base}"' for base in bases)
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=doc.docstring,
+                bases=bases,
+                kind="class",
+                signature=f'setClass("{name}"' + (f", contains = {contains})" if bases else ")"),
+                fields=fields,
+                private=name.startswith("."),
+            )
+        )
+
+    def _attach_methods(self) -> None:
+        """List each `setMethod` under its class, or as a function when the class is elsewhere."""
+        owners = {cls.name: pos for pos, cls in enumerate(self.classes)}
+        for item in self.methods:
+            pos = owners.get(item.owner)
+            if pos is None:
+                self.functions.append(item.method)
+                continue
+            cls = self.classes[pos]
+            if self._keep(item.method):
+                self.classes[pos] = replace(cls, methods=[*cls.methods, item.method])
+
+    def _source(self, start: int, end: int) -> str:
+        """Join lines `start`..`end` with their comments cut off, string contents intact."""
+        return " ".join(
+            self.raw[idx][: len(self.code[idx])].strip() for idx in range(start, end + 1)
+        )
+
+    def _statement_end(self, start: int) -> int:
+        """Return the last line of the expression starting on line `start`.
+
+        Brackets must be closed and the line must not end in an operator or comma. A
+        `function(...)` header whose `{` body opens on the next line continues too.
+        """
+        depth = 0
+        for idx in range(start, len(self.code)):
+            line = self.code[idx]
+            depth += sum(line.count(char) for char in "([{") - sum(
+                line.count(char) for char in ")]}"
+            )
+            if depth > 0:
+                continue
+            if line.rstrip().endswith(_CONTINUATION_TAIL):
+                continue
+            following = next((text.strip() for text in self.code[idx + 1 :] if text.strip()), "")
+            header = " ".join(self.code[start : idx + 1])
+            if following.startswith("{") and _ends_with_header(header):
+                continue
+            return idx
+        return len(self.code) - 1
+
+    def _doc(self, idx: int) -> _Roxygen:
+        return _roxygen(leading_comment(self.raw, idx, prefixes=_DOC_PREFIXES, block=None)[0])
+
+    def _keep(self, symbol: FunctionDoc | ClassDoc) -> bool:
+        return self.include_private or not symbol.private
+
+
+def _roxygen(text: str | None) -> _Roxygen:
+    """Turn a roxygen2 block into a docstring and its `@slot` descriptions.
+
+    The title and description paragraphs come first (so do `@title`, `@description`
+    and `@details`); `@param` tags become an "Arguments" list and `@return` a
+    "Returns" line. Other tags such as `@export` or `@examples` are left out.
+    """
+    if not text:
+        return _Roxygen()
+    description: list[str] = []
+    tags: list[tuple[str, list[str]]] = []
+    for line in text.splitlines():
+        tag = _TAG_RE.match(line.strip())
+        if tag is not None:
+            tags.append((tag.group("tag"), [tag.group("text")]))
+        elif tags:
+            tags[-1][1].append(line)
+        else:
+            description.append(line)
+    params: list[tuple[str, str]] = []
+    returns = ""
+    slots: dict[str, str] = {}
+    for tag, lines in tags:
+        body = " ".join(" ".join(lines).split())
+        if tag in _DESCRIPTION_TAGS:
+            description.extend(["", *lines])
+        elif tag in {"param", "slot"} and body:
+            names, _, value = body.partition(" ")
+            for name in names.split(","):
+                if tag == "param":
+                    params.append((name, value))
+                else:
+                    slots[name] = value
+        elif tag in {"return", "returns"}:
+            returns = body
+    parts = ["\n".join(description).strip()]
+    if params:
+        parts.append(
+            "Arguments:\n" + "\n".join(f"- `{name}`: {value}" for name, value in params)
+        )
+    if returns:
+        parts.append(f"Returns: {returns}")
+    return _Roxygen(docstring="\n\n".join(part for part in parts if part) or None, slots=slots)
+
+
+def namespace_exports(text: str) -> tuple[set[str], list[re.Pattern[str]]]:
+    """Return the names and name patterns a package NAMESPACE file exports.
+
+    `export()`, `exportClasses()` and `exportMethods()` name symbols, `S3method(print,
+    money)` exports `print.money` and `exportPattern()` adds a regular expression;
+    POSIX classes such as `[[:alpha:]]` are translated and invalid patterns skipped.
+    """
+    code = code_lines(text, line_comments=("#",), block_comments=())
+    source = "\n".join(raw[: len(line)] for raw, line in zip(text.splitlines(), code))
+    names: set[str] = set()
+    patterns: list[re.Pattern[str]] = []
+    for match in _DIRECTIVE_RE.finditer(source):
+        args = [_string(arg) or arg.strip() for arg in split_top_level(match.group("args"))]
+        args = [arg for arg in args if arg]
+        directive = match.group("directive")
+        if directive == "S3method":
+            if args[1:]:
+                names.add(args[2] if args[2:] else f"{args[0]}.{args[1]}")
+        elif directive == "exportPattern":
+            for pattern in args:
+                for posix, chars in _POSIX_CLASSES.items():
+                    pattern = pattern.replace(posix, chars)
+                try:
+                    patterns.append(re.compile(pattern))
+                except re.error:
+                    continue
+        else:
+            names.update(args)
+    return names, patterns
+
+
+def is_exported(name: str, exports: tuple[set[str], list[re.Pattern[str]]]) -> bool:
+    names, patterns = exports
+    return name in names or any(pattern.search(name) for pattern in patterns)
+
+
+def _ends_with_header(line: str) -> bool:
+    """Whether `line` ends with a `function(...)` header that has no body yet."""
+    at = line.rfind("function")
+    if at < 0:
+        return False
+    rest = line[at + len("function") :].lstrip()
+    if not rest.startswith("("):
+        return False
+    return not rest[len(paren_contents(rest)) + 2 :].strip()
+
+
+def _named(arg: str) -> bool:
+    return re.match(rf"^\s*{_NAME}\s*=(?!=)", arg) is not None
+
+
+def _string(text: str) -> str:
+    match = _STRING_RE.match(text.strip())
+    return match.group("value") if match is not None else ""
+
+
+def _vector(text: str) -> list[str]:
+    """Return the entries of `c(...)`, `list(...)`, `representation(...)` or a lone value."""
+    text = text.strip()
+    if re.match(r"^(?:c|list|representation|signature)\s*\(", text):
+        return [part.strip() for part in split_top_level(paren_contents(text)) if part.strip()]
+    return [text] if text else []
+
+
+def _param_names(params: str) -> list[str]:
+    return [
+        param.split("=", 1)[0].strip() for param in split_top_level(params) if param.strip()
+    ]
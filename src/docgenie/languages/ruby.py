@@ -0,0 +1,342 @@
+"""Ruby parser for modules, classes, methods and attribute macros."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from dataclasses import dataclass, field
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines, leading_comment, paren_contents, split_top_level, with_doc_ranges
+
+_CONST = r"[A-Z]\w*(?:::[A-Z]\w*)*"
+_NAMESPACE_RE = re.compile(
+    rf"^(?P<kind>module|class)\s+(?:::)?(?P<name>{_CONST})"
+    rf"(?:\s*<\s*(?P<super>(?:::)?{_CONST}))?"
+)
+_SINGLETON_RE = re.compile(r"^class\s*<<\s*self\b")
+_METHOD_NAME = r"[A-Za-z_]\w*[?!=]?|\[\]=?|<=>|===?|=~|\*\*|[+\-*/%<>!~^&|]=?|[+\-!~]@"
+_DEF_RE = re.compile(
+    rf"^(?:(?P<vis>private|protected|public|private_class_method)\s+)?"
+    rf"def\s+(?P<self>self\.)?(?P<name>{_METHOD_NAME})"
+)
+_VISIBILITY_RE = re.compile(r"^(?P<vis>private|protected|public)\s*$")
+_VISIBILITY_NAMES_RE = re.compile(r"^(?P<vis>private|protected|public|private_class_method)\s+:")
+_ATTR_RE = re.compile(
+    r"^(?:(?P<vis>private|protected|public)\s+)?"
+    r"(?P<macro>attr_(?:accessor|reader|writer))\s+(?P<names>:.+)$"
+)
+_MIXIN_RE = re.compile(rf"^(?:include|extend|prepend)\s*\(?\s*(?P<names>(?:::)?{_CONST}.*)$")
+_REQUIRE_RE = re.compile(
+    r"^\s*(?P<kind>require|require_relative)\s*\(?\s*[\"'](?P<path>[^\"']+)[\"']"
+)
+_SYMBOL_RE = re.compile(r":(?P<name>[A-Za-z_]\w*[?!=]?)")
+_HEREDOC_RE = re.compile(r"<<[~-]?([\"'`]?)(?P<tag>[A-Za-z_]\w*)\1")
+
+_BLOCK_START_RE = re.compile(
+    r"^(?:(?:private|protected|public|private_class_method)\s+)?"
+    r"(?:module|class|def|if|unless|while|until|case|begin|for)\b"
+)
+_LOOP_START_RE = re.compile(r"^(?:while|until|for)\b")
+_INLINE_BLOCK_RE = re.compile(
+    r"(?:=|\(|,|\|\||&&|\breturn)\s*(?:if|unless|case|begin|while|until)\b"
+)
+_DO_RE = re.compile(r"(?<![.:\w])do\b")
+_END_RE = re.compile(r"(?<![.:\w])end\b(?![?!:])")
+
+
+class RubyParser(ParserPlugin):
+    """Extract modules, classes, public methods and `attr_*` fields from Ruby sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="ruby", languages={"ruby"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _RubyWalker(content, path)
+        walker.walk()
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports=walker.imports,
+        )
+        return with_doc_ranges(result, walker.raw, prefixes=("#",), block=None)
+
+
+@dataclass
+class _Scope:
+    """An open `module`, `class`, `class << self` or `def` body."""
+
+    kind: str
+    name: str
+    line: int
+    depth: int = 0
+    docstring: str | None = None
+    signature: str = ""
+    bases: list[str] = field(default_factory=list)
+    fields: list[FieldDoc] = field(default_factory=list)
+    methods: list[tuple[MethodDoc, bool]] = field(default_factory=list)
+    visibility: str = "public"
+    hidden_names: set[str] = field(default_factory=set)
+    hidden_class_methods: set[str] = field(default_factory=set)
+    args: list[str] = field(default_factory=list)
+    hidden: bool = False
+    class_method: bool = False
+
+
+class _RubyWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        self.code = _blank_heredocs(
+            self.raw,
+            code_lines(content, line_comments=("#",), block_comments=(("=begin", "=end"),)),
+        )
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.imports: set[str] = set()
+        self.scopes: list[_Scope] = []
+        self.depth = 0
+
+    def walk(self) -> None:
+        for idx, code in enumerate(self.code):
+            line = code.strip()
+            if not line:
+                continue
+            require = _REQUIRE_RE.match(self.raw[idx])
+            if require is not None:
+                self.imports.add(_require_target(require.group("kind"), require.group("path")))
+            opened = self._declaration(idx, line) if self._in_body() else None
+            opens, closes = _block_balance(line)
+            if opened is not None:
+                opened.depth = self.depth + 1
+                self.scopes.append(opened)
+            self.depth = max(self.depth + opens - closes, 0)
+            while self.scopes and self.depth < self.scopes[-1].depth:
+                self._close(self.scopes.pop(), idx + 1)
+        while self.scopes:
+            self._close(self.scopes.pop(), len(self.code))
+
+    def _in_body(self) -> bool:
+        """True when the current line sits directly in a namespace body or at the top level."""
+        if not self.scopes:
+            return self.depth == 0
+        scope = self.scopes[-1]
+        return scope.kind != "def" and self.depth == scope.depth
+
+    def _declaration(self, idx: int, line: str) -> _Scope | None:
+        owner = self.scopes[-1] if self.scopes else None
+        if _SINGLETON_RE.match(line):
+            return _Scope(kind="singleton", name=owner.name if owner else "", line=idx)
+        if namespace := _NAMESPACE_RE.match(line):
+            return self._namespace(idx, line, namespace, owner)
+        if method := _DEF_RE.match(line):
+            return self._def(idx, line, method, owner)
+        if owner is None:
+            return None
+        if visibility := _VISIBILITY_RE.match(line):
+            owner.visibility = visibility.group("vis")
+        elif named := _VISIBILITY_NAMES_RE.match(line):
+            self._hide_names(owner, named.group("vis"), line[named.end() - 1 :])
+        elif attr := _ATTR_RE.match(line):
+            self._attributes(idx, attr, owner)
+        elif mixin := _MIXIN_RE.match(line):
+            for name in _constants(mixin.group("names")):
+                owner.bases.append(name)
+                self.imports.add(name)
+        return None
+
+    def _namespace(
+        self, idx: int, line: str, match: re.Match[str], owner: _Scope | None
+    ) -> _Scope:
+        name = match.group("name")
+        if owner is not None and owner.kind in {"module", "class"}:
+            name = f"{owner.name}::{name}"
+        superclass = match.group("super")
+        return _Scope(
+            kind=match.group("kind"),
+            name=name,
+            line=idx,
+            docstring=_doc(self.raw, idx),
+            signature=line.split(";", 1)[0].strip(),
+            bases=[superclass.removeprefix("::")] if superclass else [],
+        )
+
+    def _def(
+        self, idx: int, line: str, match: re.Match[str], owner: _Scope | None
+    ) -> _Scope | None:
+        in_singleton = owner is not None and owner.kind == "singleton"
+        class_method = bool(match.group("self")) or in_singleton
+        visibility = match.group("vis")
+        if visibility is None:
+            # `private` on its own line does not apply to `def self.name` methods.
+            visibility = owner.visibility if owner and not match.group("self") else "public"
+        rest = line[match.end() :].split(";", 1)[0]
+        params = paren_contents(rest) if rest.lstrip().startswith("(") else rest.split("=", 1)[0]
+        scope = _Scope(
+            kind="def",
+            name=match.group("name"),
+            line=idx,
+            docstring=_doc(self.raw, idx),
+            signature=_def_signature(line),
+            args=_param_names(params),
+            hidden=visibility in {"private", "private_class_method"},
+            class_method=class_method,
+        )
+        if _is_endless(rest):
+            self._close(scope, idx + 1)
+            return None
+        return scope
+
+    def _attributes(self, idx: int, match: re.Match[str], owner: _Scope) -> None:
+        if (match.group("vis") or owner.visibility) == "private":
+            return
+        doc = _doc(self.raw, idx)
+        for name in _SYMBOL_RE.findall(match.group("names")):
+            owner.fields.append(FieldDoc(name=name, type=match.group("macro"), docstring=doc))
+
+    def _hide_names(self, owner: _Scope, visibility: str, names: str) -> None:
+        symbols = set(_SYMBOL_RE.findall(names))
+        if visibility == "private_class_method":
+            owner.hidden_class_methods.update(symbols)
+        elif visibility == "private":
+            owner.hidden_names.update(symbols)
+            owner.fields = [item for item in owner.fields if item.name not in symbols]
+
+    def _close(self, scope: _Scope, end_line: int) -> None:
+        owner = self.scopes[-1] if self.scopes else None
+        if scope.kind == "def":
+            self._close_def(scope, owner, end_line)
+        elif scope.kind == "singleton":
+            if owner is not None:
+                owner.methods.extend(
+                    (method, hidden or method.name in scope.hidden_names)
+                    for method, hidden in scope.methods
+                )
+        else:
+            self.classes.append(
+                ClassDoc(
+                    name=scope.name,
+                    file=self.path,
+                    line=scope.line + 1,
+                    end_line=end_line,
+                    docstring=scope.docstring,
+                    bases=scope.bases,
+                    methods=[
+                        method
+                        for method, hidden in scope.methods
+                        if not hidden and not _hidden_by_name(scope, method)
+                    ],
+                    kind=scope.kind,
+                    signature=scope.signature,
+                    fields=scope.fields,
+                )
+            )
+
+    def _close_def(self, scope: _Scope, owner: _Scope | None, end_line: int) -> None:
+        if owner is None:
+            if not scope.hidden:
+                self.functions.append(
+                    FunctionDoc(
+                        name=scope.name,
+                        file=self.path,
+                        line=scope.line + 1,
+                        end_line=end_line,
+                        docstring=scope.docstring,
+                        args=scope.args,
+                        signature=scope.signature,
+                    )
+                )
+            return
+        method = MethodDoc(
+            name=scope.name,
+            file=self.path,
+            line=scope.line + 1,
+            end_line=end_line,
+            docstring=scope.docstring,
+            args=scope.args,
+            kind="class_method" if scope.class_method else "method",
+            signature=scope.signature,
+        )
+        owner.methods.append((method, scope.hidden))
+
+
+def _hidden_by_name(scope: _Scope, method: MethodDoc) -> bool:
+    if method.kind == "class_method":
+        return method.name in scope.hidden_class_methods
+    return method.name in scope.hidden_names
+
+
+def _block_balance(line: str) -> tuple[int, int]:
+    """Return how many `end`-terminated blocks `line` opens and closes."""
+    opens = 0
+    if _BLOCK_START_RE.match(line) and not _is_endless_def(line):
+        opens += 1
+    opens += len(_INLINE_BLOCK_RE.findall(line))
+    # `while cond do` uses `do` as a separator, not as a block opener.
+    if not _LOOP_START_RE.match(line):
+        opens += len(_DO_RE.findall(line))
+    return opens, len(_END_RE.findall(line))
+
+
+def _is_endless_def(line: str) -> bool:
+    match = _DEF_RE.match(line)
+    return match is not None and _is_endless(line[match.end() :])
+
+
+def _is_endless(rest: str) -> bool:
+    """True for Ruby 3 one-expression methods: `def name(args) = expr`."""
+    rest = rest.strip()
+    if rest.startswith("("):
+        depth = 0
+        for idx, char in enumerate(rest):
+            depth += {"(": 1, ")": -1}.get(char, 0)
+            if depth == 0:
+                rest = rest[idx + 1 :].strip()
+                break
+    return rest.startswith("=") and not rest.startswith("==")
+
+
+def _def_signature(line: str) -> str:
+    signature = line.split(";", 1)[0].strip()
+    return re.sub(r"^(?:private|protected|public|private_class_method)\s+", "", signature)
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(params):
+        name = re.split(r"[=:\s]", param.lstrip("*&").strip(), maxsplit=1)[0]
+        if name:
+            names.append(name)
+    return names
+
+
+def _constants(text: str) -> list[str]:
+    return [name.removeprefix("::") for name in re.findall(rf"(?:::)?{_CONST}", text)]
+
+
+def _require_target(kind: str, path: str) -> str:
+    # `require_relative` is resolved against the requiring file like a `./` import.
+    if kind == "require_relative" and not path.startswith(("./", "../")):
+        return f"./{path}"
+    return path
+
+
+def _blank_heredocs(raw: Sequence[str], code: list[str]) -> list[str]:
+    """Blank heredoc bodies so their text is not mistaken for Ruby keywords."""
+    result = list(code)
+    terminators: list[str] = []
+    for idx, line in enumerate(raw):
+        if terminators:
+            result[idx] = ""
+            if line.strip() == terminators[0]:
+                terminators.pop(0)
+            continue
+        if "<<" in code[idx]:
+            terminators.extend(match.group("tag") for match in _HEREDOC_RE.finditer(line))
+    return result
+
+
+def _doc(raw: Sequence[str], idx: int) -> str | None:
+    return leading_comment(raw, idx, prefixes=("#",), block=None)[0]
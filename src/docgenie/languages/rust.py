@@ -0,0 +1,291 @@
+"""Rust parser built on the shared line scanner."""
+
+from __future__ import annotations
+
+import re
+from dataclasses import replace
+from pathlib import Path
+
+from ..models import ClassDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    code_lines,
+    header_text,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    with_doc_ranges,
+)
+
+_QUALIFIERS = r"(?:(?:default|const|async|unsafe|extern(?:\s+\"[^\"]*\")?)\s+)*"
+_VISIBILITY = r"(?:(?P<vis>pub(?:\s*\([^)]*\))?)\s+)?"
+_ITEM_RE = re.compile(
+    rf"^{_VISIBILITY}{_QUALIFIERS}"
+    r"(?P<kind>fn|struct|enum|union|trait|impl|mod|use)\b"
+)
+_NAME_RE = re.compile(r"\b(?:fn|struct|enum|union|trait|mod)\s+(?P<name>[A-Za-z_]\w*)")
+_MACRO_RULES_RE = re.compile(r"^(?:#\[[^\]]*\]\s*)*macro_rules!")
+_MACRO_CALL_RE = re.compile(r"^[A-Za-z_][\w:]*!\s*[\(\[\{]")
+_IMPL_RE = re.compile(r"^impl\s*(?:<.*?>\s*)?(?P<rest>.+)$")
+_INLINE_ATTR_RE = re.compile(r"^(?:#\[[^\]]*\]\s*)+")
+
+SKIP_CFG = "rust_cfg_gated"
+SKIP_MACRO = "rust_macro"
+
+
+class RustParser(ParserPlugin):
+    """Extract public functions, types, traits and impl blocks from Rust sources.
+
+    Methods of an `impl` block are attached to the type it implements when that type is
+    declared in the same file; impls of other types are listed on their own.
+    """
+
+    def __init__(self) -> None:
+        super().__init__(name="rust", languages={"rust"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _RustWalker(content, path)
+        walker.walk(0, len(walker.code))
+        walker.attach_impls()
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports=walker.imports,
+            skipped=walker.skipped,
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=("///",),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("#["),
+        )
+
+
+class _RustWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        self.code = code_lines(content, quotes='"', char_literals=True)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+        self.impls: list[ClassDoc] = []
+        self.private_types: set[str] = set()
+        self.imports: set[str] = set()
+        self.skipped: list[str] = []
+
+    def walk(self, start: int, stop: int) -> None:
+        idx = start
+        while idx < stop:
+            line = _INLINE_ATTR_RE.sub("", self.code[idx].strip())
+            if not line or line.startswith("#"):
+                idx += 1
+                continue
+            if _MACRO_RULES_RE.match(line) or _MACRO_CALL_RE.match(line):
+                self.skipped.append(SKIP_MACRO)
+                idx = item_end(self.code, idx)[0] + 1
+                continue
+            match = _ITEM_RE.match(line)
+            if match is None:
+                idx += 1
+                continue
+            end, has_body = item_end(self.code, idx)
+            doc, attrs = self._doc_and_attrs(idx)
+            if any(attr.startswith("#[cfg(") for attr in attrs):
+                self.skipped.append(SKIP_CFG)
+            else:
+                self._handle_item(match, idx, end, has_body, doc, attrs)
+            idx = end + 1
+
+    def _handle_item(
+        self,
+        match: re.Match[str],
+        idx: int,
+        end: int,
+        has_body: bool,
+        doc: str | None,
+        attrs: list[str],
+    ) -> None:
+        kind = match.group("kind")
+        header = _INLINE_ATTR_RE.sub("", header_text(self.code, idx, end))
+        if kind == "use":
+            self.imports.add(self._use_path(idx, end))
+        elif kind == "mod":
+            if has_body:
+                self.walk(idx + 1, end)
+        elif kind == "impl":
+            self._handle_impl(idx, end, header, doc, attrs)
+        elif kind == "fn":
+            if match.group("vis"):
+                self.functions.append(self._function(FunctionDoc, idx, end, header, doc, attrs))
+        elif match.group("vis"):
+            self._handle_type(kind, idx, end, has_body, header, doc, attrs)
+        elif name_match := _NAME_RE.search(header):
+            self.private_types.add(name_match.group("name"))
+
+    def _handle_type(
+        self,
+        kind: str,
+        idx: int,
+        end: int,
+        has_body: bool,
+        header: str,
+        doc: str | None,
+        attrs: list[str],
+    ) -> None:
+        name_match = _NAME_RE.search(header)
+        if name_match is None:
+            return
+        methods: list[MethodDoc] = []
+        bases: list[str] = []
+        if kind == "trait":
+            bases = _trait_bounds(header)
+            if has_body:
+                methods = self._methods(idx + 1, end, require_pub=False)
+        self.classes.append(
+            ClassDoc(
+                name=name_match.group("name"),
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=doc,
+                bases=bases,
+                decorators=attrs,
+                methods=methods,
+                kind=kind,
+                signature=header,
+            )
+        )
+
+    def _handle_impl(
+        self, idx: int, end: int, header: str, doc: str | None, attrs: list[str]
+    ) -> None:
+        impl_match = _IMPL_RE.match(header)
+        if impl_match is None:
+            return
+        rest = re.split(r"\s+where\s+", impl_match.group("rest"), maxsplit=1)[0]
+        bases: list[str] = []
+        if " for " in rest:
+            trait, target = rest.split(" for ", 1)
+            bases = [trait.strip()]
+        else:
+            target = rest
+        name = _strip_generics(target.strip().lstrip("&").strip())
+        self.impls.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                end_line=end + 1,
+                docstring=doc,
+                bases=bases,
+                decorators=attrs,
+                # Trait impls inherit the trait's visibility, so every method is public API.
+                methods=self._methods(idx + 1, end, require_pub=not bases),
+                kind="impl",
+                signature=header,
+            )
+        )
+
+    def attach_impls(self) -> None:
+        """Merge each impl block into its type, dropping impls of private types.
+
+        A trait impl adds the trait to the type's bases. Impls of a type declared
+        elsewhere are merged into the first impl of that type instead.
+        """
+        owners = {cls.name: position for position, cls in enumerate(self.classes)}
+        for impl in self.impls:
+            position = owners.get(impl.name)
+            if position is None:
+                if impl.name not in self.private_types:
+                    owners[impl.name] = len(self.classes)
+                    self.classes.append(impl)
+                continue
+            owner = self.classes[position]
+            self.classes[position] = replace(
+                owner,
+                bases=[*owner.bases, *(base for base in impl.bases if base not in owner.bases)],
+                methods=[*owner.methods, *impl.methods],
+            )
+
+    def _methods(self, start: int, stop: int, *, require_pub: bool) -> list[MethodDoc]:
+        methods: list[MethodDoc] = []
+        idx = start
+        while idx < stop:
+            line = _INLINE_ATTR_RE.sub("", self.code[idx].strip())
+            match = _ITEM_RE.match(line)
+            if match is None:
+                if _MACRO_CALL_RE.match(line):
+                    self.skipped.append(SKIP_MACRO)
+                    idx = item_end(self.code, idx)[0] + 1
+                else:
+                    idx += 1
+                continue
+            end, _ = item_end(self.code, idx)
+            doc, attrs = self._doc_and_attrs(idx)
+            if any(attr.startswith("#[cfg(") for attr in attrs):
+                self.skipped.append(SKIP_CFG)
+            elif match.group("kind") == "fn" and (match.group("vis") or not require_pub):
+                header = _INLINE_ATTR_RE.sub("", header_text(self.code, idx, end))
+                methods.append(self._function(MethodDoc, idx, end, header, doc, attrs))
+            idx = end + 1
+        return methods
+
+    def _function(
+        self,
+        doc_type: type[FunctionDoc],
+        idx: int,
+        end: int,
+        header: str,
+        doc: str | None,
+        attrs: list[str],
+    ) -> FunctionDoc:
+        name_match = _NAME_RE.search(header)
+        name = name_match.group("name") if name_match else "<anonymous>"
+        after_name = header[name_match.end() :] if name_match else header
+        return doc_type(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            end_line=end + 1,
+            docstring=doc,
+            args=[_param_name(param) for param in split_top_level(paren_contents(after_name))],
+            decorators=attrs,
+            is_async=bool(re.search(r"\basync\s+(?:unsafe\s+)?fn\b", header)),
+            signature=header,
+        )
+
+    def _doc_and_attrs(self, idx: int) -> tuple[str | None, list[str]]:
+        return leading_comment(
+            self.raw,
+            idx,
+            prefixes=("///",),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("#["),
+        )
+
+    def _use_path(self, start: int, end: int) -> str:
+        text = " ".join(line.strip() for line in self.raw[start : end + 1])
+        text = _INLINE_ATTR_RE.sub("", text)
+        text = re.sub(r"^(?:pub(?:\s*\([^)]*\))?\s+)?use\s+", "", text)
+        return re.sub(r"\s+", " ", text.split(";", 1)[0]).strip()
+
+
+def _param_name(param: str) -> str:
+    cleaned = re.sub(r"^(?:#\[[^\]]*\]\s*)+", "", param).strip()
+    if re.fullmatch(r"&?\s*(?:'\w+\s+)?(?:mut\s+)?self(?:\s*:.*)?", cleaned):
+        return "self"
+    name = cleaned.split(":", 1)[0].strip()
+    return re.sub(r"^(?:mut|ref)\s+", "", name)
+
+
+def _strip_generics(name: str) -> str:
+    return re.split(r"[<\s]", name, maxsplit=1)[0]
+
+
+def _trait_bounds(header: str) -> list[str]:
+    match = re.search(r"\btrait\s+\w+\s*(?:<.*?>)?\s*:\s*(?P<bounds>.+?)(?:\s+where\s+|$)", header)
+    if match is None:
+        return []
+    return [bound.strip() for bound in split_top_level(match.group("bounds"), "+")]
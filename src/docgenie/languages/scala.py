@@ -0,0 +1,399 @@
+"""Scala parser for classes, case classes, traits, objects and ScalaDoc comments."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from dataclasses import replace
+from pathlib import Path
+
+from ..models import ClassDoc, FieldDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    leading_comment,
+    split_top_level,
+    statement_end,
+    with_doc_ranges,
+)
+from .kotlin import COMPANION_KIND
+
+_MODIFIER_WORDS = (
+    r"private(?:\[\w+\])?|protected(?:\[\w+\])?|override|final|sealed|abstract|implicit|lazy|"
+    r"case|inline|transparent|opaque|open|infix|package"
+)
+_MODIFIERS = rf"(?:(?:{_MODIFIER_WORDS})\s+)*"
+_ANNOTATION = r"@[\w.]+(?:\[[^\]]*\])?(?:\((?:\"[^\"]*\"|[^)\"])*\))?"
+_LEADING_ANNOTATIONS_RE = re.compile(rf"^(?:{_ANNOTATION}\s+)+")
+_ANNOTATION_RE = re.compile(_ANNOTATION)
+_TYPE_RE = re.compile(
+    rf"^(?P<mods>{_MODIFIERS})(?P<kind>class|trait|object|enum)\s+(?P<name>[A-Za-z_]\w*)"
+)
+_DEF_RE = re.compile(
+    rf"^(?P<mods>{_MODIFIERS})def\s+"
+    r"(?P<name>[A-Za-z_]\w*|`[^`]+`|[!#%&*+\-/:<=>?@\\^|~]+)"
+)
+_CTOR_PREFIX_RE = re.compile(rf"^\s*(?:{_ANNOTATION}\s*)*(?:(?:private|protected)\s*)?")
+_CTOR_PARAM_RE = re.compile(
+    rf"^(?P<mods>{_MODIFIERS})(?P<binding>(?:val|var)\s+)?(?P<name>\w+)\s*:\s*"
+    r"(?P<type>[^=]+?)\s*(?:=.*)?$"
+)
+_PARAM_TAG_RE = re.compile(r"^@param\s+(?P<name>\w+)\s*(?P<text>.*)$")
+_IMPORT_RE = re.compile(r"^\s*import\s+(?P<body>.+)$", re.MULTILINE)
+_HEADER_CONTINUES_RE = re.compile(r"^(?:extends|with|derives)\b")
+_HIDDEN_RE = re.compile(r"\bprivate\b")
+_OBJECT_KINDS = ("object", "case object", "package object")
+
+
+class ScalaParser(ParserPlugin):
+    """Extract classes, case classes, traits, objects and defs from Scala sources."""
+
+    def __init__(self) -> None:
+        super().__init__(name="scala", languages={"scala"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _ScalaWalker(content, path)
+        walker.walk(0, len(walker.code), depth=0, owner=None)
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.group_companions(),
+            imports=_imports(content),
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=(),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+
+
+class _ScalaWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        # Triple-quoted strings span lines; an ordinary `"` string never does.
+        self.code = code_lines(content, quotes='"', multiline_quotes='"', char_literals=True)
+        self.depths = brace_depths(self.code)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+
+    def walk(self, start: int, stop: int, *, depth: int, owner: str | None) -> None:
+        """Record types declared at `depth` and, at the top level, Scala 3 defs."""
+        idx = start
+        while idx < stop:
+            if self.depths[idx] != depth:
+                idx += 1
+                continue
+            line, inline_annotations = _strip_annotations(self.code[idx].strip())
+            if not line:
+                idx += 1
+                continue
+            end = _declaration_end(self.code, idx)
+            if _is_hidden(line):
+                idx = end + 1
+                continue
+            type_match = _TYPE_RE.match(line)
+            def_match = _DEF_RE.match(line)
+            if type_match is not None:
+                self._handle_type(type_match, idx, end, depth, owner, inline_annotations)
+            elif owner is None and def_match is not None:
+                self.functions.append(
+                    self._function(FunctionDoc, def_match, idx, end, inline_annotations)
+                )
+            idx = end + 1
+
+    def group_companions(self) -> list[ClassDoc]:
+        """Fold each companion `object` into the class or trait of the same name.
+
+        Scala code calls companion members through the type (`User.guest`), so they
+        are listed there as `companion_method`s instead of under a separate object.
+        """
+        types = {cls.name for cls in self.classes if cls.kind not in _OBJECT_KINDS}
+        companions = {
+            cls.name: cls for cls in self.classes if cls.kind == "object" and cls.name in types
+        }
+        grouped: list[ClassDoc] = []
+        for cls in self.classes:
+            companion = companions.get(cls.name)
+            if companion is None:
+                grouped.append(cls)
+            elif companion is not cls:
+                members = [replace(method, kind=COMPANION_KIND) for method in companion.methods]
+                grouped.append(replace(cls, methods=cls.methods + members))
+        return grouped
+
+    def _handle_type(
+        self,
+        match: re.Match[str],
+        idx: int,
+        end: int,
+        depth: int,
+        owner: str | None,
+        inline_annotations: list[str],
+    ) -> None:
+        header, _ = _signature(self.code, idx, end)
+        header = _LEADING_ANNOTATIONS_RE.sub("", header)
+        doc, annotations = self._scaladoc(idx)
+        name = match.group("name")
+        qualified = name if owner is None else f"{owner}.{name}"
+        kind = _kind(match)
+        header_match = _TYPE_RE.match(header)
+        params, bases = _split_header(header, header_match.end() if header_match else 0)
+        has_body = "{" in "".join(self.code[idx : end + 1])
+        self.classes.append(
+            ClassDoc(
+                name=qualified,
+                file=self.path,
+                line=idx + 1,
+                docstring=_strip_param_tags(doc) if params is not None else doc,
+                bases=bases,
+                decorators=annotations + inline_annotations,
+                methods=self._members(idx, end, depth + 1) if has_body else [],
+                kind=kind,
+                signature=header,
+                fields=_constructor_fields(params, doc, case=kind.startswith("case")),
+                end_line=end + 1,
+            )
+        )
+        if has_body:
+            self.walk(idx + 1, end, depth=depth + 1, owner=qualified)
+
+    def _members(self, start: int, end: int, depth: int) -> list[MethodDoc]:
+        methods: list[MethodDoc] = []
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, inline_annotations = _strip_annotations(self.code[idx].strip())
+            if not line:
+                idx += 1
+                continue
+            member_end = _declaration_end(self.code, idx)
+            def_match = _DEF_RE.match(line)
+            if def_match is not None and not _is_hidden(line):
+                methods.append(
+                    self._function(MethodDoc, def_match, idx, member_end, inline_annotations)
+                )
+            idx = member_end + 1
+        return methods
+
+    def _function(
+        self,
+        doc_type: type[FunctionDoc],
+        match: re.Match[str],
+        idx: int,
+        end: int,
+        inline_annotations: list[str],
+    ) -> FunctionDoc:
+        header, has_body = _signature(self.code, idx, end)
+        header = _LEADING_ANNOTATIONS_RE.sub("", header)
+        doc, annotations = self._scaladoc(idx)
+        name = match.group("name").strip("`")
+        after_name = header[header.find(match.group("name")) + len(match.group("name")) :]
+        return doc_type(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            docstring=doc,
+            args=[arg for params in _param_lists(after_name) for arg in _param_names(params)],
+            decorators=annotations + inline_annotations,
+            # Only traits and abstract classes may leave a def without a body.
+            kind=doc_type.kind if has_body else "abstract_method",
+            signature=header,
+            end_line=end + 1,
+        )
+
+    def _scaladoc(self, idx: int) -> tuple[str | None, list[str]]:
+        doc, annotations = leading_comment(
+            self.raw, idx, prefixes=(), block=("/**", "*/"), skip=lambda line: line.startswith("@")
+        )
+        return doc, [found for line in annotations for found in _ANNOTATION_RE.findall(line)]
+
+
+def _declaration_end(code: Sequence[str], start: int) -> int:
+    """Find the last line of a declaration, following `extends`/`with` lines below it."""
+    end = statement_end(code, start)
+    while True:
+        following = next((idx for idx in range(end + 1, len(code)) if code[idx].strip()), None)
+        if following is None or not _HEADER_CONTINUES_RE.match(code[following].strip()):
+            return end
+        end = statement_end(code, following)
+
+
+def _signature(code: Sequence[str], start: int, end: int) -> tuple[str, bool]:
+    """Collapse a declaration header and report whether a body (`=` or `{`) follows it."""
+    text = " ".join(line.strip() for line in code[start : end + 1])
+    depth = 0
+    for idx, char in enumerate(text):
+        previous, following = text[idx - 1 : idx], text[idx + 1 : idx + 2]
+        if char in "([":
+            depth += 1
+        elif char in ")]":
+            depth -= 1
+        elif depth <= 0 and char == "{":
+            return _collapse(text[:idx]), True
+        elif (
+            depth <= 0
+            and char == "="
+            and previous not in ("!", "<", ">", "=")
+            and following not in (">", "=")
+        ):
+            return _collapse(text[:idx]), True
+    return _collapse(text), False
+
+
+def _split_header(header: str, name_end: int) -> tuple[list[str] | None, list[str]]:
+    """Return the primary constructor parameter lists and the `extends ... with ...` types."""
+    rest = _skip_brackets(header[name_end:])
+    params: list[str] | None = None
+    prefix = _CTOR_PREFIX_RE.match(rest)
+    if prefix is not None and rest[prefix.end() :].startswith("("):
+        rest = rest[prefix.end() :]
+        params = _param_lists(rest)
+        for _ in params:
+            rest = rest[_group_end(rest) :].lstrip()
+    rest = rest.strip()
+    if not rest.startswith("extends"):
+        return params, []
+    listed = re.split(r"\s+derives\s+", rest[len("extends") :], maxsplit=1)[0]
+    bases: list[str] = []
+    for clause in re.split(r"\s+with\s+", listed):
+        # `Base(arg)` calls the superclass constructor; only the type name is a base.
+        bases.extend(_strip_call(item) for item in split_top_level(clause) if item)
+    return params, bases
+
+
+def _param_lists(text: str) -> list[str]:
+    """Return the contents of each `(...)` parameter list, skipping leading `[T]` clauses."""
+    rest = _skip_brackets(text)
+    lists: list[str] = []
+    while rest.startswith("("):
+        end = _group_end(rest)
+        lists.append(rest[1 : end - 1])
+        rest = rest[end:].lstrip()
+    return lists
+
+
+def _param_names(params: str) -> list[str]:
+    """Return parameter names; `implicit`/`using` clauses keep that keyword as a marker."""
+    stripped = params.strip()
+    marker = next((word for word in ("implicit", "using") if stripped.startswith(word + " ")), "")
+    if marker:
+        stripped = stripped[len(marker) :]
+    names: list[str] = []
+    for param in split_top_level(stripped):
+        cleaned = _LEADING_ANNOTATIONS_RE.sub("", param.strip())
+        cleaned = re.sub(rf"^{_MODIFIERS}(?:val\s+|var\s+)?", "", cleaned)
+        name = cleaned.split(":", 1)[0].strip()
+        if name:
+            names.append(f"{marker} {name}" if marker else name)
+    return names
+
+
+def _constructor_fields(
+    params: list[str] | None, doc: str | None, *, case: bool
+) -> list[FieldDoc]:
+    """Turn constructor `val`/`var` parameters (every parameter of a case class) into fields.
+
+    Each field is documented by the class's ScalaDoc `@param` tag of the same name.
+    Implicit and `using` parameter lists are dependencies, not fields.
+    """
+    if params is None:
+        return []
+    notes = _param_notes(doc)
+    fields: list[FieldDoc] = []
+    explicit = [group for group in params if not group.strip().startswith(("implicit ", "using "))]
+    for param in (item for group in explicit for item in split_top_level(group)):
+        text = _LEADING_ANNOTATIONS_RE.sub("", param.strip())
+        match = _CTOR_PARAM_RE.match(text)
+        if match is None or _HIDDEN_RE.search(match.group("mods")):
+            continue
+        if not case and not match.group("binding"):
+            continue
+        fields.append(
+            FieldDoc(
+                name=match.group("name"),
+                type=match.group("type"),
+                docstring=notes.get(match.group("name")),
+            )
+        )
+    return fields
+
+
+def _param_notes(doc: str | None) -> dict[str, str]:
+    notes: dict[str, str] = {}
+    for line in (doc or "").splitlines():
+        match = _PARAM_TAG_RE.match(line.strip())
+        if match is not None:
+            notes[match.group("name")] = match.group("text").strip()
+    return notes
+
+
+def _strip_param_tags(doc: str | None) -> str | None:
+    if doc is None:
+        return None
+    kept = [line for line in doc.splitlines() if not _PARAM_TAG_RE.match(line.strip())]
+    return "\n".join(kept).strip() or None
+
+
+def _imports(content: str) -> set[str]:
+    """Expand `import a.b.{C, D => E}` selectors into `a.b.C` and `a.b.D`."""
+    found: set[str] = set()
+    for match in _IMPORT_RE.finditer(content):
+        for clause in split_top_level(match.group("body").split("//", 1)[0]):
+            prefix, brace, selectors = clause.partition("{")
+            if not brace:
+                found.add(clause.strip())
+                continue
+            for selector in split_top_level(selectors.rstrip("} ")):
+                name = re.split(r"\s*(?:=>|\bas\b)\s*", selector.strip(), maxsplit=1)[0]
+                found.add(prefix.strip() + name)
+    return found
+
+
+def _kind(match: re.Match[str]) -> str:
+    kind = match.group("kind")
+    modifiers = match.group("mods").split()
+    for modifier in ("case", "sealed", "abstract", "implicit", "package"):
+        if modifier in modifiers:
+            return f"{modifier} {kind}"
+    return kind
+
+
+def _is_hidden(line: str) -> bool:
+    prefix = re.match(_MODIFIERS, line)
+    return bool(prefix and _HIDDEN_RE.search(prefix.group(0)))
+
+
+def _skip_brackets(text: str) -> str:
+    """Drop a leading `[...]` type parameter clause."""
+    text = text.lstrip()
+    return text[_group_end(text) :].lstrip() if text.startswith("[") else text
+
+
+def _group_end(text: str) -> int:
+    """Return the index just past the bracket group that opens `text`."""
+    depth = 0
+    for idx, char in enumerate(text):
+        depth += {"(": 1, "[": 1, ")": -1, "]": -1}.get(char, 0)
+        if depth == 0:
+            return idx + 1
+    return len(text)
+
+
+def _strip_annotations(line: str) -> tuple[str, list[str]]:
+    match = _LEADING_ANNOTATIONS_RE.match(line)
+    if match is None:
+        return ("", []) if _ANNOTATION_RE.fullmatch(line) else (line, [])
+    return line[match.end() :], _ANNOTATION_RE.findall(match.group(0))
+
+
+def _strip_call(base: str) -> str:
+    return re.sub(r"\s*\(.*\)$", "", base.strip())
+
+
+def _collapse(text: str) -> str:
+    return re.sub(r"\s+", " ", text).strip()
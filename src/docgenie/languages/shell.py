@@ -0,0 +1,214 @@
+"""Shell script parser for functions and documented configuration variables.
+
+`name() {` and `function name {` definitions are functions, documented by the `#`
+comment block directly above them; a leading `# Usage: deploy <env>` line of that
+block becomes the function's usage. Top-level assignments with a comment above them
+(`NAME=value`, `export NAME=value`, `readonly NAME=value` or the `: "${NAME:=value}"`
+default idiom) are reported as constants, valued by the default in the last form. The
+comment block at the top of the script, after the shebang, is the module doc.
+"""
+
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+from ..models import ConstantDoc, FunctionDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import code_lines, leading_comment, with_doc_ranges
+
+# Strings may span lines; heredoc bodies are blanked separately.
+_CODE_OPTIONS = {
+    "line_comments": ("#",),
+    "block_comments": (),
+    "multiline_quotes": "\"'",
+}
+
+_NAME = r"[A-Za-z_][\w:.-]*"
+_FUNCTION_RE = re.compile(
+    rf"^(?:function\s+(?P<keyword>{_NAME})\s*(?:\(\s*\))?|(?P<name>{_NAME})\s*\(\s*\))"
+    r"\s*(?P<body>[{(])?"
+)
+_ASSIGN_RE = re.compile(
+    r"^(?:(?:export|readonly|declare(?:\s+-\w+)*)\s+)?(?P<name>[A-Za-z_]\w*)="
+)
+_DEFAULT_RE = re.compile(
+    r"""^:\s+["']?\$\{(?P<name>[A-Za-z_]\w*):?[=-](?P<default>[^}]*)\}"""
+)
+_SOURCE_RE = re.compile(r"""^(?:source|\.)\s+["']?(?P<path>[^\s"'$;]+)["']?\s*(?:;|$)""")
+_HEREDOC_RE = re.compile(r"""<<-?\s*(?P<quote>["']?)(?P<tag>\w+)(?P=quote)""")
+# `#` only starts a comment at the beginning of a word; `$#`, `${#x}` and `a#b` are code.
+_WORD_HASH_RE = re.compile(r"(?<=[^\s;|&(){}])#")
+_USAGE_RE = re.compile(r"^usage:\s*(?P<usage>.*)$", re.IGNORECASE)
+
+
+class ShellParser(ParserPlugin):
+    """Extract functions and documented configuration variables from shell scripts."""
+
+    def __init__(self) -> None:
+        super().__init__(name="shell", languages={"shell"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        raw = content.splitlines()
+        code = code_lines(_code_text(raw), **_CODE_OPTIONS)
+        # The shebang is never part of a doc comment.
+        docs = ["", *raw[1:]] if raw and raw[0].startswith("#!") else raw
+        functions: list[FunctionDoc] = []
+        constants: list[ConstantDoc] = []
+        imports: set[str] = set()
+        idx = 0
+        while idx < len(code):
+            line = code[idx].strip()
+            if source := _SOURCE_RE.match(raw[idx].strip()):
+                imports.add(source.group("path"))
+            if match := _FUNCTION_RE.match(line):
+                end = self._function(docs, code, idx, match, path, functions)
+                if end is not None:
+                    idx = end + 1
+                    continue
+            if assignment := _assignment(raw[idx], code[idx]):
+                variable = self._variable(docs, idx, *assignment, path)
+                if variable is not None and (self.include_private or not variable.private):
+                    constants.append(variable)
+            idx += 1
+
+        result = ParseResult(
+            functions=functions,
+            imports=imports,
+            module_doc=_module_doc(raw),
+            constants=constants,
+        )
+        return with_doc_ranges(result, docs, prefixes=("#",), block=None)
+
+    def _function(
+        self,
+        raw: list[str],
+        code: list[str],
+        idx: int,
+        match: re.Match[str],
+        path: Path,
+        functions: list[FunctionDoc],
+    ) -> int | None:
+        """Record the function declared at `idx` and return its last line, or None."""
+        name = match.group("keyword") or match.group("name")
+        body_line, opener = idx, match.group("body")
+        column = len(code[idx]) - len(code[idx].lstrip()) + match.start("body")
+        if opener is None:
+            # The body may open on the next line: `deploy()` then `{`.
+            body_line = next(
+                (pos for pos in range(idx + 1, len(code)) if code[pos].strip()), len(code)
+            )
+            if body_line >= len(code) or code[body_line].strip()[:1] not in ("{", "("):
+                return None
+            column = len(code[body_line]) - len(code[body_line].lstrip())
+            opener = code[body_line][column]
+        end = _body_end(code, body_line, column, opener)
+        docstring, usage = _split_usage(leading_comment(raw, idx, prefixes=("#",), block=None)[0])
+        function = FunctionDoc(
+            name=name,
+            file=path,
+            line=idx + 1,
+            end_line=end + 1,
+            docstring=docstring,
+            signature=f"function {name}" if match.group("keyword") else f"{name}()",
+            private=name.startswith("_"),
+            usage=usage,
+        )
+        if self.include_private or not function.private:
+            functions.append(function)
+        return end
+
+    def _variable(
+        self, raw: list[str], idx: int, name: str, expression: str, path: Path
+    ) -> ConstantDoc | None:
+        docstring = leading_comment(raw, idx, prefixes=("#",), block=None)[0]
+        if docstring is None:
+            return None
+        return ConstantDoc(
+            name=name,
+            file=path,
+            line=idx + 1,
+            expression=expression.strip(),
+            value=_literal(expression.strip()),
+            docstring=docstring,
+            private=name.startswith("_"),
+        )
+
+
+def _assignment(raw: str, code: str) -> tuple[str, str] | None:
+    """Return the variable a line assigns and its value expression, or None."""
+    if default := _DEFAULT_RE.match(raw.strip()):
+        return default.group("name"), default.group("default")
+    if assigned := _ASSIGN_RE.match(code.strip()):
+        start = len(code) - len(code.lstrip()) + assigned.end()
+        return assigned.group("name"), raw[start : len(code.rstrip())]
+    return None
+
+
+def _literal(expression: str) -> str | None:
+    """Return the text a shell word expands to, or None when it substitutes anything."""
+    if len(expression) > 1 and expression[0] == expression[-1] == "'":
+        return expression[1:-1]
+    if len(expression) > 1 and expression[0] == expression[-1] == '"':
+        expression = expression[1:-1]
+    elif any(char.isspace() or char in "'\"" for char in expression):
+        return None
+    return None if any(char in expression for char in "$`\\") else expression
+
+
+def _code_text(raw: list[str]) -> str:
+    """Return the script with heredoc bodies blanked and word-internal `#` neutralized."""
+    lines: list[str] = []
+    tag: str | None = None
+    for line in raw:
+        if tag is not None:
+            if line.strip() == tag:
+                tag = None
+            lines.append("")
+            continue
+        lines.append(_WORD_HASH_RE.sub("_", line))
+        if heredoc := _HEREDOC_RE.search(line):
+            tag = heredoc.group("tag")
+    return "\n".join(lines)
+
+
+def _body_end(code: list[str], start: int, column: int, opener: str) -> int:
+    """Return the line closing the `{` or `(` body opened at `column` of line `start`."""
+    closer = "}" if opener == "{" else ")"
+    depth = 0
+    for idx in range(start, len(code)):
+        for char in code[idx][column if idx == start else 0 :]:
+            if char == opener:
+                depth += 1
+            elif char == closer:
+                depth -= 1
+                if depth == 0:
+                    return idx
+    return len(code) - 1
+
+
+def _split_usage(doc: str | None) -> tuple[str | None, str | None]:
+    """Split a leading `Usage:` line off a function's comment block."""
+    if doc is None:
+        return None, None
+    first, _, rest = doc.partition("\n")
+    match = _USAGE_RE.match(first.strip())
+    if match is None:
+        return doc, None
+    return rest.strip() or None, match.group("usage").strip() or None
+
+
+def _module_doc(raw: list[str]) -> str | None:
+    """Return the comment block opening the script, skipping the shebang.
+
+    A block directly above the first declaration documents that declaration
+    instead, so the header needs a blank line after it.
+    """
+    idx = 1 if raw and raw[0].startswith("#!") else 0
+    collected: list[str] = []
+    while idx < len(raw) and raw[idx].strip().startswith("#"):
+        collected.append(raw[idx].strip()[1:].removeprefix(" ").rstrip())
+        idx += 1
+    if not collected or (idx < len(raw) and raw[idx].strip()):
+        return None
+    return "\n".join(collected).strip() or None
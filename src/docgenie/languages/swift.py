@@ -0,0 +1,241 @@
+"""Swift parser for types, extensions, functions and `///` doc comments."""
+
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+from ..models import ClassDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    statement_end,
+    with_doc_ranges,
+)
+
+_MODIFIER_WORDS = (
+    "public|open|internal|fileprivate|private|final|static|override|mutating|nonmutating|"
+    "convenience|required|dynamic|lazy|weak|unowned|indirect|nonisolated|optional"
+)
+_MODIFIERS = rf"(?:(?:{_MODIFIER_WORDS})(?:\(set\))?\s+)*"
+_ATTRIBUTE = r'@\w+(?:\((?:"[^"]*"|[^)"])*\))?'
+_LEADING_ATTRIBUTES_RE = re.compile(rf"^(?:{_ATTRIBUTE}\s+)+")
+_ATTRIBUTE_RE = re.compile(_ATTRIBUTE)
+_TYPE_RE = re.compile(
+    rf"^{_MODIFIERS}(?P<kind>struct|class|enum|protocol|extension|actor)\s+"
+    r"(?!(?:func|var|let|init|subscript)\b)(?P<name>[A-Za-z_][\w.]*)"
+)
+_FUNC_RE = re.compile(rf"^{_MODIFIERS}(?:class\s+{_MODIFIERS})?func\s+(?P<name>[^\s(<]+)")
+_INIT_RE = re.compile(rf"^{_MODIFIERS}(?:class\s+{_MODIFIERS})?(?P<name>init)[?!]?\s*[(<]")
+_PROPERTY_RE = re.compile(
+    rf"^{_MODIFIERS}(?:class\s+{_MODIFIERS})?var\s+(?P<name>[A-Za-z_]\w*)"
+    r"\s*(?::\s*(?P<type>.+))?$"
+)
+_IMPORT_RE = re.compile(
+    r"^\s*(?:@\w+\s+)*import\s+(?:(?:typealias|struct|class|enum|protocol|let|var|func)\s+)?"
+    r"(?P<name>[\w.]+)",
+    re.MULTILINE,
+)
+_MODIFIERS_RE = re.compile(_MODIFIERS)
+_HIDDEN_RE = re.compile(r"\b(?:private|fileprivate)\b(?!\()")
+_CONFORMANCE_RE = re.compile(
+    r"\b(?:struct|class|enum|protocol|extension|actor)\s+[\w.]+\s*(?:<.*?>)?\s*:\s*(?P<list>.+)"
+)
+
+
+class SwiftParser(ParserPlugin):
+    """Extract types, extensions, functions and documented computed properties from Swift."""
+
+    def __init__(self) -> None:
+        super().__init__(name="swift", languages={"swift"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        walker = _SwiftWalker(content, path)
+        walker.walk(0, len(walker.code), depth=0, owner=None)
+        result = ParseResult(
+            functions=walker.functions,
+            classes=walker.classes,
+            imports={match.group("name") for match in _IMPORT_RE.finditer(content)},
+        )
+        return with_doc_ranges(
+            result,
+            walker.raw,
+            prefixes=("///",),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+
+
+class _SwiftWalker:
+    def __init__(self, content: str, path: Path) -> None:
+        self.path = path
+        self.raw = content.splitlines()
+        self.code = code_lines(content, quotes='"')
+        self.depths = brace_depths(self.code)
+        self.functions: list[FunctionDoc] = []
+        self.classes: list[ClassDoc] = []
+
+    def walk(self, start: int, stop: int, *, depth: int, owner: str | None) -> None:
+        """Record types declared at `depth` and, at the top level, free functions."""
+        idx = start
+        while idx < stop:
+            if self.depths[idx] != depth:
+                idx += 1
+                continue
+            line, inline_attrs = _strip_attributes(self.code[idx].strip())
+            if not line or _is_hidden(line):
+                idx = self._skip(idx, line)
+                continue
+            end = statement_end(self.code, idx, question_continues=False)
+            match = _TYPE_RE.match(line)
+            if match is not None:
+                self._handle_type(match, idx, end, depth, owner, inline_attrs)
+            elif owner is None and _FUNC_RE.match(line):
+                self.functions.append(self._function(FunctionDoc, idx, end, inline_attrs))
+            idx = end + 1
+
+    def _handle_type(
+        self,
+        match: re.Match[str],
+        idx: int,
+        end: int,
+        depth: int,
+        owner: str | None,
+        inline_attrs: list[str],
+    ) -> None:
+        kind = match.group("kind")
+        header = _LEADING_ATTRIBUTES_RE.sub("", header_text(self.code, idx, end))
+        doc, attrs = self._doc_and_attrs(idx)
+        # Extensions document the extended type, so they keep its name unprefixed.
+        name = match.group("name")
+        if owner is not None and kind != "extension":
+            name = f"{owner}.{name}"
+        has_body = "{" in "".join(self.code[idx : end + 1])
+        self.classes.append(
+            ClassDoc(
+                name=name,
+                file=self.path,
+                line=idx + 1,
+                docstring=doc,
+                bases=_conformances(header),
+                decorators=attrs + inline_attrs,
+                methods=self._members(idx, end, depth + 1, is_protocol=kind == "protocol")
+                if has_body
+                else [],
+                kind=kind,
+                signature=header,
+                end_line=end + 1,
+            )
+        )
+        if has_body:
+            self.walk(idx + 1, end, depth=depth + 1, owner=name)
+
+    def _members(self, start: int, end: int, depth: int, *, is_protocol: bool) -> list[MethodDoc]:
+        methods: list[MethodDoc] = []
+        idx = start + 1
+        while idx < end:
+            if self.depths[idx] != depth or not self.code[idx].strip():
+                idx += 1
+                continue
+            line, inline_attrs = _strip_attributes(self.code[idx].strip())
+            if not line or _is_hidden(line):
+                idx = self._skip(idx, line)
+                continue
+            member_end = statement_end(self.code, idx, question_continues=False)
+            if _TYPE_RE.match(line):
+                # Nested types are walked separately.
+                pass
+            elif _FUNC_RE.match(line) or _INIT_RE.match(line):
+                methods.append(self._function(MethodDoc, idx, member_end, inline_attrs))
+            elif (prop := _PROPERTY_RE.match(header_text(self.code, idx, member_end))) and (
+                is_protocol or "{" in "".join(self.code[idx : member_end + 1])
+            ):
+                doc, attrs = self._doc_and_attrs(idx)
+                # Only documented computed properties (and protocol requirements) are API.
+                if doc and "=" not in (prop.group("type") or ""):
+                    methods.append(
+                        MethodDoc(
+                            name=prop.group("name"),
+                            file=self.path,
+                            line=idx + 1,
+                            docstring=doc,
+                            decorators=attrs + inline_attrs,
+                            kind="property",
+                            signature=_LEADING_ATTRIBUTES_RE.sub(
+                                "", header_text(self.code, idx, member_end)
+                            ),
+                            end_line=member_end + 1,
+                        )
+                    )
+            idx = member_end + 1
+        return methods
+
+    def _function(
+        self, doc_type: type[FunctionDoc], idx: int, end: int, inline_attrs: list[str]
+    ) -> FunctionDoc:
+        header = _LEADING_ATTRIBUTES_RE.sub("", header_text(self.code, idx, end))
+        match = _FUNC_RE.match(header) or _INIT_RE.match(header)
+        name = match.group("name") if match else "<anonymous>"
+        after_name = header[match.end("name") :] if match else header
+        doc, attrs = self._doc_and_attrs(idx)
+        return doc_type(
+            name=name,
+            file=self.path,
+            line=idx + 1,
+            docstring=doc,
+            args=[_param_name(param) for param in split_top_level(paren_contents(after_name))],
+            decorators=attrs + inline_attrs,
+            is_async=bool(re.search(r"\)\s*async\b", header)),
+            kind="constructor" if name == "init" else doc_type.kind,
+            signature=header,
+            end_line=end + 1,
+        )
+
+    def _doc_and_attrs(self, idx: int) -> tuple[str | None, list[str]]:
+        doc, attrs = leading_comment(
+            self.raw,
+            idx,
+            prefixes=("///",),
+            block=("/**", "*/"),
+            skip=lambda line: line.startswith("@"),
+        )
+        return doc, [found for attr in attrs for found in _ATTRIBUTE_RE.findall(attr)]
+
+    def _skip(self, idx: int, line: str) -> int:
+        return idx + 1 if not line else statement_end(self.code, idx, question_continues=False) + 1
+
+
+def _is_hidden(line: str) -> bool:
+    """Return True for `private`/`fileprivate` declarations; `private(set)` stays visible."""
+    prefix = _MODIFIERS_RE.match(line)
+    return bool(prefix and _HIDDEN_RE.search(prefix.group(0)))
+
+
+def _strip_attributes(line: str) -> tuple[str, list[str]]:
+    match = _LEADING_ATTRIBUTES_RE.match(line)
+    if match is None:
+        # A lone attribute line belongs to the declaration below it.
+        return ("", []) if _ATTRIBUTE_RE.fullmatch(line) else (line, [])
+    return line[match.end() :], _ATTRIBUTE_RE.findall(match.group(0))
+
+
+def _conformances(header: str) -> list[str]:
+    """Return the superclass and protocols after `:`, ignoring generics and `where`."""
+    match = _CONFORMANCE_RE.search(header)
+    if match is None:
+        return []
+    listed = re.split(r"\s+where\s+", match.group("list"), maxsplit=1)[0]
+    return [base for base in split_top_level(listed) if base]
+
+
+def _param_name(param: str) -> str:
+    """Return the argument label callers write, or the parameter name for `_` labels."""
+    names = _ATTRIBUTE_RE.sub("", param).split(":", 1)[0].split()
+    if not names:
+        return param.strip()
+    return names[1] if names[0] == "_" and len(names) > 1 else names[0]
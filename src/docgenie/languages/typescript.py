@@ -0,0 +1,320 @@
+"""TypeScript parser for exported declarations and their TSDoc comments."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Sequence
+from pathlib import Path
+
+from ..models import ClassDoc, FunctionDoc, MethodDoc, ParseResult
+from ..parsers import ParserPlugin
+from ._scan import (
+    brace_depths,
+    code_lines,
+    header_text,
+    heritage,
+    item_end,
+    leading_comment,
+    paren_contents,
+    split_top_level,
+    statement_end,
+    with_doc_ranges,
+)
+
+_EXPORT = r"^export\s+(?P<default>default\s+)?(?:declare\s+)?"
+_FUNCTION_RE = re.compile(
+    _EXPORT + r"(?P<async>async\s+)?function\s*\*?\s*(?P<name>[A-Za-z_$][\w$]*)?"
+)
+_CLASS_RE = re.compile(_EXPORT + r"(?:abstract\s+)?class\b\s*(?P<name>[A-Za-z_$][\w$]*)?")
+_INTERFACE_RE = re.compile(_EXPORT + r"interface\s+(?P<name>[A-Za-z_$][\w$]*)")
+_TYPE_RE = re.compile(_EXPORT + r"type\s+(?P<name>[A-Za-z_$][\w$]*)")
+_ENUM_RE = re.compile(_EXPORT + r"(?:const\s+)?enum\s+(?P<name>[A-Za-z_$][\w$]*)")
+_CONST_RE = re.compile(_EXPORT + r"(?:const|let|var)\s+(?P<name>[A-Za-z_$][\w$]*)")
+_DEFAULT_ARROW_RE = re.compile(
+    r"^export\s+default\s+(?:async\s+)?(?:\([^)]*\)|[A-Za-z_$][\w$]*)\s*=>"
+)
+
+# `= async (a, b): T =>`, `= x =>` and `= function (...)` all count as functions.
+_ARROW_INIT_RE = re.compile(
+    r"=\s*(?P<async>async\s+)?(?:<[^=]*?>\s*)?"
+    r"(?P<params>\((?P<inner>.*?)\)|[A-Za-z_$][\w$]*)\s*(?::\s*[^=]+?)?\s*=>",
+    re.DOTALL,
+)
+_FUNCTION_INIT_RE = re.compile(r"=\s*(?P<async>async\s+)?function\b")
+
+_MODIFIERS = (
+    r"(?:(?:public|protected|private|static|readonly|abstract|override|async|declare|get|set)\s+)*"
+)
+_METHOD_RE = re.compile(
+    rf"^{_MODIFIERS}\*?\s*(?P<name>#?[A-Za-z_$][\w$]*)\s*\??\s*(?:<[^>]*>)?\s*\("
+)
+_PROPERTY_ARROW_RE = re.compile(
+    rf"^{_MODIFIERS}(?P<name>#?[A-Za-z_$][\w$]*)\s*(?::[^=]+)?{_ARROW_INIT_RE.pattern}",
+    re.DOTALL,
+)
+_NOT_METHODS = {"if", "for", "while", "switch", "catch", "return", "super", "function"}
+
+_FROM_RE = re.compile(
+    r"""^\s*(?:import|export)\s+(?:type\s+)?[^;'"]*?\bfrom\s*['"](?P<spec>[^'"]+)['"]""",
+    re.MULTILINE,
+)
+_SIDE_EFFECT_IMPORT_RE = re.compile(r"""^\s*import\s*['"](?P<spec>[^'"]+)['"]""", re.MULTILINE)
+_REQUIRE_RE = re.compile(r"""\brequire\s*\(\s*['"](?P<spec>[^'"]+)['"]\s*\)""")
+
+
+class TypeScriptParser(ParserPlugin):
+    """Extract exported functions, classes, interfaces, types and constants."""
+
+    def __init__(self) -> None:
+        super().__init__(name="typescript", languages={"typescript"}, priority=10)
+
+    def parse(self, content: str, path: Path, language: str) -> ParseResult:
+        raw = content.splitlines()
+        code = code_lines(content, quotes="\"'`", multiline_quotes="`")
+        depths = brace_depths(code)
+        functions: list[FunctionDoc] = []
+        classes: list[ClassDoc] = []
+
+        idx = 0
+        while idx < len(code):
+            line = code[idx].strip()
+            if depths[idx] != 0 or not line.startswith("export"):
+                idx += 1
+                continue
+            doc, decorators = _doc_comment(raw, idx)
+            end = idx
+
+            if match := _FUNCTION_RE.match(line):
+                end, _ = item_end(code, idx)
+                header = header_text(code, idx, end)
+                functions.append(
+                    FunctionDoc(
+                        name=match.group("name") or "default",
+                        file=path,
+                        line=idx + 1,
+                        end_line=end + 1,
+                        docstring=doc,
+                        args=_param_names(paren_contents(header[match.end() :])),
+                        decorators=decorators,
+                        is_async=bool(match.group("async")),
+                        signature=header,
+                    )
+                )
+            elif match := _CLASS_RE.match(line):
+                end, has_body = item_end(code, idx)
+                header = header_text(code, idx, end)
+                methods = _class_methods(raw, code, depths, idx, end, path) if has_body else []
+                classes.append(
+                    ClassDoc(
+                        name=match.group("name") or "default",
+                        file=path,
+                        line=idx + 1,
+                        end_line=end + 1,
+                        docstring=doc,
+                        bases=heritage(header),
+                        decorators=decorators,
+                        methods=methods,
+                        signature=header,
+                    )
+                )
+            elif match := (_INTERFACE_RE.match(line) or _ENUM_RE.match(line)):
+                end, _ = item_end(code, idx)
+                header = header_text(code, idx, end)
+                classes.append(
+                    ClassDoc(
+                        name=match.group("name"),
+                        file=path,
+                        line=idx + 1,
+                        end_line=end + 1,
+                        docstring=doc,
+                        bases=heritage(header),
+                        kind="interface" if _INTERFACE_RE.match(line) else "enum",
+                        signature=header,
+                    )
+                )
+            elif match := _TYPE_RE.match(line):
+                end = statement_end(code, idx)
+                classes.append(
+                    ClassDoc(
+                        name=match.group("name"),
+                        file=path,
+                        line=idx + 1,
+                        end_line=end + 1,
+                        docstring=doc,
+                        kind="type",
+                        signature=_declaration_head(code, idx, end),
+                    )
+                )
+            elif match := _CONST_RE.match(line):
+                end = statement_end(code, idx)
+                text = " ".join(part.strip() for part in code[idx : end + 1])
+                function = _function_initializer(text, match.group("name"), path, idx, end, doc)
+                if function is not None:
+                    functions.append(function)
+                else:
+                    classes.append(
+                        ClassDoc(
+                            name=match.group("name"),
+                            file=path,
+                            line=idx + 1,
+                            end_line=end + 1,
+                            docstring=doc,
+                            kind="constant",
+                            signature=_declaration_head(code, idx, end),
+                        )
+                    )
+            elif _DEFAULT_ARROW_RE.match(line):
+                end = statement_end(code, idx)
+                text = " ".join(part.strip() for part in code[idx : end + 1])
+                function = _function_initializer(
+                    text.replace("export default", "export default =", 1),
+                    "default",
+                    path,
+                    idx,
+                    end,
+                    doc,
+                )
+                if function is not None:
+                    functions.append(function)
+            idx = end + 1
+
+        result = ParseResult(functions=functions, classes=classes, imports=_imports(content))
+        return with_doc_ranges(
+            result, raw, prefixes=(), block=("/**", "*/"), skip=lambda line: line.startswith("@")
+        )
+
+
+def _doc_comment(raw: Sequence[str], idx: int) -> tuple[str | None, list[str]]:
+    return leading_comment(
+        raw, idx, prefixes=(), block=("/**", "*/"), skip=lambda line: line.startswith("@")
+    )
+
+
+def _function_initializer(
+    text: str, name: str, path: Path, idx: int, end: int, doc: str | None
+) -> FunctionDoc | None:
+    head = _until_top_level(text, "=")
+    init = text[len(head) :]
+    if arrow := _ARROW_INIT_RE.match(init):
+        params = arrow.group("inner") if arrow.group("inner") is not None else arrow.group("params")
+        return FunctionDoc(
+            name=name,
+            file=path,
+            line=idx + 1,
+            end_line=end + 1,
+            docstring=doc,
+            args=_param_names(params),
+            is_async=bool(arrow.group("async")),
+            signature=re.sub(r"\s+", " ", head + init[: arrow.end() - 2]).strip(),
+        )
+    if func := _FUNCTION_INIT_RE.match(init):
+        return FunctionDoc(
+            name=name,
+            file=path,
+            line=idx + 1,
+            end_line=end + 1,
+            docstring=doc,
+            args=_param_names(paren_contents(init[func.end() :])),
+            is_async=bool(func.group("async")),
+            signature=re.sub(r"\s+", " ", head + init.split("{", 1)[0]).strip(),
+        )
+    return None
+
+
+def _class_methods(
+    raw: Sequence[str],
+    code: Sequence[str],
+    depths: Sequence[int],
+    start: int,
+    end: int,
+    path: Path,
+) -> list[MethodDoc]:
+    member_depth = depths[start] + 1
+    methods: list[MethodDoc] = []
+    idx = start + 1
+    while idx < end:
+        line = code[idx].strip()
+        if depths[idx] != member_depth or not line:
+            idx += 1
+            continue
+        arrow = _PROPERTY_ARROW_RE.match(line)
+        match = arrow or _METHOD_RE.match(line)
+        name = match.group("name") if match else ""
+        if match is None or name in _NOT_METHODS or _is_private(line, name):
+            idx += 1
+            continue
+        member_end, _ = item_end(code, idx)
+        header = header_text(code, idx, member_end)
+        doc, decorators = _doc_comment(raw, idx)
+        params = (
+            (arrow.group("inner") or arrow.group("params"))
+            if arrow
+            else paren_contents(header[header.find(name) + len(name) :])
+        )
+        methods.append(
+            MethodDoc(
+                name=name,
+                file=path,
+                line=idx + 1,
+                end_line=member_end + 1,
+                docstring=doc,
+                args=_param_names(params or ""),
+                decorators=decorators,
+                is_async=bool(re.search(r"\basync\b", header.split("(", 1)[0] + " "))
+                or bool(arrow and arrow.group("async")),
+                signature=header,
+            )
+        )
+        idx = max(member_end, idx) + 1
+    return methods
+
+
+def _is_private(line: str, name: str) -> bool:
+    return name.startswith("#") or bool(re.match(r"^(?:\w+\s+)*private\b", line))
+
+
+def _param_names(params: str) -> list[str]:
+    names: list[str] = []
+    for param in split_top_level(params):
+        cleaned = re.sub(r"^@\w+(?:\([^)]*\))?\s*", "", param.strip())
+        cleaned = re.sub(r"^(?:(?:public|protected|private|readonly|override)\s+)+", "", cleaned)
+        name = _until_top_level(cleaned, ":=").strip().rstrip("?")
+        if name and name != "this":
+            names.append(re.sub(r"\s+", " ", name))
+    return names
+
+
+def _until_top_level(text: str, stops: str) -> str:
+    """Return `text` up to the first top-level stop character.
+
+    `=>`, `==` and comparison operators never count as an `=` stop, so arrow
+    types in annotations do not end a declaration head early.
+    """
+    depth = 0
+    for idx, char in enumerate(text):
+        prev = text[idx - 1] if idx else ""
+        nxt = text[idx + 1] if idx + 1 < len(text) else ""
+        if char == "=" and (nxt in "=>" or prev in "=!<>"):
+            continue
+        if char == ">" and prev == "=":
+            continue
+        if char in "([{<":
+            depth += 1
+        elif char in ")]}>":
+            depth -= 1
+        elif depth == 0 and char in stops:
+            return text[:idx]
+    return text
+
+
+def _declaration_head(code: Sequence[str], start: int, end: int) -> str:
+    text = " ".join(line.strip() for line in code[start : end + 1])
+    head = _until_top_level(text, "=").strip() if "=" in text else text.rstrip(";")
+    return re.sub(r"\s+", " ", head).strip()
+
+
+def _imports(content: str) -> set[str]:
+    imports: set[str] = set()
+    for pattern in (_FROM_RE, _SIDE_EFFECT_IMPORT_RE, _REQUIRE_RE):
+        imports.update(match.group("spec") for match in pattern.finditer(content))
+    return imports
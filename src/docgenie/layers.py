@@ -0,0 +1,167 @@
+"""Assign modules to architectural layers by path glob and check the imports between them.
+
+Layers are listed outermost first (`handler`, `service`, `repository`). A layer may
+import its own modules and those of the layers after it; importing an earlier layer,
+such as a repository importing a handler, is a violation.
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable, Mapping
+from pathlib import PurePosixPath
+from typing import Any
+
+from .file_rules import glob_regex
+from .module_index import module_grouping, package_of
+
+# Violations named in the warning; the rest are counted.
+MAX_WARNED_VIOLATIONS = 5
+
+
+def parse_layers(value: str) -> dict[str, list[str]]:
+    """Parse `--layers "handler=**/handler/**,service=**/service/**"`.
+
+    A layer named twice collects both globs. Raises ValueError for an entry
+    without `NAME=GLOB`.
+    """
+    layers: dict[str, list[str]] = {}
+    for entry in value.split(","):
+        if not entry.strip():
+            continue
+        name, sep, glob = entry.partition("=")
+        if not sep or not name.strip() or not glob.strip():
+            raise ValueError(f"expected NAME=GLOB, got {entry.strip()!r}")
+        layers.setdefault(name.strip(), []).append(glob.strip())
+    return layers
+
+
+def configured_layers(analysis_data: Mapping[str, Any]) -> dict[str, list[str]]:
+    """Return the `layers` config as layer name -> globs, in declaration order."""
+    config = analysis_data.get("config", {})
+    layers = config.get("layers") if isinstance(config, dict) else None
+    if not isinstance(layers, dict):
+        return {}
+    configured: dict[str, list[str]] = {}
+    for name, globs in layers.items():
+        values = [globs] if isinstance(globs, str) else globs
+        if isinstance(values, list) and values:
+            configured[str(name)] = [str(glob) for glob in values]
+    return configured
+
+
+def import_targets(spec: str, paths: Iterable[str]) -> list[str]:
+    """Return the analyzed files an import of `spec` refers to.
+
+    A resolved import is a file path already. Otherwise a file matches when its path
+    without suffix, or its directory, and the import end the same way, by path
+    (`example.com/svc/internal/handler` and `internal/handler/`) or dotted name
+    (`shop.service.orders` and `src/shop/service/orders.py`). A dotted import of a
+    name from a module (`shop.service.orders.place`) falls back to the module.
+    """
+    candidates = list(paths)
+    if spec in candidates:
+        return [spec]
+    if spec.startswith((".", "/")):
+        return []
+    wanted = [spec] if "/" in spec else [spec.replace(".", "/")]
+    if "/" not in spec and "." in spec:
+        wanted.append(spec.rsplit(".", 1)[0].replace(".", "/"))
+    for target in wanted:
+        found = [path for path in candidates if _same_module(path, target)]
+        if found:
+            return found
+    return []
+
+
+def _same_module(path: str, target: str) -> bool:
+    posix = PurePosixPath(path)
+    for module in (str(posix.with_suffix("")), str(posix.parent)):
+        if module == "." or not module:
+            continue
+        if target == module or target.endswith(f"/{module}") or module.endswith(f"/{target}"):
+            return True
+    return False
+
+
+def layer_report(analysis_data: Mapping[str, Any]) -> dict[str, Any] | None:
+    """Return the layers' modules and the imports between layers, or None without `layers`.
+
+    `layers` lists `{name, globs, modules}`; a file belongs to the first layer with a
+    matching glob. `dependencies` counts imports per `(source, target)` layer pair with
+    `allowed` set, and `violations` lists each file import that points at an earlier
+    layer. Imports within a layer are not counted.
+    """
+    layers = configured_layers(analysis_data)
+    if not layers:
+        return None
+    rank = {name: position for position, name in enumerate(layers)}
+    rules = [(name, [glob_regex(glob) for glob in globs]) for name, globs in layers.items()]
+    file_imports = analysis_data.get("file_imports")
+    file_imports = file_imports if isinstance(file_imports, dict) else {}
+    paths = sorted(str(path) for path in file_imports)
+    assigned = {
+        path: layer
+        for path in paths
+        if (layer := next((name for name, res in rules if _matches(path, res)), None))
+    }
+
+    counts: dict[tuple[str, str], int] = {}
+    violations: list[dict[str, Any]] = []
+    for path in paths:
+        source = assigned.get(path)
+        imports = file_imports.get(path)
+        if source is None or not isinstance(imports, list):
+            continue
+        for spec in imports:
+            targets = {assigned[t] for t in import_targets(str(spec), paths) if t in assigned}
+            for target in sorted(targets - {source}, key=rank.__getitem__):
+                counts[(source, target)] = counts.get((source, target), 0) + 1
+                if rank[target] < rank[source]:
+                    violations.append(
+                        {"source": source, "target": target, "file": path, "import": str(spec)}
+                    )
+
+    by_package = module_grouping(dict(analysis_data)) == "package"
+    members: dict[str, list[str]] = {name: [] for name in layers}
+    for path, layer in assigned.items():
+        module = package_of(path) if by_package else path
+        if module not in members[layer]:
+            members[layer].append(module)
+    return {
+        "layers": [
+            {"name": name, "globs": globs, "modules": members[name]}
+            for name, globs in layers.items()
+        ],
+        "dependencies": [
+            {
+                "source": source,
+                "target": target,
+                "count": count,
+                "allowed": rank[target] > rank[source],
+            }
+            for (source, target), count in sorted(
+                counts.items(), key=lambda item: (rank[item[0][0]], rank[item[0][1]])
+            )
+        ],
+        "violations": violations,
+    }
+
+
+def _matches(path: str, patterns: list[re.Pattern[str]]) -> bool:
+    return any(pattern.fullmatch(path) for pattern in patterns)
+
+
+def layer_warnings(report: Mapping[str, Any] | None) -> list[str]:
+    """Warn once when imports cross layers the wrong way, naming the first few."""
+    violations = list(report.get("violations", [])) if report else []
+    if not violations:
+        return []
+    named = ", ".join(
+        f"`{item['source']}` imports `{item['target']}` ({item['file']})"
+        for item in violations[:MAX_WARNED_VIOLATIONS]
+    )
+    extra = len(violations) - MAX_WARNED_VIOLATIONS
+    more = f" and {extra} more" if extra > 0 else ""
+    noun = "import violates" if len(violations) == 1 else "imports violate"
+    return [f"{len(violations)} {noun} the layer order: {named}{more}"]
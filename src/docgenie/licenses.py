@@ -0,0 +1,58 @@
+"""Identify the project license from the license file at the repository root."""
+
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+LICENSE_FILENAMES = ("LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "LICENCE.md", "COPYING")
+_READ_LIMIT = 8192
+_SPDX_RE = re.compile(r"SPDX-License-Identifier:\s*(?P<id>[\w.+-]+)")
+
+# Checked in order; the GPL family is matched most specific first.
+_SIGNATURES: tuple[tuple[str, tuple[str, ...]], ...] = (
+    ("AGPL-3.0", ("GNU AFFERO GENERAL PUBLIC LICENSE",)),
+    ("LGPL-3.0", ("GNU LESSER GENERAL PUBLIC LICENSE", "Version 3")),
+    ("LGPL-2.1", ("GNU LESSER GENERAL PUBLIC LICENSE",)),
+    ("GPL-3.0", ("GNU GENERAL PUBLIC LICENSE", "Version 3")),
+    ("GPL-2.0", ("GNU GENERAL PUBLIC LICENSE", "Version 2")),
+    ("Apache-2.0", ("Apache License", "Version 2.0")),
+    ("MPL-2.0", ("Mozilla Public License", "2.0")),
+    ("MIT", ("Permission is hereby granted, free of charge",)),
+    ("ISC", ("Permission to use, copy, modify, and/or distribute",)),
+    ("BSD-3-Clause", ("Redistribution and use in source and binary forms", "endorse or promote")),
+    ("BSD-2-Clause", ("Redistribution and use in source and binary forms",)),
+    ("Unlicense", ("This is free and unencumbered software",)),
+)
+
+
+def detect_license(root_path: Path) -> dict[str, str]:
+    """Return `{"spdx_id", "file"}` for the root license file, or `{}` when unrecognized.
+
+    An explicit `SPDX-License-Identifier:` line wins; otherwise the text is matched
+    against the opening wording of common licenses.
+    """
+    for name in LICENSE_FILENAMES:
+        path = root_path / name
+        if not path.is_file():
+            continue
+        try:
+            with path.open(encoding="utf-8", errors="replace") as handle:
+                text = handle.read(_READ_LIMIT)
+        except OSError:
+            continue
+        spdx_id = _identify(text)
+        if spdx_id:
+            return {"spdx_id": spdx_id, "file": name}
+    return {}
+
+
+def _identify(text: str) -> str | None:
+    explicit = _SPDX_RE.search(text)
+    if explicit:
+        return explicit.group("id")
+    flat = " ".join(text.split()).lower()
+    for spdx_id, phrases in _SIGNATURES:
+        if all(phrase.lower() in flat for phrase in phrases):
+            return spdx_id
+    return None
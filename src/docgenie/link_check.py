@@ -0,0 +1,82 @@
+"""Find in-page links in a generated README that point at anchors it never emits.
+
+Autolinks, route handler links and TOC entries are built from the analysis, so a symbol
+filtered out later (by visibility, confidence or a custom template) can leave a link to
+an anchor that is not in the document. Links inside code are not links and are skipped.
+"""
+
+from __future__ import annotations
+
+import re
+from typing import Any
+
+from .toc import markdown_anchors
+
+_MARKDOWN_FENCE_RE = re.compile(r"^\s*(```|~~~)")
+# Inline code is matched too so links shown as code are consumed, not checked.
+_MARKDOWN_LINK_RE = re.compile(
+    r"\[(?P<text>(?:`[^`\n]*`|[^\]`\n])*)\]\(#(?P<anchor>[^)\s]+)\)"
+    r"|href=\"#(?P<href>[^\"]+)\""
+    r"|(?P<code>`+)[^`\n]*(?P=code)"
+)
+_ADOC_LINK_RE = re.compile(r"<<(?P<anchor>[^,>\s]+)(?:,(?P<text>[^>]*))?>>|(?P<code>`[^`\n]*`)")
+_ADOC_ANCHOR_RE = re.compile(r"\[\[(?P<id>[^,\]\s]+)[^\]]*\]\]|\[#(?P<short>[\w.:-]+)\]")
+_ADOC_SECTION_RE = re.compile(r"^(?P<marks>={1,6})\s+(?P<title>.+?)\s*$")
+_ADOC_BLOCK_RE = re.compile(r"^(?:-{4,}|\.{4,}|\+{4,})\s*$")
+
+
+def find_broken_links(content: str, output_format: str = "markdown") -> list[dict[str, Any]]:
+    """Return `{anchor, text, line}` for each in-page link with no matching anchor.
+
+    Handles Markdown and AsciiDoc output; other formats have no checkable links and
+    give an empty list. Lines are 1-based.
+    """
+    if output_format == "markdown":
+        return _broken(content, markdown_anchors(content), _MARKDOWN_LINK_RE, _MARKDOWN_FENCE_RE)
+    if output_format == "adoc":
+        return _broken(content, _adoc_anchors(content), _ADOC_LINK_RE, _ADOC_BLOCK_RE)
+    return []
+
+
+def _broken(
+    content: str, anchors: set[str], link_re: re.Pattern[str], fence_re: re.Pattern[str]
+) -> list[dict[str, Any]]:
+    broken: list[dict[str, Any]] = []
+    fence: str | None = None
+    for number, line in enumerate(content.splitlines(), start=1):
+        opener = fence_re.match(line)
+        if opener is not None:
+            marker = opener.group(1) if opener.groups() else line.strip()
+            if fence is None:
+                fence = marker
+            elif marker == fence:
+                fence = None
+            continue
+        if fence is not None:
+            continue
+        for match in link_re.finditer(line):
+            if match.group("code"):
+                continue
+            anchor = match.group("anchor") or match.groupdict().get("href")
+            if anchor and anchor not in anchors:
+                text = match.group("text") or anchor
+                broken.append({"anchor": anchor, "text": text, "line": number})
+    return broken
+
+
+def _adoc_anchors(content: str) -> set[str]:
+    """Explicit `[[id]]` / `[#id]` anchors plus Asciidoctor's generated section IDs."""
+    anchors = {
+        match.group("id") or match.group("short") for match in _ADOC_ANCHOR_RE.finditer(content)
+    }
+    for line in content.splitlines():
+        section = _ADOC_SECTION_RE.match(line)
+        if section is not None:
+            slug = re.sub(r"\W+", "_", section.group("title").lower()).strip("_")
+            base = f"_{slug}"
+            anchor, count = base, 1
+            while anchor in anchors:
+                count += 1
+                anchor = f"{base}_{count}"
+            anchors.add(anchor)
+    return anchors
@@ -0,0 +1,210 @@
+"""Render a compact plaintext context file (`llms.txt`) meant for LLM ingestion."""
+
+from __future__ import annotations
+
+import math
+from pathlib import Path
+from typing import Any
+
+from .html_sections import build_caller_index, symbol_node_id
+from .module_index import is_visible, relative_path, summarize, symbol_visibility
+from .redaction import redact_text
+from .reproducible import path_root
+
+# Rough English/code average; good enough to keep a file under a model's context budget.
+CHARS_PER_TOKEN = 4
+MAX_LISTED_DEPENDENCIES = 30
+
+
+def estimate_tokens(text: str) -> int:
+    return math.ceil(len(text) / CHARS_PER_TOKEN)
+
+
+class LlmsTxtGenerator:
+    """One line per public symbol, grouped by module, after a short project overview.
+
+    There are no tables, badges or graphs. With `max_tokens`, the modules with the
+    fewest inbound references (then the fewest documented symbols) are dropped first
+    and an `Omitted` section lists what was left out.
+    """
+
+    def generate(
+        self,
+        analysis_data: dict[str, Any],
+        output_path: str | Path | None = None,
+        *,
+        max_tokens: int | None = None,
+    ) -> str:
+        """Return the llms.txt content; write it to `output_path` when given."""
+        config = analysis_data.get("config", {})
+        if max_tokens is None and isinstance(config, dict):
+            llms_config = config.get("llms", {})
+            if isinstance(llms_config, dict) and llms_config.get("max_tokens"):
+                max_tokens = int(llms_config["max_tokens"])
+
+        overview = self._overview(analysis_data)
+        modules = self._modules(analysis_data)
+        kept, omitted = _fit_budget(overview, modules, max_tokens)
+        parts = [overview, *(module["text"] for module in kept)]
+        if omitted:
+            parts.append(_omitted_note(omitted, max_tokens))
+        content = redact_output("\n".join(parts), config)
+        if output_path:
+            path = Path(output_path)
+            path.parent.mkdir(parents=True, exist_ok=True)
+            path.write_text(content, encoding="utf-8")
+        return content
+
+    def _overview(self, analysis_data: dict[str, Any]) -> str:
+        project = str(analysis_data.get("project_name") or "project")
+        lines = [f"# {project}", "", f"> {project_summary(analysis_data, project)}", ""]
+        ranked = ranked_languages(analysis_data)
+        if ranked:
+            lines.append("Languages: " + ", ".join(f"{lang} ({count})" for lang, count in ranked))
+        dependencies = dependency_names(analysis_data.get("dependencies", {}))
+        if dependencies:
+            listed = dependencies[:MAX_LISTED_DEPENDENCIES]
+            more = len(dependencies) - len(listed)
+            lines.append(
+                "Dependencies: " + ", ".join(listed) + (f" (+{more} more)" if more else "")
+            )
+        program = (analysis_data.get("cli_interface") or {}).get("program")
+        if program:
+            lines.append(f"CLI: {program}")
+        routes = analysis_data.get("http_routes", []) or []
+        if routes:
+            lines.append(f"HTTP routes: {len(routes)}")
+        return "\n".join(lines) + "\n"
+
+    def _modules(self, analysis_data: dict[str, Any]) -> list[dict[str, Any]]:
+        """Return each module's text block with the numbers used to rank it."""
+        root = path_root(analysis_data)
+        visibility = symbol_visibility(analysis_data)
+        callers = build_caller_index(analysis_data, max_callers=0)
+        grouped: dict[str, list[tuple[int, list[str], int, bool]]] = {}
+        for key, default_kind in (("functions", "function"), ("classes", "class")):
+            for item in analysis_data.get(key, []) or []:
+                if not isinstance(item, dict) or not is_shown(item, visibility):
+                    continue
+                module = relative_path(root, str(item.get("file", "")))
+                lines = [_symbol_line(item, default_kind)]
+                for method in item.get("methods", []) or []:
+                    if isinstance(method, dict) and is_shown(method, visibility):
+                        lines.append("  " + _symbol_line(method, "method"))
+                entry = callers.get(symbol_node_id(module, str(item["name"])), {})
+                grouped.setdefault(module, []).append(
+                    (
+                        int(item.get("line", 0) or 0),
+                        lines,
+                        int(entry.get("total", 0)),
+                        bool(item.get("docstring")),
+                    )
+                )
+        modules: list[dict[str, Any]] = []
+        for path in sorted(grouped):
+            symbols = sorted(grouped[path], key=lambda symbol: symbol[0])
+            body = [line for _, lines, _, _ in symbols for line in lines]
+            modules.append(
+                {
+                    "path": path,
+                    "text": "\n".join([f"## {path}", *body]) + "\n",
+                    "symbols": len(symbols),
+                    "references": sum(symbol[2] for symbol in symbols),
+                    "documented": sum(symbol[3] for symbol in symbols),
+                }
+            )
+        return modules
+
+
+def _fit_budget(
+    overview: str, modules: list[dict[str, Any]], max_tokens: int | None
+) -> tuple[list[dict[str, Any]], list[dict[str, Any]]]:
+    """Drop the least important modules until the output fits `max_tokens`."""
+    if max_tokens is None:
+        return modules, []
+    ranked = sorted(
+        modules,
+        key=lambda module: (-module["references"], -module["documented"], module["path"]),
+    )
+    kept = list(ranked)
+    omitted: list[dict[str, Any]] = []
+
+    def total() -> int:
+        note = _omitted_note(omitted, max_tokens) if omitted else ""
+        return estimate_tokens("\n".join([overview, *(m["text"] for m in kept), note]))
+
+    while kept and total() > max_tokens:
+        omitted.append(kept.pop())
+    order = {module["path"]: index for index, module in enumerate(modules)}
+    return sorted(kept, key=lambda module: order[module["path"]]), omitted
+
+
+def _omitted_note(omitted: list[dict[str, Any]], max_tokens: int | None) -> str:
+    symbols = sum(module["symbols"] for module in omitted)
+    paths = ", ".join(module["path"] for module in omitted)
+    return (
+        f"## Omitted\nTo fit the {max_tokens}-token budget, {len(omitted)} modules "
+        f"({symbols} symbols) were left out, least referenced first: {paths}\n"
+    )
+
+
+def _symbol_line(item: dict[str, Any], default_kind: str) -> str:
+    name = str(item.get("name"))
+    signature = " ".join(str(item.get("signature") or "").split())
+    if not signature:
+        kind = str(item.get("kind") or default_kind)
+        if default_kind == "class":
+            bases = item.get("bases", []) or []
+            signature = f"{kind} {name}" + (f"({', '.join(map(str, bases))})" if bases else "")
+        else:
+            signature = f"{name}({', '.join(str(arg) for arg in item.get('args', []) or [])})"
+    summary = summarize(item.get("docstring"))
+    return f"- {signature}" + (f": {summary}" if summary else "")
+
+
+def is_shown(item: dict[str, Any], visibility: str) -> bool:
+    """Return whether a symbol has a name and passes the `visibility` setting."""
+    return bool(item.get("name")) and is_visible(item, visibility)
+
+
+def project_summary(analysis_data: dict[str, Any], project: str) -> str:
+    """Return the one-sentence overview: language and file, function and class counts."""
+    return (
+        f"{project} is a {analysis_data.get('main_language', 'unknown')} project: "
+        f"{analysis_data.get('files_analyzed', 0)} files, "
+        f"{len(analysis_data.get('functions', []) or [])} functions, "
+        f"{len(analysis_data.get('classes', []) or [])} classes."
+    )
+
+
+def ranked_languages(analysis_data: dict[str, Any]) -> list[tuple[str, int]]:
+    """Return `(language, files)` pairs, most files first and then by name."""
+    languages = analysis_data.get("languages", {}) or {}
+    return sorted(languages.items(), key=lambda item: (-item[1], item[0]))
+
+
+def redact_output(content: str, config: Any) -> str:
+    """Apply the `safety` redaction settings in `config` to rendered output."""
+    safety = config.get("safety", {}) if isinstance(config, dict) else {}
+    patterns = safety.get("redact_patterns", []) if isinstance(safety, dict) else []
+    return redact_text(
+        content,
+        str(safety.get("redaction_mode", "strict")),
+        patterns if isinstance(patterns, list) else [],
+    )
+
+
+def dependency_names(dependencies: Any) -> list[str]:
+    """Flatten the per-manifest dependency lists (and their groups) into unique names."""
+    names: list[str] = []
+    stack = [dependencies]
+    while stack:
+        value = stack.pop(0)
+        if isinstance(value, dict):
+            stack.extend(value.values())
+        elif isinstance(value, list):
+            stack.extend(value)
+        elif isinstance(value, str) and value.strip() and value.strip() not in names:
+            names.append(value.strip())
+    return names
+
@@ -0,0 +1,123 @@
+"""Collect the targets of a project's Makefiles for a Build/Tasks section.
+
+Follows the self-documenting Makefile convention: a target is described by the `##`
+comment lines right above it, or by a `## text` comment after its prerequisites. Only
+`.PHONY` targets and documented ones are listed, so file targets such as `build/app`
+stay out; targets starting with `_` are internal and never listed.
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from .module_index import relative_path
+
+MAKEFILE_NAMES = frozenset({"Makefile", "makefile", "GNUmakefile"})
+
+# `name other: deps ## help`; `:=`, `::=` and `?=` are variable assignments, not rules.
+_RULE_RE = re.compile(r"^(?P<targets>[^\s:=#?+!][^:=#]*?)\s*::?(?![=:])(?P<rest>.*)$")
+_DOC_RE = re.compile(r"^##(?!#)\s?(?P<text>.*)$")
+_INLINE_DOC_RE = re.compile(r"\s##\s?(?P<text>.*)$")
+_ASSIGNMENT_RE = re.compile(r"^\s*(?:export\s+|override\s+)?[\w.-]+\s*(?:[:+?!]?=|::=)")
+
+
+def is_makefile(path: Path) -> bool:
+    """Return True for `Makefile`, `makefile`, `GNUmakefile` and `*.mk` includes."""
+    return path.name in MAKEFILE_NAMES or path.suffix == ".mk"
+
+
+def scan_make_targets(root_path: Path, files: Iterable[Path]) -> list[dict[str, Any]]:
+    """Return the listed targets of every Makefile under `root_path`.
+
+    Each target has `name`, `file`, `line`, `help`, `phony`, `prerequisites` and
+    `command`, the `make` invocation that runs it from the project root.
+    """
+    targets: list[dict[str, Any]] = []
+    for path in sorted(files):
+        if not is_makefile(path):
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        rel = relative_path(root_path, str(path))
+        directory = Path(rel).parent.as_posix()
+        for target in parse_makefile(content):
+            prefix = "make" if directory == "." or path.suffix == ".mk" else f"make -C {directory}"
+            targets.append({**target, "file": rel, "command": f"{prefix} {target['name']}"})
+    return targets
+
+
+def parse_makefile(content: str) -> list[dict[str, Any]]:
+    """Return the `.PHONY` and documented targets of one Makefile, in file order.
+
+    Each target has `name`, `line`, `help`, `phony` and `prerequisites`; a target
+    defined by several rules is listed once, at its first rule.
+    """
+    rules: dict[str, dict[str, Any]] = {}
+    phony: set[str] = set()
+    comments: list[str] = []
+    for line_no, line in _logical_lines(content):
+        if line.startswith("\t") or not line.strip():
+            comments = []
+            continue
+        doc = _DOC_RE.match(line)
+        if doc:
+            comments.append(doc.group("text").strip())
+            continue
+        if line.lstrip().startswith("#") or _ASSIGNMENT_RE.match(line):
+            comments = []
+            continue
+        rule = _RULE_RE.match(line)
+        if rule is None:
+            comments = []
+            continue
+        rest, inline = rule.group("rest"), _INLINE_DOC_RE.search(rule.group("rest"))
+        if inline:
+            rest = rest[: inline.start()]
+        prerequisites = rest.split(";", 1)[0].split("|", 1)[0].split("#", 1)[0].split()
+        names = rule.group("targets").split()
+        if names == [".PHONY"]:
+            phony.update(prerequisites)
+            comments = []
+            continue
+        text = inline.group("text").strip() if inline else " ".join(filter(None, comments))
+        comments = []
+        for name in names:
+            if name.startswith((".", "_")) or "%" in name or "$" in name:
+                continue
+            existing = rules.get(name)
+            if existing is None:
+                rules[name] = {
+                    "name": name,
+                    "line": line_no,
+                    "help": text,
+                    "prerequisites": prerequisites,
+                }
+            elif not existing["help"]:
+                existing["help"] = text
+    return [
+        {**rule, "phony": rule["name"] in phony}
+        for rule in rules.values()
+        if rule["help"] or rule["name"] in phony
+    ]
+
+
+def _logical_lines(content: str) -> Iterable[tuple[int, str]]:
+    """Yield (first line number, text) with backslash-continued lines joined."""
+    pending: list[str] = []
+    start = 0
+    for line_no, line in enumerate(content.splitlines(), start=1):
+        if not pending:
+            start = line_no
+        if line.endswith("\\"):
+            pending.append(line[:-1].rstrip())
+            continue
+        pending.append(line.strip() if pending else line)
+        yield start, " ".join(pending)
+        pending = []
+    if pending:
+        yield start, " ".join(pending)
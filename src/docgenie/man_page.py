@@ -0,0 +1,122 @@
+"""Render a section 1 man page (troff `man` macros) from the detected CLI interface."""
+
+from __future__ import annotations
+
+import re
+from datetime import date as Date
+from pathlib import Path
+from typing import Any
+
+from .redaction import redact_text
+from .reproducible import build_time
+
+
+def program_name(analysis_data: dict[str, Any]) -> str:
+    """Return the detected program name, falling back to the project name."""
+    cli = analysis_data.get("cli_interface") or {}
+    return str(cli.get("program") or analysis_data.get("project_name") or "program")
+
+
+def escape(text: str) -> str:
+    """Escape troff specials: backslashes, hyphens, and control characters at line start."""
+    escaped = text.replace("\\", "\\e").replace("-", "\\-")
+    lines = [
+        f"\\&{line}" if line.startswith((".", "'")) else line for line in escaped.splitlines()
+    ]
+    return "\n".join(lines)
+
+
+def _paragraphs(text: str) -> str:
+    return "\n.PP\n".join(escape(part.strip()) for part in re.split(r"\n\s*\n", text.strip()))
+
+
+class ManPageGenerator:
+    """Build NAME/SYNOPSIS/DESCRIPTION/OPTIONS from `analysis_data["cli_interface"]`."""
+
+    def generate(
+        self,
+        analysis_data: dict[str, Any],
+        output_path: str | Path | None = None,
+        *,
+        date: Date | None = None,
+    ) -> str:
+        """Return the man page; write it to `output_path` (creating `man1/`) when given."""
+        cli = analysis_data.get("cli_interface") or {}
+        name = program_name(analysis_data)
+        flags = [flag for flag in cli.get("flags", []) if not flag.get("command")]
+        commands = cli.get("commands", [])
+        summary = self._summary(analysis_data, cli, name)
+
+        lines = [
+            f'.TH "{name.upper()}" "1" "{(date or build_time().date()).isoformat()}" '
+            f'"{escape(str(analysis_data.get("project_name", name)))}" "User Commands"',
+            ".SH NAME",
+            f"{escape(name)} \\- {escape(summary)}",
+            ".SH SYNOPSIS",
+            self._synopsis(name, bool(flags), bool(commands)),
+            ".SH DESCRIPTION",
+            _paragraphs(str(cli.get("description") or summary)),
+        ]
+        if cli.get("entry_point"):
+            lines.extend([".PP", f"Entry point: \\fI{escape(str(cli['entry_point']))}\\fR."])
+        if commands:
+            lines.append(".SH COMMANDS")
+            for command in commands:
+                use = escape(str(command["use"]))
+                lines.extend([".TP", f"\\fB{use}\\fR", escape(str(command.get("short") or ""))])
+                lines.extend(self._options(cli, command_name=str(command["name"])))
+        if flags:
+            lines.append(".SH OPTIONS")
+            lines.extend(self._options(cli, command_name=""))
+
+        content = "\n".join(line for line in lines if line) + "\n"
+        config = analysis_data.get("config", {})
+        safety = config.get("safety", {}) if isinstance(config, dict) else {}
+        patterns = safety.get("redact_patterns", []) if isinstance(safety, dict) else []
+        content = redact_text(
+            content,
+            str(safety.get("redaction_mode", "strict")),
+            patterns if isinstance(patterns, list) else [],
+        )
+        if output_path:
+            path = Path(output_path)
+            path.parent.mkdir(parents=True, exist_ok=True)
+            path.write_text(content, encoding="utf-8")
+        return content
+
+    def _summary(self, analysis_data: dict[str, Any], cli: dict[str, Any], name: str) -> str:
+        summary = str(cli.get("summary") or "").strip().rstrip(".")
+        project = analysis_data.get("project_name", name)
+        return summary or f"command-line interface for {project}"
+
+    def _synopsis(self, name: str, has_flags: bool, has_commands: bool) -> str:
+        synopsis = f".B {escape(name)}"
+        if has_flags:
+            synopsis += "\n[\\fIOPTIONS\\fR]"
+        if has_commands:
+            synopsis += "\n\\fICOMMAND\\fR [\\fIARGS\\fR]"
+        return synopsis
+
+    def _options(self, cli: dict[str, Any], *, command_name: str) -> list[str]:
+        lines: list[str] = []
+        for flag in cli.get("flags", []):
+            if flag.get("command", "") != command_name:
+                continue
+            lines.extend([".TP", self._flag_term(flag)])
+            usage = escape(str(flag.get("usage") or ""))
+            if flag.get("default"):
+                usage = f"{usage} (default: {escape(str(flag['default']))})".strip()
+            lines.append(usage)
+        if command_name and lines:
+            lines = [".RS", *lines, ".RE"]
+        return lines
+
+    def _flag_term(self, flag: dict[str, Any]) -> str:
+        # cobra (pflag) uses --long and -s; the standard library accepts a single dash.
+        long_prefix = "\\-\\-" if flag.get("style") == "posix" else "\\-"
+        term = f"\\fB{long_prefix}{escape(str(flag['name']))}\\fR"
+        if flag.get("shorthand"):
+            term = f"\\fB\\-{escape(str(flag['shorthand']))}\\fR, {term}"
+        if flag.get("type") not in {"bool", "count"}:
+            term += f" \\fI{escape(str(flag.get('type', 'value')))}\\fR"
+        return term
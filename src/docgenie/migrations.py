@@ -0,0 +1,228 @@
+"""Build a database schema from the `.sql` files in a `migrations/` directory.
+
+Migrations are applied in file name order, which is their version order for the common
+tools (golang-migrate, goose, dbmate, Flyway). Only the up direction changes the schema:
+a `NAME.down.sql` file, or the part after a `-- +goose Down` / `-- migrate:down` marker,
+is recorded as the rollback of its migration.
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from .languages._scan import split_top_level
+from .module_index import relative_path
+
+MIGRATION_DIRS = frozenset({"migrations", "migration", "migrate"})
+
+_DIRECTION_RE = re.compile(r"[._](up|down)$", re.IGNORECASE)
+_DOWN_MARKER_RE = re.compile(
+    r"^\s*--\s*(?:\+goose\s+down|migrate:down|\+migrate\s+down)\b", re.IGNORECASE | re.MULTILINE
+)
+_NAME = r"(?:`[^`]+`|\"[^\"]+\"|\[[^\]]+\]|[\w$.]+)"
+_CREATE_RE = re.compile(
+    rf"^CREATE\s+(?:(?:GLOBAL|LOCAL)\s+)?(?:TEMP(?:ORARY)?\s+|UNLOGGED\s+)?TABLE\s+"
+    rf"(?:IF\s+NOT\s+EXISTS\s+)?(?P<name>{_NAME})\s*\((?P<body>.*)\)",
+    re.IGNORECASE | re.DOTALL,
+)
+_ALTER_RE = re.compile(
+    rf"^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(?P<name>{_NAME})\s+(?P<actions>.*)$",
+    re.IGNORECASE | re.DOTALL,
+)
+_DROP_RE = re.compile(r"^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?P<names>.*)$", re.IGNORECASE)
+_ADD_RE = re.compile(
+    r"^ADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(?P<column>.+)$", re.IGNORECASE | re.DOTALL
+)
+_KEEP_COLUMN_RE = re.compile(r"^DROP\s+(?:CONSTRAINT|DEFAULT|NOT\s+NULL)\b", re.IGNORECASE)
+_DROP_COLUMN_RE = re.compile(
+    rf"^DROP\s+(?:COLUMN\s+)?(?:IF\s+EXISTS\s+)?(?P<name>{_NAME})", re.IGNORECASE
+)
+_RENAME_COLUMN_RE = re.compile(
+    rf"^RENAME\s+(?:COLUMN\s+)?(?P<old>{_NAME})\s+TO\s+(?P<new>{_NAME})$", re.IGNORECASE
+)
+_RENAME_TABLE_RE = re.compile(rf"^RENAME\s+TO\s+(?P<new>{_NAME})$", re.IGNORECASE)
+_ALTER_TYPE_RE = re.compile(
+    rf"^(?:ALTER|MODIFY)\s+(?:COLUMN\s+)?(?P<name>{_NAME})\s+(?:(?:SET\s+DATA\s+)?TYPE\s+)?"
+    r"(?P<type>.+)$",
+    re.IGNORECASE | re.DOTALL,
+)
+# Table-level constraints inside CREATE TABLE are not columns.
+_CONSTRAINT_RE = re.compile(
+    r"^(?:CONSTRAINT|PRIMARY\s+KEY|FOREIGN\s+KEY|UNIQUE|CHECK|INDEX|KEY|EXCLUDE|FULLTEXT)\b",
+    re.IGNORECASE,
+)
+_CONSTRAINT_WORDS = re.compile(
+    r"\s(?=(?:NOT\s+NULL|NULL|PRIMARY|REFERENCES|DEFAULT|UNIQUE|CHECK|COLLATE|GENERATED|"
+    r"AUTO_INCREMENT|AUTOINCREMENT|CONSTRAINT|IDENTITY)\b)",
+    re.IGNORECASE,
+)
+
+
+def is_migration_file(rel_path: str) -> bool:
+    """Return True for a `.sql` file under a `migrations/`-style directory."""
+    parts = rel_path.lower().split("/")
+    return parts[-1].endswith(".sql") and any(part in MIGRATION_DIRS for part in parts[:-1])
+
+
+def scan_migrations(root_path: Path, files: Iterable[Path]) -> dict[str, Any]:
+    """Return the `migrations` found and the `tables` they leave behind.
+
+    Each migration has `name`, `file` (the up script) and `down` (the rollback file,
+    or None); each table has `name`, `columns` (`name`, `type`, `constraints`) and the
+    `migration` that created it. Empty when the project has no migration files.
+    """
+    scripts: dict[str, dict[str, Any]] = {}
+    for path in sorted(files):
+        rel = relative_path(root_path, str(path))
+        if not is_migration_file(rel):
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        stem = rel[: -len(".sql")]
+        direction = _DIRECTION_RE.search(stem)
+        key = stem[: direction.start()] if direction else stem
+        entry = scripts.setdefault(
+            key, {"name": key.rsplit("/", 1)[-1], "file": None, "down": None}
+        )
+        if direction and direction.group(1).lower() == "down":
+            entry["down"] = rel
+            continue
+        marker = _DOWN_MARKER_RE.search(content)
+        entry["file"] = rel
+        entry["sql"] = content[: marker.start()] if marker else content
+        if marker:
+            entry["down"] = rel
+    migrations = [entry for _, entry in sorted(scripts.items()) if entry["file"]]
+    tables: dict[str, dict[str, Any]] = {}
+    for migration in migrations:
+        for statement in _statements(migration.pop("sql")):
+            _apply(tables, statement, migration["name"])
+    return {"migrations": migrations, "tables": sorted(tables.values(), key=_table_key)}
+
+
+def _table_key(table: dict[str, Any]) -> str:
+    return str(table["name"]).lower()
+
+
+def _apply(tables: dict[str, dict[str, Any]], statement: str, migration: str) -> None:
+    create = _CREATE_RE.match(statement)
+    if create:
+        name = _unquote(create.group("name"))
+        columns = [
+            column
+            for column in (_column(part) for part in split_top_level(create.group("body")))
+            if column is not None
+        ]
+        tables[name.lower()] = {"name": name, "columns": columns, "migration": migration}
+        return
+    drop = _DROP_RE.match(statement)
+    if drop:
+        for name in split_top_level(drop.group("names")):
+            tables.pop(_unquote(name.split()[0]).lower(), None)
+        return
+    alter = _ALTER_RE.match(statement)
+    if alter is None:
+        return
+    key = _unquote(alter.group("name")).lower()
+    for action in split_top_level(alter.group("actions")):
+        table = tables.get(key)
+        if table is None:
+            return
+        key = _alter(tables, key, table, action.strip())
+
+
+def _alter(tables: dict[str, dict[str, Any]], key: str, table: dict[str, Any], action: str) -> str:
+    """Apply one ALTER TABLE action and return the table's (possibly renamed) key."""
+    columns: list[dict[str, str]] = table["columns"]
+    add = _ADD_RE.match(action)
+    if add:
+        column = _column(add.group("column"))
+        if column is not None:
+            columns[:] = [item for item in columns if item["name"] != column["name"]]
+            columns.append(column)
+        return key
+    rename_table = _RENAME_TABLE_RE.match(action)
+    if rename_table:
+        table["name"] = _unquote(rename_table.group("new"))
+        del tables[key]
+        tables[table["name"].lower()] = table
+        return table["name"].lower()
+    rename = _RENAME_COLUMN_RE.match(action)
+    if rename:
+        old, new = _unquote(rename.group("old")), _unquote(rename.group("new"))
+        for column in columns:
+            if column["name"] == old:
+                column["name"] = new
+        return key
+    drop = _DROP_COLUMN_RE.match(action)
+    if drop and not _KEEP_COLUMN_RE.match(action):
+        name = _unquote(drop.group("name"))
+        columns[:] = [item for item in columns if item["name"] != name]
+        return key
+    retype = _ALTER_TYPE_RE.match(action)
+    if retype and not re.match(r"^\s*(?:SET|DROP)\b", retype.group("type"), re.IGNORECASE):
+        changed = _column(f"{retype.group('name')} {retype.group('type')}")
+        for column in columns:
+            if changed is not None and column["name"] == changed["name"]:
+                column["type"] = changed["type"]
+                # MySQL's MODIFY restates the constraints; Postgres' ALTER ... TYPE keeps them.
+                if changed["constraints"]:
+                    column["constraints"] = changed["constraints"]
+    return key
+
+
+def _column(definition: str) -> dict[str, str] | None:
+    definition = " ".join(definition.split())
+    if not definition or _CONSTRAINT_RE.match(definition):
+        return None
+    name, _, rest = definition.partition(" ")
+    column_type, *constraints = _CONSTRAINT_WORDS.split(rest, maxsplit=1)
+    return {
+        "name": _unquote(name),
+        "type": column_type.strip(),
+        "constraints": constraints[0].strip() if constraints else "",
+    }
+
+
+def _unquote(name: str) -> str:
+    return ".".join(part.strip('`"[]') for part in name.strip().split("."))
+
+
+def _statements(sql: str) -> list[str]:
+    """Split `sql` on `;` outside quotes, dropping comments."""
+    statements: list[str] = []
+    current: list[str] = []
+    quote: str | None = None
+    index = 0
+    while index < len(sql):
+        char = sql[index]
+        if quote is not None:
+            current.append(char)
+            if char == quote:
+                quote = None
+        elif char in "'\"`":
+            quote = char
+            current.append(char)
+        elif sql.startswith("--", index):
+            index = sql.find("\n", index)
+            if index == -1:
+                break
+            continue
+        elif sql.startswith("/*", index):
+            end = sql.find("*/", index + 2)
+            index = len(sql) if end == -1 else end + 2
+            current.append(" ")
+            continue
+        elif char == ";":
+            statements.append("".join(current).strip())
+            current = []
+        else:
+            current.append(char)
+        index += 1
+    statements.append("".join(current).strip())
+    return [statement for statement in statements if statement]
@@ -2,8 +2,12 @@
 
 from __future__ import annotations
 
-from dataclasses import dataclass, field
+from collections.abc import Callable, Iterable, Mapping, Sequence
+from dataclasses import asdict, dataclass, field
 from pathlib import Path
+from typing import Protocol, TextIO
+
+from .utils import posix_path
 
 
 @dataclass(frozen=True)
@@ -15,22 +19,85 @@ class FunctionDoc:
     args: list[str] = field(default_factory=list)
     decorators: list[str] = field(default_factory=list)
     is_async: bool = False
+    kind: str = "function"
+    signature: str | None = None
+    # `line`..`end_line` is the declaration and its body, never its decorators or doc
+    # comment; the doc comment (or Python docstring) has its own `doc_line`..`doc_end_line`.
+    end_line: int | None = None
+    type_params: list[str] = field(default_factory=list)
+    doc_line: int | None = None
+    doc_end_line: int | None = None
+    # Best-effort failure modes (Go): `returns_error`, `panics` and the `wrapped`
+    # fmt.Errorf message templates; empty when nothing was detected.
+    errors: dict[str, object] = field(default_factory=dict)
+    # Set by a `# docgenie: ignore` / `//docgenie:ignore` directive on or above the
+    # declaration; the symbol is left out of documentation coverage.
+    doc_ignore: bool = False
+    # Body of a Go `ExampleXxx` test function, `// Output:` comment included.
+    example: str | None = None
+    # Python deprecation message ("" when none was given); None when not deprecated.
+    deprecated: str | None = None
+    # Private API kept for `--visibility all`: unexported Go names, Java `private`
+    # members and Python names behind a `_` prefix or left out of `__all__`.
+    private: bool = False
+    # Heuristic cyclomatic complexity (1 + branch points in the body); None when the
+    # body's extent is unknown.
+    complexity: int | None = None
+    # Calling convention from a shell function's `# Usage:` comment line.
+    usage: str | None = None
+    # Go method receiver type: `*UserService` for a pointer receiver, `UserService` for
+    # a value receiver.
+    receiver: str | None = None
+    # Best-effort concurrency use of the body (Go): `goroutines` and `channels` flags and
+    # the `locks` taken (`Lock`, `RLock`); empty when nothing was detected.
+    concurrency: dict[str, object] = field(default_factory=dict)
 
     def to_public_dict(self) -> dict[str, object]:
         return {
             "name": self.name,
-            "file": str(self.file),
+            "file": posix_path(self.file),
             "line": self.line,
+            "end_line": self.end_line,
+            "doc_line": self.doc_line,
+            "doc_end_line": self.doc_end_line,
             "docstring": self.docstring,
             "args": list(self.args),
             "decorators": list(self.decorators),
             "is_async": self.is_async,
+            "kind": self.kind,
+            "signature": self.signature,
+            "type_params": list(self.type_params),
+            "errors": dict(self.errors),
+            "doc_ignore": self.doc_ignore,
+            "example": self.example,
+            "deprecated": self.deprecated,
+            "private": self.private,
+            "complexity": self.complexity,
+            "usage": self.usage,
+            "receiver": self.receiver,
+            "concurrency": dict(self.concurrency),
         }
 
 
 @dataclass(frozen=True)
 class MethodDoc(FunctionDoc):
-    pass
+    kind: str = "method"
+
+
+@dataclass(frozen=True)
+class FieldDoc:
+    name: str
+    type: str
+    tags: dict[str, str] = field(default_factory=dict)
+    docstring: str | None = None
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {
+            "name": self.name,
+            "type": self.type,
+            "tags": dict(self.tags),
+            "docstring": self.docstring,
+        }
 
 
 @dataclass(frozen=True)
@@ -42,16 +109,74 @@ class ClassDoc:
     bases: list[str] = field(default_factory=list)
     decorators: list[str] = field(default_factory=list)
     methods: list[MethodDoc] = field(default_factory=list)
+    kind: str = "class"
+    signature: str | None = None
+    fields: list[FieldDoc] = field(default_factory=list)
+    end_line: int | None = None
+    type_params: list[str] = field(default_factory=list)
+    doc_line: int | None = None
+    doc_end_line: int | None = None
+    doc_ignore: bool = False
+    deprecated: str | None = None
+    private: bool = False
+    # Go struct fields holding a mutex (`mutexes`) or a channel (`channels`), each as
+    # `{name, type}`; empty when there are none.
+    concurrency: dict[str, object] = field(default_factory=dict)
 
     def to_public_dict(self) -> dict[str, object]:
         return {
             "name": self.name,
-            "file": str(self.file),
+            "file": posix_path(self.file),
             "line": self.line,
+            "end_line": self.end_line,
+            "doc_line": self.doc_line,
+            "doc_end_line": self.doc_end_line,
             "docstring": self.docstring,
             "bases": list(self.bases),
             "decorators": list(self.decorators),
             "methods": [method.to_public_dict() for method in self.methods],
+            "kind": self.kind,
+            "signature": self.signature,
+            "fields": [item.to_public_dict() for item in self.fields],
+            "type_params": list(self.type_params),
+            "doc_ignore": self.doc_ignore,
+            "deprecated": self.deprecated,
+            "private": self.private,
+            "concurrency": dict(self.concurrency),
+        }
+
+
+@dataclass(frozen=True)
+class ConstantDoc:
+    name: str
+    file: Path
+    line: int
+    # Source text of the value; a Go spec that repeats the previous one's expression
+    # gets that expression.
+    expression: str
+    # The literal value when it can be worked out statically (`0` for `iota`), else None.
+    value: str | None = None
+    # Declared type, or the type of a literal value; None when neither is known.
+    type: str | None = None
+    docstring: str | None = None
+    # Go `const (...)` blocks that use `iota` form a group named after its type (or
+    # first constant); `index` is the constant's `iota`.
+    group: str | None = None
+    index: int | None = None
+    private: bool = False
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {
+            "name": self.name,
+            "file": posix_path(self.file),
+            "line": self.line,
+            "expression": self.expression,
+            "value": self.value,
+            "type": self.type,
+            "docstring": self.docstring,
+            "group": self.group,
+            "index": self.index,
+            "private": self.private,
         }
 
 
@@ -60,12 +185,19 @@ class ParseResult:
     functions: list[FunctionDoc] = field(default_factory=list)
     classes: list[ClassDoc] = field(default_factory=list)
     imports: set[str] = field(default_factory=set)
+    skipped: list[str] = field(default_factory=list)
+    # The file's own documentation: a Python module docstring or a Go package comment.
+    module_doc: str | None = None
+    constants: list[ConstantDoc] = field(default_factory=list)
 
     def to_public_dict(self) -> dict[str, object]:
         return {
             "functions": [func.to_public_dict() for func in self.functions],
             "classes": [cls.to_public_dict() for cls in self.classes],
             "imports": sorted(self.imports),
+            "skipped": list(self.skipped),
+            "module_doc": self.module_doc,
+            "constants": [const.to_public_dict() for const in self.constants],
         }
 
 
@@ -76,6 +208,44 @@ class FileAnalysis:
     parse: ParseResult
 
 
+@dataclass(frozen=True)
+class PluginFile:
+    """One parsed source file as analyzer plugins see it; symbols use the public dict shape."""
+
+    path: str
+    language: str
+    functions: list[dict[str, object]] = field(default_factory=list)
+    classes: list[dict[str, object]] = field(default_factory=list)
+    imports: list[str] = field(default_factory=list)
+
+
+@dataclass(frozen=True)
+class PluginSection:
+    """Extra README section returned by an analyzer plugin; `body` is Markdown."""
+
+    title: str
+    body: str
+    plugin: str = ""
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {"title": self.title, "body": self.body, "plugin": self.plugin}
+
+
+class AnalyzerPlugin(Protocol):
+    """Contract for custom analyzers.
+
+    Register a callable under the `docgenie.analyzers` entry point group or pass
+    `--plugin module.path[:function]` (the function defaults to `analyze`). It runs once
+    per analysis, after language parsing and before generation, and returns the sections
+    to add to the README, either as `PluginSection`s or as `{"title", "body"}` mappings.
+    Exceptions are counted under `skipped_reasons["plugin_error"]` instead of aborting.
+    """
+
+    def __call__(
+        self, root_path: Path, files: Sequence[PluginFile]
+    ) -> Iterable[PluginSection | Mapping[str, str]]: ...
+
+
 @dataclass(frozen=True)
 class FileIndexRecord:
     path: str
@@ -113,6 +283,9 @@ class RunMetrics:
     duration_sec: float = 0.0
     cache_hit_ratio: float = 0.0
     skip_reasons: dict[str, int] = field(default_factory=dict)
+    cache_hits: int = 0
+    pruned_files: int = 0
+    coverage: dict[str, object] = field(default_factory=dict)
 
 
 @dataclass(frozen=True)
@@ -129,8 +302,38 @@ class SkipReason:
     reason: str
 
 
+@dataclass(frozen=True)
+class AnalysisOptions:
+    """Options for `docgenie.api.analyze`, the library form of `docgenie analyze`.
+
+    `config_overrides` is deep-merged over the project's `.docgenie.yaml` and has the
+    same shape (`{"analysis": {"incremental": False}}`). With `use_config_file` False
+    the file is not read and the overrides apply to the defaults. `progress` is called
+    as `progress(processed, total)` while files are parsed, e.g. a
+    `docgenie.progress.ProgressReporter`. With `json_stream` the public JSON document
+    is written there module by module as it is compiled, and the returned result has
+    empty `functions` and `classes`.
+    """
+
+    ignore_patterns: tuple[str, ...] = ()
+    enable_tree_sitter: bool = True
+    config_overrides: dict[str, object] = field(default_factory=dict)
+    use_config_file: bool = True
+    progress: Callable[[int, int], None] | None = field(default=None, compare=False)
+    json_stream: TextIO | None = field(default=None, compare=False)
+
+
 @dataclass
 class AnalysisResult:
+    """Everything `CodebaseAnalyzer` found in one run.
+
+    `functions` and `classes` hold one dict per symbol (`name`, `file`, `line`,
+    `docstring`, ...; classes add `methods` and `fields`), sorted by file and line.
+    `languages` maps language to file count, `file_imports` maps each analyzed file to
+    what it imports, and `config` is the effective configuration. `to_public_dict()`
+    gives the JSON shape of `docgenie analyze --format json`, which the generators take.
+    """
+
     project_name: str
     files_analyzed: int
     languages: dict[str, int]
@@ -146,12 +349,31 @@ class AnalysisResult:
     website_detection_reason: str
     root_path: Path
     file_imports: dict[str, list[str]] = field(default_factory=dict)
+    module_docs: dict[str, str] = field(default_factory=dict)
+    # Module-level constants per root-relative file, as `ConstantDoc.to_public_dict()`.
+    constants: dict[str, list[dict[str, object]]] = field(default_factory=dict)
     config: dict[str, object] = field(default_factory=dict)
     diff_summary: dict[str, object] = field(default_factory=dict)
     folder_reviews: list[dict[str, object]] = field(default_factory=list)
     file_reviews: list[dict[str, object]] = field(default_factory=list)
     output_links: list[dict[str, object]] = field(default_factory=list)
+    http_routes: list[dict[str, object]] = field(default_factory=list)
+    symbol_references: dict[str, list[str]] = field(default_factory=dict)
+    external_calls: dict[str, list[str]] = field(default_factory=dict)
+    cli_interface: dict[str, object] = field(default_factory=dict)
+    env_vars: list[dict[str, object]] = field(default_factory=list)
+    tech_debt: list[dict[str, object]] = field(default_factory=list)
+    feature_flags: list[dict[str, object]] = field(default_factory=list)
+    database_schema: dict[str, object] = field(default_factory=dict)
+    grpc_api: dict[str, object] = field(default_factory=dict)
+    make_targets: list[dict[str, object]] = field(default_factory=list)
+    plugin_sections: list[dict[str, object]] = field(default_factory=list)
+    license: dict[str, str] = field(default_factory=dict)
+    # User-written header from the `project` config block or OVERVIEW.md.
+    project_overview: dict[str, object] = field(default_factory=dict)
     readme_readiness: dict[str, object] = field(default_factory=dict)
+    skipped_reasons: dict[str, int] = field(default_factory=dict)
+    run_metrics: dict[str, object] = field(default_factory=dict)
 
     def to_public_dict(self) -> dict[str, object]:
         return {
@@ -167,6 +389,8 @@ class AnalysisResult:
             "classes": self.classes,
             "imports": self.imports,
             "file_imports": self.file_imports,
+            "module_docs": self.module_docs,
+            "constants": self.constants,
             "documentation_files": self.documentation_files,
             "config_files": self.config_files,
             "git_info": self.git_info,
@@ -178,5 +402,119 @@ class AnalysisResult:
             "folder_reviews": self.folder_reviews,
             "file_reviews": self.file_reviews,
             "output_links": self.output_links,
+            "http_routes": self.http_routes,
+            "symbol_references": self.symbol_references,
+            "external_calls": self.external_calls,
+            "cli_interface": self.cli_interface,
+            "env_vars": self.env_vars,
+            "tech_debt": self.tech_debt,
+            "feature_flags": self.feature_flags,
+            "database_schema": self.database_schema,
+            "grpc_api": self.grpc_api,
+            "make_targets": self.make_targets,
+            "plugin_sections": self.plugin_sections,
+            "license": dict(self.license),
+            "project_overview": self.project_overview,
             "readme_readiness": self.readme_readiness,
+            "skipped_reasons": dict(self.skipped_reasons),
+            "run_metrics": dict(self.run_metrics),
+        }
+
+
+# Bump on any backward-incompatible change to the `analyze --schema-version` document:
+# removed or renamed keys, changed types or changed meaning. New optional keys do not bump it.
+SCHEMA_VERSION = 1
+
+
+@dataclass(frozen=True)
+class SchemaSymbol:
+    """A documented symbol. Methods are qualified by their owner (`Class.method`)."""
+
+    symbol_id: str
+    name: str
+    qualified_name: str
+    kind: str
+    line: int
+    end_line: int | None
+    signature: str | None
+    docstring: str | None
+    parent: str | None = None
+    last_modified: dict[str, str] | None = None
+    doc_line: int | None = None
+    doc_end_line: int | None = None
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {
+            "id": self.symbol_id,
+            "name": self.name,
+            "qualified_name": self.qualified_name,
+            "kind": self.kind,
+            "line": self.line,
+            "end_line": self.end_line,
+            "doc_line": self.doc_line,
+            "doc_end_line": self.doc_end_line,
+            "signature": self.signature,
+            "docstring": self.docstring,
+            "parent": self.parent,
+            "last_modified": self.last_modified,
+        }
+
+
+@dataclass(frozen=True)
+class SchemaModule:
+    """One analyzed source file, addressed by its repository-relative POSIX path."""
+
+    path: str
+    language: str
+    imports: list[str] = field(default_factory=list)
+    symbols: list[SchemaSymbol] = field(default_factory=list)
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {
+            "path": self.path,
+            "language": self.language,
+            "imports": list(self.imports),
+            "symbols": [symbol.to_public_dict() for symbol in self.symbols],
+        }
+
+
+@dataclass(frozen=True)
+class SchemaEdge:
+    """An impact edge between `file:<path>`, `module:<name>` and symbol ids."""
+
+    source: str
+    target: str
+    kind: str
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {"source": self.source, "target": self.target, "kind": self.kind}
+
+
+@dataclass(frozen=True)
+class AnalysisDocument:
+    """Versioned, machine-readable analysis emitted by `analyze --format json --schema-version`."""
+
+    project_name: str
+    root_path: str
+    generator_version: str
+    languages: dict[str, int]
+    modules: list[SchemaModule]
+    edges: list[SchemaEdge]
+    run_metrics: RunMetrics
+    skipped_reasons: dict[str, int] = field(default_factory=dict)
+    schema_version: int = SCHEMA_VERSION
+
+    def to_public_dict(self) -> dict[str, object]:
+        return {
+            "schema_version": self.schema_version,
+            "generator": {"name": "docgenie", "version": self.generator_version},
+            "project": {
+                "name": self.project_name,
+                "root_path": self.root_path,
+                "languages": dict(self.languages),
+            },
+            "modules": [module.to_public_dict() for module in self.modules],
+            "edges": [edge.to_public_dict() for edge in self.edges],
+            "run_metrics": asdict(self.run_metrics),
+            "skipped_reasons": dict(self.skipped_reasons),
         }
@@ -0,0 +1,719 @@
+"""Group analyzed symbols by source module for per-module README tables."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Callable
+from pathlib import Path, PurePosixPath
+from typing import Any
+
+from .reproducible import path_root
+from .sanitize import sanitize_attribute
+from .utils import get_file_language, posix_path
+
+SUMMARY_LIMIT = 120
+# Long constant values (big dict literals, joined strings) are cut to this in tables.
+CONSTANT_VALUE_LIMIT = 60
+_ELLIPSIS = "..."
+# A link whose `[text]` or `(url)` the cut left unfinished.
+_OPEN_LINK_RE = re.compile(r"\[[^\]]*(?:\]\([^)]*)?$")
+# A line opening (or closing) a fenced code block in a docstring.
+_FENCE_RE = re.compile(r"^\s*(?:```|~~~)", re.MULTILINE)
+_FENCE_LINE_RE = re.compile(r"^\s*(?P<fence>`{3,}|~{3,})(?P<info>.*)$")
+# An ATX heading in a docstring, which would otherwise become a README section.
+_DOC_HEADING_RE = re.compile(r"^\s{0,3}#{1,6}(?:\s+(?P<text>.*?))?(?:\s+#+)?\s*$")
+
+# Struct tag keys that control how a field is serialized (Go `json:"id"`, `db:"id"`, ...).
+SERIALIZATION_TAG_KEYS = ("json", "xml", "yaml", "toml", "db", "bson", "msgpack", "form")
+_MEMBER_ROW_KINDS = ("abstract_method", "property")
+# Go predeclared types and type keywords, which never refer to a declared constraint.
+_PREDECLARED_TYPES = frozenset(
+    "any bool byte chan comparable complex64 complex128 error float32 float64 func int int8 "
+    "int16 int32 int64 interface map rune string struct uint uint8 uint16 uint32 uint64 "
+    "uintptr".split()
+)
+_TYPE_NAME_RE = re.compile(r"[A-Za-z_][\w.]*")
+
+# How rows are ordered within each module table. `alpha` is the default because it does
+# not move when declarations are reordered, keeping CI diffs of generated docs quiet.
+SYMBOL_SORT_ORDERS = ("source", "alpha", "kind")
+DEFAULT_SYMBOL_SORT = "alpha"
+_SYMBOL_SORT_KEYS: dict[str, Callable[[dict[str, Any]], tuple[Any, ...]]] = {
+    "source": lambda sym: (sym["line"], sym["name"]),
+    "alpha": lambda sym: (sym["name"].casefold(), sym["name"], sym["line"]),
+    "kind": lambda sym: (sym["kind"], sym["name"].casefold(), sym["name"], sym["line"]),
+}
+
+# `package` merges the files of one Go or Python package into a single section.
+MODULE_GROUPINGS = ("file", "package")
+DEFAULT_MODULE_GROUPING = "file"
+
+# `public` documents the exported API only; `all` adds private symbols for app developers.
+VISIBILITY_LEVELS = ("public", "all")
+DEFAULT_VISIBILITY = "public"
+
+
+def build_module_index(
+    analysis_data: dict[str, Any], sort: str | None = None, group_by: str | None = None
+) -> list[dict[str, Any]]:
+    """Return one entry per source file with its symbols ordered by `sort`.
+
+    `sort` is one of `SYMBOL_SORT_ORDERS`; by default it comes from
+    `template_customizations.sort_symbols`. `source` keeps declaration order.
+    With `group_by="package"` (default: `template_customizations.group_by`) there is
+    one entry per `package_of` instead, listing its `files`.
+
+    Each entry's `summary` is the first paragraph of its module docstring or Go package
+    comment. Without one it is the summary of the entry's most referenced documented
+    symbol, named in `summary_from` so templates can mark it as synthesized.
+
+    Visible module-level constants are listed in source order under `constant_groups`,
+    one untitled group first and then one group per Go `iota` block. Files with only
+    constants still get an entry.
+    """
+    order = sort or symbol_sort_order(analysis_data)
+    sort_key = _SYMBOL_SORT_KEYS[order]
+    root = path_root(analysis_data)
+    modules: dict[str, list[dict[str, Any]]] = {}
+    run_metrics = analysis_data.get("run_metrics")
+    coverage = run_metrics.get("coverage") if isinstance(run_metrics, dict) else None
+    covered_modules = coverage.get("modules", {}) if isinstance(coverage, dict) else {}
+    type_modules: dict[str, list[str]] = {}
+    for item in analysis_data.get("classes", []):
+        if isinstance(item, dict) and item.get("name"):
+            module = relative_path(root, str(item.get("file", "")))
+            type_modules.setdefault(str(item["name"]), []).append(module)
+
+    visibility = symbol_visibility(analysis_data)
+    for item in analysis_data.get("functions", []):
+        if isinstance(item, dict) and is_visible(item, visibility):
+            _add_symbol(modules, root, item, default_kind="function", types=type_modules)
+    for item in analysis_data.get("classes", []):
+        if isinstance(item, dict) and is_visible(item, visibility):
+            _add_symbol(modules, root, item, default_kind="class", types=type_modules)
+            # Abstract methods get their own rows so implementers can see what to provide;
+            # documented properties (Swift computed properties) are listed the same way.
+            for method in item.get("methods") or []:
+                if (
+                    isinstance(method, dict)
+                    and method.get("kind") in _MEMBER_ROW_KINDS
+                    and is_visible(method, visibility)
+                ):
+                    owned = {**method, "name": f"{item.get('name')}::{method.get('name')}"}
+                    _add_symbol(modules, root, owned, default_kind="method", types=type_modules)
+
+    constants = _module_constants(analysis_data, visibility)
+    for path in constants:
+        modules.setdefault(path, [])
+
+    docs = analysis_data.get("module_docs")
+    docs = docs if isinstance(docs, dict) else {}
+    references = analysis_data.get("symbol_references")
+    references = references if isinstance(references, dict) else {}
+    if (group_by or module_grouping(analysis_data)) == "package":
+        return _anchor_constraints(
+            _package_index(modules, order, covered_modules, docs, references, constants)
+        )
+    index: list[dict[str, Any]] = []
+    for path in sorted(modules):
+        symbols = sorted(modules[path], key=sort_key)
+        summary, summary_from = module_summary(docs.get(path), symbols, references, [path])
+        index.append(
+            {
+                "path": path,
+                "language": get_file_language(Path(path)) or "unknown",
+                "summary": summary,
+                "summary_from": summary_from,
+                "symbols": symbols,
+                "constant_groups": group_constants(constants.get(path, [])),
+                "has_last_updated": any(sym["last_updated"] for sym in symbols),
+                "has_complexity": any(sym["complexity"] is not None for sym in symbols),
+                "coverage": covered_modules.get(path),
+                "files": [path],
+            }
+        )
+    return _anchor_constraints(index)
+
+
+def _anchor_constraints(index: list[dict[str, Any]]) -> list[dict[str, Any]]:
+    """Point each type parameter constraint at the heading of the module defining it."""
+    from .html_sections import module_anchors  # noqa: PLC0415 - avoids import cycle
+
+    constraints = [
+        item for entry in index for sym in entry["symbols"] for item in sym["constraints"]
+    ]
+    anchors = module_anchors(
+        [*(entry["path"] for entry in index), *(item["module"] for item in constraints)]
+    )
+    for item in constraints:
+        item["anchor"] = anchors[item["module"]]
+    return index
+
+
+def _package_index(
+    modules: dict[str, list[dict[str, Any]]],
+    order: str,
+    covered_modules: dict[str, Any],
+    docs: dict[str, Any],
+    references: dict[str, Any],
+    constants: dict[str, list[dict[str, Any]]],
+) -> list[dict[str, Any]]:
+    """Merge per-file symbol lists by package, dropping repeats of the same declaration.
+
+    A symbol declared in several files of a package (build-tagged variants of one
+    function) is listed once, from the first file in path order. Under `source`
+    order, symbols follow file path and then line. The package summary comes from
+    `__init__.py` or the first file with a package comment, such as a symbol-less
+    Go `doc.go`.
+    """
+    packages: dict[str, dict[str, list[Any]]] = {}
+    for path in sorted(modules):
+        entry = packages.setdefault(package_of(path), {"files": [], "symbols": []})
+        entry["files"].append(path)
+        for sym in modules[path]:
+            constraints = [
+                {**item, "module": owner}
+                for item in sym["constraints"]
+                if (owner := package_of(item["module"]))
+            ]
+            entry["symbols"].append({**sym, "file": path, "constraints": constraints})
+
+    sort_key = _SYMBOL_SORT_KEYS[order]
+    index: list[dict[str, Any]] = []
+    for package in sorted(packages):
+        files = packages[package]["files"]
+        seen: set[tuple[str, str, str]] = set()
+        symbols: list[dict[str, Any]] = []
+        for sym in packages[package]["symbols"]:
+            key = (sym["name"], sym["kind"], sym["signature"])
+            if key not in seen:
+                seen.add(key)
+                symbols.append(sym)
+        if order == "source":
+            symbols.sort(key=lambda sym: (sym["file"], sym["line"], sym["name"]))
+        else:
+            symbols.sort(key=lambda sym: (*sort_key(sym), sym["file"]))
+        stats = [covered_modules[path] for path in files if path in covered_modules]
+        covered = sum(int(item.get("covered", 0)) for item in stats)
+        total = sum(int(item.get("total", 0)) for item in stats)
+        documented = sorted(
+            (PurePosixPath(path).name != "__init__.py", path)
+            for path in docs
+            if package_of(path) == package
+        )
+        doc = docs[documented[0][1]] if documented else None
+        summary, summary_from = module_summary(doc, symbols, references, files)
+        index.append(
+            {
+                "path": package,
+                "language": get_file_language(Path(files[0])) or "unknown",
+                "summary": summary,
+                "summary_from": summary_from,
+                "symbols": symbols,
+                "constant_groups": group_constants(
+                    [row for path in files for row in constants.get(path, [])]
+                ),
+                "has_last_updated": any(sym["last_updated"] for sym in symbols),
+                "has_complexity": any(sym["complexity"] is not None for sym in symbols),
+                "coverage": {
+                    "covered": covered,
+                    "total": total,
+                    "percent": round(covered * 100 / total, 1) if total else 0.0,
+                }
+                if stats
+                else None,
+                "files": files,
+            }
+        )
+    return index
+
+
+def _module_constants(
+    analysis_data: dict[str, Any], visibility: str
+) -> dict[str, list[dict[str, Any]]]:
+    """Return table rows for the visible constants of each module, in source order."""
+    by_file = analysis_data.get("constants")
+    if not isinstance(by_file, dict):
+        return {}
+    rows: dict[str, list[dict[str, Any]]] = {}
+    for path, items in by_file.items():
+        for item in items if isinstance(items, list) else []:
+            if not isinstance(item, dict) or not is_visible(item, visibility):
+                continue
+            value = item.get("value")
+            shown = str(value if value is not None else item.get("expression", ""))
+            shown = _table_cell(summarize(" ".join(shown.split()), CONSTANT_VALUE_LIMIT))
+            rows.setdefault(str(path), []).append(
+                {
+                    "name": str(item.get("name", "")),
+                    "file": str(path),
+                    "line": int(item.get("line", 0) or 0),
+                    "type": _table_cell(str(item["type"])) if item.get("type") else None,
+                    "value": shown,
+                    "value_span": code_span(shown),
+                    "summary": _table_cell(summarize(item.get("docstring"))),
+                    "group": item.get("group"),
+                    "index": item.get("index"),
+                }
+            )
+    for path_rows in rows.values():
+        path_rows.sort(key=lambda row: row["line"])
+    return rows
+
+
+def group_constants(rows: list[dict[str, Any]]) -> list[dict[str, Any]]:
+    """Split constant rows into `{name, constants}` groups for the module tables.
+
+    Constants outside an `iota` block share one group whose `name` is None, listed
+    first; each `iota` block follows as its own group, named after its type.
+    """
+    ungrouped = [row for row in rows if not row["group"]]
+    blocks: dict[tuple[str, str], list[dict[str, Any]]] = {}
+    for row in rows:
+        if row["group"]:
+            blocks.setdefault((row["file"], str(row["group"])), []).append(row)
+    groups = [{"name": None, "constants": ungrouped}] if ungrouped else []
+    groups.extend({"name": name, "constants": block} for (_, name), block in blocks.items())
+    return groups
+
+
+def package_of(module: str) -> str:
+    """Return the package a root-relative source path belongs to.
+
+    Go files belong to their directory (`internal/store`, or `.` at the root). Python
+    files belong to their dotted package with a leading `src` dropped
+    (`src/shop/models/user.py` -> `shop.models`); top-level modules stand alone.
+    Files in other languages keep their own path.
+    """
+    path = PurePosixPath(module)
+    if path.suffix == ".go":
+        return path.parent.as_posix()
+    if path.suffix in {".py", ".pyi"}:
+        parts = list(path.parent.parts)
+        if parts[:1] == ["src"]:
+            parts = parts[1:]
+        return ".".join(parts) or path.stem
+    return module
+
+
+def module_grouping(analysis_data: dict[str, Any]) -> str:
+    """Return the configured module grouping, falling back to the default."""
+    config = analysis_data.get("config", {})
+    customizations = config.get("template_customizations", {}) if isinstance(config, dict) else {}
+    value = customizations.get("group_by") if isinstance(customizations, dict) else None
+    return str(value) if value in MODULE_GROUPINGS else DEFAULT_MODULE_GROUPING
+
+
+def symbol_visibility(analysis_data: dict[str, Any]) -> str:
+    """Return the configured `analysis.visibility`, falling back to the default."""
+    config = analysis_data.get("config", {})
+    analysis = config.get("analysis", {}) if isinstance(config, dict) else {}
+    value = analysis.get("visibility") if isinstance(analysis, dict) else None
+    return str(value) if value in VISIBILITY_LEVELS else DEFAULT_VISIBILITY
+
+
+def is_private_name(name: str) -> bool:
+    """Return whether a leading underscore marks `name` private; `__dunder__` names are public."""
+    return name.startswith("_") and not (len(name) > 4 and name[:2] == name[-2:] == "__")
+
+
+def is_exported(item: dict[str, Any]) -> bool:
+    """Return whether a symbol is part of the public API.
+
+    Names with a leading underscore are private unless they are `__dunder__` names; in Go
+    only capitalized names are exported.
+    Parsers mark other private symbols (Java `private`, names outside Python's `__all__`)
+    with `private`.
+    """
+    if item.get("private"):
+        return False
+    name = str(item.get("name", ""))
+    if str(item.get("file", "")).endswith(".go"):
+        return name[:1].isupper()
+    return not is_private_name(name)
+
+
+def is_visible(item: dict[str, Any], visibility: str = DEFAULT_VISIBILITY) -> bool:
+    """Return whether a symbol belongs in documentation generated at `visibility`."""
+    return visibility == "all" or is_exported(item)
+
+
+def symbol_sort_order(analysis_data: dict[str, Any]) -> str:
+    """Return the configured module table order, falling back to the default."""
+    config = analysis_data.get("config", {})
+    customizations = config.get("template_customizations", {}) if isinstance(config, dict) else {}
+    value = customizations.get("sort_symbols") if isinstance(customizations, dict) else None
+    return str(value) if value in SYMBOL_SORT_ORDERS else DEFAULT_SYMBOL_SORT
+
+
+def _add_symbol(
+    modules: dict[str, list[dict[str, Any]]],
+    root: Path,
+    item: dict[str, Any],
+    *,
+    default_kind: str,
+    types: dict[str, list[str]],
+) -> None:
+    name = str(item.get("name", "")).strip()
+    if not name:
+        return
+    rel_path = relative_path(root, str(item.get("file", "")))
+    signature = item.get("signature") or _fallback_signature(name, item, default_kind)
+    complexity = item.get("complexity")
+    constraints = [
+        {"name": constraint, "module": module}
+        for constraint in type_param_constraints(item.get("type_params"))
+        if (module := _defining_module(constraint, rel_path, types)) is not None
+    ]
+    modules.setdefault(rel_path, []).append(
+        {
+            "name": name,
+            "kind": str(item.get("kind") or default_kind),
+            "line": int(item.get("line", 0) or 0),
+            "signature": _table_cell(str(signature)),
+            "summary": _table_cell(summarize(item.get("docstring"))),
+            "summary_title": summary_title(item.get("docstring")),
+            "doc": block_docstring(item.get("docstring")),
+            "last_updated": _table_cell(last_updated(item.get("last_modified"))),
+            "complexity": complexity if isinstance(complexity, int) else None,
+            "constraints": constraints,
+        }
+    )
+
+
+def type_param_constraints(type_params: Any) -> list[str]:
+    """Return the declared types named in type parameter constraints, in first-seen order.
+
+    `["K comparable", "V Number", "S ~[]V"]` -> `["Number"]`: predeclared types and
+    the type parameters themselves are skipped, and `pkg.Name` is reduced to `Name`.
+    """
+    if not isinstance(type_params, list):
+        return []
+    params = [str(param).split(None, 1) for param in type_params]
+    own = {parts[0] for parts in params if parts}
+    names: list[str] = []
+    for parts in params:
+        for token in _TYPE_NAME_RE.findall(parts[1] if len(parts) > 1 else ""):
+            bare = token.rsplit(".", 1)[-1]
+            if bare not in own and bare not in _PREDECLARED_TYPES and bare not in names:
+                names.append(bare)
+    return names
+
+
+def _defining_module(name: str, module: str, types: dict[str, list[str]]) -> str | None:
+    """Prefer a definition in `module`, then the only one; ambiguous names stay unlinked."""
+    candidates = types.get(name, [])
+    if module in candidates:
+        return module
+    return candidates[0] if len(candidates) == 1 else None
+
+
+def module_summary(
+    doc: Any,
+    symbols: list[dict[str, Any]],
+    references: dict[str, Any],
+    files: list[str],
+) -> tuple[str | None, str | None]:
+    """Return `(summary, summary_from)` for a module section.
+
+    A module doc gives its first paragraph on one line and `summary_from` None.
+    Otherwise the summary of the documented symbol mentioned by the most other files
+    (per `symbol_references`) is borrowed, ties going to the first row, and
+    `summary_from` names that symbol. `(None, None)` when nothing is documented.
+    """
+    if isinstance(doc, str) and doc.strip():
+        paragraph = re.split(r"\n\s*\n", doc.strip(), maxsplit=1)[0]
+        return " ".join(paragraph.split()), None
+    documented = [sym for sym in symbols if sym["summary"]]
+    if not documented:
+        return None, None
+
+    def mentions(sym: dict[str, Any]) -> int:
+        return sum(
+            1
+            for path, names in references.items()
+            if path not in files and isinstance(names, list) and sym["name"] in names
+        )
+
+    best = max(documented, key=mentions)
+    return best["summary"], best["name"]
+
+
+def summarize(docstring: Any, limit: int = SUMMARY_LIMIT) -> str:
+    """Return the first docstring line, shortened to `limit` characters for table display.
+
+    A long line is cut at a word boundary, code spans, emphasis and links left open by
+    the cut are closed or dropped, and `...` marks the cut.
+    """
+    first = _first_line(docstring)
+    if len(first) <= limit:
+        return first
+    budget = limit - len(_ELLIPSIS)
+    while True:
+        cut = _word_cut(first, budget)
+        closers = "".join(marker for marker, _ in reversed(_open_markup(cut)))
+        if len(cut) + len(closers) <= budget:
+            return cut + closers + _ELLIPSIS
+        budget -= len(closers)
+
+
+def summary_title(docstring: Any) -> str:
+    """Return the full first line of a summary `summarize` shortened, as an HTML attribute.
+
+    Empty when the summary is not shortened. Backticks are dropped since the title is
+    plain text, and `|` is encoded so it cannot end a table cell.
+    """
+    first = _first_line(docstring)
+    if len(first) <= SUMMARY_LIMIT:
+        return ""
+    return sanitize_attribute(first.replace("`", "")).replace("|", "&#124;")
+
+
+def block_docstring(docstring: Any) -> str:
+    """Return the whole docstring when it holds a fenced code block, else "".
+
+    A fence cannot live in a one-line table cell, so templates list such symbols below
+    their module's table with the docstring in full rather than as a table row.
+    Headings outside the fences become bold lines so they do not open README sections.
+    A docstring with a fence left open stays a table row, since it would swallow the
+    rest of the README. Only fences are detected: indented code, lists and tables keep
+    the one-line summary.
+    """
+    if not isinstance(docstring, str) or not _FENCE_RE.search(docstring):
+        return ""
+    lines: list[str] = []
+    fence = ""
+    for line in docstring.strip().splitlines():
+        match = _FENCE_LINE_RE.match(line)
+        if fence:
+            # A closing fence repeats the opener's character at least as many times.
+            if match and match["fence"][0] == fence[0] and len(match["fence"]) >= len(fence):
+                fence = "" if not match["info"].strip() else fence
+        elif match and not (match["fence"][0] == "`" and "`" in match["info"]):
+            fence = match["fence"]
+        elif heading := _DOC_HEADING_RE.match(line):
+            line = f"**{heading['text']}**" if heading["text"] else ""
+        lines.append(line)
+    return "" if fence else "\n".join(lines)
+
+
+def _first_line(docstring: Any) -> str:
+    if not isinstance(docstring, str) or not docstring.strip():
+        return ""
+    return docstring.strip().splitlines()[0].strip()
+
+
+def _word_cut(text: str, budget: int) -> str:
+    """Cut `text` to at most `budget` characters, preferring the last word boundary."""
+    cut = text[:budget]
+    if len(text) > budget and not text[budget].isspace():
+        space = cut.rfind(" ")
+        # A single overlong word (a URL, say) is cut where it is.
+        if space > budget // 2:
+            cut = cut[:space]
+    link = _OPEN_LINK_RE.search(cut)
+    if link is not None and link.start() > 0:
+        cut = cut[: link.start()]
+    cut = cut.rstrip(" ,;:-")
+    # An opener the cut left with nothing after it is dropped rather than closed.
+    opened = _open_markup(cut)
+    while opened and opened[-1][1] + len(opened[-1][0]) >= len(cut):
+        cut = cut[: opened[-1][1]].rstrip(" ,;:-")
+        opened = _open_markup(cut)
+    return cut
+
+
+def _open_markup(text: str) -> list[tuple[str, int]]:
+    """Return the code spans and emphasis markers `text` leaves open, with their offsets."""
+    stack: list[tuple[str, int]] = []
+    idx = 0
+    while idx < len(text):
+        char = text[idx]
+        if char == "\\":
+            idx += 2
+            continue
+        run = len(text[idx:]) - len(text[idx:].lstrip(char)) if char in "`*_" else 1
+        in_code = bool(stack) and stack[-1][0].startswith("`")
+        if char == "`":
+            marker = char * run
+            if in_code and stack[-1][0] == marker:
+                stack.pop()
+            elif not in_code:
+                stack.append((marker, idx))
+            idx += run
+            continue
+        if char in "*_" and not in_code:
+            marker = char * min(run, 2)
+            before = text[idx - 1] if idx else " "
+            after = text[idx + len(marker)] if idx + len(marker) < len(text) else " "
+            if stack and stack[-1][0] == marker and not before.isspace():
+                stack.pop()
+            elif not after.isspace() and (char == "*" or not before.isalnum()):
+                stack.append((marker, idx))
+            idx += len(marker)
+            continue
+        idx += 1
+    return stack
+
+
+def last_updated(stamp: Any) -> str:
+    """Format git blame metadata as `2024-05-01 (alice)`, or "" when there is none."""
+    if not isinstance(stamp, dict) or not stamp.get("date"):
+        return ""
+    author = str(stamp.get("author", "")).strip()
+    return f"{stamp['date']} ({author})" if author else str(stamp["date"])
+
+
+def error_note(errors: Any) -> dict[str, Any] | None:
+    """Summarize detected failure modes as `May return an error or panic` plus wrap templates."""
+    if not isinstance(errors, dict) or not errors:
+        return None
+    outcomes = [
+        text
+        for key, text in (("returns_error", "return an error"), ("panics", "panic"))
+        if errors.get(key)
+    ]
+    wrapped = [str(template) for template in errors.get("wrapped") or []]
+    summary = f"May {' or '.join(outcomes)}" if outcomes else "Wraps errors"
+    return {"summary": summary, "wrapped": wrapped}
+
+
+def concurrency_note(symbol: Any) -> str | None:
+    """Summarize a Go symbol's detected concurrency use as a sentence, or None.
+
+    A function says what its body does: `Launches goroutines and takes a read lock`. A
+    type holding a mutex is called safe for concurrent use, on the assumption that the
+    mutex guards its state, followed by its channel fields and the methods that lock
+    or launch goroutines.
+    """
+    info = symbol.get("concurrency") if isinstance(symbol, dict) else None
+    if not isinstance(info, dict) or not info:
+        return None
+    if "mutexes" not in info:
+        note = _series(_concurrency_actions(info))
+        return note[:1].upper() + note[1:] if note else None
+    mutexes = [item for item in info.get("mutexes") or [] if isinstance(item, dict)]
+    channels = [item for item in info.get("channels") or [] if isinstance(item, dict)]
+    methods = [
+        (f"`{method.get('name')}`", method.get("concurrency") or {})
+        for method in symbol.get("methods") or []
+        if isinstance(method, dict) and isinstance(method.get("concurrency") or {}, dict)
+    ]
+    spawning = [name for name, found in methods if found.get("goroutines")]
+    locking = [name for name, found in methods if found.get("locks")]
+    parts: list[str] = []
+    if mutexes:
+        parts.append(f"safe for concurrent use: guarded by {_typed_fields(mutexes)}")
+    if channels:
+        parts.append(f"channel fields {_typed_fields(channels)}")
+    if spawning:
+        verb = "launches" if len(spawning) == 1 else "launch"
+        parts.append(f"{_series(spawning)} {verb} goroutines")
+    if mutexes and locking:
+        parts.append(f"{_series(locking)} {'takes' if len(locking) == 1 else 'take'} the lock")
+    note = "; ".join(parts)
+    return note[:1].upper() + note[1:] if note else None
+
+
+def _typed_fields(fields: list[dict[str, Any]]) -> str:
+    return _series([f"`{item.get('name')}` (`{item.get('type')}`)" for item in fields])
+
+
+def _concurrency_actions(info: dict[str, Any]) -> list[str]:
+    locks = [str(lock) for lock in info.get("locks") or []]
+    actions = [
+        text
+        for key, text in (("goroutines", "launches goroutines"), ("channels", "uses channels"))
+        if info.get(key)
+    ]
+    if locks == ["RLock"]:
+        actions.append("takes a read lock")
+    elif locks:
+        actions.append("takes a read and a write lock" if "RLock" in locks else "takes a lock")
+    return actions
+
+
+def _series(items: list[str]) -> str:
+    """Join `items` as `a`, `a and b` or `a, b and c`."""
+    if len(items) < 2:  # noqa: PLR2004
+        return "".join(items)
+    return f"{', '.join(items[:-1])} and {items[-1]}"
+
+
+def field_table(fields: Any) -> dict[str, Any]:
+    """Return the rows of a struct field table and which optional columns it needs.
+
+    `serialization` is set when any field has serialization tags and `descriptions`
+    when any field carries its own doc comment (Go field comments, KDoc `@property`).
+    """
+    rows: list[dict[str, str]] = []
+    for item in fields if isinstance(fields, list) else []:
+        if not isinstance(item, dict) or not item.get("name"):
+            continue
+        tags = item.get("tags") if isinstance(item.get("tags"), dict) else {}
+        rows.append(
+            {
+                "name": _table_cell(str(item["name"])),
+                "type": _table_cell(str(item.get("type", ""))),
+                "serialization": _table_cell(serialization_summary(tags)),
+                "description": _table_cell(summarize(item.get("docstring"))),
+            }
+        )
+    return {
+        "rows": rows,
+        "serialization": any(row["serialization"] for row in rows),
+        "descriptions": any(row["description"] for row in rows),
+    }
+
+
+def serialization_summary(tags: dict[str, Any]) -> str:
+    """Describe serialization tags, e.g. ``json: `id` (omitempty), db: omitted``."""
+    parts: list[str] = []
+    for key in SERIALIZATION_TAG_KEYS:
+        if key not in tags:
+            continue
+        name, _, options = str(tags[key]).partition(",")
+        if name == "-" and not options:
+            parts.append(f"{key}: omitted")
+            continue
+        label = f"{key}: `{name}`" if name else f"{key}: (field name)"
+        if options.strip(","):
+            label += f" ({options.strip(',')})"
+        parts.append(label)
+    return ", ".join(parts)
+
+
+def _fallback_signature(name: str, item: dict[str, Any], default_kind: str) -> str:
+    if default_kind in ("function", "method"):
+        args = ", ".join(str(arg) for arg in item.get("args", []))
+        if item.get("receiver"):
+            return f"func ({item['receiver']}) {name.rsplit('.', 1)[-1]}({args})"
+        return f"{name}({args})"
+    bases = item.get("bases", [])
+    return f"{name}({', '.join(str(base) for base in bases)})" if bases else name
+
+
+def relative_path(root: Path, file_path: str) -> str:
+    """Return `file_path` relative to `root` in POSIX form, or unchanged if outside it.
+
+    Backslashes count as separators on every OS, so Windows paths read from an
+    analysis JSON give the same module names as on Windows itself.
+    """
+    path = Path(posix_path(file_path))
+    try:
+        return path.resolve().relative_to(Path(posix_path(root)).resolve()).as_posix()
+    except (OSError, ValueError):
+        return path.as_posix()
+
+
+def code_span(text: str) -> str:
+    """Wrap `text` in a Markdown code span whose fence outruns every backtick run in it."""
+    longest = max((len(run) for run in re.findall(r"`+", text)), default=0)
+    fence = "`" * (longest + 1)
+    # Markdown strips one space from each side, so a backtick at either end stays literal.
+    padding = " " if text.startswith("`") or text.endswith("`") else ""
+    return f"{fence}{padding}{text}{padding}{fence}"
+
+
+def _table_cell(value: str) -> str:
+    return value.replace("|", "\\|").replace("\n", " ").strip()
@@ -0,0 +1,80 @@
+"""Load the project header (name, tagline, description, links) the user wrote themselves.
+
+The `project` config block (`.docgenie.yaml`, or `[project]` in docgenie.toml) and an
+`OVERVIEW.md` in the project root both override what DocGenie would detect or generate.
+The file's body is the description, kept verbatim; `---` front matter at its top may set
+the other keys. A key set in the config wins over the same key in the file.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Mapping
+from pathlib import Path
+from typing import Any
+
+import yaml
+
+OVERVIEW_FILENAME = "OVERVIEW.md"
+OVERVIEW_KEYS = ("name", "tagline", "description", "links")
+
+
+def load_project_overview(root_path: Path, config: Mapping[str, Any]) -> dict[str, Any]:
+    """Return the user-supplied header keys, with `links` as `[{label, url}]`.
+
+    Keys nobody set are left out, so callers fall back to detected values; the result is
+    empty when there is neither an `OVERVIEW.md` nor a `project` block.
+    """
+    overview = _overview_file(root_path / OVERVIEW_FILENAME)
+    block = config.get("project")
+    if isinstance(block, Mapping):
+        overview.update({key: block[key] for key in OVERVIEW_KEYS if block.get(key)})
+    result: dict[str, Any] = {
+        key: str(overview[key]).strip()
+        for key in ("name", "tagline", "description")
+        if overview.get(key) and str(overview[key]).strip()
+    }
+    links = _links(overview.get("links"))
+    if links:
+        result["links"] = links
+    return result
+
+
+def _overview_file(path: Path) -> dict[str, Any]:
+    try:
+        content = path.read_text(encoding="utf-8")
+    except (OSError, UnicodeDecodeError):
+        return {}
+    front_matter: dict[str, Any] = {}
+    lines = content.splitlines(keepends=True)
+    if lines and lines[0].strip() == "---":
+        end = next((idx for idx in range(1, len(lines)) if lines[idx].strip() == "---"), None)
+        if end is not None:
+            try:
+                loaded = yaml.safe_load("".join(lines[1:end]))
+            except yaml.YAMLError:
+                loaded = None
+            if isinstance(loaded, dict):
+                front_matter = {key: loaded[key] for key in OVERVIEW_KEYS if loaded.get(key)}
+                content = "".join(lines[end + 1 :])
+    if content.strip():
+        front_matter.setdefault("description", content)
+    return front_matter
+
+
+def _links(value: Any) -> list[dict[str, str]]:
+    """Accept `{label: url}` or a list of `{label, url}` mappings."""
+    if isinstance(value, Mapping):
+        pairs = list(value.items())
+    elif isinstance(value, list):
+        pairs = [
+            (item.get("label") or item.get("url"), item.get("url"))
+            for item in value
+            if isinstance(item, Mapping)
+        ]
+    else:
+        return []
+    return [
+        {"label": str(label).strip(), "url": str(url).strip()}
+        for label, url in pairs
+        if label and url and str(url).strip()
+    ]
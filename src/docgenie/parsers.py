@@ -3,16 +3,19 @@
 from __future__ import annotations
 
 import ast
+import inspect
 import re
 from collections.abc import Iterable, Sequence
-from dataclasses import dataclass, field
+from dataclasses import dataclass, field, replace
 from importlib import metadata
 from pathlib import Path
-from typing import Any, cast
+from typing import Any, TypedDict, cast
 
 from tree_sitter_language_pack import get_parser as ts_get_parser
 
-from .models import ClassDoc, FunctionDoc, MethodDoc, ParseResult
+from .complexity import apply_complexity, python_complexity
+from .models import ClassDoc, ConstantDoc, FunctionDoc, MethodDoc, ParseResult
+from .module_index import is_private_name
 
 
 @dataclass
@@ -22,10 +25,17 @@ class ParserPlugin:
     name: str
     languages: set[str]
     priority: int = 100  # lower number = higher priority
+    # Set by the registry for `--visibility all`: also return private symbols, marked
+    # `private`. Parsers that only ever see public declarations ignore it.
+    include_private: bool = False
 
     def supports(self, language: str) -> bool:
         return language.lower() in self.languages
 
+    def accepts(self, path: Path) -> bool:
+        """Return False to pass files of a supported language on to the next parser."""
+        return True
+
     def parse(
         self, content: str, path: Path, language: str
     ) -> ParseResult:  # pragma: no cover - interface
@@ -45,6 +55,8 @@ class PythonAstParser(ParserPlugin):
         functions: list[FunctionDoc] = []
         classes: list[ClassDoc] = []
         imports: set[str] = set()
+        lines = content.splitlines()
+        private = _python_private_nodes(tree)
 
         for node in ast.walk(tree):
             if isinstance(node, ast.FunctionDef):
@@ -53,10 +65,15 @@ class PythonAstParser(ParserPlugin):
                         name=node.name,
                         file=path,
                         line=node.lineno,
+                        end_line=node.end_lineno,
                         docstring=ast.get_docstring(node),
                         args=[arg.arg for arg in node.args.args],
+                        **_docstring_range(node),
                         decorators=[_get_decorator_name(dec) for dec in node.decorator_list],
                         is_async=isinstance(node, ast.AsyncFunctionDef),
+                        deprecated=_python_deprecation(node, lines),
+                        private=is_private_name(node.name) or node in private,
+                        complexity=python_complexity(node),
                     )
                 )
             elif isinstance(node, ast.ClassDef):
@@ -65,10 +82,15 @@ class PythonAstParser(ParserPlugin):
                         name=item.name,
                         file=path,
                         line=item.lineno,
+                        end_line=item.end_lineno,
                         docstring=ast.get_docstring(item),
                         args=[arg.arg for arg in item.args.args],
                         is_async=isinstance(item, ast.AsyncFunctionDef),
                         decorators=[_get_decorator_name(dec) for dec in item.decorator_list],
+                        deprecated=_python_deprecation(item, lines),
+                        private=is_private_name(item.name),
+                        complexity=python_complexity(item),
+                        **_docstring_range(item),
                     )
                     for item in node.body
                     if isinstance(item, ast.FunctionDef)
@@ -79,10 +101,14 @@ class PythonAstParser(ParserPlugin):
                         name=node.name,
                         file=path,
                         line=node.lineno,
+                        end_line=node.end_lineno,
                         docstring=ast.get_docstring(node),
                         bases=[_get_base_name(base) for base in node.bases],
                         decorators=[_get_decorator_name(dec) for dec in node.decorator_list],
                         methods=methods,
+                        deprecated=_python_deprecation(node, lines),
+                        private=is_private_name(node.name) or node in private,
+                        **_docstring_range(node),
                     )
                 )
             elif isinstance(node, ast.Import | ast.ImportFrom):
@@ -93,7 +119,13 @@ class PythonAstParser(ParserPlugin):
                     for alias in node.names:
                         imports.add(f"{module}.{alias.name}" if module else alias.name)
 
-        return ParseResult(functions=functions, classes=classes, imports=imports)
+        return ParseResult(
+            functions=functions,
+            classes=classes,
+            imports=imports,
+            module_doc=ast.get_docstring(tree),
+            constants=_python_constants(tree, content, path),
+        )
 
 
 class RegexParser(ParserPlugin):
@@ -180,7 +212,8 @@ class TreeSitterParser(ParserPlugin):
                         FunctionDoc(
                             name=name,
                             file=path,
-                            line=node.start_point[0] + 1,
+                            line=_declaration_line(node),
+                            end_line=node.end_point[0] + 1,
                             docstring=None,
                             args=[],
                         )
@@ -192,7 +225,8 @@ class TreeSitterParser(ParserPlugin):
                         ClassDoc(
                             name=name,
                             file=path,
-                            line=node.start_point[0] + 1,
+                            line=_declaration_line(node),
+                            end_line=node.end_point[0] + 1,
                             docstring=None,
                         )
                     )
@@ -268,6 +302,9 @@ def _language_patterns(language: str) -> _LanguagePatterns:
     }.get(lang, _LanguagePatterns())
 
 
+_ANNOTATION_NODES = {"decorator", "annotation", "marker_annotation", "attribute_item"}
+
+
 def _function_nodes(language: str) -> set[str]:
     return {
         "python": {"function_definition"},
@@ -341,6 +378,41 @@ def _extract_name(node: Any, content: str) -> str | None:
     return None
 
 
+class _DocRange(TypedDict):
+    doc_line: int | None
+    doc_end_line: int | None
+
+
+def _docstring_range(node: Any) -> _DocRange:
+    """Return `doc_line`/`doc_end_line` for the string literal `ast.get_docstring` reads."""
+    first = node.body[0] if node.body else None
+    if (
+        isinstance(first, ast.Expr)
+        and isinstance(first.value, ast.Constant)
+        and isinstance(first.value.value, str)
+    ):
+        return {"doc_line": first.lineno, "doc_end_line": first.end_lineno}
+    return {"doc_line": None, "doc_end_line": None}
+
+
+def _declaration_line(node: Any) -> int:
+    """Return the 1-based line where `node` starts, skipping decorators and annotations.
+
+    Java and TypeScript grammars nest annotations inside the declaration node, so its
+    start point alone would put `@Override` methods a line (or more) early.
+    """
+    for child in getattr(node, "children", None) or []:
+        if child.type in _ANNOTATION_NODES:
+            continue
+        if child.type == "modifiers":
+            keywords = [item for item in child.children if item.type not in _ANNOTATION_NODES]
+            if not keywords:
+                continue
+            return int(keywords[0].start_point[0]) + 1
+        return int(child.start_point[0]) + 1
+    return int(node.start_point[0]) + 1
+
+
 def _get_decorator_name(decorator: Any) -> str:
     if isinstance(decorator, ast.Name):
         return decorator.id
@@ -349,9 +421,174 @@ def _get_decorator_name(decorator: Any) -> str:
         if isinstance(owner, str):
             return f"{owner}.{decorator.attr}"
         return decorator.attr
+    if isinstance(decorator, ast.Call):
+        arguments = ", ".join(ast.unparse(arg) for arg in [*decorator.args, *decorator.keywords])
+        return f"{_get_decorator_name(decorator.func)}({arguments})"
     return str(decorator)
 
 
+def _python_private_nodes(tree: ast.Module) -> list[ast.AST]:
+    """Return the module-level functions and classes a literal `__all__` leaves out.
+
+    Without `__all__`, or when it is not a plain list or tuple of strings (so it cannot
+    be read statically), only the `_` prefix marks a name private.
+    """
+    exported: set[str] = set()
+    found = False
+    for statement in tree.body:
+        if isinstance(statement, ast.Assign):
+            targets, value = statement.targets, statement.value
+        elif isinstance(statement, ast.AugAssign | ast.AnnAssign):
+            targets, value = [statement.target], statement.value
+        else:
+            continue
+        if not any(isinstance(target, ast.Name) and target.id == "__all__" for target in targets):
+            continue
+        if not isinstance(value, ast.List | ast.Tuple) or not all(
+            isinstance(item, ast.Constant) and isinstance(item.value, str) for item in value.elts
+        ):
+            return []
+        if not isinstance(statement, ast.AugAssign):
+            exported = set()
+        exported.update(cast(ast.Constant, item).value for item in value.elts)
+        found = True
+    if not found:
+        return []
+    return [
+        statement
+        for statement in tree.body
+        if isinstance(statement, ast.FunctionDef | ast.AsyncFunctionDef | ast.ClassDef)
+        and statement.name not in exported
+    ]
+
+
+_CONSTANT_NAME_RE = re.compile(r"^_?[A-Z][A-Z0-9_]*$")
+_TRAILING_COMMENT_RE = re.compile(r"#\s*(?P<text>.*?)\s*$")
+
+
+def _python_constants(tree: ast.Module, content: str, path: Path) -> list[ConstantDoc]:
+    """Return module-level `UPPER_CASE = value` assignments.
+
+    The type is the annotation, or the type of a literal value; non-literal values
+    keep only their expression. A string on the next line (the attribute docstring
+    convention) or a trailing `#` comment documents the constant.
+    """
+    lines = content.splitlines()
+    constants: list[ConstantDoc] = []
+    for position, statement in enumerate(tree.body):
+        if isinstance(statement, ast.Assign) and len(statement.targets) == 1:
+            target, annotation = statement.targets[0], None
+        elif isinstance(statement, ast.AnnAssign) and statement.value is not None:
+            target, annotation = statement.target, statement.annotation
+        else:
+            continue
+        value_node = statement.value
+        if (
+            not isinstance(target, ast.Name)
+            or value_node is None
+            or not _CONSTANT_NAME_RE.match(target.id)
+        ):
+            continue
+        expression = ast.get_source_segment(content, value_node) or ""
+        try:
+            literal: Any = ast.literal_eval(value_node)
+        except (ValueError, TypeError, SyntaxError, RecursionError):
+            value, literal_type = None, None
+        else:
+            value, literal_type = expression, type(literal).__name__
+        docstring = None
+        following = tree.body[position + 1] if position + 1 < len(tree.body) else None
+        if (
+            isinstance(following, ast.Expr)
+            and isinstance(following.value, ast.Constant)
+            and isinstance(following.value.value, str)
+        ):
+            docstring = inspect.cleandoc(following.value.value) or None
+        elif statement.end_lineno is not None:
+            tail = lines[statement.end_lineno - 1][statement.end_col_offset or 0 :]
+            comment = _TRAILING_COMMENT_RE.search(tail)
+            docstring = (comment.group("text") or None) if comment else None
+        constants.append(
+            ConstantDoc(
+                name=target.id,
+                file=path,
+                line=statement.lineno,
+                expression=" ".join(expression.split()),
+                value=" ".join(value.split()) if value is not None else None,
+                type=ast.unparse(annotation) if annotation is not None else literal_type,
+                docstring=docstring,
+                private=is_private_name(target.id),
+            )
+        )
+    return constants
+
+
+_DEPRECATED_COMMENT_RE = re.compile(r"#\s*Deprecated:\s*(?P<note>.*)$")
+_DEPRECATION_CATEGORIES = {"DeprecationWarning", "PendingDeprecationWarning"}
+
+
+def _python_deprecation(node: Any, lines: Sequence[str]) -> str | None:
+    """Return the deprecation note of a Python function or class, or None.
+
+    `@deprecated("...")` (PEP 702 or the `deprecated` package), a
+    `warnings.warn(..., DeprecationWarning)` call in the body and a `# Deprecated:`
+    comment above or on the `def` line all count. The note is the message given,
+    or an empty string when there is none.
+    """
+    for decorator in node.decorator_list:
+        target = decorator.func if isinstance(decorator, ast.Call) else decorator
+        if _get_decorator_name(target).rsplit(".", 1)[-1] == "deprecated":
+            return _string_argument(decorator) if isinstance(decorator, ast.Call) else ""
+    comments = [lines[node.lineno - 1]]
+    above = min([node.lineno, *(dec.lineno for dec in node.decorator_list)]) - 2
+    while above >= 0 and lines[above].lstrip().startswith("#"):
+        comments.append(lines[above])
+        above -= 1
+    for comment in comments:
+        match = _DEPRECATED_COMMENT_RE.search(comment)
+        if match is not None:
+            return match.group("note").strip()
+    if isinstance(node, ast.FunctionDef | ast.AsyncFunctionDef):
+        for call in _own_calls(node):
+            name = _get_decorator_name(call.func)
+            if name in {"warn", "warnings.warn"} and _warns_deprecation(call):
+                return _string_argument(call)
+    return None
+
+
+def _own_calls(node: Any) -> Iterable[ast.Call]:
+    """Yield the calls in a function body, skipping nested functions and classes."""
+    stack = list(node.body)
+    while stack:
+        child = stack.pop()
+        if isinstance(child, ast.FunctionDef | ast.AsyncFunctionDef | ast.ClassDef | ast.Lambda):
+            continue
+        if isinstance(child, ast.Call):
+            yield child
+        stack.extend(ast.iter_child_nodes(child))
+
+
+def _warns_deprecation(call: ast.Call) -> bool:
+    categories = [*call.args[1:2], *(kw.value for kw in call.keywords if kw.arg == "category")]
+    return any(
+        _get_decorator_name(category).rsplit(".", 1)[-1] in _DEPRECATION_CATEGORIES
+        for category in categories
+    )
+
+
+def _string_argument(call: ast.Call) -> str:
+    """Return the message passed first to `call`; f-strings keep their literal parts."""
+    keywords = [kw.value for kw in call.keywords if kw.arg in {"message", "reason"}]
+    message = call.args[0] if call.args else next(iter(keywords), None)
+    if isinstance(message, ast.Constant) and isinstance(message.value, str):
+        return message.value.strip()
+    if isinstance(message, ast.JoinedStr):
+        return "".join(
+            part.value for part in message.values if isinstance(part, ast.Constant)
+        ).strip()
+    return ""
+
+
 def _get_base_name(base: Any) -> str:
     if isinstance(base, ast.Name):
         return base.id
@@ -367,27 +604,84 @@ class ParserRegistry:
     """Registry responsible for selecting the best parser for a language."""
 
     def __init__(
-        self, enable_tree_sitter: bool = True, plugins: Iterable[ParserPlugin] | None = None
+        self,
+        enable_tree_sitter: bool = True,
+        plugins: Iterable[ParserPlugin] | None = None,
+        *,
+        include_private: bool = False,
     ):
+        from .languages import builtin_language_parsers  # noqa: PLC0415 - avoids import cycle
+
         builtin: list[ParserPlugin] = [PythonAstParser(), RegexParser()]
+        builtin.extend(builtin_language_parsers())
         if enable_tree_sitter:
             builtin.append(TreeSitterParser())
         self.plugins: list[ParserPlugin] = sorted(
             list(builtin) + list(plugins or []) + list(_load_external_plugins()),
             key=lambda p: p.priority,
         )
+        for plugin in self.plugins:
+            plugin.include_private = include_private
 
-    def resolve(self, language: str) -> ParserPlugin | None:
+    def resolve(self, language: str, path: Path | None = None) -> ParserPlugin | None:
         for plugin in self.plugins:
-            if plugin.supports(language):
+            if plugin.supports(language) and (path is None or plugin.accepts(path)):
                 return plugin
         return None
 
     def parse(self, content: str, path: Path, language: str) -> ParseResult:
-        parser = self.resolve(language)
+        parser = self.resolve(language, path)
         if not parser:
             return ParseResult()
-        return parser.parse(content, path, language)
+        result = apply_ignore_directives(parser.parse(content, path, language), content)
+        return apply_complexity(result, content, language)
+
+
+IGNORE_DIRECTIVE_RE = re.compile(r"(?:#|//)\s*docgenie:\s*ignore\b")
+# Comment-based parsers hand the directive over as part of the doc comment.
+_DOCSTRING_DIRECTIVE_RE = re.compile(r"^\s*docgenie:\s*ignore\b.*$\n?", re.MULTILINE)
+# Decorator, attribute and comment lines that belong to the declaration below them.
+_DECLARATION_HEADER_RE = re.compile(r"^\s*(?:@|#|//|/\*|\*)")
+
+
+def apply_ignore_directives(result: ParseResult, content: str) -> ParseResult:
+    """Mark symbols carrying a `docgenie: ignore` comment.
+
+    The directive may trail the declaration line or sit on any line of the decorators and
+    doc comment directly above it.
+    """
+    if not IGNORE_DIRECTIVE_RE.search(content):
+        return result
+    lines = content.splitlines()
+    marked = {
+        number
+        for number, line in enumerate(lines, start=1)
+        if IGNORE_DIRECTIVE_RE.search(line) is not None
+    }
+
+    def ignored(symbol: Any) -> bool:
+        first = min(symbol.line, symbol.doc_line or symbol.line)
+        while first > 1 and _DECLARATION_HEADER_RE.match(lines[first - 2]):
+            first -= 1
+        return any(number in marked for number in range(first, symbol.line + 1))
+
+    def mark(symbol: Any, **changes: Any) -> Any:
+        docstring = symbol.docstring
+        if docstring and "docgenie:" in docstring:
+            docstring = _DOCSTRING_DIRECTIVE_RE.sub("", docstring).strip() or None
+        return replace(symbol, doc_ignore=ignored(symbol), docstring=docstring, **changes)
+
+    return ParseResult(
+        functions=[mark(func) for func in result.functions],
+        classes=[
+            mark(cls, methods=[mark(method) for method in cls.methods])
+            for cls in result.classes
+        ],
+        imports=result.imports,
+        skipped=result.skipped,
+        module_doc=result.module_doc,
+        constants=result.constants,
+    )
 
 
 def _load_external_plugins() -> Iterable[ParserPlugin]:
@@ -0,0 +1,115 @@
+"""Report analysis progress on stderr: files processed out of those discovered, with an ETA."""
+
+from __future__ import annotations
+
+import json
+import sys
+import time
+from collections import deque
+from collections.abc import Callable
+from typing import TextIO
+
+PROGRESS_MODES = ("text", "json")
+
+# The ETA follows the rate over the last few seconds, so a slow start (or a run of
+# cache hits) does not skew it for the rest of the run.
+_ETA_WINDOW_SEC = 10.0
+
+
+class ProgressReporter:
+    """Progress callback for `CodebaseAnalyzer`, called as `reporter(processed, total)`.
+
+    `text` keeps rewriting one status line and stays silent when `stream` (stderr by
+    default) is not a terminal; `json` writes one JSON object per line, terminal or not.
+    Updates are throttled to one per `interval` seconds; `finish` writes the last one.
+    """
+
+    def __init__(
+        self,
+        mode: str = "text",
+        stream: TextIO | None = None,
+        *,
+        interval: float = 0.2,
+        clock: Callable[[], float] = time.monotonic,
+    ) -> None:
+        if mode not in PROGRESS_MODES:
+            raise ValueError(f"Unknown progress mode: {mode}")
+        self.mode = mode
+        self.stream = stream if stream is not None else sys.stderr
+        self.interval = interval
+        self.clock = clock
+        self.enabled = mode == "json" or _is_terminal(self.stream)
+        self.processed = 0
+        self.total = 0
+        self._samples: deque[tuple[float, int]] = deque()
+        self._last_write: float | None = None
+
+    def __call__(self, processed: int, total: int) -> None:
+        now = self.clock()
+        self.processed, self.total = processed, total
+        self._samples.append((now, processed))
+        while len(self._samples) > 2 and now - self._samples[0][0] > _ETA_WINDOW_SEC:
+            self._samples.popleft()
+        if not self.enabled:
+            return
+        if self._last_write is not None and now - self._last_write < self.interval:
+            return
+        self._last_write = now
+        self._write("progress")
+
+    def eta(self) -> float | None:
+        """Seconds left at the rolling rate, or None before the rate is known."""
+        if len(self._samples) < 2:
+            return None
+        (start, done_then), (end, done_now) = self._samples[0], self._samples[-1]
+        if end <= start or done_now <= done_then:
+            return None
+        rate = (done_now - done_then) / (end - start)
+        return max(self.total - self.processed, 0) / rate
+
+    def finish(self) -> None:
+        """Write the final count; the text status line is cleared instead."""
+        if not self.enabled or self._last_write is None:
+            return
+        if self.mode == "json":
+            self._write("done")
+        else:
+            self.stream.write("\r\x1b[K")
+            self.stream.flush()
+
+    def _write(self, event: str) -> None:
+        eta = None if event == "done" else self.eta()
+        if self.mode == "json":
+            line = json.dumps(
+                {
+                    "event": event,
+                    "processed": self.processed,
+                    "total": self.total,
+                    "eta_sec": None if eta is None else round(eta, 1),
+                }
+            )
+            self.stream.write(line + "\n")
+        else:
+            percent = self.processed * 100 // self.total if self.total else 100
+            status = f"Analyzing files: {self.processed}/{self.total} ({percent}%)"
+            if eta is not None:
+                status += f", ETA {format_eta(eta)}"
+            self.stream.write(f"\r{status}\x1b[K")
+        self.stream.flush()
+
+
+def format_eta(seconds: float) -> str:
+    """Format `seconds` as `42s`, `3m05s` or `1h02m`."""
+    whole = int(round(seconds))
+    if whole < 60:
+        return f"{whole}s"
+    minutes, secs = divmod(whole, 60)
+    if minutes < 60:
+        return f"{minutes}m{secs:02d}s"
+    hours, minutes = divmod(minutes, 60)
+    return f"{hours}h{minutes:02d}m"
+
+
+def _is_terminal(stream: TextIO) -> bool:
+    isatty = getattr(stream, "isatty", None)
+    return bool(isatty and isatty())
@@ -0,0 +1,82 @@
+"""CI gating on the quality score and on per-language documentation coverage."""
+
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+from .exceptions import ConfigError
+from .module_index import symbol_visibility
+from .readme_quality import docstring_coverage
+from .utils import LANGUAGE_EXTENSIONS, get_file_language
+
+# `py=60` reads as `python=60`: any file extension DocGenie maps to a language is an alias.
+LANGUAGE_ALIASES = {
+    suffix.lstrip(".").lower(): name for suffix, name in LANGUAGE_EXTENSIONS.items()
+}
+
+
+def parse_language_thresholds(spec: str | None) -> dict[str, float]:
+    """Parse `go=80,py=60` into `{"go": 80.0, "python": 60.0}`.
+
+    Raises ConfigError for entries without `=`, non-numeric thresholds or values
+    outside 0-100.
+    """
+    thresholds: dict[str, float] = {}
+    for entry in (spec or "").split(","):
+        if not entry.strip():
+            continue
+        language, sep, value = entry.partition("=")
+        language = language.strip().lower()
+        if not sep or not language:
+            raise ConfigError(f"Invalid language threshold '{entry.strip()}' (expected lang=N)")
+        try:
+            threshold = float(value)
+        except ValueError as exc:
+            raise ConfigError(f"Threshold for '{language}' must be a number: {value!r}") from exc
+        if not 0 <= threshold <= 100:  # noqa: PLR2004
+            raise ConfigError(f"Threshold for '{language}' must be between 0 and 100")
+        thresholds[LANGUAGE_ALIASES.get(language, language)] = threshold
+    return thresholds
+
+
+def language_coverage(analysis_data: dict[str, Any]) -> dict[str, float]:
+    """Return the docstring coverage (0-100) of documentable symbols per language."""
+    grouped: dict[str, tuple[list[Any], list[Any]]] = {}
+    for key, slot in (("functions", 0), ("classes", 1)):
+        for item in analysis_data.get(key, []):
+            if not isinstance(item, dict) or not item.get("file"):
+                continue
+            language = get_file_language(Path(str(item["file"])))
+            if language is not None:
+                grouped.setdefault(language, ([], []))[slot].append(item)
+    visibility = symbol_visibility(analysis_data)
+    return {
+        language: round(docstring_coverage(functions, classes, visibility) * 100, 1)
+        for language, (functions, classes) in sorted(grouped.items())
+    }
+
+
+def evaluate_quality_gate(
+    score: float,
+    coverage: dict[str, float],
+    *,
+    fail_under: float | None = None,
+    language_thresholds: dict[str, float] | None = None,
+) -> list[str]:
+    """Return one message per failed threshold; an empty list means the gate passed.
+
+    A language with a threshold but no symbols fails, so a misspelled language name
+    cannot pass silently.
+    """
+    failures: list[str] = []
+    if fail_under is not None and score < fail_under:
+        failures.append(f"Quality score {score:g} is below --fail-under {fail_under:g}")
+    for language, threshold in sorted((language_thresholds or {}).items()):
+        if language not in coverage:
+            failures.append(f"{language}: no public symbols found")
+        elif coverage[language] < threshold:
+            failures.append(
+                f"{language}: symbol coverage {coverage[language]:g}% is below {threshold:g}%"
+            )
+    return failures
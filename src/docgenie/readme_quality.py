@@ -2,8 +2,14 @@
 
 from __future__ import annotations
 
+import re
+from pathlib import Path
 from typing import Any
 
+from .exceptions import ConfigError
+from .module_index import DEFAULT_VISIBILITY, is_visible, symbol_visibility
+from .reproducible import path_root
+
 # Quality scoring thresholds and constants
 MIN_FILES_HIGH = 20
 MIN_FILES_MEDIUM = 5
@@ -13,7 +19,7 @@ CONFIDENCE_HIGH_THRESHOLD = 75
 CONFIDENCE_MEDIUM_THRESHOLD = 50
 
 # Score point allocations
-SCORE_BASE = 30
+SCORE_BASE = 25
 SCORE_FILES_HIGH = 20
 SCORE_FILES_MEDIUM = 10
 SCORE_LANGUAGES_MULTI = 15
@@ -22,41 +28,105 @@ SCORE_SYMBOLS = 20
 SCORE_SYMBOLS_ANY = 10
 SCORE_DEPENDENCIES = 10
 SCORE_TESTS = 5
+SCORE_EXAMPLES = 5
+
+# Go runs `ExampleXxx` functions from _test.go files; directories holding example programs.
+GO_EXAMPLE_RE = re.compile(r"^Example($|[A-Z_])")
+EXAMPLE_DIR_NAMES = frozenset({"example", "examples"})
+# "Use `new_api` instead", "replaced by Client.fetch()", "in favor of parse".
+_REPLACEMENT_RE = re.compile(
+    r"\b(?:use|replaced (?:by|with)|superseded by|in favou?r of)\s+`?"
+    r"(?!(?:a|an|the|of|it|this|that|instead)\b)(?P<name>[A-Za-z_][\w.]*(?:\(\))?)",
+    re.IGNORECASE,
+)
+# Go's "Deprecated:" paragraph convention, also used by other doc comment styles.
+_DEPRECATED_PARAGRAPH_RE = re.compile(r"^Deprecated:[ \t]*(?P<note>.*(?:\n(?!\s*\n).*)*)", re.M)
+_DEPRECATED_TAG_RE = re.compile(r"@deprecated\b[ \t]*(?P<note>.*)")
+# "since v1.2, removed in v2.0": the version a deprecation started and its planned removal.
+_SINCE_RE = re.compile(r"\bsince\s+(?P<version>v?\d[\w.+-]*?)(?=[.,;)]?(?:\s|$))", re.I)
+_REMOVAL_RE = re.compile(
+    r"\b(?:remov(?:ed|al)|will be removed|to be removed)\s+(?:in|by|at)\s+"
+    r"(?P<version>v?\d[\w.+-]*?)(?=[.,;)]?(?:\s|$))",
+    re.IGNORECASE,
+)
+
+
+# Default factor weights; they sum to 100 so the score is a plain weighted percentage.
+DEFAULT_SCORE_WEIGHTS: dict[str, float] = {
+    "base": SCORE_BASE,
+    "files": SCORE_FILES_HIGH,
+    "languages": SCORE_LANGUAGES_MULTI,
+    "symbols": SCORE_SYMBOLS,
+    "dependencies": SCORE_DEPENDENCIES,
+    "tests": SCORE_TESTS,
+    "examples": SCORE_EXAMPLES,
+    "docstrings": 0,
+}
+
+
+def resolve_score_weights(overrides: Any = None) -> dict[str, float]:
+    """Merge `quality.score_weights` over the defaults and renormalize them to sum to 100.
+
+    Raises ConfigError for unknown factors, negative or non-numeric weights, or when
+    every weight is zero.
+    """
+    weights = {key: float(value) for key, value in DEFAULT_SCORE_WEIGHTS.items()}
+    if overrides is not None:
+        if not isinstance(overrides, dict):
+            raise ConfigError("quality.score_weights must be a mapping of factor to weight")
+        for key, value in overrides.items():
+            if key not in weights:
+                known = ", ".join(sorted(weights))
+                raise ConfigError(f"Unknown quality score weight '{key}' (expected: {known})")
+            if isinstance(value, bool) or not isinstance(value, int | float) or value < 0:
+                raise ConfigError(f"Quality score weight '{key}' must be a non-negative number")
+            weights[key] = float(value)
+    total = sum(weights.values())
+    if total <= 0:
+        raise ConfigError("At least one quality score weight must be positive")
+    return {key: round(value * 100 / total, 4) for key, value in weights.items()}
+
 
+def build_quality_report(
+    analysis_data: dict[str, Any],
+    *,
+    has_tests: bool,
+    weights: dict[str, float] | None = None,
+) -> dict[str, Any]:
+    """Compute simple quality/confidence signals for generated docs.
 
-def build_quality_report(analysis_data: dict[str, Any], *, has_tests: bool) -> dict[str, Any]:
-    """Compute simple quality/confidence signals for generated docs."""
+    Each factor scores between 0 and 1 and is multiplied by its weight from
+    `resolve_score_weights`, so the result always lands on 0-100.
+    """
+    effective = weights if weights is not None else resolve_score_weights()
     files_analyzed = int(analysis_data.get("files_analyzed", 0) or 0)
     languages = analysis_data.get("languages", {})
     functions = analysis_data.get("functions", [])
     classes = analysis_data.get("classes", [])
     dependencies = analysis_data.get("dependencies", {})
 
-    score = SCORE_BASE
     warnings: list[str] = []
-
-    # Score based on file count
-    score += _score_files(files_analyzed, warnings)
-
-    # Score based on language diversity
-    score += _score_languages(languages, warnings)
-
-    # Score based on code symbols
-    score += _score_symbols(functions, classes, warnings)
-
-    # Score based on dependencies
-    if dependencies:
-        score += SCORE_DEPENDENCIES
-    else:
+    factors = {
+        "base": 1.0,
+        "files": _score_files(files_analyzed, warnings),
+        "languages": _score_languages(languages, warnings),
+        "symbols": _score_symbols(functions, classes, warnings),
+        "dependencies": 1.0 if dependencies else 0.0,
+        "tests": 1.0 if has_tests else 0.0,
+        "examples": 1.0 if count_examples(analysis_data) else 0.0,
+        "docstrings": docstring_coverage(functions, classes, symbol_visibility(analysis_data)),
+    }
+    if not dependencies:
         warnings.append("No dependency metadata files were detected.")
-
-    # Score based on tests
-    if has_tests:
-        score += SCORE_TESTS
-    else:
+    if not has_tests:
         warnings.append("No tests detected. Generated usage guidance may need manual review.")
+    if not factors["examples"]:
+        warnings.append(
+            "No runnable examples detected (Go Example functions, doctests or example files)."
+        )
 
-    score = max(0, min(score, 100))
+    raw = sum(effective.get(key, 0.0) * value for key, value in factors.items())
+    score = max(0, min(round(raw), 100))
     if score >= CONFIDENCE_HIGH_THRESHOLD:
         confidence = "High"
     elif score >= CONFIDENCE_MEDIUM_THRESHOLD:
@@ -66,32 +136,301 @@ def build_quality_report(analysis_data: dict[str, Any], *, has_tests: bool) -> d
     return {"score": score, "confidence": confidence, "warnings": warnings}
 
 
-def _score_files(files_analyzed: int, warnings: list[str]) -> int:
-    """Return score based on file count."""
+def docstring_coverage(
+    functions: list[Any], classes: list[Any], visibility: str = DEFAULT_VISIBILITY
+) -> float:
+    """Return the share of documentable functions and classes that carry a docstring."""
+    public = documentable_symbols(functions, classes, visibility)
+    if not public:
+        return 0.0
+    return 1 - len(undocumented_symbols(functions, classes, visibility)) / len(public)
+
+
+def documentable_symbols(
+    functions: list[Any], classes: list[Any], visibility: str = DEFAULT_VISIBILITY
+) -> list[dict[str, Any]]:
+    """Functions and classes that count towards documentation coverage.
+
+    Only exported symbols count unless `visibility` is `all`. Symbols marked with a
+    `docgenie: ignore` directive are left out, as are Go example functions, which are
+    documentation themselves.
+    """
+    return [
+        item
+        for item in list(functions) + list(classes)
+        if isinstance(item, dict)
+        and is_visible(item, visibility)
+        and not item.get("doc_ignore")
+        and not item.get("example")
+    ]
+
+
+def undocumented_symbols(
+    functions: list[Any], classes: list[Any], visibility: str = DEFAULT_VISIBILITY
+) -> list[dict[str, Any]]:
+    """The documentable symbols without a doc comment; the gap `docstring_coverage` measures."""
+    return [
+        item
+        for item in documentable_symbols(functions, classes, visibility)
+        if not str(item.get("docstring") or "").strip()
+    ]
+
+
+def count_examples(analysis_data: dict[str, Any]) -> int:
+    """Count runnable examples: Go `Example` test functions, doctests and example files.
+
+    An `examples/` directory only counts through the non-empty files inside it.
+    """
+    functions = [item for item in analysis_data.get("functions", []) if isinstance(item, dict)]
+    classes = [item for item in analysis_data.get("classes", []) if isinstance(item, dict)]
+    go_examples = sum(
+        1
+        for func in functions
+        if str(func.get("file", "")).endswith("_test.go")
+        and GO_EXAMPLE_RE.match(str(func.get("name", "")))
+    )
+    symbols = functions + classes
+    symbols += [m for cls in classes for m in cls.get("methods", []) or [] if isinstance(m, dict)]
+    doctests = sum(1 for item in symbols if _has_doctest(item.get("docstring")))
+    return go_examples + doctests + _count_example_files(analysis_data)
+
+
+def _has_doctest(docstring: Any) -> bool:
+    if not isinstance(docstring, str):
+        return False
+    return any(line.lstrip().startswith(">>> ") for line in docstring.splitlines())
+
+
+def _count_example_files(analysis_data: dict[str, Any]) -> int:
+    structure = analysis_data.get("project_structure", {})
+    if not isinstance(structure, dict):
+        return 0
+    root = Path(str(analysis_data.get("root_path", ".")))
+    count = 0
+    for rel_dir, entry in structure.items():
+        parts = Path(rel_dir).parts
+        if rel_dir == "root" or not EXAMPLE_DIR_NAMES.intersection(parts):
+            continue
+        for name in entry.get("files", []) if isinstance(entry, dict) else []:
+            path = root / rel_dir / str(name)
+            try:
+                populated = not str(name).startswith(".") and path.stat().st_size > 0
+            except OSError:
+                continue
+            count += populated
+    return count
+
+
+def _score_files(files_analyzed: int, warnings: list[str]) -> float:
+    """Return the file-count factor."""
     if files_analyzed >= MIN_FILES_HIGH:
-        return SCORE_FILES_HIGH
+        return 1.0
     if files_analyzed >= MIN_FILES_MEDIUM:
-        return SCORE_FILES_MEDIUM
+        return SCORE_FILES_MEDIUM / SCORE_FILES_HIGH
     warnings.append("Low file count analyzed. Results may be incomplete.")
-    return 0
+    return 0.0
 
 
-def _score_languages(languages: dict[str, int], warnings: list[str]) -> int:
-    """Return score based on language diversity."""
+def _score_languages(languages: dict[str, int], warnings: list[str]) -> float:
+    """Return the language-diversity factor."""
     if len(languages) >= MIN_LANGUAGES:
-        return SCORE_LANGUAGES_MULTI
+        return 1.0
     if len(languages) == 1:
-        return SCORE_LANGUAGES_SINGLE
+        return SCORE_LANGUAGES_SINGLE / SCORE_LANGUAGES_MULTI
     warnings.append("No recognized source languages detected.")
-    return 0
+    return 0.0
 
 
-def _score_symbols(functions: list[Any], classes: list[Any], warnings: list[str]) -> int:
-    """Return score based on code symbol count."""
+def _score_symbols(functions: list[Any], classes: list[Any], warnings: list[str]) -> float:
+    """Return the code-symbol factor."""
     symbol_count = len(functions) + len(classes)
     if symbol_count >= MIN_SYMBOLS:
-        return SCORE_SYMBOLS
+        return 1.0
     if symbol_count >= 1:
-        return SCORE_SYMBOLS_ANY
+        return SCORE_SYMBOLS_ANY / SCORE_SYMBOLS
     warnings.append("No functions/classes were extracted from source files.")
-    return 0
+    return 0.0
+
+
+def deprecation_warnings(
+    analysis_data: dict[str, Any], current_version: str | None = None
+) -> list[str]:
+    """Return one warning per deprecated function, class or method.
+
+    With `current_version`, symbols whose planned removal version it has reached get an
+    "Error:" warning instead: they should be gone by now.
+    """
+    root = path_root(analysis_data)
+    warnings: list[str] = []
+    for kind, name, symbol in _deprecated_symbols(analysis_data):
+        message = _deprecation_message(kind, name, symbol, root)
+        removed_in = deprecation_timeline(deprecation_note(symbol))["removed_in"]
+        if removal_overdue(removed_in, current_version):
+            message = (
+                f"Error: {message}; it was due for removal in {removed_in} "
+                f"(current version {current_version})"
+            )
+        warnings.append(message)
+    return warnings
+
+
+def deprecations(
+    analysis_data: dict[str, Any], current_version: str | None = None
+) -> list[dict[str, Any]]:
+    """Return the Deprecations listing, soonest planned removal first.
+
+    Symbols without a removal version come last; `overdue` marks those whose removal
+    version `current_version` has reached.
+    """
+    root = path_root(analysis_data)
+    rows: list[dict[str, Any]] = []
+    for kind, name, symbol in _deprecated_symbols(analysis_data):
+        timeline = deprecation_timeline(deprecation_note(symbol))
+        rows.append(
+            {
+                "name": name,
+                "kind": kind,
+                "file": _location(symbol, root),
+                "line": symbol.get("line", 0),
+                **timeline,
+                "replacement": timeline["replacement"] or deprecation_replacement(symbol),
+                "overdue": removal_overdue(timeline["removed_in"], current_version),
+            }
+        )
+    rows.sort(
+        key=lambda row: (
+            row["removed_in"] is None,
+            version_key(row["removed_in"] or ""),
+            row["file"],
+            row["line"],
+        )
+    )
+    return rows
+
+
+def _deprecated_symbols(analysis_data: dict[str, Any]) -> list[tuple[str, str, dict[str, Any]]]:
+    """Return (kind, display name, symbol) for every deprecated function, class or method."""
+    found: list[tuple[str, str, dict[str, Any]]] = []
+    # The Python parser also lists methods as functions; report them once, as methods.
+    methods = {
+        (method.get("file"), method.get("line"))
+        for cls in analysis_data.get("classes", [])
+        if isinstance(cls, dict)
+        for method in cls.get("methods", [])
+        if isinstance(method, dict)
+    }
+    for func in analysis_data.get("functions", []):
+        if (
+            isinstance(func, dict)
+            and (func.get("file"), func.get("line")) not in methods
+            and is_deprecated(func)
+        ):
+            found.append(("function", str(func.get("name")), func))
+    for cls in analysis_data.get("classes", []):
+        if not isinstance(cls, dict):
+            continue
+        if is_deprecated(cls):
+            found.append((str(cls.get("kind") or "class"), str(cls.get("name")), cls))
+        for method in cls.get("methods", []):
+            if isinstance(method, dict) and is_deprecated(method):
+                found.append(("method", f"{cls.get('name')}.{method.get('name')}", method))
+    return found
+
+
+def is_deprecated(symbol: dict[str, Any]) -> bool:
+    """Return True for `@Deprecated`-style annotations or a `@deprecated` doc tag.
+
+    Swift's `@available(*, deprecated, ...)` and `@available(iOS, deprecated: 15)` count too,
+    as do C# `[Obsolete]` attributes, the Python deprecations the parser records in
+    `deprecated` (`warnings.warn(..., DeprecationWarning)`, `# Deprecated:` comments) and
+    Go-style `Deprecated:` paragraphs in the doc comment.
+    """
+    if symbol.get("deprecated") is not None:
+        return True
+    for decorator in symbol.get("decorators", []) or []:
+        name, _, arguments = str(decorator).lstrip("@[").partition("(")
+        name = name.rstrip("]").rsplit(".", 1)[-1]
+        if name.lower() in {"deprecated", "obsolete", "obsoleteattribute"}:
+            return True
+        if name == "available" and re.search(r"\bdeprecated\b", arguments):
+            return True
+    docstring = symbol.get("docstring")
+    return isinstance(docstring, str) and (
+        "@deprecated" in docstring or _DEPRECATED_PARAGRAPH_RE.search(docstring) is not None
+    )
+
+
+def deprecation_note(symbol: dict[str, Any]) -> str:
+    """Return the text of a symbol's deprecation note, or "" when it gives none.
+
+    The parser's `deprecated` note wins over a `Deprecated:` paragraph or `@deprecated` tag
+    in the docstring.
+    """
+    note = symbol.get("deprecated")
+    if isinstance(note, str) and note.strip():
+        return note.strip()
+    docstring = symbol.get("docstring")
+    if not isinstance(docstring, str):
+        return ""
+    match = _DEPRECATED_PARAGRAPH_RE.search(docstring) or _DEPRECATED_TAG_RE.search(docstring)
+    return " ".join(match.group("note").split()) if match else ""
+
+
+def deprecation_timeline(note: str) -> dict[str, str | None]:
+    """Split a note like "since v1.2, removed in v2.0; use NewThing" into its parts.
+
+    Returns `since`, `removed_in` and `replacement`, each None when the note omits it.
+    """
+    since = _SINCE_RE.search(note)
+    removal = _REMOVAL_RE.search(note)
+    replacement = _REPLACEMENT_RE.search(note)
+    return {
+        "since": since.group("version") if since else None,
+        "removed_in": removal.group("version") if removal else None,
+        "replacement": replacement.group("name").rstrip(".").removesuffix("()")
+        if replacement
+        else None,
+    }
+
+
+def version_key(version: str) -> tuple[int, ...]:
+    """Return the numeric parts of a version such as "v2.0.1" for ordering ("v2" == "2.0")."""
+    parts = [int(part) for part in re.findall(r"\d+", version.split("-", 1)[0])]
+    while parts and parts[-1] == 0:
+        parts.pop()
+    return tuple(parts)
+
+
+def current_version(config: Any) -> str | None:
+    """Return `project.version`, the release deprecation removal versions are checked against."""
+    project = config.get("project", {}) if isinstance(config, dict) else {}
+    version = project.get("version") if isinstance(project, dict) else None
+    return str(version) if version else None
+
+
+def removal_overdue(removed_in: str | None, current_version: str | None) -> bool:
+    """Return True when `current_version` has reached the planned removal version."""
+    if not removed_in or not current_version:
+        return False
+    return version_key(current_version) >= version_key(removed_in)
+
+
+def deprecation_replacement(symbol: dict[str, Any]) -> str | None:
+    """Return the replacement named by a deprecation note ("use `new_api` instead")."""
+    match = _REPLACEMENT_RE.search(deprecation_note(symbol))
+    return match.group("name").rstrip(".").removesuffix("()") if match else None
+
+
+def _location(symbol: dict[str, Any], root: Path) -> str:
+    file_path = Path(str(symbol.get("file", "")))
+    try:
+        return file_path.resolve().relative_to(root.resolve()).as_posix()
+    except (OSError, ValueError):
+        return file_path.as_posix()
+
+
+def _deprecation_message(kind: str, name: str, symbol: dict[str, Any], root: Path) -> str:
+    location = _location(symbol, root)
+    message = f"Deprecated {kind} `{name}` ({location}:{symbol.get('line', 0)})"
+    replacement = deprecation_replacement(symbol)
+    return f"{message}; use `{replacement}` instead" if replacement else message
@@ -0,0 +1,108 @@
+"""Regenerate only the marked regions of a hand-edited Markdown README.
+
+A region is the text between `<!-- docgenie:begin SECTION -->` and
+`<!-- docgenie:end -->` on lines of their own, where SECTION is the anchor of a
+generated `##` section (`installation`, `api-reference`). Regeneration replaces
+each region with that section and keeps everything outside the markers as written.
+Markers inside code fences are text, so a README can document them.
+"""
+
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+from .exceptions import GeneratorError
+from .html_sections import heading_slug
+from .toc import split_markdown_sections
+
+_MARKER_RE = re.compile(
+    r"^\s*<!--\s*docgenie:(?P<kind>begin|end)(?:\s+(?P<section>\S+?))?\s*-->\s*$"
+)
+_FENCE_RE = re.compile(r"^\s*(```|~~~)")
+_HEADING_RE = re.compile(r"^##\s+(?P<text>.+?)(?:\s+#+)?\s*$")
+
+
+def generated_sections(content: str) -> dict[str, str]:
+    """Return each `##` section of generated Markdown keyed by its heading anchor."""
+    sections: dict[str, str] = {}
+    for chunk in split_markdown_sections(content, max_level=2):
+        match = _HEADING_RE.match(chunk.split("\n", 1)[0])
+        if match is not None:
+            sections.setdefault(heading_slug(match.group("text")), chunk.strip("\n"))
+    return sections
+
+
+def find_regions(existing: str, path: Path | None = None) -> list[tuple[str, int, int]]:
+    """Return `(section, begin line, end line)` for each marked region, 0-based.
+
+    Raises GeneratorError for a begin without a section name, a begin inside
+    another region, an end without a begin, or a begin that is never closed.
+    """
+    regions: list[tuple[str, int, int]] = []
+    open_region: tuple[str, int] | None = None
+    fence: str | None = None
+    for index, line in enumerate(existing.splitlines()):
+        opener = _FENCE_RE.match(line)
+        if opener is not None:
+            if fence is None:
+                fence = opener.group(1)
+            elif opener.group(1) == fence:
+                fence = None
+            continue
+        marker = None if fence is not None else _MARKER_RE.match(line)
+        if marker is None:
+            continue
+        if marker.group("kind") == "begin":
+            section = marker.group("section")
+            if not section:
+                raise GeneratorError(f"docgenie:begin on line {index + 1} names no section", path)
+            if open_region is not None:
+                raise GeneratorError(
+                    f"docgenie:begin {section} on line {index + 1} is nested inside "
+                    f"docgenie:begin {open_region[0]} from line {open_region[1] + 1}",
+                    path,
+                )
+            open_region = (section, index)
+        elif open_region is None:
+            raise GeneratorError(
+                f"docgenie:end on line {index + 1} has no matching docgenie:begin", path
+            )
+        else:
+            regions.append((open_region[0], open_region[1], index))
+            open_region = None
+    if open_region is not None:
+        raise GeneratorError(
+            f"docgenie:begin {open_region[0]} on line {open_region[1] + 1} is never closed "
+            "by docgenie:end",
+            path,
+        )
+    return regions
+
+
+def merge_regions(existing: str, generated: str, path: Path | None = None) -> str | None:
+    """Return `existing` with each marked region replaced by its generated section.
+
+    Returns None when `existing` has no markers, so the caller writes `generated`
+    in full. Raises GeneratorError for mismatched markers or a section the
+    generated README does not have.
+    """
+    regions = find_regions(existing, path)
+    if not regions:
+        return None
+    sections = generated_sections(generated)
+    lines = existing.splitlines(keepends=True)
+    merged: list[str] = []
+    position = 0
+    for section, begin, end in regions:
+        if section not in sections:
+            raise GeneratorError(
+                f"docgenie:begin {section} on line {begin + 1} names no generated section "
+                f"(available: {', '.join(sorted(sections)) or 'none'})",
+                path,
+            )
+        merged.extend(lines[position : begin + 1])
+        merged.append(f"{sections[section]}\n")
+        position = end
+    merged.extend(lines[position:])
+    return "".join(merged)
@@ -0,0 +1,68 @@
+"""Keep generated output independent of the machine and moment it was produced on.
+
+Two runs over the same tree should write the same bytes: file paths are made
+relative to the project root (`analysis.path_root`, `--root`), so neither the
+checkout location nor the user's home directory leaks into the docs, and
+`SOURCE_DATE_EPOCH` (https://reproducible-builds.org/specs/source-date-epoch/)
+replaces the wall clock for "generated on" dates and run durations.
+"""
+
+from __future__ import annotations
+
+import ntpath
+import os
+from datetime import datetime, timezone
+from pathlib import Path, PurePosixPath
+from typing import Any
+
+from .utils import posix_path
+
+SOURCE_DATE_EPOCH = "SOURCE_DATE_EPOCH"
+
+
+def source_date_epoch() -> datetime | None:
+    """Return the UTC time in `SOURCE_DATE_EPOCH`, or None when unset or not an integer."""
+    raw = os.environ.get(SOURCE_DATE_EPOCH, "").strip()
+    if not raw.isdigit():
+        return None
+    return datetime.fromtimestamp(int(raw), tz=timezone.utc)
+
+
+def build_time() -> datetime:
+    """Return the time to stamp generated docs with: `SOURCE_DATE_EPOCH`, else now."""
+    return source_date_epoch() or datetime.now()
+
+
+def path_root(analysis_data: dict[str, Any]) -> Path:
+    """Return the directory output paths are relative to.
+
+    `analysis.path_root` wins, resolved against the analyzed directory when relative;
+    otherwise it is the analyzed directory itself.
+    """
+    root = Path(str(analysis_data.get("root_path", ".")))
+    config = analysis_data.get("config", {})
+    analysis = config.get("analysis", {}) if isinstance(config, dict) else {}
+    configured = analysis.get("path_root") if isinstance(analysis, dict) else None
+    if not configured:
+        return root
+    return (root / Path(str(configured)).expanduser()).resolve()
+
+
+def relative_paths(value: Any, root: Path) -> Any:
+    """Return a copy of `value` with every absolute path under `root` made relative.
+
+    Dicts and lists are walked recursively. Only string values naming `root` or a path
+    below it change, to POSIX form (`root` itself becomes `.`); paths outside `root`
+    and strings that merely start with `/`, such as HTTP routes, are kept as they are.
+    Windows paths (`C:\\work\\app\\main.go`) are recognized on every OS.
+    """
+    if isinstance(value, dict):
+        return {key: relative_paths(item, root) for key, item in value.items()}
+    if isinstance(value, list):
+        return [relative_paths(item, root) for item in value]
+    if isinstance(value, str) and (os.path.isabs(value) or ntpath.isabs(value)):
+        try:
+            return PurePosixPath(posix_path(value)).relative_to(posix_path(root)).as_posix()
+        except ValueError:
+            return value
+    return value
@@ -0,0 +1,185 @@
+"""Detect HTTP routes registered through Go routers (net/http, gorilla/mux)."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable
+from pathlib import Path
+from typing import Any
+
+from .html_sections import api_anchor
+from .languages._scan import code_lines, split_top_level
+from .utils import get_file_language
+
+UNRECOGNIZED_ROUTE_REASON = "unrecognized_route_pattern"
+
+_REGISTER_RE = re.compile(r"\b[A-Za-z_]\w*\.(HandleFunc|Handle)\(")
+_CHAIN_RE = re.compile(r"\s*\.\s*(\w+)\(")
+_STRING_RE = re.compile(r'^(?:"((?:\\.|[^"\\])*)"|`([^`]*)`)$')
+_IDENT_RE = re.compile(r"^[A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*$")
+_WRAPPER_RE = re.compile(r"^http\.HandlerFunc\((.+)\)$")
+_METHOD_PREFIX_RE = re.compile(r"^([A-Z]+)\s+(/\S*)$")
+
+
+def scan_http_routes(
+    root_path: Path, files: Iterable[Path]
+) -> tuple[list[dict[str, Any]], list[str]]:
+    """Return routes registered in Go sources and the files whose registrations were skipped.
+
+    A registration is only reported when its path is a string literal; calls that
+    build the path at runtime are not guessed at, and their file is returned in
+    the second list instead.
+    """
+    routes: list[dict[str, Any]] = []
+    unrecognized: list[str] = []
+    for path in sorted(files):
+        if get_file_language(path) != "go":
+            continue
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        if "Handle" not in content:
+            continue
+        try:
+            rel = path.relative_to(root_path).as_posix()
+        except ValueError:
+            rel = path.as_posix()
+        found, skipped = _scan_source(content)
+        routes.extend({**route, "file": rel} for route in found)
+        if skipped:
+            unrecognized.append(rel)
+    routes.sort(key=lambda route: (route["path"], route["method"], route["file"], route["line"]))
+    return routes, unrecognized
+
+
+def _scan_source(content: str) -> tuple[list[dict[str, Any]], bool]:
+    raw = content.splitlines()
+    code = code_lines(content, quotes="\"'`", multiline_quotes="`")
+    text = "\n".join(raw)
+    offsets = [0]
+    for line in raw:
+        offsets.append(offsets[-1] + len(line) + 1)
+
+    routes: list[dict[str, Any]] = []
+    skipped = False
+    for line_no, code_line in enumerate(code):
+        for match in _REGISTER_RE.finditer(code_line):
+            start = offsets[line_no] + match.end()
+            args, end = call_arguments(text, start)
+            parts = split_top_level(args)
+            pattern = string_literal(parts[0]) if parts else None
+            if pattern is None or len(parts[1:]) != 1:
+                skipped = True
+                continue
+            method, route_path = _split_method(pattern)
+            methods = _chained_methods(text, end) or [method]
+            for verb in methods:
+                routes.append(
+                    {
+                        "method": verb,
+                        "path": route_path,
+                        "handler": _handler_name(parts[1]),
+                        "line": line_no + 1,
+                    }
+                )
+    return routes, skipped
+
+
+def call_arguments(text: str, start: int) -> tuple[str, int]:
+    """Return the argument text of the call opened just before `start` and its end offset."""
+    depth = 1
+    idx = start
+    quote: str | None = None
+    while idx < len(text):
+        char = text[idx]
+        if quote is not None:
+            if char == "\\" and quote == '"':
+                idx += 1
+            elif char == quote:
+                quote = None
+        elif char in "\"`":
+            quote = char
+        elif char == "(":
+            depth += 1
+        elif char == ")":
+            depth -= 1
+            if depth == 0:
+                return text[start:idx], idx + 1
+        idx += 1
+    return text[start:], len(text)
+
+
+def _chained_methods(text: str, offset: int) -> list[str]:
+    """Collect verbs from a `.Methods("GET", ...)` call chained onto a registration."""
+    while True:
+        chained = _CHAIN_RE.match(text, offset)
+        if chained is None:
+            return []
+        args, offset = call_arguments(text, chained.end())
+        if chained.group(1) == "Methods":
+            verbs = [string_literal(arg) for arg in split_top_level(args)]
+            return [verb.upper() for verb in verbs if verb]
+
+
+def _split_method(pattern: str) -> tuple[str, str]:
+    """Split Go 1.22 `"GET /users/{id}"` patterns into method and path."""
+    match = _METHOD_PREFIX_RE.match(pattern.strip())
+    if match:
+        return match.group(1), match.group(2)
+    return "ANY", pattern
+
+
+def string_literal(value: str) -> str | None:
+    """Return the contents of a Go `"..."` or raw `` `...` `` literal, else None."""
+    match = _STRING_RE.match(value.strip())
+    if match is None:
+        return None
+    return match.group(1) if match.group(1) is not None else match.group(2)
+
+
+def _handler_name(expr: str) -> str:
+    """Return the handler identifier, or "" for inline closures and composed handlers."""
+    value = " ".join(expr.split())
+    wrapped = _WRAPPER_RE.match(value)
+    if wrapped:
+        value = wrapped.group(1).strip()
+    return value if _IDENT_RE.match(value) else ""
+
+
+def link_route_handlers(
+    routes: list[dict[str, Any]], api_docs: dict[str, Any]
+) -> list[dict[str, Any]]:
+    """Attach the API doc anchor of each route's handler, giving linked docs an `anchor`.
+
+    Handlers are matched on their last dotted segment (`handlers.GetUser`,
+    `s.GetUser`) against documented functions first, then class methods. A name
+    that matches more than one documented symbol is left unlinked.
+    """
+    rows: list[dict[str, Any]] = []
+    for route in routes:
+        handler = str(route.get("handler") or "")
+        doc = _documented_handler(handler, api_docs) if handler else None
+        anchor = ""
+        if doc is not None:
+            anchor = str(doc.get("anchor") or api_anchor(str(doc["name"])))
+            doc["anchor"] = anchor
+        rows.append({**route, "anchor": anchor})
+    return rows
+
+
+def _documented_handler(handler: str, api_docs: dict[str, Any]) -> dict[str, Any] | None:
+    short = handler.rsplit(".", 1)[-1]
+    functions = [
+        doc
+        for doc in api_docs.get("functions", [])
+        if str(doc.get("name", "")).rsplit(".", 1)[-1] == short
+    ]
+    if functions:
+        return functions[0] if len(functions) == 1 else None
+    owners = [
+        doc
+        for doc in api_docs.get("classes", [])
+        if any(method.get("name") == short for method in doc.get("methods", []))
+    ]
+    return owners[0] if len(owners) == 1 else None
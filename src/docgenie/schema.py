@@ -0,0 +1,144 @@
+"""Build the versioned machine-readable analysis document (`analyze --schema-version`)."""
+
+from __future__ import annotations
+
+from dataclasses import fields
+from pathlib import Path
+from typing import Any
+
+from . import __version__
+from .exceptions import ConfigError
+from .html_sections import base_name, resolve_symbol_module, symbol_node_id
+from .models import (
+    SCHEMA_VERSION,
+    AnalysisDocument,
+    RunMetrics,
+    SchemaEdge,
+    SchemaModule,
+    SchemaSymbol,
+)
+from .module_index import relative_path
+from .reproducible import path_root
+from .utils import get_file_language
+
+SUPPORTED_SCHEMA_VERSIONS = (SCHEMA_VERSION,)
+_DEFAULT_KINDS = {"classes": "class", "functions": "function"}
+
+
+def build_analysis_document(
+    analysis_data: dict[str, Any], *, schema_version: int = SCHEMA_VERSION
+) -> AnalysisDocument:
+    """Convert analyzer output into the stable `AnalysisDocument` shape."""
+    if schema_version not in SUPPORTED_SCHEMA_VERSIONS:
+        supported = ", ".join(str(version) for version in SUPPORTED_SCHEMA_VERSIONS)
+        raise ConfigError(f"Unsupported schema version {schema_version} (supported: {supported})")
+
+    root = Path(str(analysis_data.get("root_path", ".")))
+    modules_root = path_root(analysis_data)
+    file_imports = analysis_data.get("file_imports", {})
+    imports_by_module = file_imports if isinstance(file_imports, dict) else {}
+    symbols: dict[str, list[SchemaSymbol]] = {path: [] for path in imports_by_module}
+    bases: list[tuple[str, str, list[str]]] = []
+    # Some parsers also report methods as top-level functions; keep only the method entry.
+    method_positions: set[tuple[str, int, str]] = set()
+
+    for key, default_kind in _DEFAULT_KINDS.items():
+        for item in analysis_data.get(key, []):
+            if not isinstance(item, dict) or not item.get("name"):
+                continue
+            module = relative_path(modules_root, str(item.get("file", "")))
+            symbol = _symbol(module, item, default_kind=default_kind)
+            if (module, symbol.line, symbol.name) in method_positions:
+                continue
+            module_symbols = symbols.setdefault(module, [])
+            module_symbols.append(symbol)
+            if key == "classes":
+                bases.append((module, symbol.qualified_name, list(item.get("bases", []) or [])))
+                for method in item.get("methods", []) or []:
+                    if isinstance(method, dict) and method.get("name"):
+                        member = _symbol(
+                            module, method, default_kind="method", parent=symbol.qualified_name
+                        )
+                        method_positions.add((module, member.line, member.name))
+                        module_symbols.append(member)
+
+    modules = [
+        SchemaModule(
+            path=path,
+            language=get_file_language(Path(path)) or "unknown",
+            imports=sorted(str(imp) for imp in imports_by_module.get(path, []) or []),
+            symbols=sorted(symbols[path], key=lambda sym: (sym.line, sym.qualified_name)),
+        )
+        for path in sorted(symbols)
+    ]
+    metrics = analysis_data.get("run_metrics", {})
+    known = {item.name for item in fields(RunMetrics)}
+    return AnalysisDocument(
+        project_name=str(analysis_data.get("project_name", "")),
+        root_path=str(root),
+        generator_version=__version__,
+        languages=dict(analysis_data.get("languages", {})),
+        modules=modules,
+        edges=_edges(modules, bases),
+        run_metrics=RunMetrics(
+            **{key: value for key, value in (metrics or {}).items() if key in known}
+        ),
+        skipped_reasons=dict(analysis_data.get("skipped_reasons", {})),
+        schema_version=schema_version,
+    )
+
+
+def _symbol(
+    module: str, item: dict[str, Any], *, default_kind: str, parent: str | None = None
+) -> SchemaSymbol:
+    raw_name = str(item["name"])
+    qualified = f"{parent}.{raw_name}" if parent else raw_name
+    owner, _, short = qualified.rpartition(".")
+    end_line = item.get("end_line")
+    doc_line, doc_end_line = item.get("doc_line"), item.get("doc_end_line")
+    return SchemaSymbol(
+        symbol_id=symbol_node_id(module, qualified),
+        name=short,
+        qualified_name=qualified,
+        kind=str(item.get("kind") or default_kind),
+        line=int(item.get("line", 0) or 0),
+        end_line=int(end_line) if end_line else None,
+        signature=item.get("signature"),
+        docstring=item.get("docstring") or None,
+        parent=owner or None,
+        last_modified=item.get("last_modified") or None,
+        doc_line=int(doc_line) if doc_line else None,
+        doc_end_line=int(doc_end_line) if doc_end_line else None,
+    )
+
+
+def _edges(
+    modules: list[SchemaModule], bases: list[tuple[str, str, list[str]]]
+) -> list[SchemaEdge]:
+    """Return import edges for every module and `extends` edges between resolved classes."""
+    paths = {module.path for module in modules}
+    edges: set[tuple[str, str, str]] = set()
+    for module in modules:
+        for imported in module.imports:
+            target = f"file:{imported}" if imported in paths else f"module:{imported}"
+            edges.add((f"file:{module.path}", target, "import"))
+
+    by_name: dict[str, list[str]] = {}
+    for module in modules:
+        for symbol in module.symbols:
+            if symbol.kind != "method":
+                by_name.setdefault(symbol.qualified_name, []).append(module.path)
+    imports = {module.path: module.imports for module in modules}
+    for module_path, name, raw_bases in bases:
+        for raw in raw_bases:
+            base = base_name(str(raw))
+            base_module = resolve_symbol_module(base, module_path, imports[module_path], by_name)
+            if base and base_module is not None:
+                edges.add(
+                    (
+                        symbol_node_id(module_path, name),
+                        symbol_node_id(base_module, base),
+                        "extends",
+                    )
+                )
+    return [SchemaEdge(source, target, kind) for source, target, kind in sorted(edges)]
@@ -0,0 +1,123 @@
+"""Collect `TODO`/`FIXME`-style markers left in source comments."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable, Sequence
+from pathlib import Path
+from typing import Any
+
+from .languages._scan import code_lines
+
+DEFAULT_DEBT_MARKERS = ("TODO", "FIXME")
+_MAX_NOTE_LENGTH = 120
+# A marker only counts right after a comment opener, so `todo_list` is not reported.
+_COMMENT_OPENER = r"(?:#+|//+|/\*+|--|<!--|^\s*\*+)"
+_QUOTES = "\"'`"
+
+
+def scan_debt_markers(
+    root_path: Path, files: Iterable[Path], markers: Sequence[str] = DEFAULT_DEBT_MARKERS
+) -> list[dict[str, Any]]:
+    """Return one entry per marker comment with `marker`, `file`, `line` and `note`.
+
+    Markers are matched case-sensitively. `TODO(alice): ...` also records `owner`.
+    """
+    keywords = [marker.strip() for marker in markers if marker.strip()]
+    if not keywords:
+        return []
+    pattern = re.compile(
+        rf"{_COMMENT_OPENER}\s*(?P<marker>{'|'.join(map(re.escape, keywords))})\b"
+        r"(?:\((?P<owner>[^)]*)\))?[\s:-]*(?P<note>.*?)\s*(?:\*/|-->)?\s*$"
+    )
+    found: list[dict[str, Any]] = []
+    for path in sorted(files):
+        try:
+            content = path.read_text(encoding="utf-8")
+        except (OSError, UnicodeDecodeError):
+            continue
+        if not any(keyword in content for keyword in keywords):
+            continue
+        try:
+            rel = path.relative_to(root_path).as_posix()
+        except ValueError:
+            rel = path.as_posix()
+        code = code_lines(
+            content,
+            line_comments=("#", "//", "--"),
+            block_comments=(("/*", "*/"), ("<!--", "-->")),
+            quotes=_QUOTES,
+        )
+        for number, (line, stripped) in enumerate(zip(content.splitlines(), code), start=1):
+            match = _comment_match(pattern, line, stripped)
+            if match is None:
+                continue
+            entry = {
+                "marker": match.group("marker"),
+                "file": rel,
+                "line": number,
+                "note": _shorten(match.group("note")),
+            }
+            if match.group("owner"):
+                entry["owner"] = match.group("owner").strip()
+            found.append(entry)
+    return found
+
+
+def _comment_match(pattern: re.Pattern[str], line: str, code: str) -> re.Match[str] | None:
+    """Return the first match of `pattern` whose marker `code_lines` blanked as a comment.
+
+    String bodies are blanked too, so a marker is also rejected when the quotes
+    kept in `code` before it are unbalanced: `"# TODO"` is a string, not a comment.
+    """
+    start = 0
+    while (match := pattern.search(line, start)) is not None:
+        column = match.start("marker")
+        if column >= len(code) or (code[column] == " " and not _in_string(code[:column])):
+            return match
+        start = match.start() + 1
+    return None
+
+
+def _in_string(code: str) -> bool:
+    quote: str | None = None
+    for char in code:
+        if quote is None and char in _QUOTES:
+            quote = char
+        elif char == quote:
+            quote = None
+    return quote is not None
+
+
+def debt_groups(items: Iterable[dict[str, Any]], markers: Sequence[str]) -> list[dict[str, Any]]:
+    """Group markers for the README in configured marker order, then by file and line."""
+    grouped: dict[str, list[dict[str, Any]]] = {}
+    for item in items:
+        grouped.setdefault(str(item.get("marker", "")), []).append(item)
+    order = [marker for marker in markers if marker in grouped]
+    order += sorted(marker for marker in grouped if marker not in order)
+    return [
+        {
+            "marker": marker,
+            "items": sorted(grouped[marker], key=lambda item: (item["file"], item["line"])),
+            "has_authors": any(item.get("author") for item in grouped[marker]),
+        }
+        for marker in order
+    ]
+
+
+def debt_warnings(items: Sequence[dict[str, Any]], threshold: int | None) -> list[str]:
+    """Warn once when the number of markers is above `threshold`."""
+    if threshold is None or len(items) <= threshold:
+        return []
+    return [
+        f"{len(items)} technical debt markers found in source comments "
+        f"(threshold {threshold}); see Technical Debt."
+    ]
+
+
+def _shorten(note: str) -> str:
+    note = " ".join(note.split()).replace("|", "\\|")
+    if len(note) <= _MAX_NOTE_LENGTH:
+        return note
+    return note[: _MAX_NOTE_LENGTH - 3].rstrip() + "..."
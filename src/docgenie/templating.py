@@ -0,0 +1,92 @@
+"""Load the built-in Jinja templates or user overrides from a template directory."""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from pathlib import Path
+from typing import Any
+
+from jinja2 import Template, TemplateSyntaxError, meta
+
+from .exceptions import ConfigError
+from .logging import get_logger
+
+BUILTIN_TEMPLATE_DIR = Path(__file__).parent / "templates"
+README_TEMPLATE = "readme.md.j2"
+ADOC_TEMPLATE = "readme.adoc.j2"
+CONFLUENCE_TEMPLATE = "readme.confluence.j2"
+HTML_TEMPLATE = "html.j2"
+TEMPLATE_NAMES = (README_TEMPLATE, ADOC_TEMPLATE, CONFLUENCE_TEMPLATE, HTML_TEMPLATE)
+
+
+@dataclass(frozen=True)
+class LoadedTemplate:
+    """A compiled template plus the top-level variables it reads."""
+
+    template: Template
+    path: Path
+    variables: frozenset[str]
+    custom: bool = False
+
+    def render(self, context: dict[str, Any]) -> str:
+        if self.custom:
+            globals_ = set(self.template.environment.globals)
+            missing = sorted(self.variables - set(context) - globals_)
+            if missing:
+                get_logger(__name__).warning(
+                    "Custom template references undefined variables",
+                    template=str(self.path),
+                    missing=missing,
+                    available=sorted(context),
+                )
+        return self.template.render(**context)
+
+
+def load_template(name: str, template_dir: Path | None = None) -> LoadedTemplate:
+    """Return `name` from `template_dir` when it exists there, else the built-in copy."""
+    path = template_path(name, template_dir)
+    return _compile(path, custom=path.parent != BUILTIN_TEMPLATE_DIR)
+
+
+def template_path(name: str, template_dir: Path | None = None) -> Path:
+    """Return the file `load_template` would read for `name`."""
+    custom_path = template_dir / name if template_dir is not None else None
+    if custom_path is not None and custom_path.is_file():
+        return custom_path
+    return BUILTIN_TEMPLATE_DIR / name
+
+
+def template_dir_from_config(analysis_data: dict[str, Any]) -> Path | None:
+    """Read `template_customizations.template_dir`, resolving it against the project root."""
+    config = analysis_data.get("config", {})
+    customizations = config.get("template_customizations", {}) if isinstance(config, dict) else {}
+    raw = customizations.get("template_dir") if isinstance(customizations, dict) else None
+    if not raw:
+        return None
+    path = Path(str(raw)).expanduser()
+    if not path.is_absolute():
+        path = Path(str(analysis_data.get("root_path", "."))) / path
+    return path
+
+
+def validate_template_dir(template_dir: Path) -> list[str]:
+    """Return the template names `template_dir` overrides; raise if it overrides none."""
+    if not template_dir.is_dir():
+        raise ConfigError(f"Template directory not found: {template_dir}")
+    found = [name for name in TEMPLATE_NAMES if (template_dir / name).is_file()]
+    if not found:
+        expected = ", ".join(TEMPLATE_NAMES)
+        raise ConfigError(f"No templates in {template_dir} (expected one of: {expected})")
+    for name in found:
+        _compile(template_dir / name, custom=True)
+    return found
+
+
+def _compile(path: Path, *, custom: bool) -> LoadedTemplate:
+    source = path.read_text(encoding="utf-8")
+    try:
+        template = Template(source)
+        variables = meta.find_undeclared_variables(template.environment.parse(source))
+    except TemplateSyntaxError as exc:
+        raise ConfigError(f"Invalid template {path}:{exc.lineno}: {exc.message}") from exc
+    return LoadedTemplate(template, path, frozenset(variables), custom=custom)
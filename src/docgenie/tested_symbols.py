@@ -0,0 +1,90 @@
+"""Report which public symbols are referenced by at least one test file.
+
+This is not line coverage: a symbol counts as tested when a test file (`_test.go`,
+`test_*.py`, `*.test.ts`, files under `tests/`...) mentions it, as resolved by the
+impact graph's `references` edges. It needs no instrumentation and works the same
+for every language the reference scan sees.
+"""
+
+from __future__ import annotations
+
+import sys
+from typing import Any
+
+from .dead_code import TEST_PATH_RE, method_positions
+from .html_sections import build_impact_graph_data, symbol_node_id
+from .module_index import is_exported, relative_path
+from .reproducible import path_root
+
+
+def is_test_file(module: str) -> bool:
+    """Return whether a root-relative path names a test file."""
+    return TEST_PATH_RE.search(module) is not None
+
+
+def find_tested_symbols(analysis_data: dict[str, Any]) -> dict[str, Any] | None:
+    """Return `{"tested", "total", "ratio", "untested"}` for public functions and classes.
+
+    `untested` lists `{"name", "kind", "module", "line"}` in source order. Symbols
+    declared in test files and class methods are not counted. Returns None when the
+    project has no test files or no public symbols, since a ratio would say nothing.
+    """
+    root = path_root(analysis_data)
+    graph = build_impact_graph_data(analysis_data, max_nodes=sys.maxsize, max_edges=sys.maxsize)
+    test_files = {
+        str(node["id"])
+        for node in graph["nodes"]
+        if node.get("type") == "file" and is_test_file(str(node["label"]))
+    }
+    if not test_files:
+        return None
+    tested_ids = {
+        str(edge["target"])
+        for edge in graph["edges"]
+        if edge["kind"] == "references" and edge["source"] in test_files
+    }
+    methods = method_positions(analysis_data, root)
+
+    seen: set[str] = set()
+    tested = 0
+    untested: list[dict[str, Any]] = []
+    for key, default_kind in (("functions", "function"), ("classes", "class")):
+        for item in analysis_data.get(key, []):
+            if not isinstance(item, dict) or not item.get("name") or not item.get("file"):
+                continue
+            name = str(item["name"])
+            module = relative_path(root, str(item["file"]))
+            line = int(item.get("line", 0) or 0)
+            node_id = symbol_node_id(module, name)
+            if node_id in seen or not is_exported(item) or is_test_file(module):
+                continue
+            if (module, line, name) in methods or item.get("example"):
+                continue
+            seen.add(node_id)
+            if node_id in tested_ids:
+                tested += 1
+                continue
+            kind = str(item.get("kind") or default_kind)
+            untested.append({"name": name, "kind": kind, "module": module, "line": line})
+    total = tested + len(untested)
+    if not total:
+        return None
+    return {
+        "tested": tested,
+        "total": total,
+        "ratio": round(tested / total, 3),
+        "untested": sorted(untested, key=lambda sym: (sym["module"], sym["line"], sym["name"])),
+    }
+
+
+def tested_symbol_warnings(report: dict[str, Any] | None, max_untested_ratio: float) -> list[str]:
+    """Warn once when the share of untested public symbols is above `max_untested_ratio`."""
+    if not report:
+        return []
+    untested = len(report["untested"])
+    if untested / report["total"] <= max_untested_ratio:
+        return []
+    return [
+        f"{untested} of {report['total']} public symbols are not referenced by any test "
+        f"({1 - report['ratio']:.0%} untested); see Tested Symbols."
+    ]
@@ -0,0 +1,133 @@
+"""Insert a table of contents into generated Markdown READMEs."""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Iterable, Iterator
+from typing import Any
+
+from .html_sections import allocate_heading_ids
+
+TOC_TITLE = "Table of Contents"
+DEFAULT_TOC_DEPTH = 2
+DEFAULT_TOC_MIN_HEADINGS = 4
+MODULES_HEADING = "## Modules"
+
+_FENCE_RE = re.compile(r"^\s*(```|~~~)")
+_ATX_RE = re.compile(r"^(?P<hashes>#{1,6})\s+(?P<text>.+?)(?:\s+#+)?\s*$")
+_LINK_RE = re.compile(r"\[([^\]]*)\]\([^)]*\)")
+_ANCHOR_RE = re.compile(r'\bid="([^"]+)"')
+_MODULE_HEADING_RE = re.compile(r"### `(?P<path>[^`]+)`")
+
+
+def toc_settings(config: Any) -> dict[str, int] | None:
+    """Read `template_customizations.include_toc` / `toc_depth` / `toc_min_headings`.
+
+    Returns None when the TOC is disabled.
+    """
+    customizations = config.get("template_customizations", {}) if isinstance(config, dict) else {}
+    if not isinstance(customizations, dict):
+        customizations = {}
+    if not customizations.get("include_toc", True):
+        return None
+    return {
+        "depth": int(customizations.get("toc_depth", DEFAULT_TOC_DEPTH)),
+        "min_headings": int(customizations.get("toc_min_headings", DEFAULT_TOC_MIN_HEADINGS)),
+    }
+
+
+def insert_toc(
+    content: str,
+    *,
+    depth: int = DEFAULT_TOC_DEPTH,
+    min_headings: int = DEFAULT_TOC_MIN_HEADINGS,
+) -> str:
+    """Insert a `## Table of Contents` before the first section heading.
+
+    Lists `##` through `depth` headings, linking to the IDs `normalize_heading_ids`
+    gives them in HTML output. Content with fewer than `min_headings` such headings,
+    or with a TOC already, is returned unchanged.
+    """
+    lines = content.splitlines(keepends=True)
+    headings = _markdown_headings(lines)
+    listed = [heading for heading in headings if 2 <= heading[1] <= depth]  # noqa: PLR2004
+    if not listed or len(listed) < min_headings:
+        return content
+    if any(_label(text) == TOC_TITLE for _, _, text in headings):
+        return content
+
+    insert_at = listed[0][0]
+    before = [text for index, _, text in headings if index < insert_at]
+    after = [heading for heading in headings if heading[0] >= insert_at]
+    # The inserted heading takes an ID too, so allocate in final document order.
+    ordered = [*before, TOC_TITLE, *(text for _, _, text in after)]
+    ids = allocate_heading_ids([_label(text) for text in ordered], _ANCHOR_RE.findall(content))
+
+    entries = [
+        f"{'  ' * (level - 2)}- [{_label(text)}](#{anchor})\n"
+        for (_, level, text), anchor in zip(after, ids[len(before) + 1 :], strict=True)
+        if level <= depth
+    ]
+    block = f"## {TOC_TITLE}\n\n{''.join(entries)}\n"
+    return "".join(lines[:insert_at]) + block + "".join(lines[insert_at:])
+
+
+def markdown_anchors(content: str) -> set[str]:
+    """Return every anchor a Markdown README defines: `id="..."` attributes and heading IDs.
+
+    Heading IDs are allocated the way `insert_toc` links to them.
+    """
+    explicit = _ANCHOR_RE.findall(content)
+    headings = _markdown_headings(content.splitlines(keepends=True))
+    return {*explicit, *allocate_heading_ids([_label(text) for _, _, text in headings], explicit)}
+
+
+def split_markdown_sections(content: str, *, max_level: int = 3) -> list[str]:
+    """Split Markdown before every heading down to `max_level`, ignoring code fences.
+
+    Joining the returned chunks gives back `content` unchanged.
+    """
+    lines = content.splitlines(keepends=True)
+    starts = [idx for idx, level, _ in _markdown_headings(lines) if level <= max_level and idx]
+    bounds = [0, *starts, len(lines)]
+    return ["".join(lines[start:end]) for start, end in zip(bounds, bounds[1:], strict=False)]
+
+
+def module_sections(sections: Iterable[str]) -> Iterator[tuple[str | None, str]]:
+    """Pair each chunk from `split_markdown_sections` with the module it documents.
+
+    A chunk under the `## Modules` heading that opens with "### `path`" documents
+    `path`; every other chunk is paired with None.
+    """
+    in_modules = False
+    for section in sections:
+        first_line = section.split("\n", 1)[0].rstrip()
+        if first_line.startswith("## "):
+            in_modules = first_line == MODULES_HEADING
+        match = _MODULE_HEADING_RE.fullmatch(first_line) if in_modules else None
+        yield (match.group("path") if match else None), section
+
+
+def _label(text: str) -> str:
+    """Drop links from heading text so TOC entries do not nest them."""
+    return _LINK_RE.sub(r"\1", text).strip()
+
+
+def _markdown_headings(lines: list[str]) -> list[tuple[int, int, str]]:
+    """Return `(line index, level, text)` for ATX headings outside code fences."""
+    headings: list[tuple[int, int, str]] = []
+    fence: str | None = None
+    for index, line in enumerate(lines):
+        opener = _FENCE_RE.match(line)
+        if opener is not None:
+            if fence is None:
+                fence = opener.group(1)
+            elif opener.group(1) == fence:
+                fence = None
+            continue
+        if fence is not None:
+            continue
+        match = _ATX_RE.match(line.rstrip("\n"))
+        if match is not None:
+            headings.append((index, len(match.group("hashes")), match.group("text")))
+    return headings
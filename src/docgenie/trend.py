@@ -0,0 +1,60 @@
+"""Append one row per run to a CSV file for tracking documentation health over time."""
+
+from __future__ import annotations
+
+import csv
+from datetime import timezone
+from pathlib import Path
+from typing import Any
+
+from .exceptions import ConfigError
+from .quality_gate import language_coverage
+from .reproducible import build_time
+
+# Dashboards read these columns across runs, so the set and order never change.
+TREND_COLUMNS = ("timestamp", "commit", "score", "symbols", "coverage")
+
+
+def trend_row(analysis_data: dict[str, Any], score: float) -> dict[str, str]:
+    """Return the trend row for one run.
+
+    `coverage` lists per-language docstring coverage in `--fail-under-lang` syntax
+    (`go=80,python=62.5`), so languages added later do not change the columns.
+    `commit` is empty outside a git repository.
+    """
+    latest = analysis_data.get("git_info", {}).get("latest_commit", {})
+    commit = latest.get("hash", "") if isinstance(latest, dict) else ""
+    coverage = language_coverage(analysis_data)
+    return {
+        "timestamp": build_time().astimezone(timezone.utc).strftime("%Y-%m-%dT%H:%M:%SZ"),
+        "commit": str(commit),
+        "score": f"{score:g}",
+        "symbols": str(
+            len(analysis_data.get("functions", [])) + len(analysis_data.get("classes", []))
+        ),
+        "coverage": ",".join(f"{language}={value:g}" for language, value in coverage.items()),
+    }
+
+
+def append_trend(path: Path, analysis_data: dict[str, Any], score: float) -> dict[str, str]:
+    """Append this run's row to the CSV at `path`, writing the header if the file is new.
+
+    Raises ConfigError when an existing file has a different header, rather than
+    appending rows that no longer line up with its columns.
+    """
+    row = trend_row(analysis_data, score)
+    new_file = not path.exists() or path.stat().st_size == 0
+    if not new_file:
+        with path.open(encoding="utf-8", newline="") as handle:
+            header = next(csv.reader(handle), [])
+        if tuple(header) != TREND_COLUMNS:
+            raise ConfigError(
+                f"{path} has columns {', '.join(header)}; expected {', '.join(TREND_COLUMNS)}"
+            )
+    path.parent.mkdir(parents=True, exist_ok=True)
+    with path.open("a", encoding="utf-8", newline="") as handle:
+        writer = csv.DictWriter(handle, fieldnames=TREND_COLUMNS)
+        if new_file:
+            writer.writeheader()
+        writer.writerow(row)
+    return row
@@ -95,12 +95,18 @@ LANGUAGE_EXTENSIONS = {
     ".kt": "kotlin",
     ".scala": "scala",
     ".cs": "csharp",
+    ".dart": "dart",
+    ".ex": "elixir",
+    ".exs": "elixir",
+    ".lua": "lua",
+    ".proto": "protobuf",
     ".sh": "shell",
     ".bash": "shell",
     ".zsh": "shell",
     ".fish": "shell",
     ".r": "r",
     ".R": "r",
+    ".hs": "haskell",
     ".sql": "sql",
     ".html": "html",
     ".htm": "html",
@@ -182,6 +188,15 @@ def should_ignore_file(file_path: str, additional_patterns: List[str] | None = N
     return False
 
 
+def posix_path(path: str | os.PathLike[str]) -> str:
+    """Return `path` with forward slashes, whichever OS wrote it.
+
+    Module names, anchors, cache keys and ignore rules all use this form, so a
+    Windows checkout (or analysis JSON written on Windows) gives `pkg/mod.py`.
+    """
+    return os.fspath(path).replace("\\", "/")
+
+
 def load_gitignore_spec(root_path: Path) -> PathSpec | None:
     """Load .gitignore rules as a pathspec matcher."""
     gitignore = root_path / ".gitignore"
@@ -200,7 +215,7 @@ def is_path_ignored_by_gitignore(
     """Check whether a relative path matches .gitignore rules."""
     if matcher is None:
         return False
-    candidate = rel_path.rstrip("/")
+    candidate = posix_path(rel_path).rstrip("/")
     if is_dir:
         candidate = f"{candidate}/"
     return matcher.match_file(candidate)
@@ -0,0 +1,98 @@
+"""Polling file watcher used by `docgenie watch`."""
+
+from __future__ import annotations
+
+import os
+import time
+from collections.abc import Callable, Iterable
+from pathlib import Path
+
+from .utils import is_path_ignored_by_gitignore, load_gitignore_spec, should_ignore_file
+
+# rel path -> (mtime_ns, size); enough to notice saves without hashing every file.
+Snapshot = dict[str, tuple[int, int]]
+
+
+def changed_paths(before: Snapshot, after: Snapshot) -> list[str]:
+    """Return paths created, modified or deleted between two snapshots."""
+    keys = before.keys() | after.keys()
+    return sorted(key for key in keys if before.get(key) != after.get(key))
+
+
+class ChangeWatcher:
+    """Poll a directory tree and report changed files after a quiet period.
+
+    Hidden directories (`.git`, `.docgenie`), `.gitignore` matches, ignore
+    patterns and `excluded` paths are never reported, so writing the generated
+    docs back into the tree does not trigger another run.
+    """
+
+    def __init__(
+        self,
+        root: Path,
+        *,
+        ignore_patterns: list[str] | None = None,
+        excluded: Iterable[Path] = (),
+        interval: float = 0.5,
+        debounce: float = 0.5,
+        sleep: Callable[[float], None] = time.sleep,
+    ) -> None:
+        self.root = root.resolve()
+        self.ignore_patterns = ignore_patterns or []
+        self.excluded = {path.resolve() for path in excluded}
+        self.interval = interval
+        self.debounce = debounce
+        self._sleep = sleep
+        self._gitignore = load_gitignore_spec(self.root)
+        self._last = self.snapshot()
+        self.pending: set[str] = set()
+
+    def snapshot(self) -> Snapshot:
+        state: Snapshot = {}
+        for dirpath, dirs, files in os.walk(self.root):
+            current = Path(dirpath)
+            dirs[:] = [name for name in dirs if not self._skip(current / name, is_dir=True)]
+            for name in files:
+                file_path = current / name
+                if self._skip(file_path, is_dir=False):
+                    continue
+                try:
+                    stat = file_path.stat()
+                except OSError:
+                    continue
+                state[file_path.relative_to(self.root).as_posix()] = (
+                    stat.st_mtime_ns,
+                    stat.st_size,
+                )
+        return state
+
+    def poll(self) -> list[str]:
+        """Record changes since the previous poll in `pending` and return them."""
+        current = self.snapshot()
+        changes = changed_paths(self._last, current)
+        self._last = current
+        self.pending.update(changes)
+        return changes
+
+    def wait_for_changes(self) -> list[str]:
+        """Block until something changes, then until no change is seen for `debounce` seconds."""
+        while not self.poll():
+            self._sleep(self.interval)
+        quiet = 0.0
+        while quiet < self.debounce:
+            self._sleep(self.interval)
+            quiet = 0.0 if self.poll() else quiet + self.interval
+        return self.take_pending()
+
+    def take_pending(self) -> list[str]:
+        changes = sorted(self.pending)
+        self.pending.clear()
+        return changes
+
+    def _skip(self, path: Path, *, is_dir: bool) -> bool:
+        if path.name.startswith(".") or path.resolve() in self.excluded:
+            return True
+        rel = path.relative_to(self.root).as_posix()
+        if is_path_ignored_by_gitignore(rel, self._gitignore, is_dir=is_dir):
+            return True
+        return should_ignore_file(rel, self.ignore_patterns)
@@ -0,0 +1,238 @@
+"""Monorepo workspace mode: find subprojects by manifest and document each one separately."""
+
+from __future__ import annotations
+
+import json
+import os
+import re
+from collections import Counter
+from dataclasses import asdict, dataclass
+from pathlib import Path
+from typing import Any
+
+import toml
+
+from .config import load_config, merge_configs
+from .core import CodebaseAnalyzer
+from .html_sections import import_subproject
+from .utils import should_ignore_file
+
+WORKSPACE_MARKERS = ("go.mod", "package.json", "pyproject.toml")
+_SUMMED_METRICS = ("scanned_files", "changed_files", "skipped_files", "cache_hits", "pruned_files")
+_GO_MODULE_RE = re.compile(r"^module\s+(\S+)", re.MULTILINE)
+
+
+@dataclass(frozen=True)
+class Subproject:
+    """A directory holding its own manifest, documented as an independent project."""
+
+    path: str
+    marker: str
+    package: str = ""
+
+    @property
+    def name(self) -> str:
+        return self.package or Path(self.path).name
+
+    def to_public_dict(self) -> dict[str, str]:
+        return {**asdict(self), "name": self.name}
+
+
+def detect_subprojects(
+    root: Path,
+    markers: tuple[str, ...] | list[str] = WORKSPACE_MARKERS,
+    ignore_patterns: list[str] | None = None,
+) -> list[Subproject]:
+    """Return every directory below `root` containing one of `markers`, sorted by path.
+
+    The root itself is not a subproject; hidden and ignored directories (such as
+    `node_modules`) are not searched, so vendored manifests are never picked up.
+    """
+    root = root.resolve()
+    found: list[Subproject] = []
+    for dirpath, dirs, files in os.walk(root):
+        rel = Path(dirpath).relative_to(root).as_posix()
+        dirs[:] = sorted(
+            name
+            for name in dirs
+            if not name.startswith(".")
+            and not should_ignore_file(name if rel == "." else f"{rel}/{name}", ignore_patterns)
+        )
+        if rel == ".":
+            continue
+        marker = next((name for name in markers if name in files), None)
+        if marker is not None:
+            found.append(Subproject(rel, marker, _package_name(Path(dirpath) / marker)))
+    return sorted(found, key=lambda sub: sub.path)
+
+
+def _package_name(manifest: Path) -> str:
+    """Return the name other subprojects import this one by, or "" when unknown."""
+    try:
+        text = manifest.read_text(encoding="utf-8")
+        if manifest.name == "go.mod":
+            match = _GO_MODULE_RE.search(text)
+            return match.group(1) if match else ""
+        if manifest.name == "package.json":
+            data = json.loads(text)
+            return str(data.get("name") or "") if isinstance(data, dict) else ""
+        if manifest.name == "pyproject.toml":
+            data = toml.loads(text)
+            project = data.get("project") or data.get("tool", {}).get("poetry") or {}
+            # Distribution names use dashes; the importable package uses underscores.
+            return str(project.get("name") or "").replace("-", "_")
+    except (OSError, ValueError, AttributeError):
+        return ""
+    return ""
+
+
+def nested_ignore_patterns(subproject: Subproject, subprojects: list[Subproject]) -> list[str]:
+    """Ignore patterns that keep subprojects nested inside `subproject` out of its analysis."""
+    prefix = f"{subproject.path}/"
+    return [
+        f"{other.path[len(prefix):]}/*" for other in subprojects if other.path.startswith(prefix)
+    ]
+
+
+def workspace_context(subproject: Subproject, subprojects: list[Subproject]) -> dict[str, Any]:
+    """Return the `workspace` entry of a subproject's analysis.
+
+    `packages` maps the import names of the other subprojects to their paths so
+    impact-graph edges into them can be marked as external.
+    """
+    return {
+        "subproject": subproject.path,
+        "packages": {
+            other.package: other.path
+            for other in subprojects
+            if other.package and other.path != subproject.path
+        },
+    }
+
+
+def analyze_workspace(
+    root: Path,
+    *,
+    enable_tree_sitter: bool = True,
+    config_overrides: dict[str, Any] | None = None,
+) -> dict[str, Any]:
+    """Analyze every subproject of `root` on its own and aggregate the run metrics.
+
+    Each subproject keeps its own incremental index, so a change in one does not
+    invalidate the cached parse results of the others.
+    """
+    root = root.resolve()
+    root_config = load_config(root)
+    if config_overrides:
+        root_config = merge_configs(root_config, config_overrides)
+    monorepo = root_config.get("monorepo", {})
+    markers = monorepo.get("markers") if isinstance(monorepo, dict) else None
+    subprojects = detect_subprojects(
+        root, markers or WORKSPACE_MARKERS, root_config.get("ignore_patterns", [])
+    )
+
+    results: list[dict[str, Any]] = []
+    for subproject in subprojects:
+        sub_root = root / subproject.path
+        config = load_config(sub_root)
+        if config_overrides:
+            config = merge_configs(config, _partition_overrides(config_overrides, subproject))
+        ignore = list(config.get("ignore_patterns", []))
+        ignore += nested_ignore_patterns(subproject, subprojects)
+        analysis = CodebaseAnalyzer(
+            str(sub_root), ignore, enable_tree_sitter=enable_tree_sitter, config=config
+        ).analyze()
+        analysis["workspace"] = workspace_context(subproject, subprojects)
+        results.append({**subproject.to_public_dict(), "analysis": analysis})
+
+    return {
+        "root_path": str(root),
+        "project_name": root.name,
+        "subprojects": results,
+        "run_metrics": aggregate_run_metrics(
+            {item["path"]: item["analysis"].get("run_metrics", {}) for item in results}
+        ),
+        "cross_imports": cross_subproject_imports(results),
+        "config": root_config,
+    }
+
+
+def _partition_overrides(overrides: dict[str, Any], subproject: Subproject) -> dict[str, Any]:
+    """Give each subproject its own directory below a shared `--cache-dir`."""
+    analysis = overrides.get("analysis")
+    if not isinstance(analysis, dict) or not analysis.get("cache_dir"):
+        return overrides
+    cache_dir = str(Path(str(analysis["cache_dir"])) / subproject.path)
+    return {**overrides, "analysis": {**analysis, "cache_dir": cache_dir}}
+
+
+def aggregate_run_metrics(per_subproject: dict[str, dict[str, Any]]) -> dict[str, Any]:
+    """Sum the per-subproject run metrics; the per-subproject values are kept alongside."""
+    totals: Counter[str] = Counter()
+    skip_reasons: Counter[str] = Counter()
+    duration = 0.0
+    for metrics in per_subproject.values():
+        for key in _SUMMED_METRICS:
+            totals[key] += int(metrics.get(key, 0) or 0)
+        skip_reasons.update(metrics.get("skip_reasons", {}) or {})
+        duration += float(metrics.get("duration_sec", 0.0) or 0.0)
+    scanned = totals["scanned_files"]
+    return {
+        "scanned_files": scanned,
+        "changed_files": totals["changed_files"],
+        "skipped_files": totals["skipped_files"],
+        "duration_sec": round(duration, 3),
+        "cache_hit_ratio": round(totals["cache_hits"] / scanned, 3) if scanned else 0.0,
+        "skip_reasons": dict(sorted(skip_reasons.items())),
+        "cache_hits": totals["cache_hits"],
+        "pruned_files": totals["pruned_files"],
+        "subprojects": dict(sorted(per_subproject.items())),
+    }
+
+
+def cross_subproject_imports(results: list[dict[str, Any]]) -> dict[str, list[str]]:
+    """Map each subproject path to the sorted paths of the sibling subprojects it imports."""
+    edges: dict[str, list[str]] = {}
+    for item in results:
+        analysis = item["analysis"]
+        workspace = analysis.get("workspace", {})
+        targets = {
+            target
+            for imports in (analysis.get("file_imports") or {}).values()
+            for imported in imports or []
+            if (target := import_subproject(str(imported), workspace)) is not None
+        }
+        if targets:
+            edges[item["path"]] = sorted(targets)
+    return edges
+
+
+def render_workspace_index(workspace: dict[str, Any], readme_name: str = "README.md") -> str:
+    """Return the top-level Markdown index linking every subproject README."""
+    lines = [
+        f"# {workspace.get('project_name', 'Workspace')}",
+        "",
+        f"This workspace contains {len(workspace.get('subprojects', []))} subprojects.",
+        "",
+        "| Subproject | Manifest | Package | Files | Functions | Classes |",
+        "|---|---|---|---|---|---|",
+    ]
+    for item in workspace.get("subprojects", []):
+        analysis = item.get("analysis", {})
+        lines.append(
+            f"| [{item['path']}]({item['path']}/{readme_name}) | `{item['marker']}` "
+            f"| {item.get('package') or '-'} | {analysis.get('files_analyzed', 0)} "
+            f"| {len(analysis.get('functions', []))} | {len(analysis.get('classes', []))} |"
+        )
+    cross = workspace.get("cross_imports") or {}
+    if cross:
+        lines += ["", "## Cross-subproject dependencies", ""]
+        for source in sorted(cross):
+            lines.append(f"- `{source}` imports " + ", ".join(f"`{t}`" for t in cross[source]))
+    metrics = workspace.get("run_metrics", {})
+    lines += [
+        "",
+        f"_Scanned {metrics.get('scanned_files', 0)} files, re-parsed "
+        f"{metrics.get('changed_files', 0)} ({metrics.get('cache_hits', 0)} cache hits)._",
+    ]
+    return "\n".join(lines) + "\n"
@@ -1,9 +1,27 @@
+import json
 from pathlib import Path
+from typing import Any
 
+import pytest
+import typer
 from typer.testing import CliRunner
 
-from docgenie import __version__
-from docgenie.cli import app
+from docgenie import AnalysisOptions, AnalysisResult, __version__, analyze, generate
+from docgenie.api import readme_readiness
+from docgenie.cli import _build_outputs, _validate_format, app
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.readme_quality import build_quality_report, resolve_score_weights
+
+
+def test_short_f_always_means_format() -> None:
+    group = typer.main.get_command(app)
+    for name, command in group.commands.items():
+        for param in command.params:
+            if "-f" in param.opts:
+                assert "--format" in param.opts, name
+            if "--force" in param.opts:
+                assert "-f" not in param.opts, name
 
 
 def test_generate_preview(tmp_path: Path) -> None:
@@ -33,3 +51,214 @@ def test_analyze_text(tmp_path: Path) -> None:
     result = runner.invoke(app, ["analyze", str(tmp_path), "--format", "text"])
     assert result.exit_code == 0
     assert "Files analyzed" in result.stdout
+
+
+def test_analyze_empty_directory(tmp_path: Path) -> None:
+    runner = CliRunner()
+    metrics_path = tmp_path.parent / f"{tmp_path.name}-metrics.json"
+    result = runner.invoke(
+        app, ["analyze", str(tmp_path), "--format", "json", "--metrics-json", str(metrics_path)]
+    )
+
+    assert result.exit_code == 0
+
+    def reject(constant: str) -> None:
+        raise AssertionError(f"non-finite number in JSON: {constant}")
+
+    payload = json.loads(result.stdout, parse_constant=reject)
+    assert payload["files_analyzed"] == 0
+    assert payload["run_metrics"]["scanned_files"] == 0
+    assert payload["run_metrics"]["cache_hit_ratio"] == 0.0
+    assert json.loads(metrics_path.read_text(encoding="utf-8"), parse_constant=reject)[
+        "cache_hit_ratio"
+    ] == 0.0
+
+    preview = runner.invoke(app, ["generate", str(tmp_path), "--format", "markdown", "--preview"])
+    assert preview.exit_code == 0
+    assert "No files analyzed." in preview.stdout
+
+
+def test_library_api_matches_cli_output(tmp_path: Path, monkeypatch: pytest.MonkeyPatch) -> None:
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "1700000000")
+    project = tmp_path / "project"
+    project.mkdir()
+    (project / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n', encoding="utf-8"
+    )
+    # Both paths score readiness with the configured sections, which the README reports.
+    (project / ".docgenie.yaml").write_text(
+        "quality:\n  required_sections: [Overview, Deployment]\n  min_confidence: high\n",
+        encoding="utf-8",
+    )
+    readme = tmp_path / "README.md"
+
+    result = CliRunner().invoke(
+        app,
+        ["generate", str(project), "--format", "markdown", "-o", str(readme), "--no-cache"],
+    )
+    assert result.exit_code == 0
+
+    analysis = analyze(
+        project, AnalysisOptions(config_overrides={"analysis": {"incremental": False}})
+    )
+    assert generate(analysis, "markdown") == readme.read_bytes()
+    readiness = readme_readiness(analysis.to_public_dict())
+    assert "Missing section: Deployment" in readiness["reasons"]
+    assert readiness["min_confidence"] == "high"
+
+
+def test_multiple_formats_share_one_analysis(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    project = tmp_path / "project"
+    project.mkdir()
+    (project / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n', encoding="utf-8"
+    )
+    calls = {"analyze": 0, "context": 0}
+    analyze_result = CodebaseAnalyzer.analyze_result
+    prepare_context = ReadmeGenerator._prepare_context
+
+    def counted_analyze(self: CodebaseAnalyzer) -> AnalysisResult:
+        calls["analyze"] += 1
+        return analyze_result(self)
+
+    def counted_context(self: ReadmeGenerator, *args: Any) -> dict[str, Any]:
+        calls["context"] += 1
+        return prepare_context(self, *args)
+
+    monkeypatch.setattr(CodebaseAnalyzer, "analyze_result", counted_analyze)
+    monkeypatch.setattr(ReadmeGenerator, "_prepare_context", counted_context)
+    out_dir = tmp_path / "site"
+
+    result = CliRunner().invoke(
+        app, ["generate", str(project), "--format", "md,html,json", "--out-dir", str(out_dir)]
+    )
+
+    assert result.exit_code == 0, result.stdout
+    assert sorted(path.name for path in out_dir.iterdir()) == [
+        "README.md",
+        "analysis.json",
+        "docs.html",
+        "search-index.json",
+    ]
+    assert calls == {"analyze": 1, "context": 1}
+    assert _validate_format("md, HTML,json,md") == "markdown,html,json"
+    assert [name for name, _ in _build_outputs("both,json", None, tmp_path)] == [
+        "markdown",
+        "html",
+        "json",
+    ]
+    analysis = json.loads((out_dir / "analysis.json").read_text(encoding="utf-8"))
+    assert analysis["run_metrics"]["scanned_files"] == 1
+    assert [func["name"] for func in analysis["functions"]] == ["add"]
+    assert "Add two numbers." in (out_dir / "README.md").read_text(encoding="utf-8")
+    assert "Add two numbers." in (out_dir / "docs.html").read_text(encoding="utf-8")
+
+    clash = CliRunner().invoke(
+        app, ["generate", str(project), "--format", "md,html", "-o", str(tmp_path / "x.md")]
+    )
+    assert clash.exit_code == 1
+    assert "--output must be an existing directory" in clash.stdout
+
+
+def test_quiet_analyze_writes_no_progress(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text("def add(a, b):\n    return a + b\n", encoding="utf-8")
+    runner = CliRunner()
+
+    reported = runner.invoke(app, ["analyze", str(tmp_path), "--progress", "json"])
+    quiet = runner.invoke(
+        app, ["analyze", str(tmp_path), "--format", "json", "--progress", "json", "--quiet"]
+    )
+
+    assert reported.exit_code == 0
+    assert '{"event": "done", "processed": 1, "total": 1, "eta_sec": null}' in reported.output
+    assert quiet.exit_code == 0
+    # Output mixes stdout and stderr, so any progress line would break the JSON.
+    assert json.loads(quiet.output)["files_analyzed"] == 1
+
+
+def test_check_fails_only_when_committed_readme_is_stale(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n', encoding="utf-8"
+    )
+    readme = tmp_path / "README.md"
+    readme.write_text("# calc\n", encoding="utf-8")
+    runner = CliRunner()
+    generated = runner.invoke(app, ["generate", str(tmp_path), "-f", "markdown", "--force"])
+    assert generated.exit_code == 0
+
+    fresh = runner.invoke(app, ["check", str(tmp_path)])
+    assert fresh.exit_code == 0, fresh.output
+    assert "stale" not in fresh.output
+
+    readme.write_text(
+        readme.read_text(encoding="utf-8").replace("Add two numbers.", "Sum of numbers."),
+        encoding="utf-8",
+    )
+    stale = runner.invoke(app, ["check", str(tmp_path)])
+    assert stale.exit_code == 1
+    assert "README.md is out of date" in stale.output
+    removed = [line for line in stale.output.splitlines() if line.startswith("-")]
+    assert any("Sum of numbers." in line for line in removed)
+    assert "1 stale file(s); run `docgenie generate` to update them" in stale.output
+
+
+def test_check_renders_with_configured_output_options(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n\n\n'
+        "def _carry(a):\n    return a\n",
+        encoding="utf-8",
+    )
+    (tmp_path / ".docgenie.yaml").write_text(
+        "analysis:\n  visibility: all\ntemplate_customizations:\n  group_by: package\n",
+        encoding="utf-8",
+    )
+    runner = CliRunner()
+    generated = runner.invoke(
+        app, ["generate", str(tmp_path), "--format", "md,html", "-o", str(tmp_path), "--force"]
+    )
+    assert generated.exit_code == 0, generated.output
+    assert "_carry" in (tmp_path / "README.md").read_text(encoding="utf-8")
+
+    # Both formats report the same readiness score `generate` wrote.
+    checked = runner.invoke(app, ["check", str(tmp_path), "--format", "md,html"])
+    assert checked.exit_code == 0, checked.output
+
+
+def test_html_codebase_source_reads_project_config(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n\n\n'
+        "def _carry(a):\n    return a\n",
+        encoding="utf-8",
+    )
+    (tmp_path / "scratch.py").write_text("def scratch_only():\n    pass\n", encoding="utf-8")
+    (tmp_path / ".docgenie.yaml").write_text(
+        "analysis:\n  visibility: all\nignore_patterns: [scratch.py]\n", encoding="utf-8"
+    )
+
+    result = CliRunner().invoke(app, ["html", str(tmp_path), "--source", "codebase", "--force"])
+    assert result.exit_code == 0, result.output
+
+    page = (tmp_path / "docs.html").read_text(encoding="utf-8")
+    assert "_carry" in page
+    assert "scratch_only" not in page
+    assert (tmp_path / "search-index.json").exists()
+
+
+def test_cli_default_score_matches_library_weights(tmp_path: Path) -> None:
+    project = tmp_path / "project"
+    project.mkdir()
+    (project / "calc.py").write_text("def add(a, b):\n    return a + b\n", encoding="utf-8")
+    metrics_path = tmp_path / "weights.json"
+    result = CliRunner().invoke(
+        app, ["analyze", str(project), "--format", "json", "--metrics-json", str(metrics_path)]
+    )
+    assert result.exit_code == 0
+
+    weights = json.loads(metrics_path.read_text(encoding="utf-8"))["quality_weights"]
+    assert weights == resolve_score_weights({})
+    analysis = json.loads(result.stdout)
+    report = ReadmeGenerator().quality_report(analysis)
+    library = build_quality_report(analysis, has_tests=False, weights=resolve_score_weights({}))
+    assert report["score"] == library["score"]
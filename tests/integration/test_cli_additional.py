@@ -1,5 +1,6 @@
 from __future__ import annotations
 
+import json
 import runpy
 import sys
 from pathlib import Path
@@ -8,7 +9,7 @@ import pytest
 import typer
 from typer.testing import CliRunner
 
-from docgenie import cli
+from docgenie import api, cli
 from docgenie.cli import (
     _build_outputs,
     _confirm_overwrite,
@@ -16,6 +17,7 @@ from docgenie.cli import (
     _print_summary,
     _resolve_output,
     _validate_format,
+    _validate_graph_format,
     app,
 )
 
@@ -24,6 +26,9 @@ def test_validate_format_and_extract_title() -> None:
     assert _validate_format("BOTH") == "both"
     with pytest.raises(typer.Exit):
         _validate_format("invalid")
+    assert _validate_graph_format("Mermaid") == "mermaid"
+    with pytest.raises(typer.Exit):
+        _validate_graph_format("dot")
 
     assert _extract_title("# Title\ntext") == "Title"
     assert _extract_title("text\n## no") is None
@@ -41,6 +46,11 @@ def test_output_resolution_helpers(tmp_path: Path, monkeypatch: pytest.MonkeyPat
 
     outputs = _build_outputs("both", None, base)
     assert {x[0] for x in outputs} == {"markdown", "html"}
+    assert _build_outputs("adoc", None, base) == [("adoc", base / "README.adoc")]
+    assert _validate_format("adoc") == "adoc"
+    assert _build_outputs("confluence", None, base) == [
+        ("confluence", base / "README.confluence.xhtml")
+    ]
 
     # confirm overwrite exit path
     target = tmp_path / "README.md"
@@ -97,6 +107,16 @@ def test_generate_and_init_and_html_commands(tmp_path: Path, monkeypatch: pytest
     assert analyze_json.exit_code == 0
     assert "files_analyzed" in analyze_json.stdout
 
+    analyze_stream = runner.invoke(app, ["analyze", str(tmp_path), "--format", "json", "--stream"])
+    assert analyze_stream.exit_code == 0
+    streamed, buffered = json.loads(analyze_stream.stdout), json.loads(analyze_json.stdout)
+    assert list(streamed) == list(buffered)
+    assert streamed["functions"] == buffered["functions"]
+    assert streamed["classes"] == buffered["classes"]
+    stream_yaml = runner.invoke(app, ["analyze", str(tmp_path), "--format", "yaml", "--stream"])
+    assert stream_yaml.exit_code == 1
+    assert "--stream requires --format json" in stream_yaml.stdout
+
     analyze_yaml = runner.invoke(app, ["analyze", str(tmp_path), "--format", "yaml"])
     assert analyze_yaml.exit_code == 0
     assert "files_analyzed" in analyze_yaml.stdout
@@ -163,11 +183,14 @@ def test_render_outputs_direct(monkeypatch: pytest.MonkeyPatch, tmp_path: Path)
     }
 
     class FakeReadme:
+        def __init__(self, **_kwargs):
+            pass
+
         def generate(self, _analysis, _output):
             return "# readme"
 
     class FakeHtml:
-        def generate_from_analysis(self, _analysis, _output):
+        def generate_from_analysis(self, _analysis, _output, **_kwargs):
             return "<h1>html</h1>\n" * 100
 
     monkeypatch.setattr(cli, "ReadmeGenerator", FakeReadme)
@@ -185,11 +208,22 @@ def test_run_analysis_verbose(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -
         def __init__(self, *_args, **_kwargs):
             pass
 
-        def analyze(self):
-            return {"project_name": "X", "files_analyzed": 1, "languages": {}, "functions": [], "classes": [], "git_info": {}}
-
-    monkeypatch.setattr(cli, "CodebaseAnalyzer", FakeAnalyzer)
-    monkeypatch.setattr(cli, "load_config", lambda _path: {"ignore_patterns": ["*.tmp"]})
+        def analyze_result(self):
+            return FakeResult()
+
+    class FakeResult:
+        def to_public_dict(self):
+            return {
+                "project_name": "X",
+                "files_analyzed": 1,
+                "languages": {},
+                "functions": [],
+                "classes": [],
+                "git_info": {},
+            }
+
+    monkeypatch.setattr(api, "CodebaseAnalyzer", FakeAnalyzer)
+    monkeypatch.setattr(api, "load_config", lambda _path: {"ignore_patterns": ["*.tmp"]})
     data = cli._run_analysis(tmp_path, ["*.log"], True, verbose=True)
     assert data["files_analyzed"] == 1
 
@@ -239,3 +273,86 @@ def test_pr_summary_command(tmp_path: Path) -> None:
     )
     assert result_out.exit_code == 0
     assert out.exists()
+
+
+def test_watch_command_reruns_on_change_and_flushes_on_interrupt(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    (tmp_path / "m.py").write_text("def x():\n    return 1\n", encoding="utf-8")
+    runs: list[list[str]] = []
+    monkeypatch.setattr(
+        cli,
+        "_watch_run",
+        lambda _path, _outputs, *, ignore, tree_sitter, changes: runs.append(changes),
+    )
+    waits = iter([["m.py"]])
+
+    def fake_wait(self: cli.ChangeWatcher) -> list[str]:
+        try:
+            return next(waits)
+        except StopIteration:
+            self.pending.add("late.py")
+            raise KeyboardInterrupt from None
+
+    monkeypatch.setattr(cli.ChangeWatcher, "wait_for_changes", fake_wait)
+    cli.watch_command(
+        tmp_path,
+        output=None,
+        fmt="markdown",
+        ignore=[],
+        force=True,
+        interval=0.1,
+        debounce=0.1,
+        tree_sitter=False,
+    )
+    assert runs == [[], ["m.py"], ["late.py"]]
+    assert cli._watch_exclusions(cli._build_outputs("both", None, tmp_path)) == [
+        tmp_path / "README.md",
+        tmp_path / "docs.html",
+        tmp_path / "search-index.json",
+    ]
+
+
+def test_analyze_schema_version_output(tmp_path: Path, monkeypatch: pytest.MonkeyPatch) -> None:
+    (tmp_path / "m.py").write_text("def x():\n    return 1\n", encoding="utf-8")
+    echoed: list[str] = []
+    monkeypatch.setattr(cli.typer, "echo", echoed.append)
+    with pytest.raises(typer.Exit):
+        cli._validate_schema_version(1, "yaml")
+    with pytest.raises(typer.Exit):
+        cli._validate_schema_version(99, "json")
+    cli._validate_schema_version(None, "text")
+
+    cli.analyze(
+        tmp_path,
+        fmt="json",
+        tree_sitter=False,
+        metrics_json=None,
+        engine="hybrid",
+        incremental=False,
+        cache_dir=None,
+        jobs=1,
+        git_metadata=False,
+        schema_version=1,
+    )
+    payload = json.loads(echoed[-1])
+    assert payload["schema_version"] == 1
+    assert payload["modules"][0]["symbols"][0]["qualified_name"] == "x"
+
+
+def test_fail_on_broken_links_flag(tmp_path: Path) -> None:
+    (tmp_path / "main.py").write_text("def hello():\n    return 'world'\n", encoding="utf-8")
+    templates = tmp_path / "templates"
+    templates.mkdir()
+    # The custom template links to an API entry it never renders.
+    (templates / "readme.md.j2").write_text(
+        "# {{ project_name }}\n\nSee [`save`](#api-save).\n", encoding="utf-8"
+    )
+    args = ["generate", str(tmp_path), "--template-dir", str(templates), "--force"]
+    runner = CliRunner()
+
+    assert runner.invoke(app, args).exit_code == 0
+    result = runner.invoke(app, [*args, "--fail-on-broken-links"])
+    assert result.exit_code == 1
+    assert "1 broken internal link(s)" in result.stdout
+    assert "(#api-save)" in (tmp_path / "README.md").read_text(encoding="utf-8")
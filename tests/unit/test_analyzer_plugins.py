@@ -0,0 +1,95 @@
+from __future__ import annotations
+
+import sys
+import types
+from collections.abc import Sequence
+from pathlib import Path
+
+import pytest
+
+from docgenie.analyzer_plugins import load_analyzer_plugins, run_analyzer_plugins
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.models import PluginFile, PluginSection
+
+_MODULE = "docgenie_test_rpc_plugin"
+
+
+def rpc_endpoints(root_path: Path, files: Sequence[PluginFile]) -> list[PluginSection]:
+    names = [
+        func["name"] for parsed in files for func in parsed.functions if func["name"] != "helper"
+    ]
+    return [PluginSection(title="RPC Endpoints", body="\n".join(f"- `{n}`" for n in names))]
+
+
+def broken(root_path: Path, files: Sequence[PluginFile]) -> list[PluginSection]:
+    raise RuntimeError("boom")
+
+
+def as_mapping(root_path: Path, files: Sequence[PluginFile]) -> list[dict[str, str]]:
+    return [{"title": "Stats", "body": f"{len(files)} files"}]
+
+
+@pytest.fixture
+def plugin_module(monkeypatch: pytest.MonkeyPatch) -> str:
+    module = types.ModuleType(_MODULE)
+    module.analyze = rpc_endpoints  # type: ignore[attr-defined]
+    module.broken = broken  # type: ignore[attr-defined]
+    module.as_mapping = as_mapping  # type: ignore[attr-defined]
+    monkeypatch.setitem(sys.modules, _MODULE, module)
+    return _MODULE
+
+
+def _write(root: Path) -> None:
+    (root / "svc.py").write_text(
+        "def get_user():\n    pass\n\ndef helper():\n    pass\n", encoding="utf-8"
+    )
+
+
+def test_load_analyzer_plugins_resolves_specs(plugin_module: str) -> None:
+    plugins, failed = load_analyzer_plugins(
+        [plugin_module, f"{plugin_module}:broken", "missing.module", f"{plugin_module}:nope"],
+        entry_points=False,
+    )
+
+    assert [name for name, _ in plugins] == [plugin_module, f"{plugin_module}:broken"]
+    assert failed == 2  # noqa: PLR2004
+
+
+def test_run_analyzer_plugins_isolates_errors(plugin_module: str, tmp_path: Path) -> None:
+    plugins, _ = load_analyzer_plugins(
+        [f"{plugin_module}:broken", f"{plugin_module}:as_mapping"], entry_points=False
+    )
+    files = [PluginFile(path="a.py", language="python")]
+
+    sections, errors = run_analyzer_plugins(plugins, tmp_path, files)
+
+    assert sections == [
+        PluginSection(title="Stats", body="1 files", plugin=f"{plugin_module}:as_mapping")
+    ]
+    assert errors == {"plugin_error": 1}
+
+
+def test_analyzer_runs_plugins_after_parsing(plugin_module: str, tmp_path: Path) -> None:
+    _write(tmp_path)
+    config = {
+        "plugins": {"modules": [plugin_module, f"{plugin_module}:broken", "missing.module"]}
+    }
+
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    assert result["plugin_sections"] == [
+        {"title": "RPC Endpoints", "body": "- `get_user`", "plugin": plugin_module}
+    ]
+    assert result["skipped_reasons"]["plugin_error"] == 1
+    assert result["skipped_reasons"]["plugin_load_error"] == 1
+
+
+def test_plugin_sections_reach_generator_context(plugin_module: str, tmp_path: Path) -> None:
+    _write(tmp_path)
+    config = {"plugins": {"modules": [plugin_module]}}
+    data = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    context = ReadmeGenerator()._prepare_context(data)
+
+    assert [section["title"] for section in context["plugin_sections"]] == ["RPC Endpoints"]
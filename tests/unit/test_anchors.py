@@ -0,0 +1,117 @@
+from __future__ import annotations
+
+import xml.etree.ElementTree as ET  # noqa: N817  # nosec B405
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.docbook import DOCBOOK_NS, DocBookGenerator
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_generator import HTMLGenerator
+from docgenie.html_sections import (
+    code_heading_anchors,
+    element_ids,
+    iter_search_entries,
+    module_anchors,
+    symbol_anchors,
+)
+
+DB = f"{{{DOCBOOK_NS}}}"
+XML_ID = "{http://www.w3.org/XML/1998/namespace}id"
+
+USERS = '''"""User lookups."""
+
+
+def get_user(user_id):
+    """Return one user."""
+    return user_id
+
+
+class Store:
+    """Keeps users."""
+
+    def save(self, user):
+        """Persist a user."""
+        return user
+'''
+
+
+def _analysis(tmp_path: Path) -> dict[str, object]:
+    for package in ("admin", "app"):
+        (tmp_path / package).mkdir()
+        (tmp_path / package / "users.py").write_text(USERS, encoding="utf-8")
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+
+def test_same_symbol_has_same_anchor_in_every_format(tmp_path: Path) -> None:
+    analysis = _analysis(tmp_path)
+    anchors = symbol_anchors(analysis)
+    assert sorted(anchors.items()) == [
+        (("admin/users.py", "Store", 9), "api-store"),
+        (("admin/users.py", "Store.save", 12), "api-store-save"),
+        (("admin/users.py", "get_user", 4), "api-get-user"),
+        (("admin/users.py", "save", 12), "api-save"),
+        (("app/users.py", "Store", 9), "api-store-2"),
+        (("app/users.py", "Store.save", 12), "api-store-save-2"),
+        (("app/users.py", "get_user", 4), "api-get-user-2"),
+        (("app/users.py", "save", 12), "api-save-2"),
+    ]
+
+    api = ReadmeGenerator()._prepare_context(analysis)["api_docs"]
+    readme = {
+        (Path(doc["file"]).parent.name, doc["name"]): doc["anchor"]
+        for doc in [*api["functions"], *api["classes"]]
+    }
+    assert readme == {
+        ("admin", "get_user"): "api-get-user",
+        ("app", "get_user"): "api-get-user-2",
+        ("admin", "save"): "api-save",
+        ("app", "save"): "api-save-2",
+        ("admin", "Store"): "api-store",
+        ("app", "Store"): "api-store-2",
+    }
+
+    # The HTML page keeps the README's explicit anchors, and the search index links to them.
+    page = HTMLGenerator().generate_from_analysis(analysis, None)
+    assert set(readme.values()) <= element_ids(page)
+    search = {
+        (entry["module"].split("/")[0], entry["name"]): entry["anchor"]
+        for entry in iter_search_entries(analysis, code_heading_anchors(page), element_ids(page))
+    }
+    assert {key: search[key] for key in readme} == readme
+
+    article = ET.fromstring(DocBookGenerator().generate(analysis, None))
+    docbook = [
+        (element.get(XML_ID), element.findtext(f"{DB}title"))
+        for element in article.iter(f"{DB}section")
+    ]
+    assert docbook[2:] == [
+        ("module-admin-users-py", "admin/users.py"),
+        ("api-get-user", "get_user (function)"),
+        ("api-store", "Store (class)"),
+        ("api-store-save", "Store.save (method)"),
+        ("api-save", "save (function)"),
+        ("module-app-users-py", "app/users.py"),
+        ("api-get-user-2", "get_user (function)"),
+        ("api-store-2", "Store (class)"),
+        ("api-store-save-2", "Store.save (method)"),
+        ("api-save-2", "save (function)"),
+    ]
+
+
+def test_symbol_anchors_skip_template_heading_ids(tmp_path: Path) -> None:
+    (tmp_path / "api.py").write_text(
+        'def reference():\n    """Look one up."""\n', encoding="utf-8"
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert symbol_anchors(analysis) == {("api.py", "reference", 1): "api-reference-2"}
+    page = HTMLGenerator().generate_from_analysis(analysis, None)
+    assert page.count('id="api-reference"') == 1
+    assert 'id="api-reference-2"' in page
+
+
+def test_module_anchors_deduplicate_like_headings() -> None:
+    assert module_anchors(["src/a-b.py", "src/a_b.py", "src/a-b.py"]) == {
+        "src/a-b.py": "src-a-b-py",
+        "src/a_b.py": "src-a-b-py-2",
+    }
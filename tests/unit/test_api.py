@@ -0,0 +1,47 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie import AnalysisOptions, AnalysisResult, analyze
+from docgenie.api import generate, resolve_config
+from docgenie.core import CodebaseAnalyzer
+from docgenie.exceptions import ConfigError
+
+
+def _project(tmp_path: Path) -> Path:
+    (tmp_path / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n', encoding="utf-8"
+    )
+    (tmp_path / "scratch.py").write_text("def draft():\n    pass\n", encoding="utf-8")
+    (tmp_path / ".docgenie.yaml").write_text("ignore_patterns: [scratch.py]\n", encoding="utf-8")
+    return tmp_path
+
+
+def test_analyze_returns_the_analyzer_result(tmp_path: Path) -> None:
+    project = _project(tmp_path)
+    options = AnalysisOptions(config_overrides={"analysis": {"incremental": False}})
+
+    result = analyze(project, options)
+
+    assert isinstance(result, AnalysisResult)
+    assert [func["name"] for func in result.functions] == ["add"]
+    assert result.config["analysis"]["incremental"] is False
+    expected = CodebaseAnalyzer(
+        str(project), ["scratch.py"], config=resolve_config(project, options)
+    ).analyze()
+    assert result.to_public_dict()["functions"] == expected["functions"]
+
+    without_file = analyze(project, AnalysisOptions(use_config_file=False))
+    assert sorted(func["name"] for func in without_file.functions) == ["add", "draft"]
+
+
+def test_invalid_configuration_and_format_raise(tmp_path: Path) -> None:
+    project = _project(tmp_path)
+    (project / ".docgenie.yaml").write_text("analysis: {parallelism: many}\n", encoding="utf-8")
+
+    with pytest.raises(ConfigError, match="configuration:\n  - `analysis.parallelism` must be"):
+        analyze(project)
+    with pytest.raises(ValueError, match="Unsupported format: pdf"):
+        generate(analyze(project, AnalysisOptions(use_config_file=False)), "pdf")
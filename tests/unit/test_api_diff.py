@@ -0,0 +1,115 @@
+from __future__ import annotations
+
+import json
+from pathlib import Path
+from typing import Any
+
+import pytest
+import typer
+
+from docgenie import cli
+from docgenie.api_diff import diff_api, render_api_diff
+from docgenie.schema import build_analysis_document
+
+
+def _analysis(
+    tmp_path: Path, functions: list[dict[str, Any]], classes: list[dict[str, Any]]
+) -> dict[str, Any]:
+    for item in [*functions, *classes]:
+        item["file"] = str(tmp_path / item.pop("module"))
+    return {
+        "project_name": "demo",
+        "root_path": str(tmp_path),
+        "functions": functions,
+        "classes": classes,
+    }
+
+
+def _old(tmp_path: Path) -> dict[str, Any]:
+    return _analysis(
+        tmp_path,
+        [
+            {"name": "Connect", "module": "db/conn.go", "line": 3, "args": ["dsn string"]},
+            {"name": "OpenPool", "module": "db/conn.go", "line": 9, "args": ["size int"]},
+            {"name": "Close", "module": "db/conn.go", "line": 20, "args": []},
+            {"name": "helper", "module": "db/conn.go", "line": 30, "args": ["x int"]},
+        ],
+        [],
+    )
+
+
+def _new(tmp_path: Path) -> dict[str, Any]:
+    return _analysis(
+        tmp_path,
+        [
+            {"name": "Connect", "module": "db/conn.go", "line": 3, "args": ["ctx", "dsn string"]},
+            {"name": "NewPool", "module": "db/conn.go", "line": 9, "args": ["size int"]},
+            {"name": "Ping", "module": "db/ping.go", "line": 1, "args": []},
+        ],
+        [],
+    )
+
+
+def test_detects_renames_signature_changes_and_breaking_removals(tmp_path: Path) -> None:
+    report = diff_api(_old(tmp_path), _new(tmp_path))
+
+    assert report["totals"] == {
+        "removed": 2,
+        "renamed": 1,
+        "changed": 1,
+        "added": 1,
+        "breaking": 2,
+    }
+    conn = report["modules"][0]
+    assert conn["path"] == "db/conn.go"
+    assert [(c["change"], c["qualified_name"], c["breaking"]) for c in conn["changes"]] == [
+        ("removed", "Close", True),
+        ("removed", "helper", False),
+        ("renamed", "NewPool", True),
+        ("changed", "Connect", False),
+    ]
+    assert report["modules"][1]["changes"][0]["change"] == "added"
+
+    markdown = render_api_diff(report, old_label="v1.json", new_label="v2.json")
+    assert "## `db/conn.go`\n\n- **Breaking:** Removed function `Close()`\n" in markdown
+    renamed = "- **Breaking:** Renamed function `OpenPool` to `NewPool` (`NewPool(size int)`)"
+    changed = "- Changed function `Connect`: `Connect(dsn string)` -> `Connect(ctx, dsn string)`"
+    assert renamed in markdown
+    assert changed in markdown
+    assert "## `db/ping.go`\n\n- Added function `Ping()`" in markdown
+
+
+def test_accepts_schema_documents(tmp_path: Path) -> None:
+    old = build_analysis_document(_old(tmp_path)).to_public_dict()
+    new = build_analysis_document(_new(tmp_path)).to_public_dict()
+
+    report = diff_api(old, new)
+
+    # Schema symbols carry no arguments, so only name-level changes are visible.
+    assert report["totals"]["changed"] == 0
+    assert report["totals"]["added"] == 2
+    assert report["totals"]["breaking"] == 2
+    assert render_api_diff(diff_api(old, old), old_label="a", new_label="b").endswith(
+        "No API changes.\n"
+    )
+
+
+def test_diff_command_compares_files(tmp_path: Path, monkeypatch: pytest.MonkeyPatch) -> None:
+    old_path = tmp_path / "old.json"
+    new_path = tmp_path / "new.json"
+    old_path.write_text(json.dumps(_old(tmp_path)), encoding="utf-8")
+    new_path.write_text(json.dumps(_new(tmp_path)), encoding="utf-8")
+    echoed: list[str] = []
+    monkeypatch.setattr(cli.typer, "echo", echoed.append)
+
+    cli.diff_command(old_path, new_path, None, "HEAD", "json", True, None)
+    assert json.loads(echoed[0])["totals"]["renamed"] == 1
+
+    report = tmp_path / "API.md"
+    cli.diff_command(old_path, new_path, None, "HEAD", "text", True, report)
+    assert report.read_text(encoding="utf-8").startswith("# API Changes")
+
+    (tmp_path / "bad.json").write_text("[]", encoding="utf-8")
+    with pytest.raises(typer.Exit):
+        cli.diff_command(tmp_path / "bad.json", new_path, None, "HEAD", "text", True, None)
+    assert echoed[-1].startswith("Invalid analysis")
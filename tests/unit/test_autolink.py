@@ -0,0 +1,81 @@
+from __future__ import annotations
+
+from typing import Any
+
+from docgenie.autolink import autolink_docstrings
+from docgenie.generator import ReadmeGenerator
+
+
+def _api_docs() -> dict[str, Any]:
+    return {
+        "functions": [
+            {
+                "name": "NewServer",
+                "docstring": "Build a server; see UserService and `UserService`.",
+            },
+            {"name": "run", "docstring": "Call NewServer, then run the loop. See also stop()."},
+            {"name": "stop", "docstring": "Stop what run() started."},
+        ],
+        "classes": [
+            {"name": "UserService", "docstring": "UserService serves users. Made by NewServer."},
+        ],
+    }
+
+
+def test_links_known_symbols_but_not_self_or_code() -> None:
+    api_docs = _api_docs()
+    assert autolink_docstrings(api_docs) == 5
+
+    new_server, run, stop = api_docs["functions"]
+    service = api_docs["classes"][0]
+    assert new_server["docstring"] == (
+        "Build a server; see [UserService](#api-userservice) and `UserService`."
+    )
+    assert run["docstring"] == (
+        "Call [NewServer](#api-newserver), then run the loop. See also [stop()](#api-stop)."
+    )
+    assert stop["docstring"] == "Stop what [run()](#api-run) started."
+    assert service["docstring"] == "UserService serves users. Made by [NewServer](#api-newserver)."
+    assert service["anchor"] == "api-userservice"
+
+
+def test_adoc_links_and_unknown_names() -> None:
+    api_docs = _api_docs()
+    api_docs["functions"][0]["docstring"] = "Wraps UserService, unlike OrderService."
+    autolink_docstrings(api_docs, output_format="adoc")
+    assert api_docs["functions"][0]["docstring"] == (
+        "Wraps <<api-userservice,UserService>>, unlike OrderService."
+    )
+
+
+def test_readme_autolink_is_opt_in() -> None:
+    analysis = {
+        "project_name": "demo",
+        "files_analyzed": 1,
+        "languages": {"go": 1},
+        "main_language": "go",
+        "functions": [
+            {"name": "Serve", "file": "s.go", "line": 1, "args": [], "docstring": "Uses Store."}
+        ],
+        "classes": [{"name": "Store", "file": "s.go", "line": 5, "docstring": "Keeps data."}],
+        "config": {"template_customizations": {"autolink": False}},
+    }
+    assert "Uses Store.\n" in ReadmeGenerator().generate(analysis, None)
+
+    analysis["config"] = {"template_customizations": {"autolink": True}}
+    readme = ReadmeGenerator().generate(analysis, None)
+    assert "Uses [Store](#api-store)." in readme
+    assert '<a id="api-store"></a>' in readme
+
+
+def test_missing_anchors_do_not_reuse_existing_ones() -> None:
+    api_docs = {
+        "functions": [
+            {"name": "stop", "anchor": "api-stop", "docstring": "Undo Stop."},
+            {"name": "Stop", "docstring": "Call stop() first."},
+        ],
+        "classes": [],
+    }
+    assert autolink_docstrings(api_docs) == 2
+    assert api_docs["functions"][0]["docstring"] == "Undo [Stop](#api-stop-2)."
+    assert api_docs["functions"][1]["docstring"] == "Call [stop()](#api-stop) first."
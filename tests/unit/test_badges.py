@@ -0,0 +1,108 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_sections import badges_html
+from docgenie.licenses import detect_license
+
+MIT_TEXT = """MIT License
+
+Copyright (c) 2026 Example
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files...
+"""
+
+
+def _analysis(**extra: object) -> dict[str, object]:
+    return {
+        "project_name": "svc",
+        "root_path": ".",
+        "files_analyzed": 4,
+        "languages": {"go": 1, "python": 3},
+        "functions": [{"name": "run"}, {"name": "_hidden"}],
+        "classes": [{"name": "Server"}],
+        "license": {"spdx_id": "Apache-2.0", "file": "LICENSE"},
+        **extra,
+    }
+
+
+def test_detect_license_from_text_and_spdx_header(tmp_path: Path) -> None:
+    assert detect_license(tmp_path) == {}
+
+    (tmp_path / "LICENSE").write_text(MIT_TEXT, encoding="utf-8")
+    assert detect_license(tmp_path) == {"spdx_id": "MIT", "file": "LICENSE"}
+
+    (tmp_path / "LICENSE").write_text(
+        "SPDX-License-Identifier: BSD-3-Clause\n\nCopyright...", encoding="utf-8"
+    )
+    assert detect_license(tmp_path)["spdx_id"] == "BSD-3-Clause"
+
+    (tmp_path / "LICENSE").unlink()
+    (tmp_path / "COPYING").write_text(
+        "GNU GENERAL PUBLIC LICENSE\n   Version 3, 29 June 2007\n", encoding="utf-8"
+    )
+    assert detect_license(tmp_path) == {"spdx_id": "GPL-3.0", "file": "COPYING"}
+
+
+def test_build_badges_escapes_shields_urls_and_reports_language_breakdown() -> None:
+    badges = ReadmeGenerator()._build_badges(_analysis(), quality_score=72)
+
+    assert [(badge["label"], badge["message"], badge["color"]) for badge in badges] == [
+        ("license", "Apache-2.0", "blue"),
+        ("language", "python 75%", "informational"),
+        ("public symbols", "2", "informational"),
+        ("docs quality", "72/100", "yellow"),
+    ]
+    license_badge, language, symbols, _quality = badges
+    assert license_badge["url"] == "https://img.shields.io/badge/license-Apache--2.0-blue"
+    assert license_badge["link"] == "LICENSE"
+    assert language["title"] == "Files by language: python 75%, go 25%"
+    assert symbols["url"] == "https://img.shields.io/badge/public%20symbols-2-informational"
+
+
+def test_badges_honor_config_switches() -> None:
+    generator = ReadmeGenerator()
+    disabled = {"template_customizations": {"include_badges": False}}
+    assert generator.badges(_analysis(config=disabled)) == []
+
+    no_quality = generator.badges(_analysis(config={"quality": {"confidence_enabled": False}}))
+    assert [badge["label"] for badge in no_quality] == ["license", "language", "public symbols"]
+
+    bare = generator._build_badges(
+        _analysis(license={}, languages={}, functions=[], classes=[]), quality_score=None
+    )
+    assert [(badge["label"], badge["message"]) for badge in bare] == [("public symbols", "0")]
+
+
+def test_badges_html_renders_styled_spans() -> None:
+    badges = ReadmeGenerator()._build_badges(_analysis(), quality_score=90)
+
+    rendered = badges_html(badges)
+    assert rendered.startswith('<p class="badges">')
+    assert (
+        '<a class="badge" href="LICENSE" title="license: Apache-2.0">'
+        '<span class="badge-label">license</span>'
+        '<span class="badge-message badge-blue">Apache-2.0</span></a>'
+    ) in rendered
+    assert '<span class="badge-message badge-brightgreen">90/100</span>' in rendered
+    assert "img.shields.io" not in rendered
+    assert badges_html([]) == ""
+
+
+def test_readme_renders_badge_block_under_title(tmp_path: Path) -> None:
+    (tmp_path / "LICENSE").write_text(MIT_TEXT, encoding="utf-8")
+    (tmp_path / "app.py").write_text("def run():\n    return 1\n", encoding="utf-8")
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert result["license"] == {"spdx_id": "MIT", "file": "LICENSE"}
+
+    content = ReadmeGenerator().generate(result)
+    assert (
+        '[![license: MIT](https://img.shields.io/badge/license-MIT-blue "license: MIT")](LICENSE)'
+        in content
+    )
+    assert "![language: python 100%]" in content
+    assert content.index("![public symbols: 1]") < content.index("## ")
+    assert "docs quality" not in ReadmeGenerator().generate(result, include_badges=False)
@@ -0,0 +1,227 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import CHeaderParser
+from docgenie.parsers import ParserRegistry, RegexParser
+from docgenie.readme_quality import docstring_coverage
+
+SAMPLE = """#ifndef MYLIB_H
+#define MYLIB_H
+
+#include <stddef.h>
+#include "mylib/config.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/** Maximum buffer size in bytes. */
+#define MYLIB_MAX_BUF 4096
+
+/// Return the larger of two values.
+#define MYLIB_MAX(a, b) ((a) > (b) ? (a) : (b))
+
+#define _MYLIB_INTERNAL 1
+
+/**
+ * A 2D point.
+ */
+typedef struct {
+    int x; ///< Horizontal offset.
+    int y;
+} mylib_point;
+
+/** Opaque handle. */
+typedef struct mylib_ctx mylib_ctx;
+
+/// Callback invoked on every event.
+typedef void (*mylib_callback)(int event, void *user);
+
+/** Colour options. */
+enum mylib_color { MYLIB_RED, MYLIB_GREEN };
+
+struct mylib_forward;
+
+/**
+ * Create a context.
+ * @param size buffer size
+ */
+mylib_ctx *mylib_create(size_t size,
+                        mylib_callback cb);
+
+/** Destroy a context. */
+void mylib_destroy(mylib_ctx *ctx);
+
+#ifdef _WIN32
+/** Windows-only init. */
+int mylib_init(void);
+#else
+int mylib_init(void);
+#endif
+int mylib_version(void);
+
+/** Internal helper. */
+static inline int mylib_clamp(int v) { return v < 0 ? 0 : v; }
+
+__attribute__((deprecated)) int mylib_old(int, unsigned long);
+
+#if 0
+int mylib_disabled(void);
+#endif
+
+extern int (*mylib_hook)(int);
+void mylib_set_handler(void (*handler)(int code));
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* MYLIB_H */
+"""
+
+
+def _parse():
+    return CHeaderParser().parse(SAMPLE, Path("mylib.h"), "c")
+
+
+def test_header_parser_only_claims_headers() -> None:
+    registry = ParserRegistry(enable_tree_sitter=False)
+    assert isinstance(registry.resolve("c", Path("mylib.h")), CHeaderParser)
+    assert isinstance(registry.resolve("cpp", Path("widget.hpp")), CHeaderParser)
+    assert isinstance(registry.resolve("c", Path("mylib.c")), RegexParser)
+
+
+def test_header_prototypes_exclude_static_and_pointers() -> None:
+    functions = {func.name: func for func in _parse().functions if func.kind == "function"}
+    assert list(functions) == [
+        "mylib_create",
+        "mylib_destroy",
+        "mylib_init",
+        "mylib_version",
+        "mylib_old",
+        "mylib_set_handler",
+    ]
+    create = functions["mylib_create"]
+    assert create.args == ["size", "cb"]
+    assert create.signature == "mylib_ctx *mylib_create(size_t size, mylib_callback cb)"
+    assert create.docstring == "Create a context.\n@param size buffer size"
+    assert functions["mylib_old"].args == []
+    assert functions["mylib_old"].decorators == ["deprecated"]
+    assert functions["mylib_set_handler"].args == ["handler"]
+
+
+def test_header_comments_follow_preprocessor_branches() -> None:
+    functions = {func.name: func for func in _parse().functions}
+    # The comment above `#ifdef` documents the first branch; `#endif` ends the pairing.
+    assert functions["mylib_init"].docstring == "Windows-only init."
+    assert functions["mylib_init"].line == 50
+    assert functions["mylib_version"].docstring is None
+    assert "mylib_disabled" not in functions
+
+
+def test_header_macros_and_types() -> None:
+    result = _parse()
+    macros = {func.name: func for func in result.functions if func.kind == "macro"}
+    assert set(macros) == {"MYLIB_MAX_BUF", "MYLIB_MAX"}
+    assert macros["MYLIB_MAX"].args == ["a", "b"]
+    assert macros["MYLIB_MAX"].signature == "#define MYLIB_MAX(a, b)"
+    assert macros["MYLIB_MAX_BUF"].docstring == "Maximum buffer size in bytes."
+
+    classes = {cls.name: cls for cls in result.classes}
+    assert {name: cls.kind for name, cls in classes.items()} == {
+        "mylib_point": "struct",
+        "mylib_ctx": "typedef",
+        "mylib_callback": "typedef",
+        "mylib_color": "enum",
+    }
+    point = classes["mylib_point"]
+    assert point.docstring == "A 2D point."
+    assert [(field.name, field.type, field.docstring) for field in point.fields] == [
+        ("x", "int", "Horizontal offset."),
+        ("y", "int", None),
+    ]
+    assert classes["mylib_callback"].signature == (
+        "typedef void (*mylib_callback)(int event, void *user)"
+    )
+    assert result.imports == {"stddef.h", "mylib/config.h"}
+
+
+def test_header_symbols_feed_quality_scoring(tmp_path: Path) -> None:
+    (tmp_path / "mylib.h").write_text(SAMPLE, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    names = {func["name"] for func in analysis["functions"]}
+    assert "mylib_create" in names
+    assert "mylib_clamp" not in names
+    assert 0 < docstring_coverage(analysis["functions"], analysis["classes"]) < 1
+
+
+CPP_SAMPLE = """#pragma once
+
+namespace gfx {
+
+/// A drawable shape.
+class Widget : public Base {
+public:
+    /// Build a widget.
+    explicit Widget(int size) : size_(size) {}
+    virtual ~Widget();
+
+    /** Draw onto `canvas`. */
+    void draw(Canvas &canvas) const override;
+    int size() const { return size_; }
+    bool operator==(const Widget &other) const;
+    operator bool() const;
+    friend void swap(Widget &a, Widget &b);
+    int (*hook)(int);
+
+protected:
+    void resize(int size);
+
+private:
+    int size_ = 0;
+};
+
+struct Point {
+    int x;
+    /// Length from the origin.
+    double length() const;
+private:
+    void normalize();
+};
+
+}  // namespace gfx
+"""
+
+
+def test_cpp_class_bodies_yield_member_functions() -> None:
+    result = CHeaderParser().parse(CPP_SAMPLE, Path("widget.hpp"), "cpp")
+    classes = {cls.name: cls for cls in result.classes}
+    widget = classes["Widget"]
+    assert [(method.name, method.kind) for method in widget.methods] == [
+        ("Widget", "constructor"),
+        ("~Widget", "method"),
+        ("draw", "method"),
+        ("size", "method"),
+    ]
+    constructor, _, draw, _ = widget.methods
+    assert constructor.signature == "explicit Widget(int size)"
+    assert constructor.args == ["size"]
+    assert constructor.docstring == "Build a widget."
+    assert draw.signature == "void draw(Canvas &canvas) const override"
+    assert draw.docstring == "Draw onto `canvas`."
+    assert draw.line == 13  # noqa: PLR2004
+    # Struct members are public until an access label says otherwise.
+    assert [method.name for method in classes["Point"].methods] == ["length"]
+    assert [field.name for field in classes["Point"].fields] == ["x"]
+    assert result.functions == []
+
+
+def test_cpp_private_members_follow_visibility() -> None:
+    parser = CHeaderParser()
+    parser.include_private = True
+    widget = parser.parse(CPP_SAMPLE, Path("widget.hpp"), "cpp").classes[0]
+    private = {method.name: method.private for method in widget.methods}
+    assert private["draw"] is False
+    assert private["resize"] is True
@@ -0,0 +1,77 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_sections import build_caller_index, invert_edges, symbol_node_id
+
+
+def _analysis(root: Path) -> dict[str, Any]:
+    return {
+        "root_path": str(root),
+        "functions": [
+            {"name": "helper", "file": str(root / "lib.py"), "line": 1},
+            {"name": "main", "file": str(root / "app.py"), "line": 3},
+        ],
+        "classes": [
+            {"name": "Base", "file": str(root / "lib.py"), "line": 5, "bases": []},
+            {"name": "Child", "file": str(root / "app.py"), "line": 8, "bases": ["Base"]},
+        ],
+        "file_imports": {"app.py": ["lib.py"], "lib.py": [], "cli.py": []},
+        "symbol_references": {"app.py": ["helper"], "cli.py": ["helper", "main"]},
+    }
+
+
+def test_invert_edges_skips_defines() -> None:
+    edges = [
+        {"source": "file:a.py", "target": "symbol:a.py::f", "kind": "defines"},
+        {"source": "file:b.py", "target": "symbol:a.py::f", "kind": "references"},
+        {"source": "file:c.py", "target": "symbol:a.py::f", "kind": "references"},
+        {"source": "file:b.py", "target": "file:a.py", "kind": "import"},
+    ]
+
+    inbound = invert_edges(edges)
+
+    assert [edge["source"] for edge in inbound["symbol:a.py::f"]] == ["file:b.py", "file:c.py"]
+    assert [edge["source"] for edge in inbound["file:a.py"]] == ["file:b.py"]
+
+
+def test_build_caller_index_inverts_simple_call_graph(tmp_path: Path) -> None:
+    index = build_caller_index(_analysis(tmp_path))
+
+    assert index[symbol_node_id("lib.py", "helper")] == {
+        "callers": [
+            {"label": "app.py", "module": "", "kind": "references"},
+            {"label": "cli.py", "module": "", "kind": "references"},
+        ],
+        "total": 2,
+        "truncated": False,
+    }
+    assert index[symbol_node_id("lib.py", "Base")]["callers"] == [
+        {"label": "Child", "module": "app.py", "kind": "extends"}
+    ]
+    assert symbol_node_id("app.py", "Child") not in index
+
+
+def test_build_caller_index_truncates_with_total(tmp_path: Path) -> None:
+    entry = build_caller_index(_analysis(tmp_path), max_callers=1)[
+        symbol_node_id("lib.py", "helper")
+    ]
+
+    assert [caller["label"] for caller in entry["callers"]] == ["app.py"]
+    assert entry["total"] == 2  # noqa: PLR2004
+    assert entry["truncated"] is True
+
+
+def test_api_docs_carry_called_by(tmp_path: Path) -> None:
+    data = _analysis(tmp_path)
+    data["config"] = {"template_customizations": {"max_callers": 1}}
+
+    context = ReadmeGenerator()._prepare_context(data)
+
+    helper = next(doc for doc in context["api_docs"]["functions"] if doc["name"] == "helper")
+    assert helper["called_by"]["total"] == 2  # noqa: PLR2004
+    assert len(helper["called_by"]["callers"]) == 1
+    child = next(doc for doc in context["api_docs"]["classes"] if doc["name"] == "Child")
+    assert child["called_by"] is None
@@ -0,0 +1,115 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.complexity import complexity_level, complexity_warnings
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import mark_complexity_cells
+from docgenie.module_index import build_module_index
+from docgenie.parsers import ParserRegistry
+
+GO_SOURCE = """package calc
+
+// Sign classifies n.
+func Sign(n int) string {
+\t// if this comment counted, so would "for" in the string below
+\tlabel := "if for case"
+\tif n > 0 && n < 100 || n == 1000 {
+\t\treturn "positive"
+\t}
+\tfor i := 0; i < n; i++ {
+\t}
+\tswitch n {
+\tcase 0:
+\t\treturn "zero"
+\tcase -1:
+\t\treturn "minus one"
+\t}
+\treturn label
+}
+
+func Zero() int { return 0 }
+"""
+
+PYTHON_SOURCE = '''def simple(x):
+    """Return x; if it were a branch, this docstring would count."""
+    return x
+
+
+def branchy(items):
+    total = 0
+    for item in items:
+        if item and item.ready or item.forced:
+            total += 1
+        elif item is None:
+            continue
+    try:
+        squares = [i * i for i in items if i]
+    except TypeError:
+        squares = []
+
+    def nested():
+        if total:
+            return 1
+
+    return total if squares else 0
+'''
+
+
+def _complexities(source: str, name: str, language: str) -> dict[str, int | None]:
+    parsed = ParserRegistry(enable_tree_sitter=False).parse(source, Path(name), language)
+    return {func.name: func.complexity for func in parsed.functions}
+
+
+def test_go_branch_points_skip_comments_and_strings() -> None:
+    # if, &&, ||, for and two case arms.
+    assert _complexities(GO_SOURCE, "calc.go", "go") == {"Sign": 7, "Zero": 1}
+
+
+def test_python_complexity_comes_from_the_ast() -> None:
+    found = _complexities(PYTHON_SOURCE, "calc.py", "python")
+
+    assert found["simple"] == 1
+    # for, if, and/or, elif, comprehension loop and filter, except, conditional expression;
+    # the nested function's `if` belongs to `nested` only.
+    assert found["branchy"] == 10  # noqa: PLR2004
+    assert found["nested"] == 2  # noqa: PLR2004
+
+
+def test_module_table_and_warning_use_complexity(tmp_path: Path) -> None:
+    (tmp_path / "calc.go").write_text(GO_SOURCE, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    (module,) = build_module_index(analysis)
+    assert module["has_complexity"] is True
+    assert {sym["name"]: sym["complexity"] for sym in module["symbols"]} == {
+        "Sign": 7,
+        "Zero": 1,
+    }
+    assert complexity_warnings(analysis) == []
+    analysis["config"] = {"quality": {"complexity_threshold": 5}}
+    assert complexity_warnings(analysis) == [
+        "1 function exceeds estimated complexity 5: `Sign` (calc.go:4, 7)"
+    ]
+
+
+def test_html_complexity_cells_are_colored_by_level() -> None:
+    assert [complexity_level(n) for n in (1, 10, 11, 20, 21)] == [
+        "low",
+        "low",
+        "moderate",
+        "moderate",
+        "high",
+    ]
+    table = (
+        "<table>\n<thead>\n<tr>\n<th>Symbol</th>\n<th>Complexity</th>\n</tr>\n</thead>\n"
+        "<tbody>\n<tr>\n<td><code>Sign</code></td>\n<td>24</td>\n</tr>\n"
+        "<tr>\n<td><code>Calc</code></td>\n<td>-</td>\n</tr>\n</tbody>\n</table>"
+    )
+    marked = mark_complexity_cells(table)
+
+    assert '<td class="complexity complexity-high">24</td>' in marked
+    assert "<td>-</td>" in marked
+    # Tables without a Complexity column are untouched.
+    other = table.replace("Complexity", "Line")
+    assert mark_complexity_cells(other) == other
@@ -0,0 +1,66 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import yaml
+
+from docgenie.config import get_default_config, load_config
+from docgenie.config_schema import config_json_schema, validate_config
+
+GOOD_CONFIG = """
+ignore_patterns: ["*.tmp"]
+analysis:
+  parallelism: 4
+  visibility: all
+template_customizations:
+  template_dir: templates
+  sort_symbols: kind
+coverage:
+  file: coverage.xml
+  threshold: 0.8
+quality:
+  score_weights:
+    docstrings: 12.5
+    examples: 5
+"""
+
+BAD_CONFIG = """
+ignore_patterns: "*.tmp"
+analysis:
+  incremental: "yes"
+  parallelism: 0
+  visibilty: all
+template_customizations:
+  group_by: folder
+quality:
+  score_weights:
+    docstrings: -5
+"""
+
+
+def test_schema_mirrors_default_config() -> None:
+    schema = config_json_schema()
+
+    assert schema["$schema"] == "https://json-schema.org/draft/2020-12/schema"
+    assert schema["properties"].keys() == get_default_config().keys()
+    analysis = schema["properties"]["analysis"]
+    assert analysis["additionalProperties"] is False
+    assert analysis["properties"]["incremental"] == {"type": "boolean"}
+    assert analysis["properties"]["visibility"] == {"enum": ["public", "all"]}
+    coverage = schema["properties"]["coverage"]["properties"]
+    assert coverage["threshold"] == {"type": ["number", "null"]}
+    assert validate_config(get_default_config(), schema) == []
+
+
+def test_schema_validates_good_and_rejects_bad_config(tmp_path: Path) -> None:
+    (tmp_path / ".docgenie.yaml").write_text(GOOD_CONFIG, encoding="utf-8")
+    assert validate_config(load_config(tmp_path)) == []
+
+    assert validate_config(yaml.safe_load(BAD_CONFIG)) == [
+        "`ignore_patterns` must be a list of strings, got string",
+        "`analysis.incremental` must be a boolean, got string",
+        "`analysis.parallelism` must be 'auto' or an integer >= 1, got 0",
+        "Unknown key `analysis.visibilty`",
+        "`template_customizations.group_by` must be one of file, package, got 'folder'",
+        "`quality.score_weights.docstrings` must be at least 0, got -5",
+    ]
@@ -0,0 +1,122 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import GoParser
+from docgenie.module_index import CONSTANT_VALUE_LIMIT, build_module_index
+
+GO_SOURCE = """package color
+
+// Color is a palette entry.
+type Color int
+
+const (
+	// Red is the zero Color.
+	Red Color = iota
+	Green // Second in line.
+	_
+	Blue
+)
+
+const (
+	_  = iota
+	KB = 1 << (10 * iota)
+	MB
+)
+
+// Version is the current release.
+const Version = "1.2.3"
+
+const (
+	Pi         = 3.14
+	Name, Tag  = "a,b", 'x'
+	HalfLimit  = maxSize / 2
+	maxSize    = 64
+)
+"""
+
+
+def test_go_iota_block_is_grouped_and_numbered() -> None:
+    result = GoParser().parse(GO_SOURCE, Path("color.go"), "go")
+    constants = {const.name: const for const in result.constants}
+
+    assert [
+        (const.name, const.value, const.type, const.group, const.index)
+        for const in result.constants[:5]
+    ] == [
+        ("Red", "0", "Color", "Color", 0),
+        ("Green", "1", "Color", "Color", 1),
+        ("Blue", "3", "Color", "Color", 3),
+        ("KB", "1024", "int", "KB", 1),
+        ("MB", "1048576", "int", "KB", 2),
+    ]
+    assert constants["Red"].docstring == "Red is the zero Color."
+    assert constants["Green"].docstring == "Second in line."
+    assert constants["Blue"].expression == "iota"
+    assert (constants["Version"].value, constants["Version"].type) == ('"1.2.3"', "string")
+    assert constants["Version"].docstring == "Version is the current release."
+    assert [(constants[n].value, constants[n].type) for n in ("Pi", "Name", "Tag")] == [
+        ("3.14", "float64"),
+        ('"a,b"', "string"),
+        ("'x'", "rune"),
+    ]
+    half = constants["HalfLimit"]
+    assert (half.expression, half.value, half.type, half.group) == ("maxSize / 2", None, None, None)
+    assert "maxSize" not in constants
+
+
+def test_constants_section_per_module(tmp_path: Path) -> None:
+    (tmp_path / "color.go").write_text(GO_SOURCE, encoding="utf-8")
+    (tmp_path / "settings.py").write_text(
+        "MAX_RETRIES = 3  # Attempts before giving up.\n"
+        "TIMEOUT: float = 2.5\n"
+        '"""Seconds to wait for a reply."""\n'
+        "CACHE_DIR = Path.home() / '.cache'\n"
+        "_SECRET = 'x'\n"
+        "lowercase = 1\n",
+        encoding="utf-8",
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert [item["name"] for item in analysis["constants"]["settings.py"]] == [
+        "MAX_RETRIES",
+        "TIMEOUT",
+        "CACHE_DIR",
+        "_SECRET",
+    ]
+    modules = {module["path"]: module for module in build_module_index(analysis)}
+    assert modules["settings.py"]["symbols"] == []
+    [settings] = modules["settings.py"]["constant_groups"]
+    assert [
+        (row["name"], row["type"], row["value"], row["summary"]) for row in settings["constants"]
+    ] == [
+        ("MAX_RETRIES", "int", "3", "Attempts before giving up."),
+        ("TIMEOUT", "float", "2.5", "Seconds to wait for a reply."),
+        ("CACHE_DIR", None, "Path.home() / '.cache'", ""),
+    ]
+    groups = modules["color.go"]["constant_groups"]
+    assert [group["name"] for group in groups] == [None, "Color", "KB"]
+    assert [(row["index"], row["name"], row["value"]) for row in groups[1]["constants"]] == [
+        (0, "Red", "0"),
+        (1, "Green", "1"),
+        (3, "Blue", "3"),
+    ]
+
+
+def test_constant_values_keep_backticks_inside_their_code_span(tmp_path: Path) -> None:
+    (tmp_path / "markup.py").write_text(
+        'FENCE = "```"\n'
+        'TICK = "`"\n'
+        "BANNER = 'Welcome to the service, please read the docs before calling any endpoint'\n",
+        encoding="utf-8",
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    [group] = build_module_index(analysis)[0]["constant_groups"]
+    rows = {row["name"]: row for row in group["constants"]}
+    assert rows["FENCE"]["value_span"] == '````"```"````'
+    assert rows["TICK"]["value_span"] == '``"`"``'
+    banner = rows["BANNER"]["value"]
+    assert banner.endswith("...") and len(banner) <= CONSTANT_VALUE_LIMIT
+    assert banner == "'Welcome to the service, please read the docs before..."
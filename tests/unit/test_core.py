@@ -4,6 +4,7 @@ from pathlib import Path
 
 import pytest
 
+from docgenie import core
 from docgenie.core import CacheManager, CodebaseAnalyzer, _hash_file
 
 
@@ -56,7 +57,7 @@ def test_cache_manager_invalidation(tmp_path: Path) -> None:
 
 def test_cache_manager_corrupted_file(tmp_path: Path) -> None:
     """Test graceful handling of corrupted cache."""
-    cache_file = tmp_path / ".docgenie" / "cache.json"
+    cache_file = tmp_path / ".docgenie" / "index.json"
     cache_file.parent.mkdir(exist_ok=True)
     cache_file.write_text("invalid json {{{", encoding="utf-8")
 
@@ -65,6 +66,63 @@ def test_cache_manager_corrupted_file(tmp_path: Path) -> None:
     assert cache._data == {}
 
 
+def test_cache_manager_prune_and_relocation(tmp_path: Path) -> None:
+    """Test pruning deleted entries and a custom cache directory."""
+    cache_dir = tmp_path / "cache"
+    cache = CacheManager(tmp_path, cache_dir)
+    cache.set(Path("kept.py"), "h1", {"functions": []}, "python")
+    cache.set(Path("gone.py"), "h2", {"functions": []}, "python")
+
+    assert cache.prune(["kept.py"]) == ["gone.py"]
+    cache.persist()
+    assert (cache_dir / "index.json").exists()
+    assert not (tmp_path / ".docgenie" / "index.json").exists()
+
+    # An index written for another root is discarded
+    other = CacheManager(tmp_path / "elsewhere", cache_dir)
+    assert other.get(Path("kept.py"), "h1") is None
+
+
+def test_cache_manager_discards_index_from_other_docgenie_release(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    """Test that upgrading DocGenie re-parses files cached by the previous release."""
+    cache = CacheManager(tmp_path)
+    cache.set(Path("a.py"), "h1", {"functions": []}, "python")
+    cache.persist()
+    assert CacheManager(tmp_path).get(Path("a.py"), "h1") is not None
+
+    monkeypatch.setattr(core, "__version__", "99.0.0")
+    assert CacheManager(tmp_path).get(Path("a.py"), "h1") is None
+
+
+def test_analyzer_incremental_runs(tmp_path: Path) -> None:
+    """Test that unchanged files are served from the index and deleted ones pruned."""
+    (tmp_path / "a.py").write_text("def alpha():\n    pass\n", encoding="utf-8")
+    (tmp_path / "b.py").write_text("def beta():\n    pass\n", encoding="utf-8")
+    first = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert first["run_metrics"]["cache_hits"] == 0
+    assert first["run_metrics"]["changed_files"] == 2
+
+    (tmp_path / "b.py").unlink()
+    (tmp_path / "a.py").write_text("def alpha():\n    pass\n", encoding="utf-8")
+    (tmp_path / "c.py").write_text("def gamma():\n    pass\n", encoding="utf-8")
+    second = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    metrics = second["run_metrics"]
+    assert metrics["scanned_files"] == 2
+    assert metrics["cache_hits"] == 1
+    assert metrics["changed_files"] == 1
+    assert metrics["pruned_files"] == 1
+    assert metrics["cache_hit_ratio"] == 0.5
+    assert sorted(f["name"] for f in second["functions"]) == ["alpha", "gamma"]
+
+    full = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"analysis": {"incremental": False}}
+    ).analyze()
+    assert full["run_metrics"]["cache_hits"] == 0
+    assert full["run_metrics"]["changed_files"] == 2
+
+
 def test_analyzer_basic_python_project(tmp_path: Path) -> None:
     """Test analyzing a simple Python project."""
     # Create a minimal project
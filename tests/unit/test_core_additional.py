@@ -2,22 +2,26 @@ from __future__ import annotations
 
 import builtins
 import json
+from datetime import datetime
 from pathlib import Path
 
 import pytest
 
-from docgenie import core
+from docgenie import core, generator
+from docgenie.cli import _analysis_overrides
 from docgenie.core import CodebaseAnalyzer, _analyze_file_task
+from docgenie.generator import ReadmeGenerator
 
 
 def test_analyze_file_task_handles_no_language_and_permission(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -> None:
     unknown = tmp_path / "x.unknown"
     unknown.write_text("x", encoding="utf-8")
-    p, lang, parsed, digest = _analyze_file_task((str(unknown), [], False))
+    p, lang, parsed, digest, error = _analyze_file_task((str(unknown), [], False))
     assert p == str(unknown)
     assert lang == ""
     assert parsed is None
     assert digest == ""
+    assert error == ""
 
     py = tmp_path / "a.py"
     py.write_text("def x():\n    return 1\n", encoding="utf-8")
@@ -26,11 +30,12 @@ def test_analyze_file_task_handles_no_language_and_permission(monkeypatch: pytes
         raise PermissionError("no")
 
     monkeypatch.setattr(builtins, "open", raise_perm)
-    p2, lang2, parsed2, digest2 = _analyze_file_task((str(py), [], False))
+    p2, lang2, parsed2, digest2, error2 = _analyze_file_task((str(py), [], False))
     assert p2 == str(py)
     assert lang2 == "python"
     assert parsed2 is None
     assert digest2 == ""
+    assert error2 == "read_error"
 
 
 def test_apply_parsed_data_unknown_language(tmp_path: Path) -> None:
@@ -175,7 +180,10 @@ def test_dependency_parsers_and_detect_dependencies(tmp_path: Path, monkeypatch:
 
 def test_analyze_with_mocked_process_pool(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -> None:
     (tmp_path / "a.py").write_text("def f():\n    return 1\n", encoding="utf-8")
-    analyzer = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False)
+    (tmp_path / "b.py").write_text("def g():\n    return 2\n", encoding="utf-8")
+    analyzer = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"analysis": {"parallelism": 2}}
+    )
 
     class DummyFuture:
         def __init__(self, result):
@@ -185,6 +193,9 @@ def test_analyze_with_mocked_process_pool(monkeypatch: pytest.MonkeyPatch, tmp_p
             return self._result
 
     class DummyExecutor:
+        def __init__(self, max_workers=None):
+            assert max_workers == 2
+
         def __enter__(self):
             return self
 
@@ -200,3 +211,61 @@ def test_analyze_with_mocked_process_pool(monkeypatch: pytest.MonkeyPatch, tmp_p
     result = analyzer.analyze()
     assert result["files_analyzed"] >= 1
     assert result["website_detection_reason"]
+
+
+def test_parse_errors_are_collected_not_raised(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -> None:
+    (tmp_path / "good.py").write_text("def ok():\n    return 1\n", encoding="utf-8")
+    (tmp_path / "bad.py").write_text("def boom():\n    return 2\n", encoding="utf-8")
+    real_parse = core.ParserRegistry.parse
+
+    def flaky_parse(self, content, file_path, language):
+        if file_path.name == "bad.py":
+            raise RuntimeError("parser crashed")
+        return real_parse(self, content, file_path, language)
+
+    monkeypatch.setattr(core.ParserRegistry, "parse", flaky_parse)
+    result = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"analysis": {"parallelism": 1}}
+    ).analyze()
+    assert [f["name"] for f in result["functions"]] == ["ok"]
+    assert result["skipped_reasons"]["parse_error"] == 1
+
+
+def test_readme_is_identical_for_any_job_count(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -> None:
+    for idx in range(12):
+        pkg = tmp_path / f"pkg{idx % 3}"
+        pkg.mkdir(exist_ok=True)
+        (pkg / f"mod{idx}.py").write_text(
+            f"import os\n\n\nclass Service{idx}:\n    def run(self):\n        return {idx}\n\n\n"
+            f"def helper_{idx}(value):\n    return value\n",
+            encoding="utf-8",
+        )
+
+    class FrozenDatetime:
+        @staticmethod
+        def now():
+            return datetime(2024, 1, 1)
+
+    monkeypatch.setattr(core.time, "perf_counter", lambda: 0.0)
+    monkeypatch.setattr(generator, "datetime", FrozenDatetime)
+
+    readmes = []
+    for jobs in (1, 8):
+        analysis_config = _analysis_overrides(incremental=False, cache_dir=None, jobs=jobs)
+        assert analysis_config["parallelism"] == jobs
+        analyzer = CodebaseAnalyzer(
+            str(tmp_path), enable_tree_sitter=False, config={"analysis": analysis_config}
+        )
+        assert analyzer.jobs == jobs
+        readmes.append(ReadmeGenerator().generate(analyzer.analyze(), None).encode("utf-8"))
+    assert readmes[0] == readmes[1]
+
+
+def test_full_run_creates_no_cache_directory(tmp_path: Path) -> None:
+    (tmp_path / "m.py").write_text("def x():\n    return 1\n", encoding="utf-8")
+    config = {"analysis": _analysis_overrides(incremental=False, cache_dir=None)}
+
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    assert [func["name"] for func in result["functions"]] == ["x"]
+    assert not (tmp_path / ".docgenie").exists()
@@ -0,0 +1,132 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.coverage import build_coverage, coverage_badge, coverage_warnings
+from docgenie.exceptions import ConfigError
+from docgenie.generator import ReadmeGenerator
+
+GO_PROFILE = """mode: set
+github.com/acme/svc/users/users.go:10.2,12.3 3 1
+github.com/acme/svc/users/users.go:14.2,16.3 1 0
+github.com/acme/svc/users/users.go:14.2,16.3 1 1
+github.com/acme/svc/billing/invoice.go:5.1,9.2 4 0
+github.com/acme/other/vendor.go:1.1,2.2 2 1
+"""
+
+COBERTURA = """<?xml version="1.0" ?>
+<coverage line-rate="0.5">
+  <sources><source>/build/repo/src</source></sources>
+  <packages><package name="app"><classes>
+    <class name="core" filename="/build/repo/src/app/core.py">
+      <lines><line number="1" hits="3"/><line number="2" hits="0"/></lines>
+    </class>
+    <class name="util" filename="app/util.py">
+      <lines><line number="4" hits="1"/></lines>
+    </class>
+  </classes></package></packages>
+</coverage>
+"""
+
+
+def test_go_profile_is_attributed_to_repository_files(tmp_path: Path) -> None:
+    report = tmp_path / "coverage.out"
+    report.write_text(GO_PROFILE, encoding="utf-8")
+
+    coverage = build_coverage(report, ["users/users.go", "billing/invoice.go"], threshold=50)
+
+    assert coverage["format"] == "go"
+    assert coverage["modules"] == {
+        "billing/invoice.go": {"covered": 0, "total": 4, "percent": 0.0},
+        "users/users.go": {"covered": 4, "total": 4, "percent": 100.0},
+    }
+    assert (coverage["covered"], coverage["total"], coverage["percent"]) == (4, 8, 50.0)
+    assert coverage["unmatched"] == 1
+    assert coverage_warnings(coverage) == [
+        "Test coverage for `billing/invoice.go` is 0.0%, below the 50% threshold."
+    ]
+
+
+def test_cobertura_report_strips_sources(tmp_path: Path) -> None:
+    report = tmp_path / "coverage.xml"
+    report.write_text(COBERTURA, encoding="utf-8")
+
+    coverage = build_coverage(report, ["src/app/core.py", "src/app/util.py"])
+
+    assert coverage["format"] == "cobertura"
+    assert coverage["modules"]["src/app/core.py"]["percent"] == 50.0
+    assert coverage["modules"]["src/app/util.py"]["percent"] == 100.0
+    assert coverage_warnings(coverage) == []
+    badge = coverage_badge(coverage)
+    assert badge == {
+        "label": "66.7%",
+        "url": "https://img.shields.io/badge/coverage-66.7%25-yellow",
+    }
+
+
+def test_unrecognized_report_raises(tmp_path: Path) -> None:
+    report = tmp_path / "coverage.txt"
+    report.write_text("TOTAL 10 2 80%\n", encoding="utf-8")
+    with pytest.raises(ConfigError, match="Unrecognized coverage report"):
+        build_coverage(report, [])
+
+
+def test_analyzer_stores_coverage_in_run_metrics(tmp_path: Path) -> None:
+    (tmp_path / "users").mkdir()
+    (tmp_path / "users" / "users.go").write_text(
+        "package users\n\n// Get loads a user.\nfunc Get() {}\n", encoding="utf-8"
+    )
+    (tmp_path / "coverage.out").write_text(GO_PROFILE, encoding="utf-8")
+    config = {"coverage": {"file": "coverage.out", "threshold": 80}}
+
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    coverage = result["run_metrics"]["coverage"]
+    assert coverage["modules"] == {"users/users.go": {"covered": 4, "total": 4, "percent": 100.0}}
+    assert coverage["threshold"] == 80.0
+
+    broken = {"coverage": {"file": "missing.out"}}
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=broken).analyze()
+    assert result["run_metrics"]["coverage"] == {}
+    assert result["skipped_reasons"]["coverage_report_error"] == 1
+
+
+def test_readme_renders_coverage_only_when_reported(tmp_path: Path) -> None:
+    analysis = {
+        "project_name": "svc",
+        "root_path": str(tmp_path),
+        "files_analyzed": 2,
+        "languages": {"go": 2},
+        "functions": [
+            {"name": "Get", "file": str(tmp_path / "users.go"), "line": 4, "args": []},
+            {"name": "Bill", "file": str(tmp_path / "invoice.go"), "line": 2, "args": []},
+        ],
+        "classes": [],
+        "run_metrics": {
+            "coverage": {
+                "report": "coverage.out",
+                "percent": 40.0,
+                "covered": 4,
+                "total": 10,
+                "threshold": 50.0,
+                "modules": {
+                    "invoice.go": {"covered": 0, "total": 6, "percent": 0.0},
+                    "users.go": {"covered": 4, "total": 4, "percent": 100.0},
+                },
+            }
+        },
+    }
+
+    content = ReadmeGenerator().generate(analysis)
+    assert "![Coverage: 40%](https://img.shields.io/badge/coverage-40%25-red)" in content
+    assert "## Test Coverage" in content
+    assert "| `invoice.go` | 0.0% (below threshold) | 0/6 |" in content
+    assert "Coverage: **100.0%** (4/4 statements)" in content
+    assert "Test coverage for `invoice.go` is 0.0%, below the 50% threshold." in content
+
+    analysis["run_metrics"] = {}
+    content = ReadmeGenerator().generate(analysis)
+    assert "Coverage" not in content
@@ -0,0 +1,173 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.html_sections import build_impact_graph_data, symbol_node_id
+from docgenie.languages import CSharpParser
+from docgenie.parsers import ParserRegistry
+from docgenie.readme_quality import deprecation_warnings
+
+SAMPLE = '''using System;
+using System.Collections.Generic;
+using static System.Math;
+using Json = System.Text.Json;
+
+namespace Shop.Users
+{
+    /// <summary>
+    /// Loads and stores <see cref="T:Shop.Users.User"/> records.
+    /// </summary>
+    /// <typeparam name="T">The entity type.</typeparam>
+    [Serializable, Obsolete("Use UserStore")]
+    public class Repository<T> : RepositoryBase<T>, IRepository<T> where T : class, IEntity, new()
+    {
+        /// <summary>Number of cached rows.</summary>
+        public int Count { get; private set; }
+
+        /// <summary>Name shown in logs.</summary>
+        public string Name => "users";
+
+        private readonly Dictionary<int, T> _rows = new();
+
+        /// <summary>Creates a repository.</summary>
+        public Repository(string connection) : base(connection)
+        {
+        }
+
+        /// <summary>Finds a row by id.</summary>
+        /// <param name="id">Primary key.</param>
+        /// <param name="fallback">Returned when <paramref name="id"/> is unknown.</param>
+        /// <returns>The row, or <see langword="null"/>.</returns>
+        public T? Find(int id, T? fallback = default)
+        {
+            var text = "{ not a brace }";
+            return _rows.TryGetValue(id, out var row) ? row : fallback;
+        }
+
+        [Obsolete]
+        public IEnumerable<TResult> Map<TResult>(Func<T, TResult> map) where TResult : IEntity
+            => _rows.Values.Select(map);
+
+        private void Hidden() { }
+
+        /// <summary>Row wrapper.</summary>
+        public struct Row
+        {
+            public int Id { get; init; }
+        }
+    }
+
+    /// <summary>An account holder.</summary>
+    /// <param name="Id">Primary key.</param>
+    /// <param name="Email">Login address.</param>
+    public record User(int Id, string Email) : IEntity;
+
+    public interface IEntity
+    {
+        /// <summary>Validates the entity.</summary>
+        bool Validate(bool strict);
+    }
+
+    public enum Role : byte { Guest, Admin = 2 }
+}
+'''
+
+
+def _parse() -> dict:
+    return CSharpParser().parse(SAMPLE, Path("Users.cs"), "csharp").to_public_dict()
+
+
+def test_csharp_parser_is_registered() -> None:
+    registry = ParserRegistry(enable_tree_sitter=False)
+
+    assert registry.parse(SAMPLE, Path("Users.cs"), "csharp").classes
+
+
+def test_csharp_types_members_and_xml_docs() -> None:
+    parsed = _parse()
+    classes = {cls["name"]: cls for cls in parsed["classes"]}
+
+    assert sorted(classes) == [
+        "Shop.Users.IEntity",
+        "Shop.Users.Repository",
+        "Shop.Users.Repository.Row",
+        "Shop.Users.Role",
+        "Shop.Users.User",
+    ]
+    repo = classes["Shop.Users.Repository"]
+    assert repo["docstring"] == "Loads and stores `User` records.\n\n- `T`: The entity type."
+    assert repo["bases"] == ["RepositoryBase<T>", "IRepository<T>"]
+    assert repo["type_params"] == ["T IEntity"]
+    assert repo["signature"].endswith("where T : class, IEntity, new()")
+    assert repo["decorators"] == ["[Serializable]", '[Obsolete("Use UserStore")]']
+    assert [(f["name"], f["type"], f["docstring"]) for f in repo["fields"]] == [
+        ("Count", "int", "Number of cached rows."),
+        ("Name", "string", "Name shown in logs."),
+    ]
+
+    methods = {method["name"]: method for method in repo["methods"]}
+    assert sorted(methods) == ["Find", "Map", "Repository"]
+    assert methods["Repository"]["kind"] == "constructor"
+    find = methods["Find"]
+    assert find["args"] == ["id", "fallback"]
+    assert find["docstring"] == (
+        "Finds a row by id.\n\nReturns: The row, or `null`.\n\nParameters:\n"
+        "- `id`: Primary key.\n- `fallback`: Returned when `id` is unknown."
+    )
+    assert (find["line"], find["end_line"], find["doc_line"]) == (32, 36, 28)
+    mapper = methods["Map"]
+    assert mapper["signature"] == (
+        "public IEnumerable<TResult> Map<TResult>(Func<T, TResult> map) where TResult : IEntity"
+    )
+    assert mapper["type_params"] == ["TResult IEntity"]
+
+    user = classes["Shop.Users.User"]
+    assert (user["kind"], user["bases"]) == ("record", ["IEntity"])
+    assert user["docstring"] == "An account holder."
+    assert [(f["name"], f["type"], f["docstring"]) for f in user["fields"]] == [
+        ("Id", "int", "Primary key."),
+        ("Email", "string", "Login address."),
+    ]
+    assert [m["name"] for m in classes["Shop.Users.IEntity"]["methods"]] == ["Validate"]
+    assert classes["Shop.Users.Role"]["bases"] == []
+    assert parsed["imports"] == [
+        "System",
+        "System.Collections.Generic",
+        "System.Math",
+        "System.Text.Json",
+    ]
+
+
+def test_csharp_file_scoped_namespace_and_qualified_graph_edges(tmp_path: Path) -> None:
+    base = "namespace Shop.Core;\n\npublic abstract class Entity { }\n"
+    user = (
+        "using Shop.Core;\n\nnamespace Shop.Users;\n\npublic class Admin : Entity\n{\n"
+        "    public void Promote() { }\n}\n"
+    )
+    parsed = [
+        CSharpParser().parse(source, tmp_path / name, "csharp").to_public_dict()
+        for name, source in (("Entity.cs", base), ("Admin.cs", user))
+    ]
+    analysis = {
+        "root_path": str(tmp_path),
+        "classes": [cls for result in parsed for cls in result["classes"]],
+        "file_imports": {"Entity.cs": [], "Admin.cs": []},
+    }
+
+    edges = build_impact_graph_data(analysis)["edges"]
+
+    assert {
+        "source": symbol_node_id("Admin.cs", "Shop.Users.Admin"),
+        "target": symbol_node_id("Entity.cs", "Shop.Core.Entity"),
+        "kind": "extends",
+    } in edges
+
+
+def test_csharp_obsolete_attributes_surface_as_warnings(tmp_path: Path) -> None:
+    parsed = CSharpParser().parse(SAMPLE, tmp_path / "Users.cs", "csharp").to_public_dict()
+    analysis = {"root_path": str(tmp_path), **parsed}
+
+    assert deprecation_warnings(analysis) == [
+        "Deprecated class `Shop.Users.Repository` (Users.cs:13)",
+        "Deprecated method `Shop.Users.Repository.Map` (Users.cs:39)",
+    ]
@@ -0,0 +1,88 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.cycles import find_cycles
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_sections import build_impact_graph_data
+
+
+def _edge(source: str, target: str, kind: str = "import") -> dict[str, str]:
+    return {"source": source, "target": target, "kind": kind}
+
+
+def test_find_cycles_reports_components_with_shortest_paths() -> None:
+    edges = [
+        _edge("a", "b"),
+        _edge("b", "a"),
+        _edge("c", "d"),
+        _edge("d", "e"),
+        _edge("e", "c"),
+        _edge("e", "d"),
+        _edge("f", "f"),
+        _edge("a", "g"),
+        _edge("g", "a", kind="defines"),
+    ]
+
+    assert find_cycles(edges) == [
+        {"members": ["c", "d", "e"], "path": ["c", "d", "e", "c"]},
+        {"members": ["a", "b"], "path": ["a", "b", "a"]},
+    ]
+    assert find_cycles([_edge("a", "b"), _edge("b", "c")]) == []
+
+
+def test_impact_graph_marks_cycle_edges_and_caps_reported_cycles() -> None:
+    file_imports = {f"m{i}.py": [f"n{i}.py"] for i in range(5)}
+    file_imports.update({f"n{i}.py": [f"m{i}.py", "os"] for i in range(5)})
+
+    graph = build_impact_graph_data({"file_imports": file_imports}, max_cycles=3)
+
+    assert graph["total_cycles"] == 5
+    assert len(graph["cycles"]) == 3
+    flagged = {(edge["source"], edge["target"]) for edge in graph["edges"] if edge.get("cycle")}
+    assert ("file:m0.py", "file:n0.py") in flagged
+    assert ("file:n0.py", "file:m0.py") in flagged
+    assert not any(target == "module:os" for _source, target in flagged)
+
+
+def test_readme_lists_circular_dependencies(tmp_path: Path) -> None:
+    (tmp_path / "a.js").write_text(
+        'import { b } from "./b";\n\nexport function a() {\n  return b();\n}\n', encoding="utf-8"
+    )
+    (tmp_path / "b.js").write_text(
+        'import { a } from "./a";\n\nexport function b() {\n  return a;\n}\n', encoding="utf-8"
+    )
+    (tmp_path / "c.js").write_text('import { a } from "./a";\n', encoding="utf-8")
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    graph = build_impact_graph_data(result)
+    assert graph["cycles"] == [
+        {"members": ["file:a.js", "file:b.js"], "path": ["file:a.js", "file:b.js", "file:a.js"]}
+    ]
+
+    content = ReadmeGenerator().generate(result)
+    assert "## Circular Dependencies" in content
+    assert "- `a.js` → `b.js` → `a.js`\n" in content
+    assert "`c.js`" not in content.split("## Circular Dependencies")[1].split("## ")[0]
+
+
+def test_python_import_cycle_is_found_through_module_names(tmp_path: Path) -> None:
+    (tmp_path / "a.py").write_text(
+        "import b\n\n\ndef one():\n    return b.two()\n", encoding="utf-8"
+    )
+    (tmp_path / "b.py").write_text(
+        "import a\n\n\ndef two():\n    return a.one()\n", encoding="utf-8"
+    )
+    # More imports than the graph draws per file; the cycle edge comes last.
+    padding = "".join(f"import mod{i}\n" for i in range(10))
+    (tmp_path / "c.py").write_text(padding + "import d\n", encoding="utf-8")
+    (tmp_path / "d.py").write_text("import c\n", encoding="utf-8")
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    graph = build_impact_graph_data(result)
+    assert graph["cycles"] == [
+        {"members": ["file:a.py", "file:b.py"], "path": ["file:a.py", "file:b.py", "file:a.py"]},
+        {"members": ["file:c.py", "file:d.py"], "path": ["file:c.py", "file:d.py", "file:c.py"]},
+    ]
+    assert ("file:a.py", "file:b.py") in {(e["source"], e["target"]) for e in graph["edges"]}
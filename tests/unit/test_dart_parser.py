@@ -0,0 +1,171 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import DartParser
+from docgenie.module_index import build_module_index
+from docgenie.parsers import ParserRegistry
+from docgenie.readme_quality import deprecation_warnings
+
+SAMPLE = """import 'package:flutter/material.dart';
+import 'dart:async' show Future;
+
+/// A point in the plane.
+@immutable
+class Point<T extends num> extends Shape with Comparable implements Printable {
+  final T x;
+  final List<int> cache = List.filled(3, 0);
+
+  /// Create a point.
+  const Point(this.x, {required this.y}) : assert(x >= 0);
+
+  /// The origin.
+  Point.origin() : x = 0;
+
+  /// Parse a point.
+  factory Point.fromJson(Map<String, dynamic> json) => Point(json['x']);
+
+  Point._internal();
+
+  /// Distance to [other].
+  double distanceTo(Point other) => (x - other.x).abs();
+
+  /// The magnitude.
+  double get magnitude => x * x;
+
+  int get undocumented => 1;
+
+  bool operator ==(Object other) => other is Point && other.x == x;
+
+  @override
+  String toString() {
+    return 'Point($x, {)';
+  }
+
+  @Deprecated('Use distanceTo')
+  double dist(Point other) => 0;
+
+  void _secret() {}
+}
+
+/// Colors.
+enum Color {
+  red(0xFF0000),
+  green(0x00FF00);
+
+  const Color(this.value);
+
+  String describe() => '$name';
+}
+
+mixin Walker on Animal {
+  void walk() {}
+}
+
+extension StringX on String {
+  String shout() => toUpperCase();
+}
+
+extension on int {
+  int doubled() => this * 2;
+}
+
+abstract class Repository<T> {
+  Future<T?> find(String id);
+  Stream<T> watch() async* {}
+}
+
+class _Hidden {}
+
+typedef Callback = void Function(int value);
+
+/// Load users.
+Future<List<User>> loadUsers(String url, [int retries = 3]) async {
+  return [];
+}
+
+@deprecated
+void oldApi(void onDone(int code), void Function(String) cb) {}
+
+void _helper() {}
+
+final config = Config.load();
+"""
+
+
+def test_dart_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("dart"), DartParser)
+
+
+def test_dart_types_and_constructors_grouped_under_class() -> None:
+    parsed = DartParser().parse(SAMPLE, Path("point.dart"), "dart")
+    by_name = {cls.name: cls for cls in parsed.classes}
+    assert [(cls.kind, cls.name) for cls in parsed.classes] == [
+        ("class", "Point"),
+        ("enum", "Color"),
+        ("mixin", "Walker"),
+        ("extension", "StringX"),
+        ("extension", "int"),
+        ("abstract class", "Repository"),
+    ]
+
+    point = by_name["Point"]
+    assert point.docstring == "A point in the plane."
+    assert point.decorators == ["@immutable"]
+    assert point.bases == ["Shape", "Comparable", "Printable"]
+    assert [(m.kind, m.name) for m in point.methods] == [
+        ("constructor", "Point"),
+        ("constructor", "Point.origin"),
+        ("constructor", "Point.fromJson"),
+        ("method", "distanceTo"),
+        ("property", "magnitude"),
+        ("method", "operator =="),
+        ("method", "toString"),
+        ("method", "dist"),
+    ]
+    assert point.methods[0].args == ["x", "y"]
+    assert point.methods[2].signature == "factory Point.fromJson(Map<String, dynamic> json)"
+    assert point.methods[2].docstring == "Parse a point."
+
+    # Enum values are not methods; the const constructor and members after `;` are.
+    assert [(m.kind, m.name) for m in by_name["Color"].methods] == [
+        ("constructor", "Color"),
+        ("method", "describe"),
+    ]
+    assert by_name["Walker"].bases == ["Animal"]
+    assert by_name["StringX"].bases == ["String"]
+    assert [m.name for m in by_name["int"].methods] == ["doubled"]
+    assert parsed.imports == {"package:flutter/material.dart", "dart:async"}
+
+
+def test_dart_functions_async_and_privacy() -> None:
+    parsed = DartParser().parse(SAMPLE, Path("point.dart"), "dart")
+
+    assert [func.name for func in parsed.functions] == ["loadUsers", "oldApi"]
+    load, old = parsed.functions
+    assert load.is_async
+    assert load.args == ["url", "retries"]
+    assert load.signature == "Future<List<User>> loadUsers(String url, [int retries = 3]) async"
+    assert old.args == ["onDone", "cb"]
+
+    repository = next(cls for cls in parsed.classes if cls.name == "Repository")
+    assert [(m.name, m.is_async) for m in repository.methods] == [("find", False), ("watch", True)]
+
+
+def test_dart_deprecations_and_symbol_table(tmp_path: Path) -> None:
+    (tmp_path / "lib").mkdir()
+    (tmp_path / "lib" / "point.dart").write_text(SAMPLE, encoding="utf-8")
+    (tmp_path / "lib" / "point.g.dart").write_text("class Generated {}\n", encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert analysis["languages"] == {"dart": 1}
+    assert deprecation_warnings(analysis) == [
+        "Deprecated function `oldApi` (lib/point.dart:79)",
+        "Deprecated method `Point.dist` (lib/point.dart:37)",
+    ]
+    modules = build_module_index(analysis)
+    rows = {sym["name"]: sym for module in modules for sym in module["symbols"]}
+    assert rows["Point::magnitude"]["kind"] == "property"
+    assert "Generated" not in rows
+    assert rows["loadUsers"]["summary"] == "Load users."
@@ -0,0 +1,74 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.dead_code import find_unreferenced_symbols, scan_symbol_references
+from docgenie.generator import ReadmeGenerator
+
+
+def _project(tmp_path: Path) -> None:
+    (tmp_path / "lib.py").write_text(
+        "def used():\n    return 1\n\n\ndef orphan():\n    return 2\n\n\n"
+        "def public_api():\n    return 3\n\n\ndef _private():\n    return 4\n\n\n"
+        "class Widget:\n    def render(self):\n        return used()\n",
+        encoding="utf-8",
+    )
+    (tmp_path / "app.py").write_text(
+        "from lib import Widget, used\n\n\ndef main():\n    return Widget(), used()\n",
+        encoding="utf-8",
+    )
+    tests_dir = tmp_path / "tests"
+    tests_dir.mkdir()
+    (tests_dir / "test_lib.py").write_text(
+        "def test_orphan_helper():\n    assert True\n", encoding="utf-8"
+    )
+
+
+def test_scan_symbol_references_matches_whole_identifiers(tmp_path: Path) -> None:
+    (tmp_path / "a.py").write_text("value = used_more()\n", encoding="utf-8")
+    (tmp_path / "b.py").write_text("used()\n", encoding="utf-8")
+    refs = scan_symbol_references(tmp_path, list(tmp_path.iterdir()), ["used", "missing"])
+    assert refs == {"b.py": ["used"]}
+
+
+def test_find_unreferenced_symbols_excludes_entry_points_and_ignores(tmp_path: Path) -> None:
+    _project(tmp_path)
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    names = [sym["name"] for sym in find_unreferenced_symbols(analysis)]
+    assert names == ["orphan", "public_api"]
+
+    ignored = find_unreferenced_symbols(analysis, ignore=["public_*", "lib.py::orphan"])
+    assert ignored == []
+
+
+def test_unreferenced_section_lists_symbols_with_warning(tmp_path: Path) -> None:
+    _project(tmp_path)
+    analysis = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"dead_code": {"max_listed": 1}}
+    ).analyze()
+
+    readme = ReadmeGenerator().generate(analysis, None)
+    section = readme.split("## Unreferenced Symbols", 1)[1]
+    assert "> **Warning:** Detection is static" in section
+    assert "| `orphan` | function | `lib.py` | 5 |" in section
+    assert "`public_api`" not in section
+    assert "_...and 1 more._" in section
+
+    adoc = ReadmeGenerator().generate(analysis, None, output_format="adoc")
+    assert "== Unreferenced Symbols" in adoc
+    assert "WARNING: Detection is static" in adoc
+
+
+def test_unreferenced_section_needs_reference_scan(tmp_path: Path) -> None:
+    _project(tmp_path)
+    analysis = CodebaseAnalyzer(
+        str(tmp_path),
+        enable_tree_sitter=False,
+        config={"dead_code": {"enabled": False}},
+    ).analyze()
+    assert analysis["symbol_references"] == {}
+    analysis.pop("symbol_references")
+
+    assert "Unreferenced Symbols" not in ReadmeGenerator().generate(analysis, None)
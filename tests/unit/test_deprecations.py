@@ -0,0 +1,71 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.readme_quality import deprecation_note, deprecation_timeline, is_deprecated
+
+GO_SOURCE = """package things
+
+// OldThing builds a thing the old way.
+//
+// Deprecated: since v1.2, removed in v2.0; use NewThing
+func OldThing() {}
+
+// LegacyThing builds a thing the legacy way.
+//
+// Deprecated: since v1.0, removed in v1.5.
+func LegacyThing() {}
+
+// Thing is what gets built.
+//
+// Deprecated: use Widget instead.
+type Thing struct{}
+
+// NewThing builds a thing.
+func NewThing() {}
+"""
+
+
+def test_structured_deprecation_note_is_parsed() -> None:
+    symbol = {
+        "name": "OldThing",
+        "docstring": "OldThing builds a thing the old way.\n\n"
+        "Deprecated: since v1.2, removed in v2.0; use NewThing",
+    }
+    assert is_deprecated(symbol)
+    assert deprecation_note(symbol) == "since v1.2, removed in v2.0; use NewThing"
+    assert deprecation_timeline(deprecation_note(symbol)) == {
+        "since": "v1.2",
+        "removed_in": "v2.0",
+        "replacement": "NewThing",
+    }
+    assert deprecation_timeline("Will be removed in 3.0.") == {
+        "since": None,
+        "removed_in": "3.0",
+        "replacement": None,
+    }
+    assert not is_deprecated({"docstring": "Replaces the deprecated: flag handling."})
+
+
+def test_deprecations_section_is_sorted_and_flags_overdue_removals(tmp_path: Path) -> None:
+    (tmp_path / "things.go").write_text(GO_SOURCE, encoding="utf-8")
+    config = {"project": {"version": "v1.5.0"}, "analysis": {"incremental": False}}
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    context = ReadmeGenerator()._prepare_context(analysis)
+    rows = [
+        (row["name"], row["since"], row["removed_in"], row["replacement"], row["overdue"])
+        for row in context["deprecations"]
+    ]
+    assert rows == [
+        ("LegacyThing", "v1.0", "v1.5", None, True),
+        ("OldThing", "v1.2", "v2.0", "NewThing", False),
+        ("Thing", None, None, "Widget", False),
+    ]
+    errors = [warning for warning in context["analysis_warnings"] if warning.startswith("Error:")]
+    assert errors == [
+        "Error: Deprecated function `LegacyThing` (things.go:11); it was due for removal in "
+        "v1.5 (current version v1.5.0)"
+    ]
@@ -0,0 +1,62 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.doc_links import doc_url, link_module_signatures, parse_doc_bases
+from docgenie.module_index import build_module_index
+
+ROUTER_GO = """package api
+
+import (
+	"github.com/gorilla/mux"
+	"example.com/acme/auth"
+	"example.com/app/store"
+)
+
+// Routes registers the HTTP handlers.
+func Routes(r *mux.Router, a auth.Checker, s store.Store) error { return nil }
+"""
+
+
+def _signature_links(tmp_path: Path, config: dict | None = None) -> list[dict]:
+    (tmp_path / "router.go").write_text(ROUTER_GO, encoding="utf-8")
+    (tmp_path / "store").mkdir(exist_ok=True)
+    (tmp_path / "store" / "store.go").write_text(
+        "package store\n\n// Store persists data.\ntype Store struct{}\n", encoding="utf-8"
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+    modules = link_module_signatures(build_module_index(analysis), analysis)
+    (routes,) = [sym for module in modules for sym in module["symbols"] if sym["name"] == "Routes"]
+    return routes.get("signature_links", [])
+
+
+def test_gorilla_mux_type_links_to_pkg_go_dev(tmp_path: Path) -> None:
+    links = _signature_links(tmp_path)
+
+    assert links == [
+        {"text": "func Routes(r *", "url": None},
+        {"text": "mux.Router", "url": "https://pkg.go.dev/github.com/gorilla/mux#Router"},
+        {"text": ", a ", "url": None},
+        {"text": "auth.Checker", "url": "https://pkg.go.dev/example.com/acme/auth#Checker"},
+        {"text": ", s store.Store) error", "url": None},
+    ]
+
+
+def test_doc_base_overrides_and_unknown_packages(tmp_path: Path) -> None:
+    bases = parse_doc_bases(["example.com/acme=https://docs.acme.dev/{path}?type={name}"])
+    links = _signature_links(tmp_path, {"doc_links": {"bases": bases}})
+
+    assert links[3]["url"] == "https://docs.acme.dev/example.com/acme/auth?type=Checker"
+    assert doc_url("json", "JSONDecoder", "python", {}) == (
+        "https://docs.python.org/3/library/json.html#json.JSONDecoder"
+    )
+    assert doc_url("requests", "Session", "python", {}) is None
+    assert doc_url("requests", "Session", "python", {"requests": "https://docs.example"}) == (
+        "https://docs.example/requests"
+    )
+    assert _signature_links(tmp_path, {"doc_links": {"enabled": False}}) == []
+    with pytest.raises(ValueError, match="expected PREFIX=URL"):
+        parse_doc_bases(["github.com/acme"])
@@ -0,0 +1,89 @@
+from __future__ import annotations
+
+import xml.etree.ElementTree as ET  # noqa: N817  # nosec B405
+from pathlib import Path
+from typing import Any
+
+from docgenie import cli
+from docgenie.docbook import DOCBOOK_NS, DocBookGenerator
+
+DB = f"{{{DOCBOOK_NS}}}"
+XML_ID = "{http://www.w3.org/XML/1998/namespace}id"
+
+
+def _analysis(root: Path) -> dict[str, Any]:
+    return {
+        "project_name": "Shop",
+        "root_path": str(root),
+        "main_language": "python",
+        "files_analyzed": 2,
+        "languages": {"python": 1, "go": 1},
+        "dependencies": {},
+        "project_structure": {},
+        "functions": [
+            {
+                "name": "checkout",
+                "file": str(root / "core.py"),
+                "line": 4,
+                "args": ["cart"],
+                "docstring": "Charge <cart> & ship.\x0b\n\nSee `{% raw %}` too.",
+            },
+            {"name": "Render", "file": str(root / "view.go"), "line": 3, "docstring": None},
+            {"name": "_private", "file": str(root / "core.py"), "line": 1},
+        ],
+        "classes": [
+            {
+                "name": "checkout",
+                "kind": "class",
+                "file": str(root / "core.py"),
+                "line": 10,
+                "methods": [{"name": "add", "args": ["self", "item"], "line": 12}],
+            },
+        ],
+    }
+
+
+def test_docbook_output_is_well_formed_and_anchored(tmp_path: Path) -> None:
+    content = DocBookGenerator().generate(_analysis(tmp_path))
+    article = ET.fromstring(content.split("\n", 1)[1])
+
+    assert article.tag == f"{DB}article"
+    assert article.get("version") == "5.0"
+    assert article.findtext(f"{DB}info/{DB}title") == "Shop"
+    ids = [section.get(XML_ID) for section in article.iter(f"{DB}section")]
+    # Same-named symbols reuse the README anchor dedup: the second one gets `-2`.
+    assert ids == [
+        "languages",
+        "module-core-py",
+        "api-checkout",
+        "api-checkout-2",
+        "api-checkout-add",
+        "module-view-go",
+        "api-render",
+    ]
+    checkout = next(s for s in article.iter(f"{DB}section") if s.get(XML_ID) == "api-checkout")
+    assert checkout.findtext(f"{DB}programlisting") == "checkout(cart)"
+    assert [para.text for para in checkout.findall(f"{DB}para")] == [
+        "Charge <cart> & ship.",
+        "See `{% raw %}` too.",
+        "Defined at line 4.",
+    ]
+    rows = article.findall(f".//{DB}table/{DB}tgroup/{DB}tbody/{DB}row")
+    assert [[entry.text for entry in row] for row in rows] == [["go", "1"], ["python", "1"]]
+
+
+def test_docbook_omits_empty_sections(tmp_path: Path) -> None:
+    analysis = {**_analysis(tmp_path), "languages": {}, "functions": [], "classes": []}
+    article = ET.fromstring(DocBookGenerator().generate(analysis).split("\n", 1)[1])
+
+    assert article.findall(f"{DB}section") == []
+    assert article.findtext(f"{DB}info/{DB}abstract/{DB}para", "").startswith("Shop is a python")
+
+
+def test_docbook_format_writes_output(tmp_path: Path) -> None:
+    assert cli._validate_format("DocBook") == "docbook"
+    outputs = cli._build_outputs("docbook", None, tmp_path)
+    assert outputs == [("docbook", tmp_path / "README.docbook.xml")]
+
+    DocBookGenerator().generate(_analysis(tmp_path), outputs[0][1])
+    ET.parse(outputs[0][1])
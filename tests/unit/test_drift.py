@@ -0,0 +1,60 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie.drift import diff_stats, drift_diff
+from docgenie.exceptions import GeneratorError
+
+GENERATED = """# Demo
+
+## Installation
+
+pip install demo
+
+## Usage
+
+demo run
+
+## License
+
+MIT
+
+*This README was automatically generated by DocGenie on 2026-10-15 09:30:00*
+"""
+
+
+def test_matching_output_has_no_drift_despite_a_new_timestamp() -> None:
+    committed = GENERATED.replace("2026-10-15 09:30:00", "2026-01-02 08:00:00")
+    assert drift_diff(committed, GENERATED, Path("README.md")) == []
+
+
+def test_mismatching_output_reports_a_diff() -> None:
+    committed = GENERATED.replace("demo run", "demo start")
+    diff = drift_diff(committed, GENERATED, Path("README.md"))
+
+    assert diff[:2] == ["--- README.md (committed)", "+++ README.md (generated)"]
+    assert "-demo start" in diff and "+demo run" in diff
+    assert diff_stats(diff) == (1, 1)
+    # A file that was never generated is drift too.
+    missing = drift_diff(None, GENERATED, Path("README.md"))
+    assert diff_stats(missing) == (len(GENERATED.splitlines()), 0)
+
+
+def test_only_managed_regions_are_compared() -> None:
+    committed = (
+        "# Demo\n\nHand-written intro that generate never touches.\n\n"
+        "<!-- docgenie:begin usage -->\n## Usage\n\ndemo run\n<!-- docgenie:end -->\n"
+    )
+    path = Path("README.md")
+    assert drift_diff(committed, GENERATED, path, regions=True) == []
+
+    stale = committed.replace("demo run", "demo start")
+    diff = drift_diff(stale, GENERATED, path, regions=True)
+    assert [line for line in diff if line[:1] in "+-" and line[:3] not in ("---", "+++")] == [
+        "-demo start",
+        "+demo run",
+    ]
+    with pytest.raises(GeneratorError):
+        drift_diff("<!-- docgenie:begin usage -->\n", GENERATED, path, regions=True)
@@ -0,0 +1,141 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.languages import ElixirParser
+from docgenie.languages.elixir import module_path
+from docgenie.models import ClassDoc
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = '''defmodule MyApp.Accounts do
+  @moduledoc """
+  The accounts context.
+
+  Users sign up here; the docs mention do and end.
+  """
+
+  use MyApp.Context
+  import Ecto.Query, only: [from: 2]
+  alias MyApp.{Repo, User}
+  require Logger
+
+  defstruct [:name, email: nil]
+
+  @doc "Fetches a user by id."
+  @spec get_user(integer()) :: User.t() | nil
+  def get_user(id) when is_integer(id) do
+    Repo.get(User, id)
+  end
+
+  def get_user(_id), do: nil
+
+  @doc """
+  Creates a user.
+  """
+  @deprecated "Use register/2 instead"
+  def create_user(%{} = attrs, opts \\\\ []) do
+    case validate(attrs) do
+      {:ok, user} -> Repo.insert(user, opts)
+      {:error, _} = error -> error
+    end
+  end
+
+  @doc false
+  def internal_hook, do: :ok
+
+  @doc "Wraps a block in a transaction."
+  defmacro transaction(do: block) do
+    quote do
+      Repo.transaction(fn -> unquote(block) end)
+    end
+  end
+
+  defp validate(attrs) do
+    fn -> attrs end
+    {:ok, attrs}
+  end
+
+  defmodule Token do
+    @moduledoc "A signed token."
+    def sign(data), do: data
+  end
+end
+'''
+
+
+def _parse(*, include_private: bool = False) -> list[ClassDoc]:
+    parser = ElixirParser()
+    parser.include_private = include_private
+    return parser.parse(SAMPLE, Path("lib/my_app/accounts.ex"), "elixir").classes
+
+
+def test_elixir_modules_use_doc_attributes_and_specs() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("elixir"), ElixirParser)
+    classes = {cls.name: cls for cls in _parse()}
+
+    assert list(classes) == ["MyApp.Accounts.Token", "MyApp.Accounts"]
+    accounts = classes["MyApp.Accounts"]
+    assert accounts.kind == "module"
+    assert accounts.docstring == (
+        "The accounts context.\n\nUsers sign up here; the docs mention do and end."
+    )
+    assert (accounts.doc_line, accounts.doc_end_line) == (2, 6)
+    assert (accounts.line, accounts.end_line) == (1, 53)
+    assert accounts.bases == ["MyApp.Context"]
+    assert [item.name for item in accounts.fields] == ["name", "email"]
+    assert classes["MyApp.Accounts.Token"].docstring == "A signed token."
+
+    methods = {method.name: method for method in accounts.methods}
+    assert list(methods) == ["get_user", "create_user", "transaction"]
+    get_user = methods["get_user"]
+    assert get_user.docstring == "Fetches a user by id."
+    assert get_user.signature == "get_user(integer()) :: User.t() | nil"
+    assert get_user.args == ["id"]
+    # The second clause extends the function's extent.
+    assert (get_user.line, get_user.end_line) == (17, 21)
+    create = methods["create_user"]
+    assert create.docstring == "Creates a user."
+    assert create.signature == "def create_user(%{} = attrs, opts \\\\ [])"
+    assert create.args == ["attrs", "opts"]
+    assert create.deprecated == "Use register/2 instead"
+    assert (create.line, create.end_line) == (27, 32)
+    assert methods["transaction"].kind == "macro"
+
+
+def test_elixir_private_functions_need_include_private() -> None:
+    accounts = next(cls for cls in _parse(include_private=True) if cls.name == "MyApp.Accounts")
+    private = {method.name: method.private for method in accounts.methods}
+
+    assert private == {
+        "get_user": False,
+        "create_user": False,
+        "internal_hook": True,
+        "transaction": False,
+        "validate": True,
+    }
+
+
+def test_elixir_use_and_import_link_files_in_impact_graph(tmp_path: Path) -> None:
+    lib = tmp_path / "lib" / "my_app"
+    lib.mkdir(parents=True)
+    (lib / "http_client.ex").write_text(
+        "defmodule MyApp.HTTPClient do\n  def get(url), do: url\nend\n", encoding="utf-8"
+    )
+    (lib / "sync.ex").write_text(
+        "defmodule MyApp.Sync do\n  use GenServer\n  import MyApp.HTTPClient\nend\n",
+        encoding="utf-8",
+    )
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert module_path("MyApp.HTTPClient") == "my_app/http_client.ex"
+    assert result["file_imports"]["lib/my_app/sync.ex"] == [
+        "GenServer",
+        "lib/my_app/http_client.ex",
+    ]
+    edges = {
+        (edge["source"], edge["target"]) for edge in build_impact_graph_data(result)["edges"]
+    }
+    assert ("file:lib/my_app/sync.ex", "file:lib/my_app/http_client.ex") in edges
+    assert ("file:lib/my_app/sync.ex", "module:GenServer") in edges
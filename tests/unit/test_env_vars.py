@@ -0,0 +1,98 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.env_vars import env_var_groups, env_var_names, scan_env_vars
+
+GO_SOURCE = """package main
+
+import (
+\t"cmp"
+\t"os"
+)
+
+// Reads os.Getenv("IN_COMMENT") in prose only.
+func main() {
+\tport := cmp.Or(os.Getenv("PORT"), "8080")
+\tdsn := os.Getenv("DATABASE_URL")
+\thost, ok := os.LookupEnv(prefix + "_HOST")
+\tmsg := "os.Getenv(\\"IN_STRING\\")"
+\t_ = os.Getenv("PORT")
+}
+"""
+
+PY_SOURCE = '''"""Settings; os.environ["IN_DOCSTRING"] is prose."""
+import os
+from os import getenv
+
+DEBUG = os.environ.get("DEBUG", "false")
+SECRET = os.environ["SECRET_KEY"]
+REGION = getenv("REGION")
+TIMEOUT = int(os.getenv("TIMEOUT", 30))
+NAME = os.environ.get(f"{PREFIX}_NAME", default="svc")
+os.environ["WRITTEN"] = "x"
+'''
+
+
+def _write(root: Path) -> list[Path]:
+    (root / "cmd").mkdir()
+    (root / "cmd" / "main.go").write_text(GO_SOURCE, encoding="utf-8")
+    (root / "settings.py").write_text(PY_SOURCE, encoding="utf-8")
+    return [root / "cmd" / "main.go", root / "settings.py"]
+
+
+def test_go_reads_with_cmp_or_defaults_and_dynamic_keys(tmp_path: Path) -> None:
+    reads = scan_env_vars(tmp_path, _write(tmp_path))
+    reads = [read for read in reads if read["file"] == "cmd/main.go"]
+
+    assert [(read["name"], read["line"], read["default"], read["dynamic"]) for read in reads] == [
+        ("PORT", 10, "8080", False),
+        ("DATABASE_URL", 11, None, False),
+        ('prefix + "_HOST"', 12, None, True),
+        ("PORT", 14, None, False),
+    ]
+
+
+def test_python_reads_environ_and_getenv(tmp_path: Path) -> None:
+    reads = scan_env_vars(tmp_path, _write(tmp_path))
+    reads = [read for read in reads if read["file"] == "settings.py"]
+
+    assert [(read["name"], read["default"], read["dynamic"]) for read in reads] == [
+        ("DEBUG", "false", False),
+        ("SECRET_KEY", None, False),
+        ("REGION", None, False),
+        ("TIMEOUT", "30", False),
+        ("f'{PREFIX}_NAME'", "svc", True),
+    ]
+
+
+def test_groups_are_unique_per_module(tmp_path: Path) -> None:
+    reads = scan_env_vars(tmp_path, _write(tmp_path))
+
+    groups = {group["module"]: group["variables"] for group in env_var_groups(reads)}
+    assert list(groups) == ["cmd/main.go", "settings.py"]
+    assert groups["cmd/main.go"] == [
+        {"name": "DATABASE_URL", "dynamic": False, "default": None, "lines": [11]},
+        {"name": "PORT", "dynamic": False, "default": "8080", "lines": [10, 14]},
+        {"name": 'prefix + "_HOST"', "dynamic": True, "default": None, "lines": [12]},
+    ]
+    assert env_var_names(reads) == [
+        "DATABASE_URL",
+        "DEBUG",
+        "PORT",
+        "REGION",
+        "SECRET_KEY",
+        "TIMEOUT",
+    ]
+
+
+def test_analyzer_exposes_env_vars_and_honors_disable(tmp_path: Path) -> None:
+    _write(tmp_path)
+
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert {read["name"] for read in result["env_vars"]} >= {"PORT", "SECRET_KEY"}
+
+    disabled = {"env_vars": {"enabled": False}}
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=disabled).analyze()
+    assert result["env_vars"] == []
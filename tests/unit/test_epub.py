@@ -0,0 +1,128 @@
+from __future__ import annotations
+
+import io
+import xml.etree.ElementTree as ET  # noqa: N817  # nosec B405
+import zipfile
+from pathlib import Path
+
+from docgenie import cli
+from docgenie.epub import (
+    CONTAINER_NS,
+    NCX_NS,
+    OPF_NS,
+    XHTML_NS,
+    EpubGenerator,
+    to_xhtml,
+    validate_epub,
+)
+
+README = """# Shop
+
+Order handling. Start with [orders](#src-orders-py).
+
+## Installation
+
+```bash
+pip install shop
+```
+
+## Modules
+
+### `src/orders.py`
+
+Places orders & refunds. See [Installation](#installation).
+
+#### Functions
+
+### `src/users.py`
+
+#### Functions
+
+## Contributing
+
+Line one<br>line two &para;
+"""
+
+
+def _book(tmp_path: Path) -> zipfile.ZipFile:
+    path = tmp_path / "docs.epub"
+    EpubGenerator().generate_from_readme(README, path, "Shop")
+    return zipfile.ZipFile(path)
+
+
+def test_epub_unzips_to_valid_structure(tmp_path: Path) -> None:
+    with _book(tmp_path) as book:
+        first = book.infolist()[0]
+        assert (first.filename, first.compress_type) == ("mimetype", zipfile.ZIP_STORED)
+        assert book.read("mimetype") == b"application/epub+zip"
+        container = ET.fromstring(book.read("META-INF/container.xml"))
+        rootfile = container.find(f".//{{{CONTAINER_NS}}}rootfile")
+        assert rootfile is not None and rootfile.get("full-path") == "OEBPS/content.opf"
+
+        package = ET.fromstring(book.read("OEBPS/content.opf"))
+        spine = package.find(f"{{{OPF_NS}}}spine")
+        assert spine is not None and spine.get("toc") == "ncx"
+        assert [ref.get("idref") for ref in spine] == [
+            "nav",
+            *(f"chapter-00{number}" for number in range(1, 7)),
+        ]
+        for item in package.iterfind(f"{{{OPF_NS}}}manifest/*"):
+            data = book.read(f"OEBPS/{item.get('href')}")
+            if item.get("media-type") == "application/xhtml+xml":
+                ET.fromstring(data)
+
+        ncx = ET.fromstring(book.read("OEBPS/toc.ncx"))
+        labels = [text.text for text in ncx.iter(f"{{{NCX_NS}}}text")]
+        assert labels == [
+            "Shop",
+            "Shop",
+            "Installation",
+            "Modules",
+            "src/orders.py",
+            "src/users.py",
+            "Contributing",
+        ]
+        nav = book.read("OEBPS/nav.xhtml").decode("utf-8")
+        assert 'epub:type="toc"' in nav
+        # Modules nest under their section, and link to IDs deduplicated across the book.
+        assert (
+            '<a href="chapter-003.xhtml#modules">Modules</a><ol>'
+            '<li><a href="chapter-004.xhtml#src-orders-py">src/orders.py</a></li>'
+            '<li><a href="chapter-005.xhtml#src-users-py">src/users.py</a></li></ol>'
+        ) in nav
+
+        orders = ET.fromstring(book.read("OEBPS/chapter-004.xhtml"))
+        ids = [element.get("id") for element in orders.iter() if element.get("id")]
+        assert ids == ["src-orders-py", "functions"]
+        links = [link.get("href") for link in orders.iter(f"{{{XHTML_NS}}}a")]
+        assert links == ["chapter-002.xhtml#installation"]
+        users = book.read("OEBPS/chapter-005.xhtml").decode("utf-8")
+        assert 'id="functions-2"' in users
+        title_page = book.read("OEBPS/chapter-001.xhtml").decode("utf-8")
+        assert 'href="chapter-004.xhtml#src-orders-py"' in title_page
+        assert "headerlink" not in title_page
+
+
+def test_epub_validation_and_format_option(tmp_path: Path) -> None:
+    assert cli._validate_format("EPUB") == "epub"
+    assert cli._build_outputs(cli._validate_format("md,epub"), None, tmp_path) == [
+        ("markdown", tmp_path / "README.md"),
+        ("epub", tmp_path / "docs.epub"),
+    ]
+    assert to_xhtml('<p>a<br>b &para;<img src="x.png" alt><div>open') == (
+        '<p>a<br/>b ¶<img src="x.png" alt=""/><div>open</div></p>'
+    )
+
+    with _book(tmp_path) as book:
+        assert validate_epub((tmp_path / "docs.epub").read_bytes()) == []
+        entries = [(info, book.read(info.filename)) for info in book.infolist()]
+    broken = io.BytesIO()
+    with zipfile.ZipFile(broken, "w", zipfile.ZIP_DEFLATED) as archive:
+        for info, data in entries:
+            if info.filename != "OEBPS/toc.ncx":
+                archive.writestr(info.filename, data)
+    assert validate_epub(broken.getvalue()) == [
+        "`mimetype` is compressed",
+        "OEBPS/toc.ncx is in the manifest but not the archive",
+    ]
+    assert validate_epub(b"not a zip") == ["not a zip archive"]
@@ -0,0 +1,117 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.external_calls import import_aliases
+from docgenie.graph_export import mermaid_impact_graph
+from docgenie.html_sections import build_impact_graph_data
+
+MAIN_GO = """package main
+
+import (
+\t"fmt"
+
+\t"example.com/app/services"
+)
+
+func main() {
+\tfmt.Println(services.NewUserService())
+}
+"""
+
+USER_GO = """package services
+
+// UserService manages users.
+type UserService struct{}
+
+// NewUserService builds a UserService.
+func NewUserService() *UserService { return &UserService{} }
+"""
+
+CACHE_GO = """package services
+
+import "github.com/redis/go-redis/v9"
+
+// CacheService caches users in Redis.
+type CacheService struct {
+\tclient *redis.Client
+}
+
+// NewCacheService connects to Redis.
+func NewCacheService(addr string) *CacheService {
+\treturn &CacheService{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+"""
+
+# A repository function sharing its name with the Redis constructor.
+CLIENT_GO = """package client
+
+// NewClient builds an API client.
+func NewClient() int { return 0 }
+"""
+
+
+def _analysis(root: Path) -> dict:
+    (root / "services").mkdir()
+    (root / "client").mkdir()
+    (root / "go.mod").write_text("module example.com/app\n", encoding="utf-8")
+    (root / "main.go").write_text(MAIN_GO, encoding="utf-8")
+    (root / "services" / "user.go").write_text(USER_GO, encoding="utf-8")
+    (root / "services" / "cache.go").write_text(CACHE_GO, encoding="utf-8")
+    (root / "client" / "client.go").write_text(CLIENT_GO, encoding="utf-8")
+    return CodebaseAnalyzer(str(root), enable_tree_sitter=False).analyze()
+
+
+def test_redis_client_is_external_and_user_service_internal(tmp_path: Path) -> None:
+    analysis = _analysis(tmp_path)
+
+    assert analysis["external_calls"] == {
+        "main.go": ["fmt.Println"],
+        "services/cache.go": ["redis.NewClient"],
+    }
+    # `redis.NewClient` does not reference the repository's own `NewClient`.
+    assert "NewClient" not in analysis["symbol_references"]["services/cache.go"]
+
+    # `services` resolves to the repository, so its call is a reference, not an external call.
+    graph = build_impact_graph_data(analysis)
+    node_types = {node["id"]: node["type"] for node in graph["nodes"]}
+    assert node_types["external:redis.NewClient"] == "external"
+    assert "external:services.NewUserService" not in node_types
+    assert {
+        "source": "file:main.go",
+        "target": "symbol:services/user.go::NewUserService",
+        "kind": "references",
+    } in graph["edges"]
+
+
+def test_import_aliases_follow_package_naming() -> None:
+    assert import_aliases("github.com/redis/go-redis/v9") == {"redis"}
+    assert import_aliases("gopkg.in/yaml.v3") == {"yaml"}
+    assert import_aliases("os.path") == {"os", "path"}
+
+
+def test_graph_mutes_or_drops_external_calls(tmp_path: Path) -> None:
+    analysis = _analysis(tmp_path)
+
+    graph = build_impact_graph_data(analysis)
+    external = {node["id"] for node in graph["nodes"] if node["type"] == "external"}
+    assert external == {"external:fmt.Println", "external:redis.NewClient"}
+    call = next(edge for edge in graph["edges"] if edge["target"] == "external:redis.NewClient")
+    assert call == {
+        "source": "file:services/cache.go",
+        "target": "external:redis.NewClient",
+        "kind": "calls",
+    }
+
+    internal = build_impact_graph_data(analysis, scope="internal-only")
+    ids = {node["id"] for node in internal["nodes"]}
+    assert not any(node_id.startswith("external:") for node_id in ids)
+    assert "module:fmt" not in ids
+    # The repository's own package import resolves to its files.
+    assert "module:example.com/app/services" not in ids
+    assert {"file:services/user.go", "file:services/cache.go"} <= ids
+    assert "symbol:services/user.go::NewUserService" in ids
+
+    analysis["config"] = {"template_customizations": {"graph_scope": "internal-only"}}
+    assert "redis.NewClient" not in mermaid_impact_graph(analysis)["diagram"]
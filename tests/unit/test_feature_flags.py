@@ -0,0 +1,86 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.config import get_default_config
+from docgenie.core import CodebaseAnalyzer
+from docgenie.feature_flags import DEFAULT_FLAG_PATTERNS, flag_groups, scan_feature_flags
+
+GO_SOURCE = """package billing
+
+import "example.com/app/flags"
+
+func Charge(name string) {
+	if flags.Enabled("new-checkout") {
+		return
+	}
+	// flags.Enabled("commented-out")
+	_ = ld.BoolVariation("invoice-v2", ctx, false)
+	_ = flags.Enabled(name)
+}
+"""
+
+PY_SOURCE = """from app import featureflags
+
+
+def render():
+    if featureflags.IsOn('new-checkout'):
+        return "new"
+    return client.is_on("custom-flag")
+"""
+
+
+def _write(tmp_path: Path) -> list[Path]:
+    (tmp_path / "billing.go").write_text(GO_SOURCE, encoding="utf-8")
+    (tmp_path / "views.py").write_text(PY_SOURCE, encoding="utf-8")
+    return [tmp_path / "billing.go", tmp_path / "views.py"]
+
+
+def test_flag_lookups_are_grouped_by_flag(tmp_path: Path) -> None:
+    lookups = scan_feature_flags(tmp_path, _write(tmp_path))
+
+    assert [(item["name"], item["file"], item["line"]) for item in lookups] == [
+        ("new-checkout", "billing.go", 6),
+        ("invoice-v2", "billing.go", 10),
+        ("name", "billing.go", 11),
+        ("new-checkout", "views.py", 5),
+    ]
+    assert flag_groups(lookups) == [
+        {"name": "invoice-v2", "dynamic": False, "modules": ["billing.go"]},
+        {"name": "new-checkout", "dynamic": False, "modules": ["billing.go", "views.py"]},
+        {"name": "name", "dynamic": True, "modules": ["billing.go"]},
+    ]
+
+
+def test_configured_patterns_extend_detection(tmp_path: Path) -> None:
+    _write(tmp_path)
+    patterns = [*DEFAULT_FLAG_PATTERNS, "client.is_on"]
+    assert get_default_config()["feature_flags"]["patterns"] == list(DEFAULT_FLAG_PATTERNS)
+
+    analysis = CodebaseAnalyzer(
+        str(tmp_path),
+        enable_tree_sitter=False,
+        config={"feature_flags": {"patterns": patterns}},
+    ).analyze()
+
+    assert {item["name"] for item in analysis["feature_flags"]} == {
+        "new-checkout",
+        "invoice-v2",
+        "name",
+        "custom-flag",
+    }
+
+
+def test_generic_variation_calls_are_not_flags_and_names_stay_raw(tmp_path: Path) -> None:
+    (tmp_path / "app.js").write_text(
+        "const a = ldClient.variation('dark-mode', user, false);\n"
+        "const b = product.variation('size');\n"
+        "const c = flags.Enabled(mode || 'beta');\n",
+        encoding="utf-8",
+    )
+
+    lookups = scan_feature_flags(tmp_path, [tmp_path / "app.js"])
+
+    assert [item["name"] for item in lookups] == ["dark-mode", "mode || 'beta'"]
+    # Escaping `|` is left to each template's table syntax.
+    assert flag_groups(lookups)[1]["name"] == "mode || 'beta'"
@@ -0,0 +1,104 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie.config import load_config
+from docgenie.core import CodebaseAnalyzer
+from docgenie.file_rules import EXCLUDED_REASON, FileRules, glob_regex
+
+
+@pytest.mark.parametrize(
+    ("pattern", "path", "matches"),
+    [
+        ("*.pb.go", "api/v1/user.pb.go", True),
+        ("*.pb.go", "api/v1/user.go", False),
+        ("src/*.py", "src/app.py", True),
+        ("src/*.py", "src/pkg/app.py", False),
+        ("src/**/*.py", "src/pkg/deep/app.py", True),
+        ("src/**/*.py", "src/app.py", True),
+        ("vendor/", "vendor/github.com/x/y.go", True),
+        ("./docs", "docs/index.md", True),
+        ("test_[!a]*.py", "tests/test_b.py", True),
+        ("test_[!a]*.py", "tests/test_a.py", False),
+    ],
+)
+def test_glob_regex(pattern: str, path: str, matches: bool) -> None:
+    assert bool(glob_regex(pattern).fullmatch(path)) is matches
+
+
+def test_exclude_beats_include_within_a_layer() -> None:
+    rules = FileRules.from_globs([(["src/**", "*.py"], ["*_test.py", "src/gen/"])])
+
+    assert not rules.excluded("src/app.py")
+    assert not rules.excluded("tools/build.py")
+    assert rules.excluded("src/app_test.py")
+    assert rules.excluded("src/gen/models.py")
+    # With includes present, files matching no rule are left out.
+    assert rules.excluded("README.md")
+    assert rules.excluded("src/gen", is_dir=True)
+    assert not rules.excluded("docs", is_dir=True)
+
+
+def test_later_layers_override_earlier_ones() -> None:
+    rules = FileRules.from_globs(
+        [
+            (["src/**"], ["src/gen/"]),
+            (["src/gen/keep.py"], ["src/legacy/**"]),
+        ]
+    )
+
+    assert not rules.excluded("src/gen/keep.py")
+    assert rules.excluded("src/gen/other.py")
+    assert rules.excluded("src/legacy/old.py")
+    assert not rules.excluded("src/app.py")
+    # The directory must still be walked so the re-included file is found.
+    assert not rules.excluded("src/gen", is_dir=True)
+
+
+def test_no_rules_exclude_nothing() -> None:
+    assert not FileRules.from_config({"include": [], "exclude": []}).excluded("a/b.py")
+    assert not FileRules.from_config(None).excluded("a/b.py")
+
+
+def _tree(root: Path) -> None:
+    files = ("src/app.py", "src/app_test.py", "src/gen/models.py", "src/gen/keep.py", "setup.py")
+    for rel in files:
+        path = root / rel
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text("def run():\n    return 1\n", encoding="utf-8")
+
+
+def test_analyzer_counts_excluded_files(tmp_path: Path) -> None:
+    _tree(tmp_path)
+    config = {
+        "files": {
+            "include": ["src/**"],
+            "exclude": ["*_test.py", "src/gen/"],
+            "overrides": {"include": ["src/gen/keep.py"], "exclude": []},
+        }
+    }
+
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    files = sorted(
+        Path(func["file"]).relative_to(tmp_path).as_posix() for func in result["functions"]
+    )
+    assert files == ["src/app.py", "src/gen/keep.py"]
+    assert result["skipped_reasons"][EXCLUDED_REASON] == 3  # noqa: PLR2004
+
+
+def test_files_section_from_docgenie_toml_and_gitignore_switch(tmp_path: Path) -> None:
+    _tree(tmp_path)
+    (tmp_path / ".gitignore").write_text("setup.py\n", encoding="utf-8")
+    (tmp_path / "docgenie.toml").write_text(
+        '[files]\nexclude = ["src/**"]\ngitignore = false\n', encoding="utf-8"
+    )
+
+    config = load_config(tmp_path)
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    assert config["files"] == {"include": [], "exclude": ["src/**"], "gitignore": False}
+    assert [Path(func["file"]).name for func in result["functions"]] == ["setup.py"]
+    assert "gitignore" not in result["skipped_reasons"]
@@ -2,7 +2,10 @@ from __future__ import annotations
 
 from pathlib import Path
 
+import pytest
+
 from docgenie.generator import ReadmeGenerator
+from docgenie.html_generator import HTMLGenerator
 
 
 def _base() -> dict:
@@ -264,7 +267,7 @@ def test_quality_report_and_template_section() -> None:
     analysis["dependencies"] = {"requirements.txt": ["requests"]}
     analysis["project_structure"] = {"root": {"files": ["README.md"], "dirs": []}, "tests": {}}
 
-    quality = gen._build_quality_report(analysis)
+    quality = gen.quality_report(analysis)
     assert quality["score"] >= 75
     assert quality["confidence"] == "High"
 
@@ -292,3 +295,79 @@ def test_generator_redaction_and_package_docs(tmp_path: Path) -> None:
     artifacts = gen.generate_package_docs(analysis2, tmp_path / ".docgenie" / "packages")
     assert "pkg-a" in artifacts
     assert (tmp_path / ".docgenie" / "packages" / "pkg-a" / "README.md").exists()
+
+
+def test_generate_adoc_format(tmp_path: Path) -> None:
+    gen = ReadmeGenerator()
+    analysis = _base()
+    analysis["root_path"] = str(tmp_path)
+    analysis["functions"] = [
+        {"name": "run", "file": str(tmp_path / "main.py"), "line": 3, "docstring": "Run it."}
+    ]
+    target = tmp_path / "README.adoc"
+    content = gen.generate(analysis, str(target), output_format="adoc")
+
+    assert content.startswith("= Proj")
+    assert "== Documentation Quality" in content
+    assert "|===" in content
+    assert "`run`" in content
+    assert "## " not in content
+    assert target.read_text(encoding="utf-8") == content
+
+    with pytest.raises(ValueError, match="Unsupported README format"):
+        gen.generate(analysis, None, output_format="rst")
+
+
+def test_generate_confluence_format(tmp_path: Path) -> None:
+    gen = ReadmeGenerator()
+    analysis = _base()
+    analysis["root_path"] = str(tmp_path)
+    analysis["functions"] = [
+        {"name": "run", "file": str(tmp_path / "main.py"), "line": 3, "docstring": "Run <it> & go."}
+    ]
+    analysis["config"] = {"template_customizations": {"autolink": True}}
+    target = tmp_path / "README.confluence.xhtml"
+    content = gen.generate(analysis, str(target), output_format="confluence")
+
+    assert content.lstrip().startswith("<h1>Proj</h1>")
+    assert '<ac:structured-macro ac:name="toc">' in content
+    assert '<ac:structured-macro ac:name="info">' in content
+    assert '<ac:parameter ac:name="language">bash</ac:parameter>' in content
+    assert "<![CDATA[" in content
+    assert "<p>Run &lt;it&gt; &amp; go.</p>" in content
+    assert "Monorepo Inventory" not in content
+    assert "HTTP Endpoints" not in content
+    assert "<table><tbody>\n</tbody></table>" not in content
+    assert "## " not in content
+    assert target.read_text(encoding="utf-8") == content
+
+
+JINJA_DOCSTRING = "Render {{ not_a_var }} with {% raw %} and {# note #}.\n{% if debug %}"
+
+
+@pytest.mark.parametrize("output_format", ["markdown", "adoc", "confluence"])
+def test_docstring_with_jinja_syntax_renders_verbatim(tmp_path: Path, output_format: str) -> None:
+    analysis = _base()
+    analysis["root_path"] = str(tmp_path)
+    analysis["functions"] = [
+        {"name": "run", "file": str(tmp_path / "main.py"), "line": 3, "docstring": JINJA_DOCSTRING}
+    ]
+
+    content = ReadmeGenerator().generate(analysis, None, output_format=output_format)
+
+    assert "Render {{ not_a_var }} with {% raw %} and {# note #}." in content
+    assert "{% if debug %}" in content
+
+
+def test_html_keeps_brace_lines_of_docstrings(tmp_path: Path) -> None:
+    analysis = _base()
+    analysis["root_path"] = str(tmp_path)
+    analysis["functions"] = [
+        {"name": "run", "file": str(tmp_path / "main.py"), "line": 3, "docstring": JINJA_DOCSTRING}
+    ]
+
+    html = HTMLGenerator().generate_from_analysis(analysis, None)
+
+    # Without the fix, attr_list turned the last line into `<p if="if" debug="debug">`.
+    assert "{% if debug %}" in html
+    assert 'debug="debug"' not in html
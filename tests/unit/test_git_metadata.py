@@ -0,0 +1,99 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie import git_metadata
+from docgenie.git_metadata import UNCOMMITTED_SHA, attach_git_metadata, parse_line_porcelain
+from docgenie.index_store import IndexStore
+from docgenie.module_index import build_module_index
+
+OLD = "a" * 40
+NEW = "b" * 40
+
+
+def _porcelain(entries: list[tuple[str, str, int]]) -> str:
+    out: list[str] = []
+    for idx, (sha, author, when) in enumerate(entries, 1):
+        out += [
+            f"{sha} {idx} {idx} 1",
+            f"author {author}",
+            f"author-time {when}",
+            "summary change",
+            "filename mod.py",
+            f"\tline {idx}",
+        ]
+    return "\n".join(out)
+
+
+def _fake_repo(monkeypatch: pytest.MonkeyPatch, root: Path, output: str) -> list[tuple[str, ...]]:
+    calls: list[tuple[str, ...]] = []
+
+    class FakeGit:
+        def blame(self, *args: str) -> str:
+            calls.append(args)
+            return output
+
+    class FakeRepo:
+        def __init__(self, *_args, **_kwargs) -> None:
+            self.working_tree_dir = str(root)
+            self.head = type("Head", (), {"commit": type("Commit", (), {"hexsha": "c" * 40})()})()
+            self.git = FakeGit()
+
+    monkeypatch.setattr(git_metadata, "Repo", FakeRepo)
+    return calls
+
+
+def test_parse_line_porcelain() -> None:
+    blame = parse_line_porcelain(_porcelain([(OLD, "Ann", 100), (NEW, "Bo", 200)]))
+    assert blame["lines"] == [OLD, NEW]
+    assert blame["commits"][NEW] == {"author": "Bo", "time": 200}
+
+
+def test_attach_git_metadata_uses_newest_commit_in_range_and_caches(
+    monkeypatch: pytest.MonkeyPatch, tmp_path: Path
+) -> None:
+    source = tmp_path / "mod.py"
+    source.write_text("def a():\n    pass\n\ndef b():\n    pass\n", encoding="utf-8")
+    calls = _fake_repo(
+        monkeypatch,
+        tmp_path,
+        _porcelain(
+            [
+                (OLD, "Ann", 1_700_000_000),
+                (NEW, "Bo", 1_710_000_000),
+                (OLD, "Ann", 1_700_000_000),
+                (UNCOMMITTED_SHA, "Not Committed Yet", 1_720_000_000),
+                (UNCOMMITTED_SHA, "Not Committed Yet", 1_720_000_000),
+            ]
+        ),
+    )
+    symbols = [
+        {"name": "a", "file": str(source), "line": 1, "end_line": 2},
+        {"name": "b", "file": str(source), "line": 4, "end_line": 5},
+    ]
+    store = IndexStore(tmp_path)
+
+    assert attach_git_metadata(tmp_path, symbols, store=store) == 1
+    assert symbols[0]["last_modified"] == {
+        "date": "2024-03-09",
+        "author": "Bo",
+        "commit": NEW[:12],
+    }
+    assert "last_modified" not in symbols[1]
+
+    attach_git_metadata(tmp_path, [dict(symbols[0])], store=store)
+    assert len(calls) == 1
+    assert store.stats()["blame_entries"] == 1
+    store.close()
+
+    index = build_module_index({"root_path": str(tmp_path), "functions": symbols, "classes": []})
+    assert index[0]["has_last_updated"] is True
+    assert [sym["last_updated"] for sym in index[0]["symbols"]] == ["2024-03-09 (Bo)", ""]
+
+
+def test_attach_git_metadata_skips_outside_git(tmp_path: Path) -> None:
+    symbols = [{"name": "a", "file": str(tmp_path / "a.py"), "line": 1}]
+    assert attach_git_metadata(tmp_path / "missing", symbols) == 0
+    assert "last_modified" not in symbols[0]
@@ -0,0 +1,99 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.languages import GoParser
+from docgenie.module_index import concurrency_note
+
+GO_SOURCE = """package service
+
+import "sync"
+
+// UserService manages users in memory.
+type UserService struct {
+	mu    sync.RWMutex
+	users map[string]*User
+	Events chan<- string
+}
+
+// User is a stored account.
+type User struct {
+	ID string
+}
+
+// GetUser looks a user up by ID.
+func (s *UserService) GetUser(id string) *User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.users[id]
+}
+
+// CreateUser stores a user and announces it.
+func (s *UserService) CreateUser(u *User) {
+	s.mu.Lock()
+	s.users[u.ID] = u
+	s.mu.Unlock()
+	go func() { s.Events <- u.ID }()
+}
+
+// Fanout starts one worker per job.
+func Fanout(jobs []string, done chan struct{}) {
+	for _, job := range jobs {
+		go process(job)
+	}
+	<-done
+}
+
+// Name is the package name ("go run" style words are not goroutines).
+func Name() string { return "go service" }
+
+func process(job string) {}
+"""
+
+
+def test_rwmutex_holder_is_flagged_safe_for_concurrent_use() -> None:
+    result = GoParser().parse(GO_SOURCE, Path("service.go"), "go")
+    classes = {cls.name: cls for cls in result.classes}
+    service = classes["UserService"]
+
+    assert service.concurrency == {
+        "mutexes": [{"name": "mu", "type": "sync.RWMutex"}],
+        "channels": [{"name": "Events", "type": "chan<- string"}],
+    }
+    assert classes["User"].concurrency == {}
+    methods = {method.name: method.concurrency for method in service.methods}
+    assert methods == {
+        "GetUser": {"goroutines": False, "channels": False, "locks": ["RLock"]},
+        "CreateUser": {"goroutines": True, "channels": True, "locks": ["Lock"]},
+    }
+    functions = {func.name: func.concurrency for func in result.functions}
+    assert functions["Fanout"] == {"goroutines": True, "channels": True, "locks": []}
+    assert functions["Name"] == {}
+
+    assert concurrency_note(service.to_public_dict()) == (
+        "Safe for concurrent use: guarded by `mu` (`sync.RWMutex`); channel fields "
+        "`Events` (`chan<- string`); `CreateUser` launches goroutines; `GetUser` and "
+        "`CreateUser` take the lock"
+    )
+    assert concurrency_note({"concurrency": methods["GetUser"]}) == "Takes a read lock"
+    assert (
+        concurrency_note({"concurrency": functions["Fanout"]})
+        == "Launches goroutines and uses channels"
+    )
+
+
+def test_concurrency_notes_reach_the_api_reference(tmp_path: Path) -> None:
+    (tmp_path / "service.go").write_text(GO_SOURCE, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    api = ReadmeGenerator()._prepare_context(analysis)["api_docs"]
+    classes = {cls["name"]: cls for cls in api["classes"]}
+    functions = {func["name"]: func for func in api["functions"]}
+    assert str(classes["UserService"]["concurrency"]).startswith(
+        "Safe for concurrent use: guarded by `mu`"
+    )
+    assert classes["User"]["concurrency"] is None
+    assert functions["Fanout"]["concurrency"] == "Launches goroutines and uses channels"
+    assert functions["Name"]["concurrency"] is None
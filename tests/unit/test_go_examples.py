@@ -0,0 +1,86 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.go_examples import example_target, find_go_examples
+from docgenie.readme_quality import count_examples, undocumented_symbols
+
+
+def _project(tmp_path: Path) -> dict[str, Any]:
+    svc = tmp_path / "svc"
+    svc.mkdir()
+    (svc / "user.go").write_text(
+        "package svc\n\n// UserService manages users.\ntype UserService struct{}\n\n"
+        "// CreateUser stores a user.\nfunc (s *UserService) CreateUser(name string) error {\n"
+        "\treturn nil\n}\n\n// New returns a service.\nfunc New() *UserService { return nil }\n",
+        encoding="utf-8",
+    )
+    (svc / "user_test.go").write_text(
+        "package svc_test\n\n"
+        "func ExampleUserService_CreateUser() {\n"
+        "\ts := svc.New()\n"
+        "\tif err := s.CreateUser(\"ada\"); err != nil {\n"
+        "\t\tpanic(err)\n"
+        "\t}\n"
+        '\tfmt.Println("created")\n'
+        "\t// Output: created\n"
+        "}\n\n"
+        "func ExampleNew_second() { fmt.Println(svc.New() != nil) }\n\n"
+        "func Example() {\n\tfmt.Println(\"svc\")\n}\n\n"
+        "func ExampleMissing() { fmt.Println(1) }\n\n"
+        "func TestCreateUser(t *testing.T) {}\n",
+        encoding="utf-8",
+    )
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+
+def test_example_target_follows_go_naming() -> None:
+    assert example_target("Example") is None
+    assert example_target("Example_basic") is None
+    assert example_target("ExampleNew") == "New"
+    assert example_target("ExampleNew_second") == "New"
+    assert example_target("ExampleUserService_CreateUser") == "UserService.CreateUser"
+    assert example_target("ExampleUserService_CreateUser_retry") == "UserService.CreateUser"
+
+
+def test_go_examples_attach_to_their_target_symbol(tmp_path: Path) -> None:
+    analysis = _project(tmp_path)
+    examples = find_go_examples(analysis)
+
+    (create,) = examples["symbols"][("svc", "UserService.CreateUser")]
+    assert create["module"] == "svc/user_test.go"
+    assert create["code"] == (
+        "s := svc.New()\n"
+        'if err := s.CreateUser("ada"); err != nil {\n'
+        "\tpanic(err)\n"
+        "}\n"
+        'fmt.Println("created")\n'
+        "// Output: created"
+    )
+    assert [e["code"] for e in examples["symbols"][("svc", "New")]] == [
+        "fmt.Println(svc.New() != nil)"
+    ]
+    # Package examples and examples of unknown symbols have no target to attach to.
+    assert [e["name"] for e in examples["general"]] == ["Example", "ExampleMissing"]
+    assert count_examples(analysis) == 4
+    # Examples document other symbols; they are not undocumented API themselves.
+    assert [item["name"] for item in undocumented_symbols(analysis["functions"], [])] == [
+        "TestCreateUser"
+    ]
+
+
+def test_go_examples_render_under_symbol_and_in_examples_section(tmp_path: Path) -> None:
+    readme = ReadmeGenerator().generate(_project(tmp_path), None)
+
+    api, rest = readme.split("## Examples\n", 1)
+    section = rest.split("\n## ", 1)[0]
+    service = api.split("#### `UserService`", 1)[1]
+    assert "**Example** (`ExampleUserService_CreateUser`):" in service
+    assert "// Output: created" in service
+    assert "#### `ExampleUserService_CreateUser" not in api
+    assert "### `Example`\n\nFrom `svc/user_test.go:14`." in section
+    assert "### `ExampleMissing`" in section
+    assert "ExampleUserService_CreateUser" not in section
@@ -0,0 +1,104 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.go_interfaces import find_go_implementations, method_shape
+from docgenie.html_sections import build_impact_graph_data
+
+STORE = """package store
+
+// Reader loads records.
+type Reader interface {
+	Get(ctx context.Context, id int64) (*Record, error)
+}
+
+// ReadCloser loads records and releases them.
+type ReadCloser interface {
+	Reader
+	Close() error
+}
+
+// Closer only wraps io.Closer, which is not analyzed.
+type Closer interface {
+	io.Closer
+}
+
+// Record is a stored row.
+type Record struct {
+	ID int64
+}
+"""
+
+MEMORY = """package mem
+
+// Memory keeps records in a map.
+type Memory struct{}
+
+// Get returns a record.
+func (m *Memory) Get(c context.Context, id int64) (rec *store.Record, err error) {
+	return nil, nil
+}
+
+// Cache is keyed by string and so satisfies nothing.
+type Cache struct{}
+
+// Get returns a record.
+func (c Cache) Get(ctx context.Context, key string) (*store.Record, error) { return nil, nil }
+
+// Close satisfies store.ReadCloser.
+func (c Cache) Close() error { return nil }
+"""
+
+CLOSE = """package mem
+
+// Close releases the map.
+func (m *Memory) Close() error { return nil }
+"""
+
+
+def _analyze(tmp_path: Path) -> dict[str, object]:
+    (tmp_path / "store").mkdir()
+    (tmp_path / "mem").mkdir()
+    (tmp_path / "store" / "store.go").write_text(STORE, encoding="utf-8")
+    (tmp_path / "mem" / "mem.go").write_text(MEMORY, encoding="utf-8")
+    (tmp_path / "mem" / "close.go").write_text(CLOSE, encoding="utf-8")
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+
+def test_method_shape_ignores_names_receivers_and_qualifiers() -> None:
+    concrete = method_shape("func (m *Memory) Get(c ctx.Context, a, b int) (n int, err error)")
+    interface = method_shape("Get(context.Context, int, int) (int, error)")
+    assert concrete == interface == ("Get", (("Context", "int", "int"), ("int", "error")))
+    assert method_shape("Close() error") == ("Close", ((), ("error",)))
+    assert method_shape("func (m *Memory) Reset()") == ("Reset", ((), ()))
+
+
+def test_implementations_flatten_embedded_interfaces(tmp_path: Path) -> None:
+    implementations = find_go_implementations(_analyze(tmp_path))
+
+    memory = {"name": "Memory", "module": "mem/mem.go"}
+    assert implementations == {
+        ("store/store.go", "ReadCloser"): [memory],
+        ("store/store.go", "Reader"): [memory],
+    }
+
+
+def test_implementations_render_in_api_docs_and_impact_graph(tmp_path: Path) -> None:
+    result = _analyze(tmp_path)
+
+    graph = build_impact_graph_data(result)
+    implements = {
+        (edge["source"], edge["target"]) for edge in graph["edges"] if edge["kind"] == "implements"
+    }
+    assert implements == {
+        ("symbol:mem/mem.go::Memory", "symbol:store/store.go::Reader"),
+        ("symbol:mem/mem.go::Memory", "symbol:store/store.go::ReadCloser"),
+    }
+
+    content = ReadmeGenerator().generate(result)
+    reader = content.split("#### `Reader`", 1)[1].split("####", 1)[0]
+    assert "**Implementations:**\n- `Memory` (`mem/mem.go`)" in reader
+    closer = content.split("#### `Closer`", 1)[1].split("####", 1)[0]
+    assert "Implementations" not in closer
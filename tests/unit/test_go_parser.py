@@ -0,0 +1,338 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.languages import GoParser
+from docgenie.module_index import build_module_index, field_table, serialization_summary
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = '''package users
+
+import (
+	"context"
+	db "github.com/acme/db"
+)
+
+// User is an account holder.
+type User struct {
+	// ID is the primary key.
+	ID       int64  `json:"id" db:"id"`
+	FullName string `json:"full_name,omitempty" db:"name"`
+	Password string `json:"-"`
+	internal string
+	*Base
+	Meta struct {
+		Tags []string
+	} `json:"meta"`
+}
+
+type (
+	// Store loads users.
+	Store interface {
+		io.Closer
+		Get(ctx context.Context, id int64) (*User, error)
+	}
+	ID string
+)
+
+// GetUser loads a user by id.
+func GetUser(ctx context.Context, id int64) (*User, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("{")
+	}
+	return nil, nil
+}
+
+// Name returns the display name.
+func (u *User) Name() string { return u.FullName }
+
+func (c Client) Fetch(a, b int) {}
+
+func helper() {}
+'''
+
+
+def _parse():
+    return GoParser().parse(SAMPLE, Path("users.go"), "go")
+
+
+def test_go_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("go"), GoParser)
+
+
+def test_go_exported_declarations() -> None:
+    result = _parse()
+    functions = {func.name: func for func in result.functions}
+    assert set(functions) == {"GetUser", "Client.Fetch"}
+    assert functions["GetUser"].args == ["ctx", "id"]
+    assert functions["GetUser"].docstring == "GetUser loads a user by id."
+    assert functions["Client.Fetch"].kind == "method"
+
+    classes = {cls.name: cls for cls in result.classes}
+    assert {name: cls.kind for name, cls in classes.items()} == {
+        "User": "struct",
+        "Store": "interface",
+        "ID": "type",
+    }
+    assert [method.name for method in classes["User"].methods] == ["Name"]
+    assert classes["Store"].bases == ["io.Closer"]
+    assert classes["Store"].docstring == "Store loads users."
+    assert [method.args for method in classes["Store"].methods] == [["ctx", "id"]]
+    assert result.imports == {"context", "github.com/acme/db"}
+
+
+def test_go_struct_field_tags() -> None:
+    user = next(cls for cls in _parse().classes if cls.name == "User")
+    fields = {item.name: item for item in user.fields}
+    assert list(fields) == ["ID", "FullName", "Password", "Base", "Meta"]
+    assert fields["ID"].tags == {"json": "id", "db": "id"}
+    assert fields["ID"].docstring == "ID is the primary key."
+    assert fields["Password"].tags == {"json": "-"}
+    assert fields["Base"].type == "*Base"
+    assert fields["Meta"].tags == {"json": "meta"}
+
+
+def test_serialization_summary() -> None:
+    assert serialization_summary({"json": "id", "db": "id"}) == "json: `id`, db: `id`"
+    assert serialization_summary({"json": "name,omitempty"}) == "json: `name` (omitempty)"
+    assert serialization_summary({"json": "-"}) == "json: omitted"
+    assert serialization_summary({"validate": "required"}) == ""
+    assert field_table([{"name": "Plain", "type": "string", "tags": {}}])["serialization"] is False
+
+
+def test_readme_renders_serialization_column_only_when_tagged(tmp_path: Path) -> None:
+    parsed = GoParser().parse(SAMPLE, tmp_path / "users.go", "go").to_public_dict()
+    analysis = {
+        "project_name": "Users",
+        "root_path": str(tmp_path),
+        "files_analyzed": 1,
+        "languages": {"go": 1},
+        "functions": parsed["functions"],
+        "classes": parsed["classes"],
+        "dependencies": {},
+    }
+    content = ReadmeGenerator().generate(analysis)
+    assert "| Field | Type | Serialization |" in content
+    assert "| `Password` | `string` | json: omitted |" in content
+    assert "| `FullName` | `string` | json: `full_name` (omitempty), db: `name` |" in content
+
+    for cls in analysis["classes"]:
+        for item in cls["fields"]:
+            item["tags"] = {}
+    untagged = ReadmeGenerator().generate(analysis)
+    assert "Serialization" not in untagged
+    assert "| `ID` | `int64` |" in untagged
+
+
+GENERIC_SAMPLE = '''package gen
+
+// Number is satisfied by numeric types.
+type Number interface {
+	~int | ~float64
+}
+
+// Map applies f to every element.
+func Map[T, U any](xs []T, f func(T) U) []U {
+	return nil
+}
+
+// Sum adds the elements of s.
+func Sum[S ~[]E, E Number](s S) E {
+	var total E
+	return total
+}
+
+// Stack is a LIFO of T.
+type Stack[T any] struct {
+	Items []T
+}
+
+// Push adds v on top.
+func (s *Stack[T]) Push(v T) {}
+
+// Pair keys a number.
+type Pair[K comparable, V Number] struct {
+	Key K
+	Val V
+}
+
+type Grid [4]int
+'''
+
+
+def test_go_generic_function_type_params() -> None:
+    result = GoParser().parse(GENERIC_SAMPLE, Path("gen.go"), "go")
+    functions = {func.name: func for func in result.functions}
+
+    assert functions["Map"].type_params == ["T any", "U any"]
+    assert functions["Map"].args == ["xs", "f"]
+    assert functions["Sum"].signature == "func Sum[S ~[]E, E Number](s S) E"
+    assert functions["Sum"].type_params == ["S ~[]E", "E Number"]
+    assert functions["Sum"].args == ["s"]
+
+
+def test_go_generic_struct_type_params() -> None:
+    result = GoParser().parse(GENERIC_SAMPLE, Path("gen.go"), "go")
+    classes = {cls.name: cls for cls in result.classes}
+
+    assert classes["Stack"].type_params == ["T any"]
+    assert classes["Stack"].signature == "type Stack[T any] struct"
+    assert [method.name for method in classes["Stack"].methods] == ["Push"]
+    assert [item.type for item in classes["Stack"].fields] == ["[]T"]
+    assert classes["Pair"].type_params == ["K comparable", "V Number"]
+    # An array length is not a type parameter list.
+    assert classes["Grid"].type_params == []
+
+
+def test_go_generic_constraints_link_and_form_edges(tmp_path: Path) -> None:
+    (tmp_path / "gen.go").write_text(GENERIC_SAMPLE, encoding="utf-8")
+    (tmp_path / "main.go").write_text(
+        "package gen\n\nfunc run() { _ = Map[int, string](nil, nil); _ = Stack[int]{} }\n",
+        encoding="utf-8",
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    graph = build_impact_graph_data(analysis)
+    edges = {(edge["source"], edge["target"], edge["kind"]) for edge in graph["edges"]}
+    assert ("symbol:gen.go::Pair", "symbol:gen.go::Number", "constraint") in edges
+    assert ("symbol:gen.go::Sum", "symbol:gen.go::Number", "constraint") in edges
+    assert ("file:main.go", "symbol:gen.go::Map", "references") in edges
+    assert ("file:main.go", "symbol:gen.go::Stack", "references") in edges
+
+    rows = {sym["name"]: sym for sym in build_module_index(analysis)[0]["symbols"]}
+    assert rows["Pair"]["constraints"] == [
+        {"name": "Number", "module": "gen.go", "anchor": "gen-go"}
+    ]
+    assert rows["Map"]["constraints"] == []
+    assert rows["Pair"]["signature"] == "type Pair[K comparable, V Number] struct"
+
+
+ERRORS_SAMPLE = '''package users
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when no user matches.
+var ErrNotFound = errors.New("user not found")
+
+// CreateUser validates and stores a new user.
+func CreateUser(db *DB, name string) (*User, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	user, err := db.Insert(name)
+	if err != nil {
+		return nil, fmt.Errorf("create user %q: %w", name, err)
+	}
+	return user, nil
+}
+
+// GetUser looks a user up by id.
+func GetUser(db *DB, id int) (*User, error) {
+	user, ok := db.users[id]
+	if !ok {
+		return nil, fmt.Errorf(
+			"get user %d: %w", id, ErrNotFound)
+	}
+	return user, nil
+}
+
+// MustGetUser panics when the user is missing.
+func MustGetUser(db *DB, id int) *User {
+	user, err := GetUser(db, id)
+	if err != nil {
+		panic(err) // unreachable in tests
+	}
+	return user
+}
+
+// Count never fails.
+func Count(db *DB) int {
+	// panic("not here")
+	log := "return nil, err"
+	_ = log
+	return len(db.users)
+}
+
+// Close closes the store.
+func (db *DB) Close() (err error) {
+	defer func() {
+		err = db.conn.Close()
+	}()
+	return
+}
+
+// Ping always succeeds.
+func (db *DB) Ping() error {
+	return nil
+}
+'''
+
+
+def test_go_error_returns_panics_and_wrap_templates() -> None:
+    parsed = GoParser().parse(ERRORS_SAMPLE, Path("users.go"), "go")
+    errors = {func.name: func.errors for func in parsed.functions}
+
+    assert errors["CreateUser"] == {
+        "returns_error": True,
+        "panics": False,
+        "wrapped": ["create user %q: %w"],
+    }
+    # The format string sits on the line after `fmt.Errorf(`.
+    assert errors["GetUser"]["wrapped"] == ["get user %d: %w"]
+    assert errors["MustGetUser"] == {"returns_error": False, "panics": True, "wrapped": []}
+    # Comments and strings that look like `panic(` or `return nil, err` are ignored.
+    assert errors["Count"] == {}
+    # A bare return with a named `err error` result may hand back an error.
+    assert errors["DB.Close"]["returns_error"] is True
+    assert errors["DB.Ping"] == {}
+
+
+def test_go_error_notes_in_api_docs() -> None:
+    parsed = GoParser().parse(ERRORS_SAMPLE, Path("users.go"), "go").to_public_dict()
+
+    api_docs = ReadmeGenerator()._generate_api_docs(parsed["functions"], [], {})
+
+    notes = {func["name"]: func["errors"] for func in api_docs["functions"]}
+    assert notes["CreateUser"] == {
+        "summary": "May return an error",
+        "wrapped": ["create user %q: %w"],
+    }
+    assert notes["MustGetUser"] == {"summary": "May panic", "wrapped": []}
+    assert notes["Count"] is None
+
+
+def test_user_service_methods_nest_under_receiver_with_pointer_marker(tmp_path: Path) -> None:
+    (tmp_path / "user.go").write_text(
+        "package users\n\n// UserService manages users.\ntype UserService struct{}\n\n"
+        "// Count returns the number of users.\nfunc (s UserService) Count() int { return 0 }\n",
+        encoding="utf-8",
+    )
+    (tmp_path / "service.go").write_text(
+        "package users\n\n// CreateUser stores a user.\n"
+        "func (s *UserService) CreateUser(name string) error { return nil }\n\n"
+        "// New returns a service.\nfunc New() *UserService { return &UserService{} }\n",
+        encoding="utf-8",
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    api_docs = ReadmeGenerator()._generate_api_docs(
+        analysis["functions"], analysis["classes"], {}, root_path=tmp_path
+    )
+
+    assert [func["name"] for func in api_docs["functions"]] == ["New"]
+    (service,) = api_docs["classes"]
+    assert [(m["name"], m["receiver"]) for m in service["methods"]] == [
+        ("Count", "UserService"),
+        ("CreateUser", "*UserService"),
+    ]
+    rows = {sym["name"]: sym for sym in build_module_index(analysis)[0]["symbols"]}
+    assert rows["UserService.CreateUser"]["kind"] == "method"
+    assert rows["UserService.CreateUser"]["signature"] == (
+        "func (s *UserService) CreateUser(name string) error"
+    )
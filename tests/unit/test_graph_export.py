@@ -0,0 +1,136 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.graph_export import mermaid_from_graph, mermaid_impact_graph, plantuml_class_diagram
+
+
+def _analysis(root: Path) -> dict:
+    return {
+        "project_name": "Proj",
+        "root_path": str(root),
+        "files_analyzed": 2,
+        "languages": {"go": 2},
+        "file_imports": {"api/users.go": ["fmt", "db/store.go"], "db/store.go": []},
+        "functions": [{"name": "GetUser", "file": str(root / "api/users.go"), "args": []}],
+        "classes": [],
+    }
+
+
+def test_mermaid_graph_nodes_and_edges(tmp_path: Path) -> None:
+    result = mermaid_impact_graph(_analysis(tmp_path))
+    diagram = result["diagram"]
+    assert diagram.startswith("graph TD\n")
+    assert 'n0["api/users.go"]' in diagram
+    assert '(["fmt"])' in diagram
+    assert '{{"GetUser<br/>api/users.go"}}' in diagram
+    assert "n0 -->|import| n1" in diagram
+    assert "-->|defines|" in diagram
+    assert "classDef symbol" in diagram
+    assert result["note"] is None
+
+
+def test_mermaid_graph_truncation_note(tmp_path: Path) -> None:
+    result = mermaid_impact_graph(_analysis(tmp_path), max_nodes=2)
+    assert result["diagram"].count('"]') == 2
+    assert result["note"] == "Showing 2 of 4 nodes; 2 nodes and 2 edges omitted."
+    assert mermaid_from_graph({"nodes": [], "edges": []}) == ""
+
+
+def test_mermaid_graph_escapes_labels() -> None:
+    graph = {"nodes": [{"id": "module:a|b", "label": 'say "hi"', "type": "module"}], "edges": []}
+    assert 'n0(["say #quot;hi#quot;"])' in mermaid_from_graph(graph)
+
+
+def test_readme_embeds_mermaid_block_when_enabled(tmp_path: Path) -> None:
+    analysis = _analysis(tmp_path)
+    assert "```mermaid" not in ReadmeGenerator().generate(analysis)
+
+    analysis["config"] = {"template_customizations": {"graph_format": "mermaid"}}
+    content = ReadmeGenerator().generate(analysis)
+    assert "## Dependency Graph" in content
+    assert "```mermaid\ngraph TD" in content
+    adoc = ReadmeGenerator().generate(analysis, output_format="adoc")
+    assert "[mermaid]\n....\ngraph TD" in adoc
+
+
+JAVA_SERVICE = """package shop;
+
+/** Stores users. */
+public interface Repo {
+    User find(String id);
+}
+
+/** Base service. */
+public abstract class Base {
+    public void start() {}
+}
+
+/** User service. */
+public class UserService extends Base implements Repo {
+    public User find(String id) { return null; }
+}
+"""
+
+GO_STORE = """package store
+
+// Store reads items.
+type Store interface {
+\tGet(id string) string
+}
+
+// Mem keeps items in memory.
+type Mem struct {
+\tItems map[string]string
+\tNext  *Mem
+}
+
+// Get returns an item.
+func (m Mem) Get(id string) string { return m.Items[id] }
+
+// Cache wraps a Store.
+type Cache struct {
+\tBackend Store
+}
+"""
+
+
+def _oo_project(root: Path) -> dict:
+    (root / "Service.java").write_text(JAVA_SERVICE, encoding="utf-8")
+    (root / "store.go").write_text(GO_STORE, encoding="utf-8")
+    return CodebaseAnalyzer(str(root), enable_tree_sitter=False).analyze()
+
+
+def test_plantuml_class_diagram_relationships(tmp_path: Path) -> None:
+    analysis = _oo_project(tmp_path)
+    result = plantuml_class_diagram(analysis)
+    lines = result["diagram"].splitlines()
+
+    assert lines[0] == "@startuml" and lines[-1] == "@enduml"
+    assert 'interface "Repo" as C0 {' in lines
+    assert "  Items : map[string]string" in lines
+    # Java `extends` and `implements`, Go's implicit interface and a field typed as a
+    # documented type; the self-referencing `Next *Mem` field draws no edge.
+    assert "C1 <|-- C2" in lines
+    assert "C0 <|.. C2" in lines
+    assert "C3 <|.. C4" in lines
+    assert "C5 *-- C3 : Backend" in lines
+    assert not any(line.startswith("C4 *--") for line in lines)
+    assert result["note"] is None
+
+    analysis["config"] = {"template_customizations": {"graph_format": "plantuml"}}
+    content = ReadmeGenerator().generate(analysis)
+    assert "## Class Diagram\n\n```plantuml\n@startuml" in content
+    assert "```mermaid" not in content
+
+
+def test_plantuml_class_diagram_truncation(tmp_path: Path) -> None:
+    result = plantuml_class_diagram(_oo_project(tmp_path), max_classes=5, max_members=1)
+
+    assert 'class "Cache"' not in result["diagram"]
+    assert "C5" not in result["diagram"]
+    assert "  .. 2 more .." in result["diagram"]
+    assert result["note"] == "Showing 5 of 6 types; 1 omitted."
+    assert plantuml_class_diagram({"classes": []}) == {"diagram": "", "note": None}
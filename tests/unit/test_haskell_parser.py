@@ -0,0 +1,149 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import HaskellParser
+from docgenie.parsers import ParserRegistry
+
+# A Haddock-documented module, as `cabal haddock` expects it.
+STATS_HS = """{-# LANGUAGE BangPatterns #-}
+-- | Small statistics helpers.
+module Data.Stats
+  ( weightedMean
+  , Shape (..)
+  , Config (cfgName)
+  , Container (empty, insert)
+  , (<+>)
+  ) where
+
+import qualified Data.Map.Strict as Map
+import Data.List (foldl')
+
+-- | Weighted mean of a list of values.
+--
+-- Each value is weighted by the matching weight.
+weightedMean
+  :: [Double] -- ^ values
+  -> [Double] -- ^ weights
+  -> Double
+weightedMean xs ws = total / sum ws
+  where
+    total = foldl' (+) 0 (zipWith (*) xs ws)
+
+-- Not a Haddock comment.
+clamp :: Double -> Double
+clamp !x
+  | x < 0 = 0
+  | otherwise = x
+
+-- | Add two vectors.
+(<+>) :: (Double, Double) -> (Double, Double) -> (Double, Double)
+(a, b) <+> (c, d) = (a + c, b + d)
+infixl 6 <+>
+
+-- | A geometric shape.
+data Shape
+  = Circle Double
+  | Rect Double Double
+  deriving (Show, Eq)
+
+{-| Runtime configuration. -}
+data Config = Config
+  { cfgName :: String -- ^ Display name
+  , cfgPort, cfgAdmin :: Int
+    -- ^ Ports to listen on
+  } deriving stock (Show)
+
+newtype Wrapper = Wrapper { unwrap :: Int }
+
+-- | Things that hold values.
+class (Eq a, Show (f a)) => Container f a | f -> a where
+  -- | The empty container.
+  empty :: f a
+  insert :: a -> f a -> f a
+  size :: f a -> Int
+  size _ = 0
+
+instance Show Wrapper where
+  show (Wrapper n) = "Wrapper " ++ show n
+"""
+
+
+def test_haskell_signatures_haddock_and_declarations() -> None:
+    result = ParserRegistry().parse(STATS_HS, Path("src/Data/Stats.hs"), "haskell")
+
+    assert result.module_doc == "Small statistics helpers."
+    assert result.imports == {"Data.Map.Strict", "Data.List"}
+    functions = {func.name: func for func in result.functions}
+    assert list(functions) == ["weightedMean", "(<+>)"]
+    mean = functions["weightedMean"]
+    assert mean.signature == "weightedMean :: [Double] -> [Double] -> Double"
+    assert (mean.line, mean.end_line, mean.doc_line, mean.doc_end_line) == (17, 23, 14, 16)
+    assert mean.args == ["xs", "ws"]
+    assert mean.docstring == (
+        "Weighted mean of a list of values.\n\nEach value is weighted by the matching weight."
+    )
+    assert functions["(<+>)"].args == ["(a, b)", "(c, d)"]
+    assert functions["(<+>)"].docstring == "Add two vectors."
+
+    classes = {cls.name: cls for cls in result.classes}
+    assert [(cls.kind, cls.name) for cls in result.classes] == [
+        ("data", "Shape"),
+        ("data", "Config"),
+        ("class", "Container"),
+        ("instance", "Wrapper"),
+    ]
+    shape, config = classes["Shape"], classes["Config"]
+    assert shape.signature == "data Shape = Circle Double | Rect Double Double"
+    assert shape.decorators == ["deriving (Show, Eq)"]
+    assert (config.signature, config.docstring) == (
+        "data Config = Config {..}",
+        "Runtime configuration.",
+    )
+    assert [(f.name, f.type, f.docstring) for f in config.fields] == [
+        ("cfgName", "String", "Display name"),
+        ("cfgPort", "Int", "Ports to listen on"),
+        ("cfgAdmin", "Int", "Ports to listen on"),
+    ]
+    container = classes["Container"]
+    assert container.bases == ["Eq", "Show"]
+    assert [(m.name, m.kind, m.signature, m.docstring) for m in container.methods] == [
+        ("empty", "abstract_method", "empty :: f a", "The empty container."),
+        ("insert", "abstract_method", "insert :: a -> f a -> f a", None),
+    ]
+    instance = classes["Wrapper"]
+    assert (instance.bases, instance.signature) == (["Show"], "instance Show Wrapper")
+    assert [(m.name, m.args) for m in instance.methods] == [("show", ["(Wrapper n)"])]
+
+
+def test_export_list_defines_the_public_surface(tmp_path: Path) -> None:
+    everything = HaskellParser()
+    everything.include_private = True
+    result = everything.parse(STATS_HS, Path("Stats.hs"), "haskell")
+    private = {func.name: func.private for func in result.functions}
+    assert private == {"weightedMean": False, "clamp": True, "(<+>)": False}
+    clamp = result.functions[1]
+    assert (clamp.signature, clamp.args) == ("clamp :: Double -> Double", ["!x"])
+    assert clamp.docstring is None
+    assert [cls.name for cls in result.classes if cls.private] == ["Wrapper"]
+    container = next(cls for cls in result.classes if cls.name == "Container")
+    assert [(m.name, m.private) for m in container.methods][-1] == ("size", True)
+
+    # Without a module header the file is `Main`, which only exports `main`.
+    (tmp_path / "Main.hs").write_text(
+        "-- | Entry point.\nmain :: IO ()\nmain = print 1\n\nunused = 2\n", encoding="utf-8"
+    )
+    (tmp_path / "Stats.hs").write_text(STATS_HS, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert [(func["name"], func["complexity"]) for func in analysis["functions"]] == [
+        ("main", 1),
+        ("weightedMean", 1),
+        ("(<+>)", 1),
+    ]
+    config = {"analysis": {"visibility": "all", "incremental": False}}
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+    clamp = next(func for func in analysis["functions"] if func["name"] == "clamp")
+    # Two guards on top of the one path through the function.
+    assert clamp["complexity"] == 3
+    assert "unused" in {func["name"] for func in analysis["functions"]}
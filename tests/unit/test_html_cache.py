@@ -0,0 +1,203 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+import pytest
+
+from docgenie import api
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_cache import HTML_CACHE_FILENAME, HtmlSectionCache
+from docgenie.html_generator import HTMLGenerator
+from docgenie.html_sections import scope_heading_ids
+from docgenie.templating import BUILTIN_TEMPLATE_DIR, README_TEMPLATE
+from docgenie.toc import module_sections, split_markdown_sections
+
+
+def test_split_markdown_sections_ignores_fenced_headings() -> None:
+    content = "# T\n\nintro\n\n## A\n\n```\n## not a heading\n```\n### B\nx\n#### C\n## D\n"
+
+    sections = split_markdown_sections(content)
+    assert sections == [
+        "# T\n\nintro\n\n",
+        "## A\n\n```\n## not a heading\n```\n",
+        "### B\nx\n#### C\n",
+        "## D\n",
+    ]
+    assert "".join(sections) == content
+
+
+def test_module_sections_pairs_module_subsections_with_their_path() -> None:
+    content = (
+        "# T\n\n## Modules\n\n### `a.py`\n\nA.\n\n#### Constants\n\n### `b.py`\n\n"
+        "## Undocumented Public API\n\n### `a.py`\n\n- `x`\n"
+    )
+
+    assert [module for module, _ in module_sections(split_markdown_sections(content))] == [
+        None,
+        None,
+        "a.py",
+        "b.py",
+        None,
+        None,
+    ]
+
+
+def test_scope_heading_ids_rewrites_only_heading_links() -> None:
+    html = '<h2 id="run">run<a class="headerlink" href="#run">¶</a></h2><a href="#api-run">x</a>'
+
+    assert scope_heading_ids(html, "s1") == (
+        '<h2 id="s1-run">run<a class="headerlink" href="#s1-run">¶</a></h2>'
+        '<a href="#api-run">x</a>'
+    )
+
+
+def test_section_cache_reuses_sections_and_drops_stale_fingerprints(tmp_path: Path) -> None:
+    converted: list[str] = []
+
+    def convert(text: str) -> str:
+        converted.append(text)
+        return f"<p>{text}</p>"
+
+    cache = HtmlSectionCache(tmp_path, "v1")
+    assert cache.render("a", convert) == "<p>a</p>"
+    assert cache.render("b", convert) == "<p>b</p>"
+    cache.persist()
+
+    again = HtmlSectionCache(tmp_path, "v1")
+    assert again.render("a", convert) == "<p>a</p>"
+    assert again.render("c", convert) == "<p>c</p>"
+    assert (again.hits, again.misses) == (1, 1)
+    again.persist()
+    assert '"b"' not in (tmp_path / HTML_CACHE_FILENAME).read_text(encoding="utf-8")
+
+    changed_template = HtmlSectionCache(tmp_path, "v2")
+    changed_template.render("a", convert)
+    assert converted == ["a", "b", "c", "a"]
+
+
+def test_section_cache_splices_modules_whose_data_is_unchanged(tmp_path: Path) -> None:
+    def convert(text: str) -> str:
+        return f"<p>{text}</p>"
+
+    first = HtmlSectionCache(tmp_path, "v1")
+    assert not first.reuse_module({"path": "a.py", "summary": "A."})
+    assert not first.reuse_module({"path": "b.py", "summary": "B."})
+    first.render_module("a.py", "A.", convert)
+    first.render_module("b.py", "B.", convert)
+    first.persist()
+
+    second = HtmlSectionCache(tmp_path, "v1")
+    assert second.reuse_module({"path": "a.py", "summary": "A."})
+    assert not second.reuse_module({"path": "b.py", "summary": "B, edited."})
+    # A reused module keeps its previous HTML whatever stub Markdown it was rendered as.
+    assert second.render_module("a.py", "### `a.py`\n", convert) == "<p>A.</p>"
+    assert second.render_module("b.py", "B, edited.", convert) == "<p>B, edited.</p>"
+    assert (second.modules_reused, second.misses) == (1, 1)
+    second.persist()
+
+    third = HtmlSectionCache(tmp_path, "v1")
+    assert third.reuse_module({"path": "b.py", "summary": "B, edited."})
+    changed_template = HtmlSectionCache(tmp_path, "v2")
+    assert not changed_template.reuse_module({"path": "b.py", "summary": "B, edited."})
+
+
+def _module_section(page: str, path: str) -> str:
+    heading = page.index(f"<code>{path}</code>")
+    start = page.rindex("<h3", 0, heading)
+    ends = [page.find(tag, heading) for tag in ("<h2", "<h3")]
+    return page[start : min(end for end in ends if end >= 0)]
+
+
+def test_editing_one_module_leaves_other_sections_byte_identical(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    (tmp_path / "alpha.py").write_text('def run():\n    """Run it."""\n', encoding="utf-8")
+    (tmp_path / "beta.py").write_text('def stop():\n    """Stop it."""\n', encoding="utf-8")
+    output = tmp_path / "docs.html"
+    converted: list[str] = []
+    original = HTMLGenerator._convert_section
+
+    def spy(self: HTMLGenerator, markdown_text: str, *args: Any) -> str:
+        converted.append(markdown_text)
+        return original(self, markdown_text, *args)
+
+    monkeypatch.setattr(HTMLGenerator, "_convert_section", spy)
+    readmes: list[str] = []
+    original_generate = ReadmeGenerator.generate
+
+    def readme_spy(self: ReadmeGenerator, *args: Any, **kwargs: Any) -> str:
+        readmes.append(original_generate(self, *args, **kwargs))
+        return readmes[-1]
+
+    monkeypatch.setattr(ReadmeGenerator, "generate", readme_spy)
+
+    def build() -> tuple[dict[str, Any], str]:
+        analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+        return analysis, HTMLGenerator().generate_from_analysis(analysis, str(output))
+
+    _, first = build()
+    full_build = len(converted)
+    (tmp_path / "alpha.py").write_text('def run():\n    """Run it twice."""\n', encoding="utf-8")
+    converted.clear()
+    analysis, second = build()
+
+    assert 0 < len(converted) < full_build
+    assert not any(section.startswith("### `beta.py`") for section in converted)
+    # The unchanged module is not rendered either: only its heading is in the Markdown.
+    assert "### `beta.py`\n" in readmes[-1] and "Stop it." not in readmes[-1].split("## Modules")[1]
+    assert _module_section(second, "beta.py") == _module_section(first, "beta.py")
+    assert "Run it twice." in _module_section(second, "alpha.py")
+    cache_file = tmp_path / ".docgenie" / HTML_CACHE_FILENAME
+    assert cache_file.exists()
+
+    # The spliced page is exactly what a full rebuild of the same analysis gives.
+    cache_file.unlink()
+    assert HTMLGenerator().generate_from_analysis(analysis, str(output)) == second
+
+
+def test_library_html_generation_uses_the_section_cache(tmp_path: Path) -> None:
+    (tmp_path / "alpha.py").write_text('def run():\n    """Run it."""\n', encoding="utf-8")
+
+    page = api.generate(api.analyze(tmp_path), "html")
+
+    assert b"Run it." in page
+    assert (tmp_path / ".docgenie" / HTML_CACHE_FILENAME).exists()
+
+
+def test_template_change_reconverts_every_section_with_unchanged_sources(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    (tmp_path / "alpha.py").write_text('def run():\n    """Run it."""\n', encoding="utf-8")
+    templates = tmp_path / "templates"
+    templates.mkdir()
+    template = templates / README_TEMPLATE
+    template.write_text((BUILTIN_TEMPLATE_DIR / README_TEMPLATE).read_text(encoding="utf-8"))
+    output = tmp_path / "docs.html"
+    converted: list[str] = []
+    original = HTMLGenerator._convert_section
+
+    def spy(self: HTMLGenerator, markdown_text: str, *args: Any) -> str:
+        converted.append(markdown_text)
+        return original(self, markdown_text, *args)
+
+    monkeypatch.setattr(HTMLGenerator, "_convert_section", spy)
+    config = {"template_customizations": {"template_dir": str(templates)}}
+
+    def build() -> tuple[dict[str, Any], str]:
+        analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config)
+        data = analysis.analyze()
+        return data, HTMLGenerator().generate_from_analysis(data, str(output))
+
+    build()
+    full_build = len(converted)
+    converted.clear()
+    build()
+    assert converted == []
+
+    # A change that leaves the rendered Markdown identical still invalidates the cache.
+    template.write_text(template.read_text(encoding="utf-8") + "{# tweak #}", encoding="utf-8")
+    data, _ = build()
+    assert data["run_metrics"]["cache_hits"] == 1
+    assert len(converted) == full_build
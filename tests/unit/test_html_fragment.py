@@ -0,0 +1,60 @@
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+from docgenie.html_generator import HTMLGenerator
+from docgenie.html_sections import FRAGMENT_CSS_FILENAME, SEARCH_INDEX_FILENAME
+
+README = "# Title\n\n## Usage\n\nRun it.\n\n## API\n\n### `fetch(url)`\n\nFetch a page.\n"
+
+
+def test_fragment_has_no_page_shell_and_a_standalone_stylesheet(tmp_path: Path) -> None:
+    output = tmp_path / "docs.html"
+    html = HTMLGenerator().generate_from_readme(
+        README,
+        str(output),
+        "P",
+        toc_min_headings=1,
+        theme_css=".brand { color: red; }",
+        fragment=True,
+    )
+
+    assert output.read_text(encoding="utf-8") == html
+    assert html.lstrip().startswith('<div class="docgenie">')
+    assert html.rstrip().endswith("</div>")
+    for tag in ("<!DOCTYPE", "<html", "<head>", "<body", "<style"):
+        assert tag not in html
+    # The content, sidebar, anchors and search box are all still there.
+    assert 'id="usage"' in html and 'href="#usage"' in html
+    assert 'id="symbol-search"' in html
+    assert f'data-search-index="{SEARCH_INDEX_FILENAME}"' in html
+
+    css = (tmp_path / FRAGMENT_CSS_FILENAME).read_text(encoding="utf-8")
+    assert "prefers-color-scheme: dark" in css
+    assert css.index(".brand { color: red; }") > css.index(".docgenie .brand")
+
+
+def test_fragment_stylesheet_is_scoped_to_the_wrapper() -> None:
+    css = HTMLGenerator().stylesheet(fragment=True)
+
+    # Nothing that would restyle the page the fragment is embedded in.
+    assert not re.search(r"(?m)^\s*(body|:root|\*)[\s{,]", css)
+    assert ".layout" not in css
+    selectors = re.findall(r"(?:^|[{}])\s*([^{}@;]+?)\s*\{", css)
+    assert selectors
+    for selector in selectors:
+        for part in selector.split(","):
+            assert ".docgenie" in part, part
+    assert ".docgenie .sidebar {" in css
+    assert "@media (max-width: 960px) {\n  .docgenie .sidebar {" in css
+    assert '[data-theme="dark"] .docgenie {' in css
+
+
+def test_full_page_keeps_inline_styles_and_writes_no_stylesheet(tmp_path: Path) -> None:
+    output = tmp_path / "docs.html"
+    html = HTMLGenerator().generate_from_readme(README, str(output), "P")
+
+    assert html.startswith("<!DOCTYPE html>")
+    assert "<style>" in html
+    assert not (tmp_path / FRAGMENT_CSS_FILENAME).exists()
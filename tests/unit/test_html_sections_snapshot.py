@@ -1,9 +1,16 @@
 from __future__ import annotations
 
+import json
+import re
+from pathlib import Path
+
 from docgenie.html_sections import (
     build_impact_graph_data,
+    code_heading_anchors,
     impact_graph_block,
+    iter_search_entries,
     normalize_heading_ids,
+    write_search_index,
 )
 
 
@@ -20,6 +27,36 @@ def test_normalize_heading_ids_snapshot() -> None:
     assert 'href="#installation-2"' in normalized_toc
 
 
+def test_normalize_heading_ids_keeps_colliding_anchors_unique_and_stable() -> None:
+    content = (
+        '<a id="api-getuser"></a>'
+        '<h4 id="toc_1"><code>GetUser</code><a class="headerlink" href="#toc_1">¶</a></h4>'
+        '<h4 id="toc_2">GetUser 2</h4>'
+        '<h4 id="toc_3"><code>GetUser</code><a class="headerlink" href="#toc_3">¶</a></h4>'
+        '<h2 id="toc_4">API GetUser</h2>'
+        '<p>See <a href="#toc_3">the admin one</a> or <a href="#api-getuser">the handler</a>.</p>'
+    )
+    toc = '<a href="#toc_1">GetUser</a><a href="#toc_2">GetUser 2</a><a href="#toc_3">GetUser</a>'
+
+    normalized_content, normalized_toc = normalize_heading_ids(content, toc)
+
+    ids = re.findall(r'<h\d id="([^"]+)"', normalized_content)
+    assert ids == ["getuser", "getuser-2", "getuser-3", "api-getuser-2"]
+    assert 'href="#getuser"' in normalized_content
+    assert '<a href="#getuser-3">the admin one</a>' in normalized_content
+    assert '<a href="#api-getuser">the handler</a>' in normalized_content
+    assert normalized_toc == (
+        '<a href="#getuser">GetUser</a><a href="#getuser-2">GetUser 2</a>'
+        '<a href="#getuser-3">GetUser</a>'
+    )
+    assert code_heading_anchors(normalized_content) == {"GetUser": ["getuser", "getuser-3"]}
+    # Re-normalizing the output is a no-op, so anchors stay stable across rebuilds.
+    assert normalize_heading_ids(normalized_content, normalized_toc) == (
+        normalized_content,
+        normalized_toc,
+    )
+
+
 def test_build_impact_graph_data_snapshot() -> None:
     analysis_data = {
         "file_imports": {"src/a.py": ["os", "json"]},
@@ -32,9 +69,109 @@ def test_build_impact_graph_data_snapshot() -> None:
     assert graph["truncated"] is False
 
 
+def test_truncated_impact_graph_has_no_dangling_edges() -> None:
+    analysis_data = {
+        "file_imports": {
+            f"src/m{idx}.py": [f"src/m{(idx + 1) % 12}.py", "os"] for idx in range(12)
+        }
+    }
+    graph = build_impact_graph_data(analysis_data, max_nodes=5)
+
+    ids = {node["id"] for node in graph["nodes"]}
+    assert len(ids) == 5
+    assert all(edge["source"] in ids and edge["target"] in ids for edge in graph["edges"])
+    assert graph["truncated"] is True
+    assert graph["total_nodes"] == 13
+    assert graph["omitted_edges"] == graph["total_edges"] - len(graph["edges"]) > 0
+    block = impact_graph_block(graph)
+    assert f"Showing 5/13 nodes and {len(graph['edges'])}/{graph['total_edges']} edges" in block
+    assert f"(8 nodes and {graph['omitted_edges']} edges omitted)" in block
+
+
 def test_impact_graph_block_snapshot() -> None:
     graph_data = {"nodes": [{"id": "file:a", "label": "a", "type": "file"}], "edges": []}
     block = impact_graph_block(graph_data)
     assert "<section class=\"impact-graph-card\">" in block
     assert "1 nodes and 0 edges" in block
     assert "\"id\": \"file:a\"" in block
+
+
+def test_search_index_links_symbols_to_headings(tmp_path: Path) -> None:
+    content = (
+        '<h3 id="src-service-rs"><code>src/service.rs</code></h3>'
+        '<h4 id="toc_5"><code>UserService</code><a class="headerlink" href="#toc_5">&para;</a></h4>'
+        '<h2 id="toc_6">UserService</h2>'
+    )
+    normalized, _ = normalize_heading_ids(content, "")
+    assert 'id="userservice"' in normalized
+    anchors = code_heading_anchors(normalized)
+    assert anchors == {"src/service.rs": ["src-service-rs"], "UserService": ["userservice"]}
+
+    analysis_data = {
+        "root_path": str(tmp_path),
+        "functions": [{"name": "helper", "file": str(tmp_path / "src/service.rs"), "args": []}],
+        "classes": [
+            {
+                "name": "UserService",
+                "kind": "struct",
+                "file": str(tmp_path / "src/service.rs"),
+                "methods": [{"name": "find"}],
+            }
+        ],
+    }
+    index_path = tmp_path / "search-index.json"
+    count = write_search_index(iter_search_entries(analysis_data, anchors), index_path)
+    entries = json.loads(index_path.read_text(encoding="utf-8"))
+    assert count == len(entries) == 3
+    assert entries[0] == {
+        "anchor": "src-service-rs",
+        "kind": "function",
+        "module": "src/service.rs",
+        "name": "helper",
+    }
+    assert entries[1]["anchor"] == "userservice"
+    assert entries[1]["kind"] == "struct"
+    assert entries[2]["name"] == "UserService.find"
+    assert entries[2]["anchor"] == "userservice"
+
+    assert write_search_index(iter([]), index_path) == 0
+    assert json.loads(index_path.read_text(encoding="utf-8")) == []
+
+
+def test_impact_graph_keeps_same_named_symbols_apart(tmp_path: Path) -> None:
+    analysis_data = {
+        "root_path": str(tmp_path),
+        "file_imports": {"api/users.go": [], "admin/users.go": [], "admin/views.ts": []},
+        "functions": [
+            {"name": "GetUser", "file": str(tmp_path / "api/users.go")},
+            {"name": "GetUser", "file": str(tmp_path / "admin/users.go")},
+        ],
+        "classes": [
+            {"name": "Base", "file": str(tmp_path / "api/users.go")},
+            {"name": "Base", "file": str(tmp_path / "admin/users.go")},
+            {"name": "AdminView", "file": str(tmp_path / "admin/users.go"), "bases": ["Base"]},
+            {"name": "Orphan", "file": str(tmp_path / "admin/views.ts"), "bases": ["Base"]},
+        ],
+    }
+    graph = build_impact_graph_data(analysis_data)
+    nodes = {node["id"]: node for node in graph["nodes"]}
+    get_user_ids = sorted(node_id for node_id in nodes if node_id.endswith("::GetUser"))
+    assert get_user_ids == ["symbol:admin/users.go::GetUser", "symbol:api/users.go::GetUser"]
+    assert nodes["symbol:api/users.go::GetUser"]["module"] == "api/users.go"
+
+    defines = {
+        (edge["source"], edge["target"]) for edge in graph["edges"] if edge["kind"] == "defines"
+    }
+    assert ("file:api/users.go", "symbol:api/users.go::GetUser") in defines
+    assert ("file:admin/users.go", "symbol:admin/users.go::GetUser") in defines
+    assert ("file:api/users.go", "symbol:admin/users.go::GetUser") not in defines
+
+    extends = [edge for edge in graph["edges"] if edge["kind"] == "extends"]
+    # The same-module base wins; the ambiguous base in views.ts is left unlinked.
+    assert extends == [
+        {
+            "source": "symbol:admin/users.go::AdminView",
+            "target": "symbol:admin/users.go::Base",
+            "kind": "extends",
+        }
+    ]
@@ -0,0 +1,74 @@
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+import pytest
+
+from docgenie.exceptions import ConfigError
+from docgenie.html_generator import (
+    DARK_THEME,
+    LIGHT_THEME,
+    HTMLGenerator,
+    load_theme_css,
+    theme_css_path,
+    theme_variables_css,
+)
+
+
+def test_theme_variables_cover_light_dark_and_toggle() -> None:
+    css = theme_variables_css()
+
+    assert LIGHT_THEME.keys() == DARK_THEME.keys()
+    assert "@media (prefers-color-scheme: dark)" in css
+    # An explicit toggle choice beats the system preference in both directions.
+    assert ':root:not([data-theme="light"])' in css
+    assert ':root[data-theme="dark"]' in css
+    assert f"--graph-cycle: {DARK_THEME['graph-cycle']};" in css
+
+
+def test_styles_and_graph_script_use_variables_for_colors() -> None:
+    gen = HTMLGenerator()
+    styles = gen._get_css_styles()
+    rules = styles.split(theme_variables_css(), 1)[1]
+
+    # Outside the theme blocks, colors come from variables (badges keep shields.io colors).
+    literal = set(re.findall(r"#[0-9a-fA-F]{3,6}\b", rules))
+    assert literal <= {"#ffffff", "#fff", "#555555", "#007ec6", "#44cc11", "#b08800", "#e05d44"}
+    assert "stroke: var(--graph-cycle)" in rules
+    assert "fill: var(--graph-symbol)" in rules
+
+    script = gen._get_javascript()
+    assert "#dc2626" not in script
+    assert "impact-edge is-cycle" in script
+    assert "localStorage.setItem('docgenie-theme'" in script
+
+
+def test_theme_css_is_read_resolved_and_kept_inside_style(tmp_path: Path) -> None:
+    theme = tmp_path / "brand.css"
+    theme.write_text(":root { --primary-color: #ff0066; }\n/* </style> */\n", encoding="utf-8")
+
+    css = load_theme_css(theme)
+    assert "--primary-color: #ff0066" in css
+    assert "</style>" not in css
+
+    analysis = {"root_path": str(tmp_path), "config": {"template_customizations": {}}}
+    assert theme_css_path(analysis) is None
+    analysis["config"]["template_customizations"]["theme_css"] = "brand.css"
+    assert theme_css_path(analysis) == theme
+
+    with pytest.raises(ConfigError):
+        load_theme_css(tmp_path / "missing.css")
+
+
+def test_theme_css_is_inlined_after_builtin_styles(tmp_path: Path) -> None:
+    theme = tmp_path / "brand.css"
+    theme.write_text(":root { --primary-color: #ff0066; }\n", encoding="utf-8")
+    html = HTMLGenerator().generate_from_readme(
+        "# Title\n\nBody", None, "P", theme_css=load_theme_css(theme)
+    )
+
+    assert html.index("--primary-color: #ff0066") > html.index("prefers-color-scheme: dark")
+    assert 'class="theme-toggle"' in html
+    # The stylesheet is inlined, so the page stays self-contained.
+    assert "brand.css" not in html
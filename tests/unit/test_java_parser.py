@@ -0,0 +1,118 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.generator import ReadmeGenerator
+from docgenie.languages import JavaParser
+from docgenie.readme_quality import deprecation_warnings
+
+SAMPLE = """package com.example.users;
+
+import java.util.List;
+import static org.junit.Assert.assertTrue;
+
+/**
+ * Service for managing users.
+ */
+@Service
+public class UserService extends BaseService<User> implements Lookup<User> {
+    private final Map<String, List<User>> cache = new HashMap<>();
+
+    /** Creates the service. */
+    public UserService(UserRepository repo) {
+        super(repo);
+    }
+
+    /**
+     * Group users by team.
+     */
+    public Map<String, List<User>> groupByTeam(List<User> users, @Nullable String prefix) {
+        Runnable r = new Runnable() {
+            public void run() {}
+        };
+        return new HashMap<>();
+    }
+
+    @Override
+    public void close() {}
+
+    /** @deprecated use groupByTeam */
+    @Deprecated
+    public <T extends User> List<T> legacyFind(Class<T> type, String... names) {
+        return null;
+    }
+
+    private void secret() {}
+
+    /** Builder for users. */
+    public static class Builder {
+        public Builder name(String name) { return this; }
+    }
+
+    enum Status {
+        ACTIVE("a"),
+        DISABLED("d");
+
+        Status(String code) {}
+    }
+}
+
+public interface Lookup<T> {
+    T find(long id);
+}
+
+public record Point(int x, int y) implements Shape {}
+"""
+
+
+def _parse() -> dict[str, object]:
+    parsed = JavaParser().parse(SAMPLE, Path("UserService.java"), "java")
+    return {cls.name: cls for cls in parsed.classes}
+
+
+def test_java_parser_extracts_types_with_javadoc() -> None:
+    classes = _parse()
+
+    assert {name: cls.kind for name, cls in classes.items()} == {
+        "UserService": "class",
+        "UserService.Builder": "class",
+        "UserService.Status": "enum",
+        "Lookup": "interface",
+        "Point": "record",
+    }
+    service = classes["UserService"]
+    assert service.docstring == "Service for managing users."
+    assert service.decorators == ["@Service"]
+    assert service.bases == ["BaseService<User>", "Lookup<User>"]
+
+
+def test_java_parser_keeps_methods_with_generics_and_nested_classes() -> None:
+    service = _parse()["UserService"]
+    methods = {method.name: method for method in service.methods}
+
+    assert list(methods) == ["UserService", "groupByTeam", "close", "legacyFind"]
+    assert methods["UserService"].kind == "constructor"
+    assert methods["groupByTeam"].args == ["users", "prefix"]
+    assert methods["groupByTeam"].docstring == "Group users by team."
+    assert methods["close"].decorators == ["@Override"]
+    assert methods["legacyFind"].args == ["type", "names"]
+    assert [m.name for m in _parse()["UserService.Status"].methods] == ["Status"]
+
+
+def test_deprecated_java_methods_surface_as_quality_warnings(tmp_path: Path) -> None:
+    source = tmp_path / "UserService.java"
+    parsed = JavaParser().parse(SAMPLE, source, "java")
+    analysis = {
+        "project_name": "svc",
+        "root_path": str(tmp_path),
+        "files_analyzed": 1,
+        "languages": {"java": 1},
+        "main_language": "java",
+        "functions": [],
+        "classes": [cls.to_public_dict() for cls in parsed.classes],
+    }
+
+    warnings = deprecation_warnings(analysis)
+    assert warnings == ["Deprecated method `UserService.legacyFind` (UserService.java:33)"]
+    content = ReadmeGenerator().generate(analysis, None)
+    assert "Deprecated method `UserService.legacyFind`" in content
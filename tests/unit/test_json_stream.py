@@ -0,0 +1,75 @@
+from __future__ import annotations
+
+import io
+import json
+from pathlib import Path
+
+import pytest
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.json_stream import write_json_stream
+from docgenie.reproducible import relative_paths
+
+
+def _stream(document: dict, **kwargs) -> str:
+    out = io.StringIO()
+    write_json_stream(document, out, **kwargs)
+    return out.getvalue()
+
+
+def test_stream_matches_json_dumps_for_analysis(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    # Pins duration_sec so both runs report the same metrics.
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "1700000000")
+    (tmp_path / "pkg").mkdir()
+    (tmp_path / "pkg" / "core.py").write_text(
+        'class Greeter:\n    """Says hé."""\n\n    def greet(self, name):\n        return name\n\n'
+        "def main():\n    pass\n",
+        encoding="utf-8",
+    )
+    (tmp_path / "pkg" / "util.py").write_text("def helper():\n    pass\n", encoding="utf-8")
+    (tmp_path / "main.go").write_text(
+        "package main\n\n// Run starts it.\nfunc Run() {}\n", encoding="utf-8"
+    )
+    config = {"analysis": {"incremental": False}}
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+    expected = json.dumps(relative_paths(analysis, tmp_path.resolve()), indent=2)
+
+    out = io.StringIO()
+    analyzer = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config=config, json_stream=out
+    )
+    result = analyzer.analyze_result()
+
+    assert out.getvalue() == expected
+    # Symbols are released as their module is written.
+    assert result.functions == [] and result.classes == []
+    assert analyzer.functions == [] and analyzer.classes == []
+
+
+def test_stream_writes_batches_as_one_list() -> None:
+    document = {"functions": [], "classes": [], "flag": True}
+    streamed = _stream(
+        document,
+        streams={"functions": iter([[{"name": "f"}], [], [{"name": "g"}, {"name": "h"}]])},
+    )
+    assert streamed == json.dumps(
+        {"functions": [{"name": "f"}, {"name": "g"}, {"name": "h"}], "classes": [], "flag": True},
+        indent=2,
+    )
+    assert _stream({"functions": []}, streams={"functions": iter([[], []])}) == json.dumps(
+        {"functions": []}, indent=2
+    )
+
+
+def test_stream_handles_empty_and_nested_values() -> None:
+    document = {
+        "empty_list": [],
+        "empty_dict": {},
+        "nested": {"a": [1, {"b": None}], "c": "é\n"},
+        "functions": [{"name": "f", "args": []}, {"name": "g", "args": ["x"]}],
+        "flag": True,
+    }
+    assert _stream(document) == json.dumps(document, indent=2)
+    assert _stream({}) == json.dumps({}, indent=2)
@@ -0,0 +1,139 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.languages import KotlinParser
+from docgenie.module_index import field_table
+from docgenie.parsers import ParserRegistry
+from docgenie.readme_quality import deprecation_warnings
+
+SAMPLE = '''package com.acme.users
+
+import kotlinx.coroutines.flow.Flow
+import com.acme.db.*
+
+/**
+ * An account holder.
+ *
+ * @property id Primary key.
+ * @property name Display name.
+ */
+@Serializable
+data class User(
+    val id: Long,
+    @SerialName("full_name") val name: String = "anon",
+    private val secret: String = "",
+    tags: List<String> = emptyList(),
+) : Entity(id), Comparable<User> {
+    /** Compare by id. */
+    override fun compareTo(other: User): Int = id.compareTo(other.id)
+
+    private fun hidden() {}
+
+    companion object {
+        /** Build a guest user. */
+        @JvmStatic
+        fun guest(): User = User(0, "guest")
+
+        const val MAX = 10
+    }
+
+    /** Nested role. */
+    enum class Role { ADMIN, USER }
+}
+
+/** Loads users. */
+interface UserRepository {
+    /** Find one user. */
+    suspend fun find(id: Long): User?
+    fun all(): Flow<User>
+}
+
+/** Shared registry. */
+object Registry {
+    fun register(user: User, onDone: (Int) -> Unit = {}) {
+        val s = "{ not a block"
+    }
+}
+
+/** Slugify a string. */
+fun String.toSlug(separator: Char = '-'): String = lowercase().replace(' ', separator)
+
+/** Second element. */
+fun <T> List<T>.second(): T = this[1]
+
+@Deprecated("Use fetchAll")
+suspend fun loadAll(vararg ids: Long): List<User> {
+    return emptyList()
+}
+
+private fun internalHelper() = Unit
+
+class Service @Inject constructor(private val repo: UserRepository) : BaseService() {
+    constructor(other: Service) : this(other.repo)
+}
+'''
+
+
+def _parse():
+    return KotlinParser().parse(SAMPLE, Path("Users.kt"), "kotlin")
+
+
+def test_kotlin_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("kotlin"), KotlinParser)
+
+
+def test_kotlin_data_class_fields_and_companion_members() -> None:
+    classes = {cls.name: cls for cls in _parse().classes}
+
+    user = classes["User"]
+    assert user.kind == "data class"
+    assert user.docstring == "An account holder."
+    assert user.bases == ["Entity", "Comparable<User>"]
+    assert user.decorators == ["@Serializable"]
+    assert [(f.name, f.type, f.docstring) for f in user.fields] == [
+        ("id", "Long", "Primary key."),
+        ("name", "String", "Display name."),
+    ]
+    assert [(m.kind, m.name) for m in user.methods] == [
+        ("method", "compareTo"),
+        ("companion_method", "guest"),
+    ]
+    assert user.methods[1].decorators == ["@JvmStatic"]
+    assert classes["User.Role"].kind == "enum class"
+    assert classes["Registry"].kind == "object"
+    assert classes["Registry"].methods[0].args == ["user", "onDone"]
+
+    service = classes["Service"]
+    assert service.fields == []
+    assert service.bases == ["BaseService"]
+    assert [(m.kind, m.args) for m in service.methods] == [("constructor", ["other"])]
+
+    rows = field_table([field.to_public_dict() for field in user.fields])
+    assert rows["descriptions"] is True
+    assert rows["rows"][0]["description"] == "Primary key."
+
+
+def test_kotlin_functions_extensions_and_suspend() -> None:
+    parsed = _parse()
+    by_name = {func.name: func for func in parsed.functions}
+
+    assert list(by_name) == ["String.toSlug", "List.second", "loadAll"]
+    assert by_name["String.toSlug"].kind == "extension_function"
+    assert by_name["String.toSlug"].args == ["separator"]
+    load = by_name["loadAll"]
+    assert load.is_async
+    assert load.signature == "suspend fun loadAll(vararg ids: Long): List<User>"
+    assert load.args == ["ids"]
+
+    repository = next(cls for cls in parsed.classes if cls.name == "UserRepository")
+    # A nullable return type (`User?`) must not swallow the next declaration.
+    assert [(m.name, m.is_async) for m in repository.methods] == [("find", True), ("all", False)]
+    assert parsed.imports == {"kotlinx.coroutines.flow.Flow", "com.acme.db.*"}
+
+
+def test_kotlin_deprecated_functions_surface_as_warnings(tmp_path: Path) -> None:
+    parsed = KotlinParser().parse(SAMPLE, tmp_path / "Users.kt", "kotlin").to_public_dict()
+    analysis = {"root_path": str(tmp_path), **parsed}
+
+    assert deprecation_warnings(analysis) == ["Deprecated function `loadAll` (Users.kt:57)"]
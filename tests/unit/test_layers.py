@@ -0,0 +1,85 @@
+from __future__ import annotations
+
+import pytest
+
+from docgenie.config_schema import validate_config
+from docgenie.graph_export import mermaid_layer_graph
+from docgenie.layers import layer_report, layer_warnings, parse_layers
+
+MODULE = "example.com/shop"
+
+
+def _analysis(layers: dict[str, list[str]]) -> dict[str, object]:
+    return {
+        "config": {"layers": layers},
+        "file_imports": {
+            "cmd/server/main.go": [f"{MODULE}/internal/handler", "net/http"],
+            "internal/handler/orders.go": [f"{MODULE}/internal/service", "net/http"],
+            "internal/handler/users.go": [f"{MODULE}/internal/service"],
+            "internal/service/orders.go": [f"{MODULE}/internal/repository"],
+            # The deliberate violation: data access reaching back up into HTTP handlers.
+            "internal/repository/orders.go": [f"{MODULE}/internal/handler", "database/sql"],
+            "internal/repository/users.go": ["database/sql"],
+        },
+    }
+
+
+def test_repository_importing_handler_is_a_violation() -> None:
+    layers = parse_layers(
+        "handler=**/handler/**,service=**/service/**,repository=**/repository/**"
+    )
+    report = layer_report(_analysis(layers))
+
+    assert report is not None
+    assert [(layer["name"], layer["modules"]) for layer in report["layers"]] == [
+        ("handler", ["internal/handler/orders.go", "internal/handler/users.go"]),
+        ("service", ["internal/service/orders.go"]),
+        ("repository", ["internal/repository/orders.go", "internal/repository/users.go"]),
+    ]
+    assert [
+        (dep["source"], dep["target"], dep["count"], dep["allowed"])
+        for dep in report["dependencies"]
+    ] == [
+        ("handler", "service", 2, True),
+        ("service", "repository", 1, True),
+        ("repository", "handler", 1, False),
+    ]
+    assert report["violations"] == [
+        {
+            "source": "repository",
+            "target": "handler",
+            "file": "internal/repository/orders.go",
+            "import": f"{MODULE}/internal/handler",
+        }
+    ]
+    assert layer_warnings(report) == [
+        "1 import violates the layer order: `repository` imports `handler` "
+        "(internal/repository/orders.go)"
+    ]
+    diagram = mermaid_layer_graph(report)
+    assert '    l0["handler<br/>2 modules"]' in diagram
+    assert "    l2 -.->|1| l0" in diagram
+    assert diagram.endswith("    linkStyle 2 stroke:#dc2626,color:#dc2626")
+
+
+def test_layer_config_parsing_and_python_imports() -> None:
+    with pytest.raises(ValueError, match="expected NAME=GLOB"):
+        parse_layers("handler=**/handler/**,service")
+    assert layer_report({"config": {"layers": {}}}) is None
+    assert validate_config({"layers": {"api": "api/**", "core": ["core/**", "lib/**"]}}) == []
+    assert validate_config({"layers": {"api": 3}})
+
+    report = layer_report(
+        {
+            "config": {"layers": {"api": "src/shop/api/**", "core": "src/shop/core/**"}},
+            "file_imports": {
+                "src/shop/api/views.py": ["shop.core.models.Order"],
+                "src/shop/core/models.py": ["shop.api.views"],
+            },
+        }
+    )
+    assert report is not None
+    assert [(dep["source"], dep["target"], dep["allowed"]) for dep in report["dependencies"]] == [
+        ("api", "core", True),
+        ("core", "api", False),
+    ]
@@ -0,0 +1,49 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.link_check import find_broken_links
+
+# `load` links to `_save` by hand, but `visibility: public` leaves `_save` out of the
+# API Reference, so its anchor is never emitted.
+APP = '''def load():
+    """Read what [_save](#api-save) wrote; see [the API](#api-reference).
+
+    Write `[x](#not-a-link)` to link.
+    """
+
+
+def _save():
+    """Persist the state."""
+'''
+
+
+def test_links_to_filtered_symbols_are_reported(tmp_path: Path) -> None:
+    (tmp_path / "app.py").write_text(APP, encoding="utf-8")
+    config = {"analysis": {"visibility": "public"}}
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    content = ReadmeGenerator().generate(analysis)
+
+    assert "](#api-reference)" in content
+    assert 'id="api-save"' not in content
+    [broken] = find_broken_links(content)
+    assert (broken["anchor"], broken["text"]) == ("api-save", "_save")
+    assert "[_save](#api-save)" in content.splitlines()[broken["line"] - 1]
+
+
+def test_adoc_links_check_explicit_and_section_anchors() -> None:
+    content = (
+        "= svc\n\n"
+        "== API Reference\n\n"
+        "[[api-load]]\n=== `load`\n\n"
+        "See <<api-save,save>>, <<_api_reference>> and <<api-load,load>>.\n\n"
+        "----\n<<example>>\n----\n"
+    )
+
+    assert find_broken_links(content, "adoc") == [
+        {"anchor": "api-save", "text": "save", "line": 8}
+    ]
+    assert find_broken_links("<a href='#x'>x</a>", "confluence") == []
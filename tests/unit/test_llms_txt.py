@@ -0,0 +1,99 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+from docgenie import cli
+from docgenie.llms_txt import LlmsTxtGenerator, estimate_tokens
+
+
+def _analysis(root: Path) -> dict[str, Any]:
+    return {
+        "project_name": "Shop",
+        "root_path": str(root),
+        "main_language": "python",
+        "files_analyzed": 3,
+        "languages": {"python": 2, "go": 1},
+        "dependencies": {"requirements.txt": ["requests", "flask"]},
+        "functions": [
+            {
+                "name": "checkout",
+                "file": str(root / "core.py"),
+                "line": 4,
+                "args": ["cart", "user"],
+                "docstring": "Charge the user for the cart.\n\nLonger details are not listed.",
+            },
+            {"name": "_private", "file": str(root / "core.py"), "line": 1, "args": []},
+            {
+                "name": "Render",
+                "file": str(root / "view.go"),
+                "line": 3,
+                "signature": "func Render(w io.Writer) error",
+                "docstring": None,
+            },
+        ],
+        "classes": [
+            {
+                "name": "Cart",
+                "file": str(root / "core.py"),
+                "line": 10,
+                "bases": ["Base"],
+                "docstring": "Items waiting to be bought.",
+                "methods": [
+                    {"name": "add", "args": ["self", "item"], "docstring": "Add an item."},
+                    {"name": "_log", "args": ["self"]},
+                ],
+            },
+        ],
+        "file_imports": {"core.py": [], "view.go": [], "app.py": []},
+        "symbol_references": {"app.py": ["checkout"]},
+    }
+
+
+def test_llms_txt_lists_public_symbols_by_module(tmp_path: Path) -> None:
+    content = LlmsTxtGenerator().generate(_analysis(tmp_path))
+
+    assert content.startswith("# Shop\n\n> Shop is a python project: 3 files, 3 functions")
+    assert "Languages: python (2), go (1)\nDependencies: requests, flask\n" in content
+    assert (
+        "## core.py\n"
+        "- checkout(cart, user): Charge the user for the cart.\n"
+        "- class Cart(Base): Items waiting to be bought.\n"
+        "  - add(self, item): Add an item.\n"
+    ) in content
+    assert "## view.go\n- func Render(w io.Writer) error\n" in content
+    assert "_private" not in content
+    assert "_log" not in content
+    assert "|" not in content
+
+
+def test_llms_txt_budget_drops_least_referenced_modules(tmp_path: Path) -> None:
+    analysis = _analysis(tmp_path)
+    analysis["functions"][2]["docstring"] = "Write the page. " + "Uses templates. " * 20
+    full = LlmsTxtGenerator().generate(analysis)
+    budget = estimate_tokens(full) - 5
+
+    trimmed = LlmsTxtGenerator().generate(analysis, max_tokens=budget)
+
+    assert estimate_tokens(trimmed) <= budget
+    # core.py is referenced from app.py, so the unreferenced view.go goes first.
+    assert "## core.py" in trimmed
+    assert "## view.go" not in trimmed
+    assert "## Omitted\nTo fit the" in trimmed
+    assert "1 modules (1 symbols) were left out, least referenced first: view.go" in trimmed
+
+
+def test_llms_txt_reads_budget_from_config_and_writes_file(tmp_path: Path) -> None:
+    analysis = _analysis(tmp_path)
+    analysis["config"] = {"llms": {"max_tokens": 60}}
+    output = tmp_path / "out" / "llms.txt"
+
+    content = LlmsTxtGenerator().generate(analysis, output)
+
+    assert output.read_text(encoding="utf-8") == content
+    assert "## Omitted" in content
+
+
+def test_llms_format_output_path(tmp_path: Path) -> None:
+    assert cli._validate_format("LLMS") == "llms"
+    assert cli._build_outputs("llms", None, tmp_path) == [("llms", tmp_path / "llms.txt")]
@@ -0,0 +1,149 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import LuaParser
+from docgenie.models import ClassDoc, FunctionDoc
+from docgenie.module_index import build_module_index
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = """local json = require("dkjson")
+local util = require "game.util"
+
+--- Inventory helpers.
+-- Tracks items per player.
+local M = {
+  --- Count the items.
+  count = function(items)
+    return #items
+  end,
+  limit = 10,
+}
+
+--[[ Not a doc:
+function ignored() end
+]]
+
+--- A drawable widget.
+local Widget = setmetatable({}, { __index = Base })
+Widget.__index = Widget
+
+--- Create a widget.
+function Widget.new(name, ...)
+  local self = setmetatable({}, Widget)
+  if name then
+    self.name = name
+  elseif name == nil then
+    self.name = "[[ end"
+  end
+  return self
+end
+
+-- Draw at a position.
+function Widget:draw(x, y)
+  for i = 1, 3 do
+    repeat
+      x = x + 1
+    until x > 10 or y
+  end
+end
+
+function Widget:_layout() end
+
+--- Load a level.
+function M.load(path)
+  local function helper() return 1 end
+  return helper()
+end
+
+M.save = function(path, data)
+  return true
+end
+
+local function clamp(value)
+  return value
+end
+
+return M
+"""
+
+SCRIPT = """--- Spawn an enemy.
+function spawn(kind)
+  return kind
+end
+
+local function roll() return 4 end
+
+Player = {}
+
+function Player:jump(height) end
+"""
+
+
+def _parse(
+    source: str, *, include_private: bool = False
+) -> tuple[dict[str, ClassDoc], list[FunctionDoc]]:
+    parser = LuaParser()
+    parser.include_private = include_private
+    parsed = parser.parse(source, Path("inventory.lua"), "lua")
+    return {cls.name: cls for cls in parsed.classes}, parsed.functions
+
+
+def test_lua_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("lua"), LuaParser)
+
+
+def test_returned_table_is_the_public_api() -> None:
+    parser = LuaParser()
+    parsed = parser.parse(SAMPLE, Path("inventory.lua"), "lua")
+
+    # Only `M` is handed out by `return M`; the local `Widget` class and `clamp` are private.
+    assert [cls.name for cls in parsed.classes] == ["M"]
+    assert parsed.functions == []
+    module = parsed.classes[0]
+    assert (module.kind, module.signature) == ("module", "local M = {...}")
+    assert module.docstring == "Inventory helpers.\nTracks items per player."
+    assert [(m.name, m.kind, m.line, m.end_line) for m in module.methods] == [
+        ("count", "class_method", 8, 10),
+        ("load", "class_method", 45, 48),
+        ("save", "class_method", 50, 52),
+    ]
+    assert module.methods[0].docstring == "Count the items."
+    assert module.methods[2].signature == "function M.save(path, data)"
+    assert parsed.imports == {"dkjson", "game.util"}
+
+
+def test_colon_methods_are_grouped_under_their_table() -> None:
+    classes, functions = _parse(SAMPLE, include_private=True)
+
+    widget = classes["Widget"]
+    assert (widget.kind, widget.private, widget.bases) == ("class", True, ["Base"])
+    assert widget.docstring == "A drawable widget."
+    assert [(m.name, m.kind, m.private) for m in widget.methods] == [
+        ("new", "class_method", False),
+        ("draw", "method", False),
+        ("_layout", "method", True),
+    ]
+    # Keywords inside strings, long comments and nested blocks do not end a body early.
+    assert [(m.line, m.end_line) for m in widget.methods] == [(23, 31), (34, 40), (42, 42)]
+    assert widget.methods[1].args == ["x", "y"]
+    assert widget.methods[1].docstring == "Draw at a position."
+    assert [(func.name, func.private) for func in functions] == [("clamp", True)]
+
+
+def test_scripts_without_return_keep_globals_public(tmp_path: Path) -> None:
+    classes, functions = _parse(SCRIPT)
+
+    assert [(func.name, func.signature) for func in functions] == [
+        ("spawn", "function spawn(kind)")
+    ]
+    assert functions[0].docstring == "Spawn an enemy."
+    assert [m.signature for m in classes["Player"].methods] == ["function Player:jump(height)"]
+
+    (tmp_path / "game.lua").write_text(SCRIPT, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert analysis["languages"] == {"lua": 1}
+    (module,) = build_module_index(analysis)
+    assert [sym["name"] for sym in module["symbols"]] == ["Player", "spawn"]
+    assert analysis["classes"][0]["methods"][0]["name"] == "jump"
@@ -0,0 +1,75 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.make_targets import parse_makefile
+
+MAKEFILE = """.DEFAULT_GOAL := help
+GO ?= go
+SOURCES := $(wildcard *.go)
+
+.PHONY: build test \\
+	clean _stamp
+
+## Build the binary
+## into ./bin.
+build: $(SOURCES)
+\t$(GO) build -o bin/app ./cmd/app
+
+test: build ## Run the unit tests
+\t$(GO) test ./...
+
+clean:
+\trm -rf bin
+
+# Not a help comment.
+lint:
+\tgolangci-lint run
+
+_stamp: ## Internal helper
+\tdate > .stamp
+
+bin/app.tar.gz: build
+\ttar czf $@ bin/app
+
+%.o: %.c ## Pattern rules are not tasks
+\tcc -c $<
+"""
+
+
+def test_documented_and_phony_targets_are_listed() -> None:
+    targets = parse_makefile(MAKEFILE)
+    assert [(t["name"], t["line"], t["help"], t["phony"]) for t in targets] == [
+        ("build", 10, "Build the binary into ./bin.", True),
+        ("test", 13, "Run the unit tests", True),
+        ("clean", 16, "", True),
+    ]
+    assert targets[0]["prerequisites"] == ["$(SOURCES)"]
+    assert targets[1]["prerequisites"] == ["build"]
+
+
+def test_build_tasks_section_lists_make_commands(tmp_path: Path) -> None:
+    (tmp_path / "Makefile").write_text(MAKEFILE, encoding="utf-8")
+    (tmp_path / "docs").mkdir()
+    (tmp_path / "docs" / "Makefile").write_text("html: ## Build the docs site\n", encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    context = ReadmeGenerator()._prepare_context(analysis)
+    assert [(t["command"], t["file"], t["help"]) for t in context["make_targets"]] == [
+        ("make build", "Makefile", "Build the binary into ./bin."),
+        ("make test", "Makefile", "Run the unit tests"),
+        ("make clean", "Makefile", ""),
+        ("make -C docs html", "docs/Makefile", "Build the docs site"),
+    ]
+
+
+def test_help_with_a_pipe_stays_in_its_table_cell(tmp_path: Path) -> None:
+    (tmp_path / "Makefile").write_text(
+        "logs: ## Tail logs | grep ERROR\n\tkubectl logs app\n", encoding="utf-8"
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    readme = ReadmeGenerator().generate(analysis)
+    assert "| `make logs` | Tail logs \\| grep ERROR |" in readme
@@ -0,0 +1,109 @@
+from __future__ import annotations
+
+from datetime import date
+from pathlib import Path
+
+from docgenie import cli
+from docgenie.cli_flags import scan_cli_interface
+from docgenie.core import CodebaseAnalyzer
+from docgenie.man_page import ManPageGenerator
+
+STD_MAIN = """package main
+
+import "flag"
+
+func main() {
+\tport := flag.Int("port", 8080, "port to listen on, e.g. 80")
+\tvar verbose bool
+\tflag.BoolVar(&verbose, "verbose", false, "log every request")
+\tfs := flag.NewFlagSet("serve", flag.ExitOnError)
+\tfs.String("root", "", "directory to serve")
+\tflag.Parse()
+\t_ = port
+}
+"""
+
+COBRA_MAIN = """package main
+
+import "github.com/spf13/cobra"
+
+var rootCmd = &cobra.Command{
+\tUse:   "tool",
+\tShort: "Manage widgets.",
+\tLong: `Tool manages widgets.
+
+It talks to the widget API.`,
+}
+
+var syncCmd = &cobra.Command{
+\tUse:   "sync [name]",
+\tShort: "Sync one widget",
+}
+
+func init() {
+\trootCmd.PersistentFlags().StringP("config", "c", "tool.yaml", "config file")
+\tsyncCmd.Flags().Bool("dry-run", false, "print changes only")
+\trootCmd.AddCommand(syncCmd)
+}
+
+func main() {
+\t_ = rootCmd.Execute()
+}
+"""
+
+
+def test_scans_standard_flag_package(tmp_path: Path) -> None:
+    entry = tmp_path / "cmd" / "server" / "main.go"
+    entry.parent.mkdir(parents=True)
+    entry.write_text(STD_MAIN, encoding="utf-8")
+
+    cli_interface = scan_cli_interface(tmp_path, [entry])
+
+    assert cli_interface["program"] == "server"
+    assert cli_interface["entry_point"] == "cmd/server/main.go"
+    assert [(f["name"], f["default"], f["usage"]) for f in cli_interface["flags"]] == [
+        ("port", "8080", "port to listen on, e.g. 80"),
+        ("verbose", "", "log every request"),
+        ("root", "", "directory to serve"),
+    ]
+
+
+def test_cobra_commands_render_options(tmp_path: Path) -> None:
+    (tmp_path / "main.go").write_text(COBRA_MAIN, encoding="utf-8")
+    analysis = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"cli_interface": {"enabled": True}}
+    ).analyze()
+
+    page = ManPageGenerator().generate(analysis, date=date(2026, 1, 2))
+
+    assert page.startswith('.TH "TOOL" "1" "2026-01-02"')
+    assert "tool \\- Manage widgets\n" in page
+    assert "Tool manages widgets.\n.PP\nIt talks to the widget API." in page
+    assert ".SH COMMANDS\n.TP\n\\fBsync [name]\\fR\nSync one widget\n.RS" in page
+    assert "\\fB\\-\\-dry\\-run\\fR\nprint changes only\n.RE" in page
+    assert (
+        ".SH OPTIONS\n.TP\n\\fB\\-c\\fR, \\fB\\-\\-config\\fR \\fIstring\\fR\n"
+        "config file (default: tool.yaml)\n" in page
+    )
+
+
+def test_minimal_page_without_flags(tmp_path: Path) -> None:
+    (tmp_path / "go.mod").write_text("module example.com/acme/widgets\n", encoding="utf-8")
+    (tmp_path / "main.go").write_text("package main\n\nfunc main() {}\n", encoding="utf-8")
+    analysis = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"cli_interface": {"enabled": True}}
+    ).analyze()
+    output = tmp_path / "share" / "man" / "man1" / "widgets.1"
+
+    page = ManPageGenerator().generate(analysis, output)
+
+    assert output.read_text(encoding="utf-8") == page
+    assert ".SH NAME\nwidgets \\- command\\-line interface for" in page
+    assert ".SH OPTIONS" not in page
+    assert ".SH COMMANDS" not in page
+
+
+def test_man_output_path_uses_program_name(tmp_path: Path) -> None:
+    assert cli._validate_format("MAN") == "man"
+    outputs = cli._build_outputs("man", tmp_path, tmp_path, "tool")
+    assert outputs == [("man", tmp_path / "man1" / "tool.1")]
@@ -0,0 +1,87 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.migrations import is_migration_file, scan_migrations
+
+CREATE_USERS = """-- Users of the app.
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    price DECIMAL(10, 2) DEFAULT 0,
+    CONSTRAINT users_email_check CHECK (email <> '')
+);
+"""
+
+GOOSE_PROFILES = """-- +goose Up
+CREATE TABLE "profiles" (user_id BIGINT REFERENCES users(id), bio TEXT);
+ALTER TABLE users ADD COLUMN name TEXT, DROP COLUMN price;
+ALTER TABLE users RENAME COLUMN email TO login;
+-- +goose Down
+DROP TABLE profiles;
+"""
+
+
+def _write(tmp_path: Path) -> Path:
+    migrations = tmp_path / "db" / "migrations"
+    migrations.mkdir(parents=True)
+    (migrations / "0001_create_users.up.sql").write_text(CREATE_USERS, encoding="utf-8")
+    (migrations / "0001_create_users.down.sql").write_text(
+        "DROP TABLE users;\n", encoding="utf-8"
+    )
+    (migrations / "0002_profiles.sql").write_text(GOOSE_PROFILES, encoding="utf-8")
+    (tmp_path / "seed.sql").write_text("CREATE TABLE scratch (id INT);\n", encoding="utf-8")
+    return migrations
+
+
+def test_create_table_migration_builds_schema(tmp_path: Path) -> None:
+    _write(tmp_path)
+
+    schema = scan_migrations(tmp_path, sorted(tmp_path.rglob("*.sql")))
+
+    assert schema["migrations"] == [
+        {
+            "name": "0001_create_users",
+            "file": "db/migrations/0001_create_users.up.sql",
+            "down": "db/migrations/0001_create_users.down.sql",
+        },
+        {
+            "name": "0002_profiles",
+            "file": "db/migrations/0002_profiles.sql",
+            "down": "db/migrations/0002_profiles.sql",
+        },
+    ]
+    profiles, users = schema["tables"]
+    assert profiles["name"] == "profiles"
+    assert profiles["columns"][0] == {
+        "name": "user_id",
+        "type": "BIGINT",
+        "constraints": "REFERENCES users(id)",
+    }
+    assert users["migration"] == "0001_create_users"
+    assert [(c["name"], c["type"], c["constraints"]) for c in users["columns"]] == [
+        ("id", "BIGSERIAL", "PRIMARY KEY"),
+        ("login", "VARCHAR(255)", "NOT NULL UNIQUE"),
+        ("name", "TEXT", ""),
+    ]
+    assert not is_migration_file("seed.sql")
+
+
+def test_analyzer_reports_schema_only_with_migrations(tmp_path: Path) -> None:
+    (tmp_path / "app.py").write_text("def run():\n    pass\n", encoding="utf-8")
+    assert CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()[
+        "database_schema"
+    ] == {}
+
+    _write(tmp_path)
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert [table["name"] for table in analysis["database_schema"]["tables"]] == [
+        "profiles",
+        "users",
+    ]
+    disabled = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"database_schema": {"enabled": False}}
+    ).analyze()
+    assert disabled["database_schema"] == {}
@@ -0,0 +1,258 @@
+from __future__ import annotations
+
+import os
+import re
+import subprocess
+import sys
+from pathlib import Path
+
+import pytest
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.module_index import (
+    SUMMARY_LIMIT,
+    block_docstring,
+    build_module_index,
+    summarize,
+    summary_title,
+    symbol_sort_order,
+)
+
+
+def test_build_module_index_groups_symbols_by_file(tmp_path: Path) -> None:
+    data = {
+        "root_path": str(tmp_path),
+        "functions": [
+            {"name": "run", "file": str(tmp_path / "app.py"), "line": 9, "args": ["a"]},
+            {
+                "name": "serve",
+                "file": str(tmp_path / "src" / "lib.rs"),
+                "line": 3,
+                "docstring": "Start the server.\nMore detail.",
+                "signature": "pub fn serve(port: u16) -> Result<(), E>",
+            },
+        ],
+        "classes": [
+            {"name": "App", "file": str(tmp_path / "app.py"), "line": 2, "bases": ["Base"]},
+        ],
+    }
+
+    index = build_module_index(data)
+
+    assert [module["path"] for module in index] == ["app.py", "src/lib.rs"]
+    app, lib = index
+    assert [(sym["name"], sym["kind"]) for sym in app["symbols"]] == [
+        ("App", "class"),
+        ("run", "function"),
+    ]
+    assert app["symbols"][1]["signature"] == "run(a)"
+    assert lib["language"] == "rust"
+    assert lib["symbols"][0]["summary"] == "Start the server."
+
+
+def test_summarize_first_line_and_truncates() -> None:
+    assert summarize(None) == ""
+    assert summarize("x" * 200).endswith("...")
+    assert len(summarize("x" * 200)) == 120
+
+
+def test_summarize_cuts_long_sentences_at_a_word_boundary() -> None:
+    doc = (
+        "Return the parsed configuration for the project, merging defaults with the values "
+        "read from the file and the flags passed on the command line.\nMore detail."
+    )
+
+    summary = summarize(doc)
+    assert summary == (
+        "Return the parsed configuration for the project, merging defaults with the values "
+        "read from the file and the flags..."
+    )
+    assert len(summary) <= SUMMARY_LIMIT
+    assert summary_title(doc) == doc.splitlines()[0]
+    assert summary_title("Short.") == ""
+
+
+def test_summarize_closes_code_spans_left_open_by_the_cut() -> None:
+    doc = (
+        "Call `load_config(path, strict=True, fallback=None, environment=os.environ, "
+        "validate_schema=True, cache=None, retries=3)` to read it | again."
+    )
+
+    summary = summarize(doc)
+    assert summary == (
+        "Call `load_config(path, strict=True, fallback=None, environment=os.environ, "
+        "validate_schema=True, cache=None`..."
+    )
+    assert summary.count("`") % 2 == 0
+    assert summary_title(doc) == (
+        "Call load_config(path, strict=True, fallback=None, environment=os.environ, "
+        "validate_schema=True, cache=None, retries=3) to read it &#124; again."
+    )
+
+
+GO_FENCED_DOC = '''package cfg
+
+// Load reads the config file.
+//
+// ```go
+// cfg, err := cfg.Load("app.yaml")
+// if err != nil || cfg == nil {
+// \treturn err
+// }
+// ```
+func Load(path string) (*Config, error) { return nil, nil }
+
+// Save writes the config file.
+func Save(cfg *Config) error { return nil }
+'''
+
+
+def test_fenced_docstrings_are_rendered_below_the_table(tmp_path: Path) -> None:
+    assert block_docstring("One line.\nAnother line.") == ""
+    assert block_docstring("Usage:\n\n~~~\nrun()\n~~~\n").startswith("Usage:")
+    # An unclosed fence would swallow the rest of the README, so it stays a table row.
+    assert block_docstring("Usage:\n\n```\nrun()\n") == ""
+    assert block_docstring("Usage:\n````\n```\nrun()\n```\n") == ""
+    # Headings become bold lines; `#` comments inside the fence are left alone.
+    assert block_docstring("Run it.\n\n# Examples\n\n```sh\n# start\nrun\n```\n") == (
+        "Run it.\n\n**Examples**\n\n```sh\n# start\nrun\n```"
+    )
+
+    (tmp_path / "cfg.go").write_text(GO_FENCED_DOC, encoding="utf-8")
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    content = ReadmeGenerator().generate(result)
+    section = content.split("### `cfg.go`", 1)[1].split("\n## ", 1)[0]
+
+    rows = [line for line in section.splitlines() if line.startswith("|")]
+    assert [row.split(" | ")[0] for row in rows] == ["| Symbol", "| ---", "| `Save`"]
+    # Every row keeps the header's cell count; no line of the code block leaked in.
+    assert {row.count(" | ") for row in rows} == {3}
+    assert "**`Load`** (function): `func Load(path string) (*Config, error)`" in section
+    body = section.split("**`Load`**", 1)[1]
+    assert '```go\ncfg, err := cfg.Load("app.yaml")\nif err != nil || cfg == nil {' in body
+    assert body.index("Load reads the config file.") < body.index("```go")
+
+
+def test_analyze_lists_rust_module_and_skip_reasons(tmp_path: Path) -> None:
+    (tmp_path / "lib.rs").write_text(
+        "/// Add numbers.\npub fn add(a: i32, b: i32) -> i32 { a + b }\n"
+        "#[cfg(feature = \"extra\")]\npub fn extra() {}\n",
+        encoding="utf-8",
+    )
+    analyzer = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False)
+    result = analyzer.analyze()
+
+    assert result["skipped_reasons"]["rust_cfg_gated"] == 1
+    modules = build_module_index(result)
+    assert [module["path"] for module in modules] == ["lib.rs"]
+    assert modules[0]["symbols"][0]["signature"] == "pub fn add(a: i32, b: i32) -> i32"
+
+
+def test_collapse_modules_wraps_tables_in_details(tmp_path: Path) -> None:
+    (tmp_path / "app.py").write_text(
+        'def run():\n    """Run it."""\n\n\ndef stop():\n    pass\n', encoding="utf-8"
+    )
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    result["run_metrics"]["coverage"] = {
+        "threshold": None,
+        "modules": {"app.py": {"percent": 50.0, "covered": 1, "total": 2}},
+    }
+
+    plain = ReadmeGenerator().generate(result)
+    assert "<details>" not in plain
+
+    result["config"] = {"template_customizations": {"collapse_modules": True}}
+    content = ReadmeGenerator().generate(result)
+    section = content.split("### `app.py`", 1)[1].split("\n## ", 1)[0]
+    # GitHub only renders a table inside <details> after a blank line.
+    summary = r"<summary><code>app\.py</code> \(2 symbols\)</summary>\n\n+\| Symbol"
+    assert re.search(summary, section)
+    # The coverage line stays visible above the collapsed table.
+    assert section.index("Coverage: **50.0%**") < section.index("<details>")
+    assert re.search(r"\|\n\n+</details>$", section.rstrip())
+
+
+GO_USERS = """package users
+
+// GetAllUsers returns every account in the store.
+func GetAllUsers(store map[string]Account) []Account {
+\tusers := []Account{}
+\tfor _, user := range store {
+\t\tusers = append(users, user)
+\t}
+\treturn users
+}
+
+// Account is a user account.
+type Account struct {
+\tName string
+}
+
+func AddUser(store map[string]Account, user Account) {
+\tstore[user.Name] = user
+}
+"""
+
+
+@pytest.mark.parametrize(
+    ("order", "expected"),
+    [
+        ("source", ["GetAllUsers", "Account", "AddUser"]),
+        ("alpha", ["Account", "AddUser", "GetAllUsers"]),
+        ("kind", ["AddUser", "GetAllUsers", "Account"]),
+    ],
+)
+def test_module_table_sort_orders(tmp_path: Path, order: str, expected: list[str]) -> None:
+    (tmp_path / "users.go").write_text(GO_USERS, encoding="utf-8")
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    result["config"] = {"template_customizations": {"sort_symbols": order}}
+
+    [module] = build_module_index(result)
+
+    assert [sym["name"] for sym in module["symbols"]] == expected
+
+
+def test_symbol_sort_order_defaults_to_alpha() -> None:
+    assert symbol_sort_order({}) == "alpha"
+    assert symbol_sort_order({"config": {"template_customizations": {"sort_symbols": "x"}}}) == (
+        "alpha"
+    )
+
+
+_RENDER_SCRIPT = """
+import sys
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_generator import HTMLGenerator
+config = {
+    "analysis": {"incremental": False, "parallelism": 2},
+    "template_customizations": {"sort_symbols": "alpha"},
+}
+data = CodebaseAnalyzer(sys.argv[1], enable_tree_sitter=False, config=config).analyze()
+readme = ReadmeGenerator().generate(data, None)
+html = HTMLGenerator().generate_from_analysis(data, None)
+sys.stdout.write(readme + "\\0" + html)
+"""
+
+
+def test_alpha_rendered_docs_are_byte_stable_across_runs(tmp_path: Path) -> None:
+    (tmp_path / "users.go").write_text(GO_USERS, encoding="utf-8")
+    (tmp_path / "admin.go").write_text(
+        "package users\n\nfunc Revoke() {}\n\nfunc Grant() {}\n", encoding="utf-8"
+    )
+
+    outputs = [
+        subprocess.run(
+            [sys.executable, "-c", _RENDER_SCRIPT, str(tmp_path)],
+            env={**os.environ, "PYTHONHASHSEED": seed},
+            capture_output=True,
+            check=True,
+        ).stdout
+        for seed in ("1", "2", "3")
+    ]
+
+    assert outputs[0] == outputs[1] == outputs[2]
+    readme, html = outputs[0].split(b"\0")
+    assert readme.index(b"| `Grant` |") < readme.index(b"| `Revoke` |")
+    assert b"<code>Grant</code>" in html
@@ -0,0 +1,83 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import GoParser
+from docgenie.module_index import build_module_index
+
+GO_MAIN = """// Package main demonstrates a small inventory service.
+//
+// It wires the HTTP handlers to an in-memory store.
+package main
+
+// Serve starts the HTTP server.
+func Serve(addr string) error { return nil }
+"""
+
+GO_STORE_DOC = """/*
+Package store keeps inventory items in memory.
+*/
+package store
+"""
+
+GO_STORE = """package store
+
+// Put saves an item.
+func Put(key string) {}
+"""
+
+
+def _modules(tmp_path: Path, **config: object) -> dict[str, dict[str, object]]:
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    analysis["config"] = config
+    return {module["path"]: module for module in build_module_index(analysis)}
+
+
+def test_go_package_comment_is_the_module_summary(tmp_path: Path) -> None:
+    parsed = GoParser().parse(GO_MAIN, Path("main.go"), "go")
+    assert parsed.module_doc == (
+        "Package main demonstrates a small inventory service.\n\n"
+        "It wires the HTTP handlers to an in-memory store."
+    )
+
+    (tmp_path / "main.go").write_text(GO_MAIN, encoding="utf-8")
+    module = _modules(tmp_path)["main.go"]
+
+    assert module["summary"] == "Package main demonstrates a small inventory service."
+    assert module["summary_from"] is None
+
+
+def test_python_docstring_and_package_doc_file(tmp_path: Path) -> None:
+    (tmp_path / "shop").mkdir()
+    (tmp_path / "shop" / "__init__.py").write_text(
+        '"""Shopping cart helpers,\nwith tax rules."""\n', encoding="utf-8"
+    )
+    (tmp_path / "shop" / "cart.py").write_text(
+        "def total(items):\n    return sum(items)\n", encoding="utf-8"
+    )
+    (tmp_path / "store").mkdir()
+    (tmp_path / "store" / "doc.go").write_text(GO_STORE_DOC, encoding="utf-8")
+    (tmp_path / "store" / "store.go").write_text(GO_STORE, encoding="utf-8")
+
+    packages = _modules(tmp_path, template_customizations={"group_by": "package"})
+
+    # Neither doc file declares a symbol; their package still gets the summary.
+    assert packages["shop"]["summary"] == "Shopping cart helpers, with tax rules."
+    assert packages["store"]["summary"] == "Package store keeps inventory items in memory."
+    assert _modules(tmp_path)["shop/cart.py"]["summary"] is None
+
+
+def test_summary_is_synthesized_from_most_referenced_symbol(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n\n\n'
+        'def scale(a, k):\n    """Multiply by a factor."""\n    return a * k\n',
+        encoding="utf-8",
+    )
+    (tmp_path / "app.py").write_text(
+        "from calc import scale\n\n\ndef main():\n    return scale(2, 3)\n", encoding="utf-8"
+    )
+
+    module = _modules(tmp_path)["calc.py"]
+
+    assert (module["summary"], module["summary_from"]) == ("Multiply by a factor.", "scale")
@@ -0,0 +1,57 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.config import load_config
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.overview import load_project_overview
+
+OVERVIEW = """---
+tagline: Invoices without spreadsheets.
+links:
+  Docs: https://docs.example.com
+---
+Billing turns **usage records** into invoices.
+
+It runs nightly and emails each customer a PDF.
+"""
+
+
+def test_overview_file_appears_verbatim_in_readme(tmp_path: Path) -> None:
+    (tmp_path / "OVERVIEW.md").write_text(OVERVIEW, encoding="utf-8")
+    (tmp_path / "app.py").write_text("def run():\n    return 1\n", encoding="utf-8")
+    (tmp_path / "docgenie.toml").write_text(
+        '[project]\nname = "Billing"\n', encoding="utf-8"
+    )
+    analysis = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config=load_config(tmp_path)
+    ).analyze()
+
+    readme = ReadmeGenerator().generate(analysis, None)
+
+    assert readme.startswith("# Billing\n")
+    assert "_Invoices without spreadsheets._" in readme
+    assert OVERVIEW.split("---\n", 2)[2].strip() in readme
+    assert "[Docs](https://docs.example.com)" in readme
+    assert "comprehensive functionality" not in readme
+
+
+def test_project_config_wins_over_overview_front_matter(tmp_path: Path) -> None:
+    (tmp_path / "OVERVIEW.md").write_text(OVERVIEW, encoding="utf-8")
+    config = {
+        "project": {
+            "name": "Billing",
+            "tagline": "Invoices, on time.",
+            "description": None,
+            "links": {"Status": "https://status.example.com"},
+        }
+    }
+
+    assert load_project_overview(tmp_path, config) == {
+        "name": "Billing",
+        "tagline": "Invoices, on time.",
+        "description": OVERVIEW.split("---\n", 2)[2].strip(),
+        "links": [{"label": "Status", "url": "https://status.example.com"}],
+    }
+    assert load_project_overview(tmp_path / "missing", config={}) == {}
@@ -0,0 +1,88 @@
+from __future__ import annotations
+
+import sys
+from pathlib import Path
+from typing import Any
+
+from docgenie import cli
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.module_index import build_module_index, package_of
+
+
+def _go_package(tmp_path: Path) -> dict[str, Any]:
+    store = tmp_path / "store"
+    store.mkdir()
+    (store / "store.go").write_text(
+        "package store\n\n// Store keeps rows.\ntype Store struct{}\n\n"
+        "// Open opens a store.\nfunc Open() *Store { return &Store{} }\n",
+        encoding="utf-8",
+    )
+    (store / "close.go").write_text(
+        "package store\n\n// Close releases the store.\nfunc (s *Store) Close() error {\n"
+        "\treturn nil\n}\n\n// Open opens a store.\nfunc Open() *Store { return &Store{} }\n",
+        encoding="utf-8",
+    )
+    (tmp_path / "main.go").write_text(
+        'package main\n\nimport "example.com/app/store"\n\n'
+        "func main() {\n\tstore.Open().Close()\n}\n",
+        encoding="utf-8",
+    )
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+
+def test_package_of_go_directories_and_python_packages() -> None:
+    assert package_of("internal/store/store.go") == "internal/store"
+    assert package_of("main.go") == "."
+    assert package_of("src/shop/models/user.py") == "shop.models"
+    assert package_of("setup.py") == "setup"
+    assert package_of("web/app.ts") == "web/app.ts"
+
+
+def test_package_grouping_merges_two_file_go_package(tmp_path: Path) -> None:
+    analysis = _go_package(tmp_path)
+
+    by_file = build_module_index(analysis, group_by="file")
+    assert [module["path"] for module in by_file] == ["store/close.go", "store/store.go"]
+
+    (store,) = build_module_index(analysis, "alpha", "package")
+    assert store["path"] == "store"
+    assert store["files"] == ["store/close.go", "store/store.go"]
+    # `Open` is declared in both files and listed once, from the first file by path.
+    assert [(sym["name"], sym["file"]) for sym in store["symbols"]] == [
+        ("Open", "store/close.go"),
+        ("Store", "store/store.go"),
+        ("Store.Close", "store/close.go"),
+    ]
+
+    source = build_module_index(analysis, "source", "package")
+    assert [sym["name"] for sym in source[0]["symbols"]] == ["Store.Close", "Open", "Store"]
+
+
+def test_package_grouping_collapses_intra_package_graph_edges(tmp_path: Path) -> None:
+    analysis = _go_package(tmp_path)
+    analysis["symbol_references"] = {"store/close.go": ["Store"], "main.go": ["Open"]}
+
+    files = build_impact_graph_data(analysis, max_nodes=sys.maxsize, max_edges=sys.maxsize)
+    assert {
+        "source": "file:store/close.go",
+        "target": "symbol:store/store.go::Store",
+        "kind": "references",
+    } in files["edges"]
+
+    graph = build_impact_graph_data(
+        analysis, max_nodes=sys.maxsize, max_edges=sys.maxsize, group_by="package"
+    )
+    node_types = {node["id"]: node["type"] for node in graph["nodes"]}
+    assert node_types["package:store"] == "package"
+    assert not any(node_id.startswith("file:") for node_id in node_types)
+    edges = {(edge["source"], edge["target"], edge["kind"]) for edge in graph["edges"]}
+    assert ("package:store", "symbol:store/store.go::Store", "defines") in edges
+    assert ("package:.", "symbol:store/store.go::Open", "references") in edges
+    assert not any(
+        source == "package:store" and kind == "references" for source, _, kind in edges
+    )
+
+
+def test_group_by_option_validation() -> None:
+    assert cli._validate_group_by("Package") == "package"
@@ -176,6 +176,7 @@ def test_tree_sitter_parser_and_registry(monkeypatch: pytest.MonkeyPatch) -> Non
             self.start_byte = 0
             self.end_byte = len(text)
             self.start_point = (start, 0)
+            self.end_point = (start, len(text))
             self._text = text
 
         def child_by_field_name(self, name: str):
@@ -0,0 +1,174 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.languages import PhpParser
+from docgenie.module_index import build_module_index
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = """<?php
+declare(strict_types=1);
+
+namespace App\\Models;
+
+use App\\Contracts\\Shape as ShapeContract;
+use Illuminate\\Database\\Eloquent\\{Model, SoftDeletes};
+use function App\\Support\\helper;
+
+/**
+ * Base shape.
+ */
+#[Entity(table: ['shapes'])]
+abstract class Shape extends Model implements ShapeContract, \\JsonSerializable
+{
+    use SoftDeletes;
+
+    /** @var string Display name. */
+    public $label;
+
+    /** Area units. */
+    protected int $units = 1;
+
+    private string $secret = "x";
+
+    public function __construct(public readonly string $name, private int $hidden = 0)
+    {
+        $text = <<<EOT
+        public function notAMethod() {
+        EOT;
+    }
+
+    /**
+     * Computes the area.
+     *
+     * @param int $precision Digits.
+     * @return float
+     */
+    abstract public function area($precision = 2);
+
+    /**
+     * @param string ...$parts
+     */
+    public static function make(...$parts): static
+    {
+        return new static(...$parts);
+    }
+
+    private function hidden(): void {}
+}
+
+interface Drawable extends \\Countable
+{
+    public function draw(): void; # Renders it.
+}
+
+/**
+ * Greets someone.
+ * @param string $who
+ * @return string
+ */
+function greet($who, int $times = 1)
+{
+    return str_repeat("hi {$who}", $times);
+}
+"""
+
+
+def _parse():
+    return PhpParser().parse(SAMPLE, Path("Shape.php"), "php")
+
+
+def test_php_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("php"), PhpParser)
+
+
+def test_php_types_are_namespace_qualified_with_resolved_bases() -> None:
+    result = _parse()
+    classes = {cls.name: cls for cls in result.classes}
+
+    assert {name: cls.kind for name, cls in classes.items()} == {
+        "App\\Models\\Shape": "class",
+        "App\\Models\\Drawable": "interface",
+    }
+    shape = classes["App\\Models\\Shape"]
+    assert shape.docstring == "Base shape."
+    assert shape.decorators == ["#[Entity(table: ['shapes'])]"]
+    assert shape.bases == [
+        "Illuminate\\Database\\Eloquent\\Model",
+        "App\\Contracts\\Shape",
+        "JsonSerializable",
+        "Illuminate\\Database\\Eloquent\\SoftDeletes",
+    ]
+    assert (shape.line, shape.end_line) == (14, 50)
+    assert classes["App\\Models\\Drawable"].bases == ["Countable"]
+    assert result.imports == {
+        "App\\Contracts\\Shape",
+        "Illuminate\\Database\\Eloquent\\Model",
+        "Illuminate\\Database\\Eloquent\\SoftDeletes",
+        "App\\Support\\helper",
+    }
+
+
+def test_php_signatures_take_missing_types_from_phpdoc() -> None:
+    result = _parse()
+    shape = next(cls for cls in result.classes if cls.name.endswith("Shape"))
+    methods = {method.name: method for method in shape.methods}
+
+    assert list(methods) == ["__construct", "area", "make"]
+    assert methods["area"].kind == "abstract_method"
+    assert methods["area"].signature == "abstract public function area(int $precision = 2): float"
+    assert methods["area"].docstring.startswith("Computes the area.")
+    assert methods["make"].kind == "class_method"
+    assert methods["make"].signature == "public static function make(string ...$parts): static"
+    assert methods["__construct"].kind == "constructor"
+    assert methods["__construct"].args == ["name", "hidden"]
+
+    [greet] = result.functions
+    assert greet.name == "App\\Models\\greet"
+    assert greet.signature == "function greet(string $who, int $times = 1): string"
+    assert greet.args == ["who", "times"]
+
+
+def test_php_fields_cover_typed_and_promoted_properties() -> None:
+    shape = next(cls for cls in _parse().classes if cls.name.endswith("Shape"))
+
+    assert [(item.name, item.type, item.docstring) for item in shape.fields] == [
+        ("label", "string", "Display name."),
+        ("units", "int", "Area units."),
+        ("name", "string", None),
+    ]
+
+
+def test_php_abstract_methods_are_listed_in_module_index() -> None:
+    shape = next(cls for cls in _parse().classes if cls.name.endswith("Shape"))
+
+    [module] = build_module_index({"root_path": ".", "classes": [shape.to_public_dict()]})
+    rows = {sym["name"]: sym for sym in module["symbols"]}
+    assert rows["App\\Models\\Shape::area"]["kind"] == "abstract_method"
+    assert rows["App\\Models\\Shape::area"]["signature"].endswith("area(int $precision = 2): float")
+    assert "App\\Models\\Shape::make" not in rows
+
+
+def test_php_extends_edges_use_fully_qualified_names(tmp_path: Path) -> None:
+    (tmp_path / "src").mkdir()
+    (tmp_path / "src" / "Model.php").write_text(
+        "<?php\nnamespace App;\n\nclass Model {}\n", encoding="utf-8"
+    )
+    (tmp_path / "src" / "Legacy.php").write_text(
+        "<?php\nnamespace Legacy;\n\nclass Model {}\n", encoding="utf-8"
+    )
+    (tmp_path / "src" / "User.php").write_text(
+        "<?php\nnamespace App\\Users;\n\nuse App\\Model;\n\nclass User extends Model {}\n",
+        encoding="utf-8",
+    )
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    graph = build_impact_graph_data(result)
+    edges = {(edge["source"], edge["target"], edge["kind"]) for edge in graph["edges"]}
+    assert (
+        "symbol:src/User.php::App\\Users\\User",
+        "symbol:src/Model.php::App\\Model",
+        "extends",
+    ) in edges
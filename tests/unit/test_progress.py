@@ -0,0 +1,61 @@
+from __future__ import annotations
+
+import io
+import json
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.progress import ProgressReporter, format_eta
+
+
+class _Terminal(io.StringIO):
+    def isatty(self) -> bool:
+        return True
+
+
+def test_analyzer_reports_every_discovered_file(tmp_path: Path) -> None:
+    for name in ("a.py", "b.py", "c.go"):
+        (tmp_path / name).write_text("# empty\n", encoding="utf-8")
+    calls: list[tuple[int, int]] = []
+
+    def record(done: int, total: int) -> None:
+        calls.append((done, total))
+
+    CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, progress=record).analyze()
+
+    assert calls == [(0, 3), (1, 3), (2, 3), (3, 3)]
+
+
+def test_text_progress_shows_eta_only_on_terminals() -> None:
+    now = [0.0]
+    terminal = ProgressReporter("text", _Terminal(), clock=lambda: now[0])
+    for step in range(5):
+        now[0] = float(step)
+        terminal(step * 10, 100)
+    terminal.finish()
+
+    output = terminal.stream.getvalue()
+    assert "\rAnalyzing files: 40/100 (40%), ETA 6s\x1b[K" in output
+    assert output.endswith("\r\x1b[K")
+
+    piped = ProgressReporter("text", io.StringIO())
+    piped(1, 2)
+    piped.finish()
+    assert piped.stream.getvalue() == ""
+    assert (format_eta(185), format_eta(3725)) == ("3m05s", "1h02m")
+
+
+def test_json_progress_is_one_object_per_line() -> None:
+    now = [0.0]
+    reporter = ProgressReporter("json", io.StringIO(), interval=1.0, clock=lambda: now[0])
+    for step in range(4):
+        now[0] = step * 0.5
+        reporter(step, 3)
+    reporter.finish()
+
+    events = [json.loads(line) for line in reporter.stream.getvalue().splitlines()]
+    assert events == [
+        {"event": "progress", "processed": 0, "total": 3, "eta_sec": None},
+        {"event": "progress", "processed": 2, "total": 3, "eta_sec": 0.5},
+        {"event": "done", "processed": 3, "total": 3, "eta_sec": None},
+    ]
@@ -0,0 +1,114 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import ProtobufParser
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = """syntax = "proto3";
+
+package inventory.v1;
+
+import "google/protobuf/timestamp.proto";
+
+// Manages stock levels across warehouses.
+service Inventory {
+  // Looks up a single item.
+  rpc GetItem(GetItemRequest) returns (Item);
+  // Streams every change to an item.
+  rpc WatchItem(GetItemRequest)
+      returns (stream Item) {
+    option deprecated = true;
+  }
+  rpc Sync(stream Item) returns (stream Item);
+}
+
+message GetItemRequest {
+  string sku = 1; // Stock keeping unit.
+}
+
+/* An item in stock. */
+message Item {
+  string sku = 1;
+  // Units on hand, per warehouse.
+  map<string, int32> quantities = 2;
+  repeated string tags = 3;
+  oneof source {
+    string supplier = 4;
+  }
+  enum State {
+    STATE_UNSPECIFIED = 0;
+    IN_STOCK = 1; // Ready to ship.
+  }
+  State state = 5;
+}
+"""
+
+
+def test_protobuf_services_messages_and_enums() -> None:
+    assert isinstance(
+        ParserRegistry(enable_tree_sitter=False).resolve("protobuf"), ProtobufParser
+    )
+    result = ProtobufParser().parse(SAMPLE, Path("inventory.proto"), "protobuf")
+    classes = {cls.name: cls for cls in result.classes}
+
+    assert result.imports == {"google/protobuf/timestamp.proto"}
+    assert [(name, cls.kind) for name, cls in classes.items()] == [
+        ("Inventory", "service"),
+        ("GetItemRequest", "message"),
+        ("Item", "message"),
+        ("Item.State", "enum"),
+    ]
+    service = classes["Inventory"]
+    assert service.docstring == "Manages stock levels across warehouses."
+    assert (service.doc_line, service.line, service.end_line) == (7, 8, 17)
+    rpcs = {method.name: method for method in service.methods}
+    assert rpcs["GetItem"].docstring == "Looks up a single item."
+    assert rpcs["WatchItem"].signature == "rpc WatchItem(GetItemRequest) returns (stream Item)"
+    assert (rpcs["WatchItem"].line, rpcs["WatchItem"].end_line) == (12, 15)
+    assert rpcs["Sync"].signature == "rpc Sync(stream Item) returns (stream Item)"
+
+    item = classes["Item"]
+    assert item.docstring == "An item in stock."
+    assert [(f.name, f.type, f.tags["number"], f.docstring) for f in item.fields] == [
+        ("sku", "string", "1", None),
+        ("quantities", "map<string, int32>", "2", "Units on hand, per warehouse."),
+        ("tags", "repeated string", "3", None),
+        ("supplier", "oneof source string", "4", None),
+        ("state", "State", "5", None),
+    ]
+    assert classes["GetItemRequest"].fields[0].docstring == "Stock keeping unit."
+    assert [(f.name, f.docstring) for f in classes["Item.State"].fields] == [
+        ("STATE_UNSPECIFIED", None),
+        ("IN_STOCK", "Ready to ship."),
+    ]
+
+
+def test_grpc_api_maps_rpcs_to_messages(tmp_path: Path) -> None:
+    (tmp_path / "proto").mkdir()
+    (tmp_path / "proto" / "inventory.proto").write_text(SAMPLE, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    api = analysis["grpc_api"]
+    [service] = api["services"]
+    assert (service["name"], service["file"], service["line"]) == (
+        "Inventory",
+        "proto/inventory.proto",
+        8,
+    )
+    assert [
+        (rpc["name"], rpc["request"], rpc["response"], rpc["streaming"])
+        for rpc in service["rpcs"]
+    ] == [
+        ("GetItem", "GetItemRequest", "Item", ""),
+        ("WatchItem", "GetItemRequest", "Item", "server"),
+        ("Sync", "Item", "Item", "bidirectional"),
+    ]
+    assert [message["name"] for message in api["messages"]] == ["GetItemRequest", "Item"]
+    assert api["messages"][1]["fields"][1]["description"] == "Units on hand, per warehouse."
+
+    disabled = CodebaseAnalyzer(
+        str(tmp_path), enable_tree_sitter=False, config={"grpc_api": {"enabled": False}}
+    ).analyze()
+    assert disabled["grpc_api"] == {}
@@ -0,0 +1,101 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.parsers import PythonAstParser
+from docgenie.readme_quality import deprecation_replacement, deprecation_warnings
+
+SAMPLE = '''import warnings
+from typing_extensions import deprecated
+
+
+@deprecated("Use `load_all` instead.")
+def load(path):
+    """Load one file."""
+
+
+def fetch(url):
+    warnings.warn("fetch() is replaced by Client.get()", DeprecationWarning, stacklevel=2)
+
+
+# Deprecated: kept for 1.x callers.
+def legacy():
+    pass
+
+
+def current():
+    warnings.warn("slow path", RuntimeWarning)
+
+    def helper():
+        warnings.warn("inner", DeprecationWarning)
+
+
+@deprecated
+class OldClient:
+    @property
+    def name(self):  # Deprecated: use label
+        return ""
+
+    def close(self):
+        warnings.warn(category=PendingDeprecationWarning, message="going away")
+'''
+
+
+def _parsed() -> dict[str, object]:
+    parsed = PythonAstParser().parse(SAMPLE, Path("api.py"), "python")
+    return parsed.to_public_dict()
+
+
+def test_python_deprecation_sources_are_recorded() -> None:
+    parsed = _parsed()
+    notes = {func["name"]: func["deprecated"] for func in parsed["functions"]}
+
+    assert notes["load"] == "Use `load_all` instead."
+    assert notes["fetch"] == "fetch() is replaced by Client.get()"
+    assert notes["legacy"] == "kept for 1.x callers."
+    # Other warning categories do not count, nor do warnings raised by nested functions.
+    assert notes["current"] is None
+    assert notes["helper"] == "inner"
+
+    (client,) = parsed["classes"]
+    assert client["deprecated"] == ""
+    assert [(m["name"], m["deprecated"]) for m in client["methods"]] == [
+        ("name", "use label"),
+        ("close", "going away"),
+    ]
+    assert parsed["functions"][0]["decorators"] == ["deprecated('Use `load_all` instead.')"]
+
+
+def test_deprecation_replacement_reads_the_note() -> None:
+    assert deprecation_replacement({"deprecated": "Use `load_all` instead."}) == "load_all"
+    assert deprecation_replacement({"deprecated": "replaced by Client.get()"}) == "Client.get"
+    assert deprecation_replacement({"deprecated": "Please use the new API."}) is None
+    assert deprecation_replacement({"deprecated": ""}) is None
+    assert deprecation_replacement({}) is None
+
+
+def test_deprecated_python_symbols_become_warnings(tmp_path: Path) -> None:
+    (tmp_path / "api.py").write_text(SAMPLE, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert sorted(deprecation_warnings(analysis)) == [
+        "Deprecated class `OldClient` (api.py:27)",
+        "Deprecated function `fetch` (api.py:10); use `Client.get` instead",
+        "Deprecated function `helper` (api.py:22)",
+        "Deprecated function `legacy` (api.py:15)",
+        "Deprecated function `load` (api.py:6); use `load_all` instead",
+        "Deprecated method `OldClient.close` (api.py:32)",
+        "Deprecated method `OldClient.name` (api.py:29); use `label` instead",
+    ]
+
+
+def test_deprecation_badge_renders_in_api_reference(tmp_path: Path) -> None:
+    (tmp_path / "api.py").write_text(SAMPLE, encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    readme = ReadmeGenerator().generate(analysis, None)
+
+    load = readme.split("#### `load(path)`", 1)[1]
+    assert load.lstrip().startswith("**Deprecated**: use `load_all` instead.")
+    assert "- `close(self)` _(deprecated)_" in readme
@@ -0,0 +1,84 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+import pytest
+
+from docgenie import cli
+from docgenie.exceptions import ConfigError
+from docgenie.quality_gate import (
+    evaluate_quality_gate,
+    language_coverage,
+    parse_language_thresholds,
+)
+
+
+def _analysis(root: Path) -> dict[str, Any]:
+    return {
+        "root_path": str(root),
+        "files_analyzed": 3,
+        "languages": {"go": 1, "python": 2},
+        "functions": [
+            {"name": "Run", "file": str(root / "main.go"), "docstring": "Run runs."},
+            {"name": "Stop", "file": str(root / "main.go"), "docstring": None},
+            {"name": "load", "file": str(root / "app.py"), "docstring": "Load it."},
+            {"name": "_private", "file": str(root / "app.py"), "docstring": None},
+        ],
+        "classes": [
+            {"name": "Store", "file": str(root / "store.py"), "docstring": ""},
+            {"name": "Cache", "file": str(root / "store.py"), "docstring": "Caches."},
+            {"name": "Cache", "file": str(root / "store.py"), "docstring": "Caches."},
+        ],
+        "project_structure": {},
+    }
+
+
+def test_parse_language_thresholds_accepts_extension_aliases() -> None:
+    assert parse_language_thresholds("go=80, py=60,TS=50.5") == {
+        "go": 80.0,
+        "python": 60.0,
+        "typescript": 50.5,
+    }
+    assert parse_language_thresholds(None) == {}
+
+
+@pytest.mark.parametrize("spec", ["go", "go=fast", "py=101", "=40"])
+def test_parse_language_thresholds_rejects_bad_entries(spec: str) -> None:
+    with pytest.raises(ConfigError):
+        parse_language_thresholds(spec)
+
+
+def test_language_coverage_splits_public_symbols_by_language(tmp_path: Path) -> None:
+    # Private names are left out; python has 3 of 4 public symbols documented.
+    assert language_coverage(_analysis(tmp_path)) == {"go": 50.0, "python": 75.0}
+
+
+def test_quality_gate_passes_and_names_each_failure() -> None:
+    coverage = {"go": 50.0, "python": 75.0}
+
+    assert evaluate_quality_gate(72, coverage, fail_under=70, language_thresholds={}) == []
+    assert evaluate_quality_gate(72, coverage, language_thresholds={"python": 75}) == []
+    assert evaluate_quality_gate(
+        62,
+        coverage,
+        fail_under=70,
+        language_thresholds={"go": 80, "python": 60, "rust": 10},
+    ) == [
+        "Quality score 62 is below --fail-under 70",
+        "go: symbol coverage 50% is below 80%",
+        "rust: no public symbols found",
+    ]
+
+
+def test_analyze_gate_exit_codes(tmp_path: Path, monkeypatch: pytest.MonkeyPatch) -> None:
+    echoed: list[str] = []
+    monkeypatch.setattr(cli.typer, "echo", lambda message, **_: echoed.append(message))
+    analysis = _analysis(tmp_path)
+
+    cli._apply_quality_gate(analysis, None, {"python": 70})
+    assert echoed[-1].startswith("Quality gate passed")
+
+    with pytest.raises(cli.typer.Exit):
+        cli._apply_quality_gate(analysis, 101, {"go": 80})
+    assert echoed[-1] == "Quality gate failed: go: symbol coverage 50% is below 80%"
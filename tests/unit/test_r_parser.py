@@ -0,0 +1,143 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import RParser
+from docgenie.languages.r import is_exported, namespace_exports
+from docgenie.parsers import ParserRegistry
+
+# A roxygen-documented package source, as `devtools::document()` expects it.
+STATS_R = """library(stats)
+suppressPackageStartupMessages(require("dplyr"))
+
+#' Weighted mean of a vector
+#'
+#' Computes the mean of `x`, each value weighted by `w`.
+#'
+#' @param x Numeric vector of values.
+#' @param w Numeric vector of weights, the same
+#'   length as `x`.
+#' @return The weighted mean, a single number.
+#' @export
+#' @examples
+#' weighted_mean(c(1, 2), c(1, 3))
+weighted_mean <- function(x, w = rep(1, length(x)), na.rm = FALSE)
+{
+  if (na.rm) {
+    x <- x[!is.na(x)]
+  }
+  sum(x * w) / sum(w)
+}
+
+square = function(x) x^2
+
+.check <- function(a,
+                   b) {
+  stopifnot(a == b) # } is not the end
+}
+
+#' A geometric shape.
+#'
+#' @slot name Display name.
+#' @slot sides Number of sides.
+#' @importFrom methods new
+setClass("Shape", representation(name = "character", sides = "numeric"))
+
+Circle <- setClass(
+  "Circle",
+  slots = c(radius = "numeric"),
+  contains = "Shape"
+)
+
+#' Area of a shape.
+#' @param shape A shape.
+setGeneric("area", function(shape) standardGeneric("area"))
+
+#' Area of a circle.
+setMethod("area", "Circle", function(shape) {
+  pi * shape@radius^2
+})
+
+setMethod("area", signature(shape = "Square"), function(shape) shape@side^2)
+
+label <- "function( is only text"
+"""
+
+NAMESPACE = """# Generated by roxygen2: do not edit by hand
+
+S3method(print, money)
+export(weighted_mean)
+exportClasses(Shape)
+exportMethods(area)
+importFrom(methods, new)
+"""
+
+
+def test_r_functions_s4_classes_and_roxygen_docs() -> None:
+    result = ParserRegistry().parse(STATS_R, Path("R/stats.R"), "r")
+
+    functions = {(func.name, func.kind): func for func in result.functions}
+    assert list(functions) == [
+        ("weighted_mean", "function"),
+        ("square", "function"),
+        ("area", "generic"),
+        ("area", "method"),
+    ]
+    mean = functions[("weighted_mean", "function")]
+    assert (mean.line, mean.end_line, mean.doc_line, mean.doc_end_line) == (15, 21, 4, 14)
+    assert mean.args == ["x", "w", "na.rm"]
+    assert mean.signature == "weighted_mean <- function(x, w = rep(1, length(x)), na.rm = FALSE)"
+    assert mean.docstring == (
+        "Weighted mean of a vector\n\n"
+        "Computes the mean of `x`, each value weighted by `w`.\n\n"
+        "Arguments:\n"
+        "- `x`: Numeric vector of values.\n"
+        "- `w`: Numeric vector of weights, the same length as `x`.\n\n"
+        "Returns: The weighted mean, a single number."
+    )
+    assert functions[("area", "generic")].signature == 'setGeneric("area", function(shape))'
+    assert functions[("area", "method")].receiver == "Square"
+
+    classes = {cls.name: cls for cls in result.classes}
+    shape, circle = classes["Shape"], classes["Circle"]
+    assert [(f.name, f.type, f.docstring) for f in shape.fields] == [
+        ("name", "character", "Display name."),
+        ("sides", "numeric", "Number of sides."),
+    ]
+    assert shape.docstring == "A geometric shape."
+    assert (circle.line, circle.end_line, circle.bases) == (37, 41, ["Shape"])
+    assert circle.signature == 'setClass("Circle", contains = "Shape")'
+    assert [(m.name, m.signature, m.docstring) for m in circle.methods] == [
+        ("area", 'setMethod("area", "Circle", function(shape))', "Area of a circle.")
+    ]
+    assert result.imports == {"stats", "dplyr", "methods"}
+
+    everything = RParser()
+    everything.include_private = True
+    hidden = everything.parse(STATS_R, Path("R/stats.R"), "r").functions[2]
+    assert (hidden.name, hidden.private, hidden.end_line) == (".check", True, 28)
+
+
+def test_namespace_defines_the_public_surface(tmp_path: Path) -> None:
+    exports = namespace_exports(NAMESPACE + 'exportPattern("^[[:upper:]]")\n')
+    assert exports[0] == {"print.money", "weighted_mean", "Shape", "area"}
+    assert [is_exported(name, exports) for name in ("Circle", "square", "print.money")] == [
+        True,
+        False,
+        True,
+    ]
+
+    (tmp_path / "R").mkdir()
+    (tmp_path / "R" / "stats.R").write_text(STATS_R, encoding="utf-8")
+    (tmp_path / "NAMESPACE").write_text(NAMESPACE, encoding="utf-8")
+    (tmp_path / "DESCRIPTION").write_text("Package: stats2\n", encoding="utf-8")
+
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert [func["name"] for func in analysis["functions"]] == ["weighted_mean", "area", "area"]
+    assert [cls["name"] for cls in analysis["classes"]] == ["Shape"]
+
+    config = {"analysis": {"visibility": "all", "incremental": False}}
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+    private = {func["name"]: func["private"] for func in analysis["functions"]}
+    assert private == {"weighted_mean": False, "square": True, ".check": True, "area": False}
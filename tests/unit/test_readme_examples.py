@@ -0,0 +1,60 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.readme_quality import DEFAULT_SCORE_WEIGHTS, count_examples
+
+# Only the base and examples factors count, so finding an example flips Medium to High.
+EXAMPLE_WEIGHTS = dict.fromkeys(DEFAULT_SCORE_WEIGHTS, 0) | {"base": 50, "examples": 50}
+
+
+def _confidence(tmp_path: Path) -> tuple[int, str]:
+    analysis = CodebaseAnalyzer(
+        str(tmp_path),
+        enable_tree_sitter=False,
+        config={"quality": {"score_weights": EXAMPLE_WEIGHTS}},
+    ).analyze()
+    context = ReadmeGenerator()._prepare_context(analysis)
+    return count_examples(analysis), context["confidence_level"]
+
+
+def test_empty_examples_dir_is_not_an_example(tmp_path: Path) -> None:
+    (tmp_path / "app.py").write_text("def run():\n    return 1\n", encoding="utf-8")
+    examples = tmp_path / "examples"
+    examples.mkdir()
+    (examples / "placeholder.py").write_text("", encoding="utf-8")
+
+    assert _confidence(tmp_path) == (0, "Medium")
+
+
+def test_populated_example_file_counts(tmp_path: Path) -> None:
+    (tmp_path / "app.py").write_text("def run():\n    return 1\n", encoding="utf-8")
+    (tmp_path / "examples").mkdir()
+    (tmp_path / "examples" / "basic.py").write_text("print('hi')\n", encoding="utf-8")
+
+    assert _confidence(tmp_path) == (1, "High")
+
+
+def test_inline_go_example_counts(tmp_path: Path) -> None:
+    (tmp_path / "add.go").write_text(
+        "package add\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n", encoding="utf-8"
+    )
+    (tmp_path / "add_test.go").write_text(
+        'package add\n\nimport "fmt"\n\nfunc ExampleAdd() {\n\tfmt.Println(Add(1, 2))\n'
+        "\t// Output: 3\n}\n\nfunc helperForExample() {}\n",
+        encoding="utf-8",
+    )
+
+    assert _confidence(tmp_path) == (1, "High")
+
+
+def test_python_doctest_counts(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text(
+        'def double(x):\n    """Double x.\n\n    >>> double(2)\n    4\n    """\n'
+        "    return x * 2\n",
+        encoding="utf-8",
+    )
+
+    assert _confidence(tmp_path) == (1, "High")
@@ -1,6 +1,14 @@
 from __future__ import annotations
 
-from docgenie.readme_quality import build_quality_report
+import pytest
+
+from docgenie.config import get_default_config
+from docgenie.exceptions import ConfigError
+from docgenie.readme_quality import (
+    DEFAULT_SCORE_WEIGHTS,
+    build_quality_report,
+    resolve_score_weights,
+)
 
 
 def test_readme_quality_high_confidence_snapshot() -> None:
@@ -8,7 +16,7 @@ def test_readme_quality_high_confidence_snapshot() -> None:
         {
             "files_analyzed": 25,
             "languages": {"python": 20, "typescript": 5},
-            "functions": [{}] * 8,
+            "functions": [{}] * 7 + [{"name": "ExampleRun", "file": "run_test.go"}],
             "classes": [{}] * 4,
             "dependencies": {"pyproject.toml": {"dependencies": ["typer"]}},
         },
@@ -28,6 +36,35 @@ def test_readme_quality_low_confidence_snapshot() -> None:
         },
         has_tests=False,
     )
-    assert report["score"] == 30
+    assert report["score"] == 25
     assert report["confidence"] == "Low"
     assert len(report["warnings"]) >= 4
+
+
+def test_score_weights_renormalize_and_validate() -> None:
+    assert resolve_score_weights() == DEFAULT_SCORE_WEIGHTS
+    # The config defaults merged by the CLI must not shift the library's weights.
+    config_weights = get_default_config()["quality"]["score_weights"]
+    assert resolve_score_weights(config_weights) == resolve_score_weights({})
+    weights = resolve_score_weights({"docstrings": 125, "base": 0})
+    assert sum(weights.values()) == pytest.approx(100)
+    assert weights["docstrings"] == pytest.approx(62.5)
+
+    for bad in ({"tests": -1}, {"tests": "high"}, {"coverage": 5}, "nope"):
+        with pytest.raises(ConfigError):
+            resolve_score_weights(bad)
+    with pytest.raises(ConfigError):
+        resolve_score_weights(dict.fromkeys(DEFAULT_SCORE_WEIGHTS, 0))
+
+
+def test_docstring_weight_changes_score() -> None:
+    analysis = {
+        "files_analyzed": 1,
+        "languages": {"python": 1},
+        "functions": [{"name": "run", "docstring": "Run it."}, {"name": "stop"}],
+        "classes": [{"name": "_Hidden"}],
+        "dependencies": {},
+    }
+    only_docs = resolve_score_weights(dict.fromkeys(DEFAULT_SCORE_WEIGHTS, 0) | {"docstrings": 1})
+    assert build_quality_report(analysis, has_tests=False, weights=only_docs)["score"] == 50
+    assert build_quality_report(analysis, has_tests=False)["score"] == 43
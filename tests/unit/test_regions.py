@@ -0,0 +1,92 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.exceptions import GeneratorError
+from docgenie.generator import ReadmeGenerator
+from docgenie.regions import merge_regions
+
+GENERATED = """# app
+
+## Installation
+
+pip install app
+
+## API Reference
+
+### `add`
+
+Add two numbers.
+"""
+
+EXISTING = """# My App
+
+Hand-written intro.
+
+<!-- docgenie:begin api-reference -->
+stale
+<!-- docgenie:end -->
+
+## Notes
+
+```markdown
+<!-- docgenie:begin installation -->
+```
+"""
+
+
+def test_only_marked_regions_are_replaced() -> None:
+    merged = merge_regions(EXISTING, GENERATED)
+
+    assert merged == EXISTING.replace(
+        "stale\n", "## API Reference\n\n### `add`\n\nAdd two numbers.\n"
+    )
+    assert merge_regions("# Plain README\n", GENERATED) is None
+
+
+@pytest.mark.parametrize(
+    ("existing", "message"),
+    [
+        (
+            "<!-- docgenie:begin installation -->\n<!-- docgenie:begin api-reference -->\n",
+            "api-reference on line 2 is nested inside docgenie:begin installation from line 1",
+        ),
+        ("text\n<!-- docgenie:end -->\n", "docgenie:end on line 2 has no matching"),
+        ("<!-- docgenie:begin installation -->\n", "installation on line 1 is never closed"),
+        ("<!-- docgenie:begin -->\n<!-- docgenie:end -->\n", "line 1 names no section"),
+        (
+            "<!-- docgenie:begin usage -->\n<!-- docgenie:end -->\n",
+            "names no generated section (available: api-reference, installation)",
+        ),
+    ],
+)
+def test_mismatched_markers_are_errors(existing: str, message: str) -> None:
+    with pytest.raises(GeneratorError, match=message.replace("(", r"\(").replace(")", r"\)")):
+        merge_regions(existing, GENERATED, Path("README.md"))
+
+
+def test_hand_written_content_survives_regeneration(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n', encoding="utf-8"
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    readme = tmp_path / "README.md"
+    readme.write_text(
+        "# Calc\n\nWritten by hand.\n\n<!-- docgenie:begin api-reference -->\n"
+        "<!-- docgenie:end -->\n\n## License\n\nAlso by hand.\n",
+        encoding="utf-8",
+    )
+
+    for _ in range(2):
+        content = ReadmeGenerator().generate(analysis, str(readme))
+
+    assert readme.read_text(encoding="utf-8") == content
+    assert content.startswith(
+        "# Calc\n\nWritten by hand.\n\n<!-- docgenie:begin api-reference -->\n"
+    )
+    assert content.endswith("<!-- docgenie:end -->\n\n## License\n\nAlso by hand.\n")
+    assert content.count("## API Reference") == 1
+    assert "Add two numbers." in content
@@ -0,0 +1,117 @@
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+from git import Repo
+from typer.testing import CliRunner
+
+from docgenie.cli import app
+from docgenie.exceptions import RemoteRepoError
+from docgenie.remote import is_remote_url, project_root, remote_checkout, repo_name
+
+
+def _bare_repo(tmp_path: Path) -> str:
+    """Return a `file://` URL for a bare repo whose `v1` tag predates `b.py`."""
+    work = tmp_path / "work"
+    repo = Repo.init(work)
+    (work / "a.py").write_text("def a():\n    return 1\n", encoding="utf-8")
+    repo.index.add(["a.py"])
+    repo.index.commit("add a")
+    repo.create_tag("v1")
+    (work / "b.py").write_text("def b():\n    return 2\n", encoding="utf-8")
+    repo.index.add(["b.py"])
+    repo.index.commit("add b")
+    repo.clone(str(tmp_path / "billing.git"), bare=True)
+    return (tmp_path / "billing.git").as_uri()
+
+
+def test_remote_checkout_is_cached_per_url_and_ref(tmp_path: Path) -> None:
+    url = _bare_repo(tmp_path)
+    cache = tmp_path / "cache"
+
+    assert is_remote_url(url) and is_remote_url("git@github.com:org/billing.git")
+    assert not is_remote_url("src/billing")
+    assert repo_name("https://github.com/org/billing.git") == "billing"
+    with remote_checkout(url, cache_dir=cache) as checkout:
+        assert checkout.name == "billing"
+        assert sorted(path.name for path in checkout.glob("*.py")) == ["a.py", "b.py"]
+        (checkout / "marker").write_text("kept", encoding="utf-8")
+    with remote_checkout(url, cache_dir=cache) as again:
+        assert again == checkout
+        assert (again / "marker").exists()
+    with remote_checkout(url, "v1", cache_dir=cache) as tagged:
+        assert tagged != checkout
+        assert [path.name for path in tagged.glob("*.py")] == ["a.py"]
+
+    with remote_checkout(url) as temporary:
+        assert (temporary / "b.py").exists()
+    assert not temporary.exists()
+
+
+def test_cached_branch_checkout_follows_the_branch(tmp_path: Path) -> None:
+    url = _bare_repo(tmp_path)
+    cache = tmp_path / "cache"
+    with remote_checkout(url, cache_dir=cache) as checkout:
+        assert not (checkout / "c.py").exists()
+    with remote_checkout(url, "v1", cache_dir=cache) as tagged:
+        pass
+
+    work = Repo(tmp_path / "work")
+    (tmp_path / "work" / "c.py").write_text("def c():\n    return 3\n", encoding="utf-8")
+    work.index.add(["c.py"])
+    work.index.commit("add c")
+    work.git.push(str(tmp_path / "billing.git"), "HEAD")
+
+    with remote_checkout(url, cache_dir=cache) as again:
+        assert again == checkout
+        assert (again / "c.py").exists()
+    # A tag cannot move, so its checkout is reused without contacting the remote.
+    (tmp_path / "billing.git").rename(tmp_path / "moved.git")
+    with remote_checkout(url, "v1", cache_dir=cache) as reused:
+        assert reused == tagged
+    with pytest.raises(RemoteRepoError, match="^Cannot fetch file://"):
+        with remote_checkout(url, cache_dir=cache):
+            pass
+
+
+def test_default_checkout_cache_is_in_the_enclosing_project(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    url = _bare_repo(tmp_path)
+    project = tmp_path / "project"
+    (project / "docs").mkdir(parents=True)
+    Repo.init(project)
+    assert project_root(project / "docs") == project
+    assert project_root(tmp_path) == tmp_path
+
+    monkeypatch.chdir(project / "docs")
+    result = CliRunner().invoke(app, ["analyze", url, "--format", "json", "--quiet"])
+
+    assert result.exit_code == 0
+    assert (project / ".docgenie" / "remotes").is_dir()
+    assert not (project / "docs" / ".docgenie").exists()
+
+
+def test_remote_errors_are_reported_without_tracebacks(tmp_path: Path) -> None:
+    url = _bare_repo(tmp_path)
+
+    with pytest.raises(RemoteRepoError, match="no branch, tag or commit named nope"):
+        with remote_checkout(url, "nope", cache_dir=tmp_path / "cache"):
+            pass
+    assert not any((tmp_path / "cache" / "remotes").iterdir())
+    with pytest.raises(RemoteRepoError, match="^Cannot fetch file://"):
+        with remote_checkout((tmp_path / "missing.git").as_uri()):
+            pass
+
+    runner = CliRunner()
+    result = runner.invoke(
+        app, ["analyze", url, "--ref", "v1", "--format", "json", "--no-cache", "--quiet"]
+    )
+    assert result.exit_code == 0
+    assert [item["name"] for item in json.loads(result.stdout)["functions"]] == ["a"]
+    failed = runner.invoke(app, ["analyze", url, "--ref", "nope", "--quiet"])
+    assert failed.exit_code == 1
+    assert "Cannot fetch" in failed.stdout
+    assert "Traceback" not in failed.stdout
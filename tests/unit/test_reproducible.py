@@ -0,0 +1,133 @@
+from __future__ import annotations
+
+import json
+from datetime import datetime, timezone
+from pathlib import Path
+from typing import Any
+
+import pytest
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_generator import HTMLGenerator
+from docgenie.module_index import build_module_index
+from docgenie.reproducible import build_time, path_root, relative_paths, source_date_epoch
+
+
+def _project(root: Path) -> None:
+    (root / "pkg").mkdir(parents=True)
+    (root / "pkg" / "calc.py").write_text(
+        'def add(a, b):\n    """Add two numbers."""\n    return a + b\n', encoding="utf-8"
+    )
+    (root / "app.py").write_text(
+        "from pkg.calc import add\n\n\nclass App:\n    def run(self):\n        return add(1, 2)\n",
+        encoding="utf-8",
+    )
+
+
+def _outputs(analyzed: str) -> tuple[str, str]:
+    """Return the JSON analysis and the README context as the CLI would emit them."""
+    config: dict[str, Any] = {"analysis": {"incremental": False}}
+    analysis = CodebaseAnalyzer(analyzed, enable_tree_sitter=False, config=config).analyze()
+    document = relative_paths(analysis, path_root(analysis))
+    context = ReadmeGenerator()._prepare_context(analysis)
+    return (
+        json.dumps(document, sort_keys=True, default=str),
+        json.dumps(context, sort_keys=True, default=str),
+    )
+
+
+def _rendered(analyzed: Path, config: dict[str, Any] | None = None) -> tuple[bytes, bytes]:
+    """Return the README and HTML page bytes for `analyzed`."""
+    config = {"analysis": {"incremental": False}, **(config or {})}
+    analysis = CodebaseAnalyzer(str(analyzed), enable_tree_sitter=False, config=config).analyze()
+    readme = ReadmeGenerator().generate(analysis, None)
+    html = HTMLGenerator().generate_from_analysis(analysis, None)
+    return readme.encode("utf-8"), html.encode("utf-8")
+
+
+def test_relative_paths_only_rewrites_paths_under_root(tmp_path: Path) -> None:
+    document = {
+        "root_path": str(tmp_path),
+        "functions": [{"file": str(tmp_path / "pkg" / "calc.py"), "line": 1}],
+        "http_routes": [{"path": "/users/{id}", "file": "app.py"}],
+        "config": {"coverage": {"file": "/opt/ci/coverage.xml"}},
+    }
+
+    assert relative_paths(document, tmp_path) == {
+        "root_path": ".",
+        "functions": [{"file": "pkg/calc.py", "line": 1}],
+        "http_routes": [{"path": "/users/{id}", "file": "app.py"}],
+        "config": {"coverage": {"file": "/opt/ci/coverage.xml"}},
+    }
+
+
+def test_output_is_identical_from_different_working_directories(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    repo = tmp_path / "home" / "alice" / "repo"
+    _project(repo)
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+    monkeypatch.chdir(tmp_path)
+    first = _outputs("home/alice/repo")
+    monkeypatch.chdir(repo / "pkg")
+    second = _outputs("..")
+
+    assert first == second
+    for output in first:
+        assert "alice" not in output
+    assert '"generated_date": "2023-11-14 22:13:20"' in first[1]
+    assert '"duration_sec": 0.0' in first[0]
+
+
+def test_rendered_docs_are_identical_across_checkout_locations(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    first_checkout = tmp_path / "home" / "alice" / "repo"
+    second_checkout = tmp_path / "srv" / "ci" / "build-42" / "repo"
+    _project(first_checkout)
+    _project(second_checkout)
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+    first = _rendered(first_checkout)
+    second = _rendered(second_checkout)
+
+    assert first == second
+    for output in first:
+        assert b"alice" not in output
+
+
+def test_module_names_are_relative_to_root_override(tmp_path: Path) -> None:
+    _project(tmp_path / "repo" / "svc")
+    config: dict[str, Any] = {"analysis": {"incremental": False, "path_root": ".."}}
+    analysis = CodebaseAnalyzer(
+        str(tmp_path / "repo" / "svc"), enable_tree_sitter=False, config=config
+    ).analyze()
+
+    modules = [entry["path"] for entry in build_module_index(analysis, group_by="file")]
+
+    assert modules == ["svc/app.py", "svc/pkg/calc.py"]
+    assert sorted(analysis["file_imports"]) == ["svc/app.py", "svc/pkg/calc.py"]
+
+
+def test_root_override_and_source_date_epoch(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    analysis = {
+        "root_path": str(tmp_path / "services" / "api"),
+        "config": {"analysis": {"path_root": "../.."}},
+        "functions": [{"file": str(tmp_path / "services" / "api" / "main.go")}],
+    }
+    root = path_root(analysis)
+
+    assert root == tmp_path.resolve()
+    assert relative_paths(analysis, root)["functions"] == [{"file": "services/api/main.go"}]
+    assert relative_paths(analysis, root)["root_path"] == "services/api"
+
+    monkeypatch.delenv("SOURCE_DATE_EPOCH", raising=False)
+    assert source_date_epoch() is None
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "not-a-number")
+    assert source_date_epoch() is None
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "0")
+    assert build_time() == datetime(1970, 1, 1, tzinfo=timezone.utc)
@@ -0,0 +1,108 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_sections import api_anchor
+from docgenie.routes import (
+    UNRECOGNIZED_ROUTE_REASON,
+    link_route_handlers,
+    scan_http_routes,
+)
+
+ROUTER_SOURCE = """package api
+
+import (
+\t"net/http"
+
+\t"github.com/gorilla/mux"
+)
+
+// GetUser returns a single user.
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+func NewRouter() *mux.Router {
+\tr := mux.NewRouter()
+\tr.HandleFunc("/users/{id}", GetUser).Methods("GET")
+\tr.HandleFunc("/users", handlers.CreateUser).
+\t\tName("create").
+\t\tMethods("POST", "put")
+\t// r.HandleFunc("/disabled", Disabled)
+\thttp.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+\thttp.Handle("/static/", http.HandlerFunc(Static))
+\treturn r
+}
+"""
+
+
+def _write(path: Path, content: str) -> Path:
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(content, encoding="utf-8")
+    return path
+
+
+def test_scan_http_routes_detects_mux_and_net_http(tmp_path: Path) -> None:
+    source = _write(tmp_path / "api" / "router.go", ROUTER_SOURCE)
+    routes, unrecognized = scan_http_routes(tmp_path, [source])
+
+    assert unrecognized == []
+    assert [(r["method"], r["path"], r["handler"], r["line"]) for r in routes] == [
+        ("GET", "/health", "", 19),
+        ("ANY", "/static/", "Static", 20),
+        ("POST", "/users", "handlers.CreateUser", 15),
+        ("PUT", "/users", "handlers.CreateUser", 15),
+        ("GET", "/users/{id}", "GetUser", 14),
+    ]
+    assert {r["file"] for r in routes} == {"api/router.go"}
+
+
+def test_scan_http_routes_skips_dynamic_paths(tmp_path: Path) -> None:
+    dynamic = _write(
+        tmp_path / "dynamic.go",
+        'package api\n\nfunc routes(r *mux.Router) {\n\tr.HandleFunc(prefix+"/x", X)\n'
+        '\tr.HandleFunc("/ok", OK)\n}\n',
+    )
+    python = _write(tmp_path / "app.py", 'app.HandleFunc("/nope", x)\n')
+    routes, unrecognized = scan_http_routes(tmp_path, [dynamic, python])
+
+    assert [r["path"] for r in routes] == ["/ok"]
+    assert unrecognized == ["dynamic.go"]
+
+
+def test_link_route_handlers_only_links_unambiguous_docs() -> None:
+    api_docs = {
+        "functions": [{"name": "GetUser"}, {"name": "Server.List"}, {"name": "Admin.List"}],
+        "classes": [{"name": "Server", "methods": [{"name": "Create"}]}],
+    }
+    rows = link_route_handlers(
+        [
+            {"handler": "handlers.GetUser"},
+            {"handler": "s.List"},
+            {"handler": "s.Create"},
+            {"handler": ""},
+        ],
+        api_docs,
+    )
+
+    assert [row["anchor"] for row in rows] == ["api-getuser", "", "api-server", ""]
+    assert api_docs["functions"][0]["anchor"] == api_anchor("GetUser")
+    assert "anchor" not in api_docs["functions"][1]
+
+
+def test_readme_lists_http_endpoints(tmp_path: Path) -> None:
+    _write(tmp_path / "router.go", ROUTER_SOURCE)
+    _write(tmp_path / "dynamic.go", "package api\n\nfunc f() { r.HandleFunc(path, X) }\n")
+
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert analysis["skipped_reasons"][UNRECOGNIZED_ROUTE_REASON] == 1
+
+    content = ReadmeGenerator().generate(analysis, None)
+    assert "## HTTP Endpoints" in content
+    assert '<a id="api-getuser"></a>' in content
+    assert "| `GET` | `/users/{id}` | [`GetUser`](#api-getuser) | `router.go:14` |" in content
+    assert "| `GET` | `/health` | inline | `router.go:19` |" in content
+
+    adoc = ReadmeGenerator().generate(analysis, None, output_format="adoc")
+    assert "[[api-getuser]]" in adoc
+    assert "<<api-getuser,`GetUser`>>" in adoc
@@ -0,0 +1,162 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.languages import RubyParser
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = '''# frozen_string_literal: true
+
+require "json"
+require_relative "concerns/auditable"
+
+# Billing namespace.
+module Billing
+  # An invoice sent to a customer.
+  class Invoice < ApplicationRecord
+    include Auditable
+    extend Forwardable
+
+    # Total amount in cents.
+    attr_accessor :total, :currency
+    attr_reader :secret
+
+    # Builds an invoice from params.
+    def self.build(params, *rest, strict: true, &block)
+      new(params)
+    end
+
+    # Sends the invoice.
+    def deliver!(to, cc = nil)
+      if to.nil?
+        raise ArgumentError, "no recipient"
+      end
+      lines.each do |line|
+        puts "#{line} end"
+      end
+      template = <<~TEXT
+        def not_a_method
+        end
+      TEXT
+      template
+    end
+
+    def paid? = status == :paid
+
+    private def audit_log
+      @log
+    end
+
+    def ==(other)
+      total == other.total
+    end
+
+    class << self
+      def default_currency
+        "USD"
+      end
+
+      private
+
+      def hidden_factory; end
+    end
+
+    protected
+
+    def compare_with(other)
+      other
+    end
+
+    private
+
+    def recalculate
+      @total = 0
+    end
+
+    private :secret
+  end
+end
+
+def helper(value)
+  value
+end
+'''
+
+
+def _parse():
+    return RubyParser().parse(SAMPLE, Path("invoice.rb"), "ruby")
+
+
+def test_ruby_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("ruby"), RubyParser)
+
+
+def test_ruby_modules_and_classes_with_comment_docstrings() -> None:
+    result = _parse()
+    classes = {cls.name: cls for cls in result.classes}
+
+    assert {name: cls.kind for name, cls in classes.items()} == {
+        "Billing::Invoice": "class",
+        "Billing": "module",
+    }
+    invoice = classes["Billing::Invoice"]
+    assert invoice.docstring == "An invoice sent to a customer."
+    assert invoice.bases == ["ApplicationRecord", "Auditable", "Forwardable"]
+    assert (invoice.line, invoice.end_line) == (9, 70)
+    assert classes["Billing"].docstring == "Billing namespace."
+    assert [func.name for func in result.functions] == ["helper"]
+    assert result.imports == {"json", "./concerns/auditable", "Auditable", "Forwardable"}
+
+
+def test_ruby_methods_exclude_private_ones() -> None:
+    invoice = next(cls for cls in _parse().classes if cls.name == "Billing::Invoice")
+    methods = {method.name: method for method in invoice.methods}
+
+    assert list(methods) == [
+        "build",
+        "deliver!",
+        "paid?",
+        "==",
+        "default_currency",
+        "compare_with",
+    ]
+    assert methods["build"].kind == "class_method"
+    assert methods["build"].args == ["params", "rest", "strict", "block"]
+    assert methods["build"].docstring == "Builds an invoice from params."
+    assert methods["deliver!"].args == ["to", "cc"]
+    assert (methods["deliver!"].line, methods["deliver!"].end_line) == (23, 35)
+    assert methods["paid?"].end_line == methods["paid?"].line
+    assert methods["default_currency"].kind == "class_method"
+    assert methods["compare_with"].kind == "method"
+
+    assert [(item.name, item.type) for item in invoice.fields] == [
+        ("total", "attr_accessor"),
+        ("currency", "attr_accessor"),
+    ]
+    assert invoice.fields[0].docstring == "Total amount in cents."
+
+
+def test_ruby_mixins_and_require_relative_form_graph_edges(tmp_path: Path) -> None:
+    app = tmp_path / "app"
+    (app / "concerns").mkdir(parents=True)
+    (app / "concerns" / "auditable.rb").write_text(
+        "module Auditable\n  def audit; end\nend\n", encoding="utf-8"
+    )
+    (app / "user.rb").write_text(
+        'require_relative "concerns/auditable"\n\n'
+        "class User\n  include Auditable\nend\n",
+        encoding="utf-8",
+    )
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert result["file_imports"]["app/user.rb"] == ["Auditable", "app/concerns/auditable.rb"]
+    graph = build_impact_graph_data(result)
+    edges = {(edge["source"], edge["target"], edge["kind"]) for edge in graph["edges"]}
+    assert ("file:app/user.rb", "file:app/concerns/auditable.rb", "import") in edges
+    assert (
+        "symbol:app/user.rb::User",
+        "symbol:app/concerns/auditable.rb::Auditable",
+        "extends",
+    ) in edges
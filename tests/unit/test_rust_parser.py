@@ -0,0 +1,135 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.languages import RustParser
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = '''use std::collections::HashMap;
+use crate::store::{Reader, Writer};
+
+/// Parsed configuration.
+///
+/// Built from `Config::load`.
+#[derive(Debug, Clone)]
+pub struct Config {
+    pub name: String,
+}
+
+/// Supported output formats.
+pub enum Format {
+    Json,
+    Yaml,
+}
+
+/// Anything that can render itself.
+pub trait Render: Send + Sync {
+    /// Render to a string.
+    fn render(&self) -> String;
+}
+
+impl Config {
+    /// Load configuration from disk.
+    pub fn load(path: &str, strict: bool) -> Result<Self, String> {
+        let braces = "{ not a block }";
+        let c = '{';
+        Ok(Config { name: path.to_string() })
+    }
+
+    fn helper(&mut self) {}
+}
+
+impl Render for Config {
+    fn render(&self) -> String {
+        self.name.clone()
+    }
+}
+
+struct Hidden;
+
+impl Hidden {
+    pub fn exposed(&self) {}
+}
+
+impl<T: Clone> Render for Wrapper<T> {
+    fn render(&self) -> String {
+        String::new()
+    }
+}
+
+/// Start the server.
+pub async fn serve(mut port: u16, opts: HashMap<String, (u8, u8)>) {}
+
+fn private_helper() {}
+
+#[cfg(test)]
+mod tests {
+    #[test]
+    fn it_works() {}
+}
+
+macro_rules! make_fn {
+    ($name:ident) => { fn $name() {} };
+}
+
+make_fn!(generated);
+'''
+
+
+def test_rust_parser_extracts_public_functions_with_docs() -> None:
+    parsed = RustParser().parse(SAMPLE, Path("lib.rs"), "rust")
+    names = [func.name for func in parsed.functions]
+    assert names == ["serve"]
+    serve = parsed.functions[0]
+    assert serve.docstring == "Start the server."
+    assert serve.args == ["port", "opts"]
+    assert serve.is_async
+    assert serve.signature is not None and serve.signature.startswith("pub async fn serve(")
+
+
+def test_rust_parser_extracts_types_traits_and_impls() -> None:
+    parsed = RustParser().parse(SAMPLE, Path("lib.rs"), "rust")
+    by_kind = {(cls.kind, cls.name): cls for cls in parsed.classes}
+
+    config = by_kind[("struct", "Config")]
+    assert config.docstring == "Parsed configuration.\n\nBuilt from `Config::load`."
+    assert config.decorators == ["#[derive(Debug, Clone)]"]
+    assert by_kind[("enum", "Format")].docstring == "Supported output formats."
+
+    render = by_kind[("trait", "Render")]
+    assert render.bases == ["Send", "Sync"]
+    assert [m.name for m in render.methods] == ["render"]
+    assert render.methods[0].docstring == "Render to a string."
+
+    # Impl blocks attach their methods to the type instead of adding another `Config`.
+    assert [cls.name for cls in parsed.classes].count("Config") == 1
+    assert config.bases == ["Render"]
+    assert [m.name for m in config.methods] == ["load", "render"]
+    assert config.methods[0].args == ["path", "strict"]
+    assert config.methods[0].docstring == "Load configuration from disk."
+
+    # Private types are dropped like private functions, with their impls.
+    assert "Hidden" not in {cls.name for cls in parsed.classes}
+
+    trait_impl = by_kind[("impl", "Wrapper")]
+    assert trait_impl.bases == ["Render"]
+    assert [m.name for m in trait_impl.methods] == ["render"]
+    assert trait_impl.methods[0].args == ["self"]
+
+
+def test_rust_parser_records_cfg_and_macro_skips() -> None:
+    parsed = RustParser().parse(SAMPLE, Path("lib.rs"), "rust")
+    assert sorted(parsed.skipped) == ["rust_cfg_gated", "rust_macro", "rust_macro"]
+    assert "it_works" not in {func.name for func in parsed.functions}
+    assert parsed.imports == {"std::collections::HashMap", "crate::store::{Reader, Writer}"}
+
+
+def test_rust_parser_tolerates_unbalanced_source() -> None:
+    parsed = RustParser().parse("pub fn broken(a: u8 {\n", Path("broken.rs"), "rust")
+    assert [func.name for func in parsed.functions] == ["broken"]
+
+
+def test_registry_prefers_rust_parser() -> None:
+    registry = ParserRegistry(enable_tree_sitter=True, plugins=[])
+    resolved = registry.resolve("rust")
+    assert isinstance(resolved, RustParser)
@@ -0,0 +1,161 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.html_sections import build_impact_graph_data, symbol_node_id
+from docgenie.languages import ScalaParser
+from docgenie.parsers import ParserRegistry
+from docgenie.readme_quality import deprecation_warnings
+
+SAMPLE = '''package com.acme.users
+
+import scala.concurrent.{ExecutionContext, Future}
+import com.acme.db.{Repo, Row => DbRow}, com.acme.util._
+
+/** An account holder.
+  *
+  * @param id Primary key.
+  * @param name Display name.
+  */
+@SerialVersionUID(1L)
+final case class User(id: Long, name: String = "anon", private val secret: String = "")
+    extends Entity
+    with Ordered[User] {
+
+  /** Compare by id. */
+  override def compare(that: User): Int = id.compare(that.id)
+
+  private def hidden(): Unit = ()
+}
+
+/** Factory methods for [[User]]. */
+object User {
+
+  /** Build a guest user. */
+  def guest: User = User(0, "guest")
+
+  implicit val ordering: Ordering[User] = Ordering.by(_.id)
+}
+
+/** Loads users. */
+trait UserRepository extends Repository[User] with Logging {
+
+  /** Find one user. */
+  def find(id: Long)(implicit ec: ExecutionContext): Future[Option[User]]
+
+  def all: Seq[User] = Seq.empty
+}
+
+sealed trait Shape
+case object Circle extends Shape
+
+/** Runs the app. */
+object Main extends App {
+  val banner = """
+    class NotAClass {
+  """
+  def run(args: Array[String]): Unit = {
+    println(banner)
+  }
+}
+
+class Service(val repo: UserRepository, timeout: Int)(implicit ec: ExecutionContext)
+    extends BaseService(timeout) {
+  @deprecated("use fetchAll", "2.0")
+  def fetch[T: Ordering](ids: Seq[Long]): Seq[T] = Seq.empty
+
+  def map[B](f: User => B): Seq[B] = Nil
+}
+
+def topLevel(x: Int): Int = x * 2
+'''
+
+
+def _parse():
+    return ScalaParser().parse(SAMPLE, Path("Users.scala"), "scala")
+
+
+def test_scala_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("scala"), ScalaParser)
+
+
+def test_scala_case_class_fields_and_companion_object() -> None:
+    classes = {cls.name: cls for cls in _parse().classes}
+
+    user = classes["User"]
+    assert user.kind == "case class"
+    assert user.docstring == "An account holder."
+    assert user.bases == ["Entity", "Ordered[User]"]
+    assert user.decorators == ["@SerialVersionUID(1L)"]
+    assert [(f.name, f.type, f.docstring) for f in user.fields] == [
+        ("id", "Long", "Primary key."),
+        ("name", "String", "Display name."),
+    ]
+    # The companion `object User` folds into the class instead of standing on its own.
+    assert [(m.kind, m.name) for m in user.methods] == [
+        ("method", "compare"),
+        ("companion_method", "guest"),
+    ]
+    assert list(classes) == ["User", "UserRepository", "Shape", "Circle", "Main", "Service"]
+    assert classes["Shape"].kind == "sealed trait"
+    assert classes["Circle"].kind == "case object"
+    assert classes["Circle"].bases == ["Shape"]
+
+    service = classes["Service"]
+    # Only `val`/`var` parameters of a plain class are fields; implicit lists never are.
+    assert [f.name for f in service.fields] == ["repo"]
+    assert service.bases == ["BaseService"]
+
+
+def test_scala_trait_members_mark_implicit_parameters() -> None:
+    parsed = _parse()
+    repository = next(cls for cls in parsed.classes if cls.name == "UserRepository")
+
+    assert repository.kind == "trait"
+    assert repository.bases == ["Repository[User]", "Logging"]
+    find, all_users = repository.methods
+    assert find.kind == "abstract_method"
+    assert find.args == ["id", "implicit ec"]
+    assert find.signature == (
+        "def find(id: Long)(implicit ec: ExecutionContext): Future[Option[User]]"
+    )
+    assert (all_users.kind, all_users.args) == ("method", [])
+
+    main = next(cls for cls in parsed.classes if cls.name == "Main")
+    # Declarations inside the triple-quoted string are ignored.
+    assert [m.name for m in main.methods] == ["run"]
+    assert [(f.name, f.signature) for f in parsed.functions] == [
+        ("topLevel", "def topLevel(x: Int): Int")
+    ]
+    assert parsed.imports == {
+        "scala.concurrent.ExecutionContext",
+        "scala.concurrent.Future",
+        "com.acme.db.Repo",
+        "com.acme.db.Row",
+        "com.acme.util._",
+    }
+
+
+def test_scala_mixins_form_extends_edges(tmp_path: Path) -> None:
+    parsed = ScalaParser().parse(SAMPLE, tmp_path / "Users.scala", "scala").to_public_dict()
+    base = "trait Logging\ntrait Repository[T]\n"
+    bases = ScalaParser().parse(base, tmp_path / "Base.scala", "scala").to_public_dict()
+    analysis = {
+        "root_path": str(tmp_path),
+        "classes": parsed["classes"] + bases["classes"],
+        "file_imports": {"Users.scala": [], "Base.scala": []},
+    }
+
+    edges = build_impact_graph_data(analysis)["edges"]
+    extends = {(e["source"], e["target"]) for e in edges if e["kind"] == "extends"}
+
+    repository = symbol_node_id("Users.scala", "UserRepository")
+    assert (repository, symbol_node_id("Base.scala", "Logging")) in extends
+    assert (repository, symbol_node_id("Base.scala", "Repository")) in extends
+
+
+def test_scala_deprecated_methods_surface_as_warnings(tmp_path: Path) -> None:
+    parsed = ScalaParser().parse(SAMPLE, tmp_path / "Users.scala", "scala").to_public_dict()
+    analysis = {"root_path": str(tmp_path), **parsed}
+
+    assert deprecation_warnings(analysis) == ["Deprecated method `Service.fetch` (Users.scala:56)"]
@@ -0,0 +1,82 @@
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+import pytest
+
+from docgenie import __version__
+from docgenie.core import CodebaseAnalyzer
+from docgenie.exceptions import ConfigError
+from docgenie.models import SCHEMA_VERSION
+from docgenie.schema import build_analysis_document
+
+
+def _analyze(tmp_path: Path) -> dict:
+    (tmp_path / "base.py").write_text(
+        'class Base:\n    """Root type."""\n\n    def run(self):\n        return 1\n',
+        encoding="utf-8",
+    )
+    (tmp_path / "child.py").write_text(
+        "import base\nfrom base import Base\n\n\nclass Child(Base):\n    pass\n\n\n"
+        "def build(x, y):\n    return Child()\n",
+        encoding="utf-8",
+    )
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+
+def test_analysis_document_shape(tmp_path: Path) -> None:
+    payload = build_analysis_document(_analyze(tmp_path)).to_public_dict()
+
+    assert payload["schema_version"] == SCHEMA_VERSION == 1
+    assert payload["generator"] == {"name": "docgenie", "version": __version__}
+    assert payload["project"]["languages"] == {"python": 2}
+    assert [module["path"] for module in payload["modules"]] == ["base.py", "child.py"]
+
+    base_symbols = payload["modules"][0]["symbols"]
+    assert base_symbols == [
+        {
+            "id": "symbol:base.py::Base",
+            "name": "Base",
+            "qualified_name": "Base",
+            "kind": "class",
+            "line": 1,
+            "end_line": 5,
+            "doc_line": 2,
+            "doc_end_line": 2,
+            "signature": None,
+            "docstring": "Root type.",
+            "parent": None,
+            "last_modified": None,
+        },
+        {
+            "id": "symbol:base.py::Base.run",
+            "name": "run",
+            "qualified_name": "Base.run",
+            "kind": "method",
+            "line": 4,
+            "end_line": 5,
+            "doc_line": None,
+            "doc_end_line": None,
+            "signature": None,
+            "docstring": None,
+            "parent": "Base",
+            "last_modified": None,
+        },
+    ]
+    child = payload["modules"][1]
+    assert [(sym["qualified_name"], sym["kind"]) for sym in child["symbols"]] == [
+        ("Child", "class"),
+        ("build", "function"),
+    ]
+    assert {"source": "symbol:child.py::Child", "target": "symbol:base.py::Base", "kind": "extends"} in (
+        payload["edges"]
+    )
+    assert all(edge["kind"] in {"import", "extends"} for edge in payload["edges"])
+    assert set(payload["run_metrics"]) >= {"scanned_files", "changed_files", "cache_hits"}
+    json.dumps(payload)
+
+
+def test_analysis_document_rejects_unknown_version(tmp_path: Path) -> None:
+    with pytest.raises(ConfigError, match="Unsupported schema version 2"):
+        build_analysis_document({"root_path": str(tmp_path)}, schema_version=2)
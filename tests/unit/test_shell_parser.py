@@ -0,0 +1,107 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import ShellParser
+from docgenie.models import ParseResult
+from docgenie.module_index import build_module_index
+from docgenie.parsers import ParserRegistry
+
+SAMPLE = """#!/usr/bin/env bash
+# Deployment helpers for the staging cluster.
+
+set -euo pipefail
+source ./lib/log.sh
+
+# Cluster to deploy to.
+export CLUSTER="${CLUSTER:-staging}"
+# Seconds to wait for rollout.
+: "${TIMEOUT:=300}"
+UNDOCUMENTED=1
+
+# Usage: deploy <service> [--dry-run]
+# Roll out a service and wait for it.
+deploy() {
+  local count=$#
+  if [ "$count" -eq 0 ]; then
+    echo "missing service }" >&2
+    return 1
+  fi
+  cat <<TEXT
+unbalanced { brace in a heredoc
+TEXT
+}
+
+# Print the cluster status.
+function status {
+  kubectl get pods -n "$CLUSTER" || true
+}
+
+_helper() (
+  echo "private"
+)
+"""
+
+
+def _parse(*, include_private: bool = False) -> ParseResult:
+    parser = ShellParser()
+    parser.include_private = include_private
+    return parser.parse(SAMPLE, Path("deploy.sh"), "shell")
+
+
+def test_functions_with_usage_and_docs() -> None:
+    result = _parse()
+    deploy, status = result.functions
+
+    assert (deploy.name, deploy.line, deploy.end_line) == ("deploy", 15, 24)
+    assert deploy.usage == "deploy <service> [--dry-run]"
+    assert deploy.docstring == "Roll out a service and wait for it."
+    assert (deploy.doc_line, deploy.doc_end_line) == (13, 14)
+    assert deploy.signature == "deploy()"
+    assert (status.name, status.signature, status.end_line) == ("status", "function status", 29)
+    assert status.usage is None
+    assert result.imports == {"./lib/log.sh"}
+    assert result.module_doc == "Deployment helpers for the staging cluster."
+
+    private = _parse(include_private=True).functions[-1]
+    assert (private.name, private.private, private.end_line) == ("_helper", True, 33)
+
+
+def test_documented_top_level_variables_are_constants() -> None:
+    result = _parse()
+    config = {const.name: const for const in result.constants}
+
+    assert result.classes == []
+    assert sorted(config) == ["CLUSTER", "TIMEOUT"]
+    assert config["CLUSTER"].docstring == "Cluster to deploy to."
+    assert (config["CLUSTER"].expression, config["CLUSTER"].value) == (
+        '"${CLUSTER:-staging}"',
+        None,
+    )
+    assert (config["TIMEOUT"].line, config["TIMEOUT"].value) == (10, "300")
+
+
+def test_shell_symbols_reach_the_module_index(tmp_path: Path) -> None:
+    (tmp_path / "lib").mkdir()
+    (tmp_path / "deploy.sh").write_text(SAMPLE, encoding="utf-8")
+    (tmp_path / "lib" / "log.sh").write_text('# Log a line.\nlog() { echo "$@"; }\n')
+
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    modules = {module["path"]: module for module in build_module_index(analysis)}
+
+    assert analysis["file_imports"]["deploy.sh"] == ["lib/log.sh"]
+    assert [(sym["name"], sym["kind"]) for sym in modules["deploy.sh"]["symbols"]] == [
+        ("deploy", "function"),
+        ("status", "function"),
+    ]
+    (group,) = modules["deploy.sh"]["constant_groups"]
+    assert [(row["name"], row["value"]) for row in group["constants"]] == [
+        ("CLUSTER", '"${CLUSTER:-staging}"'),
+        ("TIMEOUT", "300"),
+    ]
+    assert modules["deploy.sh"]["summary"] == "Deployment helpers for the staging cluster."
+    parsed = ParserRegistry(enable_tree_sitter=False).parse(SAMPLE, Path("deploy.sh"), "shell")
+    complexity = {func.name: func.complexity for func in parsed.functions}
+    # `$#` is not a comment and the quoted or heredoc braces do not end the body early.
+    assert complexity == {"deploy": 2, "status": 2}
@@ -0,0 +1,139 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.languages import SwiftParser
+from docgenie.module_index import build_module_index
+from docgenie.parsers import ParserRegistry
+from docgenie.readme_quality import deprecation_warnings
+
+SAMPLE = '''import Foundation
+@testable import MyKit
+
+/// A shape with an area.
+public protocol Shape: CustomStringConvertible {
+    /// The area in square units.
+    var area: Double { get }
+    func scaled(by factor: Double) -> Self
+}
+
+/// A circle.
+@available(iOS 13, *)
+public struct Circle<T>: Shape, Equatable where T: Numeric {
+    public let radius: Double
+    private var cache: [String: Int] = [:]
+
+    /// Area derived from the radius.
+    public var area: Double {
+        .pi * radius * radius
+    }
+
+    var undocumented: Int { 1 }
+
+    /// Create a circle.
+    public init(radius: Double) {
+        self.radius = radius
+    }
+
+    public func scaled(by factor: Double) -> Circle {
+        Circle(radius: radius * factor)
+    }
+
+    private func secret() {}
+
+    /// Nested kind.
+    enum Kind: String {
+        case small
+    }
+}
+
+extension Circle: Hashable, Codable {
+    /// Legacy hashing.
+    @available(*, deprecated, message: "Use hash(into:)")
+    public func legacyHash() -> Int { 0 }
+}
+
+/// Load shapes.
+@discardableResult
+public func loadShapes(_ url: URL, strict: Bool = false) async throws -> [Shape] {
+    let s = "{ not a block"
+    return []
+}
+
+fileprivate func hidden() {}
+'''
+
+
+def test_swift_parser_is_registered() -> None:
+    assert isinstance(ParserRegistry(enable_tree_sitter=False).resolve("swift"), SwiftParser)
+
+
+def test_swift_types_members_and_docs() -> None:
+    parsed = SwiftParser().parse(SAMPLE, Path("Shapes.swift"), "swift")
+    by_kind = {(cls.kind, cls.name): cls for cls in parsed.classes}
+
+    shape = by_kind[("protocol", "Shape")]
+    assert shape.docstring == "A shape with an area."
+    assert shape.bases == ["CustomStringConvertible"]
+    assert [(m.kind, m.name) for m in shape.methods] == [("property", "area"), ("method", "scaled")]
+
+    circle = by_kind[("struct", "Circle")]
+    assert circle.bases == ["Shape", "Equatable"]
+    assert circle.decorators == ["@available(iOS 13, *)"]
+    assert [(m.kind, m.name) for m in circle.methods] == [
+        ("property", "area"),
+        ("constructor", "init"),
+        ("method", "scaled"),
+    ]
+    assert circle.methods[0].docstring == "Area derived from the radius."
+    assert circle.methods[2].args == ["by"]
+    assert by_kind[("enum", "Circle.Kind")].bases == ["String"]
+    assert parsed.imports == {"Foundation", "MyKit"}
+
+
+def test_swift_extensions_and_free_functions() -> None:
+    parsed = SwiftParser().parse(SAMPLE, Path("Shapes.swift"), "swift")
+
+    extension = next(cls for cls in parsed.classes if cls.kind == "extension")
+    assert (extension.name, extension.bases) == ("Circle", ["Hashable", "Codable"])
+    assert extension.methods[0].decorators == [
+        '@available(*, deprecated, message: "Use hash(into:)")'
+    ]
+
+    assert [func.name for func in parsed.functions] == ["loadShapes"]
+    load = parsed.functions[0]
+    assert load.args == ["url", "strict"]
+    assert load.is_async
+    assert load.decorators == ["@discardableResult"]
+
+
+def test_swift_deprecations_properties_and_conformance_edges(tmp_path: Path) -> None:
+    (tmp_path / "Shapes.swift").write_text(SAMPLE, encoding="utf-8")
+    (tmp_path / "Printable.swift").write_text(
+        "public protocol Printable {}\n\nextension Circle: Printable {}\n", encoding="utf-8"
+    )
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert deprecation_warnings(analysis) == [
+        "Deprecated method `Circle.legacyHash` (Shapes.swift:44)"
+    ]
+    modules = build_module_index(analysis)
+    rows = {sym["name"]: sym for module in modules for sym in module["symbols"]}
+    assert rows["Circle::area"]["kind"] == "property"
+    assert rows["Circle::area"]["summary"] == "Area derived from the radius."
+
+    edges = {
+        (edge["source"], edge["target"])
+        for edge in build_impact_graph_data(analysis)["edges"]
+        if edge["kind"] == "extends"
+    }
+    assert ("symbol:Shapes.swift::Circle", "symbol:Shapes.swift::Shape") in edges
+    assert ("symbol:Printable.swift::Circle", "symbol:Printable.swift::Printable") in edges
+
+
+def test_swift_optional_return_does_not_merge_requirements() -> None:
+    source = "protocol Store {\n    func find() -> Int?\n    func all() -> [Int]\n}\n"
+    parsed = SwiftParser().parse(source, Path("Store.swift"), "swift")
+    assert [method.name for method in parsed.classes[0].methods] == ["find", "all"]
@@ -0,0 +1,207 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie.languages.c_header import CHeaderParser
+from docgenie.languages.go import GoParser
+from docgenie.languages.java import JavaParser
+from docgenie.languages.kotlin import KotlinParser
+from docgenie.languages.php import PhpParser
+from docgenie.languages.ruby import RubyParser
+from docgenie.languages.rust import RustParser
+from docgenie.languages.swift import SwiftParser
+from docgenie.languages.typescript import TypeScriptParser
+from docgenie.models import FunctionDoc, ParseResult
+from docgenie.parsers import ParserPlugin, PythonAstParser
+
+# Every sample documents and decorates `decorated` the same way: doc comment on lines 2-3,
+# one decorator line, the declaration on line 5 and its body closing on line 7. `plain`
+# has neither and spans lines 9-11. Class-based samples nest both inside `Box` on line 1.
+SAMPLES: dict[str, tuple[ParserPlugin, str, str]] = {
+    "java": (
+        JavaParser(),
+        "Box.java",
+        "public class Box {\n"
+        "    /** Does it.\n"
+        "     * Carefully. */\n"
+        "    @Override\n"
+        "    public void decorated() {\n"
+        "        run();\n"
+        "    }\n"
+        "\n"
+        "    public void plain() {\n"
+        "        run();\n"
+        "    }\n"
+        "}\n",
+    ),
+    "kotlin": (
+        KotlinParser(),
+        "Box.kt",
+        "class Box {\n"
+        "    /** Does it.\n"
+        "     * Carefully. */\n"
+        "    @JvmStatic\n"
+        "    fun decorated() {\n"
+        "        run()\n"
+        "    }\n"
+        "\n"
+        "    fun plain() {\n"
+        "        run()\n"
+        "    }\n"
+        "}\n",
+    ),
+    "swift": (
+        SwiftParser(),
+        "Box.swift",
+        "public class Box {\n"
+        "    /// Does it.\n"
+        "    /// Carefully.\n"
+        "    @discardableResult\n"
+        "    public func decorated() -> Int {\n"
+        "        return 1\n"
+        "    }\n"
+        "\n"
+        "    public func plain() {\n"
+        "        run()\n"
+        "    }\n"
+        "}\n",
+    ),
+    "php": (
+        PhpParser(),
+        "Box.php",
+        "<?php\n"
+        "/** Does it.\n"
+        " * Carefully. */\n"
+        "#[Route('/run')]\n"
+        "function decorated() {\n"
+        "    run();\n"
+        "}\n"
+        "\n"
+        "function plain() {\n"
+        "    run();\n"
+        "}\n",
+    ),
+    "typescript": (
+        TypeScriptParser(),
+        "box.ts",
+        "export class Box {\n"
+        "  /** Does it.\n"
+        "   * Carefully. */\n"
+        "  @Input()\n"
+        "  decorated(): void {\n"
+        "    run();\n"
+        "  }\n"
+        "\n"
+        "  plain(): void {\n"
+        "    run();\n"
+        "  }\n"
+        "}\n",
+    ),
+    "rust": (
+        RustParser(),
+        "lib.rs",
+        "\n"
+        "/// Does it.\n"
+        "/// Carefully.\n"
+        "#[inline]\n"
+        "pub fn decorated() {\n"
+        "    run();\n"
+        "}\n"
+        "\n"
+        "pub fn plain() {\n"
+        "    run();\n"
+        "}\n",
+    ),
+    "c": (
+        CHeaderParser(),
+        "api.h",
+        "\n"
+        "/** Does it.\n"
+        " * Carefully. */\n"
+        "__attribute__((deprecated))\n"
+        "int decorated(\n"
+        "    int value,\n"
+        "    int flags);\n"
+        "\n"
+        "int plain(\n"
+        "    int value,\n"
+        "    int flags);\n",
+    ),
+}
+
+
+def _symbols(result: ParseResult) -> dict[str, FunctionDoc]:
+    found = {func.name: func for func in result.functions}
+    for cls in result.classes:
+        found.update({method.name: method for method in cls.methods})
+    return found
+
+
+def _ranges(symbol: FunctionDoc) -> tuple[int | None, ...]:
+    return (symbol.line, symbol.end_line, symbol.doc_line, symbol.doc_end_line)
+
+
+@pytest.mark.parametrize("language", sorted(SAMPLES))
+def test_decorated_symbol_range_starts_at_declaration(language: str) -> None:
+    parser, filename, source = SAMPLES[language]
+
+    symbols = _symbols(parser.parse(source, Path(filename), language))
+
+    assert _ranges(symbols["decorated"]) == (5, 7, 2, 3)
+    assert _ranges(symbols["plain"]) == (9, 11, None, None)
+    assert symbols["decorated"].decorators
+
+
+def test_python_decorator_and_docstring_ranges() -> None:
+    source = (
+        "@cache\n"
+        "@retry(times=3)\n"
+        "def decorated():\n"
+        '    """Does it.\n'
+        "\n"
+        '    Carefully."""\n'
+        "    return 1\n"
+        "\n"
+        "def plain():\n"
+        "    return 2\n"
+    )
+
+    symbols = _symbols(PythonAstParser().parse(source, Path("mod.py"), "python"))
+
+    assert _ranges(symbols["decorated"]) == (3, 7, 4, 6)
+    assert _ranges(symbols["plain"]) == (9, 10, None, None)
+
+
+def test_go_and_ruby_doc_comment_ranges() -> None:
+    go_source = (
+        "package box\n\n// Run does it.\n// Carefully.\nfunc Run() {\n\trun()\n}\n\n"
+        "// Box holds.\ntype Box struct {\n\tA int\n}\n\n// Open opens.\nfunc (b Box) Open() {\n}\n"
+    )
+    go = GoParser().parse(go_source, Path("box.go"), "go")
+    run = _symbols(go)["Run"]
+    box = go.classes[0]
+
+    assert _ranges(run) == (5, 7, 3, 4)
+    assert (box.line, box.end_line, box.doc_line, box.doc_end_line) == (10, 12, 9, 9)
+    assert _ranges(box.methods[0]) == (15, 16, 14, 14)
+
+    ruby_source = "class Box\n  # Runs it.\n  def run\n    go\n  end\nend\n"
+    ruby = RubyParser().parse(ruby_source, Path("box.rb"), "ruby")
+
+    assert _ranges(_symbols(ruby)["run"]) == (3, 5, 2, 2)
+
+
+def test_php_method_attributes_keep_the_member() -> None:
+    source = (
+        "<?php\nclass Box {\n    #[Pure] public function inline() {}\n\n"
+        "    #[Route('/run')]\n    public function own_line() {\n        run();\n    }\n}\n"
+    )
+
+    box = PhpParser().parse(source, Path("Box.php"), "php").classes[0]
+
+    assert [(m.name, m.line, m.end_line, m.decorators) for m in box.methods] == [
+        ("inline", 3, 3, ["#[Pure]"]),
+        ("own_line", 6, 8, ["#[Route('/run')]"]),
+    ]
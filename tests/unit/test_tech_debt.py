@@ -0,0 +1,125 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+import pytest
+
+from docgenie import core
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.tech_debt import debt_groups, debt_warnings, scan_debt_markers
+
+
+def _write(root: Path) -> list[Path]:
+    files = {
+        "app.py": (
+            "# TODO: split this module\n"
+            "todo_list = []\n"
+            'message = "TODO: not a comment"\n'
+            'url = "http://example.com # TODO: still a string"  # TODO: real one\n'
+            "def run():\n"
+            "    return 1  # FIXME(alice): handle errors | retries\n"
+        ),
+        "web/main.js": "/* TODO cache responses */\nconst x = 1; // HACK temporary\n",
+        "lib.go": "package lib\n\n// todo lowercase is ignored\n// FIXME\nfunc A() {}\n",
+    }
+    paths = []
+    for name, content in files.items():
+        path = root / name
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(content, encoding="utf-8")
+        paths.append(path)
+    return paths
+
+
+def test_scan_debt_markers_only_matches_comments(tmp_path: Path) -> None:
+    items = scan_debt_markers(tmp_path, _write(tmp_path))
+
+    assert items == [
+        {"marker": "TODO", "file": "app.py", "line": 1, "note": "split this module"},
+        {"marker": "TODO", "file": "app.py", "line": 4, "note": "real one"},
+        {
+            "marker": "FIXME",
+            "file": "app.py",
+            "line": 6,
+            "note": "handle errors \\| retries",
+            "owner": "alice",
+        },
+        {"marker": "FIXME", "file": "lib.go", "line": 4, "note": ""},
+        {"marker": "TODO", "file": "web/main.js", "line": 1, "note": "cache responses"},
+    ]
+
+
+def test_scan_debt_markers_custom_keywords(tmp_path: Path) -> None:
+    items = scan_debt_markers(tmp_path, _write(tmp_path), ["HACK"])
+
+    assert [(item["file"], item["note"]) for item in items] == [("web/main.js", "temporary")]
+    assert scan_debt_markers(tmp_path, _write(tmp_path), [" "]) == []
+
+
+def test_debt_groups_follow_marker_order() -> None:
+    items = [
+        {"marker": "TODO", "file": "b.py", "line": 3, "note": ""},
+        {"marker": "XXX", "file": "a.py", "line": 1, "note": ""},
+        {"marker": "FIXME", "file": "a.py", "line": 9, "note": "", "author": "Bob"},
+        {"marker": "TODO", "file": "a.py", "line": 7, "note": ""},
+    ]
+
+    groups = debt_groups(items, ["FIXME", "TODO"])
+
+    assert [group["marker"] for group in groups] == ["FIXME", "TODO", "XXX"]
+    assert [(item["file"], item["line"]) for item in groups[1]["items"]] == [
+        ("a.py", 7),
+        ("b.py", 3),
+    ]
+    assert [group["has_authors"] for group in groups] == [True, False, False]
+
+
+def test_debt_warnings_threshold() -> None:
+    items = [{"marker": "TODO", "file": "a.py", "line": n, "note": ""} for n in range(3)]
+
+    assert debt_warnings(items, 3) == []
+    assert debt_warnings(items, None) == []
+    assert debt_warnings(items, 2) == [
+        "3 technical debt markers found in source comments (threshold 2); see Technical Debt."
+    ]
+
+
+def test_analyzer_tech_debt_is_opt_in(tmp_path: Path) -> None:
+    _write(tmp_path)
+
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    assert result["tech_debt"] == []
+
+    enabled = {"tech_debt": {"enabled": True, "markers": ["HACK"]}}
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=enabled).analyze()
+    assert [item["marker"] for item in result["tech_debt"]] == ["HACK"]
+
+
+def test_analyzer_attaches_blamed_author(monkeypatch: pytest.MonkeyPatch, tmp_path: Path) -> None:
+    _write(tmp_path)
+
+    def fake_attach(_root, items, store=None):
+        for item in items:
+            if item["file"].endswith("main.js"):
+                item["last_modified"] = {"date": "2024-01-01", "author": "Dana", "commit": "abc"}
+        return len(items)
+
+    monkeypatch.setattr(core, "attach_git_metadata", fake_attach)
+    config = {"tech_debt": {"enabled": True}, "analysis": {"git_metadata": True}}
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    authors = {item["file"]: item.get("author") for item in result["tech_debt"]}
+    assert authors == {"app.py": None, "lib.go": None, "web/main.js": "Dana"}
+
+
+def test_generator_context_groups_markers_and_warns(tmp_path: Path) -> None:
+    _write(tmp_path)
+    config = {"tech_debt": {"enabled": True, "warning_threshold": 2}}
+    data = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+    context = ReadmeGenerator()._prepare_context(data)
+
+    assert [group["marker"] for group in context["tech_debt"]["groups"]] == ["TODO", "FIXME"]
+    assert context["tech_debt"]["total"] == 5  # noqa: PLR2004
+    assert any("technical debt markers" in warning for warning in context["analysis_warnings"])
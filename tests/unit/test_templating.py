@@ -0,0 +1,96 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+import pytest
+import typer
+
+from docgenie import cli, templating
+from docgenie.exceptions import ConfigError
+from docgenie.generator import ReadmeGenerator
+from docgenie.html_generator import HTMLGenerator
+from docgenie.templating import (
+    BUILTIN_TEMPLATE_DIR,
+    TEMPLATE_NAMES,
+    load_template,
+    validate_template_dir,
+)
+
+
+def _analysis(tmp_path: Path, template_dir: Path | None) -> dict[str, Any]:
+    return {
+        "project_name": "demo",
+        "root_path": str(tmp_path),
+        "files_analyzed": 1,
+        "languages": {"python": 1},
+        "main_language": "python",
+        "functions": [{"name": "run", "file": str(tmp_path / "m.py"), "line": 1, "args": []}],
+        "classes": [],
+        "config": {"template_customizations": {"template_dir": template_dir}},
+    }
+
+
+def test_builtin_templates_ship_as_files() -> None:
+    for name in TEMPLATE_NAMES:
+        assert (BUILTIN_TEMPLATE_DIR / name).is_file()
+        assert load_template(name).custom is False
+
+
+def test_custom_readme_template_overrides_builtin(tmp_path: Path) -> None:
+    templates = tmp_path / "tpl"
+    templates.mkdir()
+    (templates / "readme.md.j2").write_text(
+        "# {{ project_name }} ({{ main_language }})\n{% for mod in modules %}- {{ mod.path }}\n"
+        "{% endfor %}",
+        encoding="utf-8",
+    )
+
+    assert validate_template_dir(templates) == ["readme.md.j2"]
+    content = ReadmeGenerator().generate(_analysis(tmp_path, Path("tpl")), None)
+    assert content.startswith("# demo (python)\n- m.py")
+
+    adoc = ReadmeGenerator(templates).generate(
+        _analysis(tmp_path, None), None, output_format="adoc"
+    )
+    assert adoc.startswith("= demo")
+
+
+def test_custom_template_logs_available_variables(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    (tmp_path / "readme.md.j2").write_text("{{ project_name }} {{ team_owner }}", encoding="utf-8")
+    warnings: list[dict[str, Any]] = []
+
+    class RecordingLogger:
+        def warning(self, _event: str, **kwargs: Any) -> None:
+            warnings.append(kwargs)
+
+    monkeypatch.setattr(templating, "get_logger", lambda _name: RecordingLogger())
+    ReadmeGenerator(tmp_path).generate(_analysis(tmp_path, None), None)
+
+    assert warnings[0]["missing"] == ["team_owner"]
+    assert "project_name" in warnings[0]["available"]
+    assert "modules" in warnings[0]["available"]
+
+
+def test_custom_html_template(tmp_path: Path) -> None:
+    (tmp_path / "html.j2").write_text(
+        "<html><title>{{ project_name }}</title>{{ content }}</html>", encoding="utf-8"
+    )
+    html = HTMLGenerator().generate_from_analysis(_analysis(tmp_path, tmp_path))
+    assert html.startswith("<html><title>demo</title>")
+    assert "<style>" not in html
+
+
+def test_template_dir_validation(tmp_path: Path, monkeypatch: pytest.MonkeyPatch) -> None:
+    with pytest.raises(ConfigError, match="No templates"):
+        validate_template_dir(tmp_path)
+    with pytest.raises(ConfigError, match="not found"):
+        validate_template_dir(tmp_path / "missing")
+
+    echoed: list[str] = []
+    monkeypatch.setattr(cli.typer, "echo", echoed.append)
+    with pytest.raises(typer.Exit):
+        cli._validate_template_dir(tmp_path)
+    assert echoed[0].startswith("Invalid template directory")
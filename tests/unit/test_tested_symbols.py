@@ -0,0 +1,79 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.tested_symbols import find_tested_symbols, is_test_file, tested_symbol_warnings
+
+
+def _project(tmp_path: Path) -> dict[str, Any]:
+    (tmp_path / "calc.py").write_text(
+        "def add(a, b):\n    return a + b\n\n\ndef sub(a, b):\n    return a - b\n\n\n"
+        "def _clamp(value):\n    return value\n",
+        encoding="utf-8",
+    )
+    tests = tmp_path / "tests"
+    tests.mkdir()
+    (tests / "test_calc.py").write_text(
+        "from calc import add\n\n\ndef test_add():\n    assert add(1, 2) == 3\n",
+        encoding="utf-8",
+    )
+    svc = tmp_path / "svc"
+    svc.mkdir()
+    (svc / "math.go").write_text(
+        "package svc\n\nfunc Double(n int) int { return n * 2 }\n\n"
+        "func Triple(n int) int { return n * 3 }\n",
+        encoding="utf-8",
+    )
+    (svc / "math_test.go").write_text(
+        "package svc\n\nfunc TestDouble(t *testing.T) {\n\tif Double(2) != 4 {\n"
+        "\t\tt.Fatal()\n\t}\n}\n",
+        encoding="utf-8",
+    )
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+
+def test_is_test_file_matches_common_conventions() -> None:
+    assert is_test_file("svc/math_test.go")
+    assert is_test_file("pkg/test_calc.py")
+    assert is_test_file("web/app.test.ts")
+    assert not is_test_file("svc/math.go")
+    assert not is_test_file("src/contest.py")
+
+
+def test_tested_and_untested_symbols_are_split(tmp_path: Path) -> None:
+    report = find_tested_symbols(_project(tmp_path))
+
+    assert report is not None
+    # Test functions and private helpers are not part of the public API being measured.
+    assert (report["tested"], report["total"], report["ratio"]) == (2, 4, 0.5)
+    assert [(sym["name"], sym["module"]) for sym in report["untested"]] == [
+        ("sub", "calc.py"),
+        ("Triple", "svc/math.go"),
+    ]
+    assert tested_symbol_warnings(report, 0.5) == []
+    assert tested_symbol_warnings(report, 0.25) == [
+        "2 of 4 public symbols are not referenced by any test (50% untested); see Tested Symbols."
+    ]
+
+
+def test_projects_without_tests_get_no_report(tmp_path: Path) -> None:
+    (tmp_path / "calc.py").write_text("def add(a, b):\n    return a + b\n", encoding="utf-8")
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert find_tested_symbols(analysis) is None
+    assert ReadmeGenerator()._prepare_context(analysis)["tested_symbols"] is None
+
+
+def test_tested_symbols_reach_context_and_warnings(tmp_path: Path) -> None:
+    analysis = _project(tmp_path)
+    analysis["config"] = {"tested_symbols": {"max_listed": 1, "max_untested_ratio": 0.4}}
+    context = ReadmeGenerator()._prepare_context(analysis)
+
+    tested = context["tested_symbols"]
+    assert (tested["tested"], tested["total"], tested["percent"]) == (2, 4, 50)
+    assert [sym["name"] for sym in tested["untested"]] == ["sub"]
+    assert tested["remaining"] == 1
+    assert any("see Tested Symbols" in warning for warning in context["analysis_warnings"])
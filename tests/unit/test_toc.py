@@ -0,0 +1,161 @@
+from __future__ import annotations
+
+import re
+from pathlib import Path
+
+from docgenie.html_sections import (
+    allocate_heading_ids,
+    api_anchor,
+    code_heading_anchors,
+    heading_slug,
+    iter_search_entries,
+    normalize_heading_ids,
+    toc_sidebar_html,
+)
+from docgenie.languages import GoParser
+from docgenie.parsers import PythonAstParser
+from docgenie.toc import insert_toc, toc_settings
+
+README = """# svc
+
+<a id="api-getuser"></a>
+
+A service.
+
+## Features
+
+```bash
+## not a heading
+```
+
+## Installation
+
+### pip
+
+## API Reference
+
+### `GetUser`
+
+### `GetUser`
+
+## Module Index
+"""
+
+
+def test_insert_toc_lists_sections_up_to_depth() -> None:
+    content = insert_toc(README, depth=2)
+
+    toc = content.split("## Table of Contents\n\n", 1)[1].split("\n\n", 1)[0]
+    assert toc.splitlines() == [
+        "- [Features](#features)",
+        "- [Installation](#installation)",
+        "- [API Reference](#api-reference)",
+        "- [Module Index](#module-index)",
+    ]
+    assert content.index("## Table of Contents") < content.index("## Features")
+    assert content.index("A service.") < content.index("## Table of Contents")
+
+
+def test_insert_toc_links_match_deduplicated_html_ids() -> None:
+    content = insert_toc(README, depth=3)
+
+    links = re.findall(r"\]\(#([^)]+)\)", content)
+    assert links == [
+        "features",
+        "installation",
+        "pip",
+        "api-reference",
+        "getuser",
+        "getuser-2",
+        "module-index",
+    ]
+    # The HTML renderer assigns the same IDs to the same headings.
+    rendered = (
+        '<h1 id="toc_0">svc</h1><a id="api-getuser"></a>'
+        '<h2 id="toc_1">Table of Contents</h2><h2 id="toc_2">Features</h2>'
+        '<h2 id="toc_3">Installation</h2><h3 id="toc_4">pip</h3>'
+        '<h2 id="toc_5">API Reference</h2><h3 id="toc_6"><code>GetUser</code></h3>'
+        '<h3 id="toc_7"><code>GetUser</code></h3><h2 id="toc_8">Module Index</h2>'
+    )
+    normalized, _ = normalize_heading_ids(rendered, "")
+    assert re.findall(r'<h[23] id="([^"]+)"', normalized)[1:] == links
+
+
+def test_insert_toc_skips_short_docs_and_existing_tocs() -> None:
+    short = "# svc\n\n## Usage\n\n## License\n"
+    assert insert_toc(short) == short
+    assert "Table of Contents" in insert_toc(short, min_headings=2)
+
+    with_toc = insert_toc(README)
+    assert insert_toc(with_toc) == with_toc
+
+
+def test_toc_settings_and_sidebar() -> None:
+    assert toc_settings({}) == {"depth": 2, "min_headings": 4}
+    config = {"template_customizations": {"toc_depth": 3, "toc_min_headings": 1}}
+    assert toc_settings(config) == {"depth": 3, "min_headings": 1}
+    assert toc_settings({"template_customizations": {"include_toc": False}}) is None
+
+    content = (
+        '<h1 id="svc">svc</h1><h2 id="usage">Usage &amp; setup</h2>'
+        '<h3 id="pip">pip</h3><h4 id="deep">Deep</h4>'
+    )
+    sidebar = toc_sidebar_html(content, depth=3)
+    assert sidebar.startswith('<details class="toc-panel" open><summary>Table of Contents')
+    assert '<li class="toc-level-2"><a href="#usage">Usage &amp; setup</a></li>' in sidebar
+    assert '<li class="toc-level-3"><a href="#pip">pip</a></li>' in sidebar
+    assert "#deep" not in sidebar and "#svc" not in sidebar
+    assert toc_sidebar_html(content, depth=3, min_headings=3) == ""
+
+
+def test_heading_slugs_keep_non_ascii_identifiers() -> None:
+    assert heading_slug("<code>ObtenerAño</code>") == "obteneraño"
+    assert heading_slug("Größe_Wert()") == "größe-wert"
+    assert heading_slug("获取用户") == "获取用户"
+    assert heading_slug("¿?") == "section"
+    # Composed and decomposed spellings collide, so the second one gets a suffix.
+    assert allocate_heading_ids(["café", "cafe\u0301", "获取用户", "获取数据"]) == [
+        "café",
+        "café-2",
+        "获取用户",
+        "获取数据",
+    ]
+    assert api_anchor("ObtenerAño") == "api-obteneraño"
+
+
+def test_accented_symbols_share_anchors_across_toc_html_and_search(tmp_path: Path) -> None:
+    go_file, py_file = tmp_path / "señal.go", tmp_path / "cálculo.py"
+    go = GoParser().parse(
+        "package señal\n\n// ObtenerAño returns the year.\nfunc ObtenerAño() int { return 1 }\n",
+        go_file,
+        "go",
+    )
+    py = PythonAstParser().parse("def café(x):\n    return x\n", py_file, "python")
+    names = [func.name for func in [*go.functions, *py.functions]]
+    assert names == ["ObtenerAño", "café"]
+
+    readme = insert_toc(
+        "# svc\n\n## API Reference\n\n### `ObtenerAño()`\n\n### `café(x)`\n\n## License\n",
+        depth=3,
+        min_headings=1,
+    )
+    links = re.findall(r"\]\(#([^)]+)\)", readme)
+    assert links == ["api-reference", "obteneraño", "café-x", "license"]
+
+    # Python-Markdown ASCII-folds its own IDs; normalization replaces them.
+    rendered = (
+        '<h1 id="svc">svc</h1><h2 id="table-of-contents">Table of Contents</h2>'
+        '<h2 id="api-reference">API Reference</h2>'
+        '<h3 id="obtenerano"><code>ObtenerAño()</code></h3>'
+        '<h3 id="cafe-x"><code>café(x)</code></h3><h2 id="license">License</h2>'
+    )
+    normalized, _ = normalize_heading_ids(rendered, "")
+    assert re.findall(r'<h[23] id="([^"]+)"', normalized)[1:] == links
+
+    analysis_data = {
+        "root_path": str(tmp_path),
+        "functions": [func.to_public_dict() for func in [*go.functions, *py.functions]],
+        "classes": [],
+    }
+    entries = list(iter_search_entries(analysis_data, code_heading_anchors(normalized)))
+    assert [entry["anchor"] for entry in entries] == ["obteneraño", "café-x"]
@@ -0,0 +1,61 @@
+from __future__ import annotations
+
+import csv
+from pathlib import Path
+from typing import Any
+
+import pytest
+
+from docgenie.exceptions import ConfigError
+from docgenie.trend import TREND_COLUMNS, append_trend
+
+
+def _analysis(root: Path, *, with_rust: bool = False) -> dict[str, Any]:
+    functions = [
+        {"name": "Run", "file": str(root / "main.go"), "docstring": "Run runs."},
+        {"name": "Stop", "file": str(root / "main.go"), "docstring": None},
+        {"name": "load", "file": str(root / "app.py"), "docstring": "Load it."},
+    ]
+    if with_rust:
+        functions.append({"name": "parse", "file": str(root / "lib.rs"), "docstring": "Parse."})
+    return {
+        "root_path": str(root),
+        "functions": functions,
+        "classes": [],
+        "git_info": {"latest_commit": {"hash": "0123abcd"}},
+    }
+
+
+def test_append_trend_writes_header_once_with_stable_columns(
+    tmp_path: Path, monkeypatch: pytest.MonkeyPatch
+) -> None:
+    trend = tmp_path / "ci" / "trend.csv"
+    monkeypatch.setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+    append_trend(trend, _analysis(tmp_path), 72.5)
+    # A language showing up later must not add a column.
+    append_trend(trend, {**_analysis(tmp_path, with_rust=True), "git_info": {}}, 80)
+
+    lines = trend.read_text(encoding="utf-8").splitlines()
+    assert lines[0] == ",".join(TREND_COLUMNS)
+    assert len(lines) == 3  # noqa: PLR2004
+    rows = list(csv.DictReader(lines))
+    assert rows[0] == {
+        "timestamp": "2023-11-14T22:13:20Z",
+        "commit": "0123abcd",
+        "score": "72.5",
+        "symbols": "3",
+        "coverage": "go=50,python=100",
+    }
+    assert rows[1]["commit"] == ""
+    assert rows[1]["symbols"] == "4"
+    assert rows[1]["coverage"] == "go=50,python=100,rust=100"
+
+
+def test_append_trend_refuses_a_file_with_other_columns(tmp_path: Path) -> None:
+    trend = tmp_path / "trend.csv"
+    trend.write_text("date,score\n2024-01-01,50\n", encoding="utf-8")
+
+    with pytest.raises(ConfigError, match="expected timestamp, commit"):
+        append_trend(trend, _analysis(tmp_path), 60)
+    assert trend.read_text(encoding="utf-8") == "date,score\n2024-01-01,50\n"
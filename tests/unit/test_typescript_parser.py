@@ -0,0 +1,117 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.languages import TypeScriptParser
+from docgenie.readme_quality import build_quality_report
+
+SAMPLE = """import { helper } from "./util";
+import type { Props } from '../types';
+
+/**
+ * Format a user name.
+ * @param user the user
+ */
+export function formatName(user: User, opts = { upper: false }): string {
+  const s = `hello ${user.name} {`;
+  return s;
+}
+
+/** Public user shape. */
+export interface User extends Base {
+  id: number;
+}
+
+/** Identifier alias. */
+export type UserId = string | number;
+
+/** Add two numbers. */
+export const add = (a: number, b: number): number => a + b;
+
+export const fetchUser = async ({ id }: { id: number }) => {
+  return id;
+};
+
+/* not a doc comment */
+export const API_URL = "https://example.com";
+
+/** The user service. */
+@Injectable()
+export class UserService extends BaseService implements OnInit {
+  /** Load a user. */
+  async load(id: string): Promise<User> {
+    if (id) { return null; }
+  }
+  private secret() {}
+  onClick = (e: Event) => {};
+}
+
+function internal() {}
+"""
+
+
+def test_typescript_parser_extracts_exported_functions() -> None:
+    parsed = TypeScriptParser().parse(SAMPLE, Path("user.ts"), "typescript")
+    functions = {func.name: func for func in parsed.functions}
+
+    assert sorted(functions) == ["add", "fetchUser", "formatName"]
+    assert functions["formatName"].docstring == "Format a user name.\n@param user the user"
+    assert functions["formatName"].args == ["user", "opts"]
+    assert functions["add"].docstring == "Add two numbers."
+    assert functions["add"].signature == "export const add = (a: number, b: number): number"
+    assert functions["fetchUser"].is_async
+    assert functions["fetchUser"].args == ["{ id }"]
+
+
+def test_typescript_parser_separates_types_constants_and_classes() -> None:
+    parsed = TypeScriptParser().parse(SAMPLE, Path("user.ts"), "typescript")
+    kinds = {cls.name: cls.kind for cls in parsed.classes}
+
+    assert kinds == {
+        "User": "interface",
+        "UserId": "type",
+        "API_URL": "constant",
+        "UserService": "class",
+    }
+    by_name = {cls.name: cls for cls in parsed.classes}
+    assert by_name["API_URL"].docstring is None
+    service = by_name["UserService"]
+    assert service.bases == ["BaseService", "OnInit"]
+    assert service.decorators == ["@Injectable()"]
+    assert [method.name for method in service.methods] == ["load", "onClick"]
+    assert service.methods[0].is_async
+    assert parsed.imports == {"./util", "../types"}
+
+
+def test_types_only_file_keeps_symbol_score(tmp_path: Path) -> None:
+    (tmp_path / "types.ts").write_text(
+        "export interface A { id: number }\nexport type B = A | null;\n", encoding="utf-8"
+    )
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert result["functions"] == []
+    assert {cls["kind"] for cls in result["classes"]} == {"interface", "type"}
+    report = build_quality_report(result, has_tests=False)
+    assert "No functions/classes were extracted from source files." not in report["warnings"]
+
+
+def test_relative_typescript_imports_form_cross_file_edges(tmp_path: Path) -> None:
+    src = tmp_path / "src"
+    (src / "lib").mkdir(parents=True)
+    (src / "lib" / "index.ts").write_text("export const VERSION = '1';\n", encoding="utf-8")
+    (src / "util.ts").write_text("export function helper() {}\n", encoding="utf-8")
+    (src / "app.tsx").write_text(
+        'import { helper } from "./util";\nimport { VERSION } from "./lib";\n'
+        'import React from "react";\nexport const App = () => <div>{helper()}</div>;\n',
+        encoding="utf-8",
+    )
+    result = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    assert result["file_imports"]["src/app.tsx"] == ["react", "src/lib/index.ts", "src/util.ts"]
+    graph = build_impact_graph_data(result)
+    edges = {(edge["source"], edge["target"]) for edge in graph["edges"]}
+    assert ("file:src/app.tsx", "file:src/util.ts") in edges
+    assert ("file:src/app.tsx", "file:src/lib/index.ts") in edges
+    assert ("file:src/app.tsx", "module:react") in edges
@@ -0,0 +1,104 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.generator import ReadmeGenerator
+from docgenie.parsers import ParserRegistry
+from docgenie.readme_quality import docstring_coverage, undocumented_symbols
+
+
+def _project(tmp_path: Path) -> None:
+    (tmp_path / "lib.py").write_text(
+        '"""Library."""\n\n\n'
+        'def load(path):\n    """Load a file."""\n    return path\n\n\n'
+        "def save(path):\n    return path\n\n\n"
+        "def legacy():  # docgenie: ignore\n    return None\n\n\n"
+        "class Store:\n    pass\n\n\n"
+        "class _Hidden:\n    pass\n",
+        encoding="utf-8",
+    )
+    (tmp_path / "api.go").write_text(
+        "package api\n\n// Serve starts the server.\nfunc Serve() {}\n\n"
+        "func Stop() {}\n\n//docgenie:ignore\nfunc Reset() {}\n\nfunc helper() {}\n",
+        encoding="utf-8",
+    )
+
+
+def test_ignore_directive_marks_symbols_and_leaves_doc_comment_clean(tmp_path: Path) -> None:
+    registry = ParserRegistry(enable_tree_sitter=False)
+    go = registry.parse(
+        "package api\n\n//docgenie:ignore\nfunc Reset() {}\n\nfunc Stop() {}\n",
+        tmp_path / "api.go",
+        "go",
+    )
+    assert [(f.name, f.doc_ignore, f.docstring) for f in go.functions] == [
+        ("Reset", True, None),
+        ("Stop", False, None),
+    ]
+
+    py = registry.parse(
+        "class Job:  # docgenie: ignore\n    def run(self):\n        pass\n\n"
+        "    # docgenie: ignore\n    def stop(self):\n        pass\n",
+        tmp_path / "app.py",
+        "python",
+    )
+    job = py.classes[0]
+    assert job.doc_ignore is True
+    assert [(m.name, m.doc_ignore) for m in job.methods] == [("run", False), ("stop", True)]
+
+
+def test_undocumented_symbols_match_docstring_coverage(tmp_path: Path) -> None:
+    _project(tmp_path)
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+    functions, classes = analysis["functions"], analysis["classes"]
+
+    missing = undocumented_symbols(functions, classes)
+    # Private names, unexported Go names and ignored symbols are not part of the public API.
+    assert sorted(item["name"] for item in missing) == ["Stop", "Store", "save"]
+    # Serve and load are documented: 2 of the 5 counted symbols.
+    assert docstring_coverage(functions, classes) == 1 - len(missing) / 5
+
+
+def test_undocumented_section_groups_symbols_by_module(tmp_path: Path) -> None:
+    _project(tmp_path)
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False).analyze()
+
+    readme = ReadmeGenerator().generate(analysis, None)
+    section = readme.split("## Undocumented Public API", 1)[1].split("\n## ", 1)[0]
+    assert "3 exported symbols without a doc comment" in section
+    assert section.index("### `api.go`") < section.index("### `lib.py`")
+    assert "- `Stop` (function) - `api.go:6`" in section
+    assert "- `save` (function) - `lib.py:9`" in section
+    assert "- `Store` (class) - `lib.py:17`" in section
+    for hidden in ("`Reset`", "`legacy`", "`helper`", "`_Hidden`", "`load`"):
+        assert hidden not in section
+
+    capped = CodebaseAnalyzer(
+        str(tmp_path),
+        enable_tree_sitter=False,
+        config={"quality": {"max_undocumented_listed": 1}},
+    ).analyze()
+    assert "_...and 2 more._" in ReadmeGenerator().generate(capped, None)
+
+
+def test_ignore_directive_reaches_past_decorators_and_doc_comments(tmp_path: Path) -> None:
+    registry = ParserRegistry(enable_tree_sitter=False)
+    py = registry.parse(
+        "# docgenie: ignore\n@cache\n@retry(3)\ndef load():\n    pass\n\n\n"
+        "@cache\ndef save():\n    pass\n",
+        tmp_path / "app.py",
+        "python",
+    )
+    assert [(f.name, f.doc_ignore) for f in py.functions] == [("load", True), ("save", False)]
+
+    go = registry.parse(
+        "package api\n\n// docgenie: ignore\n// Reset clears the store.\n// It never fails.\n"
+        "func Reset() {}\n\n// Stop halts the server.\nfunc Stop() {}\n",
+        tmp_path / "api.go",
+        "go",
+    )
+    assert [(f.name, f.doc_ignore, f.docstring) for f in go.functions] == [
+        ("Reset", True, "Reset clears the store.\nIt never fails."),
+        ("Stop", False, "Stop halts the server."),
+    ]
@@ -0,0 +1,156 @@
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Any
+
+from docgenie.core import CodebaseAnalyzer
+from docgenie.languages import GoParser, JavaParser
+from docgenie.module_index import build_module_index, is_visible, symbol_visibility
+from docgenie.parsers import ParserRegistry, PythonAstParser
+from docgenie.readme_quality import docstring_coverage
+
+GO_SOURCE = """package store
+
+// Store keeps items.
+type Store struct{}
+
+type cache struct{}
+
+// Get returns an item.
+func (s *Store) Get(id string) string { return s.lookup(id) }
+
+func (s *Store) lookup(id string) string { return id }
+
+func (c *cache) Put(id string) {}
+
+// New returns a store.
+func New() *Store { return &Store{} }
+
+func helper() {}
+"""
+
+JAVA_SOURCE = """package shop;
+
+public class Orders {
+    /** Place an order. */
+    public void place(String id) {}
+
+    private void audit(String id) {}
+
+    private static class Ledger {}
+}
+"""
+
+PYTHON_SOURCE = '''__all__ = ["load"]
+__all__ += ["Loader"]
+
+
+def load():
+    """Load everything."""
+
+
+def save():
+    pass
+
+
+def _helper():
+    pass
+
+
+class Loader:
+    def read(self):
+        pass
+
+    def _cache(self):
+        pass
+'''
+
+
+def _names(symbols: list[Any]) -> list[tuple[str, bool]]:
+    return [(symbol.name, symbol.private) for symbol in symbols]
+
+
+def test_go_visibility_follows_capitalization() -> None:
+    public = GoParser().parse(GO_SOURCE, Path("store.go"), "go")
+    assert _names(public.functions) == [("New", False)]
+    assert _names(public.classes) == [("Store", False)]
+    assert _names(public.classes[0].methods) == [("Get", False)]
+
+    registry = ParserRegistry(enable_tree_sitter=False, include_private=True)
+    everything = registry.parse(GO_SOURCE, Path("store.go"), "go")
+    assert _names(everything.functions) == [("New", False), ("helper", True)]
+    store, cache = everything.classes
+    assert _names(store.methods) == [("Get", False), ("lookup", True)]
+    # An exported method of an unexported type is still private API.
+    assert cache.private
+    assert _names(cache.methods) == [("Put", True)]
+
+
+def test_java_visibility_follows_private_modifier() -> None:
+    public = JavaParser().parse(JAVA_SOURCE, Path("Orders.java"), "java")
+    orders, ledger = public.classes
+    assert _names(orders.methods) == [("place", False)]
+    assert (ledger.name, ledger.private) == ("Orders.Ledger", True)
+
+    registry = ParserRegistry(enable_tree_sitter=False, include_private=True)
+    orders = registry.parse(JAVA_SOURCE, Path("Orders.java"), "java").classes[0]
+    assert _names(orders.methods) == [("place", False), ("audit", True)]
+
+
+def test_python_visibility_follows_underscore_and_all() -> None:
+    parsed = PythonAstParser().parse(PYTHON_SOURCE, Path("io.py"), "python")
+    functions = {func.name: func.private for func in parsed.functions}
+
+    assert functions["load"] is False
+    # `save` is public by name but left out of `__all__`.
+    assert functions["save"] is True
+    assert functions["_helper"] is True
+    (loader,) = parsed.classes
+    assert loader.private is False
+    assert _names(loader.methods) == [("read", False), ("_cache", True)]
+
+    no_all = PythonAstParser().parse("def save():\n    pass\n", Path("io.py"), "python")
+    assert no_all.functions[0].private is False
+
+
+def _analysis(tmp_path: Path, visibility: str) -> dict[str, Any]:
+    (tmp_path / "store.go").write_text(GO_SOURCE, encoding="utf-8")
+    (tmp_path / "io.py").write_text(PYTHON_SOURCE, encoding="utf-8")
+    config = {"analysis": {"visibility": visibility}}
+    return CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+
+
+def test_visibility_selects_documented_symbols_and_coverage(tmp_path: Path) -> None:
+    public = _analysis(tmp_path, "public")
+    assert symbol_visibility(public) == "public"
+    rows = {sym["name"] for module in build_module_index(public) for sym in module["symbols"]}
+    # The Python parser lists methods such as `Loader.read` as functions too.
+    assert rows == {"Store", "New", "load", "Loader", "read"}
+    # Store, New and load are documented; Loader and read are not.
+    assert docstring_coverage(public["functions"], public["classes"], "public") == 0.6
+
+    # Switching visibility re-parses instead of reusing the public-only index.
+    everything = _analysis(tmp_path, "all")
+    rows = {sym["name"] for module in build_module_index(everything) for sym in module["symbols"]}
+    assert {"helper", "cache", "save", "_helper"} <= rows
+    coverage = docstring_coverage(everything["functions"], everything["classes"], "all")
+    assert coverage < 0.6
+    assert not is_visible({"name": "helper", "file": "store.go"})
+    assert is_visible({"name": "helper", "file": "store.go"}, "all")
+
+
+def test_dunder_names_stay_public(tmp_path: Path) -> None:
+    source = (
+        "class Point:\n    def __init__(self, x):\n        self.x = x\n\n"
+        "    def __private(self):\n        pass\n"
+    )
+    parsed = PythonAstParser().parse(source, Path("point.py"), "python")
+    assert _names(parsed.classes[0].methods) == [("__init__", False), ("__private", True)]
+
+    (tmp_path / "point.py").write_text(source, encoding="utf-8")
+    config = {"analysis": {"visibility": "public"}}
+    analysis = CodebaseAnalyzer(str(tmp_path), enable_tree_sitter=False, config=config).analyze()
+    rows = {sym["name"] for module in build_module_index(analysis) for sym in module["symbols"]}
+    assert rows == {"Point", "__init__"}
+    assert is_visible({"name": "__init__", "file": "point.py"})
+    assert not is_visible({"name": "__private", "file": "point.py"})
@@ -0,0 +1,57 @@
+from __future__ import annotations
+
+import os
+from pathlib import Path
+
+from docgenie.watcher import ChangeWatcher, changed_paths
+
+
+def _touch(path: Path, content: str, *, mtime_ns: int | None = None) -> None:
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(content, encoding="utf-8")
+    if mtime_ns is not None:
+        os.utime(path, ns=(mtime_ns, mtime_ns))
+
+
+def test_changed_paths_reports_created_modified_and_deleted() -> None:
+    before = {"a.py": (1, 10), "b.py": (1, 10), "c.py": (1, 10)}
+    after = {"a.py": (1, 10), "b.py": (2, 12), "d.py": (1, 1)}
+    assert changed_paths(before, after) == ["b.py", "c.py", "d.py"]
+
+
+def test_watcher_ignores_outputs_hidden_and_gitignored_paths(tmp_path: Path) -> None:
+    _touch(tmp_path / ".gitignore", "build/\n*.log\n")
+    _touch(tmp_path / "app.py", "x = 1\n", mtime_ns=1_000_000_000)
+    watcher = ChangeWatcher(tmp_path, excluded=[tmp_path / "README.md"])
+
+    _touch(tmp_path / "README.md", "# generated\n")
+    _touch(tmp_path / "build" / "out.py", "y = 2\n")
+    _touch(tmp_path / "debug.log", "noise\n")
+    _touch(tmp_path / ".docgenie" / "index.json", "{}")
+    assert watcher.poll() == []
+
+    _touch(tmp_path / "app.py", "x = 2\n", mtime_ns=2_000_000_000)
+    _touch(tmp_path / "pkg" / "new.py", "z = 3\n")
+    assert watcher.poll() == ["app.py", "pkg/new.py"]
+    assert watcher.take_pending() == ["app.py", "pkg/new.py"]
+    assert watcher.pending == set()
+
+
+def test_wait_for_changes_debounces_rapid_saves(tmp_path: Path) -> None:
+    target = tmp_path / "app.py"
+    _touch(target, "v0\n", mtime_ns=1_000_000_000)
+    saves = iter(["v1\n", "v22\n", None, "v333\n", None, None, None])
+    sleeps: list[float] = []
+
+    def fake_sleep(seconds: float) -> None:
+        sleeps.append(seconds)
+        content = next(saves, None)
+        if content is not None:
+            _touch(target, content, mtime_ns=(len(sleeps) + 1) * 1_000_000_000)
+            _touch(tmp_path / f"extra{len(sleeps)}.py", content)
+
+    watcher = ChangeWatcher(tmp_path, interval=0.1, debounce=0.2, sleep=fake_sleep)
+    changes = watcher.wait_for_changes()
+
+    assert changes == ["app.py", "extra1.py", "extra2.py", "extra4.py"]
+    assert len(sleeps) == 6
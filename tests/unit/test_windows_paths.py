@@ -0,0 +1,56 @@
+from __future__ import annotations
+
+from pathlib import Path
+
+from docgenie.file_rules import FileRules
+from docgenie.generator import ReadmeGenerator
+from docgenie.link_check import find_broken_links
+from docgenie.module_index import build_module_index, relative_path
+from docgenie.utils import is_path_ignored_by_gitignore, load_gitignore_spec
+
+ROOT = "C:\\work\\shop"
+
+
+def test_windows_paths_give_forward_slash_modules_and_anchors() -> None:
+    analysis = {
+        "root_path": ROOT,
+        "functions": [
+            {
+                "name": "Total",
+                "file": f"{ROOT}\\pkg\\orders\\total.go",
+                "line": 3,
+                "type_params": ["T Number"],
+            }
+        ],
+        "classes": [{"name": "Number", "file": f"{ROOT}\\pkg\\num\\number.go", "line": 1}],
+    }
+
+    assert relative_path(Path(ROOT), f"{ROOT}\\pkg\\orders\\total.go") == "pkg/orders/total.go"
+    modules = {module["path"]: module for module in build_module_index(analysis)}
+    assert sorted(modules) == ["pkg/num/number.go", "pkg/orders/total.go"]
+    [constraint] = modules["pkg/orders/total.go"]["symbols"][0]["constraints"]
+    assert constraint == {
+        "name": "Number",
+        "module": "pkg/num/number.go",
+        "anchor": "pkg-num-number-go",
+    }
+    readme = ReadmeGenerator().generate(analysis)
+    assert "### `pkg/num/number.go`" in readme
+    assert f"[`Number`](#{constraint['anchor']})" in readme
+    assert "\\" not in readme
+    assert find_broken_links(readme) == []
+
+    packages = build_module_index(analysis, group_by="package")
+    assert [module["path"] for module in packages] == ["pkg/num", "pkg/orders"]
+
+
+def test_ignore_rules_match_windows_separators(tmp_path: Path) -> None:
+    (tmp_path / ".gitignore").write_text("build/\ndocs/*.tmp\n", encoding="utf-8")
+    spec = load_gitignore_spec(tmp_path)
+
+    assert is_path_ignored_by_gitignore("build\\out\\app.exe", spec)
+    assert is_path_ignored_by_gitignore("docs\\draft.tmp", spec)
+    assert not is_path_ignored_by_gitignore("src\\build.py", spec)
+    rules = FileRules.from_globs([([], ["src\\gen"])])
+    assert rules.excluded("src\\gen\\api.pb.go")
+    assert not rules.excluded("src/app/main.go")
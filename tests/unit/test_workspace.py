@@ -0,0 +1,122 @@
+from __future__ import annotations
+
+import json
+from pathlib import Path
+
+from docgenie.graph_export import mermaid_from_graph
+from docgenie.html_sections import build_impact_graph_data
+from docgenie.workspace import (
+    Subproject,
+    analyze_workspace,
+    detect_subprojects,
+    nested_ignore_patterns,
+    render_workspace_index,
+)
+
+
+def _write(root: Path, rel: str, content: str) -> None:
+    path = root / rel
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(content, encoding="utf-8")
+
+
+def _monorepo(root: Path) -> None:
+    _write(root, "pyproject.toml", '[project]\nname = "tooling"\n')
+    _write(root, "services/auth/go.mod", "module github.com/acme/auth\n\ngo 1.22\n")
+    _write(
+        root,
+        "services/auth/token/token.go",
+        "package token\n\n// Issue creates a token.\nfunc Issue() string {\n\treturn \"t\"\n}\n",
+    )
+    _write(root, "services/api/go.mod", "module github.com/acme/api\n")
+    _write(
+        root,
+        "services/api/main.go",
+        'package main\n\nimport "github.com/acme/auth/token"\n\n'
+        "func main() {\n\ttoken.Issue()\n}\n",
+    )
+    _write(root, "web/package.json", json.dumps({"name": "@acme/web"}))
+    _write(root, "web/app.js", "function render() {\n  return 1;\n}\n")
+    _write(root, "web/plugins/charts/pyproject.toml", '[project]\nname = "acme-charts"\n')
+    _write(root, "web/plugins/charts/plot.py", "def plot():\n    return 1\n")
+    _write(root, "web/node_modules/left-pad/package.json", json.dumps({"name": "left-pad"}))
+
+
+def test_detect_subprojects_reads_package_names(tmp_path: Path) -> None:
+    _monorepo(tmp_path)
+
+    found = detect_subprojects(tmp_path, ignore_patterns=["node_modules"])
+
+    # The root manifest and vendored packages under node_modules are not subprojects.
+    assert found == [
+        Subproject("services/api", "go.mod", "github.com/acme/api"),
+        Subproject("services/auth", "go.mod", "github.com/acme/auth"),
+        Subproject("web", "package.json", "@acme/web"),
+        Subproject("web/plugins/charts", "pyproject.toml", "acme_charts"),
+    ]
+    assert nested_ignore_patterns(found[2], found) == ["plugins/charts/*"]
+    assert nested_ignore_patterns(found[0], found) == []
+
+
+def test_analyze_workspace_documents_each_subproject(tmp_path: Path) -> None:
+    _monorepo(tmp_path)
+
+    workspace = analyze_workspace(tmp_path, enable_tree_sitter=False)
+
+    by_path = {item["path"]: item["analysis"] for item in workspace["subprojects"]}
+    assert sorted(by_path) == ["services/api", "services/auth", "web", "web/plugins/charts"]
+    # The nested Python package is documented on its own, not as part of `web`.
+    assert [f["name"] for f in by_path["web"]["functions"]] == ["render"]
+    assert [f["name"] for f in by_path["web/plugins/charts"]["functions"]] == ["plot"]
+    assert workspace["cross_imports"] == {"services/api": ["services/auth"]}
+
+    metrics = workspace["run_metrics"]
+    assert metrics["scanned_files"] == sum(
+        analysis["run_metrics"]["scanned_files"] for analysis in by_path.values()
+    )
+    assert sorted(metrics["subprojects"]) == sorted(by_path)
+
+    graph = build_impact_graph_data(by_path["services/api"])
+    external = [edge for edge in graph["edges"] if edge.get("external")]
+    assert external == [
+        {
+            "source": "file:main.go",
+            "target": "module:github.com/acme/auth/token",
+            "kind": "import",
+            "external": True,
+            "subproject": "services/auth",
+        }
+    ]
+    assert "-.->|import|" in mermaid_from_graph(graph)
+
+
+def test_workspace_indexes_are_partitioned_per_subproject(tmp_path: Path) -> None:
+    _monorepo(tmp_path)
+    analyze_workspace(tmp_path, enable_tree_sitter=False)
+    warm = analyze_workspace(tmp_path, enable_tree_sitter=False)["run_metrics"]["subprojects"]
+    _write(tmp_path, "web/app.js", "function render() {\n  return 2;\n}\n")
+
+    after = analyze_workspace(tmp_path, enable_tree_sitter=False)["run_metrics"]["subprojects"]
+
+    # Editing `web` re-parses one file there and nothing in the other subprojects.
+    assert {path: after[path]["cache_hits"] - warm[path]["cache_hits"] for path in after} == {
+        "services/api": 0,
+        "services/auth": 0,
+        "web": -1,
+        "web/plugins/charts": 0,
+    }
+    assert (tmp_path / "services/auth/.docgenie/index.json").exists()
+    assert (tmp_path / "web/.docgenie/index.json").exists()
+
+
+def test_workspace_index_links_subproject_readmes(tmp_path: Path) -> None:
+    _monorepo(tmp_path)
+    workspace = analyze_workspace(tmp_path, enable_tree_sitter=False)
+
+    index = render_workspace_index(workspace)
+
+    assert index.startswith(f"# {tmp_path.name}\n\nThis workspace contains 4 subprojects.")
+    assert (
+        "| [services/auth](services/auth/README.md) | `go.mod` | github.com/acme/auth | 1 | 1 | 0 |"
+    ) in index
+    assert "- `services/api` imports `services/auth`" in index